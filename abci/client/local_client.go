@@ -16,6 +16,9 @@ type localClient struct {
 	service.BaseService
 
 	mtx *tmsync.RWMutex
+	// concurrent is whether CheckTx runs concurrently with Info, Query and
+	// other CheckTx calls, see types.ConcurrentApplication.
+	concurrent bool
 	types.Application
 	Callback
 }
@@ -25,6 +28,10 @@ var _ Client = (*localClient)(nil)
 // NewLocalClient creates a local client, which will be directly calling the
 // methods of the given app.
 //
+// Info and Query calls run concurrently with each other, and so do CheckTx
+// calls if the app is a types.ConcurrentApplication declaring itself
+// concurrency-safe. All other calls hold mtx alone.
+//
 // Both Async and Sync methods ignore the given context.Context parameter.
 func NewLocalClient(mtx *tmsync.RWMutex, app types.Application) Client {
 	if mtx == nil {
@@ -33,6 +40,7 @@ func NewLocalClient(mtx *tmsync.RWMutex, app types.Application) Client {
 
 	cli := &localClient{
 		mtx:         mtx,
+		concurrent:  types.IsConcurrencySafe(app),
 		Application: app,
 	}
 
@@ -89,8 +97,7 @@ func (app *localClient) DeliverTxAsync(ctx context.Context, params types.Request
 }
 
 func (app *localClient) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*ReqRes, error) {
-	app.mtx.Lock()
-	defer app.mtx.Unlock()
+	defer app.lockCheckTx()()
 
 	res := app.Application.CheckTx(req)
 	return app.callback(
@@ -238,8 +245,7 @@ func (app *localClient) CheckTxSync(
 	ctx context.Context,
 	req types.RequestCheckTx,
 ) (*types.ResponseCheckTx, error) {
-	app.mtx.Lock()
-	defer app.mtx.Unlock()
+	defer app.lockCheckTx()()
 
 	res := app.Application.CheckTx(req)
 	return &res, nil
@@ -348,8 +354,21 @@ func (app *localClient) ApplySnapshotChunkSync(
 
 //-------------------------------------------------------
 
+// lockCheckTx locks mtx for a CheckTx call, and returns the function unlocking
+// it.
+func (app *localClient) lockCheckTx() func() {
+	if app.concurrent {
+		app.mtx.RLock()
+		return app.mtx.RUnlock
+	}
+	app.mtx.Lock()
+	return app.mtx.Unlock
+}
+
 func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRes {
-	app.Callback(req, res)
+	if app.Callback != nil {
+		app.Callback(req, res)
+	}
 	return newLocalReqRes(req, res)
 }
 
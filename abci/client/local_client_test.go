@@ -0,0 +1,82 @@
+package abcicli_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// blockingApp blocks in CheckTx until it is unblocked, recording how many
+// CheckTx calls ran at once.
+type blockingApp struct {
+	types.BaseApplication
+	concurrent bool
+
+	mtx     sync.Mutex
+	running int
+	maxRun  int
+	unblock chan struct{}
+}
+
+func (app *blockingApp) ConcurrencySafe() bool { return app.concurrent }
+
+func (app *blockingApp) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	app.mtx.Lock()
+	app.running++
+	if app.running > app.maxRun {
+		app.maxRun = app.running
+	}
+	app.mtx.Unlock()
+
+	<-app.unblock
+
+	app.mtx.Lock()
+	app.running--
+	app.mtx.Unlock()
+	return types.ResponseCheckTx{Code: types.CodeTypeOK}
+}
+
+func (app *blockingApp) maxRunning() int {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.maxRun
+}
+
+func TestLocalClientConcurrentCheckTx(t *testing.T) {
+	testCases := []struct {
+		concurrent bool
+		maxRunning int
+	}{
+		{false, 1},
+		{true, 3},
+	}
+	for _, tc := range testCases {
+		app := &blockingApp{concurrent: tc.concurrent, unblock: make(chan struct{})}
+		c := abcicli.NewLocalClient(nil, app)
+		c.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("tx")})
+				assert.NoError(t, err)
+			}()
+		}
+
+		// the calls run at once, or wait for each other until unblocked
+		require.Eventually(t, func() bool { return app.maxRunning() == tc.maxRunning },
+			time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		close(app.unblock)
+		wg.Wait()
+		require.Equal(t, tc.maxRunning, app.maxRunning(), "concurrent=%v", tc.concurrent)
+	}
+}
@@ -24,6 +24,25 @@ const (
 	reqQueueSize = 256
 	// Don't wait longer than...
 	flushThrottleMS = 20
+
+	// defaultMaxInFlightRequests is the default max number of requests sent to
+	// the app which haven't been answered yet.
+	defaultMaxInFlightRequests = 4096
+	// defaultDrainTimeout is how long Stop waits by default for the app to
+	// answer the requests which are in flight.
+	defaultDrainTimeout = time.Second
+)
+
+var (
+	// ErrRequestQueueFull is returned by the async methods when the request
+	// queue is full, e.g. because the app is slower than the caller.
+	ErrRequestQueueFull = errors.New("request queue is full")
+	// ErrClientClosed is returned for requests made while, or abandoned
+	// because, the client is stopping.
+	ErrClientClosed = errors.New("client is closed")
+	// ErrRequestTimeout is the error the client stops with if the app doesn't
+	// answer a request within the request timeout.
+	ErrRequestTimeout = errors.New("request timed out")
 )
 
 type reqResWithContext struct {
@@ -31,6 +50,44 @@ type reqResWithContext struct {
 	C context.Context // if context.Err is not nil, reqRes will be thrown away (ignored)
 }
 
+// sentReq is a request which was sent to the app.
+type sentReq struct {
+	reqRes *ReqRes
+	sentAt time.Time
+}
+
+// SocketClientOption sets an optional parameter on the socket client.
+type SocketClientOption func(*socketClient)
+
+// MaxInFlightRequests sets the max number of requests sent to the app which
+// haven't been answered yet (default: 4096). Once reached, new requests stay
+// in the request queue and, once it is full too, the async methods return
+// ErrRequestQueueFull.
+func MaxInFlightRequests(n int) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.maxInFlight = n
+	}
+}
+
+// RequestTimeout sets how long the app may take to answer a request (default:
+// 0, i.e. no timeout). Since the app must answer requests in order, a
+// request which isn't answered in time stops the client with
+// ErrRequestTimeout.
+func RequestTimeout(d time.Duration) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.requestTimeout = d
+	}
+}
+
+// DrainTimeout sets how long Stop waits for the app to answer the requests
+// which were already queued (default: 1s). Requests which are still pending
+// afterwards are abandoned.
+func DrainTimeout(d time.Duration) SocketClientOption {
+	return func(cli *socketClient) {
+		cli.drainTimeout = d
+	}
+}
+
 // This is goroutine-safe, but users should beware that the application in
 // general is not meant to be interfaced with concurrent callers.
 type socketClient struct {
@@ -40,12 +97,19 @@ type socketClient struct {
 	mustConnect bool
 	conn        net.Conn
 
+	maxInFlight    int
+	requestTimeout time.Duration
+	drainTimeout   time.Duration
+
 	reqQueue   chan *reqResWithContext
 	flushTimer *timer.ThrottleTimer
+	inFlight   chan struct{} // one element per request in reqSent
+	recvDone   chan struct{} // closed once recvResponseRoutine returns
 
 	mtx     tmsync.RWMutex
 	err     error
-	reqSent *list.List                            // list of requests sent, waiting for response
+	closing bool                                  // set once Stop was called
+	reqSent *list.List                            // list of *sentReq, waiting for response
 	resCb   func(*types.Request, *types.Response) // called on all requests, if set.
 }
 
@@ -54,17 +118,28 @@ var _ Client = (*socketClient)(nil)
 // NewSocketClient creates a new socket client, which connects to a given
 // address. If mustConnect is true, the client will return an error upon start
 // if it fails to connect.
-func NewSocketClient(addr string, mustConnect bool) Client {
+func NewSocketClient(addr string, mustConnect bool, options ...SocketClientOption) Client {
 	cli := &socketClient{
 		reqQueue:    make(chan *reqResWithContext, reqQueueSize),
 		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottleMS),
 		mustConnect: mustConnect,
 
+		maxInFlight:  defaultMaxInFlightRequests,
+		drainTimeout: defaultDrainTimeout,
+
 		addr:    addr,
 		reqSent: list.New(),
 		resCb:   nil,
 	}
 	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
+
+	for _, option := range options {
+		option(cli)
+	}
+
+	cli.inFlight = make(chan struct{}, cli.maxInFlight)
+	cli.recvDone = make(chan struct{})
+
 	return cli
 }
 
@@ -91,13 +166,28 @@ func (cli *socketClient) OnStart() error {
 
 		go cli.sendRequestsRoutine(conn)
 		go cli.recvResponseRoutine(conn)
+		if cli.requestTimeout > 0 {
+			go cli.timeoutRoutine()
+		}
 
 		return nil
 	}
 }
 
-// OnStop implements Service by closing connection and flushing all queues.
+// OnStop implements Service by waiting for the app to answer the queued
+// requests, closing the connection and flushing all queues.
+//
+// New requests are rejected with ErrClientClosed as soon as Stop is called.
 func (cli *socketClient) OnStop() {
+	cli.mtx.Lock()
+	cli.closing = true
+	graceful := cli.err == nil && cli.conn != nil
+	cli.mtx.Unlock()
+
+	if graceful {
+		cli.drain()
+	}
+
 	if cli.conn != nil {
 		cli.conn.Close()
 	}
@@ -106,6 +196,38 @@ func (cli *socketClient) OnStop() {
 	cli.flushTimer.Stop()
 }
 
+// drain waits until the app answered all queued requests, the connection broke
+// or the drain timeout passed, whichever comes first.
+func (cli *socketClient) drain() {
+	timeout := time.NewTimer(cli.drainTimeout)
+	defer timeout.Stop()
+
+	// The app answers in order, so all requests are answered once a final
+	// flush is.
+	flush := NewReqRes(types.ToRequestFlush())
+	select {
+	case cli.reqQueue <- &reqResWithContext{R: flush, C: context.Background()}:
+	case <-cli.recvDone:
+		return
+	case <-timeout.C:
+		cli.Logger.Info("Abandoning pending requests", "timeout", cli.drainTimeout)
+		return
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		flush.Wait()
+		close(flushed)
+	}()
+
+	select {
+	case <-flushed:
+	case <-cli.recvDone:
+	case <-timeout.C:
+		cli.Logger.Info("Abandoning pending requests", "timeout", cli.drainTimeout)
+	}
+}
+
 // Error returns an error if the client was stopped abruptly.
 func (cli *socketClient) Error() error {
 	cli.mtx.RLock()
@@ -134,9 +256,28 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 
 			if reqres.C.Err() != nil {
 				cli.Logger.Debug("Request's context is done", "req", reqres.R, "err", reqres.C.Err())
+				// release waiters, the response stays nil
+				reqres.R.Done()
 				continue
 			}
 
+			// Wait for a free slot if too many requests are in flight. The app
+			// won't answer requests it hasn't received, so flush the buffer
+			// first.
+			select {
+			case cli.inFlight <- struct{}{}:
+			default:
+				if err := w.Flush(); err != nil {
+					cli.stopForError(fmt.Errorf("flush buffer: %w", err))
+					return
+				}
+				select {
+				case cli.inFlight <- struct{}{}:
+				case <-cli.Quit():
+					return
+				}
+			}
+
 			cli.willSendReq(reqres.R)
 			err := types.WriteMessage(reqres.R.Request, w)
 			if err != nil {
@@ -165,6 +306,8 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 }
 
 func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
+	defer close(cli.recvDone)
+
 	r := bufio.NewReader(conn)
 	for {
 		var res = &types.Response{}
@@ -191,10 +334,37 @@ func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
 	}
 }
 
+// timeoutRoutine stops the client if the app takes longer than the request
+// timeout to answer the oldest pending request.
+func (cli *socketClient) timeoutRoutine() {
+	ticker := time.NewTicker(cli.requestTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cli.mtx.RLock()
+			var oldest *sentReq
+			if front := cli.reqSent.Front(); front != nil {
+				oldest = front.Value.(*sentReq)
+			}
+			cli.mtx.RUnlock()
+
+			if oldest != nil && time.Since(oldest.sentAt) > cli.requestTimeout {
+				cli.stopForError(fmt.Errorf("%w: no response to %v after %v", ErrRequestTimeout,
+					reflect.TypeOf(oldest.reqRes.Request.Value), cli.requestTimeout))
+				return
+			}
+		case <-cli.Quit():
+			return
+		}
+	}
+}
+
 func (cli *socketClient) willSendReq(reqres *ReqRes) {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
-	cli.reqSent.PushBack(reqres)
+	cli.reqSent.PushBack(&sentReq{reqRes: reqres, sentAt: time.Now()})
 }
 
 func (cli *socketClient) didRecvResponse(res *types.Response) error {
@@ -207,7 +377,7 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 		return fmt.Errorf("unexpected %v when nothing expected", reflect.TypeOf(res.Value))
 	}
 
-	reqres := next.Value.(*ReqRes)
+	reqres := next.Value.(*sentReq).reqRes
 	if !resMatchesReq(reqres.Request, res) {
 		return fmt.Errorf("unexpected %v when response to %v expected",
 			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
@@ -216,6 +386,7 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 	reqres.Response = res
 	reqres.Done()            // release waiters
 	cli.reqSent.Remove(next) // pop first item from linked list
+	<-cli.inFlight           // free a slot for the next request
 
 	// Notify client listener if set (global callback).
 	if cli.resCb != nil {
@@ -316,7 +487,14 @@ func (cli *socketClient) FlushSync(ctx context.Context) error {
 
 	select {
 	case <-gotResp:
-		return cli.Error()
+		if err := cli.Error(); err != nil {
+			return err
+		}
+		if reqRes.Response == nil {
+			// abandoned while stopping
+			return ErrClientClosed
+		}
+		return nil
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -468,7 +646,8 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 //----------------------------------------
 
 // queueRequest enqueues req onto the queue. If the queue is full, it ether
-// returns an error (sync=false) or blocks (sync=true).
+// returns ErrRequestQueueFull (sync=false) or blocks (sync=true). It returns
+// ErrClientClosed once the client is stopping.
 //
 // When sync=true, ctx can be used to break early. When sync=false, ctx will be
 // used later to determine if request should be dropped (if ctx.Err is
@@ -476,6 +655,13 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 //
 // The caller is responsible for checking cli.Error.
 func (cli *socketClient) queueRequest(ctx context.Context, req *types.Request, sync bool) (*ReqRes, error) {
+	cli.mtx.RLock()
+	closing := cli.closing
+	cli.mtx.RUnlock()
+	if closing {
+		return nil, ErrClientClosed
+	}
+
 	reqres := NewReqRes(req)
 
 	if sync {
@@ -488,7 +674,7 @@ func (cli *socketClient) queueRequest(ctx context.Context, req *types.Request, s
 		select {
 		case cli.reqQueue <- &reqResWithContext{R: reqres, C: ctx}:
 		default:
-			return nil, errors.New("buffer is full")
+			return nil, ErrRequestQueueFull
 		}
 	}
 
@@ -543,9 +729,9 @@ func (cli *socketClient) flushQueue() {
 
 	// mark all in-flight messages as resolved (they will get cli.Error())
 	for req := cli.reqSent.Front(); req != nil; req = req.Next() {
-		reqres := req.Value.(*ReqRes)
-		reqres.Done()
+		req.Value.(*sentReq).reqRes.Done()
 	}
+	cli.reqSent.Init()
 
 	// mark all queued messages as resolved
 LOOP:
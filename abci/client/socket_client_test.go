@@ -2,6 +2,7 @@ package abcicli_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -99,7 +100,100 @@ func TestHangingSyncCalls(t *testing.T) {
 	}
 }
 
-func setupClientServer(t *testing.T, app types.Application) (
+func TestRequestQueueFull(t *testing.T) {
+	s, c := setupClientServer(t, slowApp{}, abcicli.MaxInFlightRequests(1))
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	// the app answers only one request at a time, so the queue fills up
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		_, err = c.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+	}
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, abcicli.ErrRequestQueueFull), err)
+	assert.NoError(t, c.Error())
+}
+
+func TestRequestTimeout(t *testing.T) {
+	s, c := setupClientServer(t, slowApp{}, abcicli.RequestTimeout(50*time.Millisecond))
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	reqres, err := c.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+	require.NoError(t, err)
+	_, err = c.FlushAsync(ctx)
+	require.NoError(t, err)
+
+	select {
+	case <-c.Quit():
+	case <-time.After(time.Second):
+		require.Fail(t, "client didn't stop")
+	}
+	assert.True(t, errors.Is(c.Error(), abcicli.ErrRequestTimeout), c.Error())
+
+	// waiters are released
+	reqres.Wait()
+	assert.Nil(t, reqres.Response)
+}
+
+func TestGracefulStop(t *testing.T) {
+	s, c := setupClientServer(t, slowApp{})
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	reqres, err := c.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+	require.NoError(t, err)
+
+	// Stop waits for the pending request to be answered
+	require.NoError(t, c.Stop())
+	reqres.Wait()
+	assert.NotNil(t, reqres.Response.GetBeginBlock())
+	assert.NoError(t, c.Error())
+
+	_, err = c.EchoAsync(ctx, "hello")
+	assert.True(t, errors.Is(err, abcicli.ErrClientClosed), err)
+}
+
+func TestStopAbandonsPendingRequests(t *testing.T) {
+	s, c := setupClientServer(t, slowApp{}, abcicli.DrainTimeout(50*time.Millisecond))
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	resp := make(chan error, 1)
+	go func() {
+		_, err := c.BeginBlockSync(ctx, types.RequestBeginBlock{})
+		resp <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	require.NoError(t, c.Stop())
+	select {
+	case err := <-resp:
+		assert.True(t, errors.Is(err, abcicli.ErrClientClosed), err)
+	case <-time.After(time.Second):
+		require.Fail(t, "sync call didn't return")
+	}
+}
+
+func setupClientServer(t *testing.T, app types.Application, options ...abcicli.SocketClientOption) (
 	service.Service, abcicli.Client) {
 	// some port between 20k and 30k
 	port := 20000 + rand.Int31()%10000
@@ -110,7 +204,7 @@ func setupClientServer(t *testing.T, app types.Application) (
 	err = s.Start()
 	require.NoError(t, err)
 
-	c := abcicli.NewSocketClient(addr, true)
+	c := abcicli.NewSocketClient(addr, true, options...)
 	err = c.Start()
 	require.NoError(t, err)
 
@@ -61,7 +61,7 @@ func prefixKey(key []byte) []byte {
 
 //---------------------------------------------------
 
-var _ types.Application = (*Application)(nil)
+var _ types.ConcurrentApplication = (*Application)(nil)
 
 type Application struct {
 	types.BaseApplication
@@ -121,6 +121,12 @@ func (app *Application) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 	return types.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
 }
 
+// ConcurrencySafe returns true: CheckTx is stateless, and Info and Query only
+// read the state.
+func (app *Application) ConcurrencySafe() bool {
+	return true
+}
+
 func (app *Application) Commit() types.ResponseCommit {
 	// Using a memdb - just return the big endian size of the db
 	appHash := make([]byte, 8)
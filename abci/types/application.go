@@ -30,6 +30,34 @@ type Application interface {
 	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a shapshot chunk
 }
 
+// ConcurrentApplication is implemented by applications which may declare
+// themselves safe for concurrent Info, Query and CheckTx calls. In-process
+// clients serialize CheckTx with all other calls unless ConcurrencySafe returns
+// true, in which case it runs concurrently with Info, Query and other CheckTx
+// calls. Calls of the consensus and state sync connections always run alone.
+type ConcurrentApplication interface {
+	Application
+
+	ConcurrencySafe() bool // Whether Info, Query and CheckTx may run concurrently
+}
+
+// IsConcurrencySafe returns whether the app declares itself safe for
+// concurrent Info, Query and CheckTx calls.
+func IsConcurrencySafe(app Application) bool {
+	concurrent, ok := app.(ConcurrentApplication)
+	return ok && concurrent.ConcurrencySafe()
+}
+
+// TxSanityChecker is implemented by applications which can reject obviously
+// malformed txs with a cheap, stateless check, e.g. decoding the tx and
+// bounding its number of fields. For in-process apps, the mempool runs
+// SanityCheckTx before CheckTx, so that garbage txs gossiped by peers are
+// dropped without an ABCI call. It must not depend on the state of the app,
+// and may be called concurrently with any other call.
+type TxSanityChecker interface {
+	SanityCheckTx(tx []byte) error // Returns an error if the tx is malformed
+}
+
 //-------------------------------------------------------
 // BaseApplication is a base form of Application
 
@@ -29,6 +29,8 @@ func TestMarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		Sequence:  2,
+		DependsOn: [][]byte{[]byte("world")},
 	}
 	b, err = json.Marshal(&r1)
 	assert.Nil(t, err)
@@ -10,6 +10,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
 	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	grpc "google.golang.org/grpc"
@@ -58,6 +59,38 @@ func (CheckTxType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_252557cfdd89a31a, []int{0}
 }
 
+// EventAttributeType is the type of the values of an event attribute.
+type EventAttributeType int32
+
+const (
+	EventAttributeType_STRING EventAttributeType = 0
+	EventAttributeType_INT    EventAttributeType = 1
+	EventAttributeType_FLOAT  EventAttributeType = 2
+	EventAttributeType_BOOL   EventAttributeType = 3
+)
+
+var EventAttributeType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+	2: "FLOAT",
+	3: "BOOL",
+}
+
+var EventAttributeType_value = map[string]int32{
+	"STRING": 0,
+	"INT":    1,
+	"FLOAT":  2,
+	"BOOL":   3,
+}
+
+func (x EventAttributeType) String() string {
+	return proto.EnumName(EventAttributeType_name, int32(x))
+}
+
+func (EventAttributeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{1}
+}
+
 type EvidenceType int32
 
 const (
@@ -83,7 +116,7 @@ func (x EvidenceType) String() string {
 }
 
 func (EvidenceType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{1}
+	return fileDescriptor_252557cfdd89a31a, []int{2}
 }
 
 type ResponseOfferSnapshot_Result int32
@@ -556,6 +589,8 @@ type RequestInitChain struct {
 	Validators      []ValidatorUpdate       `protobuf:"bytes,4,rep,name=validators,proto3" json:"validators"`
 	AppStateBytes   []byte                  `protobuf:"bytes,5,opt,name=app_state_bytes,json=appStateBytes,proto3" json:"app_state_bytes,omitempty"`
 	InitialHeight   int64                   `protobuf:"varint,6,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	AppStateOffset  uint64                  `protobuf:"varint,7,opt,name=app_state_offset,json=appStateOffset,proto3" json:"app_state_offset,omitempty"`
+	AppStateSize    uint64                  `protobuf:"varint,8,opt,name=app_state_size,json=appStateSize,proto3" json:"app_state_size,omitempty"`
 }
 
 func (m *RequestInitChain) Reset()         { *m = RequestInitChain{} }
@@ -633,6 +668,20 @@ func (m *RequestInitChain) GetInitialHeight() int64 {
 	return 0
 }
 
+func (m *RequestInitChain) GetAppStateOffset() uint64 {
+	if m != nil {
+		return m.AppStateOffset
+	}
+	return 0
+}
+
+func (m *RequestInitChain) GetAppStateSize() uint64 {
+	if m != nil {
+		return m.AppStateSize
+	}
+	return 0
+}
+
 type RequestQuery struct {
 	Data   []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -706,6 +755,7 @@ type RequestBeginBlock struct {
 	Header              types1.Header  `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
 	LastCommitInfo      LastCommitInfo `protobuf:"bytes,3,opt,name=last_commit_info,json=lastCommitInfo,proto3" json:"last_commit_info"`
 	ByzantineValidators []Evidence     `protobuf:"bytes,4,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators"`
+	Randomness          []byte         `protobuf:"bytes,5,opt,name=randomness,proto3" json:"randomness,omitempty"`
 }
 
 func (m *RequestBeginBlock) Reset()         { *m = RequestBeginBlock{} }
@@ -769,6 +819,13 @@ func (m *RequestBeginBlock) GetByzantineValidators() []Evidence {
 	return nil
 }
 
+func (m *RequestBeginBlock) GetRandomness() []byte {
+	if m != nil {
+		return m.Randomness
+	}
+	return nil
+}
+
 type RequestCheckTx struct {
 	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
@@ -1037,9 +1094,12 @@ func (m *RequestOfferSnapshot) GetAppHash() []byte {
 
 // loads a snapshot chunk
 type RequestLoadSnapshotChunk struct {
-	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Chunk  uint32 `protobuf:"varint,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Height     uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format     uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunk      uint32 `protobuf:"varint,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Offset     uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length     uint32 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	BaseHeight uint64 `protobuf:"varint,6,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *RequestLoadSnapshotChunk) Reset()         { *m = RequestLoadSnapshotChunk{} }
@@ -1096,11 +1156,34 @@ func (m *RequestLoadSnapshotChunk) GetChunk() uint32 {
 	return 0
 }
 
+func (m *RequestLoadSnapshotChunk) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *RequestLoadSnapshotChunk) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *RequestLoadSnapshotChunk) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
 // Applies a snapshot chunk
 type RequestApplySnapshotChunk struct {
-	Index  uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Chunk  []byte `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
-	Sender string `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	Index     uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk     []byte `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Sender    string `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	Offset    uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	ChunkSize uint64 `protobuf:"varint,5,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
 }
 
 func (m *RequestApplySnapshotChunk) Reset()         { *m = RequestApplySnapshotChunk{} }
@@ -1157,6 +1240,20 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 	return ""
 }
 
+func (m *RequestApplySnapshotChunk) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *RequestApplySnapshotChunk) GetChunkSize() uint64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1543,6 +1640,12 @@ type ResponseInfo struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// whether the application can answer queries at past heights
+	HistoricalQueries bool `protobuf:"varint,6,opt,name=historical_queries,json=historicalQueries,proto3" json:"historical_queries,omitempty"`
+	// types of the attributes of the events emitted by the application
+	EventSchema []EventAttributeSchema `protobuf:"bytes,7,rep,name=event_schema,json=eventSchema,proto3" json:"event_schema"`
+	// features of the application, unknown if not set
+	Capabilities *Capabilities `protobuf:"bytes,8,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
@@ -1613,10 +1716,33 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetHistoricalQueries() bool {
+	if m != nil {
+		return m.HistoricalQueries
+	}
+	return false
+}
+
+func (m *ResponseInfo) GetEventSchema() []EventAttributeSchema {
+	if m != nil {
+		return m.EventSchema
+	}
+	return nil
+}
+
+func (m *ResponseInfo) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type ResponseInitChain struct {
 	ConsensusParams *types1.ConsensusParams `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
 	Validators      []ValidatorUpdate       `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
 	AppHash         []byte                  `protobuf:"bytes,3,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// types of the attributes of the events emitted by the application
+	EventSchema []EventAttributeSchema `protobuf:"bytes,4,rep,name=event_schema,json=eventSchema,proto3" json:"event_schema"`
 }
 
 func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
@@ -1673,6 +1799,13 @@ func (m *ResponseInitChain) GetAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInitChain) GetEventSchema() []EventAttributeSchema {
+	if m != nil {
+		return m.EventSchema
+	}
+	return nil
+}
+
 type ResponseQuery struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	// bytes data = 2; // use "value" instead.
@@ -1827,14 +1960,21 @@ func (m *ResponseBeginBlock) GetEvents() []Event {
 }
 
 type ResponseCheckTx struct {
-	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Log       string  `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info      string  `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	GasWanted int64   `protobuf:"varint,5,opt,name=gas_wanted,proto3" json:"gas_wanted,omitempty"`
-	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
-	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
-	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	Code         uint32     `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Data         []byte     `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log          string     `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info         string     `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	GasWanted    int64      `protobuf:"varint,5,opt,name=gas_wanted,proto3" json:"gas_wanted,omitempty"`
+	GasUsed      int64      `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
+	Events       []Event    `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
+	Codespace    string     `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	Sender       string     `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
+	Priority     int64      `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	Sequence     uint64     `protobuf:"varint,11,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	DependsOn    [][]byte   `protobuf:"bytes,12,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	TxClass      string     `protobuf:"bytes,13,opt,name=tx_class,json=txClass,proto3" json:"tx_class,omitempty"`
+	ExpireHeight int64      `protobuf:"varint,14,opt,name=expire_height,json=expireHeight,proto3" json:"expire_height,omitempty"`
+	ExpireTime   *time.Time `protobuf:"bytes,15,opt,name=expire_time,json=expireTime,proto3,stdtime" json:"expire_time,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -1926,6 +2066,55 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetDependsOn() [][]byte {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *ResponseCheckTx) GetTxClass() string {
+	if m != nil {
+		return m.TxClass
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetExpireHeight() int64 {
+	if m != nil {
+		return m.ExpireHeight
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetExpireTime() *time.Time {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return nil
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2027,9 +2216,17 @@ func (m *ResponseDeliverTx) GetCodespace() string {
 }
 
 type ResponseEndBlock struct {
-	ValidatorUpdates      []ValidatorUpdate       `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
-	ConsensusParamUpdates *types1.ConsensusParams `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
-	Events                []Event                 `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	ValidatorUpdates               []ValidatorUpdate          `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates          *types1.ConsensusParams    `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+	Events                         []Event                    `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	ScheduledConsensusParamUpdates []ScheduledConsensusParams `protobuf:"bytes,4,rep,name=scheduled_consensus_param_updates,json=scheduledConsensusParamUpdates,proto3" json:"scheduled_consensus_param_updates"`
+	// Addresses of validators of the next height which the application jailed
+	// or tombstoned. Nodes ignore their votes at the next height, the last one
+	// before the validator updates removing them come into effect, so jailing
+	// a validator must go along with a validator update removing it. The
+	// response is rejected if the jailed validators have 1/3 or more of the
+	// voting power of the next height.
+	JailedValidators [][]byte `protobuf:"bytes,5,rep,name=jailed_validators,json=jailedValidators,proto3" json:"jailed_validators,omitempty"`
 }
 
 func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
@@ -2086,6 +2283,20 @@ func (m *ResponseEndBlock) GetEvents() []Event {
 	return nil
 }
 
+func (m *ResponseEndBlock) GetScheduledConsensusParamUpdates() []ScheduledConsensusParams {
+	if m != nil {
+		return m.ScheduledConsensusParamUpdates
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetJailedValidators() [][]byte {
+	if m != nil {
+		return m.JailedValidators
+	}
+	return nil
+}
+
 type ResponseCommit struct {
 	// reserve 1
 	Data         []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2184,7 +2395,8 @@ func (m *ResponseListSnapshots) GetSnapshots() []*Snapshot {
 }
 
 type ResponseOfferSnapshot struct {
-	Result ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseOfferSnapshot_Result" json:"result,omitempty"`
+	Result       ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseOfferSnapshot_Result" json:"result,omitempty"`
+	StreamChunks bool                         `protobuf:"varint,2,opt,name=stream_chunks,json=streamChunks,proto3" json:"stream_chunks,omitempty"`
 }
 
 func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
@@ -2227,8 +2439,16 @@ func (m *ResponseOfferSnapshot) GetResult() ResponseOfferSnapshot_Result {
 	return ResponseOfferSnapshot_UNKNOWN
 }
 
+func (m *ResponseOfferSnapshot) GetStreamChunks() bool {
+	if m != nil {
+		return m.StreamChunks
+	}
+	return false
+}
+
 type ResponseLoadSnapshotChunk struct {
-	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Chunk     []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	ChunkSize uint64 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
 }
 
 func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotChunk{} }
@@ -2271,6 +2491,13 @@ func (m *ResponseLoadSnapshotChunk) GetChunk() []byte {
 	return nil
 }
 
+func (m *ResponseLoadSnapshotChunk) GetChunkSize() uint64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
 type ResponseApplySnapshotChunk struct {
 	Result        ResponseApplySnapshotChunk_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseApplySnapshotChunk_Result" json:"result,omitempty"`
 	RefetchChunks []uint32                          `protobuf:"varint,2,rep,packed,name=refetch_chunks,json=refetchChunks,proto3" json:"refetch_chunks,omitempty"`
@@ -2499,6 +2726,67 @@ func (m *EventAttribute) GetIndex() bool {
 	return false
 }
 
+// EventAttributeSchema declares the type of the values of an event attribute.
+type EventAttributeSchema struct {
+	EventType string             `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Key       string             `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Type      EventAttributeType `protobuf:"varint,3,opt,name=type,proto3,enum=tendermint.abci.EventAttributeType" json:"type,omitempty"`
+}
+
+func (m *EventAttributeSchema) Reset()         { *m = EventAttributeSchema{} }
+func (m *EventAttributeSchema) String() string { return proto.CompactTextString(m) }
+func (*EventAttributeSchema) ProtoMessage()    {}
+func (*EventAttributeSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{34}
+}
+func (m *EventAttributeSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventAttributeSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventAttributeSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventAttributeSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventAttributeSchema.Merge(m, src)
+}
+func (m *EventAttributeSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventAttributeSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventAttributeSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventAttributeSchema proto.InternalMessageInfo
+
+func (m *EventAttributeSchema) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *EventAttributeSchema) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EventAttributeSchema) GetType() EventAttributeType {
+	if m != nil {
+		return m.Type
+	}
+	return EventAttributeType_STRING
+}
+
 // TxResult contains results of executing the transaction.
 //
 // One usage is indexing transaction results.
@@ -2507,13 +2795,16 @@ type TxResult struct {
 	Index  uint32            `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 	Tx     []byte            `protobuf:"bytes,3,opt,name=tx,proto3" json:"tx,omitempty"`
 	Result ResponseDeliverTx `protobuf:"bytes,4,opt,name=result,proto3" json:"result"`
+	// time from the tx being added to the mempool of the node to the tx being
+	// committed, or 0 if the tx was not in the mempool
+	Latency time.Duration `protobuf:"bytes,5,opt,name=latency,proto3,stdduration" json:"latency"`
 }
 
 func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2570,6 +2861,13 @@ func (m *TxResult) GetResult() ResponseDeliverTx {
 	return ResponseDeliverTx{}
 }
 
+func (m *TxResult) GetLatency() time.Duration {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
 // Validator
 type Validator struct {
 	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -2581,7 +2879,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2634,7 +2932,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2687,7 +2985,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2748,7 +3046,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2812,19 +3110,75 @@ func (m *Evidence) GetTotalVotingPower() int64 {
 	return 0
 }
 
+// ScheduledConsensusParams is an update of the consensus params which comes
+// into effect at the activation height.
+type ScheduledConsensusParams struct {
+	Params           *types1.ConsensusParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	ActivationHeight int64                   `protobuf:"varint,2,opt,name=activation_height,json=activationHeight,proto3" json:"activation_height,omitempty"`
+}
+
+func (m *ScheduledConsensusParams) Reset()         { *m = ScheduledConsensusParams{} }
+func (m *ScheduledConsensusParams) String() string { return proto.CompactTextString(m) }
+func (*ScheduledConsensusParams) ProtoMessage()    {}
+func (*ScheduledConsensusParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{40}
+}
+func (m *ScheduledConsensusParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduledConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduledConsensusParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduledConsensusParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledConsensusParams.Merge(m, src)
+}
+func (m *ScheduledConsensusParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduledConsensusParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledConsensusParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduledConsensusParams proto.InternalMessageInfo
+
+func (m *ScheduledConsensusParams) GetParams() *types1.ConsensusParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *ScheduledConsensusParams) GetActivationHeight() int64 {
+	if m != nil {
+		return m.ActivationHeight
+	}
+	return 0
+}
+
 type Snapshot struct {
-	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
-	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
-	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Height      uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format      uint32   `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks      uint32   `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash        []byte   `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata    []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ChunkHashes [][]byte `protobuf:"bytes,6,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	BaseHeight  uint64   `protobuf:"varint,7,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2888,38 +3242,123 @@ func (m *Snapshot) GetMetadata() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
-	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
-	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
-	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
-	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
-	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
-	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
-	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
-	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
-	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
-	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
-	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
-	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
-	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
-	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
-	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
-	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
-	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
-	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
-	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
-	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
-	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
-	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
-	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
-	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
-	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
-	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
-	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
-	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
-	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
-	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
+func (m *Snapshot) GetChunkHashes() [][]byte {
+	if m != nil {
+		return m.ChunkHashes
+	}
+	return nil
+}
+
+func (m *Snapshot) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+// Capabilities are the features the application advertises in Info, which the
+// node adapts its behavior to.
+type Capabilities struct {
+	Snapshots          bool  `protobuf:"varint,1,opt,name=snapshots,proto3" json:"snapshots,omitempty"`
+	QueryProofs        bool  `protobuf:"varint,2,opt,name=query_proofs,json=queryProofs,proto3" json:"query_proofs,omitempty"`
+	PrioritizedMempool bool  `protobuf:"varint,3,opt,name=prioritized_mempool,json=prioritizedMempool,proto3" json:"prioritized_mempool,omitempty"`
+	MaxTxBytes         int64 `protobuf:"varint,4,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+}
+
+func (m *Capabilities) Reset()         { *m = Capabilities{} }
+func (m *Capabilities) String() string { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()    {}
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{42}
+}
+func (m *Capabilities) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Capabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Capabilities.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Capabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Capabilities.Merge(m, src)
+}
+func (m *Capabilities) XXX_Size() int {
+	return m.Size()
+}
+func (m *Capabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_Capabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Capabilities proto.InternalMessageInfo
+
+func (m *Capabilities) GetSnapshots() bool {
+	if m != nil {
+		return m.Snapshots
+	}
+	return false
+}
+
+func (m *Capabilities) GetQueryProofs() bool {
+	if m != nil {
+		return m.QueryProofs
+	}
+	return false
+}
+
+func (m *Capabilities) GetPrioritizedMempool() bool {
+	if m != nil {
+		return m.PrioritizedMempool
+	}
+	return false
+}
+
+func (m *Capabilities) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("tendermint.abci.EventAttributeType", EventAttributeType_name, EventAttributeType_value)
+	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
+	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
+	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
+	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
+	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
+	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
+	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
+	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
+	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
+	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
+	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
+	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
+	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
+	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
+	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
+	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
+	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
+	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
+	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
+	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
+	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
+	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
+	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
+	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
+	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
+	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
+	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
 	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
 	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
@@ -2927,180 +3366,221 @@ func init() {
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
 	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
+	proto.RegisterType((*EventAttributeSchema)(nil), "tendermint.abci.EventAttributeSchema")
 	proto.RegisterType((*TxResult)(nil), "tendermint.abci.TxResult")
 	proto.RegisterType((*Validator)(nil), "tendermint.abci.Validator")
 	proto.RegisterType((*ValidatorUpdate)(nil), "tendermint.abci.ValidatorUpdate")
 	proto.RegisterType((*VoteInfo)(nil), "tendermint.abci.VoteInfo")
 	proto.RegisterType((*Evidence)(nil), "tendermint.abci.Evidence")
+	proto.RegisterType((*ScheduledConsensusParams)(nil), "tendermint.abci.ScheduledConsensusParams")
 	proto.RegisterType((*Snapshot)(nil), "tendermint.abci.Snapshot")
+	proto.RegisterType((*Capabilities)(nil), "tendermint.abci.Capabilities")
 }
 
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2585 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0x1b, 0xd8, 0x26, 0x01, 0x82, 0x23, 0x5a, 0x86, 0x60, 0x89, 0x94, 0x57, 0x25, 0xc7,
-	0x92, 0x6d, 0x32, 0xa6, 0x4a, 0x8a, 0x5c, 0xce, 0xc3, 0x04, 0x04, 0x05, 0xb4, 0x18, 0x92, 0x19,
-	0x42, 0x72, 0x39, 0x89, 0xb5, 0x5e, 0x60, 0x87, 0xc0, 0x5a, 0xc0, 0xee, 0x1a, 0x3b, 0xa0, 0x48,
-	0x1f, 0xf3, 0xb8, 0xa8, 0x72, 0xd0, 0x31, 0x17, 0x57, 0xe5, 0x1f, 0xe4, 0x9a, 0x53, 0x4e, 0x39,
-	0xf8, 0x90, 0x54, 0xf9, 0x98, 0x93, 0x93, 0x92, 0x6e, 0xf9, 0x03, 0x39, 0xa5, 0x2a, 0x35, 0x8f,
-	0x7d, 0x01, 0x58, 0x00, 0x8c, 0x73, 0xcb, 0x6d, 0xa6, 0xb7, 0xbb, 0x31, 0xd3, 0x33, 0xdd, 0xfd,
-	0x75, 0x0f, 0xe0, 0x35, 0x4a, 0x2c, 0x83, 0x0c, 0x07, 0xa6, 0x45, 0xb7, 0xf4, 0x76, 0xc7, 0xdc,
-	0xa2, 0x67, 0x0e, 0x71, 0x37, 0x9d, 0xa1, 0x4d, 0x6d, 0xb4, 0x12, 0x7c, 0xdc, 0x64, 0x1f, 0xab,
-	0x57, 0x42, 0xdc, 0x9d, 0xe1, 0x99, 0x43, 0xed, 0x2d, 0x67, 0x68, 0xdb, 0xc7, 0x82, 0xbf, 0x7a,
-	0x39, 0xf4, 0x99, 0xeb, 0x09, 0x6b, 0x8b, 0x7c, 0x95, 0xc2, 0x4f, 0xc8, 0x99, 0xf7, 0xf5, 0xca,
-	0x84, 0xac, 0xa3, 0x0f, 0xf5, 0x81, 0xf7, 0x79, 0xa3, 0x6b, 0xdb, 0xdd, 0x3e, 0xd9, 0xe2, 0xb3,
-	0xf6, 0xe8, 0x78, 0x8b, 0x9a, 0x03, 0xe2, 0x52, 0x7d, 0xe0, 0x48, 0x86, 0xb5, 0xae, 0xdd, 0xb5,
-	0xf9, 0x70, 0x8b, 0x8d, 0x04, 0x55, 0xfd, 0x6b, 0x1e, 0xf2, 0x98, 0x7c, 0x3e, 0x22, 0x2e, 0x45,
-	0xdb, 0x90, 0x21, 0x9d, 0x9e, 0x5d, 0x49, 0x5e, 0x4d, 0xbe, 0xb9, 0xb4, 0x7d, 0x79, 0x73, 0x6c,
-	0x73, 0x9b, 0x92, 0xaf, 0xd1, 0xe9, 0xd9, 0xcd, 0x04, 0xe6, 0xbc, 0xe8, 0x36, 0x64, 0x8f, 0xfb,
-	0x23, 0xb7, 0x57, 0x49, 0x71, 0xa1, 0x2b, 0x71, 0x42, 0xf7, 0x19, 0x53, 0x33, 0x81, 0x05, 0x37,
-	0xfb, 0x29, 0xd3, 0x3a, 0xb6, 0x2b, 0xe9, 0xd9, 0x3f, 0xb5, 0x6b, 0x1d, 0xf3, 0x9f, 0x62, 0xbc,
-	0xa8, 0x06, 0x60, 0x5a, 0x26, 0xd5, 0x3a, 0x3d, 0xdd, 0xb4, 0x2a, 0x19, 0x2e, 0xf9, 0x7a, 0xbc,
-	0xa4, 0x49, 0xeb, 0x8c, 0xb1, 0x99, 0xc0, 0x8a, 0xe9, 0x4d, 0xd8, 0x72, 0x3f, 0x1f, 0x91, 0xe1,
-	0x59, 0x25, 0x3b, 0x7b, 0xb9, 0x3f, 0x65, 0x4c, 0x6c, 0xb9, 0x9c, 0x1b, 0x35, 0x60, 0xa9, 0x4d,
-	0xba, 0xa6, 0xa5, 0xb5, 0xfb, 0x76, 0xe7, 0x49, 0x25, 0xc7, 0x85, 0xd5, 0x38, 0xe1, 0x1a, 0x63,
-	0xad, 0x31, 0xce, 0x66, 0x02, 0x43, 0xdb, 0x9f, 0xa1, 0xef, 0x43, 0xa1, 0xd3, 0x23, 0x9d, 0x27,
-	0x1a, 0x3d, 0xad, 0xe4, 0xb9, 0x8e, 0x8d, 0x38, 0x1d, 0x75, 0xc6, 0xd7, 0x3a, 0x6d, 0x26, 0x70,
-	0xbe, 0x23, 0x86, 0x6c, 0xff, 0x06, 0xe9, 0x9b, 0x27, 0x64, 0xc8, 0xe4, 0x0b, 0xb3, 0xf7, 0x7f,
-	0x4f, 0x70, 0x72, 0x0d, 0x8a, 0xe1, 0x4d, 0xd0, 0x8f, 0x40, 0x21, 0x96, 0x21, 0xb7, 0xa1, 0x70,
-	0x15, 0x57, 0x63, 0xcf, 0xd9, 0x32, 0xbc, 0x4d, 0x14, 0x88, 0x1c, 0xa3, 0xbb, 0x90, 0xeb, 0xd8,
-	0x83, 0x81, 0x49, 0x2b, 0xc0, 0xa5, 0xd7, 0x63, 0x37, 0xc0, 0xb9, 0x9a, 0x09, 0x2c, 0xf9, 0xd1,
-	0x3e, 0x94, 0xfa, 0xa6, 0x4b, 0x35, 0xd7, 0xd2, 0x1d, 0xb7, 0x67, 0x53, 0xb7, 0xb2, 0xc4, 0x35,
-	0x5c, 0x8f, 0xd3, 0xb0, 0x67, 0xba, 0xf4, 0xc8, 0x63, 0x6e, 0x26, 0x70, 0xb1, 0x1f, 0x26, 0x30,
-	0x7d, 0xf6, 0xf1, 0x31, 0x19, 0xfa, 0x0a, 0x2b, 0xcb, 0xb3, 0xf5, 0x1d, 0x30, 0x6e, 0x4f, 0x9e,
-	0xe9, 0xb3, 0xc3, 0x04, 0xf4, 0x73, 0xb8, 0xd0, 0xb7, 0x75, 0xc3, 0x57, 0xa7, 0x75, 0x7a, 0x23,
-	0xeb, 0x49, 0xa5, 0xc8, 0x95, 0xde, 0x88, 0x5d, 0xa4, 0xad, 0x1b, 0x9e, 0x8a, 0x3a, 0x13, 0x68,
-	0x26, 0xf0, 0x6a, 0x7f, 0x9c, 0x88, 0x1e, 0xc3, 0x9a, 0xee, 0x38, 0xfd, 0xb3, 0x71, 0xed, 0x25,
-	0xae, 0xfd, 0x66, 0x9c, 0xf6, 0x1d, 0x26, 0x33, 0xae, 0x1e, 0xe9, 0x13, 0xd4, 0x5a, 0x1e, 0xb2,
-	0x27, 0x7a, 0x7f, 0x44, 0xd4, 0xef, 0xc0, 0x52, 0xc8, 0x4d, 0x51, 0x05, 0xf2, 0x03, 0xe2, 0xba,
-	0x7a, 0x97, 0x70, 0xaf, 0x56, 0xb0, 0x37, 0x55, 0x4b, 0xb0, 0x1c, 0x76, 0x4d, 0xf5, 0x79, 0xd2,
-	0x97, 0x64, 0x5e, 0xc7, 0x24, 0x4f, 0xc8, 0xd0, 0x35, 0x6d, 0xcb, 0x93, 0x94, 0x53, 0x74, 0x0d,
-	0x8a, 0xfc, 0xfe, 0x68, 0xde, 0x77, 0xe6, 0xfa, 0x19, 0xbc, 0xcc, 0x89, 0x8f, 0x24, 0xd3, 0x06,
-	0x2c, 0x39, 0xdb, 0x8e, 0xcf, 0x92, 0xe6, 0x2c, 0xe0, 0x6c, 0x3b, 0x1e, 0xc3, 0xeb, 0xb0, 0xcc,
-	0x76, 0xea, 0x73, 0x64, 0xf8, 0x8f, 0x2c, 0x31, 0x9a, 0x64, 0x51, 0xff, 0x92, 0x82, 0xf2, 0xb8,
-	0x3b, 0xa3, 0xbb, 0x90, 0x61, 0x91, 0x4d, 0x06, 0xa9, 0xea, 0xa6, 0x08, 0x7b, 0x9b, 0x5e, 0xd8,
-	0xdb, 0x6c, 0x79, 0x61, 0xaf, 0x56, 0xf8, 0xea, 0x9b, 0x8d, 0xc4, 0xf3, 0xbf, 0x6f, 0x24, 0x31,
-	0x97, 0x40, 0x97, 0x98, 0xf7, 0xe9, 0xa6, 0xa5, 0x99, 0x06, 0x5f, 0xb2, 0xc2, 0x5c, 0x4b, 0x37,
-	0xad, 0x5d, 0x03, 0xed, 0x41, 0xb9, 0x63, 0x5b, 0x2e, 0xb1, 0xdc, 0x91, 0xab, 0x89, 0xb0, 0x2a,
-	0x43, 0x53, 0xc4, 0xc1, 0x44, 0xb0, 0xae, 0x7b, 0x9c, 0x87, 0x9c, 0x11, 0xaf, 0x74, 0xa2, 0x04,
-	0x74, 0x1f, 0xe0, 0x44, 0xef, 0x9b, 0x86, 0x4e, 0xed, 0xa1, 0x5b, 0xc9, 0x5c, 0x4d, 0x4f, 0xf5,
-	0xb2, 0x47, 0x1e, 0xcb, 0x43, 0xc7, 0xd0, 0x29, 0xa9, 0x65, 0xd8, 0x72, 0x71, 0x48, 0x12, 0xbd,
-	0x01, 0x2b, 0xba, 0xe3, 0x68, 0x2e, 0xd5, 0x29, 0xd1, 0xda, 0x67, 0x94, 0xb8, 0x3c, 0x6c, 0x2d,
-	0xe3, 0xa2, 0xee, 0x38, 0x47, 0x8c, 0x5a, 0x63, 0x44, 0x74, 0x1d, 0x4a, 0x2c, 0xc2, 0x99, 0x7a,
-	0x5f, 0xeb, 0x11, 0xb3, 0xdb, 0xa3, 0x3c, 0x40, 0xa5, 0x71, 0x51, 0x52, 0x9b, 0x9c, 0xa8, 0x1a,
-	0xfe, 0x89, 0xf3, 0xe8, 0x86, 0x10, 0x64, 0x0c, 0x9d, 0xea, 0xdc, 0x92, 0xcb, 0x98, 0x8f, 0x19,
-	0xcd, 0xd1, 0x69, 0x4f, 0xda, 0x87, 0x8f, 0xd1, 0x45, 0xc8, 0x49, 0xb5, 0x69, 0xae, 0x56, 0xce,
-	0xd0, 0x1a, 0x64, 0x9d, 0xa1, 0x7d, 0x42, 0xf8, 0xd1, 0x15, 0xb0, 0x98, 0xa8, 0xbf, 0x4e, 0xc1,
-	0xea, 0x44, 0x1c, 0x64, 0x7a, 0x7b, 0xba, 0xdb, 0xf3, 0x7e, 0x8b, 0x8d, 0xd1, 0x1d, 0xa6, 0x57,
-	0x37, 0xc8, 0x50, 0xe6, 0x8e, 0xca, 0xa4, 0xa9, 0x9b, 0xfc, 0xbb, 0x34, 0x8d, 0xe4, 0x46, 0x07,
-	0x50, 0xee, 0xeb, 0x2e, 0xd5, 0x44, 0x5c, 0xd1, 0x42, 0x79, 0x64, 0x32, 0x9a, 0xee, 0xe9, 0x5e,
-	0x24, 0x62, 0x97, 0x5a, 0x2a, 0x2a, 0xf5, 0x23, 0x54, 0x84, 0x61, 0xad, 0x7d, 0xf6, 0x85, 0x6e,
-	0x51, 0xd3, 0x22, 0xda, 0xc4, 0xc9, 0x5d, 0x9a, 0x50, 0xda, 0x38, 0x31, 0x0d, 0x62, 0x75, 0xbc,
-	0x23, 0xbb, 0xe0, 0x0b, 0xfb, 0x47, 0xea, 0xaa, 0x18, 0x4a, 0xd1, 0x48, 0x8e, 0x4a, 0x90, 0xa2,
-	0xa7, 0xd2, 0x00, 0x29, 0x7a, 0x8a, 0xbe, 0x0b, 0x19, 0xb6, 0x49, 0xbe, 0xf9, 0xd2, 0x94, 0x14,
-	0x28, 0xe5, 0x5a, 0x67, 0x0e, 0xc1, 0x9c, 0x53, 0x55, 0x7d, 0x77, 0xf0, 0xa3, 0xfb, 0xb8, 0x56,
-	0xf5, 0x06, 0xac, 0x8c, 0x85, 0xef, 0xd0, 0xf9, 0x25, 0xc3, 0xe7, 0xa7, 0xae, 0x40, 0x31, 0x12,
-	0xab, 0xd5, 0x8b, 0xb0, 0x36, 0x2d, 0xf4, 0xaa, 0x3d, 0x9f, 0x1e, 0x09, 0xa1, 0xe8, 0x36, 0x14,
-	0xfc, 0xd8, 0x2b, 0xdc, 0x71, 0xd2, 0x56, 0x1e, 0x33, 0xf6, 0x59, 0x99, 0x1f, 0xb2, 0x6b, 0xcd,
-	0xef, 0x43, 0x8a, 0x2f, 0x3c, 0xaf, 0x3b, 0x4e, 0x53, 0x77, 0x7b, 0xea, 0xa7, 0x50, 0x89, 0x8b,
-	0xab, 0x63, 0xdb, 0xc8, 0xf8, 0xd7, 0xf0, 0x22, 0xe4, 0x8e, 0xed, 0xe1, 0x40, 0xa7, 0x5c, 0x59,
-	0x11, 0xcb, 0x19, 0xbb, 0x9e, 0x22, 0xc6, 0xa6, 0x39, 0x59, 0x4c, 0x54, 0x0d, 0x2e, 0xc5, 0xc6,
-	0x56, 0x26, 0x62, 0x5a, 0x06, 0x11, 0xf6, 0x2c, 0x62, 0x31, 0x09, 0x14, 0x89, 0xc5, 0x8a, 0x09,
-	0xfb, 0x59, 0x97, 0xef, 0x95, 0xeb, 0x57, 0xb0, 0x9c, 0xa9, 0xbf, 0x2f, 0x40, 0x01, 0x13, 0xd7,
-	0x61, 0x31, 0x01, 0xd5, 0x40, 0x21, 0xa7, 0x1d, 0xe2, 0x50, 0x2f, 0x8c, 0x4e, 0x47, 0x0d, 0x82,
-	0xbb, 0xe1, 0x71, 0xb2, 0x94, 0xed, 0x8b, 0xa1, 0x5b, 0x12, 0x95, 0xc5, 0x03, 0x2c, 0x29, 0x1e,
-	0x86, 0x65, 0x77, 0x3c, 0x58, 0x96, 0x8e, 0xcd, 0xd2, 0x42, 0x6a, 0x0c, 0x97, 0xdd, 0x92, 0xb8,
-	0x2c, 0x33, 0xe7, 0xc7, 0x22, 0xc0, 0xac, 0x1e, 0x01, 0x66, 0xd9, 0x39, 0xdb, 0x8c, 0x41, 0x66,
-	0x77, 0x3c, 0x64, 0x96, 0x9b, 0xb3, 0xe2, 0x31, 0x68, 0x76, 0x3f, 0x0a, 0xcd, 0x04, 0xac, 0xba,
-	0x16, 0x2b, 0x1d, 0x8b, 0xcd, 0x7e, 0x10, 0xc2, 0x66, 0x85, 0x58, 0x60, 0x24, 0x94, 0x4c, 0x01,
-	0x67, 0xf5, 0x08, 0x38, 0x53, 0xe6, 0xd8, 0x20, 0x06, 0x9d, 0x7d, 0x10, 0x46, 0x67, 0x10, 0x0b,
-	0xf0, 0xe4, 0x79, 0x4f, 0x83, 0x67, 0xef, 0xf9, 0xf0, 0x6c, 0x29, 0x16, 0x5f, 0xca, 0x3d, 0x8c,
-	0xe3, 0xb3, 0x83, 0x09, 0x7c, 0x26, 0xf0, 0xd4, 0x1b, 0xb1, 0x2a, 0xe6, 0x00, 0xb4, 0x83, 0x09,
-	0x80, 0x56, 0x9c, 0xa3, 0x70, 0x0e, 0x42, 0xfb, 0xc5, 0x74, 0x84, 0x16, 0x8f, 0xa1, 0xe4, 0x32,
-	0x17, 0x83, 0x68, 0x5a, 0x0c, 0x44, 0x5b, 0xe1, 0xea, 0xdf, 0x8a, 0x55, 0x7f, 0x7e, 0x8c, 0x76,
-	0x83, 0x65, 0xc8, 0x31, 0x9f, 0x67, 0x51, 0x86, 0x0c, 0x87, 0xf6, 0x50, 0xa2, 0x2d, 0x31, 0x51,
-	0xdf, 0x64, 0x39, 0x3b, 0xf0, 0xef, 0x19, 0x78, 0x8e, 0x47, 0xf3, 0x90, 0x4f, 0xab, 0x7f, 0x4c,
-	0x06, 0xb2, 0x3c, 0xcd, 0x85, 0xf3, 0xbd, 0x22, 0xf3, 0x7d, 0x08, 0xe5, 0xa5, 0xa2, 0x28, 0x6f,
-	0x03, 0x96, 0x58, 0x94, 0x1e, 0x03, 0x70, 0xba, 0xe3, 0x03, 0xb8, 0x9b, 0xb0, 0xca, 0xd3, 0xb0,
-	0xc0, 0x82, 0x32, 0x34, 0x67, 0x78, 0x86, 0x59, 0x61, 0x1f, 0xc4, 0xe5, 0x14, 0x31, 0xfa, 0x1d,
-	0xb8, 0x10, 0xe2, 0xf5, 0xa3, 0xbf, 0x40, 0x33, 0x65, 0x9f, 0x7b, 0x47, 0xa6, 0x81, 0x3f, 0x27,
-	0x03, 0x0b, 0x05, 0xc8, 0x6f, 0x1a, 0x48, 0x4b, 0xfe, 0x8f, 0x40, 0x5a, 0xea, 0xbf, 0x06, 0x69,
-	0xe1, 0x6c, 0x96, 0x8e, 0x66, 0xb3, 0x7f, 0x25, 0x83, 0x33, 0xf1, 0x21, 0x57, 0xc7, 0x36, 0x88,
-	0xcc, 0x2f, 0x7c, 0x8c, 0xca, 0x90, 0xee, 0xdb, 0x5d, 0x99, 0x45, 0xd8, 0x90, 0x71, 0xf9, 0x41,
-	0x58, 0x91, 0x31, 0xd6, 0x4f, 0x4d, 0x59, 0x6e, 0x61, 0x99, 0x9a, 0xca, 0x90, 0x7e, 0x42, 0x44,
-	0xc8, 0x5c, 0xc6, 0x6c, 0xc8, 0xf8, 0xf8, 0x25, 0xe3, 0x81, 0x70, 0x19, 0x8b, 0x09, 0xba, 0x0b,
-	0x0a, 0x6f, 0x43, 0x68, 0xb6, 0xe3, 0xca, 0xe8, 0xf6, 0x5a, 0x78, 0xaf, 0xa2, 0xdb, 0xb0, 0x79,
-	0xc8, 0x78, 0x0e, 0x1c, 0x17, 0x17, 0x1c, 0x39, 0x0a, 0x65, 0x5d, 0x25, 0x02, 0xfe, 0x2e, 0x83,
-	0xc2, 0x56, 0xef, 0x3a, 0x7a, 0x87, 0xf0, 0x50, 0xa5, 0xe0, 0x80, 0xa0, 0x3e, 0x06, 0x34, 0x19,
-	0x70, 0x51, 0x13, 0x72, 0xe4, 0x84, 0x58, 0x94, 0x1d, 0x1b, 0x33, 0xf7, 0xc5, 0x29, 0xc8, 0x8a,
-	0x58, 0xb4, 0x56, 0x61, 0x46, 0xfe, 0xe7, 0x37, 0x1b, 0x65, 0xc1, 0xfd, 0xb6, 0x3d, 0x30, 0x29,
-	0x19, 0x38, 0xf4, 0x0c, 0x4b, 0x79, 0xf5, 0x57, 0x29, 0x06, 0x73, 0x22, 0xc1, 0x78, 0xaa, 0x6d,
-	0xbd, 0x2b, 0x9f, 0x0a, 0x41, 0xdc, 0xc5, 0xec, 0xbd, 0x0e, 0xd0, 0xd5, 0x5d, 0xed, 0xa9, 0x6e,
-	0x51, 0x62, 0x48, 0xa3, 0x87, 0x28, 0xa8, 0x0a, 0x05, 0x36, 0x1b, 0xb9, 0xc4, 0x90, 0x68, 0xdb,
-	0x9f, 0x87, 0xf6, 0x99, 0xff, 0x76, 0xfb, 0x8c, 0x5a, 0xb9, 0x30, 0x6e, 0xe5, 0xdf, 0xa4, 0x02,
-	0x37, 0x09, 0x10, 0xe1, 0xff, 0x9f, 0x1d, 0x7e, 0xcb, 0xeb, 0xc4, 0x68, 0x56, 0x44, 0x47, 0xb0,
-	0xea, 0x7b, 0xa9, 0x36, 0xe2, 0xde, 0xeb, 0xdd, 0xbb, 0x45, 0xdd, 0xbc, 0x7c, 0x12, 0x25, 0xbb,
-	0xe8, 0x63, 0x78, 0x75, 0x2c, 0x04, 0xf9, 0xaa, 0x53, 0x8b, 0x46, 0xa2, 0x57, 0xa2, 0x91, 0xc8,
-	0x53, 0x1d, 0x18, 0x2b, 0xfd, 0x2d, 0x9d, 0x63, 0x97, 0x95, 0x1e, 0xe1, 0x24, 0x3f, 0xf5, 0xf8,
-	0xaf, 0x41, 0x71, 0x48, 0x28, 0x2b, 0x87, 0x23, 0xc5, 0xdd, 0xb2, 0x20, 0xca, 0x92, 0xf1, 0x10,
-	0x5e, 0x99, 0x9a, 0xec, 0xd1, 0xf7, 0x40, 0x09, 0x70, 0x42, 0x32, 0xa6, 0x4e, 0xf2, 0xb1, 0x7f,
-	0xc0, 0xab, 0xfe, 0x29, 0x19, 0xa8, 0x8c, 0x56, 0x13, 0x0d, 0xc8, 0x0d, 0x89, 0x3b, 0xea, 0x0b,
-	0x7c, 0x5f, 0xda, 0x7e, 0x67, 0x31, 0x98, 0xc0, 0xa8, 0xa3, 0x3e, 0xc5, 0x52, 0x58, 0x7d, 0x0c,
-	0x39, 0x41, 0x41, 0x4b, 0x90, 0x7f, 0xb8, 0xff, 0x60, 0xff, 0xe0, 0xa3, 0xfd, 0x72, 0x02, 0x01,
-	0xe4, 0x76, 0xea, 0xf5, 0xc6, 0x61, 0xab, 0x9c, 0x44, 0x0a, 0x64, 0x77, 0x6a, 0x07, 0xb8, 0x55,
-	0x4e, 0x31, 0x32, 0x6e, 0x7c, 0xd8, 0xa8, 0xb7, 0xca, 0x69, 0xb4, 0x0a, 0x45, 0x31, 0xd6, 0xee,
-	0x1f, 0xe0, 0x9f, 0xec, 0xb4, 0xca, 0x99, 0x10, 0xe9, 0xa8, 0xb1, 0x7f, 0xaf, 0x81, 0xcb, 0x59,
-	0xf5, 0x5d, 0x56, 0x40, 0xc4, 0x00, 0x8b, 0xa0, 0x54, 0x48, 0x86, 0x4a, 0x05, 0xf5, 0x77, 0x29,
-	0xa8, 0xc6, 0xa3, 0x05, 0xf4, 0xe1, 0xd8, 0xc6, 0xb7, 0xcf, 0x01, 0x35, 0xc6, 0x76, 0x8f, 0xae,
-	0x43, 0x69, 0x48, 0x8e, 0x09, 0xed, 0xf4, 0x04, 0x7a, 0x11, 0x99, 0xad, 0x88, 0x8b, 0x92, 0xca,
-	0x85, 0x5c, 0xc1, 0xf6, 0x19, 0xe9, 0x50, 0x4d, 0x54, 0x2d, 0xe2, 0xd2, 0x29, 0x8c, 0x8d, 0x51,
-	0x8f, 0x04, 0x51, 0xfd, 0xf4, 0x5c, 0xb6, 0x54, 0x20, 0x8b, 0x1b, 0x2d, 0xfc, 0x71, 0x39, 0x8d,
-	0x10, 0x94, 0xf8, 0x50, 0x3b, 0xda, 0xdf, 0x39, 0x3c, 0x6a, 0x1e, 0x30, 0x5b, 0x5e, 0x80, 0x15,
-	0xcf, 0x96, 0x1e, 0x31, 0xab, 0x7e, 0x02, 0xa5, 0x68, 0x89, 0xce, 0x4c, 0x38, 0xb4, 0x47, 0x96,
-	0xc1, 0x8d, 0x91, 0xc5, 0x62, 0x82, 0x6e, 0x43, 0xf6, 0xc4, 0x16, 0x6e, 0x36, 0xfd, 0xae, 0x3d,
-	0xb2, 0x29, 0x09, 0x95, 0xf8, 0x82, 0x5b, 0xfd, 0x02, 0xb2, 0xdc, 0x6b, 0x98, 0x07, 0xf0, 0x62,
-	0x5b, 0x62, 0x1f, 0x36, 0x46, 0x9f, 0x00, 0xe8, 0x94, 0x0e, 0xcd, 0xf6, 0x28, 0x50, 0xbc, 0x31,
-	0xdd, 0xeb, 0x76, 0x3c, 0xbe, 0xda, 0x65, 0xe9, 0x7e, 0x6b, 0x81, 0x68, 0xc8, 0x05, 0x43, 0x0a,
-	0xd5, 0x7d, 0x28, 0x45, 0x65, 0xbd, 0x6c, 0x2d, 0xd6, 0x10, 0xcd, 0xd6, 0x02, 0x7c, 0xc9, 0x6c,
-	0xed, 0xe7, 0xfa, 0xb4, 0x68, 0xac, 0xf0, 0x89, 0xfa, 0x2c, 0x09, 0x85, 0xd6, 0xa9, 0x3c, 0x8f,
-	0x98, 0x9a, 0x3e, 0x10, 0x4d, 0x85, 0x2b, 0x58, 0xd1, 0x24, 0x48, 0xfb, 0xad, 0x87, 0x0f, 0xfc,
-	0x1b, 0x97, 0x59, 0xb4, 0x50, 0xf1, 0x7a, 0x30, 0xd2, 0xcb, 0xde, 0x07, 0xc5, 0x8f, 0x99, 0x0c,
-	0x44, 0xea, 0x86, 0x31, 0x24, 0xae, 0x2b, 0xef, 0xbd, 0x37, 0xe5, 0x2d, 0x22, 0xfb, 0xa9, 0xac,
-	0x91, 0xd3, 0x58, 0x4c, 0x54, 0x03, 0x56, 0xc6, 0x02, 0x2e, 0x7a, 0x1f, 0xf2, 0xce, 0xa8, 0xad,
-	0x79, 0xe6, 0x19, 0x7b, 0x12, 0xf0, 0xe0, 0xc9, 0xa8, 0xdd, 0x37, 0x3b, 0x0f, 0xc8, 0x99, 0xb7,
-	0x18, 0x67, 0xd4, 0x7e, 0x20, 0xac, 0x28, 0x7e, 0x25, 0x15, 0xfe, 0x95, 0x13, 0x28, 0x78, 0x97,
-	0x02, 0xfd, 0x10, 0x14, 0x3f, 0x96, 0xfb, 0x9d, 0xc3, 0xd8, 0x24, 0x20, 0xd5, 0x07, 0x22, 0x0c,
-	0xeb, 0xba, 0x66, 0xd7, 0x22, 0x86, 0x16, 0xc0, 0x58, 0xfe, 0x6b, 0x05, 0xbc, 0x22, 0x3e, 0xec,
-	0x79, 0x18, 0x56, 0xfd, 0x77, 0x12, 0x0a, 0x5e, 0x87, 0x08, 0xbd, 0x1b, 0xba, 0x77, 0xa5, 0x29,
-	0xf5, 0xb4, 0xc7, 0x18, 0x74, 0x79, 0xa2, 0x6b, 0x4d, 0x9d, 0x7f, 0xad, 0x71, 0xed, 0x3a, 0xaf,
-	0x71, 0x9a, 0x39, 0x77, 0xe3, 0xf4, 0x6d, 0x40, 0xd4, 0xa6, 0x7a, 0x5f, 0x3b, 0xb1, 0xa9, 0x69,
-	0x75, 0x35, 0x61, 0x6c, 0x81, 0x05, 0xca, 0xfc, 0xcb, 0x23, 0xfe, 0xe1, 0x90, 0xdb, 0xfd, 0x97,
-	0x49, 0x28, 0xf8, 0x41, 0xfd, 0xbc, 0x4d, 0x9b, 0x8b, 0x90, 0x93, 0x71, 0x4b, 0x74, 0x6d, 0xe4,
-	0xcc, 0xef, 0x1f, 0x66, 0x42, 0xfd, 0xc3, 0x2a, 0x14, 0x06, 0x84, 0xea, 0x3c, 0xb3, 0x89, 0x4a,
-	0xc2, 0x9f, 0xdf, 0x7c, 0x0f, 0x96, 0x42, 0xfd, 0x33, 0xe6, 0x79, 0xfb, 0x8d, 0x8f, 0xca, 0x89,
-	0x6a, 0xfe, 0xd9, 0x97, 0x57, 0xd3, 0xfb, 0xe4, 0x29, 0xbb, 0xb3, 0xb8, 0x51, 0x6f, 0x36, 0xea,
-	0x0f, 0xca, 0xc9, 0xea, 0xd2, 0xb3, 0x2f, 0xaf, 0xe6, 0x31, 0xe1, 0xb5, 0xfc, 0xcd, 0x26, 0x2c,
-	0x87, 0x4f, 0x25, 0x1a, 0xfa, 0x10, 0x94, 0xee, 0x3d, 0x3c, 0xdc, 0xdb, 0xad, 0xef, 0xb4, 0x1a,
-	0xda, 0xa3, 0x83, 0x56, 0xa3, 0x9c, 0x44, 0xaf, 0xc2, 0x85, 0xbd, 0xdd, 0x1f, 0x37, 0x5b, 0x5a,
-	0x7d, 0x6f, 0xb7, 0xb1, 0xdf, 0xd2, 0x76, 0x5a, 0xad, 0x9d, 0xfa, 0x83, 0x72, 0x6a, 0xfb, 0x0f,
-	0x0a, 0xac, 0xec, 0xd4, 0xea, 0xbb, 0x2c, 0x6c, 0x9b, 0x1d, 0x9d, 0x97, 0x79, 0x75, 0xc8, 0xf0,
-	0x42, 0x6e, 0xe6, 0xeb, 0x5a, 0x75, 0x76, 0x97, 0x07, 0xdd, 0x87, 0x2c, 0xaf, 0xf1, 0xd0, 0xec,
-	0xe7, 0xb6, 0xea, 0x9c, 0xb6, 0x0f, 0x5b, 0x0c, 0x77, 0x8f, 0x99, 0xef, 0x6f, 0xd5, 0xd9, 0x5d,
-	0x20, 0x84, 0x41, 0x09, 0xc0, 0xe7, 0xfc, 0xf7, 0xa8, 0xea, 0x02, 0xc1, 0x06, 0xed, 0x41, 0xde,
-	0x83, 0xf5, 0xf3, 0x5e, 0xc8, 0xaa, 0x73, 0xdb, 0x34, 0xcc, 0x5c, 0xa2, 0xfc, 0x9a, 0xfd, 0xdc,
-	0x57, 0x9d, 0xd3, 0x73, 0x42, 0xbb, 0x90, 0x93, 0x80, 0x6a, 0xce, 0xab, 0x57, 0x75, 0x5e, 0xdb,
-	0x85, 0x19, 0x2d, 0x28, 0x6c, 0xe7, 0x3f, 0x62, 0x56, 0x17, 0x68, 0xa7, 0xa1, 0x87, 0x00, 0xa1,
-	0x62, 0x6b, 0x81, 0xd7, 0xc9, 0xea, 0x22, 0x6d, 0x32, 0x74, 0x00, 0x05, 0x1f, 0x54, 0xcf, 0x7d,
-	0x2b, 0xac, 0xce, 0xef, 0x57, 0xa1, 0xc7, 0x50, 0x8c, 0x82, 0xc9, 0xc5, 0x5e, 0x00, 0xab, 0x0b,
-	0x36, 0xa2, 0x98, 0xfe, 0x28, 0xb2, 0x5c, 0xec, 0x45, 0xb0, 0xba, 0x60, 0x5f, 0x0a, 0x7d, 0x06,
-	0xab, 0x93, 0xc8, 0x6f, 0xf1, 0x07, 0xc2, 0xea, 0x39, 0x3a, 0x55, 0x68, 0x00, 0x68, 0x0a, 0x62,
-	0x3c, 0xc7, 0x7b, 0x61, 0xf5, 0x3c, 0x8d, 0xab, 0x5a, 0xe3, 0xab, 0x17, 0xeb, 0xc9, 0xaf, 0x5f,
-	0xac, 0x27, 0xff, 0xf1, 0x62, 0x3d, 0xf9, 0xfc, 0xe5, 0x7a, 0xe2, 0xeb, 0x97, 0xeb, 0x89, 0xbf,
-	0xbd, 0x5c, 0x4f, 0xfc, 0xec, 0xad, 0xae, 0x49, 0x7b, 0xa3, 0xf6, 0x66, 0xc7, 0x1e, 0x6c, 0x85,
-	0xff, 0x88, 0x30, 0xed, 0xcf, 0x11, 0xed, 0x1c, 0x4f, 0x2a, 0xb7, 0xfe, 0x13, 0x00, 0x00, 0xff,
-	0xff, 0x34, 0x1a, 0x9a, 0xa7, 0x3c, 0x21, 0x00, 0x00,
+	// 3192 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x6f, 0x23, 0xc7,
+	0xb1, 0xe7, 0xa7, 0x48, 0x16, 0x3f, 0x44, 0xf5, 0xca, 0x6b, 0x2e, 0xbd, 0x2b, 0xc9, 0xb3, 0xcf,
+	0x7e, 0xeb, 0xb5, 0x2d, 0xbd, 0xb7, 0x86, 0xbf, 0xe0, 0x67, 0x3f, 0x4b, 0x5c, 0xae, 0x29, 0xaf,
+	0x2c, 0xca, 0x2d, 0xee, 0x1a, 0x4e, 0xe2, 0x9d, 0x0c, 0x67, 0x5a, 0xe2, 0x78, 0xc9, 0x99, 0xf1,
+	0x4c, 0x53, 0x96, 0xf6, 0x14, 0x04, 0xc8, 0xc5, 0x27, 0x1f, 0x82, 0xc0, 0x87, 0x18, 0x08, 0x82,
+	0xdc, 0x83, 0x1c, 0xf2, 0x2f, 0x04, 0xce, 0x21, 0x80, 0x8f, 0x39, 0x39, 0x81, 0xf7, 0x96, 0x7b,
+	0x10, 0x20, 0x40, 0x80, 0xa0, 0xbf, 0x86, 0xc3, 0x8f, 0x11, 0xa9, 0x38, 0xb7, 0xdc, 0xa6, 0xab,
+	0xab, 0xaa, 0xbb, 0x6b, 0xba, 0xab, 0x7e, 0x55, 0xdd, 0xf0, 0x14, 0x25, 0x8e, 0x45, 0xfc, 0x81,
+	0xed, 0xd0, 0x2d, 0xa3, 0x6b, 0xda, 0x5b, 0xf4, 0xcc, 0x23, 0xc1, 0xa6, 0xe7, 0xbb, 0xd4, 0x45,
+	0xcb, 0xa3, 0xce, 0x4d, 0xd6, 0x59, 0xbf, 0x16, 0xe1, 0x36, 0xfd, 0x33, 0x8f, 0xba, 0x5b, 0x9e,
+	0xef, 0xba, 0x47, 0x82, 0xbf, 0x7e, 0x35, 0xd2, 0xcd, 0xf5, 0x44, 0xb5, 0xd5, 0xaf, 0x4e, 0x0b,
+	0x3f, 0x24, 0x67, 0xaa, 0xf7, 0xda, 0x94, 0xac, 0x67, 0xf8, 0xc6, 0x40, 0x75, 0xaf, 0x1f, 0xbb,
+	0xee, 0x71, 0x9f, 0x6c, 0xf1, 0x56, 0x77, 0x78, 0xb4, 0x45, 0xed, 0x01, 0x09, 0xa8, 0x31, 0xf0,
+	0x24, 0xc3, 0xea, 0xb1, 0x7b, 0xec, 0xf2, 0xcf, 0x2d, 0xf6, 0x25, 0xa9, 0x6b, 0x93, 0x62, 0xd6,
+	0xd0, 0x37, 0xa8, 0xed, 0x3a, 0xa2, 0x5f, 0xfb, 0x43, 0x0e, 0x72, 0x98, 0x7c, 0x32, 0x24, 0x01,
+	0x45, 0xb7, 0x20, 0x43, 0xcc, 0x9e, 0x5b, 0x4b, 0x6e, 0x24, 0x6f, 0x14, 0x6f, 0x5d, 0xdd, 0x9c,
+	0x58, 0xfc, 0xa6, 0xe4, 0x6b, 0x9a, 0x3d, 0xb7, 0x95, 0xc0, 0x9c, 0x17, 0xbd, 0x0c, 0xd9, 0xa3,
+	0xfe, 0x30, 0xe8, 0xd5, 0x52, 0x5c, 0xe8, 0x5a, 0x9c, 0xd0, 0x1d, 0xc6, 0xd4, 0x4a, 0x60, 0xc1,
+	0xcd, 0x86, 0xb2, 0x9d, 0x23, 0xb7, 0x96, 0x3e, 0x7f, 0xa8, 0x5d, 0xe7, 0x88, 0x0f, 0xc5, 0x78,
+	0xd1, 0x0e, 0x80, 0xed, 0xd8, 0x54, 0x37, 0x7b, 0x86, 0xed, 0xd4, 0x32, 0x5c, 0xf2, 0xe9, 0x78,
+	0x49, 0x9b, 0x36, 0x18, 0x63, 0x2b, 0x81, 0x0b, 0xb6, 0x6a, 0xb0, 0xe9, 0x7e, 0x32, 0x24, 0xfe,
+	0x59, 0x2d, 0x7b, 0xfe, 0x74, 0xdf, 0x67, 0x4c, 0x6c, 0xba, 0x9c, 0x1b, 0x35, 0xa1, 0xd8, 0x25,
+	0xc7, 0xb6, 0xa3, 0x77, 0xfb, 0xae, 0xf9, 0xb0, 0xb6, 0xc4, 0x85, 0xb5, 0x38, 0xe1, 0x1d, 0xc6,
+	0xba, 0xc3, 0x38, 0x5b, 0x09, 0x0c, 0xdd, 0xb0, 0x85, 0xfe, 0x0f, 0xf2, 0x66, 0x8f, 0x98, 0x0f,
+	0x75, 0x7a, 0x5a, 0xcb, 0x71, 0x1d, 0xeb, 0x71, 0x3a, 0x1a, 0x8c, 0xaf, 0x73, 0xda, 0x4a, 0xe0,
+	0x9c, 0x29, 0x3e, 0xd9, 0xfa, 0x2d, 0xd2, 0xb7, 0x4f, 0x88, 0xcf, 0xe4, 0xf3, 0xe7, 0xaf, 0xff,
+	0xb6, 0xe0, 0xe4, 0x1a, 0x0a, 0x96, 0x6a, 0xa0, 0xff, 0x87, 0x02, 0x71, 0x2c, 0xb9, 0x8c, 0x02,
+	0x57, 0xb1, 0x11, 0xfb, 0x9f, 0x1d, 0x4b, 0x2d, 0x22, 0x4f, 0xe4, 0x37, 0x7a, 0x0d, 0x96, 0x4c,
+	0x77, 0x30, 0xb0, 0x69, 0x0d, 0xb8, 0xf4, 0x5a, 0xec, 0x02, 0x38, 0x57, 0x2b, 0x81, 0x25, 0x3f,
+	0xda, 0x87, 0x4a, 0xdf, 0x0e, 0xa8, 0x1e, 0x38, 0x86, 0x17, 0xf4, 0x5c, 0x1a, 0xd4, 0x8a, 0x5c,
+	0xc3, 0x33, 0x71, 0x1a, 0xf6, 0xec, 0x80, 0x1e, 0x2a, 0xe6, 0x56, 0x02, 0x97, 0xfb, 0x51, 0x02,
+	0xd3, 0xe7, 0x1e, 0x1d, 0x11, 0x3f, 0x54, 0x58, 0x2b, 0x9d, 0xaf, 0xaf, 0xcd, 0xb8, 0x95, 0x3c,
+	0xd3, 0xe7, 0x46, 0x09, 0xe8, 0xfb, 0x70, 0xa9, 0xef, 0x1a, 0x56, 0xa8, 0x4e, 0x37, 0x7b, 0x43,
+	0xe7, 0x61, 0xad, 0xcc, 0x95, 0x3e, 0x17, 0x3b, 0x49, 0xd7, 0xb0, 0x94, 0x8a, 0x06, 0x13, 0x68,
+	0x25, 0xf0, 0x4a, 0x7f, 0x92, 0x88, 0x1e, 0xc0, 0xaa, 0xe1, 0x79, 0xfd, 0xb3, 0x49, 0xed, 0x15,
+	0xae, 0xfd, 0x66, 0x9c, 0xf6, 0x6d, 0x26, 0x33, 0xa9, 0x1e, 0x19, 0x53, 0xd4, 0x9d, 0x1c, 0x64,
+	0x4f, 0x8c, 0xfe, 0x90, 0x68, 0xff, 0x0d, 0xc5, 0xc8, 0x31, 0x45, 0x35, 0xc8, 0x0d, 0x48, 0x10,
+	0x18, 0xc7, 0x84, 0x9f, 0xea, 0x02, 0x56, 0x4d, 0xad, 0x02, 0xa5, 0xe8, 0xd1, 0xd4, 0x3e, 0x4f,
+	0x42, 0x31, 0x72, 0xea, 0x98, 0xe4, 0x09, 0xf1, 0x03, 0xdb, 0x75, 0x94, 0xa4, 0x6c, 0xa2, 0xeb,
+	0x50, 0xe6, 0xfb, 0x47, 0x57, 0xfd, 0xec, 0xe8, 0x67, 0x70, 0x89, 0x13, 0xef, 0x4b, 0xa6, 0x75,
+	0x28, 0x7a, 0xb7, 0xbc, 0x90, 0x25, 0xcd, 0x59, 0xc0, 0xbb, 0xe5, 0x29, 0x86, 0xa7, 0xa1, 0xc4,
+	0x56, 0x1a, 0x72, 0x64, 0xf8, 0x20, 0x45, 0x46, 0x93, 0x2c, 0xda, 0xcf, 0xd3, 0x50, 0x9d, 0x3c,
+	0xce, 0xe8, 0x35, 0xc8, 0x30, 0xcf, 0x27, 0x9d, 0x54, 0x7d, 0x53, 0xf8, 0xb7, 0x4d, 0xe5, 0xdf,
+	0x36, 0x3b, 0xca, 0x2d, 0xee, 0xe4, 0xbf, 0xfa, 0x66, 0x3d, 0xf1, 0xf9, 0x9f, 0xd6, 0x93, 0x98,
+	0x4b, 0xa0, 0x2b, 0xec, 0xf4, 0x19, 0xb6, 0xa3, 0xdb, 0x16, 0x9f, 0x72, 0x81, 0x1d, 0x2d, 0xc3,
+	0x76, 0x76, 0x2d, 0xb4, 0x07, 0x55, 0xd3, 0x75, 0x02, 0xe2, 0x04, 0xc3, 0x40, 0x17, 0x6e, 0xb7,
+	0x96, 0x9e, 0x3e, 0x60, 0xc2, 0x99, 0x37, 0x14, 0xe7, 0x01, 0x67, 0xc4, 0xcb, 0xe6, 0x38, 0x01,
+	0xdd, 0x01, 0x38, 0x31, 0xfa, 0xb6, 0x65, 0x50, 0xd7, 0x0f, 0x6a, 0x99, 0x8d, 0xf4, 0xcc, 0x53,
+	0x76, 0x5f, 0xb1, 0xdc, 0xf3, 0x2c, 0x83, 0x92, 0x9d, 0x0c, 0x9b, 0x2e, 0x8e, 0x48, 0xa2, 0x67,
+	0x61, 0xd9, 0xf0, 0x3c, 0x3d, 0xa0, 0x06, 0x25, 0x7a, 0xf7, 0x8c, 0x92, 0x80, 0xbb, 0xad, 0x12,
+	0x2e, 0x1b, 0x9e, 0x77, 0xc8, 0xa8, 0x3b, 0x8c, 0x88, 0x9e, 0x81, 0x0a, 0xf3, 0x70, 0xb6, 0xd1,
+	0xd7, 0x7b, 0xc4, 0x3e, 0xee, 0x51, 0xee, 0xa0, 0xd2, 0xb8, 0x2c, 0xa9, 0x2d, 0x4e, 0x44, 0x37,
+	0xa0, 0x3a, 0x52, 0xe7, 0x1e, 0x1d, 0x05, 0x84, 0x72, 0x2f, 0x94, 0xc1, 0x15, 0xa5, 0xaf, 0xcd,
+	0xa9, 0xe8, 0xbf, 0xa0, 0x32, 0xe2, 0x0c, 0xec, 0x47, 0x84, 0x7b, 0x9b, 0x0c, 0x2e, 0x29, 0xbe,
+	0x43, 0xfb, 0x11, 0xd1, 0x2c, 0x28, 0x45, 0xbd, 0x25, 0x42, 0x90, 0xb1, 0x0c, 0x6a, 0xf0, 0x3f,
+	0x53, 0xc2, 0xfc, 0x9b, 0xd1, 0x3c, 0x83, 0xf6, 0xa4, 0xbd, 0xf9, 0x37, 0xba, 0x0c, 0x4b, 0x72,
+	0x9a, 0x69, 0x3e, 0x4d, 0xd9, 0x42, 0xab, 0x90, 0xf5, 0x7c, 0xf7, 0x84, 0xf0, 0xad, 0x90, 0xc7,
+	0xa2, 0xa1, 0xfd, 0x2a, 0x05, 0x2b, 0x53, 0x7e, 0x95, 0xe9, 0xed, 0x19, 0x41, 0x4f, 0x8d, 0xc5,
+	0xbe, 0xd1, 0x2b, 0x4c, 0xaf, 0x61, 0x11, 0x5f, 0xc6, 0xa2, 0xda, 0xf4, 0xaf, 0x6b, 0xf1, 0x7e,
+	0x69, 0x6a, 0xc9, 0x8d, 0xda, 0x50, 0xed, 0x1b, 0x01, 0xd5, 0x85, 0x9f, 0xd2, 0x23, 0x71, 0x69,
+	0xda, 0x3b, 0xef, 0x19, 0xca, 0xb3, 0xb1, 0x43, 0x22, 0x15, 0x55, 0xfa, 0x63, 0x54, 0x84, 0x61,
+	0xb5, 0x7b, 0xf6, 0xc8, 0x70, 0xa8, 0xed, 0x10, 0x7d, 0x6a, 0x27, 0x5c, 0x99, 0x52, 0xda, 0x3c,
+	0xb1, 0x2d, 0xe2, 0x98, 0x6a, 0x0b, 0x5c, 0x0a, 0x85, 0xef, 0x8f, 0xf6, 0xc2, 0x1a, 0x80, 0x6f,
+	0x38, 0x96, 0x3b, 0x70, 0x48, 0xa0, 0xb6, 0x41, 0x84, 0xa2, 0x61, 0xa8, 0x8c, 0x47, 0x0e, 0x54,
+	0x81, 0x14, 0x3d, 0x95, 0x06, 0x4a, 0xd1, 0x53, 0xf4, 0x3f, 0x90, 0x61, 0x46, 0xe0, 0xc6, 0xa9,
+	0xcc, 0x08, 0xb9, 0x52, 0xae, 0x73, 0xe6, 0x11, 0xcc, 0x39, 0x35, 0x0d, 0xaa, 0x93, 0xd1, 0x64,
+	0x52, 0xab, 0xf6, 0x1c, 0x2c, 0x4f, 0x84, 0x8b, 0xc8, 0xff, 0x4d, 0x46, 0xff, 0xaf, 0xb6, 0x0c,
+	0xe5, 0xb1, 0xd8, 0xa0, 0x5d, 0x86, 0xd5, 0x59, 0xae, 0x5e, 0xeb, 0xc1, 0xea, 0x2c, 0x97, 0x8d,
+	0x5e, 0x86, 0x7c, 0xe8, 0xeb, 0xc5, 0xf1, 0x9f, 0xb6, 0xa5, 0x62, 0xc6, 0x21, 0x2b, 0x3b, 0xf7,
+	0x6c, 0x37, 0xf3, 0xfd, 0x92, 0xe2, 0x13, 0xcf, 0x19, 0x9e, 0xd7, 0x32, 0x82, 0x9e, 0xf6, 0x9b,
+	0x24, 0xd4, 0xe2, 0x1c, 0xf9, 0xc4, 0x3a, 0x32, 0xe1, 0x3e, 0xbd, 0x0c, 0x4b, 0x47, 0xae, 0x3f,
+	0x30, 0x28, 0xd7, 0x56, 0xc6, 0xb2, 0xc5, 0xf6, 0xaf, 0x70, 0xea, 0x69, 0x4e, 0x16, 0x0d, 0xc6,
+	0x2d, 0xcf, 0x5a, 0x46, 0x68, 0x11, 0x2d, 0x46, 0xef, 0x13, 0xe7, 0x98, 0xf6, 0xf8, 0xcf, 0x2c,
+	0x63, 0xd9, 0x62, 0x8e, 0xb3, 0x6b, 0x04, 0x24, 0x7a, 0x92, 0x33, 0x18, 0x18, 0x49, 0x1c, 0x63,
+	0xed, 0x67, 0x49, 0xb8, 0x12, 0x1b, 0x1e, 0xd8, 0x24, 0x6c, 0xc7, 0x22, 0xe2, 0x17, 0x95, 0xb1,
+	0x68, 0x8c, 0xa6, 0x26, 0xd6, 0x3f, 0x9a, 0x5a, 0xc0, 0xcd, 0xc7, 0x67, 0x5c, 0xc0, 0xb2, 0x15,
+	0x3b, 0xe5, 0x6b, 0x00, 0x5c, 0x50, 0xb8, 0x84, 0x2c, 0xef, 0x2b, 0x70, 0x0a, 0xf7, 0x07, 0xbf,
+	0xc8, 0x43, 0x1e, 0x93, 0xc0, 0x63, 0xde, 0x10, 0xed, 0x40, 0x81, 0x9c, 0x9a, 0xc4, 0xa3, 0x2a,
+	0x80, 0xcc, 0xc6, 0x4b, 0x82, 0xbb, 0xa9, 0x38, 0x19, 0x58, 0x09, 0xc5, 0xd0, 0x4b, 0x12, 0x8f,
+	0xc6, 0x43, 0x4b, 0x29, 0x1e, 0x05, 0xa4, 0xaf, 0x28, 0x40, 0x9a, 0x8e, 0xc5, 0x27, 0x42, 0x6a,
+	0x02, 0x91, 0xbe, 0x24, 0x11, 0x69, 0x66, 0xce, 0x60, 0x63, 0x90, 0xb4, 0x31, 0x06, 0x49, 0xb3,
+	0x73, 0x96, 0x19, 0x83, 0x49, 0x5f, 0x51, 0x98, 0x74, 0x69, 0xce, 0x8c, 0x27, 0x40, 0xe9, 0x9d,
+	0x71, 0x50, 0x2a, 0x00, 0xe5, 0xf5, 0x58, 0xe9, 0x58, 0x54, 0xfa, 0x66, 0x04, 0x95, 0xe6, 0x63,
+	0x21, 0xa1, 0x50, 0x32, 0x03, 0x96, 0x36, 0xc6, 0x60, 0x69, 0x61, 0x8e, 0x0d, 0x62, 0x70, 0xe9,
+	0xdb, 0x51, 0x5c, 0x0a, 0xb1, 0xd0, 0x56, 0xfe, 0xef, 0x59, 0xc0, 0xf4, 0xf5, 0x10, 0x98, 0x16,
+	0x63, 0x91, 0xb5, 0x5c, 0xc3, 0x24, 0x32, 0x6d, 0x4f, 0x21, 0x53, 0x81, 0x24, 0x9f, 0x8d, 0x55,
+	0x31, 0x07, 0x9a, 0xb6, 0xa7, 0xa0, 0x69, 0x79, 0x8e, 0xc2, 0x39, 0xd8, 0xf4, 0x07, 0xb3, 0xb1,
+	0x69, 0x3c, 0x7a, 0x94, 0xd3, 0x5c, 0x0c, 0x9c, 0xea, 0x31, 0xe0, 0x74, 0x99, 0xab, 0x7f, 0x3e,
+	0x56, 0xfd, 0xc5, 0xd1, 0xe9, 0x73, 0xb0, 0xa2, 0x84, 0xc3, 0x33, 0xcf, 0x9c, 0x13, 0xf1, 0x7d,
+	0xd7, 0x97, 0x38, 0x53, 0x34, 0xb4, 0x1b, 0x50, 0x0a, 0x59, 0xcf, 0x47, 0xb2, 0x3c, 0xae, 0x44,
+	0xce, 0xb4, 0xf6, 0xf7, 0x14, 0x94, 0xa2, 0xc7, 0x75, 0x0c, 0x99, 0x14, 0x24, 0x32, 0x89, 0xe0,
+	0xdb, 0xd4, 0x38, 0xbe, 0x5d, 0x87, 0x22, 0x8b, 0x17, 0x13, 0xd0, 0xd5, 0xf0, 0x42, 0xe8, 0x7a,
+	0x13, 0x56, 0x38, 0x60, 0x10, 0x28, 0x58, 0x3a, 0xea, 0x0c, 0x8f, 0x75, 0xcb, 0xac, 0x43, 0x6c,
+	0x4e, 0x4e, 0x46, 0x2f, 0xc2, 0xa5, 0x08, 0x6f, 0x18, 0x87, 0x44, 0x00, 0xaf, 0x86, 0xdc, 0xdb,
+	0x22, 0x20, 0xa1, 0x17, 0x01, 0xf5, 0xec, 0x80, 0xba, 0xbe, 0x6d, 0x1a, 0x7d, 0x9d, 0x9d, 0x73,
+	0x9b, 0x04, 0xdc, 0x31, 0xe4, 0xf1, 0xca, 0xa8, 0xe7, 0x7d, 0xd1, 0x81, 0xf6, 0xa1, 0x44, 0x4e,
+	0x88, 0x43, 0xf5, 0xc0, 0xec, 0x91, 0x81, 0x51, 0xcb, 0x6d, 0xa4, 0x67, 0x66, 0x40, 0x4d, 0xc6,
+	0xb4, 0x4d, 0xa9, 0x6f, 0x77, 0x87, 0x94, 0x1c, 0x72, 0x66, 0x89, 0x36, 0x8a, 0x5c, 0x81, 0x20,
+	0xa1, 0x6d, 0x28, 0x99, 0x86, 0x67, 0x74, 0xed, 0xbe, 0x4d, 0xd9, 0xc0, 0xf9, 0x18, 0x67, 0xd8,
+	0x88, 0x30, 0xe1, 0x31, 0x11, 0xed, 0xa7, 0x29, 0x58, 0x99, 0x72, 0x78, 0x33, 0x01, 0x76, 0xf2,
+	0xdf, 0x04, 0xb0, 0x53, 0xff, 0x32, 0xc0, 0x8e, 0x22, 0x83, 0xf4, 0x18, 0x32, 0x98, 0xb2, 0x6c,
+	0xe6, 0xbb, 0x59, 0x56, 0xfb, 0x5b, 0x72, 0xb4, 0x4b, 0x43, 0xb8, 0x6c, 0xba, 0x16, 0x91, 0x81,
+	0x9a, 0x7f, 0xa3, 0x2a, 0xa4, 0xfb, 0xee, 0xb1, 0x0c, 0xc7, 0xec, 0x93, 0x71, 0x85, 0x61, 0xa9,
+	0x20, 0xa3, 0x4e, 0x18, 0xe3, 0xb3, 0x7c, 0xcf, 0x89, 0x06, 0x93, 0x7d, 0x48, 0x44, 0x10, 0x29,
+	0x61, 0xf6, 0x89, 0x56, 0xe5, 0xb1, 0xe3, 0xa1, 0xa1, 0x84, 0x45, 0x03, 0xbd, 0x06, 0x05, 0x5e,
+	0xb2, 0xd2, 0x5d, 0x4f, 0xfd, 0xe0, 0xa7, 0xa2, 0xcb, 0x12, 0x95, 0xa9, 0xcd, 0x03, 0xc6, 0xd3,
+	0xf6, 0x02, 0x9c, 0xf7, 0xe4, 0x57, 0x04, 0x10, 0x15, 0xc6, 0x80, 0xfb, 0x55, 0x28, 0xb0, 0xd9,
+	0x07, 0x9e, 0x61, 0x12, 0xee, 0xbc, 0x0b, 0x78, 0x44, 0xd0, 0x1e, 0x00, 0x9a, 0x0e, 0x41, 0xa8,
+	0x05, 0x4b, 0xdc, 0x3c, 0x6c, 0x1b, 0x30, 0xcb, 0x5e, 0x9e, 0x6d, 0xd9, 0x9d, 0x1a, 0x33, 0xe5,
+	0x5f, 0xbe, 0x59, 0xaf, 0x0a, 0xee, 0x17, 0xdc, 0x81, 0x4d, 0xc9, 0xc0, 0xa3, 0x67, 0x58, 0xca,
+	0x6b, 0x7f, 0x4d, 0xc3, 0xb2, 0x1a, 0x40, 0x61, 0xdf, 0x59, 0xb6, 0x55, 0x4e, 0x20, 0x15, 0x49,
+	0x4f, 0x16, 0xb3, 0xf7, 0x1a, 0xc0, 0xb1, 0x11, 0xe8, 0x9f, 0x1a, 0x0e, 0x25, 0x96, 0x34, 0x7a,
+	0x84, 0x82, 0xea, 0x90, 0x67, 0xad, 0x61, 0x40, 0x2c, 0x99, 0x79, 0x85, 0xed, 0xc8, 0x3a, 0x73,
+	0xdf, 0x6d, 0x9d, 0xe3, 0x56, 0xce, 0x4f, 0x58, 0x39, 0x82, 0xe5, 0x0a, 0x63, 0x58, 0xae, 0x0e,
+	0x79, 0xcf, 0xb7, 0x5d, 0xdf, 0xa6, 0x67, 0xfc, 0xd7, 0xa4, 0x71, 0xd8, 0x66, 0x7d, 0x01, 0x03,
+	0x92, 0x8e, 0x49, 0x78, 0xd0, 0xcc, 0xe0, 0xb0, 0xcd, 0xb0, 0x9e, 0x45, 0x3c, 0xe2, 0x58, 0x81,
+	0xee, 0x3a, 0xb5, 0xd2, 0x46, 0xfa, 0x46, 0x09, 0x17, 0x24, 0xa5, 0xed, 0xb0, 0x93, 0x43, 0x4f,
+	0x75, 0xb3, 0x6f, 0x04, 0x01, 0x8f, 0x6d, 0x05, 0x9c, 0xa3, 0xa7, 0x0d, 0xd6, 0x64, 0xe5, 0x01,
+	0x72, 0xea, 0xd9, 0x7e, 0x08, 0x61, 0x2b, 0x7c, 0xd8, 0x92, 0x20, 0x4a, 0xb7, 0xb8, 0x0d, 0x45,
+	0xc9, 0xc4, 0x93, 0xf9, 0xe5, 0xb9, 0xc9, 0x7c, 0x86, 0x27, 0xf2, 0x20, 0x84, 0x18, 0x59, 0xfb,
+	0x49, 0xc4, 0xd1, 0x8c, 0xf2, 0x93, 0xff, 0xb8, 0x3f, 0xaf, 0xfd, 0x96, 0x57, 0x49, 0xc6, 0x91,
+	0x11, 0x3a, 0x84, 0x95, 0xd0, 0xcf, 0xe9, 0x43, 0xee, 0xff, 0xd4, 0x49, 0x5b, 0xd4, 0x51, 0x56,
+	0x4f, 0xc6, 0xc9, 0x01, 0xfa, 0x10, 0x9e, 0x9c, 0x70, 0xe2, 0xa1, 0xea, 0xd4, 0xa2, 0xbe, 0xfc,
+	0x89, 0x71, 0x5f, 0xae, 0x54, 0x8f, 0x8c, 0x95, 0xfe, 0x8e, 0xc6, 0x7a, 0x04, 0x4f, 0x33, 0x97,
+	0x6d, 0x0d, 0xfb, 0xc4, 0xd2, 0xe3, 0xa6, 0x2b, 0xbc, 0xf9, 0x74, 0x51, 0xef, 0x50, 0x49, 0x4e,
+	0x4c, 0x5b, 0x9a, 0x64, 0x2d, 0x98, 0xdd, 0xaf, 0x56, 0xf1, 0x3c, 0xac, 0x7c, 0x6c, 0xd8, 0x6c,
+	0xe0, 0x48, 0x78, 0xca, 0xf2, 0xb3, 0x53, 0x15, 0x1d, 0xa3, 0x8c, 0x5e, 0xdb, 0x85, 0x8a, 0xfa,
+	0x6d, 0x02, 0x91, 0xce, 0xdc, 0xa7, 0xd7, 0xa1, 0xec, 0x13, 0xca, 0xaa, 0x56, 0x63, 0x35, 0x93,
+	0x92, 0x20, 0xca, 0x94, 0xf0, 0x00, 0x9e, 0x98, 0x89, 0x4c, 0xd1, 0xab, 0x50, 0x18, 0x81, 0xda,
+	0x64, 0x4c, 0xf9, 0x41, 0xb1, 0xe3, 0x11, 0xaf, 0xf6, 0x38, 0x09, 0x4f, 0xcc, 0xc4, 0xa6, 0xa8,
+	0x09, 0x4b, 0x3e, 0x09, 0x86, 0x7d, 0x91, 0x15, 0x57, 0x6e, 0xbd, 0xb8, 0x18, 0xa6, 0x65, 0xd4,
+	0x61, 0x9f, 0x62, 0x29, 0xcc, 0xd6, 0x15, 0x50, 0x9f, 0x18, 0x03, 0x81, 0x35, 0xc5, 0x0e, 0xca,
+	0xe3, 0x92, 0x20, 0x72, 0xd8, 0x18, 0x68, 0x0f, 0x60, 0x49, 0x88, 0xa1, 0x22, 0xe4, 0xee, 0xed,
+	0xdf, 0xdd, 0x6f, 0x7f, 0xb0, 0x5f, 0x4d, 0x20, 0x80, 0xa5, 0xed, 0x46, 0xa3, 0x79, 0xd0, 0xa9,
+	0x26, 0x51, 0x01, 0xb2, 0xdb, 0x3b, 0x6d, 0xdc, 0xa9, 0xa6, 0x18, 0x19, 0x37, 0xdf, 0x6d, 0x36,
+	0x3a, 0xd5, 0x34, 0x5a, 0x81, 0xb2, 0xf8, 0xd6, 0xef, 0xb4, 0xf1, 0x7b, 0xdb, 0x9d, 0x6a, 0x26,
+	0x42, 0x3a, 0x6c, 0xee, 0xdf, 0x6e, 0xe2, 0x6a, 0x56, 0x3b, 0x80, 0x2b, 0x6a, 0xb2, 0xd3, 0xe9,
+	0x7f, 0x98, 0x33, 0x27, 0xa3, 0x39, 0xf3, 0x78, 0x0e, 0x9c, 0x9a, 0xcc, 0x81, 0xbf, 0x48, 0x41,
+	0x3d, 0x1e, 0x1e, 0xa3, 0x77, 0x27, 0x8c, 0x77, 0xeb, 0x02, 0xd8, 0x7a, 0xd2, 0x82, 0xcf, 0x40,
+	0xc5, 0x27, 0x47, 0x84, 0x9a, 0xbd, 0x91, 0x09, 0xd3, 0x37, 0xca, 0xb8, 0x2c, 0xa9, 0xc2, 0x86,
+	0x82, 0xed, 0x63, 0x62, 0x52, 0x5d, 0x44, 0x04, 0x71, 0xc2, 0x0a, 0xb8, 0x2c, 0xa8, 0x87, 0x82,
+	0xa8, 0xfd, 0xf0, 0x42, 0xa6, 0x2e, 0x40, 0x16, 0x37, 0x3b, 0xf8, 0xc3, 0x6a, 0x1a, 0x21, 0xa8,
+	0xf0, 0x4f, 0xfd, 0x70, 0x7f, 0xfb, 0xe0, 0xb0, 0xd5, 0x66, 0xa6, 0xbe, 0x04, 0xcb, 0xca, 0xd4,
+	0x8a, 0x98, 0xd5, 0x3e, 0x82, 0xca, 0x78, 0xf5, 0x8c, 0x59, 0xd8, 0x77, 0x87, 0x8e, 0xc5, 0x8d,
+	0x91, 0xc5, 0xa2, 0xc1, 0xae, 0x68, 0x4e, 0x5c, 0xe1, 0x53, 0x66, 0xef, 0xd7, 0xfb, 0x2e, 0x25,
+	0x91, 0xea, 0x9b, 0xe0, 0xd6, 0x1e, 0x41, 0x96, 0xbb, 0x08, 0x76, 0x8a, 0x78, 0x9d, 0x4b, 0x82,
+	0x7d, 0xf6, 0x8d, 0x3e, 0x02, 0x30, 0x14, 0x46, 0x53, 0x8a, 0xd7, 0xe7, 0x60, 0xb9, 0x9d, 0xab,
+	0xd2, 0xd7, 0xac, 0x8e, 0x44, 0x23, 0xfe, 0x26, 0xa2, 0x50, 0xdb, 0x87, 0xca, 0xb8, 0xac, 0x02,
+	0x63, 0x62, 0x0e, 0xe3, 0x60, 0x4c, 0x64, 0x1b, 0xa2, 0x31, 0x82, 0x72, 0x69, 0x51, 0xf3, 0xe4,
+	0x0d, 0xed, 0x47, 0x49, 0x58, 0x9d, 0x05, 0x2c, 0xd9, 0xee, 0x13, 0xa8, 0x34, 0xb2, 0xc2, 0x02,
+	0xa7, 0xb0, 0xb2, 0x9d, 0x1a, 0x35, 0x35, 0x1a, 0xf5, 0x55, 0x69, 0x8c, 0x34, 0xdf, 0x6e, 0xd7,
+	0xe7, 0x2c, 0x39, 0x52, 0xfb, 0xfb, 0x7d, 0x12, 0xf2, 0x9d, 0x53, 0xb9, 0x25, 0x62, 0x2a, 0x7a,
+	0xa3, 0xd9, 0xa7, 0xa2, 0xc5, 0x26, 0x51, 0x22, 0x4c, 0x87, 0x85, 0xc7, 0xb7, 0xc3, 0x4d, 0x9f,
+	0x59, 0xb4, 0x38, 0xa0, 0x2a, 0xb4, 0x72, 0xab, 0xbf, 0x09, 0xb9, 0xbe, 0x41, 0x89, 0x63, 0xaa,
+	0x7b, 0xbb, 0x2b, 0x53, 0x48, 0xe1, 0xb6, 0xbc, 0xd6, 0x14, 0x55, 0xff, 0x2f, 0x18, 0x58, 0x50,
+	0x32, 0xda, 0x1b, 0x50, 0x08, 0xfd, 0x2e, 0xcb, 0xfb, 0x0c, 0xcb, 0xf2, 0x49, 0x10, 0xc8, 0x83,
+	0xad, 0x9a, 0x6c, 0x35, 0x9e, 0xfb, 0xa9, 0xac, 0x86, 0xa5, 0xb1, 0x68, 0x68, 0x16, 0x2c, 0x4f,
+	0xc4, 0x47, 0xf4, 0x06, 0xe4, 0xbc, 0x61, 0x57, 0x57, 0x3f, 0x78, 0xe2, 0xfe, 0x52, 0xe1, 0xe7,
+	0x61, 0xb7, 0x6f, 0x9b, 0x77, 0xc9, 0x99, 0x5a, 0x8b, 0x37, 0xec, 0xde, 0x15, 0xfb, 0x40, 0x8c,
+	0x92, 0x8a, 0x8e, 0x72, 0x02, 0x79, 0xb5, 0xad, 0xd1, 0x5b, 0x50, 0x08, 0xc3, 0x47, 0x78, 0xcd,
+	0x11, 0x1b, 0xb3, 0xa5, 0xfa, 0x91, 0x08, 0x4b, 0x4f, 0x03, 0xfb, 0xd8, 0x21, 0x96, 0x3e, 0xca,
+	0x3c, 0xa5, 0x7b, 0x5d, 0x16, 0x1d, 0x7b, 0x2a, 0xed, 0xd4, 0xfe, 0x91, 0x84, 0xbc, 0x2a, 0x3f,
+	0xa3, 0xff, 0x8d, 0x9c, 0x9c, 0xca, 0x8c, 0xac, 0x4f, 0x31, 0x8e, 0xb6, 0xc9, 0xf8, 0x5c, 0x53,
+	0x17, 0x9f, 0x6b, 0xdc, 0x5d, 0x80, 0xba, 0xe5, 0xc9, 0x5c, 0xf8, 0x96, 0xe7, 0x05, 0x40, 0xd4,
+	0xa5, 0x46, 0x5f, 0x3f, 0x71, 0xa9, 0xed, 0x1c, 0xeb, 0xc2, 0xd8, 0x02, 0xba, 0x55, 0x79, 0xcf,
+	0x7d, 0xde, 0x71, 0xc0, 0xed, 0xfe, 0xe3, 0x24, 0xd4, 0xe2, 0x82, 0x3e, 0x2b, 0x29, 0x5d, 0x34,
+	0x55, 0x95, 0x02, 0x0c, 0x09, 0x18, 0x26, 0xb5, 0x4f, 0xf8, 0x9e, 0x54, 0xa1, 0x5b, 0xfc, 0xf1,
+	0xea, 0xa8, 0x43, 0x86, 0xef, 0xdf, 0x25, 0x21, 0x1f, 0xc6, 0xd7, 0x8b, 0x56, 0x9d, 0x2f, 0xc3,
+	0x92, 0x74, 0xff, 0xa2, 0xec, 0x2c, 0x5b, 0xe1, 0x0d, 0x49, 0x26, 0x72, 0x43, 0x52, 0x87, 0xfc,
+	0x80, 0x50, 0x83, 0x83, 0x0c, 0x51, 0x81, 0x08, 0xdb, 0xec, 0x3e, 0x4e, 0x04, 0x36, 0xc6, 0xc9,
+	0x6b, 0x0e, 0x0c, 0xb6, 0x14, 0x39, 0xad, 0xc5, 0x49, 0x93, 0xa5, 0xe9, 0xdc, 0x54, 0x69, 0xfa,
+	0x97, 0x49, 0x28, 0x45, 0x4b, 0x03, 0x0c, 0xb9, 0x46, 0xf1, 0x07, 0xdb, 0x82, 0x23, 0x02, 0x1b,
+	0x92, 0x57, 0x32, 0x75, 0x9e, 0x61, 0x2a, 0x08, 0x50, 0xe4, 0x34, 0x9e, 0x7e, 0x06, 0x68, 0x0b,
+	0x2e, 0xc9, 0x74, 0xc5, 0x7e, 0x44, 0x2c, 0x7d, 0x40, 0x06, 0x9e, 0xeb, 0xf6, 0xa5, 0xb7, 0x44,
+	0x91, 0xae, 0xf7, 0x44, 0x0f, 0xda, 0x80, 0xd2, 0xc0, 0x38, 0xd5, 0xe9, 0xa9, 0xbc, 0x30, 0x13,
+	0x65, 0x19, 0x18, 0x18, 0xa7, 0x9d, 0x53, 0x7e, 0x5b, 0x76, 0xf3, 0x75, 0x28, 0x46, 0xae, 0x3a,
+	0x98, 0xcf, 0xdc, 0x6f, 0x7e, 0x50, 0x4d, 0xd4, 0x73, 0x9f, 0x7d, 0xb9, 0x91, 0xde, 0x27, 0x9f,
+	0x32, 0x0f, 0x81, 0x9b, 0x8d, 0x56, 0xb3, 0x71, 0xb7, 0x9a, 0xac, 0x17, 0x3f, 0xfb, 0x72, 0x23,
+	0x87, 0x09, 0x2f, 0x76, 0xde, 0x7c, 0x0b, 0xd0, 0xb4, 0xc3, 0x64, 0x31, 0xf2, 0xb0, 0x83, 0x77,
+	0xf7, 0xdf, 0xa9, 0x26, 0x50, 0x0e, 0xd2, 0xbb, 0xfb, 0x32, 0x58, 0xde, 0xd9, 0x6b, 0x6f, 0xb3,
+	0x60, 0x99, 0x87, 0xcc, 0x4e, 0xbb, 0xbd, 0x57, 0x4d, 0xdf, 0x6c, 0x41, 0x29, 0x7a, 0x86, 0xc6,
+	0x43, 0x2d, 0x82, 0xca, 0xed, 0x7b, 0x07, 0x7b, 0xbb, 0x8d, 0xed, 0x4e, 0x53, 0xbf, 0xdf, 0xee,
+	0x34, 0xab, 0x49, 0xf4, 0x24, 0x5c, 0xda, 0xdb, 0x7d, 0xa7, 0xd5, 0xd1, 0x1b, 0x7b, 0xbb, 0xcd,
+	0xfd, 0x8e, 0xbe, 0xdd, 0xe9, 0x6c, 0x37, 0xee, 0x56, 0x53, 0xb7, 0x7e, 0x5d, 0x80, 0xe5, 0xed,
+	0x9d, 0xc6, 0x2e, 0x83, 0x09, 0xb6, 0xc9, 0x37, 0x13, 0x6a, 0x40, 0x86, 0x57, 0xca, 0xce, 0x7d,
+	0xb8, 0x51, 0x3f, 0xbf, 0x8c, 0x8e, 0xee, 0x40, 0x96, 0x17, 0xd1, 0xd0, 0xf9, 0x2f, 0x39, 0xea,
+	0x73, 0xea, 0xea, 0x6c, 0x32, 0xdc, 0x99, 0x9d, 0xfb, 0xb4, 0xa3, 0x7e, 0x7e, 0x99, 0x1d, 0x61,
+	0x28, 0x8c, 0x32, 0xbb, 0xf9, 0x4f, 0x1d, 0xea, 0x0b, 0x44, 0x16, 0xb4, 0x07, 0x39, 0x55, 0x25,
+	0x98, 0xf7, 0xf8, 0xa2, 0x3e, 0xb7, 0x0e, 0xce, 0xcc, 0x25, 0xaa, 0x39, 0xe7, 0xbf, 0x24, 0xa9,
+	0xcf, 0x29, 0xea, 0xa3, 0x5d, 0x58, 0x92, 0x49, 0xc0, 0x9c, 0x07, 0x15, 0xf5, 0x79, 0x75, 0x6d,
+	0x66, 0xb4, 0x51, 0xdd, 0x6d, 0xfe, 0xfb, 0x98, 0xfa, 0x02, 0xf7, 0x15, 0xe8, 0x1e, 0x40, 0xa4,
+	0x76, 0xb3, 0xc0, 0xc3, 0x97, 0xfa, 0x22, 0xf7, 0x10, 0xa8, 0x0d, 0xf9, 0x30, 0x63, 0x9d, 0xfb,
+	0x0c, 0xa5, 0x3e, 0xff, 0x42, 0x00, 0x3d, 0x80, 0xf2, 0x78, 0x02, 0xb4, 0xd8, 0xe3, 0x92, 0xfa,
+	0x82, 0x95, 0x7e, 0xa6, 0x7f, 0x3c, 0x1b, 0x5a, 0xec, 0xb1, 0x49, 0x7d, 0xc1, 0xc2, 0x3f, 0xfa,
+	0x18, 0x56, 0xa6, 0x13, 0x91, 0xc5, 0xdf, 0x9e, 0xd4, 0x2f, 0x70, 0x15, 0x80, 0x06, 0x80, 0x66,
+	0x64, 0x28, 0x17, 0x78, 0x8a, 0x52, 0xbf, 0xc8, 0xcd, 0xc0, 0x4e, 0xf3, 0xab, 0x6f, 0xd7, 0x92,
+	0x5f, 0x7f, 0xbb, 0x96, 0xfc, 0xf3, 0xb7, 0x6b, 0xc9, 0xcf, 0x1f, 0xaf, 0x25, 0xbe, 0x7e, 0xbc,
+	0x96, 0xf8, 0xe3, 0xe3, 0xb5, 0xc4, 0xf7, 0x9e, 0x3f, 0xb6, 0x69, 0x6f, 0xd8, 0xdd, 0x34, 0xdd,
+	0xc1, 0x56, 0xf4, 0x0d, 0xdc, 0xac, 0x77, 0x79, 0xdd, 0x25, 0x0e, 0x01, 0x5e, 0xfa, 0xe7, 0x00,
+	0xc1, 0xf7, 0xd5, 0x5e, 0xb7, 0x27, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4097,6 +4577,16 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppStateSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AppStateOffset != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateOffset))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -4225,6 +4715,13 @@ func (m *RequestBeginBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Randomness) > 0 {
+		i -= len(m.Randomness)
+		copy(dAtA[i:], m.Randomness)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Randomness)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.ByzantineValidators) > 0 {
 		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4470,6 +4967,21 @@ func (m *RequestLoadSnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, error
 	_ = i
 	var l int
 	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Length != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Length))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Offset != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Chunk != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Chunk))
 		i--
@@ -4508,6 +5020,16 @@ func (m *RequestApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.ChunkSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChunkSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Offset != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Sender) > 0 {
 		i -= len(m.Sender)
 		copy(dAtA[i:], m.Sender)
@@ -4980,6 +5502,42 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Capabilities != nil {
+		{
+			size, err := m.Capabilities.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.EventSchema) > 0 {
+		for iNdEx := len(m.EventSchema) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EventSchema[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.HistoricalQueries {
+		i--
+		if m.HistoricalQueries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -5034,6 +5592,20 @@ func (m *ResponseInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.EventSchema) > 0 {
+		for iNdEx := len(m.EventSchema) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EventSchema[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.AppHash) > 0 {
 		i -= len(m.AppHash)
 		copy(dAtA[i:], m.AppHash)
@@ -5212,6 +5784,54 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ExpireTime != nil {
+		n38, err38 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ExpireTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExpireTime):])
+		if err38 != nil {
+			return 0, err38
+		}
+		i -= n38
+		i = encodeVarintTypes(dAtA, i, uint64(n38))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.ExpireHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ExpireHeight))
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.TxClass) > 0 {
+		i -= len(m.TxClass)
+		copy(dAtA[i:], m.TxClass)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TxClass)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.DependsOn) > 0 {
+		for iNdEx := len(m.DependsOn) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DependsOn[iNdEx])
+			copy(dAtA[i:], m.DependsOn[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.DependsOn[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5372,6 +5992,29 @@ func (m *ResponseEndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.JailedValidators) > 0 {
+		for iNdEx := len(m.JailedValidators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.JailedValidators[iNdEx])
+			copy(dAtA[i:], m.JailedValidators[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.JailedValidators[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ScheduledConsensusParamUpdates) > 0 {
+		for iNdEx := len(m.ScheduledConsensusParamUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScheduledConsensusParamUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.Events) > 0 {
 		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -5507,6 +6150,16 @@ func (m *ResponseOfferSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StreamChunks {
+		i--
+		if m.StreamChunks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Result != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
 		i--
@@ -5535,6 +6188,11 @@ func (m *ResponseLoadSnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.ChunkSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChunkSize))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Chunk) > 0 {
 		i -= len(m.Chunk)
 		copy(dAtA[i:], m.Chunk)
@@ -5575,20 +6233,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA39 := make([]byte, len(m.RefetchChunks)*10)
-		var j38 int
+		dAtA40 := make([]byte, len(m.RefetchChunks)*10)
+		var j39 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA40[j39] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j39++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA40[j39] = uint8(num)
+			j39++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintTypes(dAtA, i, uint64(j38))
+		i -= j39
+		copy(dAtA[i:], dAtA40[:j39])
+		i = encodeVarintTypes(dAtA, i, uint64(j39))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5733,6 +6391,48 @@ func (m *EventAttribute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *EventAttributeSchema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventAttributeSchema) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventAttributeSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EventType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TxResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5753,6 +6453,14 @@ func (m *TxResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	n41, err41 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Latency, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Latency):])
+	if err41 != nil {
+		return 0, err41
+	}
+	i -= n41
+	i = encodeVarintTypes(dAtA, i, uint64(n41))
+	i--
+	dAtA[i] = 0x2a
 	{
 		size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -5924,12 +6632,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n43, err43 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err43 != nil {
-		return 0, err43
+	n45, err45 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err45 != nil {
+		return 0, err45
 	}
-	i -= n43
-	i = encodeVarintTypes(dAtA, i, uint64(n43))
+	i -= n45
+	i = encodeVarintTypes(dAtA, i, uint64(n45))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -5955,7 +6663,7 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Snapshot) Marshal() (dAtA []byte, err error) {
+func (m *ScheduledConsensusParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5965,33 +6673,87 @@ func (m *Snapshot) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
+func (m *ScheduledConsensusParams) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ScheduledConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Metadata) > 0 {
-		i -= len(m.Metadata)
-		copy(dAtA[i:], m.Metadata)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Metadata)))
+	if m.ActivationHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ActivationHeight))
 		i--
-		dAtA[i] = 0x2a
+		dAtA[i] = 0x10
 	}
-	if len(m.Hash) > 0 {
-		i -= len(m.Hash)
-		copy(dAtA[i:], m.Hash)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+	if m.Params != nil {
+		{
+			size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0xa
 	}
-	if m.Chunks != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Chunks))
-		i--
+	return len(dAtA) - i, nil
+}
+
+func (m *Snapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.ChunkHashes) > 0 {
+		for iNdEx := len(m.ChunkHashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ChunkHashes[iNdEx])
+			copy(dAtA[i:], m.ChunkHashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ChunkHashes[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Metadata) > 0 {
+		i -= len(m.Metadata)
+		copy(dAtA[i:], m.Metadata)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Metadata)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Chunks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Chunks))
+		i--
 		dAtA[i] = 0x18
 	}
 	if m.Format != 0 {
@@ -6007,6 +6769,64 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Capabilities) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Capabilities) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Capabilities) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxTxBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxTxBytes))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PrioritizedMempool {
+		i--
+		if m.PrioritizedMempool {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.QueryProofs {
+		i--
+		if m.QueryProofs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Snapshots {
+		i--
+		if m.Snapshots {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -6272,6 +7092,12 @@ func (m *RequestInitChain) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if m.AppStateOffset != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateOffset))
+	}
+	if m.AppStateSize != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateSize))
+	}
 	return n
 }
 
@@ -6318,6 +7144,10 @@ func (m *RequestBeginBlock) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.Randomness)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -6412,6 +7242,15 @@ func (m *RequestLoadSnapshotChunk) Size() (n int) {
 	if m.Chunk != 0 {
 		n += 1 + sovTypes(uint64(m.Chunk))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovTypes(uint64(m.Offset))
+	}
+	if m.Length != 0 {
+		n += 1 + sovTypes(uint64(m.Length))
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
 	return n
 }
 
@@ -6432,6 +7271,12 @@ func (m *RequestApplySnapshotChunk) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovTypes(uint64(m.Offset))
+	}
+	if m.ChunkSize != 0 {
+		n += 1 + sovTypes(uint64(m.ChunkSize))
+	}
 	return n
 }
 
@@ -6686,6 +7531,19 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.HistoricalQueries {
+		n += 2
+	}
+	if len(m.EventSchema) > 0 {
+		for _, e := range m.EventSchema {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.Capabilities != nil {
+		l = m.Capabilities.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -6709,6 +7567,12 @@ func (m *ResponseInitChain) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.EventSchema) > 0 {
+		for _, e := range m.EventSchema {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6806,6 +7670,33 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
+	if len(m.DependsOn) > 0 {
+		for _, b := range m.DependsOn {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.TxClass)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ExpireHeight != 0 {
+		n += 1 + sovTypes(uint64(m.ExpireHeight))
+	}
+	if m.ExpireTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExpireTime)
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -6871,6 +7762,18 @@ func (m *ResponseEndBlock) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if len(m.ScheduledConsensusParamUpdates) > 0 {
+		for _, e := range m.ScheduledConsensusParamUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.JailedValidators) > 0 {
+		for _, b := range m.JailedValidators {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6914,6 +7817,9 @@ func (m *ResponseOfferSnapshot) Size() (n int) {
 	if m.Result != 0 {
 		n += 1 + sovTypes(uint64(m.Result))
 	}
+	if m.StreamChunks {
+		n += 2
+	}
 	return n
 }
 
@@ -6927,6 +7833,9 @@ func (m *ResponseLoadSnapshotChunk) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.ChunkSize != 0 {
+		n += 1 + sovTypes(uint64(m.ChunkSize))
+	}
 	return n
 }
 
@@ -7012,6 +7921,26 @@ func (m *EventAttribute) Size() (n int) {
 	return n
 }
 
+func (m *EventAttributeSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	return n
+}
+
 func (m *TxResult) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7030,6 +7959,8 @@ func (m *TxResult) Size() (n int) {
 	}
 	l = m.Result.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Latency)
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -7099,6 +8030,22 @@ func (m *Evidence) Size() (n int) {
 	return n
 }
 
+func (m *ScheduledConsensusParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Params != nil {
+		l = m.Params.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ActivationHeight != 0 {
+		n += 1 + sovTypes(uint64(m.ActivationHeight))
+	}
+	return n
+}
+
 func (m *Snapshot) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7122,6 +8069,36 @@ func (m *Snapshot) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.ChunkHashes) > 0 {
+		for _, b := range m.ChunkHashes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
+	return n
+}
+
+func (m *Capabilities) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Snapshots {
+		n += 2
+	}
+	if m.QueryProofs {
+		n += 2
+	}
+	if m.PrioritizedMempool {
+		n += 2
+	}
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxTxBytes))
+	}
 	return n
 }
 
@@ -8172,6 +9149,44 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateOffset", wireType)
+			}
+			m.AppStateOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateOffset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateSize", wireType)
+			}
+			m.AppStateSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -8511,6 +9526,40 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Randomness", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Randomness = append(m.Randomness[:0], dAtA[iNdEx:postIndex]...)
+			if m.Randomness == nil {
+				m.Randomness = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9094,12 +10143,69 @@ func (m *RequestLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
@@ -9229,6 +10335,44 @@ func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 			}
 			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+			}
+			m.ChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10204,6 +11348,96 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalQueries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HistoricalQueries = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSchema", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventSchema = append(m.EventSchema, EventAttributeSchema{})
+			if err := m.EventSchema[len(m.EventSchema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Capabilities == nil {
+				m.Capabilities = &Capabilities{}
+			}
+			if err := m.Capabilities.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10358,6 +11592,40 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				m.AppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventSchema", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventSchema = append(m.EventSchema, EventAttributeSchema{})
+			if err := m.EventSchema[len(m.EventSchema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11020,61 +12288,250 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
-			m.Code = 0
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependsOn", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DependsOn = append(m.DependsOn, make([]byte, postIndex-iNdEx))
+			copy(m.DependsOn[len(m.DependsOn)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxClass", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxClass = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireHeight", wireType)
+			}
+			m.ExpireHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpireTime == nil {
+				m.ExpireTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.ExpireTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11445,23 +12902,89 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledConsensusParamUpdates", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
+			m.ScheduledConsensusParamUpdates = append(m.ScheduledConsensusParamUpdates, ScheduledConsensusParams{})
+			if err := m.ScheduledConsensusParamUpdates[len(m.ScheduledConsensusParamUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JailedValidators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JailedValidators = append(m.JailedValidators, make([]byte, postIndex-iNdEx))
+			copy(m.JailedValidators[len(m.JailedValidators)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
@@ -11701,6 +13224,26 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamChunks", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamChunks = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11785,6 +13328,25 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 				m.Chunk = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+			}
+			m.ChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12336,6 +13898,139 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *EventAttributeSchema) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventAttributeSchema: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventAttributeSchema: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EventAttributeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *TxResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -12470,6 +14165,39 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latency", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Latency, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12534,31 +14262,409 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VoteInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SignedLastBlock = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Evidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EvidenceType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
-			if m.Address == nil {
-				m.Address = []byte{}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
 			}
-			m.Power = 0
+			m.TotalVotingPower = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12568,7 +14674,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
+				m.TotalVotingPower |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12594,7 +14700,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+func (m *ScheduledConsensusParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12617,15 +14723,15 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScheduledConsensusParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScheduledConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12652,15 +14758,18 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Params == nil {
+				m.Params = &types1.ConsensusParams{}
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationHeight", wireType)
 			}
-			m.Power = 0
+			m.ActivationHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12670,7 +14779,7 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
+				m.ActivationHeight |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12696,7 +14805,7 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteInfo) Unmarshal(dAtA []byte) error {
+func (m *Snapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12719,17 +14828,17 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12739,30 +14848,16 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
 			}
-			var v int
+			m.Format = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12772,67 +14867,16 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Format |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SignedLastBlock = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Evidence) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
 			}
-			m.Type = 0
+			m.Chunks = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12842,16 +14886,16 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= EvidenceType(b&0x7F) << shift
+				m.Chunks |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12861,30 +14905,31 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			m.Height = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12894,16 +14939,31 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkHashes", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12913,30 +14973,29 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ChunkHashes = append(m.ChunkHashes, make([]byte, postIndex-iNdEx))
+			copy(m.ChunkHashes[len(m.ChunkHashes)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
 			}
-			m.TotalVotingPower = 0
+			m.BaseHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12946,7 +15005,7 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalVotingPower |= int64(b&0x7F) << shift
+				m.BaseHeight |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12972,7 +15031,7 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Snapshot) Unmarshal(dAtA []byte) error {
+func (m *Capabilities) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12995,17 +15054,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
+			return fmt.Errorf("proto: Capabilities: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Capabilities: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
-			m.Height = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13015,16 +15074,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Snapshots = bool(v != 0)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryProofs", wireType)
 			}
-			m.Format = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13034,16 +15094,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Format |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.QueryProofs = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrioritizedMempool", wireType)
 			}
-			m.Chunks = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13053,50 +15114,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Chunks |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.PrioritizedMempool = bool(v != 0)
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
 			}
-			var byteLen int
+			m.MaxTxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13106,26 +15134,11 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.MaxTxBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
-			if m.Metadata == nil {
-				m.Metadata = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -163,6 +164,41 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	}
 }
 
+// DropSlowestPeers removes the slower half of the peers, but at least one, and
+// reports them as errored, so that they are disconnected to make room for new
+// peers. Peers are ranked by their current receive rate; peers which haven't
+// been sent requests, e.g. because they're behind, rank slowest. It returns the
+// IDs of the removed peers.
+func (pool *BlockPool) DropSlowestPeers() []p2p.NodeID {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if len(pool.peers) == 0 {
+		return nil
+	}
+
+	peers := make([]*bpPeer, 0, len(pool.peers))
+	for _, peer := range pool.peers {
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].curRate() < peers[j].curRate()
+	})
+
+	numDrop := len(peers) / 2
+	if numDrop == 0 {
+		numDrop = 1
+	}
+
+	dropped := make([]p2p.NodeID, 0, numDrop)
+	for _, peer := range peers[:numDrop] {
+		pool.sendError(errors.New("fast sync stalled and the peer is among the slowest"), peer.id)
+		pool.removePeer(peer.id)
+		dropped = append(dropped, peer.id)
+	}
+	return dropped
+}
+
 // GetStatus returns pool's height, numPending requests and the number of
 // requesters.
 func (pool *BlockPool) GetStatus() (height int64, numPending int32, lenRequesters int) {
@@ -467,6 +503,15 @@ func (peer *bpPeer) resetMonitor() {
 	peer.recvMonitor.SetREMA(initialValue)
 }
 
+// curRate returns the current receive rate of the peer, or 0 if it was never
+// sent requests.
+func (peer *bpPeer) curRate() int64 {
+	if peer.recvMonitor == nil {
+		return 0
+	}
+	return peer.recvMonitor.Status().CurRate
+}
+
 func (peer *bpPeer) resetTimeout() {
 	if peer.timeout == nil {
 		peer.timeout = time.AfterFunc(peerTimeout, peer.onTimeout)
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	flow "github.com/tendermint/tendermint/internal/libs/flowrate"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p"
@@ -241,3 +242,45 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func TestBlockPoolDropSlowestPeers(t *testing.T) {
+	requestsCh := make(chan BlockRequest, 10)
+	errorsCh := make(chan peerError, 10)
+
+	// the peers are behind, so the pool doesn't request blocks from them
+	pool := NewBlockPool(100, requestsCh, errorsCh)
+	pool.SetLogger(log.TestingLogger())
+	require.NoError(t, pool.Start())
+	t.Cleanup(func() {
+		if err := pool.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	assert.Empty(t, pool.DropSlowestPeers())
+
+	rates := map[p2p.NodeID]float64{"fast": 3000, "medium": 2000, "slow": 1000, "idle": 0}
+	for peerID, rate := range rates {
+		pool.SetPeerRange(peerID, 1, 50)
+		if rate > 0 {
+			pool.mtx.Lock()
+			pool.peers[peerID].recvMonitor = flow.New(20*time.Millisecond, 40*time.Second)
+			pool.peers[peerID].recvMonitor.SetREMA(rate)
+			pool.mtx.Unlock()
+		}
+	}
+	// let the monitors take a sample, they report no rate before
+	time.Sleep(100 * time.Millisecond)
+
+	dropped := pool.DropSlowestPeers()
+	assert.ElementsMatch(t, []p2p.NodeID{"idle", "slow"}, dropped)
+	for range dropped {
+		pErr := <-errorsCh
+		assert.Contains(t, dropped, pErr.peerID)
+	}
+
+	// a single peer is dropped too
+	assert.Equal(t, []p2p.NodeID{"medium"}, pool.DropSlowestPeers())
+	assert.Equal(t, []p2p.NodeID{"fast"}, pool.DropSlowestPeers())
+	assert.EqualValues(t, 0, pool.MaxPeerHeight())
+}
@@ -63,6 +63,36 @@ type consensusReactor interface {
 	SwitchToConsensus(state sm.State, skipWAL bool)
 }
 
+// PeerHeightSetter records the heights reported by peers, so that other
+// reactors can tell how far along peers are, e.g. the mempool reactor to not
+// gossip txs to peers which are still block syncing. It is implemented by
+// p2p.PeerManager.
+type PeerHeightSetter interface {
+	SetHeight(p2p.NodeID, int64) error
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// PeerRequester requests the addresses of more peers, e.g. from PEX. It is
+// implemented by the PEX reactors.
+type PeerRequester interface {
+	RequestPeers()
+}
+
+// ReactorStallTimeout sets how long fast sync may make no progress before the
+// reactor drops the slowest peers, requests new ones and publishes a
+// FastSyncStall event. 0 disables the stall detection.
+func ReactorStallTimeout(timeout time.Duration) ReactorOption {
+	return func(r *Reactor) { r.stallTimeout = timeout }
+}
+
+// ReactorPeerHeights sets where the reactor records the heights peers report in
+// their StatusResponse messages.
+func ReactorPeerHeights(peerHeights PeerHeightSetter) ReactorOption {
+	return func(r *Reactor) { r.peerHeights = peerHeights }
+}
+
 type peerError struct {
 	err    error
 	peerID p2p.NodeID
@@ -87,8 +117,13 @@ type Reactor struct {
 
 	blockchainCh *p2p.Channel
 	peerUpdates  *p2p.PeerUpdates
+	peerHeights  PeerHeightSetter
 	closeCh      chan struct{}
 
+	stallTimeout  time.Duration
+	eventBus      types.FastSyncEventPublisher
+	peerRequester PeerRequester
+
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 
@@ -108,6 +143,7 @@ func NewReactor(
 	blockchainCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
 	fastSync bool,
+	options ...ReactorOption,
 ) (*Reactor, error) {
 	if state.LastBlockHeight != store.Height() {
 		return nil, fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight, store.Height())
@@ -133,12 +169,27 @@ func NewReactor(
 		blockchainCh: blockchainCh,
 		peerUpdates:  peerUpdates,
 		closeCh:      make(chan struct{}),
+		eventBus:     types.NopEventBus{},
+	}
+	for _, option := range options {
+		option(r)
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "Blockchain", r)
 	return r, nil
 }
 
+// SetEventBus sets the event bus the FastSyncStall events are published on.
+func (r *Reactor) SetEventBus(b types.FastSyncEventPublisher) {
+	r.eventBus = b
+}
+
+// SetPeerRequester sets where new peers are requested from when fast sync
+// stalls. It must be called before the reactor is started.
+func (r *Reactor) SetPeerRequester(peerRequester PeerRequester) {
+	r.peerRequester = peerRequester
+}
+
 // OnStart starts separate go routines for each p2p Channel and listens for
 // envelopes on each. In addition, it also listens for peer updates and handles
 // messages on that p2p channel accordingly. The caller must be sure to execute
@@ -241,6 +292,11 @@ func (r *Reactor) handleBlockchainMessage(envelope p2p.Envelope) error {
 
 	case *bcproto.StatusResponse:
 		r.pool.SetPeerRange(envelope.From, msg.Base, msg.Height)
+		if r.peerHeights != nil {
+			if err := r.peerHeights.SetHeight(envelope.From, msg.Height); err != nil {
+				logger.Error("failed to set peer height", "err", err)
+			}
+		}
 
 	case *bcproto.NoBlockResponse:
 		logger.Debug("peer does not have the requested block", "height", msg.Height)
@@ -404,6 +460,38 @@ func (r *Reactor) requestRoutine() {
 	}
 }
 
+// handleStall drops the slowest peers, requests new ones and publishes a
+// FastSyncStall event, since fast sync made no progress since lastAdvance.
+func (r *Reactor) handleStall(height int64, lastAdvance time.Time) {
+	dropped := r.pool.DropSlowestPeers()
+	droppedPeers := make([]string, 0, len(dropped))
+	for _, peerID := range dropped {
+		droppedPeers = append(droppedPeers, string(peerID))
+	}
+
+	maxPeerHeight := r.pool.MaxPeerHeight()
+	r.Logger.Error(
+		"fast sync stalled; dropped the slowest peers",
+		"height", height,
+		"max_peer_height", maxPeerHeight,
+		"last_advance", lastAdvance,
+		"dropped_peers", droppedPeers,
+	)
+
+	if r.peerRequester != nil {
+		r.peerRequester.RequestPeers()
+	}
+
+	if err := r.eventBus.PublishEventFastSyncStall(types.EventDataFastSyncStall{
+		Height:        height,
+		MaxPeerHeight: maxPeerHeight,
+		LastAdvance:   lastAdvance,
+		DroppedPeers:  droppedPeers,
+	}); err != nil {
+		r.Logger.Error("failed to publish fast sync stall event", "err", err)
+	}
+}
+
 // poolRoutine handles messages from the poolReactor telling the reactor what to
 // do.
 //
@@ -420,6 +508,7 @@ func (r *Reactor) poolRoutine(stateSynced bool) {
 
 		lastHundred = time.Now()
 		lastRate    = 0.0
+		lastStall   time.Time
 
 		didProcessCh = make(chan struct{}, 1)
 	)
@@ -461,6 +550,11 @@ FOR_LOOP:
 					"max_peer_height", r.pool.MaxPeerHeight(),
 					"timeout_in", syncTimeout-time.Since(lastAdvance),
 				)
+				if r.stallTimeout > 0 && time.Since(lastAdvance) > r.stallTimeout &&
+					time.Since(lastStall) > r.stallTimeout {
+					r.handleStall(height, lastAdvance)
+					lastStall = time.Now()
+				}
 				continue
 			}
 
@@ -501,7 +595,7 @@ FOR_LOOP:
 			}
 
 			var (
-				firstParts         = first.MakePartSet(types.BlockPartSizeBytes)
+				firstParts         = first.MakePartSet(state.ConsensusParams.Block.PartSize())
 				firstPartSetHeader = firstParts.Header()
 				firstID            = types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
 			)
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/p2p/pex"
+)
+
+// AddrBookCmd groups the commands which export and import address books, e.g.
+// to seed new nodes with the known-good peers of an existing one.
+var AddrBookCmd = &cobra.Command{
+	Use:   "addrbook",
+	Short: "Export, import and merge address books",
+	Long: `Export, import and merge address books.
+
+Address books can be exported from the node's address book or from one of its
+backups in the addrbook-backups directory, and imported or merged into the
+node's address book. The node must be stopped when importing or merging, since
+it overwrites the address book on exit.
+
+The addresses can be filtered by score and by the last time the node connected
+to them. Addresses the node connected to, and which haven't failed since, score
+100, addresses it hasn't connected to yet score 50, and each failed attempt
+costs 10.
+`,
+}
+
+var addrBookExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the addresses of the address book, or of the given address book or backup",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  exportAddrBook,
+}
+
+var addrBookImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Replace the address book with the addresses of the given file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importAddrBook(args[0], true)
+	},
+}
+
+var addrBookMergeCmd = &cobra.Command{
+	Use:   "merge <file>",
+	Short: "Add the addresses of the given file to the address book",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importAddrBook(args[0], false)
+	},
+}
+
+var (
+	addrBookMinScore   int
+	addrBookSeenWithin time.Duration
+	addrBookOutput     string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{addrBookExportCmd, addrBookImportCmd, addrBookMergeCmd} {
+		cmd.Flags().IntVar(&addrBookMinScore, "min-score", 0,
+			"only select addresses with at least this score, from 0 to 100")
+		cmd.Flags().DurationVar(&addrBookSeenWithin, "seen-within", 0,
+			"only select addresses the node connected to within this duration, e.g. 72h")
+	}
+	addrBookExportCmd.Flags().StringVarP(&addrBookOutput, "output", "o", "",
+		"file to write the addresses to, instead of stdout")
+
+	AddrBookCmd.AddCommand(addrBookExportCmd, addrBookImportCmd, addrBookMergeCmd)
+}
+
+func addrBookFilter() pex.AddrBookFilter {
+	return pex.AddrBookFilter{
+		MinScore:   addrBookMinScore,
+		SeenWithin: addrBookSeenWithin,
+	}
+}
+
+func exportAddrBook(cmd *cobra.Command, args []string) error {
+	filePath := config.P2P.AddrBookFile()
+	if len(args) > 0 {
+		filePath = args[0]
+	}
+
+	var w io.Writer = os.Stdout
+	if addrBookOutput != "" {
+		f, err := os.Create(addrBookOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := pex.ExportAddrBook(filePath, addrBookFilter(), w)
+	if err != nil {
+		return fmt.Errorf("failed to export address book %s: %w", filePath, err)
+	}
+	// The addresses may be written to stdout, so don't log there.
+	fmt.Fprintf(os.Stderr, "Exported %d addresses from %s\n", n, filePath)
+	return nil
+}
+
+func importAddrBook(srcPath string, replace bool) error {
+	filePath := config.P2P.AddrBookFile()
+	n, err := pex.ImportAddrBook(filePath, srcPath, addrBookFilter(), config.P2P.AddrBookStrict, replace)
+	if err != nil {
+		return fmt.Errorf("failed to import %s into address book %s: %w", srcPath, filePath, err)
+	}
+	logger.Info("Imported addresses", "count", n, "from", srcPath, "addrBook", filePath, "replaced", replace)
+	return nil
+}
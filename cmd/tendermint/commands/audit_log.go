@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// AuditLogCmd groups the commands which inspect the audit log of the votes
+// and proposals the validator signed.
+var AuditLogCmd = &cobra.Command{
+	Use:   "audit-log",
+	Short: "Show and verify the audit log of the signed votes and proposals",
+	Long: `Show and verify the audit log of the signed votes and proposals.
+
+The validator records every vote and proposal it signs in the audit log set by
+priv-validator-audit-log-file, along with the sign bytes and the signature.
+Each entry contains the hash of the previous one, so that removing or altering
+entries breaks the chain of hashes, which verify checks.
+`,
+}
+
+var auditLogShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the entries of the audit log",
+	RunE:  showAuditLog,
+}
+
+var auditLogVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the chain of hashes of the audit log, and the signatures",
+	Long: `Verify the chain of hashes of the audit log, and the signatures.
+
+The signatures are verified with the public key given by --pub-key, as printed
+by show-validator, or else with the key of priv-validator-key-file if it
+exists. They aren't verified if there is neither.
+`,
+	RunE: verifyAuditLog,
+}
+
+var (
+	auditLogFile   string
+	auditLogHeight int64
+	auditLogPubKey string
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{auditLogShowCmd, auditLogVerifyCmd} {
+		cmd.Flags().StringVar(&auditLogFile, "file", "",
+			"audit log to read, instead of priv-validator-audit-log-file")
+	}
+	auditLogShowCmd.Flags().Int64Var(&auditLogHeight, "height", 0,
+		"only show the entries of this height")
+	auditLogVerifyCmd.Flags().StringVar(&auditLogPubKey, "pub-key", "",
+		"public key to verify the signatures with, as JSON")
+
+	AuditLogCmd.AddCommand(auditLogShowCmd, auditLogVerifyCmd)
+}
+
+func auditLogPath() (string, error) {
+	if auditLogFile != "" {
+		return auditLogFile, nil
+	}
+	if path := config.PrivValidatorAuditLogFile(); path != "" {
+		return path, nil
+	}
+	return "", errors.New("priv-validator-audit-log-file isn't set, pass --file")
+}
+
+func showAuditLog(cmd *cobra.Command, args []string) error {
+	path, err := auditLogPath()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	return privval.ReadAuditLog(path, func(e privval.AuditEntry) error {
+		if auditLogHeight > 0 && e.Height != auditLogHeight {
+			return nil
+		}
+		block := e.BlockHash.String()
+		if block == "" {
+			block = "nil"
+		}
+		_, err := fmt.Fprintf(out, "#%d %s %-9s height=%d round=%d block=%s timestamp=%s signature=%X\n",
+			e.Index, e.Time.Format(time.RFC3339Nano), e.Type, e.Height, e.Round, block,
+			e.Timestamp.Format(time.RFC3339Nano), e.Signature)
+		return err
+	})
+}
+
+func verifyAuditLog(cmd *cobra.Command, args []string) error {
+	path, err := auditLogPath()
+	if err != nil {
+		return err
+	}
+
+	var pubKey crypto.PubKey
+	switch {
+	case auditLogPubKey != "":
+		if err := tmjson.Unmarshal([]byte(auditLogPubKey), &pubKey); err != nil {
+			return fmt.Errorf("invalid --pub-key: %w", err)
+		}
+	case tmos.FileExists(config.PrivValidatorKeyFile()):
+		pv, err := privval.LoadFilePVEmptyState(config.PrivValidatorKeyFile(), "")
+		if err != nil {
+			return err
+		}
+		pubKey = pv.Key.PubKey
+	}
+
+	verifier := privval.NewAuditLogVerifier(pubKey)
+	entries := 0
+	if err := privval.ReadAuditLog(path, func(e privval.AuditEntry) error {
+		entries++
+		return verifier.Verify(e)
+	}); err != nil {
+		return err
+	}
+
+	if pubKey == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Verified the chain of %d entries; no public key to verify the signatures\n",
+			entries)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Verified the chain and the signatures of %d entries\n", entries)
+	}
+	return nil
+}
@@ -18,9 +18,9 @@ import (
 
 // InitFilesCmd initializes a fresh Tendermint Core instance.
 var InitFilesCmd = &cobra.Command{
-	Use:       "init [full|validator|seed]",
+	Use:       "init [full|validator|seed|header]",
 	Short:     "Initializes a Tendermint node",
-	ValidArgs: []string{"full", "validator", "seed"},
+	ValidArgs: []string{"full", "validator", "seed", "header"},
 	// We allow for zero args so we can throw a more informative error
 	Args: cobra.MaximumNArgs(1),
 	RunE: initFiles,
@@ -37,7 +37,7 @@ func init() {
 
 func initFiles(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return errors.New("must specify a node type: tendermint init [validator|full|seed]")
+		return errors.New("must specify a node type: tendermint init [validator|full|seed|header]")
 	}
 	config.Mode = args[0]
 	return initFilesWithConfig(config)
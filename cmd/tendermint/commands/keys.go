@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// The derivation paths of the keys follow BIP44 with the coin type of the
+// Cosmos ecosystem (118). Validator keys use account 0 and node keys account 1,
+// so that both can be derived from the same mnemonic. All levels are hardened,
+// since SLIP-0010 only supports hardened derivation for ed25519.
+const (
+	validatorKeyPathFormat = "m/44'/118'/0'/0'/%d'"
+	nodeKeyPathFormat      = "m/44'/118'/1'/0'/%d'"
+)
+
+// KeysCmd groups the commands which derive the node and validator keys from a
+// BIP39 mnemonic.
+var KeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Generate mnemonics and derive the node and validator keys from them",
+	Long: `Generate mnemonics and derive the node and validator keys from them.
+
+The node and validator keys can be derived deterministically from a BIP39
+mnemonic, so that they can be recovered from the mnemonic rather than from
+backups of the key files. The keys are ed25519 keys derived as defined by
+SLIP-0010, along the paths
+
+  validator key: m/44'/118'/0'/0'/<index>'
+  node key:      m/44'/118'/1'/0'/<index>'
+`,
+}
+
+var keysMnemonicCmd = &cobra.Command{
+	Use:   "mnemonic",
+	Short: "Generate a new 24 word mnemonic",
+	Args:  cobra.NoArgs,
+	RunE:  newMnemonic,
+}
+
+var keysDeriveCmd = &cobra.Command{
+	Use:   "derive",
+	Short: "Derive the node and validator keys from a mnemonic",
+	Long: `Derive the node and validator keys from a mnemonic.
+
+The keys are written to the node key and validator key files. Existing key
+files are only replaced with --force. The last sign state of the validator is
+kept if its file exists; otherwise a new one is created, so make sure the
+validator doesn't sign at heights it signed at before.
+
+With --mnemonic and no value, the mnemonic is read from stdin, which keeps it
+out of the shell history.
+`,
+	Args: cobra.NoArgs,
+	RunE: deriveKeys,
+}
+
+var (
+	keysMnemonic   string
+	keysPassphrase string
+	keysIndex      uint32
+	keysForce      bool
+)
+
+func init() {
+	keysDeriveCmd.Flags().StringVar(&keysMnemonic, "mnemonic", "",
+		"BIP39 mnemonic to derive the keys from, read from stdin if no value is given")
+	keysDeriveCmd.Flags().Lookup("mnemonic").NoOptDefVal = "-"
+	keysDeriveCmd.Flags().StringVar(&keysPassphrase, "passphrase", "",
+		"optional BIP39 passphrase of the mnemonic")
+	keysDeriveCmd.Flags().Uint32Var(&keysIndex, "index", 0,
+		"index of the keys to derive, the last level of the derivation paths")
+	keysDeriveCmd.Flags().BoolVar(&keysForce, "force", false,
+		"replace existing key files")
+
+	KeysCmd.AddCommand(keysMnemonicCmd, keysDeriveCmd)
+}
+
+func newMnemonic(cmd *cobra.Command, args []string) error {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+	fmt.Println(mnemonic)
+	return nil
+}
+
+func deriveKeys(cmd *cobra.Command, args []string) error {
+	mnemonic := keysMnemonic
+	switch mnemonic {
+	case "":
+		return errors.New("--mnemonic is required")
+	case "-":
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read mnemonic from stdin: %w", err)
+		}
+		mnemonic = line
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, keysPassphrase)
+	if err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	nodeKeyFile, pvKeyFile, pvStateFile := config.NodeKeyFile(), config.PrivValidatorKeyFile(),
+		config.PrivValidatorStateFile()
+	if !keysForce {
+		for _, file := range []string{nodeKeyFile, pvKeyFile} {
+			if tmos.FileExists(file) {
+				return fmt.Errorf("key file %s already exists, use --force to replace it", file)
+			}
+		}
+	}
+
+	nodePrivKey, err := ed25519.DerivePrivKey(seed, fmt.Sprintf(nodeKeyPathFormat, keysIndex))
+	if err != nil {
+		return err
+	}
+	nodeKey := p2p.NodeKey{
+		ID:      p2p.NodeIDFromPubKey(nodePrivKey.PubKey()),
+		PrivKey: nodePrivKey,
+	}
+	if err := nodeKey.SaveAs(nodeKeyFile); err != nil {
+		return fmt.Errorf("failed to save node key %s: %w", nodeKeyFile, err)
+	}
+
+	pvPrivKey, err := ed25519.DerivePrivKey(seed, fmt.Sprintf(validatorKeyPathFormat, keysIndex))
+	if err != nil {
+		return err
+	}
+	pv := privval.NewFilePV(pvPrivKey, pvKeyFile, pvStateFile)
+	if tmos.FileExists(pvStateFile) {
+		pv.Key.Save()
+	} else {
+		pv.Save()
+	}
+
+	logger.Info("Derived keys from mnemonic", "node_id", nodeKey.ID, "validator_address", pv.GetAddress(),
+		"node_key_file", nodeKeyFile, "validator_key_file", pvKeyFile)
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/node"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+// MigrateStateStoreCmd migrates all the records of the state store to the
+// current schema.
+var MigrateStateStoreCmd = &cobra.Command{
+	Use:   "migrate-state-store",
+	Short: "Migrate all the records of the state store to the current schema",
+	Long: fmt.Sprintf(`Migrate all the records of the state store to the current schema (%d).
+
+Records written with an older schema are read as they are, and migrated in the
+background once the node started, so the node doesn't have to migrate the whole
+store at startup. This command migrates all the records at once, e.g.
+ahead of an upgrade which drops support for an older schema. The node must be
+stopped.
+`, sm.StateSchemaVersion),
+	RunE: migrateStateStore,
+}
+
+func migrateStateStore(cmd *cobra.Command, args []string) error {
+	db, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %w", err)
+	}
+	defer db.Close()
+
+	stateStore := sm.NewStore(db)
+	stored, err := stateStore.LoadStoreVersion()
+	if err != nil {
+		return fmt.Errorf("failed to load the state store version: %w", err)
+	}
+	current := sm.CurrentStoreVersion()
+	if err := sm.CheckStoreVersion(stored, current); err != nil {
+		return err
+	}
+
+	// saving the state again migrates it
+	state, err := stateStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if !state.IsEmpty() {
+		if err := stateStore.Save(state); err != nil {
+			return fmt.Errorf("failed to migrate state: %w", err)
+		}
+	}
+	migrated, err := stateStore.MigrateSchema(context.Background(), math.MaxInt64)
+	if err != nil {
+		return fmt.Errorf("failed to migrate state store: %w", err)
+	}
+	if err := stateStore.SaveStoreVersion(current); err != nil {
+		return fmt.Errorf("failed to save the state store version: %w", err)
+	}
+
+	logger.Info("Migrated state store", "migrated", migrated, "schema_version", sm.StateSchemaVersion)
+	return nil
+}
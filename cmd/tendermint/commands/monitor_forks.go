@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	httpp "github.com/tendermint/tendermint/light/provider/http"
+)
+
+// MonitorForksCmd compares the headers of a node with the ones of a set of
+// witnesses and reports evidence if they conflict.
+var MonitorForksCmd = &cobra.Command{
+	Use:   "monitor-forks [chainID]",
+	Short: "Monitor a set of nodes for conflicting headers",
+	Long: `Monitor a set of nodes for conflicting headers.
+
+Periodically fetches the latest header of the primary node, by default the
+local node, and compares it with the headers of the witnesses at the same
+height.
+
+When a witness serves a conflicting header signed by at least 1/3 of the
+validators, the fork is logged and evidence of the attack is submitted to the
+primary and all other witnesses. The primary is trusted: conflicting headers
+which are not signed by enough validators are logged as coming from a faulty
+witness.
+`,
+	RunE:    runMonitorForks,
+	Args:    cobra.ExactArgs(1),
+	Example: `monitor-forks cosmoshub-3 -w http://public-seed-node.cosmoshub.certus.one:26657,http://52.57.29.196:26657`,
+}
+
+var (
+	monitorPrimaryAddr  string
+	monitorWitnessAddrs string
+	monitorInterval     time.Duration
+	monitorVerbose      bool
+)
+
+func init() {
+	MonitorForksCmd.Flags().StringVarP(&monitorPrimaryAddr, "primary", "p", "tcp://localhost:26657",
+		"trusted Tendermint node to compare the witnesses with")
+	MonitorForksCmd.Flags().StringVarP(&monitorWitnessAddrs, "witnesses", "w", "",
+		"tendermint nodes to compare with the primary node, comma-separated")
+	MonitorForksCmd.Flags().DurationVar(&monitorInterval, "interval", 5*time.Second,
+		"how often to compare the headers")
+	MonitorForksCmd.Flags().BoolVar(&monitorVerbose, "verbose", false, "Verbose output")
+}
+
+func runMonitorForks(cmd *cobra.Command, args []string) error {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	var option log.Option
+	if monitorVerbose {
+		option, _ = log.AllowLevel("debug")
+	} else {
+		option, _ = log.AllowLevel("info")
+	}
+	logger = log.NewFilter(logger, option)
+
+	chainID := args[0]
+	if monitorWitnessAddrs == "" {
+		return errors.New("no witnesses were provided. Please provide at least one witness (using -w)")
+	}
+
+	primary, err := httpp.New(chainID, monitorPrimaryAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create primary provider: %w", err)
+	}
+	witnesses := []provider.Provider{}
+	for _, addr := range strings.Split(monitorWitnessAddrs, ",") {
+		witness, err := httpp.New(chainID, addr)
+		if err != nil {
+			return fmt.Errorf("failed to create witness provider %s: %w", addr, err)
+		}
+		witnesses = append(witnesses, witness)
+	}
+
+	m := light.NewForkMonitor(chainID, primary, witnesses, light.MonitorInterval(monitorInterval))
+	m.SetLogger(logger)
+
+	logger.Info("Monitoring for forks...", "chainID", chainID, "primary", primary, "witnesses", len(witnesses))
+	if err := m.Start(); err != nil {
+		return err
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, func() {
+		if err := m.Stop(); err != nil {
+			logger.Error("Error stopping fork monitor", "err", err)
+		}
+	})
+
+	// Run forever.
+	select {}
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// Output formats of the commands which print information, chosen with the
+// --output flag. The text format is meant for humans; the json format has a
+// stable schema per command, meant for automation.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+var outputFormat = OutputText
+
+func init() {
+	for _, cmd := range []*cobra.Command{ShowNodeIDCmd, ShowValidatorCmd, VersionCmd, ProbeUpnpCmd} {
+		cmd.Flags().StringVar(&outputFormat, "output", OutputText, "output format (text|json)")
+	}
+}
+
+func validateOutputFormat() error {
+	switch outputFormat {
+	case OutputText, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, must be %s or %s", outputFormat, OutputText, OutputJSON)
+	}
+}
+
+// printOutput prints text in the text format, and v encoded as JSON in the
+// json format.
+func printOutput(text string, v interface{}) error {
+	if err := validateOutputFormat(); err != nil {
+		return err
+	}
+	if outputFormat == OutputText {
+		fmt.Println(text)
+		return nil
+	}
+
+	bz, err := tmjson.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	fmt.Println(string(bz))
+	return nil
+}
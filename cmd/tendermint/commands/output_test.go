@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureOutput returns what fn prints to the standard output.
+func captureOutput(t *testing.T, fn func() error) string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	require.NoError(t, fn())
+	require.NoError(t, w.Close())
+	bz, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	return string(bz)
+}
+
+func TestPrintOutput(t *testing.T) {
+	t.Cleanup(func() { outputFormat = OutputText })
+	output := nodeIDOutput{NodeID: "7b362d49908aae962fc65f97cd52bc653906d580"}
+
+	outputFormat = OutputText
+	assert.Equal(t, "7b362d49908aae962fc65f97cd52bc653906d580\n", captureOutput(t, func() error {
+		return printOutput(string(output.NodeID), output)
+	}))
+
+	outputFormat = OutputJSON
+	assert.Equal(t, `{"node_id":"7b362d49908aae962fc65f97cd52bc653906d580"}`+"\n", captureOutput(t, func() error {
+		return printOutput(string(output.NodeID), output)
+	}))
+
+	outputFormat = "yaml"
+	assert.Error(t, printOutput(string(output.NodeID), output))
+}
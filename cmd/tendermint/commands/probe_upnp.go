@@ -21,14 +21,21 @@ var ProbeUpnpCmd = &cobra.Command{
 func probeUpnp(cmd *cobra.Command, args []string) error {
 	capabilities, err := upnp.Probe(logger)
 	if err != nil {
-		fmt.Println("Probe failed: ", err)
-	} else {
-		fmt.Println("Probe success!")
-		jsonBytes, err := tmjson.Marshal(capabilities)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonBytes))
+		return printOutput(fmt.Sprint("Probe failed: ", err), probeUpnpOutput{Error: err.Error()})
 	}
-	return nil
+	jsonBytes, err := tmjson.Marshal(capabilities)
+	if err != nil {
+		return err
+	}
+	return printOutput("Probe success!\n"+string(jsonBytes), probeUpnpOutput{
+		Success:      true,
+		Capabilities: &capabilities,
+	})
+}
+
+// probeUpnpOutput is the JSON output of probe-upnp.
+type probeUpnpOutput struct {
+	Success      bool               `json:"success"`
+	Error        string             `json:"error,omitempty"`
+	Capabilities *upnp.Capabilities `json:"capabilities,omitempty"`
 }
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/store"
+)
+
+// ReindexBlockStoreCmd rebuilds the block and tx hash indexes of the block
+// store.
+var ReindexBlockStoreCmd = &cobra.Command{
+	Use:   "reindex-block-store",
+	Short: "Rebuild the block and tx hash indexes of the block store",
+	Long: `Rebuild the block and tx hash indexes of the block store.
+
+The block store indexes the stored blocks by hash, and the stored txs by hash,
+so that the /block_by_hash and /tx routes are served without a tx indexer.
+Blocks stored by an earlier version are not indexed; this command indexes all
+the stored blocks. The node must be stopped.
+`,
+	RunE: reindexBlockStore,
+}
+
+func reindexBlockStore(cmd *cobra.Command, args []string) error {
+	db, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return fmt.Errorf("failed to open block store: %w", err)
+	}
+	defer db.Close()
+
+	blockStore := store.NewBlockStore(db)
+	indexed, err := blockStore.ReindexBlocks()
+	if err != nil {
+		return fmt.Errorf("failed to reindex block store: %w", err)
+	}
+
+	logger.Info("Reindexed block store", "blocks", indexed,
+		"base", blockStore.Base(), "height", blockStore.Height())
+	return nil
+}
@@ -30,7 +30,8 @@ func registerFlagsRootCmd(cmd *cobra.Command) {
 }
 
 // ParseConfig retrieves the default environment configuration,
-// sets up the Tendermint root and ensures that the root exists
+// sets up the Tendermint root and ensures that the root exists, unless an
+// ephemeral devnet is started, which mustn't touch the disk
 func ParseConfig() (*cfg.Config, error) {
 	conf := cfg.DefaultConfig()
 	err := viper.Unmarshal(conf)
@@ -38,7 +39,9 @@ func ParseConfig() (*cfg.Config, error) {
 		return nil, err
 	}
 	conf.SetRoot(conf.RootDir)
-	cfg.EnsureRoot(conf.RootDir)
+	if !devEphemeral {
+		cfg.EnsureRoot(conf.RootDir)
+	}
 	if err := conf.ValidateBasic(); err != nil {
 		return nil, fmt.Errorf("error in config file: %v", err)
 	}
@@ -50,6 +53,9 @@ var RootCmd = &cobra.Command{
 	Use:   "tendermint",
 	Short: "BFT state machine replication for applications in any programming languages",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
 		if cmd.Name() == VersionCmd.Name() {
 			return nil
 		}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// RotateNodeKeyCmd replaces the node key with a new one, keeping the current
+// key as the previous node key.
+var RotateNodeKeyCmd = &cobra.Command{
+	Use:   "rotate-node-key",
+	Short: "Replace the node key with a new one",
+	Long: `Replace the node key with a new one.
+
+The current node key is moved to the previous node key file, replacing any
+earlier previous node key. When the node starts, it publishes a forwarding
+record signed by the previous node key, so that peers which know the node by
+its previous ID move their addresses and persistent peer settings over to the
+new ID instead of treating the node as a new peer.
+
+Only the new stack (p2p.disable-legacy) gossips forwarding records.
+`,
+	RunE: rotateNodeKey,
+}
+
+func rotateNodeKey(cmd *cobra.Command, args []string) error {
+	oldKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return fmt.Errorf("failed to load node key %s: %w", config.NodeKeyFile(), err)
+	}
+
+	if err := oldKey.SaveAs(config.PreviousNodeKeyFile()); err != nil {
+		return fmt.Errorf("failed to save previous node key %s: %w", config.PreviousNodeKeyFile(), err)
+	}
+	newKey := p2p.GenNodeKey()
+	if err := newKey.SaveAs(config.NodeKeyFile()); err != nil {
+		return fmt.Errorf("failed to save node key %s: %w", config.NodeKeyFile(), err)
+	}
+
+	logger.Info("Rotated node key", "old_id", oldKey.ID, "new_id", newKey.ID,
+		"previous_key_file", config.PreviousNodeKeyFile())
+	return nil
+}
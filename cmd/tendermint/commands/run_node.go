@@ -4,18 +4,19 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"fmt"
-	"io"
-	"os"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
 
 	cfg "github.com/tendermint/tendermint/config"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/types"
 )
 
 var (
-	genesisHash []byte
+	genesisHash  []byte
+	devEphemeral bool
 )
 
 // AddNodeFlags exposes some common configuration options on the command-line
@@ -25,7 +26,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String("moniker", config.Moniker, "node name")
 
 	// mode flags
-	cmd.Flags().String("mode", config.Mode, "node mode (full | validator | seed)")
+	cmd.Flags().String("mode", config.Mode, "node mode (full | validator | seed | header)")
 
 	// priv val flags
 	cmd.Flags().String(
@@ -39,10 +40,12 @@ func AddNodeFlags(cmd *cobra.Command) {
 		&genesisHash,
 		"genesis-hash",
 		[]byte{},
-		"optional SHA-256 hash of the genesis file")
+		"optional SHA-256 hash of the canonical JSON of the genesis file, or of the genesis file")
 	cmd.Flags().Int64("consensus.double-sign-check-height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
+	cmd.Flags().Bool("allow-downgrade", config.AllowDowngrade,
+		"start even if the state was written by a newer version of Tendermint")
 
 	// abci flags
 	cmd.Flags().String(
@@ -105,10 +108,18 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 		Aliases: []string{"node", "run"},
 		Short:   "Run the tendermint node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := nm.BootstrapFromChainRegistry(config, logger); err != nil {
+				return err
+			}
 			if err := checkGenesisHash(config); err != nil {
 				return err
 			}
 
+			if devEphemeral {
+				nodeProvider = nm.NewEphemeralNode
+				logger.Info("Starting an ephemeral devnet; all data is kept in memory and lost on exit")
+			}
+
 			n, err := nodeProvider(config, logger)
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
@@ -135,6 +146,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 	}
 
 	AddNodeFlags(cmd)
+	cmd.Flags().BoolVar(&devEphemeral, "dev-ephemeral", false,
+		"run a throwaway single validator chain with the built-in kvstore app, "+
+			"keeping all data in memory instead of the home directory")
 	return cmd
 }
 
@@ -143,20 +157,20 @@ func checkGenesisHash(config *cfg.Config) error {
 		return nil
 	}
 
-	// Calculate SHA-256 hash of the genesis file.
-	f, err := os.Open(config.GenesisFile())
+	genDocJSON, err := ioutil.ReadFile(config.GenesisFile())
 	if err != nil {
-		return fmt.Errorf("can't open genesis file: %w", err)
+		return fmt.Errorf("can't read genesis file: %w", err)
 	}
-	defer f.Close()
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
+
+	// Compare with the hash of the canonical JSON, which doesn't depend on
+	// the formatting of the file, and for backwards compatibility with the
+	// SHA-256 hash of the file.
+	actualHash, err := types.GenesisDocHash(genDocJSON)
+	if err != nil {
 		return fmt.Errorf("error when hashing genesis file: %w", err)
 	}
-	actualHash := h.Sum(nil)
-
-	// Compare with the flag.
-	if !bytes.Equal(genesisHash, actualHash) {
+	fileHash := sha256.Sum256(genDocJSON)
+	if !bytes.Equal(genesisHash, actualHash) && !bytes.Equal(genesisHash, fileHash[:]) {
 		return fmt.Errorf(
 			"--genesis_hash=%X does not match %s hash: %X",
 			genesisHash, config.GenesisFile(), actualHash)
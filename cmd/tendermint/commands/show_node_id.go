@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/p2p"
@@ -23,6 +21,10 @@ func showNodeID(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Println(nodeKey.ID)
-	return nil
+	return printOutput(string(nodeKey.ID), nodeIDOutput{NodeID: nodeKey.ID})
+}
+
+// nodeIDOutput is the JSON output of show-node-id.
+type nodeIDOutput struct {
+	NodeID p2p.NodeID `json:"node_id"`
 }
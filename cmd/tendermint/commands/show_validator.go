@@ -71,6 +71,11 @@ func showValidator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to marshal private validator pubkey: %w", err)
 	}
 
-	fmt.Println(string(bz))
-	return nil
+	return printOutput(string(bz), validatorOutput{Address: pubKey.Address(), PubKey: pubKey})
+}
+
+// validatorOutput is the JSON output of show-validator.
+type validatorOutput struct {
+	Address crypto.Address `json:"address"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
 }
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmflags "github.com/tendermint/tendermint/libs/cli/flags"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	nm "github.com/tendermint/tendermint/node"
+)
+
+var (
+	superviseRPCLaddr        string
+	supervisePrometheusLaddr string
+)
+
+// NewSuperviseCmd returns the command that runs the nodes of several homes,
+// e.g. of different testnets, in one process.
+func NewSuperviseCmd(nodeProvider nm.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "supervise [home...]",
+		Short: "Run the nodes of several homes in one process",
+		Long: `Run the nodes of several homes in one process.
+
+Each home is initialized like the home of a single node, with its own config,
+genesis and keys, and the nodes must run different chains. The addresses in
+the configs must not conflict.
+
+The nodes share one Prometheus server, which serves the metrics of all nodes
+labeled by chain ID, and an admin RPC server lists the status of all nodes
+at /nodes.
+`,
+		Args:    cobra.MinimumNArgs(1),
+		Example: `supervise ~/.testnet-a ~/.testnet-b --prometheus-laddr :26660`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configs := make([]*cfg.Config, 0, len(args))
+			for _, home := range args {
+				conf, err := loadConfig(home)
+				if err != nil {
+					return err
+				}
+				configs = append(configs, conf)
+			}
+
+			nodeProvider := func(conf *cfg.Config, _ log.Logger) (*nm.Node, error) {
+				nodeLogger, err := tmflags.ParseLogLevel(conf.LogLevel, logger.With("home", conf.RootDir),
+					cfg.DefaultLogLevel)
+				if err != nil {
+					return nil, err
+				}
+				return nodeProvider(conf, nodeLogger)
+			}
+
+			s, err := nm.NewSupervisor(nm.SupervisorConfig{
+				RPCListenAddress:     superviseRPCLaddr,
+				PrometheusListenAddr: supervisePrometheusLaddr,
+			}, configs, nodeProvider, logger)
+			if err != nil {
+				return err
+			}
+
+			if err := s.Start(); err != nil {
+				return fmt.Errorf("failed to start nodes: %w", err)
+			}
+			logger.Info("Started nodes", "count", len(s.Nodes()))
+
+			// Stop upon receiving SIGTERM or CTRL-C.
+			tmos.TrapSignal(logger, func() {
+				if s.IsRunning() {
+					if err := s.Stop(); err != nil {
+						logger.Error("unable to stop the nodes", "error", err)
+					}
+				}
+			})
+
+			// Run forever.
+			select {}
+		},
+	}
+
+	cmd.Flags().StringVar(&superviseRPCLaddr, "rpc-laddr", "tcp://127.0.0.1:26670",
+		"address of the admin RPC server; empty disables it")
+	cmd.Flags().StringVar(&supervisePrometheusLaddr, "prometheus-laddr", "",
+		"address of the Prometheus server serving the metrics of all nodes; empty disables it")
+	return cmd
+}
+
+// loadConfig reads the config of the node with the given home.
+func loadConfig(home string) (*cfg.Config, error) {
+	home, err := filepath.Abs(home)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigName("config")
+	v.AddConfigPath(filepath.Join(home, "config"))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read the config of %v: %w", home, err)
+	}
+
+	conf := cfg.DefaultConfig()
+	if err := v.Unmarshal(conf); err != nil {
+		return nil, fmt.Errorf("failed to parse the config of %v: %w", home, err)
+	}
+	conf.SetRoot(home)
+	if err := conf.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in the config of %v: %w", home, err)
+	}
+	return conf, nil
+}
@@ -0,0 +1,440 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// TuneConsensusCmd simulates the rounds of consensus on a network with the
+// given parameters and recommends consensus timeouts for it.
+var TuneConsensusCmd = &cobra.Command{
+	Use:   "tune-consensus",
+	Short: "Recommend consensus timeouts for a network, by simulating its rounds",
+	Long: `Recommend consensus timeouts for a network, by simulating its rounds.
+
+Simulates the proposal and votes of each round of consensus between validators
+of equal voting power, whose messages take a random time to arrive following a
+log-normal distribution of the given median and 99th percentile latencies.
+
+The timeouts are recommended from a simulation without timeouts: the proposal
+timeout covers twice the 99th percentile of the time the proposal takes to
+arrive, the prevote and precommit timeouts twice the 99th percentile of the
+time the votes of all validators take to arrive after the first +2/3, and the
+commit timeout that time. The deltas add a 99th percentile latency per round
+to the proposal timeout, and half of it to the vote timeouts.
+
+Then the heights are simulated with the recommended timeouts and with the ones
+of config.toml, with the given number of validators offline, printing the
+distribution of the block time and the share of heights which took more than
+one round. The simulation assumes all validators start a height at the same
+time and leaves out the gossip of votes between peers; it is meant to get the
+timeouts in the right range, not to predict the block time exactly.
+`,
+	RunE:    runTuneConsensus,
+	Example: `tune-consensus --validators 100 --latency-p99 300ms --offline 10`,
+}
+
+var (
+	tuneValidators    int
+	tuneOffline       int
+	tuneLatencyP50    time.Duration
+	tuneLatencyP99    time.Duration
+	tuneBlockSize     int64
+	tuneBandwidth     int64
+	tuneBlockExecTime time.Duration
+	tuneHeights       int
+	tuneSeed          int64
+)
+
+func init() {
+	TuneConsensusCmd.Flags().IntVar(&tuneValidators, "validators", 100, "number of validators")
+	TuneConsensusCmd.Flags().IntVar(&tuneOffline, "offline", 0,
+		"number of validators offline, which neither propose nor vote")
+	TuneConsensusCmd.Flags().DurationVar(&tuneLatencyP50, "latency-p50", 0,
+		"median latency of the messages between validators (default a third of --latency-p99)")
+	TuneConsensusCmd.Flags().DurationVar(&tuneLatencyP99, "latency-p99", 0,
+		"99th percentile latency of the messages between validators")
+	TuneConsensusCmd.Flags().Int64Var(&tuneBlockSize, "block-size", 100*1024, "size of the blocks, in bytes")
+	TuneConsensusCmd.Flags().Int64Var(&tuneBandwidth, "bandwidth", 12500000,
+		"bandwidth of the validators, in bytes per second, which the proposal takes to send")
+	TuneConsensusCmd.Flags().DurationVar(&tuneBlockExecTime, "block-exec-time", 0,
+		"time the application takes to execute a block")
+	TuneConsensusCmd.Flags().IntVar(&tuneHeights, "heights", 1000, "number of heights to simulate")
+	TuneConsensusCmd.Flags().Int64Var(&tuneSeed, "seed", 0,
+		"seed of the simulation, to reproduce its results (default random)")
+}
+
+func runTuneConsensus(cmd *cobra.Command, args []string) error {
+	if tuneLatencyP99 <= 0 {
+		return errors.New("--latency-p99 must be set")
+	}
+	if tuneLatencyP50 == 0 {
+		tuneLatencyP50 = tuneLatencyP99 / 3
+	}
+	if tuneSeed == 0 {
+		tuneSeed = time.Now().UnixNano()
+	}
+
+	sim := consensusSim{
+		validators: tuneValidators,
+		latencyP50: tuneLatencyP50,
+		latencyP99: tuneLatencyP99,
+		execTime:   tuneBlockExecTime,
+	}
+	if tuneBandwidth > 0 {
+		sim.transferTime = time.Duration(float64(tuneBlockSize) / float64(tuneBandwidth) * float64(time.Second))
+	}
+	if err := sim.validate(); err != nil {
+		return err
+	}
+
+	recommended, err := sim.recommend(tuneHeights, tuneSeed)
+	if err != nil {
+		return err
+	}
+	sim.offline = tuneOffline
+	if err := sim.validate(); err != nil {
+		return err
+	}
+	withRecommended, err := sim.run(recommended, tuneHeights, tuneSeed)
+	if err != nil {
+		return err
+	}
+	withConfigured, err := sim.run(config.Consensus, tuneHeights, tuneSeed)
+	if err != nil {
+		return err
+	}
+
+	printTuneConsensus(cmd.OutOrStdout(), sim, recommended, config.Consensus, withRecommended, withConfigured)
+	return nil
+}
+
+func printTuneConsensus(
+	out io.Writer,
+	sim consensusSim,
+	recommended, configured *cfg.ConsensusConfig,
+	withRecommended, withConfigured simResult,
+) {
+	fmt.Fprintf(out, "Simulated %d heights of %d validators (%d offline), latency p50 %v p99 %v, proposal sent in %v\n\n",
+		len(withRecommended.blockTimes), sim.validators, sim.offline, sim.latencyP50, sim.latencyP99,
+		sim.transferTime.Round(time.Millisecond))
+
+	const row = "%-25s %-12v %v\n"
+	fmt.Fprintf(out, "%-25s %-12s %s\n", "", "recommended", "config.toml")
+	fmt.Fprintf(out, row, "timeout-propose", recommended.TimeoutPropose, configured.TimeoutPropose)
+	fmt.Fprintf(out, row, "timeout-propose-delta", recommended.TimeoutProposeDelta, configured.TimeoutProposeDelta)
+	fmt.Fprintf(out, row, "timeout-prevote", recommended.TimeoutPrevote, configured.TimeoutPrevote)
+	fmt.Fprintf(out, row, "timeout-prevote-delta", recommended.TimeoutPrevoteDelta, configured.TimeoutPrevoteDelta)
+	fmt.Fprintf(out, row, "timeout-precommit", recommended.TimeoutPrecommit, configured.TimeoutPrecommit)
+	fmt.Fprintf(out, row, "timeout-precommit-delta", recommended.TimeoutPrecommitDelta,
+		configured.TimeoutPrecommitDelta)
+	fmt.Fprintf(out, row, "timeout-commit", recommended.TimeoutCommit, configured.TimeoutCommit)
+	fmt.Fprintln(out)
+	for _, p := range []int{50, 90, 99} {
+		q := float64(p) / 100
+		fmt.Fprintf(out, row, fmt.Sprintf("block time p%d", p),
+			quantile(withRecommended.blockTimes, q).Round(time.Millisecond),
+			quantile(withConfigured.blockTimes, q).Round(time.Millisecond))
+	}
+	fmt.Fprintf(out, row, "block time max",
+		quantile(withRecommended.blockTimes, 1).Round(time.Millisecond),
+		quantile(withConfigured.blockTimes, 1).Round(time.Millisecond))
+	fmt.Fprintf(out, "%-25s %-12s %s\n", "heights with 2+ rounds",
+		fmt.Sprintf("%.1f%%", withRecommended.multiRoundShare()*100),
+		fmt.Sprintf("%.1f%%", withConfigured.multiRoundShare()*100))
+}
+
+// never is the time of an event which doesn't happen.
+const never = time.Duration(math.MaxInt64)
+
+// maxSimRounds is the number of rounds after which a simulated height is
+// considered stuck.
+const maxSimRounds = 50
+
+// consensusSim simulates the rounds of consensus between validators of equal
+// voting power, which propose in turn. The offline validators, spread evenly
+// among them, neither propose nor vote.
+type consensusSim struct {
+	validators   int
+	offline      int
+	latencyP50   time.Duration
+	latencyP99   time.Duration
+	transferTime time.Duration // taken by the proposer to send the proposal
+	execTime     time.Duration // taken by the app to execute a block
+
+	rng        *rand.Rand
+	online     []int   // index of each validator among the online ones, -1 if offline
+	mu, sigma  float64 // of the log-normal distribution of the latency, in seconds
+	timeouts   *cfg.ConsensusConfig
+	collecting *simResult // collects the waits of the validators if set
+}
+
+// simResult is the result of simulating a number of heights.
+type simResult struct {
+	blockTimes []time.Duration
+	rounds     []int
+
+	// the times, since the start of the round, the proposal took to arrive
+	proposalDelays []time.Duration
+	// the times the prevotes and precommits of all validators took to arrive
+	// after the first +2/3
+	prevoteWaits, precommitWaits []time.Duration
+}
+
+func (r simResult) multiRoundShare() float64 {
+	multi := 0
+	for _, rounds := range r.rounds {
+		if rounds > 1 {
+			multi++
+		}
+	}
+	return float64(multi) / float64(len(r.rounds))
+}
+
+func (s consensusSim) validate() error {
+	switch {
+	case s.validators < 1:
+		return errors.New("--validators must be positive")
+	case s.offline < 0:
+		return errors.New("--offline can't be negative")
+	case s.validators-s.offline < s.quorum():
+		return fmt.Errorf("with %d of %d validators offline, less than +2/3 of the validators vote and consensus halts",
+			s.offline, s.validators)
+	case s.latencyP50 <= 0 || s.latencyP50 > s.latencyP99:
+		return errors.New("--latency-p50 must be positive and at most --latency-p99")
+	}
+	return nil
+}
+
+// quorum is the number of votes which make +2/3 of the voting power.
+func (s consensusSim) quorum() int {
+	return s.validators*2/3 + 1
+}
+
+// recommend recommends timeouts from the waits of a simulation with all
+// validators online and timeouts long enough to never expire.
+func (s consensusSim) recommend(heights int, seed int64) (*cfg.ConsensusConfig, error) {
+	s.offline = 0
+	s.collecting = &simResult{}
+	noTimeouts := &cfg.ConsensusConfig{
+		TimeoutPropose:   time.Hour,
+		TimeoutPrevote:   time.Hour,
+		TimeoutPrecommit: time.Hour,
+	}
+	if _, err := s.run(noTimeouts, heights, seed); err != nil {
+		return nil, err
+	}
+
+	precommitWait := quantile(s.collecting.precommitWaits, 0.99)
+	return &cfg.ConsensusConfig{
+		TimeoutPropose:        roundTimeout(2 * quantile(s.collecting.proposalDelays, 0.99)),
+		TimeoutProposeDelta:   roundTimeout(s.latencyP99),
+		TimeoutPrevote:        roundTimeout(2 * quantile(s.collecting.prevoteWaits, 0.99)),
+		TimeoutPrevoteDelta:   roundTimeout(s.latencyP99 / 2),
+		TimeoutPrecommit:      roundTimeout(2 * precommitWait),
+		TimeoutPrecommitDelta: roundTimeout(s.latencyP99 / 2),
+		TimeoutCommit:         roundTimeout(precommitWait),
+	}, nil
+}
+
+// run simulates the given number of heights with the given timeouts.
+func (s consensusSim) run(timeouts *cfg.ConsensusConfig, heights int, seed int64) (simResult, error) {
+	// nolint:gosec // G404: Use of weak random number generator
+	s.rng = rand.New(rand.NewSource(seed))
+	s.mu = math.Log(s.latencyP50.Seconds())
+	// the 99th percentile of the standard normal distribution
+	s.sigma = (math.Log(s.latencyP99.Seconds()) - s.mu) / 2.326
+	s.timeouts = timeouts
+	s.online = make([]int, s.validators)
+	index := 0
+	for v := range s.online {
+		if v*s.offline%s.validators < s.offline {
+			s.online[v] = -1
+			continue
+		}
+		s.online[v] = index
+		index++
+	}
+
+	var res simResult
+	for h := 0; h < heights; h++ {
+		commitTime, rounds, err := s.height(h)
+		if err != nil {
+			return res, err
+		}
+		// the next height starts timeout-commit after the commit, or once the
+		// block is executed if that takes longer
+		wait := timeouts.TimeoutCommit
+		if s.execTime > wait {
+			wait = s.execTime
+		}
+		res.blockTimes = append(res.blockTimes, commitTime+wait)
+		res.rounds = append(res.rounds, rounds)
+	}
+	return res, nil
+}
+
+// height simulates the rounds of a height, all validators starting it at 0,
+// until a block is committed. It returns the median time the validators
+// commit it at and the number of rounds.
+func (s consensusSim) height(h int) (time.Duration, int, error) {
+	online := s.validators - s.offline
+	starts := make([]time.Duration, online)
+	for round := int32(0); round < maxSimRounds; round++ {
+		proposer := s.online[(h+int(round))%s.validators]
+		commits, nextStarts := s.round(proposer, round, starts)
+		if commits != nil {
+			sort.Slice(commits, func(i, j int) bool { return commits[i] < commits[j] })
+			return commits[len(commits)/2], int(round) + 1, nil
+		}
+		starts = nextStarts
+	}
+	return 0, 0, fmt.Errorf("no block was committed in %d rounds", maxSimRounds)
+}
+
+// round simulates a round given the times the online validators start it at
+// and the index of the proposer among them, -1 if it's offline. It returns the times they commit the block at, or the times they start the
+// next round at if it fails.
+func (s consensusSim) round(proposer int, round int32, starts []time.Duration) (commits, next []time.Duration) {
+	online := len(starts)
+
+	// the validators prevote the proposal if it arrives before timeout-propose
+	prevotes := make([]time.Duration, online)
+	prevoteBlock := make([]bool, online)
+	for i := range starts {
+		arrival := never
+		if proposer >= 0 {
+			arrival = starts[proposer] + s.transferTime
+			if i != proposer {
+				arrival += s.latency()
+			}
+			if s.collecting != nil {
+				s.collecting.proposalDelays = append(s.collecting.proposalDelays, arrival-starts[i])
+			}
+		}
+		timeout := starts[i] + s.timeouts.Propose(round)
+		prevotes[i], prevoteBlock[i] = timeout, false
+		if arrival <= timeout {
+			prevotes[i], prevoteBlock[i] = arrival, true
+		}
+	}
+
+	var prevoteWaits, precommitWaits *[]time.Duration
+	if s.collecting != nil {
+		prevoteWaits, precommitWaits = &s.collecting.prevoteWaits, &s.collecting.precommitWaits
+	}
+
+	precommits := make([]time.Duration, online)
+	precommitBlock := make([]bool, online)
+	for i := range starts {
+		precommits[i], _, precommitBlock[i] = s.tally(i, prevotes, prevoteBlock, s.timeouts.Prevote(round),
+			prevoteWaits)
+	}
+
+	commits = make([]time.Duration, online)
+	next = make([]time.Duration, online)
+	blockPrecommits := 0
+	for i := range starts {
+		if precommitBlock[i] {
+			blockPrecommits++
+		}
+		next[i], commits[i], _ = s.tally(i, precommits, precommitBlock, s.timeouts.Precommit(round),
+			precommitWaits)
+	}
+	if blockPrecommits < s.quorum() {
+		return nil, next
+	}
+	return commits, nil
+}
+
+// tally returns when validator i moves on after receiving the given votes,
+// cast at the given times for the block or nil, and whether it moves on with
+// +2/3 votes for the block. It moves on as soon as it has +2/3 votes for the
+// block or for nil, or wait after it has +2/3 votes for anything. It also
+// returns when +2/3 votes for the block arrive, whether before or after, and
+// appends the time the votes of all validators took to arrive after the
+// first +2/3 to waits, unless it's nil.
+func (s consensusSim) tally(
+	i int,
+	cast []time.Duration,
+	forBlock []bool,
+	wait time.Duration,
+	waits *[]time.Duration,
+) (moveOn, blockQuorum time.Duration, block bool) {
+	all := make([]time.Duration, 0, len(cast))
+	var blockVotes, nilVotes []time.Duration
+	for j, at := range cast {
+		if j != i {
+			at += s.latency()
+		}
+		all = append(all, at)
+		if forBlock[j] {
+			blockVotes = append(blockVotes, at)
+		} else {
+			nilVotes = append(nilVotes, at)
+		}
+	}
+
+	q := s.quorum()
+	anyQuorum := kthArrival(all, q)
+	blockQuorum = kthArrival(blockVotes, q)
+	nilQuorum := kthArrival(nilVotes, q)
+	if waits != nil {
+		*waits = append(*waits, kthArrival(all, len(all))-anyQuorum)
+	}
+
+	if blockQuorum <= anyQuorum+wait && blockQuorum <= nilQuorum {
+		return blockQuorum, blockQuorum, true
+	}
+	moveOn = anyQuorum + wait
+	if nilQuorum < moveOn {
+		moveOn = nilQuorum
+	}
+	return moveOn, blockQuorum, false
+}
+
+// latency returns the random time a message takes to arrive.
+func (s consensusSim) latency() time.Duration {
+	return time.Duration(math.Exp(s.mu+s.sigma*s.rng.NormFloat64()) * float64(time.Second))
+}
+
+// kthArrival returns the time the k-th of the messages arriving at the given
+// times arrives at, or never if there are fewer.
+func kthArrival(arrivals []time.Duration, k int) time.Duration {
+	if len(arrivals) < k {
+		return never
+	}
+	sort.Slice(arrivals, func(i, j int) bool { return arrivals[i] < arrivals[j] })
+	return arrivals[k-1]
+}
+
+// quantile returns the q quantile of the durations.
+func quantile(durations []time.Duration, q float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+// roundTimeout rounds a recommended timeout up to the millisecond.
+func roundTimeout(d time.Duration) time.Duration {
+	if d%time.Millisecond != 0 {
+		d += time.Millisecond - d%time.Millisecond
+	}
+	if d < time.Millisecond {
+		d = time.Millisecond
+	}
+	return d
+}
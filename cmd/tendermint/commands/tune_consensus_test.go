@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestConsensusSim(t *testing.T) {
+	sim := consensusSim{
+		validators: 10,
+		latencyP50: 30 * time.Millisecond,
+		latencyP99: 100 * time.Millisecond,
+	}
+	require.NoError(t, sim.validate())
+
+	recommended, err := sim.recommend(200, 1)
+	require.NoError(t, err)
+	assert.Greater(t, recommended.TimeoutPropose, sim.latencyP99)
+	assert.Greater(t, recommended.TimeoutPrevote, time.Duration(0))
+	assert.Greater(t, recommended.TimeoutPrecommit, time.Duration(0))
+	assert.Equal(t, sim.latencyP99, recommended.TimeoutProposeDelta)
+
+	// all heights take one round with everyone online
+	res, err := sim.run(recommended, 200, 1)
+	require.NoError(t, err)
+	assert.Len(t, res.blockTimes, 200)
+	assert.Zero(t, res.multiRoundShare())
+	for _, blockTime := range res.blockTimes {
+		assert.Greater(t, blockTime, recommended.TimeoutCommit)
+	}
+
+	// the heights proposed by an offline validator take another round, which
+	// starts after timeout-propose
+	sim.offline = 2
+	require.NoError(t, sim.validate())
+	res, err = sim.run(recommended, 200, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, res.multiRoundShare(), 0.01)
+	assert.Greater(t, quantile(res.blockTimes, 1), recommended.TimeoutPropose+recommended.TimeoutCommit)
+
+	// the default timeouts are far longer
+	slow, err := sim.run(cfg.DefaultConsensusConfig(), 200, 1)
+	require.NoError(t, err)
+	assert.Greater(t, quantile(slow.blockTimes, 0.5), quantile(res.blockTimes, 0.5))
+
+	// consensus halts without +2/3 of the validators
+	sim.offline = 4
+	assert.Error(t, sim.validate())
+}
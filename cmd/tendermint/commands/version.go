@@ -1,8 +1,6 @@
 package commands
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/version"
@@ -12,7 +10,22 @@ import (
 var VersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version info",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(version.TMVersion)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printOutput(version.TMVersion, versionOutput{
+			Tendermint:    version.TMVersion,
+			ABCI:          version.ABCISemVer,
+			BlockProtocol: version.BlockProtocol,
+			P2PProtocol:   version.P2PProtocol,
+			GitCommit:     version.GitCommit,
+		})
 	},
 }
+
+// versionOutput is the JSON output of version.
+type versionOutput struct {
+	Tendermint    string `json:"tendermint"`
+	ABCI          string `json:"abci"`
+	BlockProtocol uint64 `json:"block_protocol"`
+	P2PProtocol   uint64 `json:"p2p_protocol"`
+	GitCommit     string `json:"git_commit"`
+}
@@ -1,12 +1,14 @@
 package config
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -27,9 +29,20 @@ const (
 	ModeFull      = "full"
 	ModeValidator = "validator"
 	ModeSeed      = "seed"
+	ModeHeader    = "header"
 
 	BlockchainV0 = "v0"
 	BlockchainV2 = "v2"
+
+	ChunkCompressionNone = "none"
+	ChunkCompressionGzip = "gzip"
+
+	MempoolShardBySize = "size"
+	MempoolShardByGas  = "gas"
+
+	BlockStoreSyncAlways   = "always"
+	BlockStoreSyncInterval = "interval"
+	BlockStoreSyncNever    = "never"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -50,16 +63,18 @@ var (
 	defaultPrivValKeyName   = "priv_validator_key.json"
 	defaultPrivValStateName = "priv_validator_state.json"
 
-	defaultNodeKeyName  = "node_key.json"
-	defaultAddrBookName = "addrbook.json"
+	defaultNodeKeyName         = "node_key.json"
+	defaultPreviousNodeKeyName = "previous_node_key.json"
+	defaultAddrBookName        = "addrbook.json"
 
 	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
 	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
-	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
-	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
+	defaultNodeKeyPath         = filepath.Join(defaultConfigDir, defaultNodeKeyName)
+	defaultPreviousNodeKeyPath = filepath.Join(defaultConfigDir, defaultPreviousNodeKeyName)
+	defaultAddrBookPath        = filepath.Join(defaultConfigDir, defaultAddrBookName)
 )
 
 // Config defines the top level configuration for a Tendermint node
@@ -76,6 +91,7 @@ type Config struct {
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx-index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
+	Preflight       *PreflightConfig       `mapstructure:"preflight"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -90,6 +106,7 @@ func DefaultConfig() *Config {
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
+		Preflight:       DefaultPreflightConfig(),
 	}
 }
 
@@ -112,6 +129,7 @@ func TestConfig() *Config {
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
+		Preflight:       TestPreflightConfig(),
 	}
 }
 
@@ -122,6 +140,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.TxIndex.RootDir = root
 	return cfg
 }
 
@@ -143,15 +162,26 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.StateSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [statesync] section: %w", err)
 	}
+	if cfg.Mode == ModeHeader {
+		if err := cfg.StateSync.ValidateLightClient(); err != nil {
+			return fmt.Errorf("error in [statesync] section, required by header mode: %w", err)
+		}
+	}
 	if err := cfg.FastSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [fastsync] section: %w", err)
 	}
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx-index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.Preflight.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [preflight] section: %w", err)
+	}
 	return nil
 }
 
@@ -174,7 +204,7 @@ type BaseConfig struct { //nolint: maligned
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
-	// Mode of Node: full | validator | seed
+	// Mode of Node: full | validator | seed | header
 	// * validator
 	//   - all reactors
 	//   - with priv_validator_key.json, priv_validator_state.json
@@ -184,6 +214,13 @@ type BaseConfig struct { //nolint: maligned
 	// * seed
 	//   - only P2P, PEX Reactor
 	//   - No priv_validator_key.json, priv_validator_state.json
+	// * header
+	//   - a light node: no P2P nor ABCI app, syncs headers from [statesync]
+	//     rpc-servers, verifying them as a light client
+	//   - only stores headers, commits and validator sets
+	//   - No priv_validator_key.json, priv_validator_state.json
+	// Only validator nodes sign, so the priv-validator-laddr and
+	// priv-validator-audit-log-file settings are rejected in the other modes.
 	Mode string `mapstructure:"mode"`
 
 	// If this node is many blocks behind the tip of the chain, FastSync
@@ -215,6 +252,21 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db-dir"`
 
+	// When the block store flushes the saved blocks to disk: always | interval | never
+	// * always
+	//   - flushes every block before it is applied
+	// * interval
+	//   - flushes a block if the last flush is older than block-store-sync-interval
+	// * never
+	//   - leaves flushing to the OS
+	// The state is always flushed. If the node crashes after applying blocks
+	// which were not flushed, it can't restart from its data and must resync.
+	// Only use interval or never on nodes that can be resynced, e.g. on slow disks.
+	BlockStoreSync string `mapstructure:"block-store-sync"`
+
+	// Max time between two block store flushes, with block-store-sync = "interval"
+	BlockStoreSyncInterval time.Duration `mapstructure:"block-store-sync-interval"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log-level"`
 
@@ -224,6 +276,25 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis-file"`
 
+	// If set, the app_state of the genesis file is not loaded into memory but
+	// streamed to the app in InitChain requests with chunks of this many bytes.
+	// The app must support app state streaming, and the /genesis RPC endpoints
+	// omit the app state. 0 disables streaming.
+	GenesisAppStateChunkSize int `mapstructure:"genesis-app-state-chunk-size"`
+
+	// URL of a chain registry to bootstrap the node from. If set, the node
+	// fetches the entry of ChainRegistryChainID from the registry on start,
+	// downloads the genesis file if it doesn't exist yet, and adds the seeds
+	// and persistent peers of the entry to the configured ones.
+	ChainRegistry string `mapstructure:"chain-registry"`
+
+	// Chain ID of the network to join from the chain registry
+	ChainRegistryChainID string `mapstructure:"chain-registry-chain-id"`
+
+	// Hex-encoded ed25519 public key the chain registry signs its entries
+	// with. Entries with a missing or invalid signature are rejected.
+	ChainRegistryPubKey string `mapstructure:"chain-registry-pubkey"`
+
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `mapstructure:"priv-validator-key-file"`
 
@@ -244,15 +315,46 @@ type BaseConfig struct { //nolint: maligned
 	// Path Root Certificate Authority used to sign both client and server certificates
 	PrivValidatorRootCA string `mapstructure:"priv-validator-root-ca-file"`
 
+	// Max time a remote signer, connected over a socket, may take to sign a
+	// vote or a proposal. Slower signatures are logged as errors and counted
+	// in the privval_slow_signs metric, since they can cause missed blocks.
+	// 0 disables the check.
+	PrivValidatorMaxSignLatency time.Duration `mapstructure:"priv-validator-max-sign-latency"`
+
+	// Path to the append-only, hash-chained log of every vote and proposal the
+	// validator signed, for audits. Inspect and verify it with the audit-log
+	// command. Empty disables the log.
+	PrivValidatorAuditLog string `mapstructure:"priv-validator-audit-log-file"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
+	// A JSON file containing the previous node key, after rotating the node
+	// key. If it exists, the node publishes a forwarding record signed by the
+	// previous key, so peers move the previous node ID over to the new one.
+	PreviousNodeKey string `mapstructure:"previous-node-key-file"`
+
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter-peers"` // false
+
+	// Max time each phase of a graceful shutdown may take, e.g. draining the
+	// in-flight ABCI calls or finishing the current consensus step, before
+	// the node moves on to the next phase.
+	ShutdownDrainTimeout time.Duration `mapstructure:"shutdown-drain-timeout"`
+
+	// If the node runs as a systemd service with a watchdog, it pings the
+	// watchdog only while it makes progress. If it makes no progress for this
+	// long, systemd considers it hung. 0 pings the watchdog as long as the
+	// node runs.
+	SystemdWatchdogMaxStall time.Duration `mapstructure:"systemd-watchdog-max-stall"`
+
+	// If true, start even if the state was written by a newer version of
+	// Tendermint. Running an older version on newer state can corrupt it.
+	AllowDowngrade bool `mapstructure:"allow-downgrade"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
@@ -262,6 +364,7 @@ func DefaultBaseConfig() BaseConfig {
 		PrivValidatorKey:   defaultPrivValKeyPath,
 		PrivValidatorState: defaultPrivValStatePath,
 		NodeKey:            defaultNodeKeyPath,
+		PreviousNodeKey:    defaultPreviousNodeKeyPath,
 		Mode:               defaultMode,
 		Moniker:            defaultMoniker,
 		ProxyApp:           "tcp://127.0.0.1:26658",
@@ -272,6 +375,12 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers:        false,
 		DBBackend:          "goleveldb",
 		DBPath:             "data",
+
+		PrivValidatorMaxSignLatency: time.Second,
+		BlockStoreSync:              BlockStoreSyncAlways,
+		BlockStoreSyncInterval:      time.Second,
+		ShutdownDrainTimeout:        10 * time.Second,
+		SystemdWatchdogMaxStall:     10 * time.Minute,
 	}
 }
 
@@ -320,11 +429,25 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorAuditLogFile returns the full path to the audit log of the
+// signed votes and proposals, or "" if it is disabled.
+func (cfg BaseConfig) PrivValidatorAuditLogFile() string {
+	if cfg.PrivValidatorAuditLog == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorAuditLog, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// PreviousNodeKeyFile returns the full path to the previous_node_key.json file
+func (cfg BaseConfig) PreviousNodeKeyFile() string {
+	return rootify(cfg.PreviousNodeKey, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -352,12 +475,59 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errors.New("unknown log format (must be 'plain' or 'json')")
 	}
 	switch cfg.Mode {
-	case ModeFull, ModeValidator, ModeSeed:
+	case ModeFull, ModeValidator, ModeSeed, ModeHeader:
 	case "":
 		return errors.New("no mode has been set")
 	default:
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
+	// Settings of the signer would be silently ignored by a node which
+	// doesn't sign, e.g. a validator mistakenly run in seed mode.
+	if cfg.Mode != ModeValidator {
+		if cfg.PrivValidatorListenAddr != "" {
+			return fmt.Errorf("priv-validator-laddr is set, but a node in %s mode doesn't sign; "+
+				"set mode = \"validator\" or unset priv-validator-laddr", cfg.Mode)
+		}
+		if cfg.PrivValidatorAuditLog != "" {
+			return fmt.Errorf("priv-validator-audit-log-file is set, but a node in %s mode doesn't sign; "+
+				"set mode = \"validator\" or unset priv-validator-audit-log-file", cfg.Mode)
+		}
+	}
+	switch cfg.BlockStoreSync {
+	case BlockStoreSyncAlways, BlockStoreSyncInterval, BlockStoreSyncNever:
+	default:
+		return fmt.Errorf("unknown block-store-sync: %v (must be 'always', 'interval' or 'never')", cfg.BlockStoreSync)
+	}
+	if cfg.PrivValidatorMaxSignLatency < 0 {
+		return errors.New("priv-validator-max-sign-latency can't be negative")
+	}
+	if cfg.BlockStoreSyncInterval < 0 {
+		return errors.New("block-store-sync-interval can't be negative")
+	}
+	if cfg.GenesisAppStateChunkSize < 0 {
+		return errors.New("genesis-app-state-chunk-size can't be negative")
+	}
+	if cfg.ShutdownDrainTimeout < 0 {
+		return errors.New("shutdown-drain-timeout can't be negative")
+	}
+	if cfg.SystemdWatchdogMaxStall < 0 {
+		return errors.New("systemd-watchdog-max-stall can't be negative")
+	}
+	if cfg.ChainRegistry != "" {
+		if cfg.ChainRegistryChainID == "" {
+			return errors.New("chain-registry-chain-id is required with chain-registry")
+		}
+		if cfg.ChainRegistryPubKey == "" {
+			return errors.New("chain-registry-pubkey is required with chain-registry")
+		}
+		pubKey, err := hex.DecodeString(cfg.ChainRegistryPubKey)
+		if err != nil {
+			return fmt.Errorf("invalid chain-registry-pubkey: %w", err)
+		}
+		if len(pubKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("chain-registry-pubkey must be %d bytes, got %d", ed25519.PublicKeySize, len(pubKey))
+		}
+	}
 	return nil
 }
 
@@ -428,6 +598,23 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Maximum number of /abci_query requests the application handles
+	// concurrently. Further queries wait. Identical concurrent queries are
+	// sent to the application once.
+	// 0 - unlimited.
+	MaxConcurrentABCIQueries int `mapstructure:"max-concurrent-abci-queries"`
+
+	// Maximum number of txs of a /broadcast_tx_batch request.
+	// 0 - disables /broadcast_tx_batch.
+	MaxBroadcastTxBatch int `mapstructure:"max-broadcast-tx-batch"`
+
+	// When true, the RPC server, and the gRPC server if GRPCListenAddress is
+	// set, share the p2p listen address with the p2p connections, told apart
+	// by their first bytes, instead of listening on their own addresses. The
+	// connections told apart at once are limited by the connection limits of
+	// the p2p transport and the servers.
+	Multiplex bool `mapstructure:"multiplex"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -448,6 +635,14 @@ type RPCConfig struct {
 
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof-laddr"`
+
+	// Bech32 human-readable prefix of validator addresses, e.g. "cosmosvalcons".
+	// If set, responses include validator addresses in bech32 in addition to hex.
+	Bech32ValidatorPrefix string `mapstructure:"bech32-validator-prefix"`
+
+	// Bech32 human-readable prefix of node IDs. If set, responses include node
+	// IDs in bech32 in addition to hex.
+	Bech32NodeIDPrefix string `mapstructure:"bech32-node-id-prefix"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -470,6 +665,8 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxBroadcastTxBatch: 100,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -508,6 +705,38 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.MaxConcurrentABCIQueries < 0 {
+		return errors.New("max-concurrent-abci-queries can't be negative")
+	}
+	if cfg.MaxBroadcastTxBatch < 0 {
+		return errors.New("max-broadcast-tx-batch can't be negative")
+	}
+	if cfg.Multiplex && cfg.IsTLSEnabled() {
+		return errors.New("multiplex doesn't support TLS, unset tls-cert-file and tls-key-file")
+	}
+	if err := validateBech32Prefix(cfg.Bech32ValidatorPrefix); err != nil {
+		return fmt.Errorf("invalid bech32-validator-prefix: %w", err)
+	}
+	if err := validateBech32Prefix(cfg.Bech32NodeIDPrefix); err != nil {
+		return fmt.Errorf("invalid bech32-node-id-prefix: %w", err)
+	}
+	return nil
+}
+
+// validateBech32Prefix checks that a non-empty prefix is a valid lowercase
+// bech32 human-readable part.
+func validateBech32Prefix(prefix string) error {
+	if len(prefix) > 83 {
+		return errors.New("must be at most 83 characters")
+	}
+	for _, c := range prefix {
+		if c < 33 || c > 126 {
+			return fmt.Errorf("invalid character %q", c)
+		}
+		if c >= 'A' && c <= 'Z' {
+			return errors.New("must be lowercase")
+		}
+	}
 	return nil
 }
 
@@ -574,6 +803,14 @@ type P2PConfig struct { //nolint: maligned
 	// Set false for private or local networks
 	AddrBookStrict bool `mapstructure:"addr-book-strict"`
 
+	// Interval between compressed backups of the address book, written to
+	// the addrbook-backups directory next to the address book. 0 disables the
+	// backups.
+	AddrBookBackupInterval time.Duration `mapstructure:"addr-book-backup-interval"`
+
+	// Number of address book backups to keep
+	AddrBookBackups int `mapstructure:"addr-book-backups"`
+
 	// Maximum number of inbound peers
 	//
 	// TODO: Remove once p2p refactor is complete in favor of MaxConnections.
@@ -600,6 +837,17 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 	PersistentPeersMaxDialPeriod time.Duration `mapstructure:"persistent-peers-max-dial-period"`
 
+	// Set true to probe the latency of several candidates from the address
+	// book per outbound peer to dial, by connecting to them, and to dial the
+	// lowest-latency ones for LowLatencyPeerFraction of the outbound peers.
+	// The others are picked from network groups (/16 for IPv4) no other peer
+	// is in, to keep the peers diverse.
+	DialLatencyProbe bool `mapstructure:"dial-latency-probe"`
+
+	// Fraction of the outbound peers picked for their low latency, between 0
+	// and 1, if DialLatencyProbe is set.
+	LowLatencyPeerFraction float64 `mapstructure:"low-latency-peer-fraction"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush-throttle-timeout"`
 
@@ -622,9 +870,30 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow-duplicate-ip"`
 
-	// Peer connection configuration.
-	HandshakeTimeout time.Duration `mapstructure:"handshake-timeout"`
-	DialTimeout      time.Duration `mapstructure:"dial-timeout"`
+	// Peer connection configuration: the max time to dial a peer, to
+	// authenticate it (the secret connection handshake), and to handshake
+	// with it, including the authentication. 0 means no timeout.
+	HandshakeTimeout    time.Duration `mapstructure:"handshake-timeout"`
+	DialTimeout         time.Duration `mapstructure:"dial-timeout"`
+	AuthenticateTimeout time.Duration `mapstructure:"authenticate-timeout"`
+
+	// Max number of peers dialed and handshaked concurrently. 0 uses the
+	// number of CPUs.
+	MaxConcurrentDials int `mapstructure:"max-concurrent-dials"`
+
+	// Set true to accept inbound connections authenticated with TLS, with
+	// certificates pinned to the node IDs, as well as with the secret
+	// connection. Outbound connections use TLS for the peers whose address
+	// has the tls:// scheme, e.g. in PersistentPeers, which requires
+	// DisableLegacy. Requires an ed25519 node key.
+	AcceptTLS bool `mapstructure:"accept-tls"`
+
+	// Set true to publish a record with the moniker, version, website and
+	// contact of the node, signed with the node key, to peers. Peers relay
+	// the record, so that explorers can look it up at /net_info.
+	PublishMetadata bool   `mapstructure:"publish-metadata"`
+	MetadataWebsite string `mapstructure:"metadata-website"`
+	MetadataContact string `mapstructure:"metadata-contact"`
 
 	// Testing params.
 	// Force dial to fail
@@ -648,11 +917,15 @@ func DefaultP2PConfig() *P2PConfig {
 		UPNP:                          false,
 		AddrBook:                      defaultAddrBookPath,
 		AddrBookStrict:                true,
+		AddrBookBackupInterval:        time.Hour,
+		AddrBookBackups:               24,
 		MaxNumInboundPeers:            40,
 		MaxNumOutboundPeers:           10,
 		MaxConnections:                64,
 		MaxIncomingConnectionAttempts: 100,
 		PersistentPeersMaxDialPeriod:  0 * time.Second,
+		DialLatencyProbe:              false,
+		LowLatencyPeerFraction:        0.5,
 		FlushThrottleTimeout:          100 * time.Millisecond,
 		// The MTU (Maximum Transmission Unit) for Ethernet is 1500 bytes.
 		// The IP header and the TCP header take up 20 bytes each at least (unless
@@ -666,6 +939,8 @@ func DefaultP2PConfig() *P2PConfig {
 		AllowDuplicateIP:        false,
 		HandshakeTimeout:        20 * time.Second,
 		DialTimeout:             3 * time.Second,
+		AuthenticateTimeout:     5 * time.Second,
+		MaxConcurrentDials:      10,
 		TestDialFail:            false,
 		QueueType:               "priority",
 	}
@@ -685,9 +960,20 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// AddrBookBackupDir returns the full path to the address book backups
+func (cfg *P2PConfig) AddrBookBackupDir() string {
+	return filepath.Join(filepath.Dir(cfg.AddrBookFile()), "addrbook-backups")
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	if cfg.AddrBookBackupInterval < 0 {
+		return errors.New("addr-book-backup-interval can't be negative")
+	}
+	if cfg.AddrBookBackupInterval > 0 && cfg.AddrBookBackups <= 0 {
+		return errors.New("addr-book-backups must be positive when backups are enabled")
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max-num-inbound-peers can't be negative")
 	}
@@ -700,6 +986,9 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.PersistentPeersMaxDialPeriod < 0 {
 		return errors.New("persistent-peers-max-dial-period can't be negative")
 	}
+	if cfg.LowLatencyPeerFraction < 0 || cfg.LowLatencyPeerFraction > 1 {
+		return errors.New("low-latency-peer-fraction must be between 0 and 1")
+	}
 	if cfg.MaxPacketMsgPayloadSize < 0 {
 		return errors.New("max-packet-msg-payload-size can't be negative")
 	}
@@ -709,6 +998,22 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv-rate can't be negative")
 	}
+	if cfg.HandshakeTimeout < 0 {
+		return errors.New("handshake-timeout can't be negative")
+	}
+	if cfg.DialTimeout < 0 {
+		return errors.New("dial-timeout can't be negative")
+	}
+	if cfg.AuthenticateTimeout < 0 {
+		return errors.New("authenticate-timeout can't be negative")
+	}
+	if cfg.MaxConcurrentDials < 0 {
+		return errors.New("max-concurrent-dials can't be negative")
+	}
+	if !cfg.DisableLegacy && strings.Contains(strings.ToLower(cfg.PersistentPeers), "tls://") {
+		// the legacy stack would silently dial them with the secret connection
+		return errors.New("persistent-peers with the tls:// scheme require disable-legacy")
+	}
 	return nil
 }
 
@@ -735,10 +1040,69 @@ type MempoolConfig struct {
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 	MaxTxBytes int `mapstructure:"max-tx-bytes"`
+	// Maximum nesting depth of the messages of a protobuf encoded tx, checked
+	// before the app decodes it. 0 disables it.
+	MaxTxDepth int `mapstructure:"max-tx-depth"`
+	// Maximum number of times a field is repeated in a message of a protobuf
+	// encoded tx, checked before the app decodes it. 0 disables it.
+	MaxTxRepeatedFields int `mapstructure:"max-tx-repeated-fields"`
 	// Maximum size of a batch of transactions to send to a peer
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max-batch-bytes"`
+	// Reject txs whose CheckTx priority is below this value once the mempool
+	// utilization reaches min-tx-priority-utilization. Can be changed at
+	// runtime with CListMempool.SetMinTxPriority.
+	MinTxPriority int64 `mapstructure:"min-tx-priority"`
+	// Utilization of the mempool, as the greater fraction of size and
+	// max-txs-bytes in use, from which on min-tx-priority is enforced.
+	MinTxPriorityUtilization float64 `mapstructure:"min-tx-priority-utilization"`
+	// Utilization of the mempool from which on txs submitted over RPC with a
+	// CheckTx priority below min-tx-priority are rejected with the retriable
+	// "mempool congested" code, while txs from peers are still accepted.
+	// 0 disables it.
+	SoftHighWaterMark float64 `mapstructure:"soft-high-water-mark"`
+	// Utilization of the mempool from which on all new txs are rejected. 0
+	// disables it.
+	HardHighWaterMark float64 `mapstructure:"hard-high-water-mark"`
+	// Number of ABCI connections used to check new txs in parallel. Rechecks
+	// are all sent over a single connection, in order.
+	CheckTxConcurrency int `mapstructure:"check-tx-concurrency"`
+	// How long the reactor remembers up to which tx it gossiped to a peer
+	// after the peer disconnects. If the peer reconnects in time, gossip
+	// resumes from there rather than from the front of the mempool.
+	// 0 disables it.
+	GossipResumeTimeout time.Duration `mapstructure:"gossip-resume-timeout"`
+	// Number of txs received from a peer after which the fraction of them
+	// which were invalid is evaluated. 0 disables it.
+	InvalidTxWindow int `mapstructure:"invalid-tx-window"`
+	// Fraction of invalid txs from which on a peer is reported as bad, which
+	// lowers its score. 0 disables it.
+	InvalidTxRatioBad float64 `mapstructure:"invalid-tx-ratio-bad"`
+	// Fraction of invalid txs from which on a peer is disconnected. 0 disables
+	// it.
+	InvalidTxRatioDisconnect float64 `mapstructure:"invalid-tx-ratio-disconnect"`
+	// Partition txs into shards by "size" (in bytes) or "gas" (gas wanted, as
+	// reported by CheckTx), each with its own capacity budget. "" disables
+	// sharding.
+	ShardBy string `mapstructure:"shard-by"`
+	// Upper bounds, in ascending order, of the size or gas of the txs in each
+	// shard but the last, which holds all larger txs.
+	ShardBounds []int64 `mapstructure:"shard-bounds"`
+	// Fraction of size and max-txs-bytes each shard may use, one per shard.
+	// Empty splits the mempool evenly between the shards.
+	ShardCapacities []float64 `mapstructure:"shard-capacities"`
+	// Classes of txs, as tagged by the app in CheckTx, each with a fraction
+	// of the mempool reserved to it. Txs of other classes, or without a
+	// class, share the rest of the mempool.
+	TxClasses []string `mapstructure:"tx-classes"`
+	// Fraction of size and max-txs-bytes reserved to each class, one per
+	// class. They may add up to at most 1.
+	TxClassCapacities []float64 `mapstructure:"tx-class-capacities"`
+	// Gossip priority of each class, one per class. Txs of the classes with
+	// the highest priority are gossiped first to new peers. Txs of other
+	// classes have priority 0. Empty gives all classes priority 0.
+	TxClassGossipPriorities []int64 `mapstructure:"tx-class-gossip-priorities"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
@@ -752,6 +1116,13 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:   10000,
 		MaxTxBytes:  1024 * 1024, // 1MB
+
+		CheckTxConcurrency:  1,
+		GossipResumeTimeout: 30 * time.Second,
+
+		InvalidTxWindow:          100,
+		InvalidTxRatioBad:        0.5,
+		InvalidTxRatioDisconnect: 0.9,
 	}
 }
 
@@ -777,6 +1148,99 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max-tx-bytes can't be negative")
 	}
+	if cfg.MaxTxDepth < 0 {
+		return errors.New("max-tx-depth can't be negative")
+	}
+	if cfg.MaxTxRepeatedFields < 0 {
+		return errors.New("max-tx-repeated-fields can't be negative")
+	}
+	if cfg.MinTxPriorityUtilization < 0 || cfg.MinTxPriorityUtilization > 1 {
+		return errors.New("min-tx-priority-utilization must be between 0 and 1")
+	}
+	if cfg.SoftHighWaterMark < 0 || cfg.SoftHighWaterMark > 1 {
+		return errors.New("soft-high-water-mark must be between 0 and 1")
+	}
+	if cfg.HardHighWaterMark < 0 || cfg.HardHighWaterMark > 1 {
+		return errors.New("hard-high-water-mark must be between 0 and 1")
+	}
+	if cfg.SoftHighWaterMark > 0 && cfg.HardHighWaterMark > 0 && cfg.SoftHighWaterMark > cfg.HardHighWaterMark {
+		return errors.New("soft-high-water-mark can't be above hard-high-water-mark")
+	}
+	if cfg.CheckTxConcurrency < 1 {
+		return errors.New("check-tx-concurrency must be positive")
+	}
+	if cfg.GossipResumeTimeout < 0 {
+		return errors.New("gossip-resume-timeout can't be negative")
+	}
+	if cfg.InvalidTxWindow < 0 {
+		return errors.New("invalid-tx-window can't be negative")
+	}
+	if cfg.InvalidTxRatioBad < 0 || cfg.InvalidTxRatioBad > 1 {
+		return errors.New("invalid-tx-ratio-bad must be between 0 and 1")
+	}
+	if cfg.InvalidTxRatioDisconnect < 0 || cfg.InvalidTxRatioDisconnect > 1 {
+		return errors.New("invalid-tx-ratio-disconnect must be between 0 and 1")
+	}
+	switch cfg.ShardBy {
+	case "":
+	case MempoolShardBySize, MempoolShardByGas:
+		if len(cfg.ShardBounds) == 0 {
+			return errors.New("shard-bounds can't be empty when sharding the mempool")
+		}
+	default:
+		return fmt.Errorf("unknown shard-by %q, must be %q, %q or empty",
+			cfg.ShardBy, MempoolShardBySize, MempoolShardByGas)
+	}
+	for i, bound := range cfg.ShardBounds {
+		if bound < 0 {
+			return errors.New("shard-bounds can't be negative")
+		}
+		if i > 0 && bound <= cfg.ShardBounds[i-1] {
+			return errors.New("shard-bounds must be in ascending order")
+		}
+	}
+	if len(cfg.ShardCapacities) > 0 && len(cfg.ShardCapacities) != len(cfg.ShardBounds)+1 {
+		return fmt.Errorf("shard-capacities must have one entry per shard (%d), got %d",
+			len(cfg.ShardBounds)+1, len(cfg.ShardCapacities))
+	}
+	for _, capacity := range cfg.ShardCapacities {
+		if capacity <= 0 || capacity > 1 {
+			return errors.New("shard-capacities must be greater than 0 and at most 1")
+		}
+	}
+	classes := make(map[string]bool, len(cfg.TxClasses))
+	for _, class := range cfg.TxClasses {
+		if class == "" {
+			return errors.New("tx-classes can't be empty strings")
+		}
+		if classes[class] {
+			return fmt.Errorf("duplicate tx class %q", class)
+		}
+		classes[class] = true
+	}
+	if len(cfg.TxClassCapacities) != len(cfg.TxClasses) {
+		return fmt.Errorf("tx-class-capacities must have one entry per class (%d), got %d",
+			len(cfg.TxClasses), len(cfg.TxClassCapacities))
+	}
+	var reserved float64
+	for _, capacity := range cfg.TxClassCapacities {
+		if capacity <= 0 || capacity > 1 {
+			return errors.New("tx-class-capacities must be greater than 0 and at most 1")
+		}
+		reserved += capacity
+	}
+	if reserved > 1 {
+		return fmt.Errorf("tx-class-capacities add up to %v, more than 1", reserved)
+	}
+	if len(cfg.TxClassGossipPriorities) > 0 && len(cfg.TxClassGossipPriorities) != len(cfg.TxClasses) {
+		return fmt.Errorf("tx-class-gossip-priorities must have one entry per class (%d), got %d",
+			len(cfg.TxClasses), len(cfg.TxClassGossipPriorities))
+	}
+	for _, priority := range cfg.TxClassGossipPriorities {
+		if priority < 0 {
+			return errors.New("tx-class-gossip-priorities can't be negative")
+		}
+	}
 	return nil
 }
 
@@ -792,6 +1256,31 @@ type StateSyncConfig struct {
 	TrustHeight   int64         `mapstructure:"trust-height"`
 	TrustHash     string        `mapstructure:"trust-hash"`
 	DiscoveryTime time.Duration `mapstructure:"discovery-time"`
+
+	// Number of witnesses, i.e. the rpc-servers besides the first one, which
+	// must independently serve the header of a snapshot's height + 1 before
+	// its app hash is trusted. 0 only relies on light client verification.
+	WitnessQuorum int `mapstructure:"witness-quorum"`
+
+	// If set, a node with local state still state syncs, restoring a delta
+	// snapshot of the changes since its last height on top of its app state,
+	// or a full snapshot. It falls back to fast sync if no snapshot can be
+	// restored. The blocks it had are discarded once a snapshot is restored.
+	DeltaSync bool `mapstructure:"delta-sync"`
+
+	// Compression requested from peers when fetching snapshot chunks
+	// ("none" or "gzip"). Peers may ignore it and send chunks uncompressed.
+	ChunkCompression string `mapstructure:"chunk-compression"`
+
+	// Maximum size of a snapshot chunk in bytes, both as sent over the wire
+	// and once decompressed. 0 means chunks are only limited by the maximum
+	// p2p message size, unless they are streamed.
+	MaxChunkSize int `mapstructure:"max-chunk-size"`
+
+	// Size in bytes of the parts in which snapshot chunks are streamed from
+	// peers and to the application, if they support it, so that large chunks
+	// don't have to be held in memory at once. 0 disables chunk streaming.
+	ChunkWindowSize int `mapstructure:"chunk-window-size"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -806,8 +1295,10 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 // DefaultStateSyncConfig returns a default configuration for the state sync service
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:   168 * time.Hour,
-		DiscoveryTime: 15 * time.Second,
+		TrustPeriod:      168 * time.Hour,
+		DiscoveryTime:    15 * time.Second,
+		ChunkCompression: ChunkCompressionNone,
+		ChunkWindowSize:  1024 * 1024, // 1MB
 	}
 }
 
@@ -818,36 +1309,62 @@ func TestStateSyncConfig() *StateSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
+	switch cfg.ChunkCompression {
+	case ChunkCompressionNone, ChunkCompressionGzip:
+	default:
+		return fmt.Errorf("unknown chunk-compression %q", cfg.ChunkCompression)
+	}
+	if cfg.MaxChunkSize < 0 {
+		return errors.New("max-chunk-size can't be negative")
+	}
+	if cfg.ChunkWindowSize < 0 {
+		return errors.New("chunk-window-size can't be negative")
+	}
+	if cfg.WitnessQuorum < 0 {
+		return errors.New("witness-quorum can't be negative")
+	}
 	if cfg.Enable {
-		if len(cfg.RPCServers) == 0 {
-			return errors.New("rpc-servers is required")
-		}
-		if len(cfg.RPCServers) < 2 {
-			return errors.New("at least two rpc-servers entries is required")
+		if err := cfg.ValidateLightClient(); err != nil {
+			return err
 		}
-		for _, server := range cfg.RPCServers {
-			if len(server) == 0 {
-				return errors.New("found empty rpc-servers entry")
-			}
+		if cfg.WitnessQuorum > len(cfg.RPCServers)-1 {
+			return fmt.Errorf("witness-quorum %d exceeds the %d witnesses in rpc-servers",
+				cfg.WitnessQuorum, len(cfg.RPCServers)-1)
 		}
 		if cfg.DiscoveryTime != 0 && cfg.DiscoveryTime < 5*time.Second {
 			return errors.New("discovery time must be 0s or greater than five seconds")
 		}
+	}
+	return nil
+}
 
-		if cfg.TrustPeriod <= 0 {
-			return errors.New("trusted-period is required")
-		}
-		if cfg.TrustHeight <= 0 {
-			return errors.New("trusted-height is required")
-		}
-		if len(cfg.TrustHash) == 0 {
-			return errors.New("trusted-hash is required")
-		}
-		_, err := hex.DecodeString(cfg.TrustHash)
-		if err != nil {
-			return fmt.Errorf("invalid trusted-hash: %w", err)
+// ValidateLightClient validates the RPC servers and the trust options used to
+// verify light blocks, by state sync and by header-only nodes.
+func (cfg *StateSyncConfig) ValidateLightClient() error {
+	if len(cfg.RPCServers) == 0 {
+		return errors.New("rpc-servers is required")
+	}
+	if len(cfg.RPCServers) < 2 {
+		return errors.New("at least two rpc-servers entries is required")
+	}
+	for _, server := range cfg.RPCServers {
+		if len(server) == 0 {
+			return errors.New("found empty rpc-servers entry")
 		}
 	}
+	if cfg.TrustPeriod <= 0 {
+		return errors.New("trusted-period is required")
+	}
+	if cfg.TrustHeight <= 0 {
+		return errors.New("trusted-height is required")
+	}
+	if len(cfg.TrustHash) == 0 {
+		return errors.New("trusted-hash is required")
+	}
+	_, err := hex.DecodeString(cfg.TrustHash)
+	if err != nil {
+		return fmt.Errorf("invalid trusted-hash: %w", err)
+	}
 	return nil
 }
 
@@ -857,12 +1374,20 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// StallTimeout is how long fast sync may make no progress before it is
+	// considered stalled. A stalled sync drops its slowest peers, asks for new
+	// peers and publishes a FastSyncStall event, and does so again after every
+	// further StallTimeout without progress. Only the v0 reactor detects
+	// stalls; 0 disables the detection.
+	StallTimeout time.Duration `mapstructure:"stall-timeout"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
-		Version: BlockchainV0,
+		Version:      BlockchainV0,
+		StallTimeout: 20 * time.Second,
 	}
 }
 
@@ -873,6 +1398,9 @@ func TestFastSyncConfig() *FastSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *FastSyncConfig) ValidateBasic() error {
+	if cfg.StallTimeout < 0 {
+		return errors.New("stall-timeout can't be negative")
+	}
 	switch cfg.Version {
 	case BlockchainV0:
 		return nil
@@ -918,11 +1446,46 @@ type ConsensusConfig struct {
 	CreateEmptyBlocks         bool          `mapstructure:"create-empty-blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create-empty-blocks-interval"`
 
+	// DevMode commits blocks without waiting on any timeouts when this node
+	// holds all of the voting power. Blocks are produced on demand, i.e. only
+	// when txs are available or when one is requested through the RPC.
+	DevMode bool `mapstructure:"dev-mode"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer-gossip-sleep-duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
+	// Max rate, in bytes/second, at which block parts are sent from the block
+	// store to each peer catching up on a previous height. At least one block
+	// part per second is sent. 0 means unlimited.
+	PeerCatchupSendRate int64 `mapstructure:"peer-catchup-send-rate"`
+
+	// Deadline for the app to answer ABCI Commit, 0 means none. On a breach,
+	// the node logs a critical error, publishes an AppCommitTimeout event and
+	// writes a debug bundle, the goroutine stacks and the heap profile, to
+	// AppCommitTimeoutDebugDir, unless it's empty. Then, if
+	// AppCommitTimeoutHalt is set, consensus halts, otherwise the node keeps
+	// waiting for the app.
+	AppCommitTimeout         time.Duration `mapstructure:"app-commit-timeout"`
+	AppCommitTimeoutHalt     bool          `mapstructure:"app-commit-timeout-halt"`
+	AppCommitTimeoutDebugDir string        `mapstructure:"app-commit-timeout-debug-dir"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
+
+	// MinPeersToStart is the number of peers the node must see before it signs
+	// any votes or proposals, 0 disables the check. Until then, it follows
+	// consensus without participating in it.
+	MinPeersToStart int `mapstructure:"min-peers-to-start"`
+	// MaxHeightLagToStart is how many heights the node may be behind the
+	// highest of these peers to start signing.
+	MaxHeightLagToStart int64 `mapstructure:"max-height-lag-to-start"`
+
+	// Bounds on the drift of the time of a committed block from the time the
+	// node received it, and of the interval between two blocks from the
+	// interval between receiving them. Exceeding either logs an error and
+	// publishes a BlockTimeDrift event; 0 disables the bound.
+	MaxBlockTimeDrift     time.Duration `mapstructure:"max-block-time-drift"`
+	MaxBlockIntervalDrift time.Duration `mapstructure:"max-block-interval-drift"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -939,9 +1502,18 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		SkipTimeoutCommit:           false,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		DevMode:                     false,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		PeerCatchupSendRate:         1024000, // 1 MB/s
+		AppCommitTimeout:            time.Minute,
+		AppCommitTimeoutHalt:        false,
+		AppCommitTimeoutDebugDir:    filepath.Join(defaultDataDir, "debug"),
 		DoubleSignCheckHeight:       int64(0),
+		MinPeersToStart:             0,
+		MaxHeightLagToStart:         1,
+		MaxBlockTimeDrift:           0,
+		MaxBlockIntervalDrift:       0,
 	}
 }
 
@@ -1002,6 +1574,15 @@ func (cfg *ConsensusConfig) WalFile() string {
 	return rootify(cfg.WalPath, cfg.RootDir)
 }
 
+// AppCommitTimeoutDebugPath returns the full path to the directory of the
+// debug bundles written on an app commit timeout, or "" if there is none.
+func (cfg *ConsensusConfig) AppCommitTimeoutDebugPath() string {
+	if cfg.AppCommitTimeoutDebugDir == "" {
+		return ""
+	}
+	return rootify(cfg.AppCommitTimeoutDebugDir, cfg.RootDir)
+}
+
 // SetWalFile sets the path to the write-ahead log file
 func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
@@ -1040,9 +1621,27 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer-query-maj23-sleep-duration can't be negative")
 	}
+	if cfg.PeerCatchupSendRate < 0 {
+		return errors.New("peer-catchup-send-rate can't be negative")
+	}
+	if cfg.AppCommitTimeout < 0 {
+		return errors.New("app-commit-timeout can't be negative")
+	}
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
+	if cfg.MinPeersToStart < 0 {
+		return errors.New("min-peers-to-start can't be negative")
+	}
+	if cfg.MaxHeightLagToStart < 0 {
+		return errors.New("max-height-lag-to-start can't be negative")
+	}
+	if cfg.MaxBlockTimeDrift < 0 {
+		return errors.New("max-block-time-drift can't be negative")
+	}
+	if cfg.MaxBlockIntervalDrift < 0 {
+		return errors.New("max-block-interval-drift can't be negative")
+	}
 	return nil
 }
 
@@ -1058,6 +1657,8 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 // TxIndexConfig defines the configuration for the transaction indexer,
 // including composite keys to index.
 type TxIndexConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// The backend database list to back the indexer.
 	// If list contains `null`, meaning no indexer service will be used.
 	//
@@ -1066,18 +1667,87 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "stream" - forwards events to a NATS message bus (see StreamURL).
+	//   5) "parquet" - writes blocks, tx results and events to Parquet files
+	//      (see ParquetDir).
 	Indexer []string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The message bus the "stream" event sink publishes to, the URL format:
+	// nats://[<user>:<password>@|<token>@]<host>:<port>
+	// The tls scheme instead of nats requires TLS.
+	StreamURL string `mapstructure:"stream-url"`
+
+	// The PEM file of the CAs the "stream" event sink trusts, instead of the
+	// system ones, to verify the certificate of the message bus, relative to
+	// the home directory unless absolute. Setting it requires TLS.
+	StreamTLSCAFile string `mapstructure:"stream-tls-ca-file"`
+
+	// The topics new blocks, tx results and validator set updates are
+	// published to by the "stream" event sink. An empty topic disables
+	// publishing the corresponding events.
+	StreamBlockTopic            string `mapstructure:"stream-block-topic"`
+	StreamTxTopic               string `mapstructure:"stream-tx-topic"`
+	StreamValidatorUpdatesTopic string `mapstructure:"stream-validator-updates-topic"`
+
+	// The directory the "parquet" event sink writes its files to, relative
+	// to the home directory unless absolute.
+	ParquetDir string `mapstructure:"parquet-dir"`
+
+	// The number of blocks the "parquet" event sink buffers before writing
+	// them to a new set of files.
+	ParquetRotateBlocks int64 `mapstructure:"parquet-rotate-blocks"`
+
+	// When true, the indexed events of the heights pruned from the block
+	// store, e.g. below the RetainHeight requested by the app, are pruned too.
+	PruneWithBlocks bool `mapstructure:"prune-with-blocks"`
+
+	// The number of latest heights whose indexed events are retained,
+	// independently of the blocks. 0 retains all heights. Together with
+	// PruneWithBlocks, the events below the higher retain height are pruned.
+	RetainBlocks int64 `mapstructure:"retain-blocks"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: []string{"kv"},
+		Indexer:                     []string{"kv"},
+		StreamBlockTopic:            "tendermint.blocks",
+		StreamTxTopic:               "tendermint.txs",
+		StreamValidatorUpdatesTopic: "tendermint.validator_updates",
+		ParquetDir:                  "data/parquet",
+		ParquetRotateBlocks:         1000,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.RetainBlocks < 0 {
+		return errors.New("retain-blocks can't be negative")
 	}
+	if cfg.ParquetRotateBlocks <= 0 {
+		return errors.New("parquet-rotate-blocks must be positive")
+	}
+	return nil
+}
+
+// StreamTLSCAPath returns the full path to the PEM file of the CAs trusted by
+// the "stream" event sink, or "" if it is not set.
+func (cfg *TxIndexConfig) StreamTLSCAPath() string {
+	if cfg.StreamTLSCAFile == "" {
+		return ""
+	}
+	return rootify(cfg.StreamTLSCAFile, cfg.RootDir)
+}
+
+// ParquetPath returns the full path to the directory the "parquet" event sink
+// writes its files to.
+func (cfg *TxIndexConfig) ParquetPath() string {
+	return rootify(cfg.ParquetDir, cfg.RootDir)
 }
 
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1134,6 +1804,77 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// PreflightConfig
+
+// PreflightConfig defines the checks of the environment the node runs on
+// start, e.g. whether the clock is in sync and the ports are available.
+type PreflightConfig struct {
+	// If true, the preflight checks are run when the node starts.
+	Enable bool `mapstructure:"enable"`
+
+	// If true, the node refuses to start if a check fails. Otherwise, failed
+	// checks are logged as warnings.
+	Strict bool `mapstructure:"strict"`
+
+	// NTP server the local clock is compared with, as host:port. An empty
+	// address disables the clock check.
+	NTPServer string `mapstructure:"ntp-server"`
+
+	// Max difference between the local clock and the clock of the NTP server.
+	MaxClockSkew time.Duration `mapstructure:"max-clock-skew"`
+
+	// Min free space, in bytes, on the disk of the data directory.
+	MinFreeDiskSpace int64 `mapstructure:"min-free-disk-space"`
+
+	// Min limit of open file descriptors of the process (ulimit -n).
+	MinOpenFiles int64 `mapstructure:"min-open-files"`
+
+	// Timeout of the checks which connect to other hosts, i.e. the NTP server
+	// and a remote signer.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultPreflightConfig returns a default configuration for the preflight
+// checks.
+func DefaultPreflightConfig() *PreflightConfig {
+	return &PreflightConfig{
+		Enable:           true,
+		Strict:           false,
+		NTPServer:        "pool.ntp.org:123",
+		MaxClockSkew:     1 * time.Second,
+		MinFreeDiskSpace: 1 << 30, // 1GB
+		MinOpenFiles:     1024,
+		Timeout:          2 * time.Second,
+	}
+}
+
+// TestPreflightConfig returns a configuration for the preflight checks which
+// doesn't query an NTP server.
+func TestPreflightConfig() *PreflightConfig {
+	cfg := DefaultPreflightConfig()
+	cfg.NTPServer = ""
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *PreflightConfig) ValidateBasic() error {
+	if cfg.MaxClockSkew < 0 {
+		return errors.New("max-clock-skew can't be negative")
+	}
+	if cfg.MinFreeDiskSpace < 0 {
+		return errors.New("min-free-disk-space can't be negative")
+	}
+	if cfg.MinOpenFiles < 0 {
+		return errors.New("min-open-files can't be negative")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +38,22 @@ func TestConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestConfigValidateBasicHeaderMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Mode = ModeHeader
+
+	// header nodes require the light client options
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.StateSync.RPCServers = []string{"a:26657", "b:26657"}
+	cfg.StateSync.TrustHeight = 1
+	cfg.StateSync.TrustHash = "0a0b"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StateSync.TrustHash = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestTLSConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	cfg := DefaultConfig()
@@ -59,6 +77,60 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ShutdownDrainTimeout = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.SystemdWatchdogMaxStall = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorMaxSignLatency = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.BlockStoreSync = "sometimes"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.BlockStoreSync = BlockStoreSyncInterval
+	cfg.BlockStoreSyncInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.GenesisAppStateChunkSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ChainRegistry = "https://registry.example.com"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ChainRegistryChainID = "test-chain"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ChainRegistryPubKey = "deadbeef"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ChainRegistryPubKey = strings.Repeat("ab", 32)
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestBaseConfigValidateBasicSignerSettings(t *testing.T) {
+	for _, mode := range []string{ModeFull, ModeSeed, ModeHeader} {
+		cfg := TestBaseConfig()
+		cfg.Mode = mode
+		assert.NoError(t, cfg.ValidateBasic())
+
+		// a node which doesn't sign rejects the settings of the signer
+		cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+		assert.Error(t, cfg.ValidateBasic(), mode)
+		cfg.PrivValidatorListenAddr = ""
+		cfg.PrivValidatorAuditLog = "data/priv_validator_audit.log"
+		assert.Error(t, cfg.ValidateBasic(), mode)
+
+		cfg.Mode = ModeValidator
+		cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+		assert.NoError(t, cfg.ValidateBasic())
+	}
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -73,6 +145,8 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxConcurrentABCIQueries",
+		"MaxBroadcastTxBatch",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -80,6 +154,20 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Multiplex = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TLSCertFile, cfg.TLSKeyFile = "cert.pem", "key.pem"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TLSCertFile, cfg.TLSKeyFile = "", ""
+
+	cfg.Bech32ValidatorPrefix = "cosmosvalcons"
+	cfg.Bech32NodeIDPrefix = "node"
+	assert.NoError(t, cfg.ValidateBasic())
+	for _, prefix := range []string{"Cosmos", "cosmos valcons", strings.Repeat("a", 84)} {
+		cfg.Bech32ValidatorPrefix = prefix
+		assert.Error(t, cfg.ValidateBasic(), prefix)
+	}
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -93,6 +181,10 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"HandshakeTimeout",
+		"DialTimeout",
+		"AuthenticateTimeout",
+		"MaxConcurrentDials",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -100,6 +192,21 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	// dialing over TLS requires the new p2p stack
+	cfg.PersistentPeers = "tls://0123456789abcdef0123456789abcdef01234567@127.0.0.1:26656"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DisableLegacy = true
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg = TestP2PConfig()
+	cfg.AddrBookBackupInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AddrBookBackupInterval = time.Hour
+	cfg.AddrBookBackups = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AddrBookBackupInterval = 0
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -111,6 +218,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"MaxTxDepth",
+		"MaxTxRepeatedFields",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -118,11 +227,105 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.MinTxPriorityUtilization = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MinTxPriorityUtilization = -0.5
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MinTxPriorityUtilization = 0
+
+	cfg.CheckTxConcurrency = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CheckTxConcurrency = 1
+
+	cfg.GossipResumeTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GossipResumeTimeout = 0
+
+	cfg.InvalidTxWindow = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.InvalidTxWindow = 0
+
+	cfg.InvalidTxRatioBad = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.InvalidTxRatioBad = 0
+
+	cfg.InvalidTxRatioDisconnect = -0.5
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.InvalidTxRatioDisconnect = 0
+
+	cfg.SoftHighWaterMark = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SoftHighWaterMark = 0.9
+	cfg.HardHighWaterMark = 0.8
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.HardHighWaterMark = 0.95
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.ShardBy = "priority"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ShardBy = MempoolShardBySize
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ShardBounds = []int64{1024, 512}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ShardBounds = []int64{512, 1024}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ShardCapacities = []float64{0.5, 0.5}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ShardCapacities = []float64{0.5, 0.5, 1.5}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ShardCapacities = []float64{0.5, 0.5, 0.5}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.TxClasses = []string{"oracle", "ibc"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxClassCapacities = []float64{0.6, 0.5}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxClassCapacities = []float64{0.1, 0.2}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TxClassGossipPriorities = []int64{1}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxClassGossipPriorities = []int64{1, -1}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxClassGossipPriorities = []int64{2, 1}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TxClasses = []string{"oracle", "oracle"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TxClasses = []string{"oracle", ""}
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.ChunkCompression = ChunkCompressionGzip
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.ChunkCompression = "zip"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.ChunkCompression = ChunkCompressionNone
+
+	cfg.MaxChunkSize = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.MaxChunkSize = 0
+
+	cfg.ChunkWindowSize = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.ChunkWindowSize = 0
+
+	cfg.WitnessQuorum = -1
+	require.Error(t, cfg.ValidateBasic())
+
+	// the quorum can't exceed the rpc-servers besides the primary
+	cfg.Enable = true
+	cfg.RPCServers = []string{"a:26657", "b:26657", "c:26657"}
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0a0b"
+	cfg.WitnessQuorum = 2
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.WitnessQuorum = 3
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -135,6 +338,13 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestFastSyncConfig()
+	cfg.StallTimeout = 0
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.StallTimeout = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -161,7 +371,13 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerGossipSleepDuration negative":     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
+		"PeerCatchupSendRate negative":         {func(c *ConsensusConfig) { c.PeerCatchupSendRate = -1 }, true},
+		"AppCommitTimeout negative":            {func(c *ConsensusConfig) { c.AppCommitTimeout = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"MinPeersToStart negative":             {func(c *ConsensusConfig) { c.MinPeersToStart = -1 }, true},
+		"MaxHeightLagToStart negative":         {func(c *ConsensusConfig) { c.MaxHeightLagToStart = -1 }, true},
+		"MaxBlockTimeDrift negative":           {func(c *ConsensusConfig) { c.MaxBlockTimeDrift = -1 }, true},
+		"MaxBlockIntervalDrift negative":       {func(c *ConsensusConfig) { c.MaxBlockIntervalDrift = -1 }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -179,6 +395,29 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.RetainBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RetainBlocks = 0
+
+	cfg.ParquetRotateBlocks = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigStreamTLSCAPath(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	cfg.RootDir = "/home"
+	assert.Equal(t, "", cfg.StreamTLSCAPath())
+
+	cfg.StreamTLSCAFile = "config/nats-ca.pem"
+	assert.Equal(t, filepath.Join("/home", "config", "nats-ca.pem"), cfg.StreamTLSCAPath())
+	cfg.StreamTLSCAFile = "/etc/nats-ca.pem"
+	assert.Equal(t, "/etc/nats-ca.pem", cfg.StreamTLSCAPath())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -187,3 +426,21 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
 }
+
+func TestPreflightConfigValidateBasic(t *testing.T) {
+	cfg := TestPreflightConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	fieldsToTest := []string{
+		"MaxClockSkew",
+		"MinFreeDiskSpace",
+		"MinOpenFiles",
+		"Timeout",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+	}
+}
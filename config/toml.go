@@ -85,7 +85,7 @@ proxy-app = "{{ .BaseConfig.ProxyApp }}"
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
-# Mode of Node: full | validator | seed
+# Mode of Node: full | validator | seed | header
 # * validator node
 #   - all reactors
 #   - with priv_validator_key.json, priv_validator_state.json
@@ -95,6 +95,14 @@ moniker = "{{ .BaseConfig.Moniker }}"
 # * seed node
 #   - only P2P, PEX Reactor
 #   - No priv_validator_key.json, priv_validator_state.json
+#   - No RPC
+# * header node
+#   - a light node: no P2P nor ABCI app, syncs headers from [statesync]
+#     rpc-servers, verifying them as a light client
+#   - only stores headers, commits and validator sets, served over the RPC
+#   - No priv_validator_key.json, priv_validator_state.json
+# Only validator nodes sign: priv-validator-laddr and
+# priv-validator-audit-log-file are rejected in the other modes.
 mode = "{{ .BaseConfig.Mode }}"
 
 # If this node is many blocks behind the tip of the chain, FastSync
@@ -126,6 +134,21 @@ db-backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db-dir = "{{ js .BaseConfig.DBPath }}"
 
+# When the block store flushes the saved blocks to disk: always | interval | never
+# * always
+#   - flushes every block before it is applied
+# * interval
+#   - flushes a block if the last flush is older than block-store-sync-interval
+# * never
+#   - leaves flushing to the OS
+# The state is always flushed. If the node crashes after applying blocks
+# which were not flushed, it can't restart from its data and must resync.
+# Only use interval or never on nodes that can be resynced, e.g. on slow disks.
+block-store-sync = "{{ .BaseConfig.BlockStoreSync }}"
+
+# Max time between two block store flushes, with block-store-sync = "interval"
+block-store-sync-interval = "{{ .BaseConfig.BlockStoreSyncInterval }}"
+
 # Output level for logging, including package level options
 log-level = "{{ .BaseConfig.LogLevel }}"
 
@@ -137,6 +160,27 @@ log-format = "{{ .BaseConfig.LogFormat }}"
 # Path to the JSON file containing the initial validator set and other meta data
 genesis-file = "{{ js .BaseConfig.Genesis }}"
 
+# If set, the app_state of the genesis file is not loaded into memory but
+# streamed to the app in InitChain requests with chunks of this many bytes, so
+# that genesis files of several GBs can be used. The app must support app state
+# streaming, and the /genesis RPC endpoints omit the app state. 0 disables
+# streaming.
+genesis-app-state-chunk-size = {{ .BaseConfig.GenesisAppStateChunkSize }}
+
+# URL of a chain registry to bootstrap the node from. If set, the node fetches
+# the entry of chain-registry-chain-id from the registry on start, downloads
+# the genesis file if it doesn't exist yet, and adds the seeds and persistent
+# peers of the entry to the ones configured below. The genesis file must match
+# the hash in the entry.
+chain-registry = "{{ js .BaseConfig.ChainRegistry }}"
+
+# Chain ID of the network to join from the chain registry
+chain-registry-chain-id = "{{ js .BaseConfig.ChainRegistryChainID }}"
+
+# Hex-encoded ed25519 public key the chain registry signs its entries with.
+# Entries with a missing or invalid signature are rejected.
+chain-registry-pubkey = "{{ js .BaseConfig.ChainRegistryPubKey }}"
+
 # Path to the JSON file containing the private key to use as a validator in the consensus protocol
 priv-validator-key-file = "{{ js .BaseConfig.PrivValidatorKey }}"
 
@@ -158,9 +202,25 @@ priv-validator-client-key-file = "{{ js .BaseConfig.PrivValidatorClientKey }}"
 # Path Root Certificate Authority used to sign both client and server certificates
 priv-validator-certificate-authority = "{{ js .BaseConfig.PrivValidatorRootCA }}"
 
+# Max time a remote signer, connected over a socket, may take to sign a vote or
+# a proposal. Slower signatures are logged as errors and counted in the
+# privval_slow_signs metric, since they can cause missed blocks.
+# 0 disables the check.
+priv-validator-max-sign-latency = "{{ .BaseConfig.PrivValidatorMaxSignLatency }}"
+
+# Path to the append-only, hash-chained log of every vote and proposal the
+# validator signed, for audits, e.g. "data/priv_validator_audit.log". Inspect
+# and verify it with "tendermint audit-log". Empty disables the log.
+priv-validator-audit-log-file = "{{ js .BaseConfig.PrivValidatorAuditLog }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
+# Path to the JSON file containing the previous node key, after rotating the node key with
+# "tendermint rotate-node-key". If it exists, the node publishes a forwarding record signed
+# by the previous key, such that peers move the previous node ID over to the new one.
+previous-node-key-file = "{{ js .BaseConfig.PreviousNodeKey }}"
+
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
@@ -168,6 +228,24 @@ abci = "{{ .BaseConfig.ABCI }}"
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
 
+# Max time each phase of a graceful shutdown may take. On shutdown the node
+# stops accepting txs over RPC, drains the in-flight ABCI calls, finishes the
+# current consensus step and flushes the WAL, and then closes its p2p
+# connections and stores.
+shutdown-drain-timeout = "{{ .BaseConfig.ShutdownDrainTimeout }}"
+
+# If the node runs as a systemd service with a watchdog (WatchdogSec), it
+# pings the watchdog only while it makes progress: consensus moves to a new
+# height, round or step, or blocks are synced. If the node makes no progress
+# for this long, it stops pinging and systemd considers it hung. 0 pings the
+# watchdog as long as the node runs.
+systemd-watchdog-max-stall = "{{ .BaseConfig.SystemdWatchdogMaxStall }}"
+
+# The node refuses to start if its state was written by a newer version of
+# Tendermint, since an older version can silently corrupt newer state. Set to
+# true to start anyway, e.g. after restoring the state from a backup.
+allow-downgrade = {{ .BaseConfig.AllowDowngrade }}
+
 #######################################################################
 ###                 Advanced Configuration Options                  ###
 #######################################################################
@@ -238,6 +316,25 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of /abci_query requests the application handles concurrently.
+# Further queries wait. Identical concurrent queries are sent to the
+# application once.
+# 0 - unlimited.
+max-concurrent-abci-queries = {{ .RPC.MaxConcurrentABCIQueries }}
+
+# Maximum number of txs of a /broadcast_tx_batch request, whose txs are all
+# checked in one call. The request body is also limited by max-body-bytes.
+# 0 - disables /broadcast_tx_batch.
+max-broadcast-tx-batch = {{ .RPC.MaxBroadcastTxBatch }}
+
+# Serve the RPC server, and the gRPC server if grpc-laddr is set, on the p2p
+# listen address (p2p.laddr) along with the p2p connections, which are told
+# apart by their first bytes. laddr and grpc-laddr then only enable the servers,
+# their addresses are unused. TLS isn't supported. At most as many connections
+# as p2p.max-num-inbound-peers, max-open-connections and
+# grpc-max-open-connections add up to are told apart at once.
+multiplex = {{ .RPC.Multiplex }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -256,6 +353,15 @@ tls-key-file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
+# Bech32 human-readable prefix of validator addresses, e.g. "cosmosvalcons".
+# If set, RPC responses include validator addresses in bech32 in addition to
+# the raw hex.
+bech32-validator-prefix = "{{ .RPC.Bech32ValidatorPrefix }}"
+
+# Bech32 human-readable prefix of node IDs. If set, RPC responses include node
+# IDs in bech32 in addition to the raw hex.
+bech32-node-id-prefix = "{{ .RPC.Bech32NodeIDPrefix }}"
+
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -301,6 +407,14 @@ addr-book-file = "{{ js .P2P.AddrBook }}"
 # Set false for private or local networks
 addr-book-strict = {{ .P2P.AddrBookStrict }}
 
+# Interval between compressed backups of the address book, written to the
+# addrbook-backups directory next to the address book. The backups can be
+# exported and imported with "tendermint addrbook". 0 disables the backups.
+addr-book-backup-interval = "{{ .P2P.AddrBookBackupInterval }}"
+
+# Number of address book backups to keep
+addr-book-backups = {{ .P2P.AddrBookBackups }}
+
 # Maximum number of inbound peers
 #
 # TODO: Remove once p2p refactor is complete in favor of MaxConnections.
@@ -325,6 +439,16 @@ unconditional-peer-ids = "{{ .P2P.UnconditionalPeerIDs }}"
 # Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 persistent-peers-max-dial-period = "{{ .P2P.PersistentPeersMaxDialPeriod }}"
 
+# Set true to probe the latency of several candidates from the address book
+# per outbound peer to dial, by connecting to them, and to dial the
+# lowest-latency ones for low-latency-peer-fraction of the outbound peers.
+# The others are picked from network groups (/16 for IPv4) no other peer is
+# in, to keep the peers diverse. Only used by the legacy p2p stack.
+dial-latency-probe = {{ .P2P.DialLatencyProbe }}
+
+# Fraction of the outbound peers picked for their low latency, between 0 and 1
+low-latency-peer-fraction = {{ .P2P.LowLatencyPeerFraction }}
+
 # Time to wait before flushing messages out on the connection
 flush-throttle-timeout = "{{ .P2P.FlushThrottleTimeout }}"
 
@@ -347,9 +471,30 @@ private-peer-ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow-duplicate-ip = {{ .P2P.AllowDuplicateIP }}
 
-# Peer connection configuration.
+# Peer connection configuration: the max time to dial a peer, to authenticate
+# it (the secret connection handshake), and to handshake with it, including the
+# authentication. 0 means no timeout.
 handshake-timeout = "{{ .P2P.HandshakeTimeout }}"
 dial-timeout = "{{ .P2P.DialTimeout }}"
+authenticate-timeout = "{{ .P2P.AuthenticateTimeout }}"
+
+# Max number of peers dialed and handshaked concurrently, so that slow peers
+# can't hold up the connections to the others. 0 uses the number of CPUs.
+max-concurrent-dials = {{ .P2P.MaxConcurrentDials }}
+
+# Set true to accept inbound connections authenticated with TLS, with
+# certificates pinned to the node IDs, as well as with the secret connection.
+# Outbound connections use TLS for the peers whose address has the tls://
+# scheme, e.g. "tls://<ID>@<IP>:26656" in persistent-peers, which requires
+# disable-legacy. Requires an ed25519 node key.
+accept-tls = {{ .P2P.AcceptTLS }}
+
+# Set true to publish a record with the moniker, version, website and contact
+# of this node, signed with the node key, to peers. Peers relay the record, so
+# that explorers can look it up at /net_info.
+publish-metadata = {{ .P2P.PublishMetadata }}
+metadata-website = "{{ .P2P.MetadataWebsite }}"
+metadata-contact = "{{ .P2P.MetadataContact }}"
 
 #######################################################
 ###          Mempool Configuration Option          ###
@@ -379,11 +524,96 @@ keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 # NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 max-tx-bytes = {{ .Mempool.MaxTxBytes }}
 
+# Maximum nesting depth of the messages of a protobuf encoded transaction,
+# checked before the application decodes it, on transactions submitted over RPC
+# and received from peers alike. Transactions which aren't protobuf messages
+# pass. 0 disables it.
+max-tx-depth = {{ .Mempool.MaxTxDepth }}
+
+# Maximum number of times a field is repeated in a message of a protobuf
+# encoded transaction, checked like max-tx-depth. 0 disables it.
+max-tx-repeated-fields = {{ .Mempool.MaxTxRepeatedFields }}
+
 # Maximum size of a batch of transactions to send to a peer
 # Including space needed by encoding (one varint per transaction).
 # XXX: Unused due to https://github.com/tendermint/tendermint/issues/5796
 max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Reject transactions whose CheckTx priority is below this value, so they are
+# neither added to the mempool nor gossiped, once the mempool utilization
+# reaches min-tx-priority-utilization.
+min-tx-priority = {{ .Mempool.MinTxPriority }}
+
+# Utilization of the mempool from which on min-tx-priority is enforced, between
+# 0 and 1: the greater of the fraction of size and of max-txs-bytes in use.
+# 0 enforces min-tx-priority regardless of utilization.
+min-tx-priority-utilization = {{ .Mempool.MinTxPriorityUtilization }}
+
+# Utilization of the mempool, between 0 and 1, from which on transactions
+# submitted over RPC with a CheckTx priority below min-tx-priority are rejected
+# with the retriable code 1 of the "mempool" codespace (mempool congested), while
+# transactions from peers are still accepted and gossiped. 0 disables it.
+soft-high-water-mark = {{ .Mempool.SoftHighWaterMark }}
+
+# Utilization of the mempool, between 0 and 1, from which on all new
+# transactions are rejected, whether submitted over RPC or received from peers.
+# 0 disables it.
+hard-high-water-mark = {{ .Mempool.HardHighWaterMark }}
+
+# Number of ABCI connections used to check new transactions in parallel. The
+# application must support concurrent CheckTx calls over different connections.
+# Rechecks are all sent over a single connection, in order.
+check-tx-concurrency = {{ .Mempool.CheckTxConcurrency }}
+
+# How long the mempool remembers up to which transaction it gossiped to a peer
+# after the peer disconnects. If the peer reconnects in time, gossip resumes
+# from there rather than from the front of the mempool. 0 disables it.
+gossip-resume-timeout = "{{ .Mempool.GossipResumeTimeout }}"
+
+# Number of transactions received from a peer after which the fraction of them
+# which were invalid is evaluated. 0 disables it.
+invalid-tx-window = {{ .Mempool.InvalidTxWindow }}
+
+# Fraction of invalid transactions from which on a peer is reported as bad,
+# which lowers its score, so it is preferred less when connecting to peers.
+# 0 disables it.
+invalid-tx-ratio-bad = {{ .Mempool.InvalidTxRatioBad }}
+
+# Fraction of invalid transactions from which on a peer is disconnected.
+# 0 disables it.
+invalid-tx-ratio-disconnect = {{ .Mempool.InvalidTxRatioDisconnect }}
+
+# Partition transactions into shards by "size" (in bytes) or "gas" (gas wanted,
+# as reported by CheckTx), each with its own capacity budget, so that a flood of
+# small transactions can't crowd out a few large ones, or vice versa. Empty
+# disables sharding.
+shard-by = "{{ .Mempool.ShardBy }}"
+
+# Upper bounds, in ascending order, of the size or gas of the transactions in
+# each shard but the last, which holds all larger transactions. E.g. [1024, 65536]
+# makes three shards.
+shard-bounds = [{{ range $i, $e := .Mempool.ShardBounds }}{{if $i}}, {{end}}{{ $e }}{{end}}]
+
+# Fraction of size and max-txs-bytes each shard may use, one per shard. They may
+# add up to more than 1, the limits of the whole mempool still apply. Empty
+# splits the mempool evenly between the shards.
+shard-capacities = [{{ range $i, $e := .Mempool.ShardCapacities }}{{if $i}}, {{end}}{{ $e }}{{end}}]
+
+# Classes of transactions, as tagged by the application in CheckTx (tx_class),
+# each with a fraction of the mempool reserved to it, e.g. ["oracle"]. The
+# transactions of other classes, or without a class, share the rest of the
+# mempool.
+tx-classes = [{{ range $i, $e := .Mempool.TxClasses }}{{if $i}}, {{end}}{{ printf "%q" $e }}{{end}}]
+
+# Fraction of size and max-txs-bytes reserved to each class, one per class,
+# e.g. [0.1]. They may add up to at most 1.
+tx-class-capacities = [{{ range $i, $e := .Mempool.TxClassCapacities }}{{if $i}}, {{end}}{{ $e }}{{end}}]
+
+# Gossip priority of each class, one per class. The transactions of the classes
+# with the highest priority are gossiped first to new peers. The transactions of
+# other classes have priority 0. Empty gives all classes priority 0.
+tx-class-gossip-priorities = [{{ range $i, $e := .Mempool.TxClassGossipPriorities }}{{if $i}}, {{end}}{{ $e }}{{end}}]
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -391,8 +621,8 @@ max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 # State sync rapidly bootstraps a new node by discovering, fetching, and restoring a state machine
 # snapshot from peers instead of fetching and replaying historical blocks. Requires some peers in
 # the network to take and serve state machine snapshots. State sync is not attempted if the node
-# has any local state (LastBlockHeight > 0). The node will have a truncated block history,
-# starting from the height of the snapshot.
+# has any local state (LastBlockHeight > 0), unless delta-sync is set. The node will have a
+# truncated block history, starting from the height of the snapshot.
 enable = {{ .StateSync.Enable }}
 
 # RPC servers (comma-separated) for light client verification of the synced state machine and
@@ -406,13 +636,38 @@ trust-height = {{ .StateSync.TrustHeight }}
 trust-hash = "{{ .StateSync.TrustHash }}"
 trust-period = "{{ .StateSync.TrustPeriod }}"
 
+# Number of witnesses, i.e. the rpc-servers besides the first one, which must independently serve
+# the header at the height after a snapshot before its app hash is trusted. A witness serving a
+# different header rejects the snapshot. 0 only relies on light client verification.
+witness-quorum = {{ .StateSync.WitnessQuorum }}
+
 # Time to spend discovering snapshots before initiating a restore.
 discovery-time = "{{ .StateSync.DiscoveryTime }}"
 
+# Also state sync if the node has local state, e.g. after being offline for a while. Besides full
+# snapshots, delta snapshots of the changes since the node's last height are restored on top of its
+# app state, if the ABCI application supports them. Falls back to fast sync if no snapshot can be
+# restored. The node's blocks are discarded once a snapshot is restored.
+delta-sync = {{ .StateSync.DeltaSync }}
+
 # Temporary directory for state sync snapshot chunks, defaults to the OS tempdir (typically /tmp).
 # Will create a new, randomly named directory within, and remove it when done.
 temp-dir = "{{ .StateSync.TempDir }}"
 
+# Compression to request from peers when fetching snapshot chunks, either "none" or "gzip".
+# Peers running older versions ignore it and send chunks uncompressed.
+chunk-compression = "{{ .StateSync.ChunkCompression }}"
+
+# Maximum size of a snapshot chunk in bytes, applied both to the chunk as sent over the wire and
+# once decompressed. Chunks exceeding it are discarded. 0 means chunks are only limited by the
+# maximum p2p message size, unless they are streamed.
+max-chunk-size = {{ .StateSync.MaxChunkSize }}
+
+# Size in bytes of the parts in which snapshot chunks are streamed from peers and to the ABCI
+# application, if they support it, so that large chunks don't have to be held in memory at once.
+# Set to 0 to always transfer whole chunks.
+chunk-window-size = {{ .StateSync.ChunkWindowSize }}
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -423,6 +678,12 @@ temp-dir = "{{ .StateSync.TempDir }}"
 #   2) "v2" - complete redesign of v0, optimized for testability & readability
 version = "{{ .FastSync.Version }}"
 
+# How long fast sync may make no progress before it is considered stalled.
+# A stalled sync drops its slowest peers, asks for new peers and publishes a
+# FastSyncStall event, and does so again after every further stall-timeout
+# without progress. Only v0 detects stalls. Set to 0 to disable.
+stall-timeout = "{{ .FastSync.StallTimeout }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -453,6 +714,25 @@ timeout-commit = "{{ .Consensus.TimeoutCommit }}"
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# How many peers the node must see before it signs any votes or proposals,
+# e.g. when a validator restarts after downtime. Until then, it follows
+# consensus without participating in it. 0 disables the check.
+# The check can be skipped with /unsafe_stop_waiting_for_peers.
+min-peers-to-start = {{ .Consensus.MinPeersToStart }}
+
+# How many heights the node may be behind the highest of these peers to start
+# signing.
+max-height-lag-to-start = {{ .Consensus.MaxHeightLagToStart }}
+
+# Bounds on the drift of the time of a committed block from the time this node
+# received it, and of the interval between two blocks from the interval between
+# receiving them. Exceeding either logs an error and publishes a BlockTimeDrift
+# event, which catches validator clock problems early. As block times lag
+# behind by about a block interval, max-block-time-drift must exceed it.
+# Set to 0 to disable.
+max-block-time-drift = "{{ .Consensus.MaxBlockTimeDrift }}"
+max-block-interval-drift = "{{ .Consensus.MaxBlockIntervalDrift }}"
+
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip-timeout-commit = {{ .Consensus.SkipTimeoutCommit }}
 
@@ -460,10 +740,32 @@ skip-timeout-commit = {{ .Consensus.SkipTimeoutCommit }}
 create-empty-blocks = {{ .Consensus.CreateEmptyBlocks }}
 create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 
+# Dev mode commits blocks without waiting on any timeouts when this node holds
+# all of the voting power (e.g. a single validator local network). Blocks are
+# only produced when txs are available or when requested with /unsafe_produce_block.
+# NOTE: not intended for production networks.
+dev-mode = {{ .Consensus.DevMode }}
+
 # Reactor sleep duration parameters
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Max rate, in bytes/second, at which block parts are sent from the block store
+# to each peer catching up on a previous height, so that many peers restarting
+# at once don't saturate the bandwidth. At least one block part per second is
+# sent. 0 means unlimited.
+peer-catchup-send-rate = {{ .Consensus.PeerCatchupSendRate }}
+
+# Deadline for the app to answer ABCI Commit, so that a deadlocked app doesn't
+# hang consensus silently. On a breach, the node logs a critical error,
+# publishes an AppCommitTimeout event and writes a debug bundle, with the
+# goroutine stacks and the heap profile, to app-commit-timeout-debug-dir,
+# unless it's empty. Then, if app-commit-timeout-halt is true, consensus halts,
+# otherwise the node keeps waiting for the app. 0 means no deadline.
+app-commit-timeout = "{{ .Consensus.AppCommitTimeout }}"
+app-commit-timeout-halt = {{ .Consensus.AppCommitTimeoutHalt }}
+app-commit-timeout-debug-dir = "{{ js .Consensus.AppCommitTimeoutDebugDir }}"
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -480,12 +782,51 @@ peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 #   2) "kv" (default) - the simplest possible indexer, backed by key-value storage (defaults to levelDB; see DBBackend).
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 #   3) "psql" - the indexer services backed by PostgreSQL.
+#   4) "stream" - forwards events to a message bus (NATS).
+#   5) "parquet" - writes blocks, tx results and events to Parquet files.
 indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
 
 # The PostgreSQL connection configuration, the connection format:
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The message bus the "stream" event sink publishes to, the URL format:
+#   nats://[<user>:<password>@|<token>@]<host>:<port>
+# The tls scheme instead of nats requires TLS, which is also used if the server
+# requires it. Events are published as JSON, in order and at least once.
+stream-url = "{{ .TxIndex.StreamURL }}"
+
+# The PEM file of the CAs the "stream" event sink trusts, instead of the system
+# ones, to verify the certificate of the message bus, relative to the home
+# directory unless absolute. Setting it requires TLS.
+stream-tls-ca-file = "{{ .TxIndex.StreamTLSCAFile }}"
+
+# The topics the "stream" event sink publishes new blocks, tx results and
+# validator set updates to. An empty topic disables the corresponding events.
+stream-block-topic = "{{ .TxIndex.StreamBlockTopic }}"
+stream-tx-topic = "{{ .TxIndex.StreamTxTopic }}"
+stream-validator-updates-topic = "{{ .TxIndex.StreamValidatorUpdatesTopic }}"
+
+# The directory the "parquet" event sink writes its files to, relative to the
+# home directory unless absolute. Blocks, tx results and event attributes are
+# written to their own files, named after the first and last height of their
+# rows, e.g. txs-000000000001-000000001000.parquet.
+parquet-dir = "{{ .TxIndex.ParquetDir }}"
+
+# The number of blocks the "parquet" event sink buffers in memory before writing
+# them to a new set of files. Buffered blocks are lost if the node crashes.
+parquet-rotate-blocks = {{ .TxIndex.ParquetRotateBlocks }}
+
+# Prune the indexed events of the heights pruned from the block store, e.g.
+# below the RetainHeight requested by the app. Only the "kv" and "psql"
+# indexers are pruned.
+prune-with-blocks = {{ .TxIndex.PruneWithBlocks }}
+
+# Retain the indexed events of the latest retain-blocks heights only,
+# independently of the blocks. 0 retains all heights. Together with
+# prune-with-blocks, the events below the higher retain height are pruned.
+retain-blocks = {{ .TxIndex.RetainBlocks }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -507,6 +848,37 @@ max-open-connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+#######################################################
+###         Preflight Configuration Options         ###
+#######################################################
+[preflight]
+
+# If true, the node checks its environment when it starts: whether the clock
+# is in sync, there is enough free disk space and open files, the ports to
+# listen on are available and a remote signer is reachable.
+enable = {{ .Preflight.Enable }}
+
+# If true, the node refuses to start if a check fails. Otherwise, failed
+# checks are logged as warnings.
+strict = {{ .Preflight.Strict }}
+
+# NTP server the local clock is compared with, as host:port. An empty address
+# disables the clock check.
+ntp-server = "{{ .Preflight.NTPServer }}"
+
+# Max difference between the local clock and the clock of the NTP server
+max-clock-skew = "{{ .Preflight.MaxClockSkew }}"
+
+# Min free space, in bytes, on the disk of the data directory
+min-free-disk-space = {{ .Preflight.MinFreeDiskSpace }}
+
+# Min limit of open file descriptors of the process (ulimit -n)
+min-open-files = {{ .Preflight.MinOpenFiles }}
+
+# Timeout of the checks which connect to other hosts, i.e. the NTP server and
+# a remote signer
+timeout = "{{ .Preflight.Timeout }}"
 `
 
 /****** these are for test settings ***********/
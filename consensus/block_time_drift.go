@@ -0,0 +1,93 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// blockTimeDrift tracks the times the node receives proposal blocks, to
+// measure how far the times of the committed blocks drift from them.
+//
+// Block times are the median of the validators' precommit times for the last
+// height, so they lag behind the receive times by about a block interval. A
+// validator's clock problem shows as a drift moving away from that, or as a
+// block interval differing from the interval between receiving the blocks.
+type blockTimeDrift struct {
+	// when the complete proposal blocks of the height were received, by hash
+	received map[string]time.Time
+
+	// the last committed block received by the node in consensus
+	lastHeight   int64
+	lastTime     time.Time
+	lastReceived time.Time
+}
+
+// markBlockReceived records the time a complete proposal block was received.
+func (cs *State) markBlockReceived(block *types.Block) {
+	if cs.replayMode {
+		return
+	}
+	if cs.blockTimeDrift.received == nil {
+		cs.blockTimeDrift.received = make(map[string]time.Time)
+	}
+	hash := string(block.Hash())
+	if _, ok := cs.blockTimeDrift.received[hash]; !ok {
+		cs.blockTimeDrift.received[hash] = tmtime.Now()
+	}
+}
+
+// recordBlockTimeDrift records the drift of the time of a committed block from
+// the time it was received, and of its interval from the last block. If either
+// exceeds the configured bounds, it logs an error and publishes a
+// BlockTimeDrift event.
+func (cs *State) recordBlockTimeDrift(block *types.Block) {
+	d := &cs.blockTimeDrift
+	received, ok := d.received[string(block.Hash())]
+	d.received = nil
+	if !ok {
+		// committed while replaying the WAL
+		return
+	}
+
+	drift := block.Time.Sub(received)
+	cs.metrics.BlockTimeDriftSeconds.Observe(drift.Seconds())
+
+	var intervalDrift time.Duration
+	if d.lastHeight == block.Height-1 && !d.lastReceived.IsZero() {
+		intervalDrift = block.Time.Sub(d.lastTime) - received.Sub(d.lastReceived)
+		cs.metrics.BlockIntervalDriftSeconds.Observe(intervalDrift.Seconds())
+	}
+	d.lastHeight, d.lastTime, d.lastReceived = block.Height, block.Time, received
+
+	if !exceeds(drift, cs.config.MaxBlockTimeDrift) && !exceeds(intervalDrift, cs.config.MaxBlockIntervalDrift) {
+		return
+	}
+	cs.metrics.BlockTimeDriftExceeded.Add(1)
+	cs.Logger.Error("Block time drifted from the local time beyond the configured bounds; "+
+		"the clocks of the validators or of this node may be off",
+		"height", block.Height,
+		"proposer", block.ProposerAddress,
+		"drift", drift,
+		"interval_drift", intervalDrift,
+	)
+	if err := cs.eventBus.PublishEventBlockTimeDrift(types.EventDataBlockTimeDrift{
+		Height:        block.Height,
+		Proposer:      block.ProposerAddress,
+		BlockTime:     block.Time,
+		ReceiveTime:   received,
+		Drift:         drift,
+		IntervalDrift: intervalDrift,
+	}); err != nil {
+		cs.Logger.Error("failed publishing block time drift", "err", err)
+	}
+}
+
+// exceeds returns whether the drift exceeds the bound, 0 meaning no bound.
+func exceeds(drift, bound time.Duration) bool {
+	if bound == 0 {
+		return false
+	}
+	return drift > bound || drift < -bound
+}
@@ -0,0 +1,25 @@
+package consensus
+
+import (
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockDataAvailability is an extension point for chains which make the data
+// of their blocks available for sampling, e.g. by erasure coding it into
+// namespaced shares. It is set with State.SetBlockDataAvailability.
+//
+// The methods are called by the consensus state machine while it handles a
+// step, so they should return quickly.
+type BlockDataAvailability interface {
+	// EncodeBlockData is called with a block the node is about to propose,
+	// and its parts, before the proposal is signed and the parts are
+	// gossiped. If it returns an error, the node doesn't propose in this
+	// round.
+	EncodeBlockData(block *types.Block, parts *types.PartSet) error
+
+	// VerifyBlockData is called with a complete proposal block, including
+	// one proposed by this node, before the node prevotes for it. If it
+	// returns an error, e.g. because the data isn't available, the node
+	// prevotes nil.
+	VerifyBlockData(block *types.Block, parts *types.PartSet) error
+}
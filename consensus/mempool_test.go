@@ -17,6 +17,7 @@ import (
 	mempl "github.com/tendermint/tendermint/mempool"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 // for testing
@@ -52,7 +53,8 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	config := ResetConfig("consensus_mempool_txs_available_test")
 	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
 
-	config.Consensus.CreateEmptyBlocksInterval = ensureTimeout
+	// the interval is counted from the previous commit
+	config.Consensus.CreateEmptyBlocksInterval = ensureTimeout * 3 / 2
 	state, privVals := randGenesisState(baseConfig, 1, false, 10)
 	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
 
@@ -66,6 +68,27 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)   // until the CreateEmptyBlocksInterval has passed
 }
 
+func TestMempoolCreateEmptyBlocksIntervalSinceLastCommit(t *testing.T) {
+	baseConfig := configSetup(t)
+
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	config.Consensus.CreateEmptyBlocks = false
+	config.Consensus.CreateEmptyBlocksInterval = time.Hour
+	state, privVals := randGenesisState(baseConfig, 1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+
+	assert.Equal(t, time.Hour, cs.emptyBlockTimeout())
+
+	cs.CommitTime = tmtime.Now().Add(-time.Minute)
+	timeout := cs.emptyBlockTimeout()
+	assert.True(t, timeout <= 59*time.Minute && timeout > 58*time.Minute, timeout)
+
+	cs.CommitTime = tmtime.Now().Add(-2 * time.Hour)
+	assert.Zero(t, cs.emptyBlockTimeout())
+}
+
 func TestMempoolProgressInHigherRound(t *testing.T) {
 	baseConfig := configSetup(t)
 
@@ -106,6 +129,51 @@ func TestMempoolProgressInHigherRound(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)       // now we can commit the block
 }
 
+func TestMempoolDevModeProgressOnDemand(t *testing.T) {
+	baseConfig := configSetup(t)
+
+	config := ResetConfig("consensus_mempool_dev_mode_test")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	config.Consensus.DevMode = true
+	config.Consensus.SkipTimeoutCommit = false
+	config.Consensus.TimeoutCommit = time.Hour
+	state, privVals := randGenesisState(baseConfig, 1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	assertMempool(cs.txNotifier).EnableTxsAvailable()
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	ensureNewEventOnChannel(newBlockCh) // first block gets committed
+	ensureNoNewEventOnChannel(newBlockCh)
+	deliverTxsRange(cs, 0, 1)
+	ensureNewEventOnChannel(newBlockCh) // commit txs without waiting for timeout-commit
+	ensureNewEventOnChannel(newBlockCh) // commit updated app hash
+	ensureNoNewEventOnChannel(newBlockCh)
+
+	require.NoError(t, cs.ProduceBlock())
+	ensureNewEventOnChannel(newBlockCh) // commit the requested empty block
+	ensureNoNewEventOnChannel(newBlockCh)
+}
+
+func TestMempoolProduceBlockRequiresDevMode(t *testing.T) {
+	baseConfig := configSetup(t)
+
+	config := ResetConfig("consensus_mempool_dev_mode_test")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	// dev mode requires the node to hold all the voting power
+	config.Consensus.DevMode = true
+	state, privVals := randGenesisState(baseConfig, 2, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	assert.Equal(t, ErrNotInDevMode, cs.ProduceBlock())
+
+	config.Consensus.DevMode = false
+	state, privVals = randGenesisState(baseConfig, 1, false, 10)
+	cs = newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	assert.Equal(t, ErrNotInDevMode, cs.ProduceBlock())
+}
+
 func deliverTxsRange(cs *State, start, end int) {
 	// Deliver some txs.
 	for i := start; i < end; i++ {
@@ -14,6 +14,10 @@ const (
 	MetricsSubsystem = "consensus"
 )
 
+// driftBuckets are the buckets of the block time drift histograms, in seconds.
+// Drifts are negative when the block time is behind the local time.
+var driftBuckets = []float64{-30, -10, -5, -2, -1, -0.5, -0.1, 0, 0.1, 0.5, 1, 2, 5, 10, 30}
+
 // Metrics contains metrics exposed by this package.
 type Metrics struct {
 	// Height of the chain.
@@ -44,6 +48,13 @@ type Metrics struct {
 
 	// Time between this and the last block.
 	BlockIntervalSeconds metrics.Histogram
+	// Time of the block minus the time the node received it.
+	BlockTimeDriftSeconds metrics.Histogram
+	// Interval between this and the last block minus the interval between
+	// receiving them.
+	BlockIntervalDriftSeconds metrics.Histogram
+	// Number of blocks whose time drift exceeded the configured bounds.
+	BlockTimeDriftExceeded metrics.Counter
 
 	// Number of transactions.
 	NumTxs metrics.Gauge
@@ -60,6 +71,20 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+	// Number of bytes of block parts sent from the block store to peers
+	// catching up on a previous height.
+	CatchupBlockPartBytes metrics.Counter
+	// Number of times a block part wasn't sent to a peer catching up, to
+	// respect the peer catch-up send rate.
+	CatchupThrottled metrics.Counter
+
+	// Number of catchup round vote sets evicted to cap their memory.
+	EvictedVoteSets metrics.Counter
+	// Approximate memory held by the evicted catchup round vote sets.
+	EvictedVoteSetBytes metrics.Counter
+
+	// Number of votes of validators jailed by the application ignored.
+	JailedVotes metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -144,6 +169,26 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_interval_seconds",
 			Help:      "Time between this and the last block.",
 		}, labels).With(labelsAndValues...),
+		BlockTimeDriftSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_time_drift_seconds",
+			Help:      "Time of the block minus the time the node received it.",
+			Buckets:   driftBuckets,
+		}, labels).With(labelsAndValues...),
+		BlockIntervalDriftSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_interval_drift_seconds",
+			Help:      "Interval between this and the last block minus the interval between receiving them.",
+			Buckets:   driftBuckets,
+		}, labels).With(labelsAndValues...),
+		BlockTimeDriftExceeded: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_time_drift_exceeded",
+			Help:      "Number of blocks whose time drift exceeded the configured bounds.",
+		}, labels).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -186,6 +231,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		CatchupBlockPartBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "catchup_block_part_bytes",
+			Help:      "Number of bytes of block parts sent to peers catching up.",
+		}, labels).With(labelsAndValues...),
+		CatchupThrottled: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "catchup_throttled",
+			Help:      "Number of block parts delayed by the peer catch-up send rate.",
+		}, labels).With(labelsAndValues...),
+		EvictedVoteSets: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evicted_vote_sets",
+			Help:      "Number of catchup round vote sets evicted to cap their memory.",
+		}, labels).With(labelsAndValues...),
+		EvictedVoteSetBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evicted_vote_set_bytes",
+			Help:      "Approximate memory held by the evicted catchup round vote sets.",
+		}, labels).With(labelsAndValues...),
+		JailedVotes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "jailed_votes",
+			Help:      "Number of votes of validators jailed by the application ignored.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -207,7 +282,10 @@ func NopMetrics() *Metrics {
 		ByzantineValidators:      discard.NewGauge(),
 		ByzantineValidatorsPower: discard.NewGauge(),
 
-		BlockIntervalSeconds: discard.NewHistogram(),
+		BlockIntervalSeconds:      discard.NewHistogram(),
+		BlockTimeDriftSeconds:     discard.NewHistogram(),
+		BlockIntervalDriftSeconds: discard.NewHistogram(),
+		BlockTimeDriftExceeded:    discard.NewCounter(),
 
 		NumTxs:          discard.NewGauge(),
 		BlockSizeBytes:  discard.NewGauge(),
@@ -216,5 +294,13 @@ func NopMetrics() *Metrics {
 		FastSyncing:     discard.NewGauge(),
 		StateSyncing:    discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+
+		CatchupBlockPartBytes: discard.NewCounter(),
+		CatchupThrottled:      discard.NewCounter(),
+
+		EvictedVoteSets:     discard.NewCounter(),
+		EvictedVoteSetBytes: discard.NewCounter(),
+
+		JailedVotes: discard.NewCounter(),
 	}
 }
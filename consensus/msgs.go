@@ -139,7 +139,15 @@ type ProposalMessage struct {
 
 // ValidateBasic performs basic validation.
 func (m *ProposalMessage) ValidateBasic() error {
-	return m.Proposal.ValidateBasic()
+	if err := m.Proposal.ValidateBasic(); err != nil {
+		return err
+	}
+	// Peers track the block parts of the proposal in a bit array of this size.
+	if m.Proposal.BlockID.PartSetHeader.Total > types.MaxBlockPartsCount {
+		return fmt.Errorf("proposal has too many block parts: %d, max: %d",
+			m.Proposal.BlockID.PartSetHeader.Total, types.MaxBlockPartsCount)
+	}
+	return nil
 }
 
 // String returns a string representation.
@@ -606,7 +606,7 @@ func TestNewValidBlockMessageValidateBasic(t *testing.T) {
 		},
 		{
 			func(msg *NewValidBlockMessage) { msg.BlockParts = bits.NewBitArray(int(types.MaxBlockPartsCount) + 1) },
-			"blockParts bit array size 1602 not equal to BlockPartSetHeader.Total 1",
+			"blockParts bit array size 25602 not equal to BlockPartSetHeader.Total 1",
 		},
 	}
 
@@ -631,6 +631,44 @@ func TestNewValidBlockMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestProposalMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		malleateFn func(*ProposalMessage)
+		expErr     string
+	}{
+		{func(msg *ProposalMessage) {}, ""},
+		{func(msg *ProposalMessage) { msg.Proposal.Height = -1 }, "negative Height"},
+		{
+			func(msg *ProposalMessage) { msg.Proposal.BlockID.PartSetHeader.Total = types.MaxBlockPartsCount + 1 },
+			"proposal has too many block parts: 25602, max: 25601",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			blockID := types.BlockID{
+				Hash: tmrand.Bytes(tmhash.Size),
+				PartSetHeader: types.PartSetHeader{
+					Total: 1,
+					Hash:  tmrand.Bytes(tmhash.Size),
+				},
+			}
+			proposal := types.NewProposal(1, 0, -1, blockID)
+			proposal.Signature = tmrand.Bytes(types.MaxSignatureSize)
+			msg := &ProposalMessage{Proposal: proposal}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr != "" && assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestProposalPOLMessageValidateBasic(t *testing.T) {
 	testCases := []struct {
 		malleateFn func(*ProposalPOLMessage)
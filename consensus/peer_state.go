@@ -7,6 +7,7 @@ import (
 	"time"
 
 	cstypes "github.com/tendermint/tendermint/consensus/types"
+	flow "github.com/tendermint/tendermint/internal/libs/flowrate"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -48,6 +49,9 @@ type PeerState struct {
 
 	broadcastWG sync.WaitGroup
 	closer      *tmsync.Closer
+
+	// the block parts sent to the peer catching up, for the catch-up send rate
+	catchupMonitor *flow.Monitor
 }
 
 // NewPeerState returns a new PeerState for the given node ID.
@@ -62,8 +66,26 @@ func NewPeerState(logger log.Logger, peerID p2p.NodeID) *PeerState {
 			LastCommitRound:    -1,
 			CatchupCommitRound: -1,
 		},
-		Stats: &peerStateStats{},
+		Stats:          &peerStateStats{},
+		catchupMonitor: flow.New(time.Second, 0),
+	}
+}
+
+// allowCatchupPart returns true if a block part of the given size can be sent
+// to the peer catching up without exceeding the rate, in bytes per second. If
+// the part is larger than the rate, it is allowed once per second.
+func (ps *PeerState) allowCatchupPart(size int, rate int64) bool {
+	want := size
+	if int64(want) > rate && rate > 0 {
+		want = int(rate)
 	}
+	return ps.catchupMonitor.Limit(size, rate, false) >= want
+}
+
+// sentCatchupPart records a block part of the given size sent to the peer
+// catching up.
+func (ps *PeerState) sentCatchupPart(size int) {
+	ps.catchupMonitor.Update(size)
 }
 
 // SetRunning sets the running state of the peer.
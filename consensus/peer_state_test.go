@@ -0,0 +1,38 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestPeerStateCatchupSendRate(t *testing.T) {
+	ps := NewPeerState(log.TestingLogger(), p2p.NodeID("aa"))
+
+	// unlimited
+	for i := 0; i < 10; i++ {
+		assert.True(t, ps.allowCatchupPart(1000, 0))
+	}
+
+	// three parts per second
+	const rate = 3000
+	for i := 0; i < 3; i++ {
+		assert.True(t, ps.allowCatchupPart(1000, rate), i)
+		ps.sentCatchupPart(1000)
+	}
+	assert.False(t, ps.allowCatchupPart(1000, rate))
+	time.Sleep(time.Second)
+	assert.True(t, ps.allowCatchupPart(1000, rate))
+
+	// a part larger than the rate is allowed once per second
+	ps = NewPeerState(log.TestingLogger(), p2p.NodeID("aa"))
+	assert.True(t, ps.allowCatchupPart(5000, rate))
+	ps.sentCatchupPart(5000)
+	assert.False(t, ps.allowCatchupPart(5000, rate))
+	time.Sleep(time.Second)
+	assert.True(t, ps.allowCatchupPart(5000, rate))
+}
@@ -94,10 +94,21 @@ const (
 	votesToContributeToBecomeGoodPeer  = 10000
 
 	listenerIDConsensus = "consensus-reactor"
+
+	// how often to check whether enough peers are seen to start participating
+	// in consensus (see ConsensusConfig.MinPeersToStart)
+	waitForPeersInterval = time.Second
 )
 
 type ReactorOption func(*Reactor)
 
+// PeerHeightSetter records the heights reported by peers, so that other
+// reactors can tell how far along peers are, e.g. the mempool reactor to only
+// gossip txs to peers which caught up. It is implemented by p2p.PeerManager.
+type PeerHeightSetter interface {
+	SetHeight(p2p.NodeID, int64) error
+}
+
 // Reactor defines a reactor for the consensus service.
 type Reactor struct {
 	service.BaseService
@@ -115,6 +126,10 @@ type Reactor struct {
 	voteCh        *p2p.Channel
 	voteSetBitsCh *p2p.Channel
 	peerUpdates   *p2p.PeerUpdates
+	peerHeights   PeerHeightSetter
+
+	// verifies the signatures of the votes received on the VoteChannel
+	voteVerifier *voteVerifier
 
 	// NOTE: We need a dedicated stateCloseCh channel for signaling closure of
 	// the StateChannel due to the fact that the StateChannel message handler
@@ -154,6 +169,7 @@ func NewReactor(
 		closeCh:       make(chan struct{}),
 	}
 	r.BaseService = *service.NewBaseService(logger, "Consensus", r)
+	r.voteVerifier = newVoteVerifier(cs, r.closeCh)
 
 	for _, opt := range options {
 		opt(r)
@@ -175,6 +191,10 @@ func (r *Reactor) OnStart() error {
 	// leak the goroutine when stopping the reactor.
 	go r.peerStatsRoutine()
 
+	if r.state.WaitingForPeers() {
+		go r.waitForPeersRoutine()
+	}
+
 	r.subscribeToBroadcastEvents()
 
 	if !r.WaitSync() {
@@ -183,6 +203,8 @@ func (r *Reactor) OnStart() error {
 		}
 	}
 
+	r.voteVerifier.start()
+
 	go r.processStateCh()
 	go r.processDataCh()
 	go r.processVoteCh()
@@ -256,6 +278,12 @@ func ReactorMetrics(metrics *Metrics) ReactorOption {
 	return func(r *Reactor) { r.Metrics = metrics }
 }
 
+// ReactorPeerHeights sets where the reactor records the heights peers report in
+// their NewRoundStep messages.
+func ReactorPeerHeights(peerHeights PeerHeightSetter) ReactorOption {
+	return func(r *Reactor) { r.peerHeights = peerHeights }
+}
+
 // SwitchToConsensus switches from fast-sync mode to consensus mode. It resets
 // the state, turns off fast-sync, and starts the consensus state-machine.
 func (r *Reactor) SwitchToConsensus(state sm.State, skipWAL bool) {
@@ -369,10 +397,6 @@ func (r *Reactor) subscribeToBroadcastEvents() {
 		types.EventNewRoundStep,
 		func(data tmevents.EventData) {
 			r.broadcastNewRoundStepMessage(data.(*cstypes.RoundState))
-			select {
-			case r.state.onStopCh <- data.(*cstypes.RoundState):
-			default:
-			}
 		},
 	)
 	if err != nil {
@@ -465,6 +489,15 @@ func (r *Reactor) gossipDataForCatchup(rs *cstypes.RoundState, prs *cstypes.Peer
 			return
 		}
 
+		// many peers may be catching up at once, e.g. after a restart of the
+		// network, so limit the rate at which each one is served
+		partSize := len(part.Bytes)
+		if !ps.allowCatchupPart(partSize, r.state.config.PeerCatchupSendRate) {
+			r.Metrics.CatchupThrottled.Add(1)
+			time.Sleep(r.state.config.PeerGossipSleepDuration)
+			return
+		}
+
 		partProto, err := part.ToProto()
 		if err != nil {
 			logger.Error("failed to convert block part to proto", "err", err)
@@ -483,6 +516,11 @@ func (r *Reactor) gossipDataForCatchup(rs *cstypes.RoundState, prs *cstypes.Peer
 			},
 		}
 
+		// don't send the part again, unless the peer moves to another round
+		ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
+		ps.sentCatchupPart(partSize)
+		r.Metrics.CatchupBlockPartBytes.Add(float64(partSize))
+
 		return
 	}
 
@@ -994,6 +1032,11 @@ func (r *Reactor) handleStateMessage(envelope p2p.Envelope, msgI Message) error
 		}
 
 		ps.ApplyNewRoundStepMessage(msgI.(*NewRoundStepMessage))
+		if r.peerHeights != nil {
+			if err := r.peerHeights.SetHeight(envelope.From, msg.Height); err != nil {
+				r.Logger.Error("failed to set peer height", "peer", envelope.From, "err", err)
+			}
+		}
 
 	case *tmcons.NewValidBlock:
 		ps.ApplyNewValidBlockMessage(msgI.(*NewValidBlockMessage))
@@ -1088,6 +1131,7 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 
 		ps.SetHasProposalBlockPart(bpMsg.Height, bpMsg.Round, int(bpMsg.Part.Index))
 		r.Metrics.BlockParts.With("peer_id", string(envelope.From)).Add(1)
+		r.verifyBlockPart(bpMsg, logger)
 		r.state.peerMsgQueue <- msgInfo{bpMsg, envelope.From}
 
 	default:
@@ -1097,6 +1141,22 @@ func (r *Reactor) handleDataMessage(envelope p2p.Envelope, msgI Message) error {
 	return nil
 }
 
+// verifyBlockPart verifies the proof of a part of the current proposal block
+// as it arrives, so that the consensus state only has to insert it. The parts
+// of other heights, or which fail verification because the proposal changed
+// since, are verified by the consensus state instead.
+func (r *Reactor) verifyBlockPart(msg *BlockPartMessage, logger log.Logger) {
+	rs := r.state.GetRoundState()
+	if rs.Height != msg.Height || rs.ProposalBlockParts == nil {
+		return
+	}
+
+	if err := rs.ProposalBlockParts.VerifyPart(msg.Part); err != nil {
+		logger.Debug("failed to verify block part", "height", msg.Height, "round", msg.Round,
+			"index", msg.Part.Index, "err", err)
+	}
+}
+
 // handleVoteMessage handles envelopes sent from peers on the VoteChannel. If we
 // fail to find the peer state for the envelope sender, we perform a no-op and
 // return. This can happen when we process the envelope after the peer is
@@ -1117,17 +1177,26 @@ func (r *Reactor) handleVoteMessage(envelope p2p.Envelope, msgI Message) error {
 
 	switch msg := envelope.Message.(type) {
 	case *tmcons.Vote:
+		vMsg := msgI.(*VoteMessage)
+
 		r.state.mtx.RLock()
 		height, valSize, lastCommitSize := r.state.Height, r.state.Validators.Size(), r.state.LastCommit.Size()
+		jailed := vMsg.Vote.Height == height && r.state.state.IsJailed(vMsg.Vote.ValidatorAddress)
 		r.state.mtx.RUnlock()
 
-		vMsg := msgI.(*VoteMessage)
-
 		ps.EnsureVoteBitArrays(height, valSize)
 		ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 		ps.SetHasVote(vMsg.Vote)
 
-		r.state.peerMsgQueue <- msgInfo{vMsg, envelope.From}
+		// The votes of validators jailed by the application are neither
+		// verified, nor added to the vote sets, so they aren't gossiped.
+		if jailed {
+			logger.Debug("ignoring vote of jailed validator", "vote", vMsg.Vote)
+			r.Metrics.JailedVotes.Add(1)
+			return nil
+		}
+
+		r.voteVerifier.submit(vMsg, envelope.From)
 
 	default:
 		return fmt.Errorf("received unknown message on VoteChannel: %T", msg)
@@ -1365,6 +1434,60 @@ func (r *Reactor) processPeerUpdates() {
 	}
 }
 
+// waitForPeersRoutine lets the consensus state sign votes and proposals once
+// the node sees at least MinPeersToStart peers and is at most
+// MaxHeightLagToStart heights behind the highest of them. Only peers which
+// already told us their height are counted.
+func (r *Reactor) waitForPeersRoutine() {
+	ticker := time.NewTicker(waitForPeersInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !r.state.WaitingForPeers() {
+				return
+			}
+			if r.WaitSync() {
+				continue
+			}
+
+			var (
+				numPeers  int
+				maxHeight int64
+			)
+			r.mtx.RLock()
+			for _, ps := range r.peers {
+				if h := ps.GetHeight(); h > 0 {
+					numPeers++
+					if h > maxHeight {
+						maxHeight = h
+					}
+				}
+			}
+			r.mtx.RUnlock()
+
+			height := r.state.GetLastHeight() + 1
+			if numPeers < r.state.config.MinPeersToStart || maxHeight-height > r.state.config.MaxHeightLagToStart {
+				r.Logger.Info(
+					"waiting for peers before participating in consensus",
+					"peers", numPeers,
+					"min_peers", r.state.config.MinPeersToStart,
+					"height", height,
+					"max_peer_height", maxHeight,
+				)
+				continue
+			}
+
+			r.state.StopWaitingForPeers()
+			return
+
+		case <-r.closeCh:
+			return
+		}
+	}
+}
+
 func (r *Reactor) peerStatsRoutine() {
 	for {
 		if !r.IsRunning() {
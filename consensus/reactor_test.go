@@ -280,6 +280,43 @@ func TestReactorBasic(t *testing.T) {
 	wg.Wait()
 }
 
+func TestReactorWaitsForPeers(t *testing.T) {
+	config := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(config, n, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	t.Cleanup(cleanup)
+
+	for _, state := range states {
+		state.config.MinPeersToStart = n - 1
+		state.waitForPeers = true
+	}
+
+	rts := setup(t, n, states, 100) // buffer must be large enough to not deadlock
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(state, false)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// everyone starts signing once connected, and makes the first new block
+		go func(s types.Subscription) {
+			<-s.Out()
+			wg.Done()
+		}(sub)
+	}
+
+	wg.Wait()
+
+	for _, state := range rts.states {
+		require.False(t, state.WaitingForPeers())
+	}
+}
+
 func TestReactorWithEvidence(t *testing.T) {
 	config := configSetup(t)
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"io/ioutil"
 	"reflect"
 	"time"
 
@@ -203,9 +204,12 @@ type Handshaker struct {
 	initialState sm.State
 	store        sm.BlockStore
 	eventBus     types.BlockEventPublisher
+	eventSchema  *types.EventSchema
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	appStateChunkSize int
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -233,6 +237,19 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetEventSchema sets the event schema, which is populated with the one
+// declared by the app in its Info response, or InitChain at genesis.
+func (h *Handshaker) SetEventSchema(schema *types.EventSchema) {
+	h.eventSchema = schema
+}
+
+// SetAppStateChunkSize sets the size of the chunks in which the genesis app
+// state is sent to the app with InitChain. If 0, the default, the app state is
+// sent in a single InitChain request.
+func (h *Handshaker) SetAppStateChunkSize(size int) {
+	h.appStateChunkSize = size
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -260,6 +277,12 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"protocol-version", res.AppVersion,
 	)
 
+	if h.eventSchema != nil {
+		if err := h.eventSchema.Set(res.EventSchema); err != nil {
+			return fmt.Errorf("invalid event schema in Info: %w", err)
+		}
+	}
+
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
@@ -279,6 +302,52 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// initChain calls InitChain with the app state of the genesis doc. If an app
+// state chunk size is set, the app state is streamed to the app in a series of
+// InitChain requests with a chunk each, where app_state_offset and
+// app_state_size describe the chunk; the response to the last one is returned.
+func (h *Handshaker) initChain(
+	appConn proxy.AppConnConsensus,
+	req abci.RequestInitChain,
+) (*abci.ResponseInitChain, error) {
+	appState, size, err := h.genDoc.AppStateReader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis app state: %w", err)
+	}
+	defer appState.Close()
+
+	if h.appStateChunkSize <= 0 {
+		req.AppStateBytes, err = ioutil.ReadAll(appState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read genesis app state: %w", err)
+		}
+		return appConn.InitChainSync(context.Background(), req)
+	}
+
+	req.AppStateSize = uint64(size)
+	for {
+		chunk := make([]byte, h.appStateChunkSize)
+		n, err := io.ReadFull(appState, chunk)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, fmt.Errorf("failed to read genesis app state: %w", err)
+		}
+		if n == 0 && req.AppStateOffset < req.AppStateSize {
+			return nil, fmt.Errorf("genesis app state ended at %d of %d bytes", req.AppStateOffset, size)
+		}
+		req.AppStateBytes = chunk[:n]
+
+		res, err := appConn.InitChainSync(context.Background(), req)
+		if err != nil {
+			return nil, err
+		}
+		req.AppStateOffset += uint64(n)
+		if req.AppStateOffset >= req.AppStateSize {
+			return res, nil
+		}
+		h.logger.Debug("Sent genesis app state chunk", "offset", req.AppStateOffset, "size", size)
+	}
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -315,15 +384,20 @@ func (h *Handshaker) ReplayBlocks(
 			InitialHeight:   h.genDoc.InitialHeight,
 			ConsensusParams: &pbParams,
 			Validators:      nextVals,
-			AppStateBytes:   h.genDoc.AppState,
 		}
-		res, err := proxyApp.Consensus().InitChainSync(context.Background(), req)
+		res, err := h.initChain(proxyApp.Consensus(), req)
 		if err != nil {
 			return nil, err
 		}
 
 		appHash = res.AppHash
 
+		if h.eventSchema != nil && len(res.EventSchema) > 0 {
+			if err := h.eventSchema.Set(res.EventSchema); err != nil {
+				return nil, fmt.Errorf("invalid event schema in InitChain: %w", err)
+			}
+		}
+
 		if stateBlockHeight == 0 { // we only update state when we are in initial state
 			// If the app did not return an app hash, we keep the one set from the genesis doc in
 			// the state. We don't set appHash since we don't want the genesis doc app hash
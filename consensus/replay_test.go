@@ -1234,6 +1234,7 @@ func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	}
 }
 func (bs *mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
+func (bs *mockBlockStore) LoadTxLocation(hash []byte) (int64, uint32, bool) { return 0, 0, false }
 func (bs *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
 func (bs *mockBlockStore) LoadBlockCommit(height int64) *types.Commit {
@@ -1311,3 +1312,104 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		Validators: ica.vals,
 	}
 }
+
+func TestHandshakeSetsEventSchema(t *testing.T) {
+	app := &eventSchemaApp{schema: []abci.EventAttributeSchema{
+		{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_INT},
+	}}
+	clientCreator := proxy.NewLocalClientCreator(app)
+
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	privVal, err := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(context.Background())
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, 0x0)
+	stateStore := sm.NewStore(stateDB)
+
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	schema := types.NewEventSchema()
+	handshaker := NewHandshaker(stateStore, state, store, genDoc)
+	handshaker.SetEventSchema(schema)
+	proxyApp := proxy.NewAppConns(clientCreator)
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, handshaker.Handshake(proxyApp))
+
+	typ, ok := schema.Type("transfer.amount")
+	require.True(t, ok)
+	assert.Equal(t, abci.EventAttributeType_INT, typ)
+}
+
+// returns the event schema on InitChain
+type eventSchemaApp struct {
+	abci.BaseApplication
+	schema []abci.EventAttributeSchema
+}
+
+func (esa *eventSchemaApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	return abci.ResponseInitChain{
+		EventSchema: esa.schema,
+	}
+}
+
+func TestHandshakeStreamsAppState(t *testing.T) {
+	app := &appStateApp{}
+	clientCreator := proxy.NewLocalClientCreator(app)
+
+	config := ResetConfig("handshake_test_")
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	privVal, err := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(context.Background())
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, 0x0)
+	stateStore := sm.NewStore(stateDB)
+
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	genDoc.AppState = []byte(`{"accounts": [{"address": "a", "balance": 10}]}`)
+	handshaker := NewHandshaker(stateStore, state, store, genDoc)
+	handshaker.SetAppStateChunkSize(10)
+	proxyApp := proxy.NewAppConns(clientCreator)
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, handshaker.Handshake(proxyApp))
+
+	assert.Equal(t, 5, app.requests)
+	assert.Equal(t, []byte(genDoc.AppState), app.appState)
+
+	// the response to the last chunk is used
+	state, err = stateStore.Load()
+	require.NoError(t, err)
+	assert.EqualValues(t, app.appState, state.AppHash)
+}
+
+// assembles the app state streamed with InitChain
+type appStateApp struct {
+	abci.BaseApplication
+	appState []byte
+	requests int
+}
+
+func (asa *appStateApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	asa.requests++
+	if req.AppStateOffset != uint64(len(asa.appState)) {
+		panic(fmt.Sprintf("unexpected app state offset %d", req.AppStateOffset))
+	}
+	asa.appState = append(asa.appState, req.AppStateBytes...)
+	if uint64(len(asa.appState)) < req.AppStateSize {
+		return abci.ResponseInitChain{}
+	}
+	return abci.ResponseInitChain{AppHash: asa.appState}
+}
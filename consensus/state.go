@@ -24,6 +24,7 @@ import (
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
@@ -34,8 +35,10 @@ import (
 var (
 	ErrInvalidProposalSignature   = errors.New("error invalid proposal signature")
 	ErrInvalidProposalPOLRound    = errors.New("error invalid proposal POL round")
+	ErrInvalidProposalPartSetSize = errors.New("error invalid proposal part set size")
 	ErrAddingVote                 = errors.New("error adding vote")
 	ErrSignatureFoundInPastBlocks = errors.New("found signature from the same key")
+	ErrNotInDevMode               = errors.New("dev mode is disabled or the node does not hold all the voting power")
 
 	errPubKeyIsNotSet = errors.New("pubkey is not set. Look for \"Can't get private validator pubkey\" errors")
 )
@@ -91,6 +94,12 @@ type State struct {
 	// notify us if txs are available
 	txNotifier txNotifier
 
+	// requests to produce a block in dev mode
+	produceBlockCh chan struct{}
+
+	// requests to stop waiting for peers before signing
+	stopWaitingForPeersCh chan struct{}
+
 	// add evidence to the pool
 	// when it's detected
 	evpool evidencePool
@@ -102,6 +111,9 @@ type State struct {
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
+	// don't sign votes and proposals until the reactor saw enough peers
+	// (see ConsensusConfig.MinPeersToStart)
+	waitForPeers bool
 
 	// state changes may be triggered by: msgs from peers,
 	// msgs from ourself, or by timeouts
@@ -122,6 +134,7 @@ type State struct {
 	wal          WAL
 	replayMode   bool // so we don't log signing errors during replay
 	doWALCatchup bool // determines if we even try to do the catchup
+	walDisabled  bool // run without a WAL, see DisableWAL
 
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
@@ -143,6 +156,15 @@ type State struct {
 
 	// wait the channel event happening for shutting down the state gracefully
 	onStopCh chan *cstypes.RoundState
+
+	// max time OnStop waits for a commit in progress to finish
+	stopTimeout time.Duration
+
+	// encodes and verifies the data of proposal blocks, may be nil
+	dataAvailability BlockDataAvailability
+
+	// drift of the block times from the times they were received
+	blockTimeDrift blockTimeDrift
 }
 
 // StateOption sets an optional parameter on the State.
@@ -163,6 +185,8 @@ func NewState(
 		blockExec:        blockExec,
 		blockStore:       blockStore,
 		txNotifier:       txNotifier,
+		produceBlockCh:   make(chan struct{}, 1),
+		waitForPeers:     config.MinPeersToStart > 0,
 		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(),
@@ -174,6 +198,9 @@ func NewState(
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
 		onStopCh:         make(chan *cstypes.RoundState),
+		stopTimeout:      config.TimeoutCommit,
+
+		stopWaitingForPeersCh: make(chan struct{}, 1),
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -210,11 +237,35 @@ func (cs *State) SetEventBus(b *types.EventBus) {
 	cs.blockExec.SetEventBus(b)
 }
 
+// SetBlockDataAvailability sets the extension which encodes the data of the
+// blocks this node proposes and verifies the data of proposal blocks.
+func (cs *State) SetBlockDataAvailability(da BlockDataAvailability) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	cs.dataAvailability = da
+}
+
 // StateMetrics sets the metrics.
 func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateStopTimeout sets the max time OnStop waits for a commit in progress
+// to finish. It defaults to the commit timeout.
+func StateStopTimeout(timeout time.Duration) StateOption {
+	return func(cs *State) { cs.stopTimeout = timeout }
+}
+
+// DisableWAL makes the state run without a WAL, keeping nothing on disk. It
+// must be called before the state is started. Without the WAL, a validator
+// may sign conflicting votes after a crash, so this is only meant for
+// throwaway chains.
+func (cs *State) DisableWAL() {
+	cs.walDisabled = true
+	cs.doWALCatchup = false
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -251,6 +302,14 @@ func (cs *State) GetRoundStateJSON() ([]byte, error) {
 	return tmjson.Marshal(cs.RoundState)
 }
 
+// GetRoundStateDump returns the RoundState in the schema of
+// /dump_consensus_state_v2.
+func (cs *State) GetRoundStateDump() tmcons.RoundStateDump {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.RoundState.ToProto()
+}
+
 // GetRoundStateSimpleJSON returns a json of RoundStateSimple
 func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	cs.mtx.RLock()
@@ -303,7 +362,7 @@ func (cs *State) LoadCommit(height int64) *types.Commit {
 func (cs *State) OnStart() error {
 	// We may set the WAL in testing before calling Start, so only OpenWAL if its
 	// still the nilWAL.
-	if _, ok := cs.wal.(nilWAL); ok {
+	if _, ok := cs.wal.(nilWAL); ok && !cs.walDisabled {
 		if err := cs.loadWalFile(); err != nil {
 			return err
 		}
@@ -416,17 +475,19 @@ func (cs *State) loadWalFile() error {
 // OnStop implements service.Service.
 func (cs *State) OnStop() {
 
-	// If the node is committing a new block, wait until it is finished!
+	// If the node is committing a new block, wait until it is finished, so
+	// the block is saved and the WAL doesn't end in the middle of the commit.
+	// Stopping in any other step, e.g. before we prevoted, is safe: the
+	// receive routine finishes the message it is handling, and the priv
+	// validator keeps track of the last vote it signed.
 	if cs.GetRoundState().Step == cstypes.RoundStepCommit {
 		select {
 		case <-cs.onStopCh:
-		case <-time.After(cs.config.TimeoutCommit):
-			cs.Logger.Error("OnStop: timeout waiting for commit to finish", "time", cs.config.TimeoutCommit)
+		case <-time.After(cs.stopTimeout):
+			cs.Logger.Error("OnStop: timeout waiting for commit to finish", "time", cs.stopTimeout)
 		}
 	}
 
-	close(cs.onStopCh)
-
 	if err := cs.evsw.Stop(); err != nil {
 		cs.Logger.Error("failed trying to stop eventSwitch", "error", err)
 	}
@@ -470,6 +531,75 @@ func (cs *State) OpenWAL(walFile string) (WAL, error) {
 // If the queue is full, the function may block.
 // TODO: should these return anything or let callers just use events?
 
+// ProduceBlock requests the next block to be proposed and committed right
+// away, even if there are no txs available. It returns ErrNotInDevMode unless
+// the node runs in dev mode and holds all the voting power.
+func (cs *State) ProduceBlock() error {
+	cs.mtx.RLock()
+	devMode := cs.devMode(cs.Validators)
+	cs.mtx.RUnlock()
+
+	if !devMode {
+		return ErrNotInDevMode
+	}
+
+	select {
+	case cs.produceBlockCh <- struct{}{}:
+	default:
+		// a request is already pending
+	}
+	return nil
+}
+
+// WaitingForPeers returns true if the node does not sign votes and proposals
+// yet, because it has not seen enough peers (see
+// ConsensusConfig.MinPeersToStart).
+func (cs *State) WaitingForPeers() bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.waitForPeers
+}
+
+// StopWaitingForPeers lets the node sign votes and proposals from now on,
+// regardless of how many peers it has seen. The receive routine stops waiting,
+// like it handles other state transitions, so WaitingForPeers may return true
+// for a little while.
+func (cs *State) StopWaitingForPeers() {
+	select {
+	case cs.stopWaitingForPeersCh <- struct{}{}:
+	default:
+		// a request is already pending
+	}
+}
+
+// handleStopWaitingForPeers stops waiting for peers, and signs the proposal or
+// vote skipped in the current step.
+func (cs *State) handleStopWaitingForPeers() {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	if !cs.waitForPeers {
+		return
+	}
+	cs.waitForPeers = false
+
+	cs.Logger.Info("starting to participate in consensus", "height", cs.Height, "round", cs.Round, "step", cs.Step)
+
+	// Catch up on what we skipped in the current step. Otherwise the network
+	// would stall if all validators were waiting for peers at the same time.
+	switch cs.Step {
+	case cstypes.RoundStepPropose:
+		if cs.privValidatorPubKey != nil && cs.isProposer(cs.privValidatorPubKey.Address()) {
+			cs.decideProposal(cs.Height, cs.Round)
+		}
+	case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+		cs.doPrevote(cs.Height, cs.Round)
+	case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+		// precommitting nil is always safe
+		cs.signAddVote(tmproto.PrecommitType, nil, types.PartSetHeader{})
+	}
+}
+
 // AddVote inputs a vote.
 func (cs *State) AddVote(vote *types.Vote, peerID p2p.NodeID) (added bool, err error) {
 	if peerID == "" {
@@ -665,14 +795,18 @@ func (cs *State) updateToState(state sm.State) {
 	cs.updateHeight(height)
 	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
 
-	if cs.CommitTime.IsZero() {
+	switch {
+	case cs.devMode(validators):
+		// There are no straggler votes to wait for, all of them are ours.
+		cs.StartTime = tmtime.Now()
+	case cs.CommitTime.IsZero():
 		// "Now" makes it easier to sync up dev nodes.
 		// We add timeoutCommit to allow transactions
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
 		cs.StartTime = cs.config.Commit(tmtime.Now())
-	} else {
+	default:
 		cs.StartTime = cs.config.Commit(cs.CommitTime)
 	}
 
@@ -687,6 +821,7 @@ func (cs *State) updateToState(state sm.State) {
 	cs.ValidBlock = nil
 	cs.ValidBlockParts = nil
 	cs.Votes = cstypes.NewHeightVoteSet(state.ChainID, height, validators)
+	cs.Votes.SetMaxCatchupBytes(cstypes.DefaultMaxCatchupBytes, cs.onVoteSetEvicted)
 	cs.CommitRound = -1
 	cs.LastValidators = state.LastValidators
 	cs.TriggeredTimeoutPrecommit = false
@@ -713,6 +848,12 @@ func (cs *State) newStep() {
 
 		cs.evsw.FireEvent(types.EventNewRoundStep, &cs.RoundState)
 	}
+
+	// notify OnStop if it waits for the commit to finish
+	select {
+	case cs.onStopCh <- &cs.RoundState:
+	default:
+	}
 }
 
 //-----------------------------------------
@@ -769,6 +910,15 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		case <-cs.txNotifier.TxsAvailable():
 			cs.handleTxsAvailable()
 
+		case <-cs.produceBlockCh:
+			// proceed as if txs were available, the block may be empty
+			cs.handleTxsAvailable()
+
+		case <-cs.stopWaitingForPeersCh:
+			// the proposal or votes signed go through the internal queue and
+			// the WAL
+			cs.handleStopWaitingForPeers()
+
 		case mi = <-cs.peerMsgQueue:
 			if err := cs.wal.Write(mi); err != nil {
 				cs.Logger.Error("failed writing to WAL", "err", err)
@@ -1026,10 +1176,12 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
+	// In dev mode blocks are only produced on demand.
+	waitForTxs := (cs.config.WaitForTxs() || cs.devMode(cs.Validators)) &&
+		round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
 		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+			cs.scheduleTimeout(cs.emptyBlockTimeout(), height, round,
 				cstypes.RoundStepNewRound)
 		}
 	} else {
@@ -1037,6 +1189,34 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	}
 }
 
+// emptyBlockTimeout returns how long to wait for txs before proposing an empty
+// block. CreateEmptyBlocksInterval bounds the time between two blocks, so that
+// light clients keep receiving fresh headers on an idle chain, hence it is
+// counted from the commit of the previous block rather than from the start of
+// the round.
+func (cs *State) emptyBlockTimeout() time.Duration {
+	if cs.CommitTime.IsZero() {
+		return cs.config.CreateEmptyBlocksInterval
+	}
+
+	timeout := cs.CommitTime.Add(cs.config.CreateEmptyBlocksInterval).Sub(tmtime.Now())
+	if timeout < 0 {
+		return 0
+	}
+	return timeout
+}
+
+// devMode returns true if dev mode is enabled and our validator holds all of
+// the voting power of the given validator set.
+func (cs *State) devMode(vals *types.ValidatorSet) bool {
+	if !cs.config.DevMode || cs.privValidatorPubKey == nil || vals == nil {
+		return false
+	}
+
+	_, val := vals.GetByAddress(cs.privValidatorPubKey.Address())
+	return val != nil && val.VotingPower == vals.TotalVotingPower()
+}
+
 // needProofBlock returns true on the first height (so the genesis app hash is signed right away)
 // and where the last block (height-1) caused the app hash to change
 func (cs *State) needProofBlock(height int64) bool {
@@ -1054,7 +1234,8 @@ func (cs *State) needProofBlock(height int64) bool {
 
 // Enter (CreateEmptyBlocks): from enterNewRound(height,round)
 // Enter (CreateEmptyBlocks, CreateEmptyBlocksInterval > 0 ):
-// 		after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
+// 		after enterNewRound(height,round), once txs are in the mempool or
+// 		CreateEmptyBlocksInterval after the previous commit
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
 func (cs *State) enterPropose(height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
@@ -1108,6 +1289,11 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
+	if cs.waitForPeers {
+		logger.Debug("propose step; waiting for peers before participating in consensus")
+		return
+	}
+
 	if cs.isProposer(address) {
 		logger.Debug(
 			"propose step; our turn to propose",
@@ -1143,6 +1329,14 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 		}
 	}
 
+	// Hand the block data to the availability encoder before it is gossiped.
+	if cs.dataAvailability != nil {
+		if err := cs.dataAvailability.EncodeBlockData(block, blockParts); err != nil {
+			cs.Logger.Error("propose step; failed encoding block data", "height", height, "round", round, "err", err)
+			return
+		}
+	}
+
 	// Flush the WAL. Otherwise, we may not recompute the same proposal to sign,
 	// and the privValidator will refuse to sign anything.
 	if err := cs.wal.FlushAndSync(); err != nil {
@@ -1286,6 +1480,15 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// Verify the block data is available, prevote nil if it isn't.
+	if cs.dataAvailability != nil {
+		if err := cs.dataAvailability.VerifyBlockData(cs.ProposalBlock, cs.ProposalBlockParts); err != nil {
+			logger.Error("prevote step: ProposalBlock data is not available", "err", err)
+			cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1687,6 +1890,7 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.recordBlockTimeDrift(block)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1833,6 +2037,11 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 		return ErrInvalidProposalPOLRound
 	}
 
+	// A block within the block size limit never has more parts than this.
+	if proposal.BlockID.PartSetHeader.Total > cs.state.ConsensusParams.Block.MaxPartsCount() {
+		return ErrInvalidProposalPartSetSize
+	}
+
 	p := proposal.ToProto()
 	// Verify signature
 	if !cs.Validators.GetProposer().PubKey.VerifySignature(
@@ -1880,6 +2089,10 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.NodeID)
 		return false, nil
 	}
 
+	if partSize := cs.state.ConsensusParams.Block.PartSize(); len(part.Bytes) > int(partSize) {
+		return false, fmt.Errorf("block part is too big: %d bytes, max: %d", len(part.Bytes), partSize)
+	}
+
 	added, err = cs.ProposalBlockParts.AddPart(part)
 	if err != nil {
 		return added, err
@@ -1907,6 +2120,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.NodeID)
 		}
 
 		cs.ProposalBlock = block
+		cs.markBlockReceived(block)
 
 		// NOTE: it's possible to receive complete proposal blocks for future rounds without having the proposal
 		cs.Logger.Info("received complete proposal block", "height", cs.ProposalBlock.Height, "hash", cs.ProposalBlock.Hash())
@@ -1954,6 +2168,14 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.NodeID)
 	return added, nil
 }
 
+// onVoteSetEvicted is called when the vote sets of a catchup round are evicted
+// to cap their memory.
+func (cs *State) onVoteSetEvicted(round int32, bytes int64) {
+	cs.metrics.EvictedVoteSets.Add(1)
+	cs.metrics.EvictedVoteSetBytes.Add(float64(bytes))
+	cs.Logger.Debug("evicted catchup round vote sets", "round", round, "bytes", bytes)
+}
+
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
 func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.NodeID) (bool, error) {
 	added, err := cs.addVote(vote, peerID)
@@ -2271,6 +2493,17 @@ func (cs *State) signAddVote(msgType tmproto.SignedMsgType, hash []byte, header
 		return nil
 	}
 
+	// Other nodes ignore the votes of a validator jailed by the application.
+	if cs.state.IsJailed(cs.privValidatorPubKey.Address()) {
+		cs.Logger.Debug("not signing vote; the validator is jailed", "height", cs.Height)
+		return nil
+	}
+
+	if cs.waitForPeers {
+		cs.Logger.Debug("not signing vote; waiting for peers before participating in consensus")
+		return nil
+	}
+
 	// TODO: pass pubKey to signVote
 	vote, err := cs.signVote(msgType, hash, header)
 	if err == nil {
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/abci/example/counter"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -34,6 +36,7 @@ FullRoundSuite
 x * TestFullRound1 - 1 val, full successful round
 x * TestFullRoundNil - 1 val, full round of nil
 x * TestFullRound2 - 2 vals, both required for full round
+x * TestBlockDataAvailability - 1 val, block data is encoded before gossip and verified before prevote
 LockSuite
 x * TestLockNoPOL - 2 vals, 4 rounds. one val locked, precommits nil every round except first.
 x * TestLockPOLRelock - 4 vals, one precommits, other 3 polka at next round, so we unlock and precomit the polka
@@ -55,6 +58,54 @@ x * TestHalt1 - if we see +2/3 precommits after timing out into new round, we sh
 //----------------------------------------------------------------------------------------------------
 // ProposeSuite
 
+func TestStateWaitForPeers(t *testing.T) {
+	config := configSetup(t)
+
+	cs1, vss := randState(config, 4)
+	cs1.waitForPeers = true
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	timeoutProposeCh := subscribe(cs1.eventBus, types.EventQueryTimeoutPropose)
+	pv1, err := cs1.privValidator.GetPubKey(context.Background())
+	require.NoError(t, err)
+	voteCh := subscribeToVoter(cs1, pv1.Address())
+
+	startTestRound(cs1, height, round)
+
+	// we are the proposer, but neither propose nor vote while waiting for peers
+	ensureNewTimeout(timeoutProposeCh, height, round, cs1.config.Propose(round).Nanoseconds())
+	ensureNoNewEvent(proposalCh, ensureTimeout, "unexpected proposal while waiting for peers")
+	ensureNoNewEvent(voteCh, ensureTimeout, "unexpected vote while waiting for peers")
+
+	// once we stopped waiting, we catch up on the prevote we skipped
+	cs1.StopWaitingForPeers()
+	ensurePrevote(voteCh, height, round)
+	require.False(t, cs1.WaitingForPeers())
+
+	signAddVotes(config, cs1, tmproto.PrevoteType, nil, types.PartSetHeader{}, vss[1:]...)
+	ensurePrecommit(voteCh, height, round)
+}
+
+func TestStateJailedValidator(t *testing.T) {
+	config := configSetup(t)
+
+	cs1, _ := randState(config, 4)
+	height, round := cs1.Height, cs1.Round
+
+	pv1, err := cs1.privValidator.GetPubKey(context.Background())
+	require.NoError(t, err)
+	cs1.state.JailedValidators = [][]byte{pv1.Address()}
+	voteCh := subscribeToVoter(cs1, pv1.Address())
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	startTestRound(cs1, height, round)
+
+	// we still propose, but don't vote at the height we are jailed at
+	ensureNewProposal(proposalCh, height, round)
+	ensureNoNewEvent(voteCh, ensureTimeout, "unexpected vote of a jailed validator")
+}
+
 func TestStateProposerSelection0(t *testing.T) {
 	config := configSetup(t)
 
@@ -309,6 +360,42 @@ func TestStateOversizedBlock(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateProposalPartSize(t *testing.T) {
+	config := configSetup(t)
+
+	cs1, vss := randState(config, 1)
+	cs1.state.ConsensusParams.Block.MaxBytes = 16384
+	cs1.state.ConsensusParams.Block.PartSizeBytes = types.MinBlockPartSizeBytes
+	height, round := cs1.Height, cs1.Round
+
+	propBlock, _ := cs1.createProposalBlock()
+	propBlock.Data.Txs = []types.Tx{tmrand.Bytes(6000)}
+	propBlock.Header.DataHash = propBlock.Data.Hash()
+
+	// too many parts for a block within MaxBytes
+	header := types.PartSetHeader{Total: cs1.state.ConsensusParams.Block.MaxPartsCount() + 1, Hash: tmrand.Bytes(32)}
+	proposal := types.NewProposal(height, round, -1, types.BlockID{Hash: propBlock.Hash(), PartSetHeader: header})
+	p := proposal.ToProto()
+	require.NoError(t, vss[0].SignProposal(context.Background(), config.ChainID(), p))
+	proposal.Signature = p.Signature
+	require.Equal(t, ErrInvalidProposalPartSetSize, cs1.defaultSetProposal(proposal))
+
+	// parts bigger than the part size of the chain
+	propBlockParts := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.EqualValues(t, 1, propBlockParts.Total())
+	cs1.ProposalBlockParts = types.NewPartSetFromHeader(propBlockParts.Header())
+	added, err := cs1.addProposalBlockPart(&BlockPartMessage{height, round, propBlockParts.GetPart(0)}, "peer")
+	require.Error(t, err)
+	require.False(t, added)
+
+	// parts of the part size of the chain
+	propBlockParts = propBlock.MakePartSet(cs1.state.ConsensusParams.Block.PartSize())
+	cs1.ProposalBlockParts = types.NewPartSetFromHeader(propBlockParts.Header())
+	added, err = cs1.addProposalBlockPart(&BlockPartMessage{height, round, propBlockParts.GetPart(0)}, "peer")
+	require.NoError(t, err)
+	require.True(t, added)
+}
+
 //----------------------------------------------------------------------------------------------------
 // FullRoundSuite
 
@@ -373,6 +460,74 @@ func TestStateFullRoundNil(t *testing.T) {
 	validatePrevoteAndPrecommit(t, cs, round, -1, vss[0], nil, nil)
 }
 
+type mockDataAvailability struct {
+	mtx       tmsync.Mutex
+	encoded   []int64
+	verified  []int64
+	encodeErr error
+	verifyErr error
+}
+
+func (da *mockDataAvailability) EncodeBlockData(block *types.Block, parts *types.PartSet) error {
+	da.mtx.Lock()
+	defer da.mtx.Unlock()
+	da.encoded = append(da.encoded, block.Height)
+	return da.encodeErr
+}
+
+func (da *mockDataAvailability) VerifyBlockData(block *types.Block, parts *types.PartSet) error {
+	da.mtx.Lock()
+	defer da.mtx.Unlock()
+	if parts.IsComplete() {
+		da.verified = append(da.verified, block.Height)
+	}
+	return da.verifyErr
+}
+
+func TestStateBlockDataAvailability(t *testing.T) {
+	config := configSetup(t)
+
+	testCases := []struct {
+		name      string
+		encodeErr error
+		verifyErr error
+		prevote   bool
+	}{
+		{"available", nil, nil, true},
+		{"encoding fails", errors.New("encoding failed"), nil, false},
+		{"not available", nil, errors.New("not available"), false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cs, vss := randState(config, 1)
+			height, round := cs.Height, cs.Round
+
+			da := &mockDataAvailability{encodeErr: tc.encodeErr, verifyErr: tc.verifyErr}
+			cs.SetBlockDataAvailability(da)
+
+			voteCh := subscribeUnBuffered(cs.eventBus, types.EventQueryVote)
+			startTestRound(cs, height, round)
+
+			ensurePrevote(voteCh, height, round)
+			da.mtx.Lock()
+			assert.Equal(t, []int64{height}, da.encoded)
+			if tc.encodeErr == nil {
+				assert.Equal(t, []int64{height}, da.verified)
+			} else {
+				assert.Empty(t, da.verified)
+			}
+			da.mtx.Unlock()
+
+			if tc.prevote {
+				validatePrevote(t, cs, round, vss[0], cs.GetRoundState().ProposalBlock.Hash())
+			} else {
+				validatePrevote(t, cs, round, vss[0], nil)
+			}
+		})
+	}
+}
+
 // run through propose, prevote, precommit commit with two validators
 // where the first validator has to wait for votes from the second
 func TestStateFullRound2(t *testing.T) {
@@ -1939,6 +2094,46 @@ func TestStateOutputVoteStats(t *testing.T) {
 
 }
 
+func TestStateRecordsBlockTimeDrift(t *testing.T) {
+	config := configSetup(t)
+	cs, _ := randState(config, 1)
+	cs.config.MaxBlockTimeDrift = 5 * time.Second
+	cs.config.MaxBlockIntervalDrift = 5 * time.Second
+	driftCh := subscribe(cs.eventBus, types.EventQueryBlockTimeDrift)
+
+	commit := func(height int64, blockTime time.Time, received bool) {
+		block := types.MakeBlock(height, nil, nil, nil)
+		block.Time = blockTime
+		if received {
+			cs.markBlockReceived(block)
+		}
+		cs.recordBlockTimeDrift(block)
+	}
+	now := time.Now()
+
+	// the block time lags behind the local time within the bound
+	commit(1, now.Add(-time.Second), true)
+	ensureNoNewEventOnChannel(driftCh)
+
+	// the block interval is 10s longer than the interval between receiving
+	// the blocks
+	commit(2, now.Add(9*time.Second), true)
+	select {
+	case msg := <-driftCh:
+		data, ok := msg.Data().(types.EventDataBlockTimeDrift)
+		require.True(t, ok)
+		assert.EqualValues(t, 2, data.Height)
+		assert.InDelta(t, 9*time.Second, data.Drift, float64(time.Second))
+		assert.InDelta(t, 10*time.Second, data.IntervalDrift, float64(time.Second))
+	case <-time.After(ensureTimeout):
+		t.Fatal("expected a block time drift event")
+	}
+
+	// a block committed while replaying the WAL isn't measured
+	commit(3, now.Add(time.Hour), false)
+	ensureNoNewEventOnChannel(driftCh)
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
@@ -3,12 +3,14 @@ package types
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/p2p"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -18,6 +20,15 @@ type RoundVoteSet struct {
 	Precommits *types.VoteSet
 }
 
+const (
+	// DefaultMaxCatchupBytes is the default cap on the memory held by the
+	// catchup rounds of a HeightVoteSet.
+	DefaultMaxCatchupBytes = 32 << 20 // 32MB
+
+	// voteBytes is the approximate memory held by a vote in a vote set.
+	voteBytes = 256
+)
+
 var (
 	ErrGotVoteFromUnwantedRound = errors.New(
 		"peer has sent a vote that does not match our round for more than one round",
@@ -37,6 +48,11 @@ we create a new entry in roundVoteSets but also remember the
 peer to prevent abuse.
 We let each peer provide us with up to 2 unexpected "catchup" rounds.
 One for their LastCommit round, and another for the official commit round.
+
+The memory held by the catchup rounds of all peers is capped, so that peers
+can't exhaust it by spraying votes across many future rounds, e.g. under new
+peer IDs. Once the cap is reached, catchup rounds are evicted to make room for
+new ones: first those without any vote, then the oldest.
 */
 type HeightVoteSet struct {
 	chainID string
@@ -47,11 +63,16 @@ type HeightVoteSet struct {
 	round             int32                  // max tracked round
 	roundVoteSets     map[int32]RoundVoteSet // keys: [0...round]
 	peerCatchupRounds map[p2p.NodeID][]int32 // keys: peer.ID; values: at most 2 rounds
+	catchupRounds     []int32                // rounds > round, in the order they were added
+
+	maxCatchupBytes int64
+	onEvict         func(round int32, bytes int64)
 }
 
 func NewHeightVoteSet(chainID string, height int64, valSet *types.ValidatorSet) *HeightVoteSet {
 	hvs := &HeightVoteSet{
-		chainID: chainID,
+		chainID:         chainID,
+		maxCatchupBytes: DefaultMaxCatchupBytes,
 	}
 	hvs.Reset(height, valSet)
 	return hvs
@@ -65,11 +86,22 @@ func (hvs *HeightVoteSet) Reset(height int64, valSet *types.ValidatorSet) {
 	hvs.valSet = valSet
 	hvs.roundVoteSets = make(map[int32]RoundVoteSet)
 	hvs.peerCatchupRounds = make(map[p2p.NodeID][]int32)
+	hvs.catchupRounds = nil
 
 	hvs.addRound(0)
 	hvs.round = 0
 }
 
+// SetMaxCatchupBytes sets the cap on the memory held by catchup rounds, and a
+// function called with each evicted round and the memory it held, which may be
+// nil. It must not call back into the HeightVoteSet.
+func (hvs *HeightVoteSet) SetMaxCatchupBytes(maxBytes int64, onEvict func(round int32, bytes int64)) {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+	hvs.maxCatchupBytes = maxBytes
+	hvs.onEvict = onEvict
+}
+
 func (hvs *HeightVoteSet) Height() int64 {
 	hvs.mtx.Lock()
 	defer hvs.mtx.Unlock()
@@ -97,6 +129,15 @@ func (hvs *HeightVoteSet) SetRound(round int32) {
 		hvs.addRound(r)
 	}
 	hvs.round = round
+
+	// Catchup rounds up to round are now regular rounds.
+	catchupRounds := hvs.catchupRounds[:0]
+	for _, r := range hvs.catchupRounds {
+		if r > round {
+			catchupRounds = append(catchupRounds, r)
+		}
+	}
+	hvs.catchupRounds = catchupRounds
 }
 
 func (hvs *HeightVoteSet) addRound(round int32) {
@@ -122,8 +163,9 @@ func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID p2p.NodeID) (added bo
 	}
 	voteSet := hvs.getVoteSet(vote.Round, vote.Type)
 	if voteSet == nil {
-		if rndz := hvs.peerCatchupRounds[peerID]; len(rndz) < 2 {
+		if rndz := hvs.peerCatchupRounds[peerID]; len(rndz) < 2 && hvs.makeRoomForCatchupRound() {
 			hvs.addRound(vote.Round)
+			hvs.catchupRounds = append(hvs.catchupRounds, vote.Round)
 			voteSet = hvs.getVoteSet(vote.Round, vote.Type)
 			hvs.peerCatchupRounds[peerID] = append(rndz, vote.Round)
 		} else {
@@ -136,6 +178,38 @@ func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID p2p.NodeID) (added bo
 	return
 }
 
+// roundBytes returns the approximate memory held by a round.
+func (hvs *HeightVoteSet) roundBytes() int64 {
+	return 2 * int64(hvs.valSet.Size()) * voteBytes
+}
+
+// makeRoomForCatchupRound evicts catchup rounds until there is room for
+// another one, and returns whether there is.
+func (hvs *HeightVoteSet) makeRoomForCatchupRound() bool {
+	roundBytes := hvs.roundBytes()
+	if roundBytes > hvs.maxCatchupBytes {
+		return false
+	}
+	for int64(len(hvs.catchupRounds)+1)*roundBytes > hvs.maxCatchupBytes {
+		// Evict the oldest round without votes, or else the oldest round.
+		evict := 0
+		for i, r := range hvs.catchupRounds {
+			rvs := hvs.roundVoteSets[r]
+			if rvs.Prevotes.BitArray().IsEmpty() && rvs.Precommits.BitArray().IsEmpty() {
+				evict = i
+				break
+			}
+		}
+		round := hvs.catchupRounds[evict]
+		hvs.catchupRounds = append(hvs.catchupRounds[:evict], hvs.catchupRounds[evict+1:]...)
+		delete(hvs.roundVoteSets, round)
+		if hvs.onEvict != nil {
+			hvs.onEvict(round, roundBytes)
+		}
+	}
+	return true
+}
+
 func (hvs *HeightVoteSet) Prevotes(round int32) *types.VoteSet {
 	hvs.mtx.Lock()
 	defer hvs.mtx.Unlock()
@@ -258,6 +332,38 @@ func (hvs *HeightVoteSet) toAllRoundVotes() []roundVotes {
 	return allVotes
 }
 
+// ToProto returns the vote bitmaps of all rounds, including peer catchup
+// rounds, ordered by round.
+func (hvs *HeightVoteSet) ToProto() []tmcons.RoundVotes {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+
+	rounds := make([]int32, 0, len(hvs.roundVoteSets))
+	for round := range hvs.roundVoteSets {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+
+	votes := make([]tmcons.RoundVotes, len(rounds))
+	for i, round := range rounds {
+		rvs := hvs.roundVoteSets[round]
+		votes[i] = tmcons.RoundVotes{
+			Round:      round,
+			Prevotes:   rvs.Prevotes.BitArray().ToProto(),
+			Precommits: rvs.Precommits.BitArray().ToProto(),
+		}
+		if blockID, ok := rvs.Prevotes.TwoThirdsMajority(); ok {
+			pbi := blockID.ToProto()
+			votes[i].PrevotesMaj23 = &pbi
+		}
+		if blockID, ok := rvs.Precommits.TwoThirdsMajority(); ok {
+			pbi := blockID.ToProto()
+			votes[i].PrecommitsMaj23 = &pbi
+		}
+	}
+	return votes
+}
+
 type roundVotes struct {
 	Round              int32    `json:"round"`
 	Prevotes           []string `json:"prevotes"`
@@ -6,6 +6,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/internal/test/factory"
@@ -57,15 +59,117 @@ func TestPeerCatchupRounds(t *testing.T) {
 
 }
 
+func TestPeerCatchupRoundsEviction(t *testing.T) {
+	valSet, privVals := factory.RandValidatorSet(10, 1)
+
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+	evicted := []int32{}
+	// room for 3 catchup rounds
+	hvs.SetMaxCatchupBytes(3*hvs.roundBytes(), func(round int32, bytes int64) {
+		assert.Equal(t, hvs.roundBytes(), bytes)
+		evicted = append(evicted, round)
+	})
+
+	invalidVote := func(round int32) *types.Vote {
+		vote := makeVoteHR(t, 1, 0, round, privVals)
+		vote.Signature = []byte("invalid")
+		return vote
+	}
+
+	added, err := hvs.AddVote(makeVoteHR(t, 1, 0, 100, privVals), "peer1")
+	require.True(t, added)
+	require.NoError(t, err)
+	_, err = hvs.AddVote(invalidVote(200), "peer2")
+	require.Error(t, err)
+	_, err = hvs.AddVote(invalidVote(300), "peer3")
+	require.Error(t, err)
+	assert.Empty(t, evicted)
+
+	// Rounds without votes are evicted first, then the oldest rounds.
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 400, privVals), "peer4")
+	require.True(t, added)
+	require.NoError(t, err)
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 500, privVals), "peer5")
+	require.True(t, added)
+	require.NoError(t, err)
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 600, privVals), "peer6")
+	require.True(t, added)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{200, 300, 100}, evicted)
+	assert.Nil(t, hvs.Precommits(100))
+	assert.NotNil(t, hvs.Precommits(600))
+
+	// Catchup rounds which become regular rounds no longer count.
+	hvs.SetRound(500)
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 700, privVals), "peer7")
+	require.True(t, added)
+	require.NoError(t, err)
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 800, privVals), "peer8")
+	require.True(t, added)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{200, 300, 100}, evicted)
+
+	// No catchup rounds are added if a single one doesn't fit.
+	hvs.SetMaxCatchupBytes(hvs.roundBytes()-1, nil)
+	added, err = hvs.AddVote(makeVoteHR(t, 1, 0, 900, privVals), "peer9")
+	assert.False(t, added)
+	assert.Equal(t, ErrGotVoteFromUnwantedRound, err)
+}
+
+func TestHeightVoteSetToProto(t *testing.T) {
+	valSet, privVals := factory.RandValidatorSet(4, 1)
+
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+	hvs.SetRound(1)
+
+	blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size)}
+	for i := int32(0); i < 3; i++ {
+		added, err := hvs.AddVote(makeVoteHRB(t, 1, i, 1, blockID, privVals), "peer1")
+		require.True(t, added)
+		require.NoError(t, err)
+	}
+	// a catchup round ahead of ours
+	added, err := hvs.AddVote(makeVoteHR(t, 1, 3, 5, privVals), "peer1")
+	require.True(t, added)
+	require.NoError(t, err)
+
+	votes := hvs.ToProto()
+	require.Len(t, votes, 4)
+	for i, round := range []int32{-1, 0, 1, 5} {
+		assert.Equal(t, round, votes[i].Round)
+		assert.Nil(t, votes[i].PrevotesMaj23)
+	}
+
+	assert.Equal(t, []uint64{0x0}, votes[1].Precommits.Elems)
+	require.NotNil(t, votes[2].Precommits)
+	assert.EqualValues(t, 4, votes[2].Precommits.Bits)
+	assert.Equal(t, []uint64{0x7}, votes[2].Precommits.Elems)
+	require.NotNil(t, votes[2].PrecommitsMaj23)
+	assert.Equal(t, blockID.ToProto(), *votes[2].PrecommitsMaj23)
+
+	assert.Equal(t, []uint64{0x8}, votes[3].Precommits.Elems)
+	assert.Nil(t, votes[3].PrecommitsMaj23)
+}
+
 func makeVoteHR(t *testing.T, height int64, valIndex, round int32, privVals []types.PrivValidator) *types.Vote {
+	randBytes := tmrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{}}
+	return makeVoteHRB(t, height, valIndex, round, blockID, privVals)
+}
+
+func makeVoteHRB(
+	t *testing.T,
+	height int64,
+	valIndex, round int32,
+	blockID types.BlockID,
+	privVals []types.PrivValidator,
+) *types.Vote {
 	privVal := privVals[valIndex]
 	pubKey, err := privVal.GetPubKey(context.Background())
 	if err != nil {
 		panic(err)
 	}
 
-	randBytes := tmrand.Bytes(tmhash.Size)
-
 	vote := &types.Vote{
 		ValidatorAddress: pubKey.Address(),
 		ValidatorIndex:   valIndex,
@@ -73,7 +177,7 @@ func makeVoteHR(t *testing.T, height int64, valIndex, round int32, privVals []ty
 		Round:            round,
 		Timestamp:        tmtime.Now(),
 		Type:             tmproto.PrecommitType,
-		BlockID:          types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{}},
+		BlockID:          blockID,
 	}
 	chainID := config.ChainID()
 
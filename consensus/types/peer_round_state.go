@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/libs/bits"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -71,6 +72,28 @@ func (prs PeerRoundState) Copy() PeerRoundState {
 	return prs
 }
 
+// ToProto returns the PeerRoundState in the schema of
+// /dump_consensus_state_v2. The node ID and address are left empty.
+func (prs PeerRoundState) ToProto() tmcons.PeerStateDump {
+	return tmcons.PeerStateDump{
+		Height:                     prs.Height,
+		Round:                      prs.Round,
+		Step:                       uint32(prs.Step),
+		StartTime:                  prs.StartTime,
+		Proposal:                   prs.Proposal,
+		ProposalBlockPartSetHeader: prs.ProposalBlockPartSetHeader.ToProto(),
+		ProposalBlockParts:         prs.ProposalBlockParts.ToProto(),
+		ProposalPolRound:           prs.ProposalPOLRound,
+		ProposalPol:                prs.ProposalPOL.ToProto(),
+		Prevotes:                   prs.Prevotes.ToProto(),
+		Precommits:                 prs.Precommits.ToProto(),
+		LastCommitRound:            prs.LastCommitRound,
+		LastCommit:                 prs.LastCommit.ToProto(),
+		CatchupCommitRound:         prs.CatchupCommitRound,
+		CatchupCommit:              prs.CatchupCommit.ToProto(),
+	}
+}
+
 // StringIndented returns a string representation of the PeerRoundState
 func (prs PeerRoundState) StringIndented(indent string) string {
 	return fmt.Sprintf(`PeerRoundState{
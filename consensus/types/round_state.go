@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/libs/bytes"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -128,6 +130,58 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 	}
 }
 
+// ToProto returns the RoundState in the schema of /dump_consensus_state_v2.
+func (rs *RoundState) ToProto() tmcons.RoundStateDump {
+	rsd := tmcons.RoundStateDump{
+		Height:      rs.Height,
+		Round:       rs.Round,
+		Step:        uint32(rs.Step),
+		StartTime:   rs.StartTime,
+		CommitTime:  rs.CommitTime,
+		CommitRound: rs.CommitRound,
+		Locked: tmcons.LockInfo{
+			Round:   rs.LockedRound,
+			BlockID: blockIDProto(rs.LockedBlock, rs.LockedBlockParts),
+		},
+		Valid: tmcons.LockInfo{
+			Round:   rs.ValidRound,
+			BlockID: blockIDProto(rs.ValidBlock, rs.ValidBlockParts),
+		},
+		LastCommit:                rs.LastCommit.BitArray().ToProto(),
+		TriggeredTimeoutPrecommit: rs.TriggeredTimeoutPrecommit,
+	}
+
+	if rs.Validators != nil && rs.Validators.Size() > 0 {
+		rsd.ProposerAddress = rs.Validators.GetProposer().Address
+	}
+
+	if rs.Proposal != nil {
+		rsd.Proposal.HasProposal = true
+		rsd.Proposal.BlockID = rs.Proposal.BlockID.ToProto()
+		rsd.Proposal.PolRound = rs.Proposal.POLRound
+	} else {
+		rsd.Proposal.PolRound = -1
+	}
+	if rs.ProposalBlockParts != nil {
+		rsd.Proposal.BlockParts = rs.ProposalBlockParts.BitArray().ToProto()
+		rsd.Proposal.Complete = rs.ProposalBlockParts.IsComplete()
+	}
+
+	if rs.Votes != nil {
+		rsd.Votes = rs.Votes.ToProto()
+	}
+
+	return rsd
+}
+
+func blockIDProto(block *types.Block, parts *types.PartSet) tmproto.BlockID {
+	if block == nil {
+		return tmproto.BlockID{}
+	}
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	return blockID.ToProto()
+}
+
 // NewRoundEvent returns the RoundState with proposer information as an event.
 func (rs *RoundState) NewRoundEvent() types.EventDataNewRound {
 	addr := rs.Validators.GetProposer().Address
@@ -0,0 +1,144 @@
+package consensus
+
+import (
+	"bytes"
+	"runtime"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// voteVerifierQueueFactor bounds the number of votes queued for
+	// verification, per worker.
+	voteVerifierQueueFactor = 16
+
+	// voteSignatureCacheSize is the number of verified vote signatures cached,
+	// enough for the votes of a few rounds of large validator sets.
+	voteSignatureCacheSize = 10000
+)
+
+// voteVerifier verifies the signatures of the votes received from peers on a
+// bounded pool of workers, so that verification isn't bottlenecked on the
+// single consensus state goroutine in large validator sets. The votes are
+// handed off to the consensus state in the order they were received.
+//
+// Votes which can't be verified ahead, e.g. because they aren't for the
+// current or last height, or whose signatures are invalid, are handed off
+// unverified, so that the consensus state handles them as usual. The verified
+// signatures are cached, so that a vote gossiped by several peers is only
+// verified once.
+type voteVerifier struct {
+	state   *State
+	workers int
+	cache   *types.VoteSignatureCache
+
+	jobs    chan *voteJob // jobs to be verified by the workers
+	ordered chan *voteJob // jobs in the order they were received
+	closeCh <-chan struct{}
+}
+
+// voteJob is a vote to be verified, and handed off once done is closed.
+type voteJob struct {
+	vote   *VoteMessage
+	peerID p2p.NodeID
+	done   chan struct{}
+}
+
+// newVoteVerifier returns a vote verifier with a worker for each CPU usable
+// by the process, which stops when closeCh is closed.
+func newVoteVerifier(state *State, closeCh <-chan struct{}) *voteVerifier {
+	workers := runtime.GOMAXPROCS(0)
+	return &voteVerifier{
+		state:   state,
+		workers: workers,
+		cache:   types.NewVoteSignatureCache(voteSignatureCacheSize),
+		jobs:    make(chan *voteJob, workers*voteVerifierQueueFactor),
+		ordered: make(chan *voteJob, workers*voteVerifierQueueFactor),
+		closeCh: closeCh,
+	}
+}
+
+// start starts the workers and the routine handing off the verified votes.
+func (vv *voteVerifier) start() {
+	for i := 0; i < vv.workers; i++ {
+		go vv.verifyRoutine()
+	}
+	go vv.handOffRoutine()
+}
+
+// submit queues the vote for verification, blocking while the queue is full.
+func (vv *voteVerifier) submit(vote *VoteMessage, peerID p2p.NodeID) {
+	job := &voteJob{vote: vote, peerID: peerID, done: make(chan struct{})}
+
+	// Queue the job for hand-off first, so that jobs are handed off in the
+	// order they're submitted.
+	select {
+	case vv.ordered <- job:
+	case <-vv.closeCh:
+		return
+	}
+	select {
+	case vv.jobs <- job:
+	case <-vv.closeCh:
+	}
+}
+
+func (vv *voteVerifier) verifyRoutine() {
+	for {
+		select {
+		case job := <-vv.jobs:
+			vv.verify(job.vote.Vote)
+			close(job.done)
+
+		case <-vv.closeCh:
+			return
+		}
+	}
+}
+
+func (vv *voteVerifier) handOffRoutine() {
+	for {
+		select {
+		case job := <-vv.ordered:
+			select {
+			case <-job.done:
+			case <-vv.closeCh:
+				return
+			}
+			select {
+			case vv.state.peerMsgQueue <- msgInfo{job.vote, job.peerID}:
+			case <-vv.closeCh:
+				return
+			}
+
+		case <-vv.closeCh:
+			return
+		}
+	}
+}
+
+// verify verifies the signature of the vote with VerifyCached, if it's for the
+// current or last height, so that it isn't verified again when added to the
+// vote sets. Errors are left to the consensus state to handle.
+func (vv *voteVerifier) verify(vote *types.Vote) {
+	vv.state.mtx.RLock()
+	var valSet *types.ValidatorSet
+	switch vote.Height {
+	case vv.state.Height:
+		valSet = vv.state.Validators
+	case vv.state.Height - 1:
+		valSet = vv.state.LastValidators
+	}
+	chainID := vv.state.state.ChainID
+	vv.state.mtx.RUnlock()
+
+	if valSet == nil {
+		return
+	}
+	addr, val := valSet.GetByIndex(vote.ValidatorIndex)
+	if val == nil || !bytes.Equal(vote.ValidatorAddress, addr) {
+		return
+	}
+	_ = vote.VerifyCached(chainID, val.PubKey, vv.cache)
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVoteVerifier(t *testing.T) {
+	config := configSetup(t)
+
+	cs, vss := randState(config, 4)
+	closeCh := make(chan struct{})
+	t.Cleanup(func() { close(closeCh) })
+
+	vv := newVoteVerifier(cs, closeCh)
+	vv.start()
+
+	votes := signVotes(config, tmproto.PrevoteType, nil, types.PartSetHeader{}, vss[1:]...)
+	// a vote with an invalid signature
+	invalid := votes[1].Copy()
+	invalid.Signature = []byte("invalid")
+	// a vote for a future height, which can't be verified ahead
+	future := votes[2].Copy()
+	future.Height = cs.Height + 1
+	votes = append(votes, invalid, future)
+
+	for i, vote := range votes {
+		vv.submit(&VoteMessage{vote}, p2p.NodeID(rune('a'+i)))
+	}
+
+	// All votes are handed off in the order they were submitted.
+	for i, vote := range votes {
+		select {
+		case mi := <-cs.peerMsgQueue:
+			require.Equal(t, p2p.NodeID(rune('a'+i)), mi.PeerID)
+			require.Same(t, vote, mi.Msg.(*VoteMessage).Vote)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("vote %d wasn't handed off", i)
+		}
+	}
+
+	// The invalid vote is still rejected, and the verified votes are added.
+	_, err := cs.Votes.AddVote(invalid, "")
+	require.ErrorIs(t, err, types.ErrVoteInvalidSignature)
+	for _, vote := range votes[:3] {
+		added, err := cs.Votes.AddVote(vote, "")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+}
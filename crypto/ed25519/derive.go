@@ -0,0 +1,66 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hardenedOffset is added to the indexes of hardened derivation path levels.
+const hardenedOffset = 1 << 31
+
+// DerivePrivKey derives a private key from a seed, e.g. of a BIP39 mnemonic,
+// along a derivation path such as "m/44'/118'/0'/0'/0'", as defined by
+// SLIP-0010. Ed25519 only supports hardened derivation, so all levels of the
+// path must be hardened, marked with ' or H.
+func DerivePrivKey(seed []byte, path string) (PrivKey, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := hmacSHA512([]byte("ed25519 seed"), seed)
+	for _, index := range indexes {
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], index)
+		key, chainCode = hmacSHA512(chainCode, data)
+	}
+	return PrivKey(ed25519.NewKeyFromSeed(key)), nil
+}
+
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// parseDerivationPath parses a path of hardened levels, e.g. "m/44'/118'/0'",
+// into the indexes of its levels.
+func parseDerivationPath(path string) ([]uint32, error) {
+	levels := strings.Split(path, "/")
+	if levels[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(levels)-1)
+	for _, level := range levels[1:] {
+		if !strings.HasSuffix(level, "'") && !strings.HasSuffix(level, "H") {
+			return nil, fmt.Errorf("derivation path %q has a non-hardened level %q", path, level)
+		}
+		index, err := strconv.ParseUint(level[:len(level)-1], 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path %q has an invalid level %q", path, level)
+		}
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+	if len(indexes) == 0 {
+		return nil, errors.New("derivation path has no levels")
+	}
+	return indexes, nil
+}
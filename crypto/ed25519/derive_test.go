@@ -0,0 +1,38 @@
+package ed25519_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestDerivePrivKey(t *testing.T) {
+	// test vector 1 for ed25519 of SLIP-0010
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path string
+		seed string
+	}{
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0H/1H/2H", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"m/0'/1'/2'/2'", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, tc := range testCases {
+		privKey, err := ed25519.DerivePrivKey(seed, tc.path)
+		require.NoError(t, err, tc.path)
+		assert.Equal(t, tc.seed, hex.EncodeToString(privKey[:32]), tc.path)
+	}
+
+	for _, path := range []string{"", "m", "0'/1'", "m/0'/1", "m/x'", "m/2147483648'"} {
+		_, err := ed25519.DerivePrivKey(seed, path)
+		assert.Error(t, err, path)
+	}
+}
@@ -0,0 +1,122 @@
+package merkle
+
+import (
+	"fmt"
+
+	ics23 "github.com/confio/ics23/go"
+
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+const (
+	ProofOpICS23IAVL   = "ics23:iavl"
+	ProofOpICS23Simple = "ics23:simple"
+)
+
+// ICS23Op proves the existence or the absence of a key with an ICS-23
+// commitment proof and produces the root hash. An IAVL tree is proven with
+// the ics23:iavl type, and a Tendermint simple tree with ics23:simple.
+//
+// Run with a single value proves that the key maps to that value; run with
+// no args it proves that the key does not exist, which allows apps to serve
+// proofs of absence (see ProofRuntime.VerifyAbsence).
+type ICS23Op struct {
+	// Encoded in ProofOp.Type.
+	Type string
+
+	// Spec of the tree, derived from Type.
+	Spec *ics23.ProofSpec
+
+	// Encoded in ProofOp.Key.
+	key []byte
+
+	// To encode in ProofOp.Data
+	Proof *ics23.CommitmentProof
+}
+
+var _ ProofOperator = ICS23Op{}
+
+// NewICS23Op returns an ICS23Op for the given proof type, which must be one of
+// ProofOpICS23IAVL and ProofOpICS23Simple.
+func NewICS23Op(opType string, key []byte, proof *ics23.CommitmentProof) (ICS23Op, error) {
+	spec, err := ics23Spec(opType)
+	if err != nil {
+		return ICS23Op{}, err
+	}
+	return ICS23Op{
+		Type:  opType,
+		Spec:  spec,
+		key:   key,
+		Proof: proof,
+	}, nil
+}
+
+// ICS23OpDecoder decodes ICS23Ops. It is not registered with
+// DefaultProofRuntime, as apps may bring their own decoders for these types;
+// register it for both ProofOpICS23IAVL and ProofOpICS23Simple to use it.
+func ICS23OpDecoder(pop tmcrypto.ProofOp) (ProofOperator, error) {
+	var proof ics23.CommitmentProof
+	err := proof.Unmarshal(pop.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding ProofOp.Data into CommitmentProof: %w", err)
+	}
+	op, err := NewICS23Op(pop.Type, pop.Key, &proof)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func ics23Spec(opType string) (*ics23.ProofSpec, error) {
+	switch opType {
+	case ProofOpICS23IAVL:
+		return ics23.IavlSpec, nil
+	case ProofOpICS23Simple:
+		return ics23.TendermintSpec, nil
+	default:
+		return nil, fmt.Errorf("unexpected ProofOp.Type; got %v, want %v or %v",
+			opType, ProofOpICS23IAVL, ProofOpICS23Simple)
+	}
+}
+
+func (op ICS23Op) ProofOp() tmcrypto.ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return tmcrypto.ProofOp{
+		Type: op.Type,
+		Key:  op.key,
+		Data: bz,
+	}
+}
+
+func (op ICS23Op) String() string {
+	return fmt.Sprintf("ICS23Op{%v %v}", op.Type, op.GetKey())
+}
+
+func (op ICS23Op) Run(args [][]byte) ([][]byte, error) {
+	root, err := op.Proof.Calculate()
+	if err != nil {
+		return nil, fmt.Errorf("calculating root from proof: %w", err)
+	}
+
+	switch len(args) {
+	case 0:
+		if !ics23.VerifyNonMembership(op.Spec, root, op.Proof, op.key) {
+			return nil, fmt.Errorf("proof of absence failed for key %X", op.key)
+		}
+	case 1:
+		if !ics23.VerifyMembership(op.Spec, root, op.Proof, op.key, args[0]) {
+			return nil, fmt.Errorf("proof of existence failed for key %X", op.key)
+		}
+	default:
+		return nil, fmt.Errorf("expected 0 or 1 args, got %v", len(args))
+	}
+
+	return [][]byte{root}, nil
+}
+
+func (op ICS23Op) GetKey() []byte {
+	return op.key
+}
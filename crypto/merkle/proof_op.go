@@ -115,8 +115,10 @@ func (prt *ProofRuntime) VerifyValue(proof *tmcrypto.ProofOps, root []byte, keyp
 	return prt.Verify(proof, root, keypath, [][]byte{value})
 }
 
-// TODO In the long run we'll need a method of classifcation of ops,
-// whether existence or absence or perhaps a third?
+// VerifyAbsence verifies that the key path does not exist under the root. The
+// first operator is run without args and must prove the absence of its key,
+// e.g. an ICS-23 nonexistence proof; the following operators prove the
+// existence of the roots it produces, as for VerifyValue.
 func (prt *ProofRuntime) VerifyAbsence(proof *tmcrypto.ProofOps, root []byte, keypath string) (err error) {
 	return prt.Verify(proof, root, keypath, nil)
 }
@@ -131,7 +133,7 @@ func (prt *ProofRuntime) Verify(proof *tmcrypto.ProofOps, root []byte, keypath s
 
 // DefaultProofRuntime only knows about value proofs.
 // To use e.g. IAVL proofs, register op-decoders as
-// defined in the IAVL package.
+// defined in the IAVL package, or ICS23OpDecoder.
 func DefaultProofRuntime() (prt *ProofRuntime) {
 	prt = NewProofRuntime()
 	prt.RegisterOpDecoder(ProofOpValue, ValueOpDecoder)
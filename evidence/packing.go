@@ -0,0 +1,60 @@
+package evidence
+
+import (
+	"sort"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// packEvidence selects the evidence to propose within maxBytes, the size of
+// the evidence list in the block. The evidence is taken by priority: the
+// oldest first, as it expires first, and at the same height, the evidence
+// against the most voting power. Evidence which doesn't fit is skipped, so
+// that smaller evidence of a lower priority may still fit. A maxBytes of -1
+// takes all the evidence.
+func packEvidence(evidence []types.Evidence, maxBytes int64) ([]types.Evidence, int64) {
+	sorted := make([]types.Evidence, len(evidence))
+	copy(sorted, evidence)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if hi, hj := sorted[i].Height(), sorted[j].Height(); hi != hj {
+			return hi < hj
+		}
+		return evidencePower(sorted[i]) > evidencePower(sorted[j])
+	})
+
+	var (
+		packed    = make([]types.Evidence, 0, len(sorted))
+		totalSize int64
+	)
+	for _, ev := range sorted {
+		pb, err := types.EvidenceToProto(ev)
+		if err != nil {
+			continue
+		}
+		// the size of an evidence list is the sum of the sizes of its items
+		size := int64((&tmproto.EvidenceList{Evidence: []tmproto.Evidence{*pb}}).Size())
+		if maxBytes != -1 && totalSize+size > maxBytes {
+			continue
+		}
+		packed = append(packed, ev)
+		totalSize += size
+	}
+	return packed, totalSize
+}
+
+// evidencePower returns the voting power of the validators the evidence is
+// against.
+func evidencePower(ev types.Evidence) int64 {
+	switch ev := ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return ev.ValidatorPower
+	case *types.LightClientAttackEvidence:
+		var power int64
+		for _, val := range ev.ByzantineValidators {
+			power += val.VotingPower
+		}
+		return power
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package evidence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPackEvidence(t *testing.T) {
+	evTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	dupVote := func(height, power int64) *types.DuplicateVoteEvidence {
+		ev := types.NewMockDuplicateVoteEvidence(height, evTime, "test-chain")
+		ev.ValidatorPower = power
+		return ev
+	}
+	size := func(evs ...types.Evidence) int64 {
+		data := types.EvidenceData{Evidence: evs}
+		return data.ByteSize()
+	}
+
+	old := dupVote(1, 5)
+	strong := dupVote(2, 20)
+	weak := dupVote(2, 10)
+	recent := dupVote(3, 30)
+	evidence := []types.Evidence{recent, weak, strong, old}
+	evSize := size(old)
+
+	testCases := map[string]struct {
+		maxBytes int64
+		packed   []types.Evidence
+	}{
+		"no limit":       {-1, []types.Evidence{old, strong, weak, recent}},
+		"oldest first":   {2 * evSize, []types.Evidence{old, strong}},
+		"none fits":      {evSize - 1, []types.Evidence{}},
+		"exact fit":      {4 * evSize, []types.Evidence{old, strong, weak, recent}},
+		"one short":      {4*evSize - 1, []types.Evidence{old, strong, weak}},
+		"max bytes of 0": {0, []types.Evidence{}},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			packed, packedSize := packEvidence(evidence, tc.maxBytes)
+			assert.Equal(t, tc.packed, packed)
+			if len(packed) > 0 {
+				assert.Equal(t, size(packed...), packedSize)
+			} else {
+				assert.Zero(t, packedSize)
+			}
+		})
+	}
+
+	// evidence which doesn't fit is skipped for smaller evidence of a lower
+	// priority
+	pubKey, err := types.NewMockPV().GetPubKey(context.Background())
+	require.NoError(t, err)
+	val := types.NewValidator(pubKey, 100)
+	small := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: 2, ChainID: "test-chain", Time: evTime},
+				Commit: &types.Commit{Height: 2, Signatures: []types.CommitSig{types.NewCommitSigAbsent()}},
+			},
+			ValidatorSet: types.NewValidatorSet([]*types.Validator{val}),
+		},
+		CommonHeight:        2,
+		ByzantineValidators: []*types.Validator{val},
+		TotalVotingPower:    100,
+		Timestamp:           evTime,
+	}
+	require.Less(t, size(small), evSize)
+	packed, _ := packEvidence([]types.Evidence{small, old}, evSize)
+	assert.Equal(t, []types.Evidence{old}, packed)
+	packed, _ = packEvidence([]types.Evidence{small, old}, evSize-1)
+	assert.Equal(t, []types.Evidence{small}, packed)
+
+	// at the same height, the evidence against the most voting power first
+	assert.EqualValues(t, 100, evidencePower(small))
+	packed, _ = packEvidence([]types.Evidence{strong, small}, -1)
+	assert.Equal(t, []types.Evidence{small, strong}, packed)
+}
@@ -24,6 +24,7 @@ const (
 	// prefixes are unique across all tm db's
 	prefixCommitted = int64(9)
 	prefixPending   = int64(10)
+	prefixHash      = int64(13)
 )
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
@@ -49,6 +50,9 @@ type Pool struct {
 
 	pruningHeight int64
 	pruningTime   time.Time
+
+	// reasons of the latest rejections, reported by EvidenceStatus
+	rejected *rejectedEvidence
 }
 
 // NewPool creates an evidence pool. If using an existing evidence store,
@@ -67,12 +71,13 @@ func NewPool(logger log.Logger, evidenceDB dbm.DB, stateDB sm.Store, blockStore
 		evidenceStore:   evidenceDB,
 		evidenceList:    clist.New(),
 		consensusBuffer: make([]duplicateVoteSet, 0),
+		rejected:        newRejectedEvidence(maxRejectedEvidence),
 	}
 
 	// If pending evidence already in db, in event of prior failure, then check
 	// for expiration, update the size and load it back to the evidenceList.
 	pool.pruningHeight, pool.pruningTime = pool.removeExpiredPendingEvidence()
-	evList, _, err := pool.listEvidence(prefixPending, -1)
+	evList, err := pool.listEvidence(prefixPending)
 	if err != nil {
 		return nil, err
 	}
@@ -86,19 +91,20 @@ func NewPool(logger log.Logger, evidenceDB dbm.DB, stateDB sm.Store, blockStore
 	return pool, nil
 }
 
-// PendingEvidence is used primarily as part of block proposal and returns up to
-// maxNum of uncommitted evidence.
+// PendingEvidence is used primarily as part of block proposal and returns the
+// uncommitted evidence to propose within maxBytes, by priority, see
+// packEvidence. A maxBytes of -1 returns all of it.
 func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	if evpool.Size() == 0 {
 		return []types.Evidence{}, 0
 	}
 
-	evidence, size, err := evpool.listEvidence(prefixPending, maxBytes)
+	evidence, err := evpool.listEvidence(prefixPending)
 	if err != nil {
 		evpool.logger.Error("failed to retrieve pending evidence", "err", err)
 	}
 
-	return evidence, size
+	return packEvidence(evidence, maxBytes)
 }
 
 // Update takes both the new state and the evidence committed at that height and performs
@@ -161,6 +167,7 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 
 	// 1) Verify against state.
 	if err := evpool.verify(ev); err != nil {
+		evpool.rejected.add(ev.Hash(), err.Error())
 		return err
 	}
 
@@ -311,6 +318,10 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 		return fmt.Errorf("failed to persist evidence: %w", err)
 	}
 
+	if err := evpool.indexEvidence(ev); err != nil {
+		return fmt.Errorf("failed to index evidence: %w", err)
+	}
+
 	atomic.AddUint32(&evpool.evidenceSize, 1)
 	return nil
 }
@@ -345,6 +356,10 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height
 			evpool.logger.Error("failed to save committed evidence", "key(height/hash)", key, "err", err)
 		}
 
+		if err := evpool.indexEvidence(ev); err != nil {
+			evpool.logger.Error("failed to index committed evidence", "key(height/hash)", key, "err", err)
+		}
+
 		evpool.logger.Debug("marked evidence as committed", "evidence", ev)
 	}
 
@@ -366,19 +381,13 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height
 	atomic.AddUint32(&evpool.evidenceSize, ^uint32(len(blockEvidenceMap)-1))
 }
 
-// listEvidence retrieves lists evidence from oldest to newest within maxBytes.
-// If maxBytes is -1, there's no cap on the size of returned evidence.
-func (evpool *Pool) listEvidence(prefixKey int64, maxBytes int64) ([]types.Evidence, int64, error) {
-	var (
-		evSize    int64
-		totalSize int64
-		evidence  []types.Evidence
-		evList    tmproto.EvidenceList // used for calculating the bytes size
-	)
+// listEvidence retrieves lists evidence from oldest to newest.
+func (evpool *Pool) listEvidence(prefixKey int64) ([]types.Evidence, error) {
+	var evidence []types.Evidence
 
 	iter, err := dbm.IteratePrefix(evpool.evidenceStore, prefixToBytes(prefixKey))
 	if err != nil {
-		return nil, totalSize, fmt.Errorf("database error: %v", err)
+		return nil, fmt.Errorf("database error: %v", err)
 	}
 
 	defer iter.Close()
@@ -387,33 +396,22 @@ func (evpool *Pool) listEvidence(prefixKey int64, maxBytes int64) ([]types.Evide
 		var evpb tmproto.Evidence
 
 		if err := evpb.Unmarshal(iter.Value()); err != nil {
-			return evidence, totalSize, err
-		}
-
-		evList.Evidence = append(evList.Evidence, evpb)
-		evSize = int64(evList.Size())
-
-		if maxBytes != -1 && evSize > maxBytes {
-			if err := iter.Error(); err != nil {
-				return evidence, totalSize, err
-			}
-			return evidence, totalSize, nil
+			return evidence, err
 		}
 
 		ev, err := types.EvidenceFromProto(&evpb)
 		if err != nil {
-			return nil, totalSize, err
+			return nil, err
 		}
 
-		totalSize = evSize
 		evidence = append(evidence, ev)
 	}
 
 	if err := iter.Error(); err != nil {
-		return evidence, totalSize, err
+		return evidence, err
 	}
 
-	return evidence, totalSize, nil
+	return evidence, nil
 }
 
 func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
@@ -445,6 +443,10 @@ func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
 	// update the evidence size
 	atomic.AddUint32(&evpool.evidenceSize, ^uint32(len(blockEvidenceMap)-1))
 
+	for hash := range blockEvidenceMap {
+		evpool.rejected.add([]byte(hash), "evidence expired before it was committed")
+	}
+
 	return height, time
 }
 
@@ -478,6 +480,9 @@ func (evpool *Pool) batchExpiredPendingEvidence(batch dbm.Batch) (int64, time.Ti
 			evpool.logger.Error("failed to batch delete evidence", "err", err, "ev", ev)
 			continue
 		}
+		if err := batch.Delete(keyHash(ev.Hash())); err != nil {
+			evpool.logger.Error("failed to batch delete evidence hash", "err", err, "ev", ev)
+		}
 
 		// and add to the map to remove the evidence from the clist
 		blockEvidenceMap[evMapKey(ev)] = struct{}{}
@@ -608,8 +613,11 @@ func prefixToBytes(prefix int64) []byte {
 }
 
 func keyCommitted(evidence types.Evidence) []byte {
-	var height int64 = evidence.Height()
-	key, err := orderedcode.Append(nil, prefixCommitted, height, string(evidence.Hash()))
+	return keyCommittedByHash(evidence.Height(), evidence.Hash())
+}
+
+func keyCommittedByHash(height int64, hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixCommitted, height, string(hash))
 	if err != nil {
 		panic(err)
 	}
@@ -617,8 +625,11 @@ func keyCommitted(evidence types.Evidence) []byte {
 }
 
 func keyPending(evidence types.Evidence) []byte {
-	var height int64 = evidence.Height()
-	key, err := orderedcode.Append(nil, prefixPending, height, string(evidence.Hash()))
+	return keyPendingByHash(evidence.Height(), evidence.Hash())
+}
+
+func keyPendingByHash(height int64, hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixPending, height, string(hash))
 	if err != nil {
 		panic(err)
 	}
@@ -232,6 +232,45 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	}
 }
 
+func TestEvidenceStatus(t *testing.T) {
+	height := int64(21)
+	pool, val := defaultTestPool(t, height)
+	state := pool.State()
+
+	expiredEv := types.NewMockDuplicateVoteEvidenceWithValidator(1, defaultEvidenceTime.Add(1*time.Minute),
+		val, evidenceChainID)
+	pendingEv := types.NewMockDuplicateVoteEvidenceWithValidator(2, defaultEvidenceTime.Add(2*time.Minute),
+		val, evidenceChainID)
+	committedEv := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime.Add(21*time.Minute),
+		val, evidenceChainID)
+	invalidEv := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime.Add(21*time.Minute),
+		types.NewMockPV(), evidenceChainID)
+
+	require.NoError(t, pool.AddEvidence(expiredEv))
+	require.NoError(t, pool.AddEvidence(pendingEv))
+	require.NoError(t, pool.AddEvidence(committedEv))
+	require.Error(t, pool.AddEvidence(invalidEv))
+
+	assert.Equal(t, evidence.EvidenceStatus{Status: evidence.StatusPending}, pool.EvidenceStatus(committedEv.Hash()))
+	status := pool.EvidenceStatus(invalidEv.Hash())
+	assert.Equal(t, evidence.StatusRejected, status.Status)
+	assert.NotEmpty(t, status.Reason)
+	assert.Equal(t, evidence.StatusUnknown, pool.EvidenceStatus([]byte("unknown")).Status)
+
+	lastCommit := makeCommit(height, val.PrivKey.PubKey().Address())
+	block := types.MakeBlock(height+1, []types.Tx{}, lastCommit, []types.Evidence{committedEv})
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(state, block.Evidence.Evidence)
+
+	assert.Equal(t, evidence.EvidenceStatus{Status: evidence.StatusCommitted, Height: height + 1},
+		pool.EvidenceStatus(committedEv.Hash()))
+	assert.Equal(t, evidence.EvidenceStatus{Status: evidence.StatusPending}, pool.EvidenceStatus(pendingEv.Hash()))
+	status = pool.EvidenceStatus(expiredEv.Hash())
+	assert.Equal(t, evidence.StatusRejected, status.Status)
+	assert.Contains(t, status.Reason, "expired")
+}
+
 func TestVerifyPendingEvidencePasses(t *testing.T) {
 	var height int64 = 1
 
@@ -0,0 +1,176 @@
+package evidence
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/google/orderedcode"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxRejectedEvidence is the number of rejected evidence the pool remembers
+// the rejection reason of.
+const maxRejectedEvidence = 1000
+
+// Status is the status of evidence in the pool.
+type Status int
+
+const (
+	// StatusUnknown is the status of evidence the pool has not seen, or the
+	// rejection of which it has forgotten.
+	StatusUnknown Status = iota
+	// StatusPending is the status of verified evidence waiting to be committed.
+	StatusPending
+	// StatusCommitted is the status of evidence committed in a block.
+	StatusCommitted
+	// StatusRejected is the status of evidence which failed verification or
+	// expired before it was committed.
+	StatusRejected
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusCommitted:
+		return "committed"
+	case StatusRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// EvidenceStatus describes what happened to evidence with a given hash.
+type EvidenceStatus struct {
+	Status Status
+	// Height of the block the evidence was committed in, if committed.
+	Height int64
+	// Reason the evidence was rejected, if rejected.
+	Reason string
+}
+
+// EvidenceStatus returns the status of the evidence with the given hash.
+// Pending and committed evidence is looked up in the evidence store, while
+// only the most recent rejections are remembered, and not across restarts.
+func (evpool *Pool) EvidenceStatus(hash []byte) EvidenceStatus {
+	if height, ok := evpool.evidenceHeight(hash); ok {
+		committedBytes, err := evpool.evidenceStore.Get(keyCommittedByHash(height, hash))
+		if err != nil {
+			evpool.logger.Error("failed to find committed evidence", "err", err)
+		}
+		if committedBytes != nil {
+			var h gogotypes.Int64Value
+			if err := proto.Unmarshal(committedBytes, &h); err != nil {
+				evpool.logger.Error("failed to unmarshal committed evidence height", "err", err)
+			}
+			return EvidenceStatus{Status: StatusCommitted, Height: h.Value}
+		}
+
+		ok, err := evpool.evidenceStore.Has(keyPendingByHash(height, hash))
+		if err != nil {
+			evpool.logger.Error("failed to find pending evidence", "err", err)
+		}
+		if ok {
+			return EvidenceStatus{Status: StatusPending}
+		}
+	}
+
+	if reason, ok := evpool.rejected.get(hash); ok {
+		return EvidenceStatus{Status: StatusRejected, Reason: reason}
+	}
+	return EvidenceStatus{Status: StatusUnknown}
+}
+
+// evidenceHeight returns the height of the pending or committed evidence with
+// the given hash.
+func (evpool *Pool) evidenceHeight(hash []byte) (int64, bool) {
+	bz, err := evpool.evidenceStore.Get(keyHash(hash))
+	if err != nil {
+		evpool.logger.Error("failed to find evidence by hash", "err", err)
+		return 0, false
+	}
+	if bz == nil {
+		return 0, false
+	}
+
+	var h gogotypes.Int64Value
+	if err := proto.Unmarshal(bz, &h); err != nil {
+		evpool.logger.Error("failed to unmarshal evidence height", "err", err)
+		return 0, false
+	}
+	return h.Value, true
+}
+
+// indexEvidence records the height of the evidence by its hash, so its status
+// can be looked up by hash.
+func (evpool *Pool) indexEvidence(ev types.Evidence) error {
+	bz, err := proto.Marshal(&gogotypes.Int64Value{Value: ev.Height()})
+	if err != nil {
+		return err
+	}
+	return evpool.evidenceStore.Set(keyHash(ev.Hash()), bz)
+}
+
+// rejectedEvidence is a bounded cache of the rejection reasons of evidence,
+// which evicts the oldest rejection when full.
+type rejectedEvidence struct {
+	mtx     sync.Mutex
+	size    int
+	reasons map[string]*list.Element
+	list    *list.List
+}
+
+type rejection struct {
+	hash   string
+	reason string
+}
+
+func newRejectedEvidence(size int) *rejectedEvidence {
+	return &rejectedEvidence{
+		size:    size,
+		reasons: make(map[string]*list.Element, size),
+		list:    list.New(),
+	}
+}
+
+func (r *rejectedEvidence) add(hash []byte, reason string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if e, ok := r.reasons[string(hash)]; ok {
+		e.Value.(*rejection).reason = reason
+		r.list.MoveToBack(e)
+		return
+	}
+
+	if r.list.Len() >= r.size {
+		oldest := r.list.Front()
+		delete(r.reasons, oldest.Value.(*rejection).hash)
+		r.list.Remove(oldest)
+	}
+	r.reasons[string(hash)] = r.list.PushBack(&rejection{hash: string(hash), reason: reason})
+}
+
+func (r *rejectedEvidence) get(hash []byte) (string, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	e, ok := r.reasons[string(hash)]
+	if !ok {
+		return "", false
+	}
+	return e.Value.(*rejection).reason, true
+}
+
+func keyHash(hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixHash, string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
@@ -0,0 +1,26 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectedEvidence(t *testing.T) {
+	r := newRejectedEvidence(2)
+	r.add([]byte("a"), "reason a")
+	r.add([]byte("b"), "reason b")
+
+	// re-adding refreshes the rejection, so b is evicted first
+	r.add([]byte("a"), "new reason a")
+	r.add([]byte("c"), "reason c")
+
+	reason, ok := r.get([]byte("a"))
+	assert.True(t, ok)
+	assert.Equal(t, "new reason a", reason)
+	_, ok = r.get([]byte("b"))
+	assert.False(t, ok)
+	reason, ok = r.get([]byte("c"))
+	assert.True(t, ok)
+	assert.Equal(t, "reason c", reason)
+}
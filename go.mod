@@ -11,6 +11,7 @@ require (
 	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/btcsuite/btcutil v1.0.2
 	github.com/confio/ics23/go v0.6.6
+	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
 	github.com/cosmos/iavl v0.16.0
 	github.com/fortytw2/leaktest v1.3.0
 	github.com/go-kit/kit v0.10.0
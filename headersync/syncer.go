@@ -0,0 +1,139 @@
+package headersync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// syncInterval is how often the syncer polls the primary for new headers.
+const syncInterval = time.Second
+
+// Syncer syncs the headers, commits and validator sets of a chain, without the
+// block data nor the app state, for header-only nodes. The light blocks are
+// fetched from RPC servers and verified with a light client, then stored so
+// that the node can serve the light block and commit routes to relayers and
+// light clients.
+//
+// The blocks are synced contiguously from the trusted height of the light
+// client, or from the latest stored height on restart.
+type Syncer struct {
+	service.BaseService
+
+	lc         *light.Client
+	stateStore sm.Store
+	blockStore *store.BlockStore
+	interval   time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the sync routine returned
+}
+
+// NewSyncer returns a header syncer verifying the light blocks with the given
+// light client, and storing them in the given stores.
+func NewSyncer(
+	lc *light.Client,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	logger log.Logger,
+) *Syncer {
+	s := &Syncer{
+		lc:         lc,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		interval:   syncInterval,
+	}
+	s.BaseService = *service.NewBaseService(logger, "HeaderSyncer", s)
+	return s
+}
+
+// OnStart implements service.Service.
+func (s *Syncer) OnStart() error {
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.done = make(chan struct{})
+	go s.syncRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service. It waits for the light block being saved,
+// if any, so that the stores can be closed.
+func (s *Syncer) OnStop() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *Syncer) syncRoutine(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx); err != nil && ctx.Err() == nil {
+			s.Logger.Error("failed to sync headers", "err", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sync verifies and stores the light blocks from the next height of the block
+// store up to the latest height of the primary.
+func (s *Syncer) sync(ctx context.Context) error {
+	height := s.blockStore.Height() + 1
+	if height == 1 {
+		firstHeight, err := s.lc.FirstTrustedHeight()
+		if err != nil {
+			return err
+		}
+		if firstHeight > 0 {
+			height = firstHeight
+		}
+	}
+
+	// The latest block of the primary is only used as a bound, the blocks are
+	// verified one by one against the previous one.
+	latest, err := s.lc.Primary().LightBlock(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest light block from the primary: %w", err)
+	}
+
+	for ; height <= latest.Height; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lb, err := s.lc.VerifyLightBlockAtHeight(ctx, height, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to verify light block at height %d: %w", height, err)
+		}
+		if err := s.saveLightBlock(lb); err != nil {
+			return fmt.Errorf("failed to save light block at height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+// saveLightBlock stores the validator set before the header, so that the
+// stored height is only advanced once the light block is complete.
+func (s *Syncer) saveLightBlock(lb *types.LightBlock) error {
+	if err := s.stateStore.SaveValidatorSet(lb.Height, lb.ValidatorSet); err != nil {
+		return err
+	}
+	if err := s.blockStore.SaveSignedHeader(lb.SignedHeader); err != nil {
+		return err
+	}
+	s.Logger.Debug("synced header", "height", lb.Height, "hash", lb.Hash())
+	return nil
+}
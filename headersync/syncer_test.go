@@ -0,0 +1,119 @@
+package headersync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/provider/mock"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = factory.DefaultTestChainID
+
+// makeLightBlocks returns a chain of light blocks from height 1 to n, signed by
+// a single validator set.
+func makeLightBlocks(t *testing.T, n int64) (map[int64]*types.SignedHeader, map[int64]*types.ValidatorSet) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	headers := make(map[int64]*types.SignedHeader, n)
+	valSets := make(map[int64]*types.ValidatorSet, n+1)
+
+	lastBlockID := factory.MakeBlockID()
+	start := time.Now().Add(-time.Hour)
+	for h := int64(1); h <= n; h++ {
+		header, err := factory.MakeHeader(&types.Header{
+			ChainID:            chainID,
+			Height:             h,
+			Time:               start.Add(time.Duration(h) * time.Second),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ProposerAddress:    vals.Proposer.Address,
+		})
+		require.NoError(t, err)
+
+		blockID := factory.MakeBlockIDWithHash(header.Hash())
+		voteSet := types.NewVoteSet(chainID, h, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, h, 0, voteSet, privVals, header.Time)
+		require.NoError(t, err)
+
+		headers[h] = &types.SignedHeader{Header: header, Commit: commit}
+		valSets[h] = vals
+		lastBlockID = blockID
+	}
+	valSets[n+1] = vals
+	return headers, valSets
+}
+
+// newMockProvider returns a mock provider serving the light blocks up to height.
+func newMockProvider(
+	id string,
+	headers map[int64]*types.SignedHeader,
+	vals map[int64]*types.ValidatorSet,
+	height int64,
+) *mock.Mock {
+	provHeaders := make(map[int64]*types.SignedHeader)
+	provVals := make(map[int64]*types.ValidatorSet)
+	for h := int64(1); h <= height; h++ {
+		provHeaders[h] = headers[h]
+		provVals[h] = vals[h]
+	}
+	provVals[height+1] = vals[height+1]
+	return mock.New(id, provHeaders, provVals)
+}
+
+func TestSyncer(t *testing.T) {
+	headers, vals := makeLightBlocks(t, 12)
+	primary := newMockProvider("primary", headers, vals, 10)
+	witness := newMockProvider("witness", headers, vals, 10)
+
+	ctx := context.Background()
+	lc, err := light.NewClient(ctx, chainID, light.TrustOptions{
+		Period: 24 * time.Hour,
+		Height: 3,
+		Hash:   headers[3].Hash(),
+	}, primary, []provider.Provider{witness}, lightdb.New(dbm.NewMemDB()), light.Logger(log.TestingLogger()))
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	syncer := NewSyncer(lc, stateStore, blockStore, log.TestingLogger())
+
+	// the blocks are synced from the trusted height
+	require.NoError(t, syncer.sync(ctx))
+	require.EqualValues(t, 3, blockStore.Base())
+	require.EqualValues(t, 10, blockStore.Height())
+
+	// new blocks are synced from the latest stored height
+	for h := int64(11); h <= 12; h++ {
+		lb := &types.LightBlock{SignedHeader: headers[h], ValidatorSet: vals[h]}
+		primary.AddLightBlock(lb)
+		witness.AddLightBlock(lb)
+	}
+	require.NoError(t, syncer.sync(ctx))
+	require.EqualValues(t, 3, blockStore.Base())
+	require.EqualValues(t, 12, blockStore.Height())
+
+	for h := int64(3); h <= 12; h++ {
+		meta := blockStore.LoadBlockMeta(h)
+		require.NotNil(t, meta)
+		require.Equal(t, headers[h].Hash(), meta.Header.Hash())
+		require.Equal(t, headers[h].Commit.Hash(), blockStore.LoadBlockCommit(h).Hash())
+		require.Nil(t, blockStore.LoadBlock(h))
+
+		valSet, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		require.Equal(t, vals[h].Hash(), valSet.Hash())
+	}
+}
@@ -0,0 +1,70 @@
+// Package sdnotify implements the systemd service notification protocol
+// (sd_notify), which lets systemd know when a service is ready or stopping
+// and supervise its liveness with a watchdog.
+package sdnotify
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Notification states, see sd_notify(3).
+const (
+	// Ready tells systemd the service finished starting up.
+	Ready = "READY=1"
+	// Stopping tells systemd the service is shutting down.
+	Stopping = "STOPPING=1"
+	// Watchdog resets the watchdog timer of the service.
+	Watchdog = "WATCHDOG=1"
+)
+
+// Notify sends a notification state to systemd. It returns false, without an
+// error, if the process wasn't started by systemd with notifications enabled,
+// i.e. NOTIFY_SOCKET is not set.
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+	// a leading @ denotes a socket in the abstract namespace
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns the watchdog timeout systemd configured for the
+// service (WatchdogSec). The service must send Watchdog notifications more
+// often than that, or it is considered hung. It returns 0 if the watchdog is
+// disabled or configured for another process.
+func WatchdogInterval() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, errors.New("WATCHDOG_USEC must be positive")
+	}
+	return time.Duration(n) * time.Microsecond, nil
+}
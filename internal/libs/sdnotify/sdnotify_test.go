@@ -0,0 +1,76 @@
+package sdnotify
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestNotify(t *testing.T) {
+	setenv(t, "NOTIFY_SOCKET", "")
+	sent, err := Notify(Ready)
+	require.NoError(t, err)
+	assert.False(t, sent)
+
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	setenv(t, "NOTIFY_SOCKET", socket)
+	sent, err = Notify(Ready)
+	require.NoError(t, err)
+	assert.True(t, sent)
+
+	buf := make([]byte, 64)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, Ready, string(buf[:n]))
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	testCases := []struct {
+		usec     string
+		pid      string
+		interval time.Duration
+		expErr   bool
+	}{
+		{"", "", 0, false},
+		{"30000000", "", 30 * time.Second, false},
+		{"30000000", strconv.Itoa(os.Getpid()), 30 * time.Second, false},
+		{"30000000", strconv.Itoa(os.Getpid() + 1), 0, false},
+		{"foo", "", 0, true},
+		{"-1", "", 0, true},
+	}
+	for _, tc := range testCases {
+		setenv(t, "WATCHDOG_USEC", tc.usec)
+		setenv(t, "WATCHDOG_PID", tc.pid)
+
+		interval, err := WatchdogInterval()
+		if tc.expErr {
+			assert.Error(t, err, tc.usec)
+			continue
+		}
+		require.NoError(t, err, tc.usec)
+		assert.Equal(t, tc.interval, interval, tc.usec)
+	}
+}
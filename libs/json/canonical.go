@@ -0,0 +1,253 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MarshalCanonical marshals the value as JSON, like Marshal, and returns its
+// canonical encoding. See Canonicalize.
+func MarshalCanonical(v interface{}) ([]byte, error) {
+	blob, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(blob)
+}
+
+// Canonicalize returns the canonical encoding of the given JSON, as per the
+// JSON Canonicalization Scheme (RFC 8785). The canonical encoding of JSON
+// values which only differ in formatting is the same byte-for-byte, whatever
+// the Go version or platform, so it can be hashed or signed:
+//
+//   - whitespace is removed
+//   - object members are sorted by key, comparing the UTF-16 code units
+//   - strings only escape '"', '\\' and the control characters
+//   - numbers are formatted as ECMAScript formats doubles
+//
+// Note that numbers are parsed as IEEE 754 doubles, so integers with more than
+// 53 bits of precision are rounded. 64-bit integers are encoded as strings by
+// Marshal, so they are not affected.
+//
+// Canonicalize errors on invalid JSON, duplicate object keys, invalid UTF-8
+// and numbers out of the range of doubles.
+func Canonicalize(blob []byte) ([]byte, error) {
+	if !utf8.Valid(blob) {
+		return nil, errors.New("invalid UTF-8")
+	}
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := canonicalizeValue(&buf, dec); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid JSON: data after the top-level value")
+	}
+	return buf.Bytes(), nil
+}
+
+func canonicalizeValue(buf *bytes.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			return canonicalizeObject(buf, dec)
+		case '[':
+			return canonicalizeArray(buf, dec)
+		default:
+			return fmt.Errorf("invalid JSON: unexpected %v", tok)
+		}
+	case json.Number:
+		s, err := canonicalNumber(tok)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case string:
+		writeCanonicalString(buf, tok)
+	case bool:
+		buf.WriteString(strconv.FormatBool(tok))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("invalid JSON: unexpected token %v", tok)
+	}
+	return nil
+}
+
+func canonicalizeObject(buf *bytes.Buffer, dec *json.Decoder) error {
+	type member struct {
+		key   string
+		value []byte
+	}
+	var members []member
+	keys := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("invalid JSON: unexpected object key %v", tok)
+		}
+		if keys[key] {
+			return fmt.Errorf("duplicate object key %q", key)
+		}
+		keys[key] = true
+
+		var value bytes.Buffer
+		if err := canonicalizeValue(&value, dec); err != nil {
+			return err
+		}
+		members = append(members, member{key: key, value: value.Bytes()})
+	}
+	if _, err := dec.Token(); err != nil { // '}'
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return lessUTF16(members[i].key, members[j].key)
+	})
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(buf, m.key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func canonicalizeArray(buf *bytes.Buffer, dec *json.Decoder) error {
+	buf.WriteByte('[')
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := canonicalizeValue(buf, dec); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil { // ']'
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// lessUTF16 compares the strings by their UTF-16 code units, as RFC 8785
+// sorts the object keys.
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// writeCanonicalString writes the string, escaping only '"', '\\' and the
+// control characters, with the short escapes where JSON has one.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// canonicalNumber formats the number as ECMAScript's Number.prototype.toString
+// formats doubles, i.e. with the shortest digits which round trip, and the
+// exponent notation only for very large and very small numbers.
+func canonicalNumber(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number %s: %w", n, err)
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("invalid number %s", n)
+	}
+	if f == 0 {
+		return "0", nil // including -0
+	}
+
+	var sign string
+	if f < 0 {
+		sign, f = "-", -f
+	}
+
+	// the shortest digits, and the exponent of the decimal point such that
+	// f = 0.digits * 10^point
+	mantissa, exp := splitExponent(strconv.FormatFloat(f, 'e', -1, 64))
+	digits := strings.Replace(mantissa, ".", "", 1)
+	point := exp + 1
+	k := len(digits)
+
+	switch {
+	case k <= point && point <= 21:
+		return sign + digits + strings.Repeat("0", point-k), nil
+	case 0 < point && point <= 21:
+		return sign + digits[:point] + "." + digits[point:], nil
+	case -6 < point && point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits, nil
+	}
+
+	e := point - 1
+	expSign := "+"
+	if e < 0 {
+		expSign, e = "-", -e
+	}
+	if k == 1 {
+		return sign + digits + "e" + expSign + strconv.Itoa(e), nil
+	}
+	return sign + digits[:1] + "." + digits[1:] + "e" + expSign + strconv.Itoa(e), nil
+}
+
+// splitExponent splits a number formatted by strconv.FormatFloat with the 'e'
+// format into its mantissa and exponent.
+func splitExponent(s string) (string, int) {
+	i := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[i+1:])
+	return s[:i], exp
+}
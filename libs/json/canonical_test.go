@@ -0,0 +1,111 @@
+package json_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/json"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testcases := map[string]struct {
+		input  string
+		output string
+	}{
+		"whitespace": {" { \"a\" : [ 1 , true , null ] } \n", `{"a":[1,true,null]}`},
+		"nested":     {`{"b":{"d":1,"c":2},"a":[{"f":1,"e":2}]}`, `{"a":[{"e":2,"f":1}],"b":{"c":2,"d":1}}`},
+		"empty":      {`{"a":{},"b":[],"c":""}`, `{"a":{},"b":[],"c":""}`},
+		// the example of RFC 8785, section 3.2.3
+		"key order": {
+			"{\"\u20ac\":\"Euro Sign\",\"\\r\":\"Carriage Return\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"," +
+				"\"1\":\"One\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\u0080\":\"Control\"," +
+				"\"\u00f6\":\"Latin Small Letter O With Diaeresis\"}",
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\"," +
+				"\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\"," +
+				"\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		"strings": {
+			`["A\/\u001f\u007f ","\"\\\b\f\n\r\t","<>&"]`,
+			`["A/\u001f` + "\u007f " + `","\"\\\b\f\n\r\t","<>&"]`,
+		},
+		"numbers": {`[1.0,-0,1E2,0.000001e-1,12.50]`, `[1,0,100,1e-7,12.5]`},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			output, err := json.Canonicalize([]byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.output, string(output))
+
+			// the canonical encoding is canonical
+			again, err := json.Canonicalize(output)
+			require.NoError(t, err)
+			assert.Equal(t, output, again)
+		})
+	}
+}
+
+func TestCanonicalizeNumbers(t *testing.T) {
+	// the examples of RFC 8785, appendix B
+	testcases := map[uint64]string{
+		0x0000000000000000: "0",
+		0x8000000000000000: "0",
+		0x0000000000000001: "5e-324",
+		0x8000000000000001: "-5e-324",
+		0x7fefffffffffffff: "1.7976931348623157e+308",
+		0xffefffffffffffff: "-1.7976931348623157e+308",
+		0x4340000000000000: "9007199254740992",
+		0xc340000000000000: "-9007199254740992",
+		0x4430000000000000: "295147905179352830000",
+		0x44b52d02c7e14af5: "9.999999999999997e+22",
+		0x44b52d02c7e14af6: "1e+23",
+		0x44b52d02c7e14af7: "1.0000000000000001e+23",
+		0x444b1ae4d6e2ef4e: "999999999999999700000",
+		0x444b1ae4d6e2ef4f: "999999999999999900000",
+		0x444b1ae4d6e2ef50: "1e+21",
+		0x3eb0c6f7a0b5ed8c: "9.999999999999997e-7",
+		0x3eb0c6f7a0b5ed8d: "0.000001",
+		0x41b3de4355555553: "333333333.3333332",
+		0x41b3de4355555554: "333333333.33333325",
+		0x41b3de4355555555: "333333333.3333333",
+		0x41b3de4355555556: "333333333.3333334",
+		0x41b3de4355555557: "333333333.33333343",
+		0xbecbf647612f3696: "-0.0000033333333333333333",
+		0x43143ff3c1cb0959: "1424953923781206.2",
+	}
+	for bits, expected := range testcases {
+		input := strconv.FormatFloat(math.Float64frombits(bits), 'g', -1, 64)
+		output, err := json.Canonicalize([]byte(input))
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, string(output), "%016x", bits)
+	}
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	testcases := map[string]string{
+		"invalid JSON":  `{"a":}`,
+		"trailing data": `{"a":1} {}`,
+		"duplicate key": `{"a":1,"a":2}`,
+		"invalid UTF-8": "\"\xff\"",
+		"number range":  `1e400`,
+		"empty":         ``,
+	}
+	for name, input := range testcases {
+		_, err := json.Canonicalize([]byte(input))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	output, err := json.MarshalCanonical(struct {
+		B int64   `json:"b"`
+		A float64 `json:"a"`
+		C *Car    `json:"c"`
+	}{B: 64, A: 1.5, C: &Car{Wheels: 4}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1.5,"b":"64","c":{"Wheels":4}}`, string(output))
+}
@@ -96,4 +96,11 @@
 //	Struct{Car: &Car{Wheels: 4}, Vehicle: &Car{Wheels: 4}}
 //	// Output: {"Car": {"Wheels: 4"}, "Vehicle": {"type":"vehicle/car","value":{"Wheels":4}}}
 //
+// The output of Marshal depends on the field order of structs and is not meant to be hashed or
+// signed. MarshalCanonical and Canonicalize return the canonical encoding of the JSON as per the
+// JSON Canonicalization Scheme (RFC 8785), which is the same byte-for-byte for JSON values which
+// only differ in formatting, whatever the Go version or platform:
+//
+//	Canonicalize([]byte(`{ "b": 1.50, "a": [true, null] }`)) // Output: {"a":[true,null],"b":1.5}
+//
 package json
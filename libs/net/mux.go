@@ -0,0 +1,282 @@
+package net
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxSniffBytes is the most bytes a Matcher is given to tell the protocol of a
+// connection.
+const maxSniffBytes = 64
+
+// ErrMuxClosed is returned by the Accept method of the listeners of a closed
+// Mux.
+var ErrMuxClosed = errors.New("mux listener is closed")
+
+// MatchResult is the result of a Matcher.
+type MatchResult int
+
+const (
+	// MatchNo means the connection doesn't speak the protocol.
+	MatchNo MatchResult = iota
+	// MatchYes means the connection speaks the protocol.
+	MatchYes
+	// MatchMore means more bytes are needed to tell.
+	MatchMore
+)
+
+// Matcher tells whether a connection speaks a protocol by the first bytes it
+// sent.
+type Matcher func(prefix []byte) MatchResult
+
+// PrefixMatcher returns a Matcher for the connections starting with any of the
+// prefixes.
+func PrefixMatcher(prefixes ...string) Matcher {
+	return func(b []byte) MatchResult {
+		result := MatchNo
+		for _, prefix := range prefixes {
+			switch {
+			case bytes.HasPrefix(b, []byte(prefix)):
+				return MatchYes
+			case len(b) < len(prefix) && bytes.HasPrefix([]byte(prefix), b):
+				result = MatchMore
+			}
+		}
+		return result
+	}
+}
+
+var (
+	// HTTP1Matcher matches HTTP/1.x requests, including websocket upgrades.
+	HTTP1Matcher = PrefixMatcher(
+		"GET ", "HEAD ", "POST ", "PUT ", "DELETE ", "OPTIONS ", "PATCH ", "CONNECT ", "TRACE ")
+	// HTTP2Matcher matches HTTP/2 connections with prior knowledge, e.g. gRPC.
+	HTTP2Matcher = PrefixMatcher("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+)
+
+// Mux serves several protocols on a single listener, e.g. a TCP port, by
+// handing each accepted connection to the listener of the protocol it speaks,
+// as told by the first bytes it sends. Connections matching no protocol go to
+// the default listener, or are closed if there is none.
+//
+// The clients of all protocols must send first. Connections which send
+// nothing within the sniff timeout are closed, as are the connections the
+// listener of their protocol doesn't accept within the sniff timeout, e.g.
+// since it's at its connection limit.
+type Mux struct {
+	root         net.Listener
+	sniffTimeout time.Duration
+	pending      chan struct{} // a slot per connection sniffed or delivered
+
+	mtx      sync.Mutex
+	matchers []Matcher
+	matched  []*muxListener
+	fallback *muxListener
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+// NewMux returns a Mux splitting the connections accepted by root. At most
+// maxPending connections are sniffed or waiting to be accepted at once; root
+// accepts no more connections until one of them is handed over or closed.
+// Call Serve once the listeners of all protocols are created.
+func NewMux(root net.Listener, sniffTimeout time.Duration, maxPending int) *Mux {
+	if maxPending < 1 {
+		maxPending = 1
+	}
+	return &Mux{
+		root:         root,
+		sniffTimeout: sniffTimeout,
+		pending:      make(chan struct{}, maxPending),
+		closeCh:      make(chan struct{}),
+	}
+}
+
+// Match returns a listener accepting the connections the matcher matches.
+// Matchers are tried in the order of the Match calls.
+func (m *Mux) Match(matcher Matcher) net.Listener {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	l := m.newListener()
+	m.matchers = append(m.matchers, matcher)
+	m.matched = append(m.matched, l)
+	return l
+}
+
+// Default returns a listener accepting the connections no matcher matches.
+func (m *Mux) Default() net.Listener {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.fallback == nil {
+		m.fallback = m.newListener()
+	}
+	return m.fallback
+}
+
+func (m *Mux) newListener() *muxListener {
+	return &muxListener{
+		mux:     m,
+		connCh:  make(chan net.Conn),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Serve accepts connections until the root listener fails or the Mux is
+// closed, and hands them to the listeners of their protocols. It blocks.
+func (m *Mux) Serve() error {
+	defer m.Close()
+	for {
+		select {
+		case m.pending <- struct{}{}:
+		case <-m.closeCh:
+			return nil
+		}
+		conn, err := m.root.Accept()
+		if err != nil {
+			<-m.pending
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() { // nolint:staticcheck
+				continue
+			}
+			select {
+			case <-m.closeCh:
+				return nil
+			default:
+				return err
+			}
+		}
+		go func() {
+			defer func() { <-m.pending }()
+			m.dispatch(conn)
+		}()
+	}
+}
+
+// dispatch reads the first bytes of conn until a matcher tells its protocol,
+// and hands it to the listener of the protocol.
+func (m *Mux) dispatch(conn net.Conn) {
+	m.mtx.Lock()
+	matchers, matched, fallback := m.matchers, m.matched, m.fallback
+	m.mtx.Unlock()
+
+	if err := conn.SetReadDeadline(time.Now().Add(m.sniffTimeout)); err != nil {
+		conn.Close()
+		return
+	}
+	var (
+		buf    = make([]byte, maxSniffBytes)
+		n      int
+		target *muxListener
+	)
+sniff:
+	for n < len(buf) {
+		read, err := conn.Read(buf[n:])
+		n += read
+		if err != nil {
+			conn.Close()
+			return
+		}
+		undecided := false
+		for i, matcher := range matchers {
+			switch matcher(buf[:n]) {
+			case MatchYes:
+				target = matched[i]
+				break sniff
+			case MatchMore:
+				undecided = true
+			}
+		}
+		if !undecided {
+			break
+		}
+	}
+	if target == nil {
+		target = fallback
+	}
+	if target == nil || conn.SetReadDeadline(time.Time{}) != nil {
+		conn.Close()
+		return
+	}
+	target.deliver(&sniffedConn{Conn: conn, prefix: buf[:n]}, m.sniffTimeout)
+}
+
+// Close closes the root listener and the listeners of all protocols.
+func (m *Mux) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+		err = m.root.Close()
+
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		for _, l := range m.matched {
+			l.Close()
+		}
+		if m.fallback != nil {
+			m.fallback.Close()
+		}
+	})
+	return err
+}
+
+// muxListener is the listener of a protocol of a Mux.
+type muxListener struct {
+	mux       *Mux
+	connCh    chan net.Conn
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+var _ net.Listener = (*muxListener)(nil)
+
+func (l *muxListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.connCh:
+		return conn, nil
+	case <-l.closeCh:
+		return nil, ErrMuxClosed
+	}
+}
+
+// deliver hands conn to Accept, or closes it if the listener is closed or
+// doesn't accept it within the timeout.
+func (l *muxListener) deliver(conn net.Conn, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case l.connCh <- conn:
+	case <-timer.C:
+		conn.Close()
+	case <-l.closeCh:
+		conn.Close()
+	}
+}
+
+// Close closes the listener, but not the other listeners of the Mux.
+func (l *muxListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closeCh) })
+	return nil
+}
+
+func (l *muxListener) Addr() net.Addr {
+	return l.mux.root.Addr()
+}
+
+// sniffedConn replays the bytes read to tell its protocol before reading on.
+type sniffedConn struct {
+	net.Conn
+	prefix []byte
+}
+
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,154 @@
+package net
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixMatcher(t *testing.T) {
+	match := PrefixMatcher("GET ", "POST ")
+	assert.Equal(t, MatchYes, match([]byte("GET /status HTTP/1.1")))
+	assert.Equal(t, MatchYes, match([]byte("POST ")))
+	assert.Equal(t, MatchMore, match([]byte("PO")))
+	assert.Equal(t, MatchMore, match(nil))
+	assert.Equal(t, MatchNo, match([]byte("GETX")))
+	assert.Equal(t, MatchNo, match([]byte{0x22, 0x0a}))
+}
+
+func TestMux(t *testing.T) {
+	root, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := NewMux(root, 100*time.Millisecond, 10)
+	httpListener := mux.Match(HTTP1Matcher)
+	http2Listener := mux.Match(HTTP2Matcher)
+	defaultListener := mux.Default()
+	go func() {
+		assert.NoError(t, mux.Serve())
+	}()
+	defer mux.Close()
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})}
+	go srv.Serve(httpListener) // nolint: errcheck
+	defer srv.Close()
+
+	addr := root.Addr().String()
+	res, err := http.Get("http://" + addr + "/status")
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	// the connections are handed over with the bytes sniffed
+	for _, tc := range []struct {
+		listener net.Listener
+		data     string
+	}{
+		{http2Listener, "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\nframes"},
+		{defaultListener, "\x22handshake"},
+		{defaultListener, "G\x00"},
+	} {
+		client, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		_, err = client.Write([]byte(tc.data))
+		require.NoError(t, err)
+
+		conn, err := tc.listener.Accept()
+		require.NoError(t, err)
+		buf := make([]byte, len(tc.data))
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, tc.data, string(buf))
+
+		// the sniff timeout no longer applies
+		time.Sleep(200 * time.Millisecond)
+		_, err = client.Write([]byte("more"))
+		require.NoError(t, err)
+		buf = make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		conn.Close()
+		client.Close()
+	}
+
+	// silent connections are closed after the sniff timeout
+	client, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = client.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+
+	require.NoError(t, mux.Close())
+	_, err = httpListener.Accept()
+	assert.Equal(t, ErrMuxClosed, err)
+	_, err = defaultListener.Accept()
+	assert.Equal(t, ErrMuxClosed, err)
+}
+
+func TestMux_NoDefault(t *testing.T) {
+	root, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := NewMux(root, time.Second, 10)
+	mux.Match(HTTP1Matcher)
+	go mux.Serve() // nolint: errcheck
+	defer mux.Close()
+
+	// connections matching no protocol are closed
+	client, err := net.Dial("tcp", root.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write([]byte("\x22handshake"))
+	require.NoError(t, err)
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	_, err = client.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestMux_MaxPending(t *testing.T) {
+	root, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := NewMux(root, 200*time.Millisecond, 1)
+	listener := mux.Match(HTTP1Matcher)
+	go mux.Serve() // nolint: errcheck
+	defer mux.Close()
+	addr := root.Addr().String()
+
+	// a silent connection takes the only slot until the sniff timeout
+	silent, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer silent.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	client, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+	require.NoError(t, err)
+
+	start := time.Now()
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+
+	// connections not accepted within the sniff timeout are closed
+	unaccepted, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer unaccepted.Close()
+	_, err = unaccepted.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, unaccepted.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, err = unaccepted.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+}
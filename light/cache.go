@@ -0,0 +1,116 @@
+package light
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// verifiedBlockKey identifies a light block by its height and hash.
+type verifiedBlockKey struct {
+	height int64
+	hash   string
+}
+
+// verifiedBlockCache is a bounded cache of light blocks which were verified and
+// cross-checked with the witnesses, but not saved to the trusted store, i.e.
+// the intermediate light blocks of a verification. It evicts the least
+// recently used block when full.
+type verifiedBlockCache struct {
+	mtx    sync.Mutex
+	size   int
+	blocks map[verifiedBlockKey]*list.Element
+	list   *list.List
+}
+
+func newVerifiedBlockCache(size int) *verifiedBlockCache {
+	return &verifiedBlockCache{
+		size:   size,
+		blocks: make(map[verifiedBlockKey]*list.Element, size),
+		list:   list.New(),
+	}
+}
+
+func keyOf(l *types.LightBlock) verifiedBlockKey {
+	return verifiedBlockKey{height: l.Height, hash: string(l.Hash())}
+}
+
+// add adds the light blocks to the cache.
+func (c *verifiedBlockCache) add(blocks ...*types.LightBlock) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, l := range blocks {
+		key := keyOf(l)
+		if e, ok := c.blocks[key]; ok {
+			c.list.MoveToBack(e)
+			continue
+		}
+
+		if c.list.Len() >= c.size {
+			oldest := c.list.Front()
+			delete(c.blocks, keyOf(oldest.Value.(*types.LightBlock)))
+			c.list.Remove(oldest)
+		}
+		c.blocks[key] = c.list.PushBack(l)
+	}
+}
+
+// get returns the light block with the given height and hash.
+func (c *verifiedBlockCache) get(height int64, hash []byte) (*types.LightBlock, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.blocks[verifiedBlockKey{height: height, hash: string(hash)}]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*types.LightBlock), true
+}
+
+// getAtHeight returns a light block at the given height.
+func (c *verifiedBlockCache) getAtHeight(height int64) (*types.LightBlock, bool) {
+	return c.closest(height, height)
+}
+
+// closestBefore returns the light block with the highest height in
+// (lowerBound, height), if any.
+func (c *verifiedBlockCache) closestBefore(lowerBound, height int64) (*types.LightBlock, bool) {
+	return c.closest(lowerBound+1, height-1)
+}
+
+// closest returns the light block with the highest height in [from, to].
+func (c *verifiedBlockCache) closest(from, to int64) (*types.LightBlock, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var closest *list.Element
+	for key, e := range c.blocks {
+		if key.height < from || key.height > to {
+			continue
+		}
+		if closest == nil || key.height > closest.Value.(*types.LightBlock).Height {
+			closest = e
+		}
+	}
+	if closest == nil {
+		return nil, false
+	}
+	c.list.MoveToBack(closest)
+	return closest.Value.(*types.LightBlock), true
+}
+
+// reset removes all light blocks from the cache.
+func (c *verifiedBlockCache) reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.blocks = make(map[verifiedBlockKey]*list.Element, c.size)
+	c.list.Init()
+}
@@ -52,6 +52,10 @@ const (
 
 	// 10s is sufficient for most networks.
 	defaultMaxBlockLag = 10 * time.Second
+
+	defaultPrimaryFailureThreshold = 1
+
+	defaultVerificationCacheSize = 100
 )
 
 // Option sets a parameter for the light client.
@@ -92,6 +96,17 @@ func PruningSize(h uint16) Option {
 	}
 }
 
+// VerificationCacheSize option sets the maximum amount of intermediate light
+// blocks, verified while verifying other light blocks, that the light client
+// keeps in memory. Verifying a light block at a nearby height then starts from
+// the closest one, instead of refetching and reverifying the same light blocks.
+// Default: 100. A size of 0 disables the cache.
+func VerificationCacheSize(n uint16) Option {
+	return func(c *Client) {
+		c.verificationCacheSize = n
+	}
+}
+
 // ConfirmationFunction option can be used to prompt to confirm an action. For
 // example, remove newer headers if the light client is being reset with an
 // older header. No confirmation is required by default!
@@ -130,6 +145,16 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// PrimaryFailureThreshold sets the number of consecutive requests the primary
+// must fail, by not responding or not having the requested block, before it is
+// replaced by a witness. Until then, the error is returned to the caller.
+// Invalid light blocks always cause the primary to be replaced. Default: 1.
+func PrimaryFailureThreshold(n uint16) Option {
+	return func(c *Client) {
+		c.primaryFailureThreshold = n
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -149,6 +174,12 @@ type Client struct {
 	primary provider.Provider
 	// Providers used to "witness" new headers.
 	witnesses []provider.Provider
+	// See PrimaryFailureThreshold option
+	primaryFailureThreshold uint16
+
+	// Health of the providers, see ProviderHealth
+	statsMtx tmsync.Mutex
+	stats    map[provider.Provider]*providerStats
 
 	// Where trusted light blocks are stored.
 	trustedStore store.Store
@@ -157,6 +188,11 @@ type Client struct {
 
 	// See RemoveNoLongerTrustedHeadersPeriod option
 	pruningSize uint16
+	// See VerificationCacheSize option
+	verificationCacheSize uint16
+	// Intermediate light blocks which were verified, but not saved to the
+	// trusted store.
+	verifiedBlocks *verifiedBlockCache
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
 	// The light client keeps track of how many times it has requested a light
@@ -236,17 +272,27 @@ func NewClientFromTrustedStore(
 		pruningSize:      defaultPruningSize,
 		confirmationFn:   func(action string) bool { return true },
 		logger:           log.NewNopLogger(),
+
+		primaryFailureThreshold: defaultPrimaryFailureThreshold,
+		stats:                   make(map[provider.Provider]*providerStats),
+		verificationCacheSize:   defaultVerificationCacheSize,
 	}
 
 	for _, o := range options {
 		o(c)
 	}
 
+	c.verifiedBlocks = newVerifiedBlockCache(int(c.verificationCacheSize))
+
 	// Validate the number of witnesses.
 	if len(c.witnesses) < 1 {
 		return nil, ErrNoWitnesses
 	}
 
+	if c.primaryFailureThreshold < 1 {
+		return nil, errors.New("primary failure threshold must be at least 1")
+	}
+
 	// Validate trust level.
 	if err := ValidateTrustLevel(c.trustLevel); err != nil {
 		return nil, err
@@ -480,6 +526,12 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 		return h, nil
 	}
 
+	// Check if the light block was verified as part of another verification.
+	if l, ok := c.verifiedBlocks.getAtHeight(height); ok {
+		c.logger.Debug("header has already been verified as an intermediate header", "height", height, "hash", l.Hash())
+		return l, c.updateTrustedLightBlock(l)
+	}
+
 	// Request the light block from primary
 	l, err := c.lightBlockFromPrimary(ctx, height)
 	if err != nil {
@@ -537,6 +589,13 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 		return nil
 	}
 
+	// Check if newHeader was verified as part of another verification.
+	if l, ok := c.verifiedBlocks.get(newHeader.Height, newHeader.Hash()); ok {
+		c.logger.Debug("header has already been verified as an intermediate header",
+			"height", newHeader.Height, "hash", newHeader.Hash())
+		return c.updateTrustedLightBlock(l)
+	}
+
 	// Request the header and the vals.
 	l, err = c.lightBlockFromPrimary(ctx, newHeader.Height)
 	if err != nil {
@@ -595,6 +654,10 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 		if err != nil {
 			return fmt.Errorf("can't get signed header before height %d: %w", newLightBlock.Height, err)
 		}
+		// Start from a closer intermediate light block, if one was verified before.
+		if cached, ok := c.verifiedBlocks.closestBefore(closestBlock.Height, newLightBlock.Height); ok {
+			closestBlock = cached
+		}
 		err = verifyFunc(ctx, closestBlock, newLightBlock, now)
 	}
 	if err != nil {
@@ -686,7 +749,20 @@ func (c *Client) verifySequential(
 	//
 	// CORRECTNESS ASSUMPTION: there's at least 1 correct full node
 	// (primary or one of the witnesses).
-	return c.detectDivergence(ctx, trace, now)
+	if err := c.detectDivergence(ctx, trace, now); err != nil {
+		return err
+	}
+
+	c.cacheIntermediateBlocks(trace)
+	return nil
+}
+
+// cacheIntermediateBlocks adds the light blocks of a verified trace, except
+// for the trusted and the target light blocks, to the verified block cache.
+func (c *Client) cacheIntermediateBlocks(trace []*types.LightBlock) {
+	if len(trace) > 2 {
+		c.verifiedBlocks.add(trace[1 : len(trace)-1]...)
+	}
 }
 
 // see VerifyHeader
@@ -829,6 +905,7 @@ func (c *Client) verifySkippingAgainstPrimary(
 		if cmpErr := c.detectDivergence(ctx, trace, now); cmpErr != nil {
 			return cmpErr
 		}
+		c.cacheIntermediateBlocks(trace)
 	default:
 		return err
 	}
@@ -882,6 +959,7 @@ func (c *Client) Witnesses() []provider.Provider {
 func (c *Client) Cleanup() error {
 	c.logger.Info("removing all light blocks")
 	c.latestTrustedBlock = nil
+	c.verifiedBlocks.reset()
 	return c.trustedStore.Prune(0)
 }
 
@@ -908,6 +986,7 @@ func (c *Client) cleanupAfter(height int64) error {
 	}
 
 	c.latestTrustedBlock = nil
+	c.verifiedBlocks.reset()
 	err := c.restoreTrustedLightBlock()
 	if err != nil {
 		return err
@@ -990,16 +1069,18 @@ func (c *Client) backwards(
 // lightBlockFromPrimary retrieves the lightBlock from the primary provider
 // at the specified height. This method also handles provider behavior as follows:
 //
-// 1. If the provider does not respond or does not have the block, it tries again
-//    with a different provider
+// 1. If the provider does not respond or does not have the block for
+//    PrimaryFailureThreshold consecutive requests, it tries again with a different
+//    provider. Otherwise, the error is returned
 // 2. If all providers return the same error, the light client forwards the error to
 //    where the initial request came from
 // 3. If the provider provides an invalid light block, is deemed unreliable or returns
 //    any other error, the primary is permanently dropped and is replaced by a witness.
 func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*types.LightBlock, error) {
 	c.providerMutex.Lock()
-	l, err := c.primary.LightBlock(ctx, height)
+	primary := c.primary
 	c.providerMutex.Unlock()
+	l, err := c.lightBlock(ctx, primary, height)
 
 	switch err {
 	case nil:
@@ -1007,6 +1088,12 @@ func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*type
 		return l, nil
 
 	case provider.ErrNoResponse, provider.ErrLightBlockNotFound, provider.ErrHeightTooHigh:
+		if failures := c.providerStatus(primary, true).ConsecutiveFailures; failures < uint64(c.primaryFailureThreshold) {
+			c.logger.Debug("error from light block request from primary",
+				"error", err, "height", height, "primary", primary, "failures", failures)
+			return nil, err
+		}
+
 		// we find a new witness to replace the primary
 		c.logger.Debug("error from light block request from primary, replacing...",
 			"error", err, "height", height, "primary", c.primary)
@@ -1021,6 +1108,18 @@ func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*type
 	}
 }
 
+// lightBlock requests the light block at the given height from p and records
+// the outcome in the health of p.
+func (c *Client) lightBlock(ctx context.Context, p provider.Provider, height int64) (*types.LightBlock, error) {
+	start := time.Now()
+	l, err := p.LightBlock(ctx, height)
+	// requests we cancelled, e.g. when racing witnesses, say nothing about p
+	if ctx.Err() == nil {
+		c.recordRequest(p, start, err)
+	}
+	return l, err
+}
+
 // NOTE: requires a providerMutex lock
 func (c *Client) removeWitnesses(indexes []int) error {
 	// check that we will still have witnesses remaining
@@ -1045,8 +1144,8 @@ type witnessResponse struct {
 	err          error
 }
 
-// findNewPrimary concurrently sends a light block request, promoting the first witness to return
-// a valid light block as the new primary. The remove option indicates whether the primary should be
+// findNewPrimary concurrently sends a light block request, promoting the first (i.e. fastest) witness
+// to return a valid light block as the new primary. The remove option indicates whether the primary should be
 // entire removed or just appended to the back of the witnesses list. This method also handles witness
 // errors. If no witness is available, it returns the last error of the witness.
 func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool) (*types.LightBlock, error) {
@@ -1072,7 +1171,7 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 		go func(witnessIndex int, witnessResponsesC chan witnessResponse) {
 			defer wg.Done()
 
-			lb, err := c.witnesses[witnessIndex].LightBlock(subctx, height)
+			lb, err := c.lightBlock(subctx, c.witnesses[witnessIndex], height)
 			witnessResponsesC <- witnessResponse{lb, witnessIndex, err}
 		}(index, witnessResponsesC)
 	}
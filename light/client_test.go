@@ -783,6 +783,144 @@ func TestClientReplacesPrimaryWithWitnessIfPrimaryIsUnavailable(t *testing.T) {
 	assert.Equal(t, 2, len(c.Witnesses()))
 }
 
+func TestClientPrimaryFailureThreshold(t *testing.T) {
+	db := dbs.New(dbm.NewMemDB())
+	_, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		db,
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	c, err := light.NewClientFromTrustedStore(
+		chainID,
+		trustPeriod,
+		deadNode,
+		[]provider.Provider{fullNode, fullNode},
+		db,
+		light.PrimaryFailureThreshold(2),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	// the first failure is returned and the primary is kept
+	_, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.Equal(t, provider.ErrNoResponse, err)
+	assert.Equal(t, deadNode, c.Primary())
+
+	health := c.ProviderHealth()
+	require.Len(t, health, 3)
+	assert.True(t, health[0].Primary)
+	assert.EqualValues(t, 1, health[0].ConsecutiveFailures)
+	assert.False(t, health[0].Healthy())
+
+	// the second failure replaces the primary
+	_, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, deadNode, c.Primary())
+	assert.Len(t, c.Witnesses(), 2)
+
+	health = c.ProviderHealth()
+	assert.Equal(t, fullNode, health[0].Provider)
+	assert.True(t, health[0].Healthy())
+	assert.NotZero(t, health[0].Requests)
+	assert.Equal(t, deadNode, health[2].Provider)
+	// the dead node failed twice as primary and since as a witness
+	assert.GreaterOrEqual(t, health[2].Failures, uint64(2))
+	assert.Equal(t, provider.ErrNoResponse, health[2].LastError)
+}
+
+func TestClientAddRemoveProvider(t *testing.T) {
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{deadNode},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	witness := mockp.New(chainID, headerSet, valSet)
+
+	// removing the only witness is not possible
+	assert.Equal(t, light.ErrNoWitnesses, c.RemoveProvider(deadNode))
+	assert.Equal(t, light.ErrNoWitnesses, c.RemoveProvider(fullNode))
+	assert.Error(t, c.RemoveProvider(witness))
+
+	require.NoError(t, c.AddProvider(witness))
+	assert.Error(t, c.AddProvider(witness))
+	assert.Error(t, c.AddProvider(fullNode))
+	assert.Equal(t, []provider.Provider{deadNode, witness}, c.Witnesses())
+
+	// cross-checking with the witnesses fails on the dead node
+	_, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	// the witness which has not failed is promoted
+	require.NoError(t, c.RemoveProvider(fullNode))
+	assert.Equal(t, witness, c.Primary())
+	assert.Equal(t, []provider.Provider{deadNode}, c.Witnesses())
+
+	require.NoError(t, c.AddProvider(fullNode))
+	require.NoError(t, c.RemoveProvider(deadNode))
+	assert.Equal(t, []provider.Provider{fullNode}, c.Witnesses())
+}
+
+func TestClientVerificationCache(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		cacheSize uint16
+		cached    bool
+	}{
+		{"default", 100, true},
+		{"disabled", 0, false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			primary := mockp.New(chainID, headerSet, valSet)
+			c, err := light.NewClient(
+				ctx,
+				chainID,
+				trustOptions,
+				primary,
+				[]provider.Provider{mockp.New(chainID, headerSet, valSet)},
+				dbs.New(dbm.NewMemDB()),
+				light.SequentialVerification(),
+				light.VerificationCacheSize(tc.cacheSize),
+				light.Logger(log.TestingLogger()),
+			)
+			require.NoError(t, err)
+
+			// verifying height 3 verifies height 2 as an intermediate header
+			_, err = c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(2*time.Hour))
+			require.NoError(t, err)
+			_, err = c.TrustedLightBlock(2)
+			require.Error(t, err)
+
+			requests := c.ProviderHealth()[0].Requests
+			l, err := c.VerifyLightBlockAtHeight(ctx, 2, bTime.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, h2.Hash(), l.Hash())
+			if tc.cached {
+				assert.Equal(t, requests, c.ProviderHealth()[0].Requests)
+			} else {
+				assert.Greater(t, c.ProviderHealth()[0].Requests, requests)
+			}
+
+			// the light block is saved to the trusted store either way
+			l, err = c.TrustedLightBlock(2)
+			require.NoError(t, err)
+			assert.Equal(t, h2.Hash(), l.Hash())
+		})
+	}
+}
+
 func TestClient_BackwardsVerification(t *testing.T) {
 	{
 		trustHeader, _ := largeFullNode.LightBlock(ctx, 6)
@@ -0,0 +1,187 @@
+package light
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tendermint/tendermint/light/provider"
+)
+
+// ProviderStatus describes the health of a provider, as observed by the light
+// client.
+type ProviderStatus struct {
+	Provider provider.Provider
+	// Primary is true for the primary provider.
+	Primary bool
+	// Requests is the number of light block requests sent to the provider.
+	Requests uint64
+	// Failures is the number of requests which returned an error.
+	Failures uint64
+	// ConsecutiveFailures is the number of requests which returned an error
+	// since the last successful one.
+	ConsecutiveFailures uint64
+	// Latency is the duration of the last successful request.
+	Latency time.Duration
+	// LastError is the error returned by the last failed request, if any.
+	LastError error
+	// LastSuccess is when the last successful request finished.
+	LastSuccess time.Time
+}
+
+// Healthy returns true if the last request to the provider succeeded.
+func (s ProviderStatus) Healthy() bool {
+	return s.ConsecutiveFailures == 0
+}
+
+type providerStats struct {
+	requests            uint64
+	failures            uint64
+	consecutiveFailures uint64
+	latency             time.Duration
+	lastError           error
+	lastSuccess         time.Time
+}
+
+// recordRequest updates the statistics of p with the outcome of a light block
+// request which started at start.
+func (c *Client) recordRequest(p provider.Provider, start time.Time, err error) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	s, ok := c.stats[p]
+	if !ok {
+		s = &providerStats{}
+		c.stats[p] = s
+	}
+
+	s.requests++
+	if err != nil {
+		s.failures++
+		s.consecutiveFailures++
+		s.lastError = err
+		return
+	}
+	s.consecutiveFailures = 0
+	s.lastSuccess = time.Now()
+	s.latency = s.lastSuccess.Sub(start)
+}
+
+func (c *Client) providerStatus(p provider.Provider, primary bool) ProviderStatus {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	status := ProviderStatus{Provider: p, Primary: primary}
+	if s, ok := c.stats[p]; ok {
+		status.Requests = s.requests
+		status.Failures = s.failures
+		status.ConsecutiveFailures = s.consecutiveFailures
+		status.Latency = s.latency
+		status.LastError = s.lastError
+		status.LastSuccess = s.lastSuccess
+	}
+	return status
+}
+
+// ProviderHealth returns the health of the primary, followed by the health of
+// the witnesses.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) ProviderHealth() []ProviderStatus {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	statuses := make([]ProviderStatus, 0, len(c.witnesses)+1)
+	statuses = append(statuses, c.providerStatus(c.primary, true))
+	for _, w := range c.witnesses {
+		statuses = append(statuses, c.providerStatus(w, false))
+	}
+	return statuses
+}
+
+// AddProvider adds p to the witnesses. Providers must be comparable, e.g.
+// pointers, so they can be removed again.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) AddProvider(p provider.Provider) error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	if p == c.primary {
+		return fmt.Errorf("provider %v is already the primary", p)
+	}
+	for _, w := range c.witnesses {
+		if p == w {
+			return fmt.Errorf("provider %v is already a witness", p)
+		}
+	}
+
+	c.witnesses = append(c.witnesses, p)
+	c.logger.Info("added witness", "witness", p)
+	return nil
+}
+
+// RemoveProvider removes p, which can be a witness or the primary. If p is the
+// primary, the healthiest witness is promoted to primary. It returns
+// ErrNoWitnesses if no witness would be left.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) RemoveProvider(p provider.Provider) error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	index := -1
+	for i, w := range c.witnesses {
+		if p == w {
+			index = i
+			break
+		}
+	}
+	if index == -1 && p != c.primary {
+		return fmt.Errorf("provider %v not found", p)
+	}
+	if len(c.witnesses) <= 1 {
+		return ErrNoWitnesses
+	}
+
+	if p == c.primary {
+		index = c.healthiestWitness()
+		c.primary = c.witnesses[index]
+		c.logger.Info("removed primary, promoted witness", "primary", c.primary)
+	} else {
+		c.logger.Info("removed witness", "witness", p)
+	}
+
+	c.witnesses = append(c.witnesses[:index], c.witnesses[index+1:]...)
+
+	c.statsMtx.Lock()
+	delete(c.stats, p)
+	c.statsMtx.Unlock()
+	return nil
+}
+
+// healthiestWitness returns the index of the witness with the fewest
+// consecutive failures, using the lowest latency as a tiebreaker. Witnesses
+// which have not been used yet rank after the ones which succeeded before.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) healthiestWitness() int {
+	statuses := make([]ProviderStatus, len(c.witnesses))
+	indexes := make([]int, len(c.witnesses))
+	for i, w := range c.witnesses {
+		statuses[i] = c.providerStatus(w, false)
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := statuses[indexes[i]], statuses[indexes[j]]
+		if a.ConsecutiveFailures != b.ConsecutiveFailures {
+			return a.ConsecutiveFailures < b.ConsecutiveFailures
+		}
+		if a.LastSuccess.IsZero() != b.LastSuccess.IsZero() {
+			return !a.LastSuccess.IsZero()
+		}
+		return a.Latency < b.Latency
+	})
+	return indexes[0]
+}
@@ -0,0 +1,202 @@
+/*
+Package lighttest manufactures chains of signed headers, and forks of them, for
+testing how light clients and their users handle light client attacks.
+
+A Chain is generated with NewChain, and a conflicting chain with Chain.Fork,
+whose validator overlap with the original chain controls the kind of attack:
+all validators signing the fork is an equivocation, some of them a lunatic
+attack. Serving the chains with mock providers, e.g. the original chain from a
+witness and the fork from the primary, feeds the conflicting headers through
+the detector of a light client:
+
+	chain := lighttest.NewChain("test-chain", 10, 4, time.Now().Add(-time.Hour))
+	fork := chain.Fork(6, 4)
+	client, err := light.NewClient(ctx, chain.ChainID, chain.TrustOptions(time.Hour),
+		fork.Provider("primary"), []provider.Provider{chain.Provider("witness")},
+		dbs.New(dbm.NewMemDB()))
+	...
+	_, err = client.VerifyLightBlockAtHeight(ctx, 10, time.Now()) // light.ErrLightClientAttack
+*/
+package lighttest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider/mock"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// validatorPower is the voting power of every generated validator.
+const validatorPower = 10
+
+// Chain is a chain of signed headers from height 1 on, with a block per
+// minute. The validator set doesn't change within the chain.
+type Chain struct {
+	ChainID string
+	Keys    []crypto.PrivKey
+	Headers map[int64]*types.SignedHeader
+	Vals    map[int64]*types.ValidatorSet
+}
+
+// NewChain generates a chain of the given height, whose blocks are signed by
+// all of valSize validators of equal voting power. The first block is at
+// genesisTime.
+func NewChain(chainID string, height int64, valSize int, genesisTime time.Time) *Chain {
+	keys := make([]crypto.PrivKey, valSize)
+	for i := range keys {
+		keys[i] = ed25519.GenPrivKey()
+	}
+	c := &Chain{
+		ChainID: chainID,
+		Keys:    keys,
+		Headers: make(map[int64]*types.SignedHeader, height),
+		Vals:    make(map[int64]*types.ValidatorSet, height),
+	}
+	c.extend(1, height, genesisTime, types.BlockID{}, keys, nil)
+	return c
+}
+
+// Height returns the height of the latest block of the chain.
+func (c *Chain) Height() int64 {
+	return int64(len(c.Headers))
+}
+
+// LightBlock returns the light block of the chain at the given height, or nil
+// if there is none.
+func (c *Chain) LightBlock(height int64) *types.LightBlock {
+	if c.Headers[height] == nil {
+		return nil
+	}
+	return &types.LightBlock{SignedHeader: c.Headers[height], ValidatorSet: c.Vals[height]}
+}
+
+// Fork returns a chain of the same height which conflicts with c from the
+// given height on: the forked blocks contain other txs. They are signed by
+// overlap of the validators of c, replacing the others with new validators.
+//
+// With overlap equal to the number of validators, the fork is an equivocation:
+// the validators of c sign conflicting blocks. With fewer, it is a lunatic
+// attack: the fork has another validator set, which light clients skipping
+// from a trusted height of c accept if the overlap holds enough voting power
+// for their trust level (1/3 by default).
+func (c *Chain) Fork(height int64, overlap int) *Chain {
+	if height < 2 || height > c.Height() {
+		panic(fmt.Sprintf("fork height %d must be within 2 and %d", height, c.Height()))
+	}
+	if overlap < 0 || overlap > len(c.Keys) {
+		panic(fmt.Sprintf("overlap %d must be within 0 and %d", overlap, len(c.Keys)))
+	}
+
+	keys := make([]crypto.PrivKey, len(c.Keys))
+	copy(keys, c.Keys[:overlap])
+	for i := overlap; i < len(keys); i++ {
+		keys[i] = ed25519.GenPrivKey()
+	}
+	fork := &Chain{
+		ChainID: c.ChainID,
+		Keys:    keys,
+		Headers: make(map[int64]*types.SignedHeader, c.Height()),
+		Vals:    make(map[int64]*types.ValidatorSet, c.Height()),
+	}
+	for h := int64(1); h < height; h++ {
+		fork.Headers[h] = c.Headers[h]
+		fork.Vals[h] = c.Vals[h]
+	}
+	last := c.Headers[height-1]
+	fork.extend(height, c.Height(), last.Time.Add(time.Minute), last.Commit.BlockID, keys,
+		types.Txs{types.Tx("fork")})
+	return fork
+}
+
+// Provider returns a mock provider with the given ID serving the blocks of
+// the chain. Light clients report the evidence of attacks they detect to it,
+// which can be checked with HasEvidence.
+func (c *Chain) Provider(id string) *mock.Mock {
+	headers := make(map[int64]*types.SignedHeader, len(c.Headers))
+	vals := make(map[int64]*types.ValidatorSet, len(c.Vals))
+	for h := range c.Headers {
+		headers[h] = c.Headers[h]
+		vals[h] = c.Vals[h]
+	}
+	return mock.New(id, headers, vals)
+}
+
+// TrustOptions returns the options for a light client to trust the first
+// block of the chain for the given period.
+func (c *Chain) TrustOptions(period time.Duration) light.TrustOptions {
+	return light.TrustOptions{
+		Period: period,
+		Height: 1,
+		Hash:   c.Headers[1].Hash(),
+	}
+}
+
+// extend generates the blocks from height from to height to, containing the
+// given txs and signed by the validators with the given keys.
+func (c *Chain) extend(from, to int64, t time.Time, lastBlockID types.BlockID, keys []crypto.PrivKey, txs types.Txs) {
+	validators := make([]*types.Validator, len(keys))
+	for i, key := range keys {
+		validators[i] = types.NewValidator(key.PubKey(), validatorPower)
+	}
+	vals := types.NewValidatorSet(validators)
+
+	for height := from; height <= to; height++ {
+		header := &types.Header{
+			Version:            version.Consensus{Block: version.BlockProtocol},
+			ChainID:            c.ChainID,
+			Height:             height,
+			Time:               t,
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			DataHash:           txs.Hash(),
+			ConsensusHash:      tmhash.Sum([]byte("cons_hash")),
+			AppHash:            tmhash.Sum([]byte("app_hash")),
+			LastResultsHash:    tmhash.Sum([]byte("results_hash")),
+			ProposerAddress:    vals.Validators[0].Address,
+		}
+		c.Headers[height] = &types.SignedHeader{Header: header, Commit: signHeader(header, vals, keys)}
+		c.Vals[height] = vals.Copy()
+
+		lastBlockID = c.Headers[height].Commit.BlockID
+		t = t.Add(time.Minute)
+	}
+}
+
+// signHeader returns a commit of the header signed by all validators.
+func signHeader(header *types.Header, vals *types.ValidatorSet, keys []crypto.PrivKey) *types.Commit {
+	blockID := types.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum(header.Hash())},
+	}
+	sigs := make([]types.CommitSig, vals.Size())
+	for i := range sigs {
+		sigs[i] = types.NewCommitSigAbsent()
+	}
+	for _, key := range keys {
+		idx, _ := vals.GetByAddress(key.PubKey().Address())
+		vote := &types.Vote{
+			Type:             tmproto.PrecommitType,
+			Height:           header.Height,
+			Round:            1,
+			BlockID:          blockID,
+			Timestamp:        header.Time,
+			ValidatorAddress: key.PubKey().Address(),
+			ValidatorIndex:   idx,
+		}
+		sig, err := key.Sign(types.VoteSignBytes(header.ChainID, vote.ToProto()))
+		if err != nil {
+			panic(err)
+		}
+		vote.Signature = sig
+		sigs[idx] = vote.CommitSig()
+	}
+	return types.NewCommit(header.Height, 1, blockID, sigs)
+}
@@ -0,0 +1,77 @@
+package lighttest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/lighttest"
+	"github.com/tendermint/tendermint/light/provider"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestFork(t *testing.T) {
+	genesisTime := time.Now().Add(-time.Hour)
+	chain := lighttest.NewChain("test-chain", 10, 4, genesisTime)
+	require.EqualValues(t, 10, chain.Height())
+	for h := int64(1); h <= chain.Height(); h++ {
+		require.NoError(t, chain.LightBlock(h).ValidateBasic(chain.ChainID))
+	}
+
+	fork := chain.Fork(6, 2)
+	require.EqualValues(t, 10, fork.Height())
+	assert.Equal(t, chain.Headers[5], fork.Headers[5])
+	assert.NotEqual(t, chain.Headers[6].Hash(), fork.Headers[6].Hash())
+	assert.Equal(t, chain.Headers[5].Commit.BlockID, fork.Headers[6].LastBlockID)
+	assert.Equal(t, chain.Keys[:2], fork.Keys[:2])
+	assert.NotEqual(t, chain.Keys[2:], fork.Keys[2:])
+	for h := int64(1); h <= fork.Height(); h++ {
+		require.NoError(t, fork.LightBlock(h).ValidateBasic(fork.ChainID))
+	}
+
+	assert.Panics(t, func() { chain.Fork(1, 2) })
+	assert.Panics(t, func() { chain.Fork(6, 5) })
+}
+
+func TestDetector(t *testing.T) {
+	ctx := context.Background()
+	genesisTime := time.Now().Add(-time.Hour)
+	testCases := map[string]struct {
+		overlap      int
+		commonHeight int64
+		conflicting  int64
+	}{
+		// the validators sign conflicting blocks, the latest one is reported
+		"equivocation": {4, 10, 10},
+		// half of the validators are enough to skip to the latest block
+		"lunatic": {2, 1, 10},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			chain := lighttest.NewChain("test-chain", 10, 4, genesisTime)
+			fork := chain.Fork(6, tc.overlap)
+			primary, witness := fork.Provider("primary"), chain.Provider("witness")
+
+			client, err := light.NewClient(ctx, chain.ChainID, chain.TrustOptions(4*time.Hour),
+				primary, []provider.Provider{witness}, dbs.New(dbm.NewMemDB()),
+				light.Logger(log.TestingLogger()))
+			require.NoError(t, err)
+
+			_, err = client.VerifyLightBlockAtHeight(ctx, 10, time.Now())
+			assert.Equal(t, light.ErrLightClientAttack, err)
+
+			assert.True(t, witness.HasEvidence(&types.LightClientAttackEvidence{
+				ConflictingBlock: fork.LightBlock(tc.conflicting),
+				CommonHeight:     tc.commonHeight,
+			}))
+		})
+	}
+}
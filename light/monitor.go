@@ -0,0 +1,269 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	defaultMonitorInterval = 5 * time.Second
+
+	// maxForkBacktrack is how many heights the monitor walks back from the
+	// latest conflicting header to find where a witness diverged, the first
+	// time it checks that witness.
+	maxForkBacktrack = 100
+)
+
+// Fork describes a conflicting header served by a witness.
+type Fork struct {
+	Witness provider.Provider
+	// Trusted is the primary's block at the height the witness diverged.
+	Trusted *types.LightBlock
+	// Conflicting is the witness's block at the same height.
+	Conflicting *types.LightBlock
+	// Evidence is the evidence reported to the primary and all witnesses.
+	Evidence *types.LightClientAttackEvidence
+}
+
+// MonitorOption sets an optional parameter on the ForkMonitor.
+type MonitorOption func(*ForkMonitor)
+
+// MonitorInterval sets how often the ForkMonitor polls the providers (default:
+// 5s).
+func MonitorInterval(d time.Duration) MonitorOption {
+	return func(m *ForkMonitor) {
+		m.interval = d
+	}
+}
+
+// MonitorOnFork sets a function which is called for every detected fork, e.g.
+// to raise an alert.
+func MonitorOnFork(fn func(Fork)) MonitorOption {
+	return func(m *ForkMonitor) {
+		m.onFork = fn
+	}
+}
+
+// ForkMonitor periodically compares the latest headers of the primary, usually
+// the local node, with the ones of a set of witnesses. The primary is trusted.
+//
+// When a witness serves a header which conflicts with the primary's and is
+// signed by at least 1/3 of the validators, i.e. the network forked, the
+// monitor logs an error, calls the MonitorOnFork function and reports a
+// LightClientAttackEvidence to the primary and all witnesses. Such a witness
+// is not checked again.
+type ForkMonitor struct {
+	service.BaseService
+
+	chainID   string
+	primary   provider.Provider
+	witnesses []provider.Provider
+	interval  time.Duration
+	onFork    func(Fork)
+
+	// per witness: the last height it agreed with the primary on, and whether
+	// it forked
+	lastAgreed []int64
+	forked     []bool
+
+	cancel context.CancelFunc
+}
+
+// NewForkMonitor returns a new ForkMonitor. Start it to poll the providers in
+// the background, or use Check to compare their headers once.
+func NewForkMonitor(
+	chainID string,
+	primary provider.Provider,
+	witnesses []provider.Provider,
+	options ...MonitorOption,
+) *ForkMonitor {
+	m := &ForkMonitor{
+		chainID:    chainID,
+		primary:    primary,
+		witnesses:  witnesses,
+		interval:   defaultMonitorInterval,
+		lastAgreed: make([]int64, len(witnesses)),
+		forked:     make([]bool, len(witnesses)),
+	}
+	m.BaseService = *service.NewBaseService(log.NewNopLogger(), "ForkMonitor", m)
+
+	for _, o := range options {
+		o(m)
+	}
+
+	return m
+}
+
+// OnStart implements service.Service.
+func (m *ForkMonitor) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	go m.monitorRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (m *ForkMonitor) OnStop() {
+	m.cancel()
+}
+
+func (m *ForkMonitor) monitorRoutine(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check compares the latest header of the primary with the ones of all
+// witnesses once, and returns the detected forks. It must not be called
+// concurrently.
+func (m *ForkMonitor) Check(ctx context.Context) []Fork {
+	latest, err := m.primary.LightBlock(ctx, 0)
+	if err != nil {
+		m.Logger.Error("failed to fetch the latest block from the primary", "primary", m.primary, "err", err)
+		return nil
+	}
+
+	var forks []Fork
+	for i, witness := range m.witnesses {
+		if m.forked[i] {
+			continue
+		}
+
+		fork, err := m.checkWitness(ctx, i, latest)
+		if err != nil {
+			m.Logger.Info("failed to compare headers with witness", "witness", witness, "err", err)
+			continue
+		}
+		if fork == nil {
+			continue
+		}
+
+		m.forked[i] = true
+		m.Logger.Error("FORK DETECTED. Witness serves a conflicting header signed by the validators",
+			"witness", witness, "height", fork.Trusted.Height,
+			"trusted", fork.Trusted.Hash(), "conflicting", fork.Conflicting.Hash())
+
+		m.reportEvidence(ctx, fork.Evidence, i)
+		if m.onFork != nil {
+			m.onFork(*fork)
+		}
+		forks = append(forks, *fork)
+	}
+	return forks
+}
+
+// checkWitness compares the headers of the primary and witness i at the
+// highest height both have. It returns a non-nil Fork if they conflict.
+func (m *ForkMonitor) checkWitness(ctx context.Context, i int, latest *types.LightBlock) (*Fork, error) {
+	witness := m.witnesses[i]
+
+	primaryBlock := latest
+	witnessBlock, err := witness.LightBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case witnessBlock.Height > primaryBlock.Height:
+		witnessBlock, err = witness.LightBlock(ctx, primaryBlock.Height)
+	case witnessBlock.Height < primaryBlock.Height:
+		primaryBlock, err = m.primary.LightBlock(ctx, witnessBlock.Height)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(primaryBlock.Hash(), witnessBlock.Hash()) {
+		m.lastAgreed[i] = primaryBlock.Height
+		return nil, nil
+	}
+
+	// Walk back to the first height the witness diverged at.
+	lastAgreed := m.lastAgreed[i]
+	if lastAgreed == 0 && primaryBlock.Height > maxForkBacktrack {
+		lastAgreed = primaryBlock.Height - maxForkBacktrack
+	}
+	trusted, conflicting := primaryBlock, witnessBlock
+	for height := trusted.Height - 1; height > lastAgreed; height-- {
+		p, err := m.primary.LightBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		w, err := witness.LightBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(p.Hash(), w.Hash()) {
+			break
+		}
+		trusted, conflicting = p, w
+	}
+
+	common := trusted
+	if trusted.Height > 1 {
+		common, err = m.primary.LightBlock(ctx, trusted.Height-1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.verifyConflictingBlock(conflicting, trusted, common); err != nil {
+		return nil, fmt.Errorf("witness serves an invalid conflicting header at height %d: %w", conflicting.Height, err)
+	}
+
+	return &Fork{
+		Witness:     witness,
+		Trusted:     trusted,
+		Conflicting: conflicting,
+		Evidence:    newLightClientAttackEvidence(conflicting, trusted, common),
+	}, nil
+}
+
+// verifyConflictingBlock checks that the conflicting block was signed by at
+// least 1/3 of the validators, otherwise the witness is merely faulty and
+// there is no evidence against any validator.
+func (m *ForkMonitor) verifyConflictingBlock(conflicting, trusted, common *types.LightBlock) error {
+	if err := conflicting.ValidateBasic(m.chainID); err != nil {
+		return err
+	}
+	if conflicting.ValidatorSet == nil {
+		return errors.New("missing validator set")
+	}
+
+	vals := trusted.ValidatorSet
+	ev := &types.LightClientAttackEvidence{ConflictingBlock: conflicting}
+	if ev.ConflictingHeaderIsInvalid(trusted.Header) {
+		// lunatic attack: the signers must have been validators before
+		vals = common.ValidatorSet
+	}
+	return vals.VerifyCommitLightTrusting(m.chainID, conflicting.Commit, DefaultTrustLevel)
+}
+
+func (m *ForkMonitor) reportEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, forked int) {
+	receivers := append([]provider.Provider{m.primary}, m.witnesses...)
+	for i, receiver := range receivers {
+		if i == forked+1 {
+			continue
+		}
+		if err := receiver.ReportEvidence(ctx, ev); err != nil {
+			m.Logger.Error("failed to report evidence to provider", "ev", ev, "provider", receiver, "err", err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	mockp "github.com/tendermint/tendermint/light/provider/mock"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestForkMonitor_Equivocation(t *testing.T) {
+	var (
+		latestHeight     = int64(10)
+		valSize          = 5
+		divergenceHeight = int64(6)
+		forkedHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		forkedValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+	headers, vals, chainKeys := genMockNodeWithKeys(chainID, latestHeight+2, valSize, 2, bTime)
+	primary := mockp.New(chainID, headers, vals)
+	honest := primary.Copy("honest")
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			forkedHeaders[height] = headers[height]
+			forkedValidators[height] = vals[height]
+			continue
+		}
+		// 4/5 of the validators vote again for a different block
+		forkedHeaders[height] = chainKeys[height].GenSignedHeader(chainID, height,
+			bTime.Add(time.Duration(height)*time.Minute), []types.Tx{[]byte("abcd")},
+			vals[height], vals[height+1], hash("app_hash"),
+			hash("cons_hash"), hash("results_hash"), 0, len(chainKeys[height])-1)
+		forkedValidators[height] = vals[height]
+	}
+	forked := mockp.New(chainID, forkedHeaders, forkedValidators)
+
+	var alerts []light.Fork
+	m := light.NewForkMonitor(chainID, primary, []provider.Provider{honest, forked},
+		light.MonitorOnFork(func(f light.Fork) { alerts = append(alerts, f) }))
+	m.SetLogger(log.TestingLogger())
+
+	forks := m.Check(ctx)
+	require.Len(t, forks, 1)
+	assert.Equal(t, forks, alerts)
+	assert.Equal(t, forked, forks[0].Witness)
+	assert.Equal(t, headers[divergenceHeight], forks[0].Trusted.SignedHeader)
+	assert.Equal(t, forkedHeaders[divergenceHeight], forks[0].Conflicting.SignedHeader)
+
+	// Check evidence was sent to the primary and the honest witness.
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: forkedHeaders[divergenceHeight],
+			ValidatorSet: forkedValidators[divergenceHeight],
+		},
+		CommonHeight: divergenceHeight,
+	}
+	assert.True(t, primary.HasEvidence(ev))
+	assert.True(t, honest.HasEvidence(ev))
+	assert.False(t, forked.HasEvidence(ev))
+
+	// The forked witness is not checked again.
+	assert.Empty(t, m.Check(ctx))
+}
+
+func TestForkMonitor_Lunatic(t *testing.T) {
+	var (
+		latestHeight     = int64(10)
+		valSize          = 5
+		divergenceHeight = int64(6)
+		forkedHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		forkedValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+	headers, vals, chainKeys := genMockNodeWithKeys(chainID, latestHeight, valSize, 2, bTime)
+	primary := mockp.New(chainID, headers, vals)
+	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3) // 2/5 of the validators remain
+	forgedVals := forgedKeys.ToValidators(2, 0)
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			forkedHeaders[height] = headers[height]
+			forkedValidators[height] = vals[height]
+			continue
+		}
+		forkedHeaders[height] = forgedKeys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
+		forkedValidators[height] = forgedVals
+	}
+	forked := mockp.New(chainID, forkedHeaders, forkedValidators)
+
+	m := light.NewForkMonitor(chainID, primary, []provider.Provider{forked})
+	m.SetLogger(log.TestingLogger())
+
+	forks := m.Check(ctx)
+	require.Len(t, forks, 1)
+
+	ev := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: forkedHeaders[divergenceHeight],
+			ValidatorSet: forkedValidators[divergenceHeight],
+		},
+		CommonHeight: divergenceHeight - 1,
+	}
+	assert.True(t, primary.HasEvidence(ev))
+}
+
+func TestForkMonitor_FaultyWitness(t *testing.T) {
+	var (
+		latestHeight     = int64(10)
+		divergenceHeight = int64(6)
+	)
+	headers, vals, _ := genMockNodeWithKeys(chainID, latestHeight, 5, 2, bTime)
+	primary := mockp.New(chainID, headers, vals)
+
+	// the conflicting headers are signed by unknown keys
+	var (
+		faultyHeaders = make(map[int64]*types.SignedHeader, latestHeight)
+		faultyVals    = make(map[int64]*types.ValidatorSet, latestHeight)
+		keys          = genPrivKeys(5)
+		fakeVals      = keys.ToValidators(2, 0)
+	)
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			faultyHeaders[height] = headers[height]
+			faultyVals[height] = vals[height]
+			continue
+		}
+		faultyHeaders[height] = keys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, fakeVals, fakeVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		faultyVals[height] = fakeVals
+	}
+	faulty := mockp.New(chainID, faultyHeaders, faultyVals)
+
+	m := light.NewForkMonitor(chainID, primary, []provider.Provider{faulty})
+	m.SetLogger(log.TestingLogger())
+
+	assert.Empty(t, m.Check(ctx))
+	assert.False(t, primary.HasEvidence(&types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: faultyHeaders[divergenceHeight],
+			ValidatorSet: faultyVals[divergenceHeight],
+		},
+		CommonHeight: divergenceHeight - 1,
+	}))
+}
+
+func TestForkMonitor_WitnessBehind(t *testing.T) {
+	headers, vals, _ := genMockNodeWithKeys(chainID, 10, 5, 2, bTime)
+	primary := mockp.New(chainID, headers, vals)
+
+	behindHeaders := make(map[int64]*types.SignedHeader)
+	behindVals := make(map[int64]*types.ValidatorSet)
+	for height := int64(1); height <= 7; height++ {
+		behindHeaders[height] = headers[height]
+		behindVals[height] = vals[height]
+	}
+	witness := mockp.New(chainID, behindHeaders, behindVals)
+
+	m := light.NewForkMonitor(chainID, primary, []provider.Provider{witness})
+	m.SetLogger(log.TestingLogger())
+
+	assert.Empty(t, m.Check(ctx))
+}
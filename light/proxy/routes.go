@@ -1,6 +1,9 @@
 package proxy
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/tendermint/tendermint/libs/bytes"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -21,17 +24,21 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), "", false),
 		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), "", false),
 		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), "", false),
-		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", true),
+		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight,proposer,has_txs,min_time,max_time,order_by,cursor", true), //nolint:lll
 		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", true),
 		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", true),
 		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height", true),
 		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash", true),
 		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height", true),
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", true),
+		"light_block":          rpcserver.NewRPCFunc(makeLightBlockFunc(c), "height", true),
+		"light_blocks":         rpcserver.NewRPCFunc(makeLightBlocksFunc(c), "minHeight,maxHeight", true),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", true),
+		"tx_proof":             rpcserver.NewRPCFunc(makeTxProofFunc(c), "hash", true),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by", false),
 		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by", false),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", true),
+		"validator_changes":    rpcserver.NewRPCFunc(makeValidatorChangesFunc(c), "from,to", false),
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), "", false),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), "", false),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", true),
@@ -41,6 +48,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx", false),
 		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx", false),
+		"broadcast_tx_batch":  rpcserver.NewRPCFunc(makeBroadcastTxBatchFunc(c), "txs", false),
 		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx", false),
 
 		// abci API
@@ -49,6 +57,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence", false),
+		"evidence":           rpcserver.NewRPCFunc(makeEvidenceFunc(c), "hash", false),
 	}
 }
 
@@ -77,11 +86,34 @@ func makeNetInfoFunc(c *lrpc.Client) rpcNetInfoFunc {
 	}
 }
 
-type rpcBlockchainInfoFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+type rpcBlockchainInfoFunc func(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64,
+	proposer bytes.HexBytes,
+	hasTxs *bool,
+	minTime, maxTime time.Time,
+	orderBy string,
+	cursor int64,
+) (*ctypes.ResultBlockchainInfo, error)
 
 func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
-	return func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
-		return c.BlockchainInfo(ctx.Context(), minHeight, maxHeight)
+	return func(
+		ctx *rpctypes.Context,
+		minHeight, maxHeight int64,
+		proposer bytes.HexBytes,
+		hasTxs *bool,
+		minTime, maxTime time.Time,
+		orderBy string,
+		cursor int64,
+	) (*ctypes.ResultBlockchainInfo, error) {
+		return c.BlockchainInfoWithOptions(ctx.Context(), minHeight, maxHeight, rpcclient.BlockchainInfoOptions{
+			Proposer: proposer,
+			HasTxs:   hasTxs,
+			MinTime:  minTime,
+			MaxTime:  maxTime,
+			OrderBy:  orderBy,
+			Cursor:   cursor,
+		})
 	}
 }
 
@@ -133,6 +165,22 @@ func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
 	}
 }
 
+type rpcLightBlockFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultLightBlock, error)
+
+func makeLightBlockFunc(c *lrpc.Client) rpcLightBlockFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultLightBlock, error) {
+		return c.LightBlock(ctx.Context(), height)
+	}
+}
+
+type rpcLightBlocksFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error)
+
+func makeLightBlocksFunc(c *lrpc.Client) rpcLightBlocksFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error) {
+		return c.LightBlocks(ctx.Context(), minHeight, maxHeight)
+	}
+}
+
 type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 func makeTxFunc(c *lrpc.Client) rpcTxFunc {
@@ -141,6 +189,14 @@ func makeTxFunc(c *lrpc.Client) rpcTxFunc {
 	}
 }
 
+type rpcTxProofFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxProof, error)
+
+func makeTxProofFunc(c *lrpc.Client) rpcTxProofFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+		return c.TxProof(ctx.Context(), hash)
+	}
+}
+
 type rpcTxSearchFunc func(
 	ctx *rpctypes.Context,
 	query string,
@@ -190,6 +246,17 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
+type rpcValidatorChangesFunc func(ctx *rpctypes.Context, from, to *int64) (*ctypes.ResultValidatorChanges, error)
+
+func makeValidatorChangesFunc(c *lrpc.Client) rpcValidatorChangesFunc {
+	return func(ctx *rpctypes.Context, from, to *int64) (*ctypes.ResultValidatorChanges, error) {
+		if from == nil {
+			return nil, fmt.Errorf("%w: from height is required", ctypes.ErrInvalidRequest)
+		}
+		return c.ValidatorChanges(ctx.Context(), *from, to)
+	}
+}
+
 type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
@@ -238,6 +305,14 @@ func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
 	}
 }
 
+type rpcBroadcastTxBatchFunc func(ctx *rpctypes.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error)
+
+func makeBroadcastTxBatchFunc(c *lrpc.Client) rpcBroadcastTxBatchFunc {
+	return func(ctx *rpctypes.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+		return c.BroadcastTxBatch(ctx.Context(), txs)
+	}
+}
+
 type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 
 func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
@@ -284,3 +359,11 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcEvidenceFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultEvidence, error)
+
+func makeEvidenceFunc(c *lrpc.Client) rpcEvidenceFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+		return c.Evidence(ctx.Context(), hash)
+	}
+}
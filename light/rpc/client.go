@@ -161,25 +161,23 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 		return nil, err
 	}
 
+	// Build a Merkle key path from path and resp.Key.
+	if c.keyPathFn == nil {
+		return nil, errors.New("please configure Client with KeyPathFn option")
+	}
+	kp, err := c.keyPathFn(path, resp.Key)
+	if err != nil {
+		return nil, fmt.Errorf("can't build merkle key path: %w", err)
+	}
+
 	// Validate the value proof against the trusted header.
 	if resp.Value != nil {
-		// 1) build a Merkle key path from path and resp.Key
-		if c.keyPathFn == nil {
-			return nil, errors.New("please configure Client with KeyPathFn option")
-		}
-
-		kp, err := c.keyPathFn(path, resp.Key)
-		if err != nil {
-			return nil, fmt.Errorf("can't build merkle key path: %w", err)
-		}
-
-		// 2) verify value
 		err = c.prt.VerifyValue(resp.ProofOps, l.AppHash, kp.String(), resp.Value)
 		if err != nil {
 			return nil, fmt.Errorf("verify value proof: %w", err)
 		}
 	} else { // OR validate the absence proof against the trusted header.
-		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, string(resp.Key))
+		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, kp.String())
 		if err != nil {
 			return nil, fmt.Errorf("verify absence proof: %w", err)
 		}
@@ -200,6 +198,10 @@ func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	return c.next.BroadcastTxSync(ctx, tx)
 }
 
+func (c *Client) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	return c.next.BroadcastTxBatch(ctx, txs)
+}
+
 func (c *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.UnconfirmedTxs(ctx, limit)
 }
@@ -220,6 +222,10 @@ func (c *Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpCons
 	return c.next.DumpConsensusState(ctx)
 }
 
+func (c *Client) DumpConsensusStateV2(ctx context.Context) (*ctypes.ResultDumpConsensusStateV2, error) {
+	return c.next.DumpConsensusStateV2(ctx)
+}
+
 func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	return c.next.ConsensusState(ctx)
 }
@@ -245,7 +251,8 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Re
 	}
 
 	// Verify hash.
-	if cH, tH := res.ConsensusParams.HashConsensusParams(), l.ConsensusHash; !bytes.Equal(cH, tH) {
+	cH := res.ConsensusParams.HashConsensusParamsWithSchedule(res.ScheduledConsensusParams)
+	if tH := l.ConsensusHash; !bytes.Equal(cH, tH) {
 		return nil, fmt.Errorf("params hash %X does not match trusted hash %X",
 			cH, tH)
 	}
@@ -264,36 +271,84 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.verifyBlockMetas(ctx, res.BlockMetas); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-	// Validate res.
-	for i, meta := range res.BlockMetas {
+// BlockchainInfoWithOptions calls rpcclient#BlockchainInfoWithOptions and
+// then verifies every header returned. It doesn't verify that the headers
+// match the filters, nor that none was left out.
+func (c *Client) BlockchainInfoWithOptions(ctx context.Context, minHeight, maxHeight int64,
+	opts rpcclient.BlockchainInfoOptions) (*ctypes.ResultBlockchainInfo, error) {
+	res, err := c.next.BlockchainInfoWithOptions(ctx, minHeight, maxHeight, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyBlockMetas(ctx, res.BlockMetas); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// verifyBlockMetas verifies the headers of the metas against the ones of the
+// light client, verifying each height the light client doesn't trust yet:
+// filtered metas may be far apart.
+func (c *Client) verifyBlockMetas(ctx context.Context, metas []*types.BlockMeta) error {
+	// Validate the metas.
+	for i, meta := range metas {
 		if meta == nil {
-			return nil, fmt.Errorf("nil block meta %d", i)
+			return fmt.Errorf("nil block meta %d", i)
 		}
 		if err := meta.ValidateBasic(); err != nil {
-			return nil, fmt.Errorf("invalid block meta %d: %w", i, err)
-		}
-	}
-
-	// Update the light client if we're behind.
-	if len(res.BlockMetas) > 0 {
-		lastHeight := res.BlockMetas[len(res.BlockMetas)-1].Header.Height
-		if _, err := c.updateLightClientIfNeededTo(ctx, &lastHeight); err != nil {
-			return nil, err
+			return fmt.Errorf("invalid block meta %d: %w", i, err)
 		}
 	}
 
 	// Verify each of the BlockMetas.
-	for _, meta := range res.BlockMetas {
-		h, err := c.lc.TrustedLightBlock(meta.Header.Height)
+	for _, meta := range metas {
+		height := meta.Header.Height
+		h, err := c.updateLightClientIfNeededTo(ctx, &height)
 		if err != nil {
-			return nil, fmt.Errorf("trusted header %d: %w", meta.Header.Height, err)
+			return fmt.Errorf("trusted header %d: %w", height, err)
 		}
 		if bmH, tH := meta.Header.Hash(), h.Hash(); !bytes.Equal(bmH, tH) {
-			return nil, fmt.Errorf("block meta header %X does not match with trusted header %X",
+			return fmt.Errorf("block meta header %X does not match with trusted header %X",
 				bmH, tH)
 		}
 	}
+	return nil
+}
+
+// LightBlocks calls rpcclient#LightBlocks and then verifies every light block
+// returned.
+func (c *Client) LightBlocks(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error) {
+	res, err := c.next.LightBlocks(ctx, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res.LightBlocks {
+		lb, err := res.LightBlocks[i].DecodeLightBlock()
+		if err != nil {
+			return nil, fmt.Errorf("invalid light block %d: %w", i, err)
+		}
+
+		// Update the light client if we're behind and verify the light block.
+		l, err := c.updateLightClientIfNeededTo(ctx, &lb.Height)
+		if err != nil {
+			return nil, err
+		}
+		if lbH, tH := lb.Hash(), l.Hash(); !bytes.Equal(lbH, tH) {
+			return nil, fmt.Errorf("light block header %X does not match with trusted header %X",
+				lbH, tH)
+		}
+		if lbH, tH := lb.ValidatorSet.Hash(), l.ValidatorSet.Hash(); !bytes.Equal(lbH, tH) {
+			return nil, fmt.Errorf("light block validator set %X does not match with trusted validator set %X",
+				lbH, tH)
+		}
+	}
 
 	return res, nil
 }
@@ -452,6 +507,17 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommi
 	}, nil
 }
 
+// LightBlock returns the light block verified by the light client at the
+// given height or the latest height if no height is provided.
+func (c *Client) LightBlock(ctx context.Context, height *int64) (*ctypes.ResultLightBlock, error) {
+	l, err := c.updateLightClientIfNeededTo(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctypes.NewResultLightBlock(l)
+}
+
 // Tx calls rpcclient#Tx method and then verifies the proof if such was
 // requested.
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
@@ -475,6 +541,35 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.Resul
 	return res, res.Proof.Validate(l.DataHash)
 }
 
+// TxProof calls rpcclient#TxProof and then verifies the proofs against the
+// headers of the block of the tx and of the next block, which holds the hash
+// of the results.
+func (c *Client) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	res, err := c.next.TxProof(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, ctypes.ErrZeroOrNegativeHeight
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+	nextHeight := res.Height + 1
+	next, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proofs.
+	return res, res.Verify(l.DataHash, next.LastResultsHash)
+}
+
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -525,10 +620,37 @@ func (c *Client) Validators(
 		Total:       totalCount}, nil
 }
 
+// ValidatorChanges compares the validator sets of the light blocks verified at
+// the heights from and to, rather than trusting the changes computed by the
+// primary.
+func (c *Client) ValidatorChanges(ctx context.Context, from int64, to *int64) (*ctypes.ResultValidatorChanges, error) {
+	lTo, err := c.updateLightClientIfNeededTo(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if from <= 0 {
+		return nil, ctypes.ErrZeroOrNegativeHeight
+	}
+	if from > lTo.Height {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", from, lTo.Height)
+	}
+	lFrom, err := c.updateLightClientIfNeededTo(ctx, &from)
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.NewResultValidatorChanges(lFrom.Height, lTo.Height, lFrom.ValidatorSet, lTo.ValidatorSet), nil
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+// Evidence calls rpcclient#Evidence. The status of the evidence is not
+// verified.
+func (c *Client) Evidence(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return c.next.Evidence(ctx, hash)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
@@ -67,7 +67,7 @@ func TestABCIQuery(t *testing.T) {
 	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(2), mock.AnythingOfType("time.Time")).Return(
 		&types.LightBlock{
 			SignedHeader: &types.SignedHeader{
-				Header: &types.Header{AppHash: appHash},
+				Header: &types.Header{AppHash: bytes.HexBytes(appHash)},
 			},
 		},
 		nil,
@@ -86,6 +86,76 @@ func TestABCIQuery(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+// TestABCIQueryAbsence tests that ABCIQuery verifies ICS-23 proofs of absence.
+func TestABCIQueryAbsence(t *testing.T) {
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100)
+	require.NoError(t, err)
+
+	tree.Set([]byte("foo"), []byte("bar"))
+	tree.Set([]byte("qux"), []byte("quux"))
+
+	key := []byte("baz")
+	commitmentProof, err := tree.GetNonMembershipProof(key)
+	require.NoError(t, err)
+	appHash, err := commitmentProof.Calculate()
+	require.NoError(t, err)
+
+	op, err := merkle.NewICS23Op(merkle.ProofOpICS23IAVL, key, commitmentProof)
+	require.NoError(t, err)
+
+	next := &rpcmock.Client{}
+	next.On(
+		"ABCIQueryWithOptions",
+		context.Background(),
+		mock.AnythingOfType("string"),
+		bytes.HexBytes(key),
+		mock.AnythingOfType("client.ABCIQueryOptions"),
+	).Return(&ctypes.ResultABCIQuery{
+		Response: abci.ResponseQuery{
+			Code:   0,
+			Key:    key,
+			Height: 1,
+			ProofOps: &tmcrypto.ProofOps{
+				Ops: []tmcrypto.ProofOp{op.ProofOp()},
+			},
+		},
+	}, nil)
+
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(2), mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{AppHash: bytes.HexBytes(appHash)},
+			},
+		},
+		nil,
+	)
+
+	c := NewClient(next, lc,
+		KeyPathFn(func(_ string, key []byte) (merkle.KeyPath, error) {
+			kp := merkle.KeyPath{}
+			kp = kp.AppendKey(key, merkle.KeyEncodingURL)
+			return kp, nil
+		}))
+	c.RegisterOpDecoder(merkle.ProofOpICS23IAVL, merkle.ICS23OpDecoder)
+	res, err := c.ABCIQuery(context.Background(), "/store/accounts/key", key)
+	require.NoError(t, err)
+	assert.Nil(t, res.Response.Value)
+
+	// A proof of absence must not verify against another app hash.
+	lc.ExpectedCalls = nil
+	lc.On("VerifyLightBlockAtHeight", context.Background(), int64(2), mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{AppHash: []byte("other")},
+			},
+		},
+		nil,
+	)
+	_, err = c.ABCIQuery(context.Background(), "/store/accounts/key", key)
+	require.Error(t, err)
+}
+
 type testOp struct {
 	Spec  *ics23.ProofSpec
 	Key   []byte
@@ -0,0 +1,171 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockTx is a tx in the mempool, as seen by a BlockBuilder.
+type BlockTx struct {
+	Tx        types.Tx
+	GasWanted int64  // gas wanted by the tx, as reported by the app in CheckTx
+	Priority  int64  // priority of the tx, as reported by the app in CheckTx
+	Sender    string // sender of the tx as reported by the app in CheckTx, if any
+}
+
+// TxIterator iterates over the txs in the mempool, in the order they were
+// added.
+type TxIterator interface {
+	// Next returns the next tx, or false if there are no more txs.
+	Next() (BlockTx, bool)
+}
+
+// BlockBuilder selects and orders the txs reaped from the mempool for a
+// proposal block. Building must be deterministic: the same txs, in the same
+// order, must result in the same block.
+//
+// Either max may be negative, in which case there is no cap on the total size
+// or gas wanted of the returned txs.
+type BlockBuilder interface {
+	BuildBlock(txs TxIterator, maxBytes, maxGas int64) types.Txs
+}
+
+// FIFOBlockBuilder includes txs in the order they were added to the mempool,
+// until the first tx which doesn't fit in the block. It's the default.
+type FIFOBlockBuilder struct{}
+
+var _ BlockBuilder = FIFOBlockBuilder{}
+
+// BuildBlock implements BlockBuilder.
+func (FIFOBlockBuilder) BuildBlock(txs TxIterator, maxBytes, maxGas int64) types.Txs {
+	var (
+		limits = newBlockLimits(maxBytes, maxGas)
+		block  = types.Txs{}
+	)
+	for tx, ok := txs.Next(); ok; tx, ok = txs.Next() {
+		if !limits.add(tx) {
+			break
+		}
+		block = append(block, tx.Tx)
+	}
+	return block
+}
+
+// PriorityBlockBuilder includes txs in the order of decreasing priority, and
+// txs of the same priority in the order they were added to the mempool. Txs
+// which don't fit in the block are skipped, so that smaller txs of a lower
+// priority can fill the remaining space.
+type PriorityBlockBuilder struct{}
+
+var _ BlockBuilder = PriorityBlockBuilder{}
+
+// BuildBlock implements BlockBuilder.
+func (PriorityBlockBuilder) BuildBlock(txs TxIterator, maxBytes, maxGas int64) types.Txs {
+	all := collectTxs(txs)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Priority > all[j].Priority })
+
+	var (
+		limits = newBlockLimits(maxBytes, maxGas)
+		block  = types.Txs{}
+	)
+	for _, tx := range all {
+		if limits.full() {
+			break
+		}
+		if limits.add(tx) {
+			block = append(block, tx.Tx)
+		}
+	}
+	return block
+}
+
+// SenderFairBlockBuilder includes txs round-robin by sender, one tx of each
+// sender per round, so that a single sender can't fill the block. Senders are
+// visited in the order their first tx was added to the mempool, and the txs of
+// each sender are included in the order they were added. Once a tx of a
+// sender doesn't fit in the block, the remaining txs of that sender are
+// skipped, so that txs of a sender are never reordered.
+//
+// Txs without a sender are each treated as a sender of their own.
+type SenderFairBlockBuilder struct{}
+
+var _ BlockBuilder = SenderFairBlockBuilder{}
+
+// BuildBlock implements BlockBuilder.
+func (SenderFairBlockBuilder) BuildBlock(txs TxIterator, maxBytes, maxGas int64) types.Txs {
+	var (
+		queues  = [][]BlockTx{}
+		senders = map[string]int{} // sender -> index in queues
+	)
+	for tx, ok := txs.Next(); ok; tx, ok = txs.Next() {
+		if i, ok := senders[tx.Sender]; ok && tx.Sender != "" {
+			queues[i] = append(queues[i], tx)
+			continue
+		}
+		senders[tx.Sender] = len(queues)
+		queues = append(queues, []BlockTx{tx})
+	}
+
+	var (
+		limits = newBlockLimits(maxBytes, maxGas)
+		block  = types.Txs{}
+	)
+	for len(queues) > 0 && !limits.full() {
+		remaining := queues[:0]
+		for _, queue := range queues {
+			if !limits.add(queue[0]) {
+				continue
+			}
+			block = append(block, queue[0].Tx)
+			if len(queue) > 1 {
+				remaining = append(remaining, queue[1:])
+			}
+		}
+		queues = remaining
+	}
+	return block
+}
+
+// collectTxs returns all txs of the iterator.
+func collectTxs(txs TxIterator) []BlockTx {
+	all := []BlockTx{}
+	for tx, ok := txs.Next(); ok; tx, ok = txs.Next() {
+		all = append(all, tx)
+	}
+	return all
+}
+
+// blockLimits tracks the total size and gas wanted of the txs in a block.
+type blockLimits struct {
+	maxBytes int64
+	maxGas   int64
+	bytes    int64
+	gas      int64
+}
+
+func newBlockLimits(maxBytes, maxGas int64) *blockLimits {
+	return &blockLimits{maxBytes: maxBytes, maxGas: maxGas}
+}
+
+// add adds the tx to the totals if it fits in the block, and returns whether
+// it did.
+func (l *blockLimits) add(tx BlockTx) bool {
+	dataSize := types.ComputeProtoSizeForTxs([]types.Tx{tx.Tx})
+	if l.maxBytes > -1 && l.bytes+dataSize > l.maxBytes {
+		return false
+	}
+	// Since gas < maxGas, which must be non-negative, this won't overflow.
+	if l.maxGas > -1 && l.gas+tx.GasWanted > l.maxGas {
+		return false
+	}
+	l.bytes += dataSize
+	l.gas += tx.GasWanted
+	return true
+}
+
+// full returns whether no more txs fit in the block. Txs which want no gas
+// always fit the gas limit, so only the size limit can fill the block.
+func (l *blockLimits) full() bool {
+	return l.maxBytes > -1 && l.bytes >= l.maxBytes
+}
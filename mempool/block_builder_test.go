@@ -0,0 +1,58 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// sliceTxIterator iterates over a slice of txs.
+type sliceTxIterator []BlockTx
+
+func (it *sliceTxIterator) Next() (BlockTx, bool) {
+	if len(*it) == 0 {
+		return BlockTx{}, false
+	}
+	tx := (*it)[0]
+	*it = (*it)[1:]
+	return tx, true
+}
+
+func TestBlockBuilders(t *testing.T) {
+	// Each tx is 3 bytes in a block, and wants 1 gas unless specified.
+	txs := []BlockTx{
+		{Tx: types.Tx{1}, GasWanted: 1, Priority: 1, Sender: "a"},
+		{Tx: types.Tx{2}, GasWanted: 1, Priority: 3, Sender: "a"},
+		{Tx: types.Tx{3}, GasWanted: 5, Priority: 5, Sender: "a"},
+		{Tx: types.Tx{4}, GasWanted: 1, Priority: 3, Sender: "b"},
+		{Tx: types.Tx{5}, GasWanted: 1, Priority: 2},
+		{Tx: types.Tx{6}, GasWanted: 1, Priority: 2},
+	}
+
+	testcases := map[string]struct {
+		builder  BlockBuilder
+		maxBytes int64
+		maxGas   int64
+		expect   types.Txs
+	}{
+		"fifo no limits":        {FIFOBlockBuilder{}, -1, -1, types.Txs{{1}, {2}, {3}, {4}, {5}, {6}}},
+		"fifo max bytes":        {FIFOBlockBuilder{}, 8, -1, types.Txs{{1}, {2}}},
+		"fifo max gas":          {FIFOBlockBuilder{}, -1, 4, types.Txs{{1}, {2}}},
+		"fifo zero":             {FIFOBlockBuilder{}, 0, 0, types.Txs{}},
+		"priority no limits":    {PriorityBlockBuilder{}, -1, -1, types.Txs{{3}, {2}, {4}, {5}, {6}, {1}}},
+		"priority max bytes":    {PriorityBlockBuilder{}, 9, -1, types.Txs{{3}, {2}, {4}}},
+		"priority max gas":      {PriorityBlockBuilder{}, -1, 4, types.Txs{{2}, {4}, {5}, {6}}},
+		"sender fair no limits": {SenderFairBlockBuilder{}, -1, -1, types.Txs{{1}, {4}, {5}, {6}, {2}, {3}}},
+		"sender fair max bytes": {SenderFairBlockBuilder{}, 12, -1, types.Txs{{1}, {4}, {5}, {6}}},
+		"sender fair max gas":   {SenderFairBlockBuilder{}, -1, 6, types.Txs{{1}, {4}, {5}, {6}, {2}}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			it := sliceTxIterator(append([]BlockTx{}, txs...))
+			require.Equal(t, tc.expect, tc.builder.BuildBlock(&it, tc.maxBytes, tc.maxGas))
+		})
+	}
+}
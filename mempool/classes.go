@@ -0,0 +1,125 @@
+package mempool
+
+import (
+	"sync/atomic"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// otherTxClass is the name, for errors and metrics, of the txs whose class
+// isn't configured, including the txs without a class.
+const otherTxClass = "other"
+
+// txClasses partitions the txs in the mempool by the class the app tagged
+// them with in CheckTx. Each configured class has a fraction of the mempool
+// reserved to it, which it may not exceed, and the txs of the other classes
+// share the rest, so a flood of txs of one class can't crowd out another.
+//
+// Safe for concurrent use by multiple goroutines.
+type txClasses struct {
+	indexes          map[string]int // the other txs have index len(indexes)
+	names            []string
+	maxTxs           []int
+	maxBytes         []int64
+	gossipPriorities []int64
+
+	// Atomic integers
+	txs      []int64
+	txsBytes []int64
+}
+
+// newTxClasses returns the classes configured in config, or nil if there are
+// none.
+func newTxClasses(config *cfg.MempoolConfig) *txClasses {
+	if len(config.TxClasses) == 0 {
+		return nil
+	}
+
+	n := len(config.TxClasses) + 1
+	c := &txClasses{
+		indexes:          make(map[string]int, n-1),
+		names:            append(append([]string{}, config.TxClasses...), otherTxClass),
+		maxTxs:           make([]int, n),
+		maxBytes:         make([]int64, n),
+		gossipPriorities: make([]int64, n),
+		txs:              make([]int64, n),
+		txsBytes:         make([]int64, n),
+	}
+	reserved := 0.0
+	for i, name := range config.TxClasses {
+		c.indexes[name] = i
+		c.setCapacity(i, config.TxClassCapacities[i], config)
+		reserved += config.TxClassCapacities[i]
+		if len(config.TxClassGossipPriorities) == n-1 {
+			c.gossipPriorities[i] = config.TxClassGossipPriorities[i]
+		}
+	}
+	c.setCapacity(n-1, 1-reserved, config)
+	return c
+}
+
+func (c *txClasses) setCapacity(class int, capacity float64, config *cfg.MempoolConfig) {
+	c.maxTxs[class] = int(capacity * float64(config.Size))
+	c.maxBytes[class] = int64(capacity * float64(config.MaxTxsBytes))
+}
+
+// class returns the index of the class the app tagged a tx with.
+func (c *txClasses) class(name string) int {
+	if i, ok := c.indexes[name]; ok {
+		return i
+	}
+	return len(c.indexes)
+}
+
+// isFull returns an error if a tx of the given size doesn't fit in the
+// capacity of the class.
+func (c *txClasses) isFull(class int, txSize int) error {
+	var (
+		numTxs   = int(atomic.LoadInt64(&c.txs[class]))
+		txsBytes = atomic.LoadInt64(&c.txsBytes[class])
+	)
+
+	if numTxs >= c.maxTxs[class] || int64(txSize)+txsBytes > c.maxBytes[class] {
+		return ErrMempoolTxClassIsFull{
+			c.names[class],
+			numTxs, c.maxTxs[class],
+			txsBytes, c.maxBytes[class],
+		}
+	}
+	return nil
+}
+
+// add accounts for a tx of the given size added to the class.
+func (c *txClasses) add(class int, txSize int) {
+	atomic.AddInt64(&c.txs[class], 1)
+	atomic.AddInt64(&c.txsBytes[class], int64(txSize))
+}
+
+// remove accounts for a tx of the given size removed from the class.
+func (c *txClasses) remove(class int, txSize int) {
+	atomic.AddInt64(&c.txs[class], -1)
+	atomic.AddInt64(&c.txsBytes[class], -int64(txSize))
+}
+
+// reset empties all classes.
+func (c *txClasses) reset() {
+	for i := range c.txs {
+		atomic.StoreInt64(&c.txs[i], 0)
+		atomic.StoreInt64(&c.txsBytes[i], 0)
+	}
+}
+
+// size returns the number of txs in the class.
+func (c *txClasses) size(class int) int {
+	return int(atomic.LoadInt64(&c.txs[class]))
+}
+
+// name returns the name of the class for errors and metrics.
+func (c *txClasses) name(class int) string {
+	return c.names[class]
+}
+
+// gossipPriority returns the gossip priority of the class.
+func (c *txClasses) gossipPriority(class int) int64 {
+	return c.gossipPriorities[class]
+}
@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestTxClasses(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	require.Nil(t, newTxClasses(config))
+
+	config.Size = 100
+	config.MaxTxsBytes = 1000
+	config.TxClasses = []string{"oracle", "ibc"}
+	config.TxClassCapacities = []float64{0.1, 0.3}
+	config.TxClassGossipPriorities = []int64{2, 1}
+	classes := newTxClasses(config)
+	require.NotNil(t, classes)
+
+	testcases := []struct {
+		name           string
+		class          int
+		maxTxs         int
+		maxBytes       int64
+		gossipPriority int64
+	}{
+		{"oracle", 0, 10, 100, 2},
+		{"ibc", 1, 30, 300, 1},
+		{"transfer", 2, 60, 600, 0},
+		{"", 2, 60, 600, 0},
+	}
+	for _, tc := range testcases {
+		class := classes.class(tc.name)
+		assert.Equal(t, tc.class, class, tc.name)
+		assert.Equal(t, tc.maxTxs, classes.maxTxs[class], tc.name)
+		assert.Equal(t, tc.maxBytes, classes.maxBytes[class], tc.name)
+		assert.Equal(t, tc.gossipPriority, classes.gossipPriority(class), tc.name)
+	}
+	assert.Equal(t, otherTxClass, classes.name(classes.class("")))
+
+	// the byte budget of a class is enforced
+	classes.add(0, 90)
+	assert.NoError(t, classes.isFull(0, 10))
+	assert.IsType(t, ErrMempoolTxClassIsFull{}, classes.isFull(0, 11))
+	assert.NoError(t, classes.isFull(1, 11))
+
+	classes.remove(0, 90)
+	assert.Equal(t, 0, classes.size(0))
+	classes.add(2, 10)
+	classes.reset()
+	assert.Equal(t, 0, classes.size(2))
+}
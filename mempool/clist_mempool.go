@@ -1,13 +1,15 @@
 package mempool
 
 import (
-	"bytes"
 	"container/list"
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
@@ -32,8 +34,10 @@ const TxKeySize = sha256.Size
 // be efficiently accessed by multiple concurrent readers.
 type CListMempool struct {
 	// Atomic integers
-	height   int64 // the last block Update()'d to
-	txsBytes int64 // total size of mempool, in bytes
+	height        int64  // the last block Update()'d to
+	txsBytes      int64  // total size of mempool, in bytes
+	minTxPriority int64  // txs with a lower CheckTx priority are rejected
+	lastTxSeq     uint64 // sequence number of the last tx added to the mempool
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -43,30 +47,63 @@ type CListMempool struct {
 
 	// Exclusive mutex for Update method to prevent concurrent execution of
 	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
-	updateMtx tmsync.RWMutex
-	preCheck  PreCheckFunc
-	postCheck PostCheckFunc
+	updateMtx   tmsync.RWMutex
+	sanityCheck PreCheckFunc
+	preCheck    PreCheckFunc
+	postCheck   PostCheckFunc
 
 	txs          *clist.CList // concurrent linked-list of good txs
 	proxyAppConn proxy.AppConnMempool
 
-	// Track whether we're rechecking txs.
-	// These are not protected by a mutex and are expected to be mutated in
-	// serial (ie. by abci responses which are called in serial).
-	recheckCursor *clist.CElement // next expected response
-	recheckEnd    *clist.CElement // re-checking stops here
+	// Txs being rechecked by sequence number. The callback of each recheck
+	// request carries the sequence number of its tx, so responses are matched
+	// to the txs whatever order they arrive in, and a tx removed and added
+	// again during the recheck is not confused with the tx that was rechecked.
+	recheckMtx     tmsync.Mutex
+	pendingRecheck map[uint64]recheckTx
 
 	// Map for quick access to txs to record sender in CheckTx.
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Index of txs by the sender reported by the app in CheckTx, in the order
+	// they were added.
+	// txsBySender: sender -> []CElement
+	senderMtx   tmsync.Mutex
+	txsBySender map[string][]*clist.CElement
+
+	// Callers of CheckTx waiting for the in-flight CheckTx of the same tx,
+	// whose response they share instead of checking the tx again.
+	// inFlight: txKey -> []checkTxWaiter
+	inFlightMtx tmsync.Mutex
+	inFlight    map[[TxKeySize]byte][]checkTxWaiter
+
+	// Serializes the admission of checked txs, i.e. the capacity checks and
+	// addTx of resCbFirstTime, whose callbacks run concurrently for apps
+	// checking txs concurrently.
+	admitMtx tmsync.Mutex
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache txCache
 
+	// Capacity budgets of the txs by size or gas class, nil if the mempool
+	// isn't sharded.
+	shards *txShards
+
+	// Capacity budgets and gossip priorities of the txs by the class the app
+	// tagged them with, nil if no classes are configured.
+	classes *txClasses
+
+	// Selects and orders the txs reaped by ReapMaxBytesMaxGas.
+	blockBuilder BlockBuilder
+
 	logger log.Logger
 
 	metrics *Metrics
+
+	// Publishes the txs added to and removed from the mempool.
+	eventBus types.MempoolEventPublisher
 }
 
 var _ Mempool = &CListMempool{}
@@ -85,18 +122,22 @@ func NewCListMempool(
 		config:        config,
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
+		txsBySender:   make(map[string][]*clist.CElement),
+		inFlight:      make(map[[TxKeySize]byte][]checkTxWaiter),
+		shards:        newTxShards(config),
+		classes:       newTxClasses(config),
+		blockBuilder:  FIFOBlockBuilder{},
 		height:        height,
-		recheckCursor: nil,
-		recheckEnd:    nil,
+		minTxPriority: config.MinTxPriority,
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
+		eventBus:      types.NopEventBus{},
 	}
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
 	} else {
 		mempool.cache = nopTxCache{}
 	}
-	proxyAppConn.SetResponseCallback(mempool.globalCb)
 	for _, option := range options {
 		option(mempool)
 	}
@@ -113,6 +154,24 @@ func (mem *CListMempool) SetLogger(l log.Logger) {
 	mem.logger = l
 }
 
+// SetEventBus sets the event bus the mempool events are published on.
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *CListMempool) SetEventBus(eventBus types.MempoolEventPublisher) {
+	mem.eventBus = eventBus
+}
+
+// publishTxEvent publishes a mempool event for the tx, with the reason the tx
+// was removed, if any.
+func (mem *CListMempool) publishTxEvent(tx types.Tx, status, reason string) {
+	if err := mem.eventBus.PublishEventMempoolTx(types.EventDataMempoolTx{
+		Tx:     tx,
+		Status: status,
+		Reason: reason,
+	}); err != nil {
+		mem.logger.Error("failed publishing mempool tx event", "tx", txID(tx), "status", status, "err", err)
+	}
+}
+
 // WithPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran before CheckTx. Only applies to the first created block.
 // After that, Update overwrites the existing value.
@@ -120,6 +179,14 @@ func WithPreCheck(f PreCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.preCheck = f }
 }
 
+// WithTxSanityCheck sets a cheap, stateless check of the structure of a tx,
+// e.g. the app's decoding of it, which rejects the tx if f(tx) returns an
+// error. It is ran before the pre-check and CheckTx, and unlike them, Update
+// doesn't replace it. A panic in f rejects the tx.
+func WithTxSanityCheck(f PreCheckFunc) CListMempoolOption {
+	return func(mem *CListMempool) { mem.sanityCheck = f }
+}
+
 // WithPostCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran after CheckTx. Only applies to the first created block.
 // After that, Update overwrites the existing value.
@@ -132,6 +199,22 @@ func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithBlockBuilder sets the builder which selects and orders the txs reaped
+// for a block. Defaults to FIFOBlockBuilder.
+func WithBlockBuilder(builder BlockBuilder) CListMempoolOption {
+	return func(mem *CListMempool) { mem.blockBuilder = builder }
+}
+
+// SetBlockBuilder sets the builder which selects and orders the txs reaped for
+// a block.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SetBlockBuilder(builder BlockBuilder) {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+	mem.blockBuilder = builder
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -168,12 +251,24 @@ func (mem *CListMempool) Flush() {
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.txs.Remove(e)
 		e.DetachPrev()
+		mem.publishTxEvent(e.Value.(*mempoolTx).tx, types.MempoolTxEvicted, "mempool flushed")
 	}
 
 	mem.txsMap.Range(func(key, _ interface{}) bool {
 		mem.txsMap.Delete(key)
 		return true
 	})
+
+	mem.senderMtx.Lock()
+	mem.txsBySender = make(map[string][]*clist.CElement)
+	mem.senderMtx.Unlock()
+
+	if mem.shards != nil {
+		mem.shards.reset()
+	}
+	if mem.classes != nil {
+		mem.classes.reset()
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -185,6 +280,47 @@ func (mem *CListMempool) TxsFront() *clist.CElement {
 	return mem.txs.Front()
 }
 
+// txElement returns the element of the tx with the given key, or nil if the tx
+// is not in the mempool.
+func (mem *CListMempool) txElement(key [TxKeySize]byte) *clist.CElement {
+	if e, ok := mem.txsMap.Load(key); ok {
+		return e.(*clist.CElement)
+	}
+	return nil
+}
+
+// gossipPriorityTxs returns the txs of the classes with a gossip priority
+// above 0, highest priority first, and in the order they were added within a
+// priority.
+func (mem *CListMempool) gossipPriorityTxs() []*mempoolTx {
+	if mem.classes == nil {
+		return nil
+	}
+
+	var memTxs []*mempoolTx
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if mem.classes.gossipPriority(memTx.class) > 0 {
+			memTxs = append(memTxs, memTx)
+		}
+	}
+	sort.SliceStable(memTxs, func(i, j int) bool {
+		return mem.classes.gossipPriority(memTxs[i].class) > mem.classes.gossipPriority(memTxs[j].class)
+	})
+	return memTxs
+}
+
+// TxFirstSeen implements Mempool.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxFirstSeen(tx types.Tx) (time.Time, bool) {
+	e := mem.txElement(TxKey(tx))
+	if e == nil {
+		return time.Time{}, false
+	}
+	return e.Value.(*mempoolTx).firstSeen, true
+}
+
 // TxsWaitChan returns a channel to wait on transactions. It will be closed
 // once the mempool is not empty (ie. the internal `mem.txs` has at least one
 // element)
@@ -198,6 +334,8 @@ func (mem *CListMempool) TxsWaitChan() <-chan struct{} {
 // cb: A callback from the CheckTx command.
 //     It gets called from another goroutine.
 // CONTRACT: Either cb will get called, or err returned.
+// The calls made with the same tx while the app checks it don't check it
+// again: their cb gets the same response.
 //
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo TxInfo) error {
@@ -211,10 +349,35 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		return err
 	}
 
+	if mark := mem.config.HardHighWaterMark; mark > 0 {
+		if utilization := mem.utilization(); utilization >= mark {
+			return ErrMempoolIsCongested{utilization, mark}
+		}
+	}
+
+	// the gas wanted is only known once the app checked the tx
+	if mem.shards != nil && !mem.shards.byGas {
+		if err := mem.shards.isFull(mem.shards.shard(txSize, 0), txSize); err != nil {
+			return err
+		}
+	}
+
 	if txSize > mem.config.MaxTxBytes {
 		return ErrTxTooLarge{mem.config.MaxTxBytes, txSize}
 	}
 
+	if err := checkTxEncoding(tx, mem.config.MaxTxDepth, mem.config.MaxTxRepeatedFields); err != nil {
+		mem.metrics.MalformedTxs.Add(1)
+		return ErrTxMalformed{err}
+	}
+
+	if mem.sanityCheck != nil {
+		if err := mem.runSanityCheck(tx); err != nil {
+			mem.metrics.MalformedTxs.Add(1)
+			return ErrTxMalformed{err}
+		}
+	}
+
 	if mem.preCheck != nil {
 		if err := mem.preCheck(tx); err != nil {
 			return ErrPreCheck{err}
@@ -226,7 +389,20 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		return err
 	}
 
+	// Coalesce the concurrent submissions of the same tx into one request to
+	// the app.
+	txKey := TxKey(tx)
+	mem.inFlightMtx.Lock()
+	if waiters, ok := mem.inFlight[txKey]; ok {
+		mem.inFlight[txKey] = append(waiters, checkTxWaiter{cb: cb, senderID: txInfo.SenderID})
+		mem.inFlightMtx.Unlock()
+		mem.metrics.CoalescedCheckTxs.Add(1)
+		mem.logger.Debug("tx is being checked already, sharing the response", "tx_hash", tx.Hash())
+		return nil
+	}
 	if !mem.cache.Push(tx) {
+		mem.inFlightMtx.Unlock()
+
 		// Record a new sender for a tx we've already seen.
 		// Note it's possible a tx is still in the cache but no longer in the mempool
 		// (eg. after committing a block, txs are removed from mempool but not cache),
@@ -245,6 +421,8 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		mem.logger.Debug("tx exists already in cache", "tx_hash", tx.Hash())
 		return nil
 	}
+	mem.inFlight[txKey] = nil
+	mem.inFlightMtx.Unlock()
 
 	ctx := context.Background()
 	if txInfo.Context != nil {
@@ -254,6 +432,11 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 	reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{Tx: tx})
 	if err != nil {
 		mem.cache.Remove(tx)
+		mem.finishCheckTx(tx, abci.ToResponseCheckTx(abci.ResponseCheckTx{
+			Code:      CodeTypeCheckTxFailed,
+			Codespace: CodespaceMempool,
+			Log:       err.Error(),
+		}))
 		return err
 	}
 	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
@@ -261,25 +444,42 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 	return nil
 }
 
-// Global callback that will be called after every ABCI response.
-// Having a single global callback avoids needing to set a callback for each request.
-// However, processing the checkTx response requires the peerID (so we can track which txs we heard from who),
-// and peerID is not included in the ABCI request, so we have to set request-specific callbacks that
-// include this information. If we're not in the midst of a recheck, this function will just return,
-// so the request specific callback can do the work.
-//
-// When rechecking, we don't need the peerID, so the recheck callback happens
-// here.
-func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
-	if mem.recheckCursor == nil {
-		return
-	}
+// runSanityCheck runs the sanity check of the tx, turning a panic, e.g. of a
+// decoder fed with garbage, into an error.
+func (mem *CListMempool) runSanityCheck(tx types.Tx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in sanity check: %v", r)
+		}
+	}()
+	return mem.sanityCheck(tx)
+}
 
-	mem.metrics.RecheckTimes.Add(1)
-	mem.resCbRecheck(req, res)
+// recheckCb returns the callback of the recheck request of the tx, which
+// handles the response to the request.
+func (mem *CListMempool) recheckCb(rtx recheckTx) func(res *abci.Response) {
+	return func(res *abci.Response) {
+		mem.recheckMtx.Lock()
+		_, ok := mem.pendingRecheck[rtx.seq]
+		delete(mem.pendingRecheck, rtx.seq)
+		done := len(mem.pendingRecheck) == 0
+		mem.recheckMtx.Unlock()
+
+		if !ok {
+			mem.logger.Error("unexpected tx response from proxy during recheck",
+				"tx", fmt.Sprintf("%X", rtx.key[:]), "seq", rtx.seq)
+			return
+		}
 
-	// update metrics
-	mem.metrics.Size.Set(float64(mem.Size()))
+		mem.metrics.RecheckTimes.Add(1)
+		mem.resCbRecheck(rtx, res)
+		if done {
+			mem.recheckDone()
+		}
+
+		// update metrics
+		mem.metrics.Size.Set(float64(mem.Size()))
+	}
 }
 
 // Request specific callback that should be set on individual reqRes objects
@@ -298,11 +498,6 @@ func (mem *CListMempool) reqResCb(
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
-		if mem.recheckCursor != nil {
-			// this should never happen
-			panic("recheck cursor is not nil in reqResCb")
-		}
-
 		mem.resCbFirstTime(tx, peerID, peerP2PID, res)
 
 		// update metrics
@@ -312,15 +507,64 @@ func (mem *CListMempool) reqResCb(
 		if externalCb != nil {
 			externalCb(res)
 		}
+
+		mem.finishCheckTx(tx, res)
+	}
+}
+
+// checkTxWaiter is a caller of CheckTx waiting for the response to the
+// in-flight CheckTx of the same tx.
+type checkTxWaiter struct {
+	cb       func(*abci.Response)
+	senderID uint16
+}
+
+// finishCheckTx ends the in-flight CheckTx of the tx, passing its response to
+// the callers of CheckTx waiting for it. Their peers are recorded as senders
+// of the tx if it was added to the mempool, like the ones of a tx submitted
+// again once checked.
+func (mem *CListMempool) finishCheckTx(tx types.Tx, res *abci.Response) {
+	txKey := TxKey(tx)
+	mem.inFlightMtx.Lock()
+	waiters := mem.inFlight[txKey]
+	delete(mem.inFlight, txKey)
+	mem.inFlightMtx.Unlock()
+
+	if len(waiters) == 0 {
+		return
+	}
+	e, added := mem.txsMap.Load(txKey)
+	for _, waiter := range waiters {
+		if added {
+			e.(*clist.CElement).Value.(*mempoolTx).senders.LoadOrStore(waiter.senderID, true)
+		}
+		if waiter.cb != nil {
+			waiter.cb(res)
+		}
 	}
 }
 
 // Called from:
 //  - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
+	memTx.seq = atomic.AddUint64(&mem.lastTxSeq, 1)
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(TxKey(memTx.tx), e)
+	if memTx.sender != "" {
+		mem.senderMtx.Lock()
+		mem.txsBySender[memTx.sender] = append(mem.txsBySender[memTx.sender], e)
+		mem.senderMtx.Unlock()
+	}
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	if mem.shards != nil {
+		mem.shards.add(memTx.shard, len(memTx.tx))
+		mem.metrics.ShardSize.With("shard", mem.shards.name(memTx.shard)).Set(float64(mem.shards.size(memTx.shard)))
+	}
+	if mem.classes != nil {
+		mem.classes.add(memTx.class, len(memTx.tx))
+		mem.metrics.TxClassSize.With("class", mem.classes.name(memTx.class)).
+			Set(float64(mem.classes.size(memTx.class)))
+	}
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 }
 
@@ -331,7 +575,33 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(TxKey(tx))
+	if sender := elem.Value.(*mempoolTx).sender; sender != "" {
+		mem.senderMtx.Lock()
+		elems := mem.txsBySender[sender]
+		for i, e := range elems {
+			if e == elem {
+				elems = append(elems[:i:i], elems[i+1:]...)
+				break
+			}
+		}
+		if len(elems) == 0 {
+			delete(mem.txsBySender, sender)
+		} else {
+			mem.txsBySender[sender] = elems
+		}
+		mem.senderMtx.Unlock()
+	}
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	if mem.shards != nil {
+		shard := elem.Value.(*mempoolTx).shard
+		mem.shards.remove(shard, len(tx))
+		mem.metrics.ShardSize.With("shard", mem.shards.name(shard)).Set(float64(mem.shards.size(shard)))
+	}
+	if mem.classes != nil {
+		class := elem.Value.(*mempoolTx).class
+		mem.classes.remove(class, len(tx))
+		mem.metrics.TxClassSize.With("class", mem.classes.name(class)).Set(float64(mem.classes.size(class)))
+	}
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
@@ -344,10 +614,37 @@ func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bo
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
 			mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache)
+			mem.publishTxEvent(memTx.tx, types.MempoolTxEvicted, "removed")
 		}
 	}
 }
 
+// GetTxsBySender returns the txs in the mempool whose sender, as reported by
+// the app in CheckTx, is sender, in the order they were added.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetTxsBySender(sender string) types.Txs {
+	mem.senderMtx.Lock()
+	defer mem.senderMtx.Unlock()
+
+	elems := mem.txsBySender[sender]
+	txs := make(types.Txs, len(elems))
+	for i, e := range elems {
+		txs[i] = e.Value.(*mempoolTx).tx
+	}
+	return txs
+}
+
+// RemoveTxsBySender removes all txs whose sender, as reported by the app in
+// CheckTx, is sender from the mempool and returns how many were removed.
+func (mem *CListMempool) RemoveTxsBySender(sender string, removeFromCache bool) int {
+	txs := mem.GetTxsBySender(sender)
+	for _, tx := range txs {
+		mem.RemoveTxByKey(TxKey(tx), removeFromCache)
+	}
+	return len(txs)
+}
+
 func (mem *CListMempool) isFull(txSize int) error {
 	var (
 		memSize  = mem.Size()
@@ -364,6 +661,55 @@ func (mem *CListMempool) isFull(txSize int) error {
 	return nil
 }
 
+// SetMinTxPriority sets the CheckTx priority below which txs are rejected once
+// the mempool utilization reaches MinTxPriorityUtilization. Txs already in the
+// mempool are not affected.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) SetMinTxPriority(priority int64) {
+	atomic.StoreInt64(&mem.minTxPriority, priority)
+}
+
+// MinTxPriority returns the CheckTx priority below which txs are rejected.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) MinTxPriority() int64 {
+	return atomic.LoadInt64(&mem.minTxPriority)
+}
+
+// checkPriority returns an error if a tx with the given priority must be
+// rejected at the current utilization of the mempool.
+func (mem *CListMempool) checkPriority(priority int64) error {
+	minPriority := mem.MinTxPriority()
+	if priority >= minPriority {
+		return nil
+	}
+
+	if mem.utilization() < mem.config.MinTxPriorityUtilization {
+		return nil
+	}
+
+	return ErrTxPriorityTooLow{priority, minPriority}
+}
+
+// isCongested returns whether a tx with the given priority, submitted over
+// RPC, must be rejected with CodeTypeMempoolCongested at the current
+// utilization of the mempool.
+func (mem *CListMempool) isCongested(priority int64) bool {
+	mark := mem.config.SoftHighWaterMark
+	return mark > 0 && priority < mem.MinTxPriority() && mem.utilization() >= mark
+}
+
+// utilization returns the greater of the fractions of the max number of txs
+// and of the max bytes in use.
+func (mem *CListMempool) utilization() float64 {
+	utilization := float64(mem.TxsBytes()) / float64(mem.config.MaxTxsBytes)
+	if mem.config.Size > 0 {
+		utilization = math.Max(utilization, float64(mem.Size())/float64(mem.config.Size))
+	}
+	return utilization
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -381,8 +727,13 @@ func (mem *CListMempool) resCbFirstTime(
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Check mempool isn't full again to reduce the chance of exceeding the
-			// limits.
+			// the capacity is checked and taken atomically, since other txs
+			// may be admitted concurrently
+			mem.admitMtx.Lock()
+			defer mem.admitMtx.Unlock()
+
+			// Check mempool isn't full again, since it may have filled up
+			// while the app checked the tx.
 			if err := mem.isFull(len(tx)); err != nil {
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
@@ -390,13 +741,93 @@ func (mem *CListMempool) resCbFirstTime(
 				return
 			}
 
+			var expireTime time.Time
+			if r.CheckTx.ExpireTime != nil {
+				expireTime = *r.CheckTx.ExpireTime
+			}
+			if txExpired(r.CheckTx.ExpireHeight, expireTime, mem.height, time.Now()) {
+				// the tx stays in the cache, since it can never be valid again
+				mem.logger.Debug("rejected expired transaction",
+					"tx", txID(tx), "peerID", peerP2PID,
+					"expireHeight", r.CheckTx.ExpireHeight, "expireTime", expireTime)
+				mem.metrics.ExpiredTxs.Add(1)
+				r.CheckTx.Code = CodeTypeTxExpired
+				r.CheckTx.Codespace = CodespaceMempool
+				r.CheckTx.Log = "tx expired"
+				return
+			}
+
+			var shard int
+			if mem.shards != nil {
+				shard = mem.shards.shard(len(tx), r.CheckTx.GasWanted)
+				if err := mem.shards.isFull(shard, len(tx)); err != nil {
+					// remove from cache (the shard might have space later)
+					mem.cache.Remove(tx)
+					mem.logger.Debug("rejected transaction for a full shard",
+						"tx", txID(tx), "peerID", peerP2PID, "err", err)
+					r.CheckTx.Code = CodeTypeShardFull
+					r.CheckTx.Codespace = CodespaceMempool
+					r.CheckTx.Log = err.Error()
+					return
+				}
+			}
+
+			var class int
+			if mem.classes != nil {
+				class = mem.classes.class(r.CheckTx.TxClass)
+				if err := mem.classes.isFull(class, len(tx)); err != nil {
+					// remove from cache (the class might have space later)
+					mem.cache.Remove(tx)
+					mem.logger.Debug("rejected transaction for a full tx class",
+						"tx", txID(tx), "peerID", peerP2PID, "err", err)
+					r.CheckTx.Code = CodeTypeTxClassFull
+					r.CheckTx.Codespace = CodespaceMempool
+					r.CheckTx.Log = err.Error()
+					return
+				}
+			}
+
+			if err := mem.checkPriority(r.CheckTx.Priority); err != nil {
+				// remove from cache (the tx might be accepted later)
+				mem.cache.Remove(tx)
+				mem.logger.Debug("rejected low priority transaction",
+					"tx", txID(tx), "peerID", peerP2PID, "err", err)
+				mem.metrics.RejectedTxs.Add(1)
+				r.CheckTx.Code = CodeTypeTxPriorityTooLow
+				r.CheckTx.Codespace = CodespaceMempool
+				r.CheckTx.Log = err.Error()
+				return
+			}
+
+			// the txs of peers are still accepted and gossiped
+			if peerID == UnknownPeerID && mem.isCongested(r.CheckTx.Priority) {
+				// remove from cache (the tx might be accepted later)
+				mem.cache.Remove(tx)
+				mem.logger.Debug("rejected low priority transaction in a congested mempool", "tx", txID(tx))
+				mem.metrics.RejectedTxs.Add(1)
+				r.CheckTx.Code = CodeTypeMempoolCongested
+				r.CheckTx.Codespace = CodespaceMempool
+				r.CheckTx.Log = "mempool congested, retry later"
+				return
+			}
+
 			memTx := &mempoolTx{
-				height:    mem.height,
-				gasWanted: r.CheckTx.GasWanted,
-				tx:        tx,
+				height:       mem.height,
+				gasWanted:    r.CheckTx.GasWanted,
+				tx:           tx,
+				priority:     r.CheckTx.Priority,
+				sender:       r.CheckTx.Sender,
+				sequence:     r.CheckTx.Sequence,
+				dependsOn:    txKeys(r.CheckTx.DependsOn),
+				shard:        shard,
+				class:        class,
+				firstSeen:    time.Now(),
+				expireHeight: r.CheckTx.ExpireHeight,
+				expireTime:   expireTime,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
+			mem.publishTxEvent(tx, types.MempoolTxAdded, "")
 			mem.logger.Debug("added good transaction",
 				"tx", txID(tx),
 				"res", r,
@@ -423,17 +854,23 @@ func (mem *CListMempool) resCbFirstTime(
 //
 // The case where the app checks the tx for the first time is handled by the
 // resCbFirstTime callback.
-func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
+func (mem *CListMempool) resCbRecheck(rtx recheckTx, res *abci.Response) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
-		tx := req.GetCheckTx().Tx
-		memTx := mem.recheckCursor.Value.(*mempoolTx)
-		if !bytes.Equal(tx, memTx.tx) {
-			panic(fmt.Sprintf(
-				"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
-				memTx.tx,
-				tx))
+		e, ok := mem.txsMap.Load(rtx.key)
+		if !ok {
+			// the tx was removed during the recheck
+			return
 		}
+		elem := e.(*clist.CElement)
+		memTx := elem.Value.(*mempoolTx)
+		if memTx.seq != rtx.seq {
+			// the tx was removed and added again during the recheck, the
+			// response is for the removed one
+			return
+		}
+		tx := memTx.tx
+
 		var postCheckErr error
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
@@ -444,27 +881,28 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Debug("tx is no longer valid", "tx", txID(tx), "res", r, "err", postCheckErr)
 			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
-		}
-		if mem.recheckCursor == mem.recheckEnd {
-			mem.recheckCursor = nil
-		} else {
-			mem.recheckCursor = mem.recheckCursor.Next()
-		}
-		if mem.recheckCursor == nil {
-			// Done!
-			mem.logger.Debug("done rechecking txs")
-
-			// incase the recheck removed all txs
-			if mem.Size() > 0 {
-				mem.notifyTxsAvailable()
+			mem.removeTx(tx, elem, !mem.config.KeepInvalidTxsInCache)
+			reason := r.CheckTx.Log
+			if postCheckErr != nil {
+				reason = postCheckErr.Error()
 			}
+			mem.publishTxEvent(tx, types.MempoolTxRecheckFailed, reason)
 		}
 	default:
 		// ignore other messages
 	}
 }
 
+// recheckDone is called once all txs were rechecked.
+func (mem *CListMempool) recheckDone() {
+	mem.logger.Debug("done rechecking txs")
+
+	// incase the recheck removed all txs
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
+	}
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) TxsAvailable() <-chan struct{} {
 	return mem.txsAvailable
@@ -484,43 +922,40 @@ func (mem *CListMempool) notifyTxsAvailable() {
 	}
 }
 
+// ReapMaxBytesMaxGas reaps the txs selected by the block builder, ordered so
+// that every tx follows the txs it depends on according to the hints reported
+// by the app in CheckTx.
+//
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
-	var (
-		totalGas    int64
-		runningSize int64
-	)
-
-	// TODO: we will get a performance boost if we have a good estimate of avg
-	// size per tx, and set the initial capacity based off of that.
-	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		txs = append(txs, memTx.tx)
-
-		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
-
-		// Check total size requirement
-		if maxBytes > -1 && runningSize+dataSize > maxBytes {
-			return txs[:len(txs)-1]
-		}
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
 
-		runningSize += dataSize
+	deps := newTxDependencies(memTxs)
+	if deps == nil {
+		it := mempoolTxIterator(memTxs)
+		return mem.blockBuilder.BuildBlock(&it, maxBytes, maxGas)
+	}
 
-		// Check total gas requirement.
-		// If maxGas is negative, skip this check.
-		// Since newTotalGas < masGas, which
-		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs[:len(txs)-1]
+	// Offer the txs to the builder in dependency order, and since the builder
+	// may reorder or skip them, restore the order of the txs it selected.
+	it := mempoolTxIterator(deps.order(memTxs))
+	txs := mem.blockBuilder.BuildBlock(&it, maxBytes, maxGas)
+	selected := make([]*mempoolTx, 0, len(txs))
+	for _, tx := range txs {
+		if memTx, ok := deps.pending[TxKey(tx)]; ok {
+			selected = append(selected, memTx)
 		}
-		totalGas = newTotalGas
+	}
+	selected = deps.order(selected)
+	txs = make(types.Txs, 0, len(selected))
+	for _, memTx := range selected {
+		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
@@ -582,9 +1017,14 @@ func (mem *CListMempool) Update(
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(TxKey(tx)); ok {
 			mem.removeTx(tx, e.(*clist.CElement), false)
+			mem.publishTxEvent(tx, types.MempoolTxCommitted, "")
 		}
 	}
 
+	// Remove the txs which can no longer be included in a block, so that they
+	// are not rechecked.
+	mem.removeExpiredTxs(height, time.Now())
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
@@ -592,8 +1032,8 @@ func (mem *CListMempool) Update(
 			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
 			mem.recheckTxs()
 			// At this point, mem.txs are being rechecked.
-			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
-			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
+			// The recheck callbacks possibly remove some txs.
+			// Before mem.Reap(), we should wait for mem.pendingRecheck to be empty.
 		} else {
 			mem.notifyTxsAvailable()
 		}
@@ -605,28 +1045,65 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// removeExpiredTxs removes the txs which can no longer be included in a block
+// after the given height, according to the expiration height or time the app
+// reported in CheckTx. The txs are kept in the cache, since they can never be
+// valid again.
+func (mem *CListMempool) removeExpiredTxs(height int64, now time.Time) {
+	for e := mem.txs.Front(); e != nil; {
+		next := e.Next()
+		memTx := e.Value.(*mempoolTx)
+		if txExpired(memTx.expireHeight, memTx.expireTime, height, now) {
+			mem.logger.Debug("removed expired transaction", "tx", txID(memTx.tx), "height", height)
+			mem.removeTx(memTx.tx, e, false)
+			mem.publishTxEvent(memTx.tx, types.MempoolTxExpired, "expired")
+			mem.metrics.ExpiredTxs.Add(1)
+		}
+		e = next
+	}
+}
+
+// txExpired returns whether a tx with the given expiration height and time,
+// each of which is unset if zero, can no longer be included in a block after
+// the given height, as of now.
+func txExpired(expireHeight int64, expireTime time.Time, height int64, now time.Time) bool {
+	return (expireHeight > 0 && expireHeight <= height) ||
+		(!expireTime.IsZero() && !now.Before(expireTime))
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
 	}
 
-	mem.recheckCursor = mem.txs.Front()
-	mem.recheckEnd = mem.txs.Back()
+	memTxs := make([]*mempoolTx, 0, mem.Size())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+
+	mem.recheckMtx.Lock()
+	mem.pendingRecheck = make(map[uint64]recheckTx, len(memTxs))
+	for _, memTx := range memTxs {
+		mem.pendingRecheck[memTx.seq] = recheckTx{key: TxKey(memTx.tx), seq: memTx.seq}
+	}
+	mem.recheckMtx.Unlock()
 
 	ctx := context.Background()
 
 	// Push txs to proxyAppConn
-	// NOTE: globalCb may be called concurrently.
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		_, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
+	// NOTE: the recheck callbacks may be called concurrently.
+	for _, memTx := range memTxs {
+		reqRes, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
 			Tx:   memTx.tx,
 			Type: abci.CheckTxType_Recheck,
 		})
 		if err != nil {
 			// No need in retrying since memTx will be rechecked after next block.
 			mem.logger.Error("Can't check tx", "err", err)
+			mem.cancelRecheck(memTx.seq)
+			continue
 		}
+		reqRes.SetCallback(mem.recheckCb(recheckTx{key: TxKey(memTx.tx), seq: memTx.seq}))
 	}
 
 	_, err := mem.proxyAppConn.FlushAsync(ctx)
@@ -635,13 +1112,42 @@ func (mem *CListMempool) recheckTxs() {
 	}
 }
 
+// cancelRecheck removes the tx with the given sequence number, whose CheckTx
+// request failed, from the txs being rechecked.
+func (mem *CListMempool) cancelRecheck(seq uint64) {
+	mem.recheckMtx.Lock()
+	delete(mem.pendingRecheck, seq)
+	done := len(mem.pendingRecheck) == 0
+	mem.recheckMtx.Unlock()
+
+	if done {
+		mem.recheckDone()
+	}
+}
+
+// recheckTx identifies a tx being rechecked.
+type recheckTx struct {
+	key [TxKeySize]byte
+	seq uint64
+}
+
 //--------------------------------------------------------------------------------
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height       int64             // height that this tx had been validated in
+	gasWanted    int64             // amount of gas this tx states it will require
+	tx           types.Tx          //
+	priority     int64             // priority of the tx as reported by the app in CheckTx
+	sender       string            // sender of the tx as reported by the app in CheckTx
+	sequence     uint64            // sequence of the tx among the txs of its sender, if any
+	dependsOn    [][TxKeySize]byte // keys of the txs this tx must follow
+	seq          uint64            // sequence number, unique among all txs ever added
+	shard        int               // shard of the tx, if the mempool is sharded
+	class        int               // class of the tx, if tx classes are configured
+	firstSeen    time.Time         // time the tx was added to the mempool
+	expireHeight int64             // last height the tx can be included at, if any
+	expireTime   time.Time         // time from which the tx can't be included, if any
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -653,6 +1159,24 @@ func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
 
+// mempoolTxIterator iterates over a slice of mempool txs.
+type mempoolTxIterator []*mempoolTx
+
+// Next implements TxIterator.
+func (it *mempoolTxIterator) Next() (BlockTx, bool) {
+	if len(*it) == 0 {
+		return BlockTx{}, false
+	}
+	memTx := (*it)[0]
+	*it = (*it)[1:]
+	return BlockTx{
+		Tx:        memTx.tx,
+		GasWanted: memTx.gasWanted,
+		Priority:  memTx.priority,
+		Sender:    memTx.sender,
+	}, true
+}
+
 //--------------------------------------------------------------------------------
 
 type txCache interface {
@@ -744,6 +1268,23 @@ func TxKey(tx types.Tx) [TxKeySize]byte {
 }
 
 // txID is a hash of the Tx.
+// txKeys converts the tx keys reported by the app, ignoring malformed ones.
+func txKeys(keys [][]byte) [][TxKeySize]byte {
+	if len(keys) == 0 {
+		return nil
+	}
+	txKeys := make([][TxKeySize]byte, 0, len(keys))
+	for _, key := range keys {
+		if len(key) != TxKeySize {
+			continue
+		}
+		var txKey [TxKeySize]byte
+		copy(txKey[:], key)
+		txKeys = append(txKeys, txKey)
+	}
+	return txKeys
+}
+
 func txID(tx []byte) []byte {
 	return types.Tx(tx).Hash()
 }
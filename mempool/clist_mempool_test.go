@@ -1,12 +1,15 @@
 package mempool
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
 	mrand "math/rand"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	abciserver "github.com/tendermint/tendermint/abci/server"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
@@ -212,6 +216,80 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempool_TxFirstSeen(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	tx := types.Tx([]byte{0x01})
+	_, ok := mempool.TxFirstSeen(tx)
+	require.False(t, ok)
+
+	before := time.Now()
+	require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	firstSeen, ok := mempool.TxFirstSeen(tx)
+	require.True(t, ok)
+	assert.False(t, firstSeen.Before(before))
+	assert.False(t, firstSeen.After(time.Now()))
+
+	// committed txs are removed
+	require.NoError(t, mempool.Update(1, []types.Tx{tx}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	_, ok = mempool.TxFirstSeen(tx)
+	assert.False(t, ok)
+}
+
+func TestMempool_TxEvents(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mempool.SetEventBus(eventBus)
+	sub, err := eventBus.Subscribe(context.Background(), "TestMempool_TxEvents", types.EventQueryMempoolTx, 10)
+	require.NoError(t, err)
+
+	txs := []types.Tx{[]byte{0x01}, []byte{0x02}, []byte{0x03}, []byte{0x04}}
+	for _, tx := range txs[:3] {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	mempool.RemoveTxByKey(TxKey(txs[2]), true)
+
+	// the remaining tx fails the recheck
+	require.NoError(t, mempool.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, PostCheckMaxGas(0)))
+	require.NoError(t, mempool.FlushAppConn())
+
+	require.NoError(t, mempool.Update(2, nil, nil, nil, PostCheckMaxGas(-1)))
+	require.NoError(t, mempool.CheckTx(txs[3], nil, TxInfo{}))
+	mempool.Flush()
+
+	expected := []types.EventDataMempoolTx{
+		{Tx: txs[0], Status: types.MempoolTxAdded},
+		{Tx: txs[1], Status: types.MempoolTxAdded},
+		{Tx: txs[2], Status: types.MempoolTxAdded},
+		{Tx: txs[2], Status: types.MempoolTxEvicted, Reason: "removed"},
+		{Tx: txs[0], Status: types.MempoolTxCommitted},
+		{Tx: txs[1], Status: types.MempoolTxRecheckFailed, Reason: "gas wanted 1 is greater than max gas 0"},
+		{Tx: txs[3], Status: types.MempoolTxAdded},
+		{Tx: txs[3], Status: types.MempoolTxEvicted, Reason: "mempool flushed"},
+	}
+	for _, event := range expected {
+		select {
+		case msg := <-sub.Out():
+			assert.Equal(t, event, msg.Data())
+		case <-time.After(time.Second):
+			t.Fatalf("did not receive the mempool event %v", event)
+		}
+	}
+}
+
 func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	app := counter.NewApplication(true)
 	cc := proxy.NewLocalClientCreator(app)
@@ -260,6 +338,610 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	}
 }
 
+// priorityApp sets the priority of a tx to its first byte.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Priority: int64(req.Tx[0])}
+}
+
+func TestMempool_BlockBuilder(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	for _, tx := range []types.Tx{{1, 1}, {3, 1}, {2, 1}, {3, 2}} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	require.Equal(t, types.Txs{{1, 1}, {3, 1}, {2, 1}, {3, 2}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	mempool.SetBlockBuilder(PriorityBlockBuilder{})
+	require.Equal(t, types.Txs{{3, 1}, {3, 2}, {2, 1}, {1, 1}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, types.Txs{{3, 1}, {3, 2}}, mempool.ReapMaxBytesMaxGas(8, -1))
+}
+
+// dependencyApp sets the sender of a tx to its first byte, its sequence and
+// priority to its second byte, and its dependency to the rest of the tx.
+type dependencyApp struct {
+	abci.BaseApplication
+}
+
+func (dependencyApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{
+		Code:     abci.CodeTypeOK,
+		Sequence: uint64(req.Tx[1]),
+		Priority: int64(req.Tx[1]),
+	}
+	if req.Tx[0] != 0 {
+		res.Sender = string(req.Tx[:1])
+	}
+	if len(req.Tx) > 2 {
+		res.DependsOn = [][]byte{req.Tx[2:]}
+	}
+	return res
+}
+
+func TestMempool_Dependencies(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(dependencyApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	var (
+		a1 = types.Tx{'a', 1}
+		a2 = types.Tx{'a', 2}
+		b1 = types.Tx{'b', 1}
+		d  = types.Tx{0, 9}
+	)
+	key := TxKey(d)
+	c := append(types.Tx{0, 0}, key[:]...)
+
+	// a2 and c are added before the txs they depend on
+	for _, tx := range []types.Tx{a2, c, a1, b1, d} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	require.Equal(t, types.Txs{a1, a2, d, c, b1}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, types.Txs{a1}, mempool.ReapMaxBytesMaxGas(4, -1))
+
+	// the builder prefers a2 and d, but they must follow a1 and c must
+	// follow d
+	mempool.SetBlockBuilder(PriorityBlockBuilder{})
+	require.Equal(t, types.Txs{d, a1, a2, b1, c}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, types.Txs{d, a1, a2}, mempool.ReapMaxBytesMaxGas(12, -1))
+
+	// once a1 is committed, a2 no longer depends on anything
+	mempool.Lock()
+	err := mempool.Update(1, []types.Tx{a1}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{d, a2}, mempool.ReapMaxBytesMaxGas(8, -1))
+}
+
+func TestMempool_MinTxPriority(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.MinTxPriority = 5
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	var res *abci.ResponseCheckTx
+	require.NoError(t, mempool.CheckTx(types.Tx{3, 1}, func(r *abci.Response) { res = r.GetCheckTx() }, TxInfo{}))
+	require.NotNil(t, res)
+	assert.Equal(t, CodeTypeTxPriorityTooLow, res.Code)
+	assert.Equal(t, CodespaceMempool, res.Codespace)
+	assert.Equal(t, ErrTxPriorityTooLow{3, 5}.Error(), res.Log)
+	require.NoError(t, mempool.CheckTx(types.Tx{5, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{7, 1}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	// the rejected tx was removed from the cache
+	mempool.SetMinTxPriority(0)
+	assert.EqualValues(t, 0, mempool.MinTxPriority())
+	require.NoError(t, mempool.CheckTx(types.Tx{3, 1}, nil, TxInfo{}))
+	assert.Equal(t, 3, mempool.Size())
+}
+
+func TestMempool_MinTxPriorityUtilization(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 4
+	config.Mempool.MinTxPriority = 5
+	config.Mempool.MinTxPriorityUtilization = 0.5
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	// below the utilization threshold the priority is not enforced
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 2}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 3}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+	require.NoError(t, mempool.CheckTx(types.Tx{6, 1}, nil, TxInfo{}))
+	assert.Equal(t, 3, mempool.Size())
+}
+
+func TestMempool_HighWaterMarks(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 10
+	config.Mempool.MinTxPriority = 5
+	config.Mempool.MinTxPriorityUtilization = 1
+	config.Mempool.SoftHighWaterMark = 0.2
+	config.Mempool.HardHighWaterMark = 0.5
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	checkTx := func(tx types.Tx, txInfo TxInfo) *abci.ResponseCheckTx {
+		var res *abci.ResponseCheckTx
+		require.NoError(t, mempool.CheckTx(tx, func(r *abci.Response) { res = r.GetCheckTx() }, txInfo))
+		require.NotNil(t, res)
+		return res
+	}
+
+	// below the soft mark, low priority txs are accepted
+	assert.Equal(t, abci.CodeTypeOK, checkTx(types.Tx{1, 1}, TxInfo{}).Code)
+	assert.Equal(t, abci.CodeTypeOK, checkTx(types.Tx{1, 2}, TxInfo{}).Code)
+	assert.Equal(t, 2, mempool.Size())
+
+	// above it, they are rejected over RPC with a retriable code, but still
+	// accepted from peers
+	res := checkTx(types.Tx{1, 3}, TxInfo{})
+	assert.Equal(t, CodeTypeMempoolCongested, res.Code)
+	assert.Equal(t, CodespaceMempool, res.Codespace)
+	assert.Equal(t, 2, mempool.Size())
+	assert.Equal(t, abci.CodeTypeOK, checkTx(types.Tx{1, 3}, TxInfo{SenderID: 1}).Code)
+	assert.Equal(t, abci.CodeTypeOK, checkTx(types.Tx{6, 1}, TxInfo{}).Code)
+	assert.Equal(t, abci.CodeTypeOK, checkTx(types.Tx{6, 2}, TxInfo{}).Code)
+	assert.Equal(t, 5, mempool.Size())
+
+	// above the hard mark, all txs are rejected
+	err := mempool.CheckTx(types.Tx{6, 3}, nil, TxInfo{})
+	assert.IsType(t, ErrMempoolIsCongested{}, err)
+	err = mempool.CheckTx(types.Tx{6, 3}, nil, TxInfo{SenderID: 1})
+	assert.IsType(t, ErrMempoolIsCongested{}, err)
+	assert.Equal(t, 5, mempool.Size())
+}
+
+func TestMempool_ShardBySize(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 10
+	config.Mempool.ShardBy = cfg.MempoolShardBySize
+	config.Mempool.ShardBounds = []int64{4}
+	config.Mempool.ShardCapacities = []float64{0.2, 0.8}
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	// the shard of small txs fills up after 2 txs
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 2}, nil, TxInfo{}))
+	err := mempool.CheckTx(types.Tx{1, 3}, nil, TxInfo{})
+	require.Error(t, err)
+	assert.IsType(t, ErrMempoolShardIsFull{}, err)
+
+	// while large txs are still accepted
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 1, 1, 1, 1}, nil, TxInfo{}))
+	assert.Equal(t, 3, mempool.Size())
+
+	// removing a small tx frees up space in its shard
+	mempool.Lock()
+	err = mempool.Update(1, []types.Tx{{1, 1}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 3}, nil, TxInfo{}))
+	assert.Equal(t, 3, mempool.Size())
+
+	mempool.Flush()
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 4}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 5}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+}
+
+// gasApp sets the gas wanted of a tx to its first byte.
+type gasApp struct {
+	abci.BaseApplication
+}
+
+func (gasApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: int64(req.Tx[0])}
+}
+
+func TestMempool_ShardByGas(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(gasApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 4
+	config.Mempool.ShardBy = cfg.MempoolShardByGas
+	config.Mempool.ShardBounds = []int64{10}
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	// the shards are split evenly, so each holds 2 txs
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{10, 1}, nil, TxInfo{}))
+	var res *abci.ResponseCheckTx
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 2}, func(r *abci.Response) { res = r.GetCheckTx() }, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	// the rejected tx gets a mempool codespace code
+	require.NotNil(t, res)
+	assert.Equal(t, CodeTypeShardFull, res.Code)
+	assert.Equal(t, CodespaceMempool, res.Codespace)
+	assert.NotEmpty(t, res.Log)
+
+	require.NoError(t, mempool.CheckTx(types.Tx{11, 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{100, 1}, nil, TxInfo{}))
+	assert.Equal(t, 4, mempool.Size())
+
+	// the rejected tx was removed from the cache
+	mempool.Lock()
+	err := mempool.Update(1, []types.Tx{{1, 1}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 2}, nil, TxInfo{}))
+	assert.Equal(t, 4, mempool.Size())
+}
+
+// classApp tags a tx with the class "oracle" if its first byte is 'o'.
+type classApp struct {
+	abci.BaseApplication
+}
+
+func (classApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	if req.Tx[0] == 'o' {
+		res.TxClass = "oracle"
+	}
+	return res
+}
+
+func TestMempool_TxClasses(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(classApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 10
+	config.Mempool.TxClasses = []string{"oracle"}
+	config.Mempool.TxClassCapacities = []float64{0.2}
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	// the other txs can't use the space reserved to oracle txs
+	for i := byte(0); i < 9; i++ {
+		require.NoError(t, mempool.CheckTx(types.Tx{1, i}, nil, TxInfo{}))
+	}
+	assert.Equal(t, 8, mempool.Size())
+	require.NoError(t, mempool.CheckTx(types.Tx{'o', 1}, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx{'o', 2}, nil, TxInfo{}))
+	assert.Equal(t, 10, mempool.Size())
+
+	// and the oracle txs can't use the space of the other txs
+	mempool.Lock()
+	err := mempool.Update(1, []types.Tx{{1, 0}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	var res *abci.ResponseCheckTx
+	require.NoError(t, mempool.CheckTx(types.Tx{'o', 3}, func(r *abci.Response) { res = r.GetCheckTx() }, TxInfo{}))
+	assert.Equal(t, 9, mempool.Size())
+	require.NotNil(t, res)
+	assert.Equal(t, CodeTypeTxClassFull, res.Code)
+	assert.Equal(t, CodespaceMempool, res.Codespace)
+	assert.NotEmpty(t, res.Log)
+
+	// the rejected txs were removed from the cache, and are accepted once
+	// their class has space again
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 8}, nil, TxInfo{}))
+	mempool.Lock()
+	err = mempool.Update(2, []types.Tx{{'o', 1}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx(types.Tx{'o', 3}, nil, TxInfo{}))
+	assert.Equal(t, 10, mempool.Size())
+}
+
+func TestMempool_GossipPriorityTxs(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(classApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx(types.Tx{'o', 1}, nil, TxInfo{}))
+	assert.Empty(t, mempool.gossipPriorityTxs())
+
+	config.Mempool.TxClasses = []string{"oracle"}
+	config.Mempool.TxClassCapacities = []float64{0.5}
+	config.Mempool.TxClassGossipPriorities = []int64{1}
+	mempool, cleanup = newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	txs := []types.Tx{{1, 1}, {'o', 1}, {1, 2}, {'o', 2}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	memTxs := mempool.gossipPriorityTxs()
+	require.Len(t, memTxs, 2)
+	assert.Equal(t, txs[1], memTxs[0].tx)
+	assert.Equal(t, txs[3], memTxs[1].tx)
+}
+
+// expirationApp sets the expiration height of a tx to its first byte, and its
+// expiration time to expireTime if its second byte is 1.
+type expirationApp struct {
+	abci.BaseApplication
+	expireTime time.Time
+}
+
+func (app expirationApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK, ExpireHeight: int64(req.Tx[0])}
+	if req.Tx[1] == 1 {
+		res.ExpireTime = &app.expireTime
+	}
+	return res
+}
+
+func TestMempool_ExpiredTxs(t *testing.T) {
+	expireTime := time.Now().Add(200 * time.Millisecond)
+	cc := proxy.NewLocalClientCreator(expirationApp{expireTime: expireTime})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := []types.Tx{{0, 0}, {1, 0}, {2, 0}, {0, 1}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, 4, mempool.Size())
+
+	// the txs expiring at the committed height are removed
+	mempool.Lock()
+	err := mempool.Update(1, nil, nil, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{txs[0], txs[2], txs[3]}, mempool.ReapMaxTxs(-1))
+
+	// and the txs which already expired are rejected
+	var code uint32
+	require.NoError(t, mempool.CheckTx(types.Tx{1, 2}, func(res *abci.Response) {
+		code = res.GetCheckTx().Code
+	}, TxInfo{}))
+	assert.Equal(t, CodeTypeTxExpired, code)
+	assert.Equal(t, 3, mempool.Size())
+
+	// the txs whose expiration time passed are removed
+	time.Sleep(time.Until(expireTime))
+	mempool.Lock()
+	err = mempool.Update(2, nil, nil, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{txs[0]}, mempool.ReapMaxTxs(-1))
+
+	// the expired txs are kept in the cache, and not checked again
+	checked := false
+	require.NoError(t, mempool.CheckTx(txs[2], func(*abci.Response) { checked = true }, TxInfo{}))
+	assert.False(t, checked)
+	assert.Equal(t, 1, mempool.Size())
+}
+
+// senderApp sets the sender of a tx to its first byte.
+type senderApp struct {
+	abci.BaseApplication
+}
+
+func (senderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Sender: string(req.Tx[:1])}
+}
+
+func TestMempool_TxsBySender(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(senderApp{})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := []types.Tx{{'a', 1}, {'b', 1}, {'a', 2}, {'a', 3}, {'b', 2}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	assert.Equal(t, types.Txs{txs[0], txs[2], txs[3]}, mempool.GetTxsBySender("a"))
+	assert.Equal(t, types.Txs{txs[1], txs[4]}, mempool.GetTxsBySender("b"))
+	assert.Empty(t, mempool.GetTxsBySender("c"))
+
+	// committed txs are removed from the index
+	require.NoError(t, mempool.Update(1, []types.Tx{txs[2]}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, types.Txs{txs[0], txs[3]}, mempool.GetTxsBySender("a"))
+
+	assert.Equal(t, 2, mempool.RemoveTxsBySender("a", true))
+	assert.Empty(t, mempool.GetTxsBySender("a"))
+	assert.Equal(t, types.Txs{txs[1], txs[4]}, mempool.ReapMaxTxs(-1))
+
+	// removed from the cache
+	require.NoError(t, mempool.CheckTx(txs[0], nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[0]}, mempool.GetTxsBySender("a"))
+
+	mempool.Flush()
+	assert.Empty(t, mempool.GetTxsBySender("b"))
+}
+
+// keyValueApp accepts txs of the form key=value, and counts its CheckTx calls.
+type keyValueApp struct {
+	abci.BaseApplication
+	checkTxs *int32
+}
+
+func (app keyValueApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	atomic.AddInt32(app.checkTxs, 1)
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func (keyValueApp) SanityCheckTx(tx []byte) error {
+	if tx[0] == 0xff {
+		panic("invalid tag")
+	}
+	if fields := bytes.Split(tx, []byte("=")); len(fields) != 2 {
+		return fmt.Errorf("expected key=value, got %d fields", len(fields))
+	}
+	return nil
+}
+
+func TestMempool_TxSanityCheck(t *testing.T) {
+	var checkTxs int32
+	cc := proxy.NewLocalClientCreator(keyValueApp{checkTxs: &checkTxs})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	sanityCheck := proxy.TxSanityCheck(cc)
+	require.NotNil(t, sanityCheck)
+	WithTxSanityCheck(func(tx types.Tx) error { return sanityCheck(tx) })(mempool)
+
+	require.NoError(t, mempool.CheckTx(types.Tx("a=1"), nil, TxInfo{}))
+	for _, tx := range []types.Tx{types.Tx("a"), types.Tx("a=1=2"), {0xff, '='}} {
+		err := mempool.CheckTx(tx, nil, TxInfo{})
+		assert.IsType(t, ErrTxMalformed{}, err, "tx %q", tx)
+	}
+
+	// malformed txs don't reach the app, nor the cache
+	assert.EqualValues(t, 1, atomic.LoadInt32(&checkTxs))
+	assert.Equal(t, 1, mempool.Size())
+	assert.IsType(t, ErrTxMalformed{}, mempool.CheckTx(types.Tx("a"), nil, TxInfo{}))
+
+	// Update doesn't replace the sanity check
+	require.NoError(t, mempool.Update(1, nil, nil, nil, nil))
+	assert.IsType(t, ErrTxMalformed{}, mempool.CheckTx(types.Tx("b"), nil, TxInfo{}))
+
+	// remote apps have no sanity check
+	assert.Nil(t, proxy.TxSanityCheck(proxy.NewRemoteClientCreator("tcp://127.0.0.1:1", "socket", false)))
+	assert.Nil(t, proxy.TxSanityCheck(proxy.NewLocalClientCreator(kvstore.NewApplication())))
+}
+
+// blockingApp blocks CheckTx until release is closed, and counts its CheckTx
+// calls.
+type blockingApp struct {
+	abci.BaseApplication
+	checkTxs *int32
+	release  chan struct{}
+}
+
+func (app blockingApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	atomic.AddInt32(app.checkTxs, 1)
+	<-app.release
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Data: []byte("checked")}
+}
+
+func TestMempool_CoalesceConcurrentCheckTxs(t *testing.T) {
+	var checkTxs int32
+	app := blockingApp{checkTxs: &checkTxs, release: make(chan struct{})}
+	mempool, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	defer cleanup()
+
+	// the first CheckTx blocks in the app
+	tx := types.Tx("tx")
+	responses := make(chan *abci.Response, 4)
+	cb := func(res *abci.Response) { responses <- res }
+	go func() {
+		assert.NoError(t, mempool.CheckTx(tx, cb, TxInfo{}))
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&checkTxs) == 1 }, time.Second, time.Millisecond)
+
+	// the same tx submitted meanwhile waits for its response
+	require.NoError(t, mempool.CheckTx(tx, cb, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(tx, cb, TxInfo{SenderID: 1}))
+	require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{SenderID: 2}))
+	select {
+	case <-responses:
+		t.Fatal("got a response before the app checked the tx")
+	default:
+	}
+
+	close(app.release)
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-responses:
+			assert.Equal(t, []byte("checked"), res.GetCheckTx().Data)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the responses")
+		}
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&checkTxs))
+	require.Equal(t, 1, mempool.Size())
+
+	// the peers of the waiting callers are recorded as senders of the tx
+	e, ok := mempool.txsMap.Load(TxKey(tx))
+	require.True(t, ok)
+	memTx := e.(*clist.CElement).Value.(*mempoolTx)
+	for _, peerID := range []uint16{1, 2} {
+		_, ok := memTx.senders.Load(peerID)
+		assert.True(t, ok, "peer %d", peerID)
+	}
+
+	// the tx is no longer in flight, and is found in the cache
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(tx, nil, TxInfo{SenderID: 1}))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&checkTxs))
+}
+
+// concurrentBlockingApp is a blockingApp checking txs concurrently.
+type concurrentBlockingApp struct {
+	blockingApp
+}
+
+func (concurrentBlockingApp) ConcurrencySafe() bool { return true }
+
+func TestMempool_ConcurrentCheckTxAtCapacity(t *testing.T) {
+	var checkTxs int32
+	app := concurrentBlockingApp{blockingApp{checkTxs: &checkTxs, release: make(chan struct{})}}
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 5
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(app), config)
+	defer cleanup()
+
+	// the app checks all the txs at once, so their responses are handled
+	// concurrently
+	const count = 200
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, mempool.CheckTx(types.Tx{byte(i), byte(i >> 8)}, nil, TxInfo{}))
+		}(i)
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&checkTxs) == count }, time.Second, time.Millisecond)
+	close(app.release)
+	wg.Wait()
+
+	// but no more txs are admitted than the mempool has room for
+	assert.Equal(t, config.Mempool.Size, mempool.Size())
+	assert.EqualValues(t, 2*config.Mempool.Size, mempool.TxsBytes())
+}
+
+func TestMempool_RecheckMatchesTxGeneration(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(abci.NewBaseApplication())
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	a, b := types.Tx("a"), types.Tx("b")
+	require.NoError(t, mempool.CheckTx(a, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(b, nil, TxInfo{}))
+
+	// simulate a recheck whose responses arrive out of order, after a was
+	// removed and added again
+	ra, rb := recheckTx{key: TxKey(a), seq: 1}, recheckTx{key: TxKey(b), seq: 2}
+	mempool.pendingRecheck = map[uint64]recheckTx{ra.seq: ra, rb.seq: rb}
+	mempool.RemoveTxByKey(TxKey(a), true)
+	require.NoError(t, mempool.CheckTx(a, nil, TxInfo{}))
+
+	invalid := abci.ToResponseCheckTx(abci.ResponseCheckTx{Code: 1})
+	mempool.recheckCb(rb)(invalid)
+	mempool.recheckCb(ra)(invalid)
+
+	// b was removed, and the response for the removed a did not remove the
+	// new one
+	assert.Equal(t, types.Txs{a}, mempool.ReapMaxTxs(-1))
+	assert.Empty(t, mempool.pendingRecheck)
+
+	// an unexpected response is ignored
+	require.NotPanics(t, func() { mempool.recheckCb(recheckTx{key: TxKey(a), seq: 3})(invalid) })
+	assert.Equal(t, 1, mempool.Size())
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -0,0 +1,122 @@
+package mempool
+
+import (
+	"sort"
+)
+
+// txDependencies resolves the dependency hints the app reported in CheckTx
+// for the txs in the mempool: a tx depends on the txs whose keys it lists in
+// DependsOn, and on the tx of the same sender with the next lower Sequence.
+// Only txs in the mempool are considered; a dependency on any other tx is
+// assumed to be committed already.
+type txDependencies struct {
+	// pending: txKey -> tx, for all txs in the mempool
+	pending map[[TxKeySize]byte]*mempoolTx
+	// prev: tx -> tx of the same sender with the next lower sequence
+	prev map[*mempoolTx]*mempoolTx
+}
+
+// newTxDependencies returns the dependencies between the given txs, which must
+// be all txs in the mempool, or nil if none of them has dependency hints.
+func newTxDependencies(txs []*mempoolTx) *txDependencies {
+	var (
+		hints     bool
+		bySender  = make(map[string][]*mempoolTx)
+		senderIDs = []string{}
+	)
+	for _, memTx := range txs {
+		if len(memTx.dependsOn) > 0 {
+			hints = true
+		}
+		if memTx.sender != "" && memTx.sequence > 0 {
+			hints = true
+			if _, ok := bySender[memTx.sender]; !ok {
+				senderIDs = append(senderIDs, memTx.sender)
+			}
+			bySender[memTx.sender] = append(bySender[memTx.sender], memTx)
+		}
+	}
+	if !hints {
+		return nil
+	}
+
+	d := &txDependencies{
+		pending: make(map[[TxKeySize]byte]*mempoolTx, len(txs)),
+		prev:    make(map[*mempoolTx]*mempoolTx),
+	}
+	for _, memTx := range txs {
+		d.pending[TxKey(memTx.tx)] = memTx
+	}
+	for _, sender := range senderIDs {
+		seqTxs := bySender[sender]
+		sort.SliceStable(seqTxs, func(i, j int) bool { return seqTxs[i].sequence < seqTxs[j].sequence })
+		var prev *mempoolTx
+		for i, memTx := range seqTxs {
+			if i > 0 && seqTxs[i-1].sequence < memTx.sequence {
+				prev = seqTxs[i-1]
+			}
+			if prev != nil {
+				d.prev[memTx] = prev
+			}
+		}
+	}
+	return d
+}
+
+// deps returns the txs in the mempool which the tx depends on.
+func (d *txDependencies) deps(memTx *mempoolTx) []*mempoolTx {
+	deps := make([]*mempoolTx, 0, len(memTx.dependsOn)+1)
+	if prev, ok := d.prev[memTx]; ok {
+		deps = append(deps, prev)
+	}
+	for _, key := range memTx.dependsOn {
+		if dep, ok := d.pending[key]; ok && dep != memTx {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// order returns the given txs reordered so that every tx follows the txs it
+// depends on, and otherwise in the given order. Txs which depend on a tx in
+// the mempool that isn't among the given txs, or on themselves through a
+// cycle, are dropped, along with the txs which depend on them.
+func (d *txDependencies) order(txs []*mempoolTx) []*mempoolTx {
+	const (
+		visiting = iota + 1
+		included
+		dropped
+	)
+
+	var (
+		ordered = make([]*mempoolTx, 0, len(txs))
+		given   = make(map[*mempoolTx]bool, len(txs))
+		state   = make(map[*mempoolTx]int, len(txs))
+		visit   func(*mempoolTx) bool
+	)
+	for _, memTx := range txs {
+		given[memTx] = true
+	}
+	visit = func(memTx *mempoolTx) bool {
+		switch state[memTx] {
+		case included:
+			return true
+		case visiting, dropped:
+			return false
+		}
+		state[memTx] = visiting
+		for _, dep := range d.deps(memTx) {
+			if !given[dep] || !visit(dep) {
+				state[memTx] = dropped
+				return false
+			}
+		}
+		state[memTx] = included
+		ordered = append(ordered, memTx)
+		return true
+	}
+	for _, memTx := range txs {
+		visit(memTx)
+	}
+	return ordered
+}
@@ -0,0 +1,64 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxDependencies(t *testing.T) {
+	newTx := func(b byte, deps ...*mempoolTx) *mempoolTx {
+		memTx := &mempoolTx{tx: types.Tx{b}}
+		for _, dep := range deps {
+			memTx.dependsOn = append(memTx.dependsOn, TxKey(dep.tx))
+		}
+		return memTx
+	}
+
+	var (
+		a = newTx(1)
+		b = newTx(2, a)
+		c = newTx(3, b)
+		d = newTx(4)
+		e = newTx(5)
+		f = newTx(6, e)
+	)
+	// e and f depend on each other
+	e.dependsOn = append(e.dependsOn, TxKey(f.tx))
+	// g depends on a tx which isn't in the mempool
+	g := newTx(7, newTx(8))
+
+	all := []*mempoolTx{c, b, a, d, e, f, g}
+	deps := newTxDependencies(all)
+	require.NotNil(t, deps)
+
+	// the cycle is dropped
+	require.Equal(t, []*mempoolTx{a, b, c, d, g}, deps.order(all))
+	// txs depending on txs which aren't given are dropped
+	require.Equal(t, []*mempoolTx{d, a}, deps.order([]*mempoolTx{c, d, a}))
+
+	// without hints there are no dependencies
+	require.Nil(t, newTxDependencies([]*mempoolTx{newTx(1), newTx(2)}))
+}
+
+func TestTxDependencies_Sequence(t *testing.T) {
+	newTx := func(b byte, sender string, sequence uint64) *mempoolTx {
+		return &mempoolTx{tx: types.Tx{b}, sender: sender, sequence: sequence}
+	}
+
+	var (
+		a3 = newTx(1, "a", 3)
+		a1 = newTx(2, "a", 1)
+		b1 = newTx(3, "b", 1)
+		a5 = newTx(4, "a", 5)
+		x  = newTx(5, "", 1)
+	)
+	all := []*mempoolTx{a5, x, a3, b1, a1}
+	deps := newTxDependencies(all)
+
+	// gaps in the sequence are allowed
+	require.Equal(t, []*mempoolTx{a1, a3, a5, x, b1}, deps.order(all))
+	require.Equal(t, []*mempoolTx{b1, a1}, deps.order([]*mempoolTx{a5, b1, a1}))
+}
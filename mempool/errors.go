@@ -36,6 +36,69 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrMempoolIsCongested means the mempool utilization reached the hard
+// high-water mark, so it rejects all new txs.
+type ErrMempoolIsCongested struct {
+	utilization float64
+	mark        float64
+}
+
+func (e ErrMempoolIsCongested) Error() string {
+	return fmt.Sprintf("mempool is congested: utilization %.2f (hard high-water mark: %.2f)",
+		e.utilization, e.mark)
+}
+
+// ErrMempoolShardIsFull means the mempool shard of a tx's size or gas class is
+// full, even though the mempool as a whole may not be.
+type ErrMempoolShardIsFull struct {
+	shard string
+
+	numTxs int
+	maxTxs int
+
+	txsBytes    int64
+	maxTxsBytes int64
+}
+
+func (e ErrMempoolShardIsFull) Error() string {
+	return fmt.Sprintf(
+		"mempool shard %s is full: number of txs %d (max: %d), total txs bytes %d (max: %d)",
+		e.shard,
+		e.numTxs, e.maxTxs,
+		e.txsBytes, e.maxTxsBytes)
+}
+
+// ErrMempoolTxClassIsFull means the capacity of the class a tx was tagged
+// with in CheckTx is used up, even though the mempool as a whole may not be.
+type ErrMempoolTxClassIsFull struct {
+	class string
+
+	numTxs int
+	maxTxs int
+
+	txsBytes    int64
+	maxTxsBytes int64
+}
+
+func (e ErrMempoolTxClassIsFull) Error() string {
+	return fmt.Sprintf(
+		"mempool capacity of tx class %s is full: number of txs %d (max: %d), total txs bytes %d (max: %d)",
+		e.class,
+		e.numTxs, e.maxTxs,
+		e.txsBytes, e.maxTxsBytes)
+}
+
+// ErrTxPriorityTooLow means the CheckTx priority of a tx is below the minimum
+// priority enforced by the mempool.
+type ErrTxPriorityTooLow struct {
+	priority    int64
+	minPriority int64
+}
+
+func (e ErrTxPriorityTooLow) Error() string {
+	return fmt.Sprintf("tx priority %d is below the minimum priority %d", e.priority, e.minPriority)
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
@@ -50,3 +113,13 @@ func IsPreCheckError(err error) bool {
 	_, ok := err.(ErrPreCheck)
 	return ok
 }
+
+// ErrTxMalformed is returned when a tx exceeds the encoding limits of the
+// mempool or fails the sanity check of the app.
+type ErrTxMalformed struct {
+	Reason error
+}
+
+func (e ErrTxMalformed) Error() string {
+	return fmt.Sprintf("malformed tx: %v", e.Reason)
+}
@@ -3,12 +3,54 @@ package mempool
 import (
 	"context"
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/types"
 )
 
+// CodespaceMempool is the codespace of the CheckTx responses of the txs the
+// mempool rejects although the app accepted them.
+const CodespaceMempool = "mempool"
+
+// CodeTypeMempoolCongested is the code, in CodespaceMempool, of the CheckTx
+// response of a low priority tx submitted over RPC once the mempool reached
+// its soft high-water mark. The tx can be submitted again later.
+const CodeTypeMempoolCongested uint32 = 1
+
+// CodeTypeCheckTxFailed is the code, in CodespaceMempool, of the CheckTx
+// response shared with the callers of CheckTx waiting for the in-flight
+// CheckTx of the same tx, when the request to the app failed. The tx can be
+// submitted again.
+const CodeTypeCheckTxFailed uint32 = 2
+
+// CodeTypeTxRejected is the code, in CodespaceMempool, of the result of a tx
+// of a /broadcast_tx_batch which the mempool rejected before the app checked
+// it, e.g. because the tx is too large or the mempool is full. The log holds
+// the reason.
+const CodeTypeTxRejected uint32 = 3
+
+// CodeTypeTxExpired is the code, in CodespaceMempool, of the CheckTx response
+// of a tx whose expiration height or time, as reported by the app in CheckTx,
+// already passed.
+const CodeTypeTxExpired uint32 = 4
+
+// CodeTypeTxPriorityTooLow is the code, in CodespaceMempool, of the CheckTx
+// response of a tx whose priority is below the minimum priority of the
+// mempool. The tx can be submitted again once the minimum is lowered.
+const CodeTypeTxPriorityTooLow uint32 = 5
+
+// CodeTypeShardFull is the code, in CodespaceMempool, of the CheckTx response
+// of a tx whose shard is full. The tx can be submitted again once the shard
+// has space.
+const CodeTypeShardFull uint32 = 6
+
+// CodeTypeTxClassFull is the code, in CodespaceMempool, of the CheckTx
+// response of a tx whose tx class is full. The tx can be submitted again once
+// the class has space.
+const CodeTypeTxClassFull uint32 = 7
+
 // Mempool defines the mempool interface.
 //
 // Updates to the mempool need to be synchronized with committing a block so
@@ -69,6 +111,10 @@ type Mempool interface {
 
 	// TxsBytes returns the total size of all txs in the mempool.
 	TxsBytes() int64
+
+	// TxFirstSeen returns the time the given tx was added to the mempool, or
+	// false if the tx is not in the mempool.
+	TxFirstSeen(tx types.Tx) (time.Time, bool)
 }
 
 //--------------------------------------------------------------------------------
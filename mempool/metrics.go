@@ -18,10 +18,26 @@ const (
 type Metrics struct {
 	// Size of the mempool.
 	Size metrics.Gauge
+	// Size of each mempool shard, if the mempool is sharded.
+	ShardSize metrics.Gauge
+	// Number of txs of each class, if tx classes are configured.
+	TxClassSize metrics.Gauge
 	// Histogram of transaction sizes, in bytes.
 	TxSizeBytes metrics.Histogram
 	// Number of failed transactions.
 	FailedTxs metrics.Counter
+	// Number of transactions rejected for a CheckTx priority below the
+	// minimum.
+	RejectedTxs metrics.Counter
+	// Number of transactions rejected by the encoding limits or the sanity
+	// check of the app, before CheckTx.
+	MalformedTxs metrics.Counter
+	// Number of transactions rejected or removed because their expiration
+	// height or time from CheckTx passed.
+	ExpiredTxs metrics.Counter
+	// Number of CheckTx calls sharing the response of the in-flight CheckTx
+	// of the same tx instead of checking it again.
+	CoalescedCheckTxs metrics.Counter
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
 }
@@ -41,6 +57,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "size",
 			Help:      "Size of the mempool (number of uncommitted transactions).",
 		}, labels).With(labelsAndValues...),
+		ShardSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "shard_size",
+			Help:      "Size of each mempool shard (number of uncommitted transactions).",
+		}, append(labels, "shard")).With(labelsAndValues...),
+		TxClassSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_class_size",
+			Help:      "Number of uncommitted transactions of each class.",
+		}, append(labels, "class")).With(labelsAndValues...),
 		TxSizeBytes: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -54,6 +82,30 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "failed_txs",
 			Help:      "Number of failed transactions.",
 		}, labels).With(labelsAndValues...),
+		RejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_txs",
+			Help:      "Number of transactions rejected for a priority below the minimum.",
+		}, labels).With(labelsAndValues...),
+		MalformedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "malformed_txs",
+			Help:      "Number of transactions rejected by the encoding limits or the sanity check of the app, before CheckTx.",
+		}, labels).With(labelsAndValues...),
+		ExpiredTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "expired_txs",
+			Help:      "Number of transactions rejected or removed because their expiration height or time from CheckTx passed.",
+		}, labels).With(labelsAndValues...),
+		CoalescedCheckTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "coalesced_check_txs",
+			Help:      "Number of CheckTx calls sharing the response of the in-flight CheckTx of the same tx.",
+		}, labels).With(labelsAndValues...),
 		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -66,9 +118,15 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:              discard.NewGauge(),
+		ShardSize:         discard.NewGauge(),
+		TxClassSize:       discard.NewGauge(),
+		TxSizeBytes:       discard.NewHistogram(),
+		FailedTxs:         discard.NewCounter(),
+		RejectedTxs:       discard.NewCounter(),
+		MalformedTxs:      discard.NewCounter(),
+		ExpiredTxs:        discard.NewCounter(),
+		CoalescedCheckTxs: discard.NewCounter(),
+		RecheckTimes:      discard.NewCounter(),
 	}
 }
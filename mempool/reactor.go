@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/clist"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
@@ -67,6 +68,24 @@ type Reactor struct {
 
 	mtx          tmsync.Mutex
 	peerRoutines map[p2p.NodeID]*tmsync.Closer
+	// positions up to which txs were gossiped to disconnected peers
+	gossipCursors map[p2p.NodeID]gossipCursor
+
+	invalidTxsMtx tmsync.Mutex
+	invalidTxs    map[p2p.NodeID]*peerTxStats
+}
+
+// peerTxStats counts the txs received from a peer in the current window of
+// InvalidTxWindow txs.
+type peerTxStats struct {
+	total   int
+	invalid int
+}
+
+// gossipCursor is the last tx gossiped to a peer before it disconnected.
+type gossipCursor struct {
+	key   [TxKeySize]byte
+	saved time.Time
 }
 
 // NewReactor returns a reference to a new reactor.
@@ -88,6 +107,9 @@ func NewReactor(
 		peerUpdates:  peerUpdates,
 		closeCh:      make(chan struct{}),
 		peerRoutines: make(map[p2p.NodeID]*tmsync.Closer),
+
+		gossipCursors: make(map[p2p.NodeID]gossipCursor),
+		invalidTxs:    make(map[p2p.NodeID]*peerTxStats),
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "Mempool", r)
@@ -181,8 +203,18 @@ func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
 		}
 
 		for _, tx := range protoTxs {
-			if err := r.mempool.CheckTx(types.Tx(tx), nil, txInfo); err != nil {
+			cb := func(res *abci.Response) {
+				if checkTx := res.GetCheckTx(); checkTx != nil {
+					r.recordPeerTx(envelope.From, checkTx.Code == abci.CodeTypeOK)
+				}
+			}
+			if err := r.mempool.CheckTx(types.Tx(tx), cb, txInfo); err != nil {
 				logger.Error("checktx failed for tx", "tx", fmt.Sprintf("%X", txID(tx)), "err", err)
+
+				switch err.(type) {
+				case ErrTxTooLarge, ErrPreCheck, ErrTxMalformed:
+					r.recordPeerTx(envelope.From, false)
+				}
 			}
 		}
 
@@ -282,6 +314,10 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	case p2p.PeerStatusDown:
 		r.ids.Reclaim(peerUpdate.NodeID)
 
+		r.invalidTxsMtx.Lock()
+		delete(r.invalidTxs, peerUpdate.NodeID)
+		r.invalidTxsMtx.Unlock()
+
 		// Check if we've started a tx broadcasting goroutine for this peer.
 		// If we have, we signal to terminate the goroutine via the channel's closure.
 		// This will internally decrement the peer waitgroup and remove the peer
@@ -313,12 +349,25 @@ func (r *Reactor) processPeerUpdates() {
 
 func (r *Reactor) broadcastTxRoutine(peerID p2p.NodeID, closer *tmsync.Closer) {
 	peerMempoolID := r.ids.GetForPeer(peerID)
-	var next *clist.CElement
+	// If the peer reconnected, resume after the last tx gossiped to it.
+	next := r.resumeGossip(peerID)
+	resumed := next != nil
+	// the txs of the classes with a gossip priority, gossiped to the peer
+	// before walking the mempool in order
+	var prioritized map[[TxKeySize]byte]bool
+	if !resumed {
+		prioritized = r.gossipPriorityTxs(peerID, peerMempoolID)
+	}
+	// the last tx gossiped to the peer
+	var last *clist.CElement
 
 	// remove the peer ID from the map of routines and mark the waitgroup as done
 	defer func() {
 		r.mtx.Lock()
 		delete(r.peerRoutines, peerID)
+		if last != nil {
+			r.saveGossipCursor(peerID, last.Value.(*mempoolTx).tx)
+		}
 		r.mtx.Unlock()
 
 		r.peerWG.Done()
@@ -369,7 +418,13 @@ func (r *Reactor) broadcastTxRoutine(peerID p2p.NodeID, closer *tmsync.Closer) {
 		// NOTE: Transaction batching was disabled due to:
 		// https://github.com/tendermint/tendermint/issues/5796
 
-		if _, ok := memTx.senders.Load(peerMempoolID); !ok {
+		if resumed {
+			// the tx was gossiped before the peer disconnected
+			resumed = false
+		} else if key := TxKey(memTx.tx); prioritized[key] {
+			// the tx was gossiped by priority
+			delete(prioritized, key)
+		} else if _, ok := memTx.senders.Load(peerMempoolID); !ok {
 			// Send the mempool tx to the corresponding peer. Note, the peer may be
 			// behind and thus would not be able to process the mempool tx correctly.
 			r.mempoolCh.Out <- p2p.Envelope{
@@ -380,6 +435,7 @@ func (r *Reactor) broadcastTxRoutine(peerID p2p.NodeID, closer *tmsync.Closer) {
 			}
 			r.Logger.Debug("gossiped tx to peer", "tx", fmt.Sprintf("%X", txID(memTx.tx)), "peer", peerID)
 		}
+		last = next
 
 		select {
 		case <-next.NextWaitChan():
@@ -398,3 +454,122 @@ func (r *Reactor) broadcastTxRoutine(peerID p2p.NodeID, closer *tmsync.Closer) {
 		}
 	}
 }
+
+// gossipPriorityTxs gossips the txs of the classes with a gossip priority to
+// the new peer, highest priority first, and returns their keys.
+func (r *Reactor) gossipPriorityTxs(peerID p2p.NodeID, peerMempoolID uint16) map[[TxKeySize]byte]bool {
+	memTxs := r.mempool.gossipPriorityTxs()
+	if len(memTxs) == 0 {
+		return nil
+	}
+
+	var peerHeight int64
+	if r.peerMgr != nil {
+		peerHeight = r.peerMgr.GetHeight(peerID)
+	}
+
+	gossiped := make(map[[TxKeySize]byte]bool, len(memTxs))
+	for _, memTx := range memTxs {
+		if peerHeight > 0 && peerHeight < memTx.Height()-1 {
+			// the peer is behind, the tx is gossiped in order once it caught up
+			continue
+		}
+		if _, ok := memTx.senders.Load(peerMempoolID); !ok {
+			r.mempoolCh.Out <- p2p.Envelope{
+				To: peerID,
+				Message: &protomem.Txs{
+					Txs: [][]byte{memTx.tx},
+				},
+			}
+			r.Logger.Debug("gossiped priority tx to peer", "tx", fmt.Sprintf("%X", txID(memTx.tx)), "peer", peerID)
+		}
+		gossiped[TxKey(memTx.tx)] = true
+	}
+	return gossiped
+}
+
+// saveGossipCursor remembers tx as the last tx gossiped to the disconnecting
+// peer, and forgets the cursors of peers which did not reconnect in time.
+//
+// NOTE: requires r.mtx to be locked.
+func (r *Reactor) saveGossipCursor(peerID p2p.NodeID, tx types.Tx) {
+	if r.config.GossipResumeTimeout <= 0 {
+		return
+	}
+
+	now := time.Now()
+	for id, cursor := range r.gossipCursors {
+		if now.Sub(cursor.saved) > r.config.GossipResumeTimeout {
+			delete(r.gossipCursors, id)
+		}
+	}
+	r.gossipCursors[peerID] = gossipCursor{key: TxKey(tx), saved: now}
+}
+
+// resumeGossip returns the last tx gossiped to the peer before it disconnected,
+// if it reconnected in time and the tx is still in the mempool.
+func (r *Reactor) resumeGossip(peerID p2p.NodeID) *clist.CElement {
+	r.mtx.Lock()
+	cursor, ok := r.gossipCursors[peerID]
+	delete(r.gossipCursors, peerID)
+	r.mtx.Unlock()
+
+	if !ok || time.Since(cursor.saved) > r.config.GossipResumeTimeout {
+		return nil
+	}
+
+	e := r.mempool.txElement(cursor.key)
+	if e != nil {
+		r.Logger.Debug("resuming gossip to reconnected peer", "peer", peerID)
+	}
+	return e
+}
+
+// recordPeerTx records whether a tx received from the peer was valid. Once
+// InvalidTxWindow txs were received from the peer, it is reported as bad or
+// disconnected if too many of them were invalid, and a new window starts.
+func (r *Reactor) recordPeerTx(peerID p2p.NodeID, valid bool) {
+	if r.config.InvalidTxWindow <= 0 || peerID == "" {
+		return
+	}
+
+	r.invalidTxsMtx.Lock()
+	stats, ok := r.invalidTxs[peerID]
+	if !ok {
+		stats = &peerTxStats{}
+		r.invalidTxs[peerID] = stats
+	}
+	stats.total++
+	if !valid {
+		stats.invalid++
+	}
+	if stats.total < r.config.InvalidTxWindow {
+		r.invalidTxsMtx.Unlock()
+		return
+	}
+	ratio := float64(stats.invalid) / float64(stats.total)
+	*stats = peerTxStats{}
+	r.invalidTxsMtx.Unlock()
+
+	// Don't block the CheckTx callback on the router.
+	switch {
+	case r.config.InvalidTxRatioDisconnect > 0 && ratio >= r.config.InvalidTxRatioDisconnect:
+		r.Logger.Info("disconnecting peer sending invalid txs", "peer", peerID, "ratio", ratio)
+		go func() {
+			select {
+			case r.mempoolCh.Error <- p2p.PeerError{
+				NodeID: peerID,
+				Err:    fmt.Errorf("%.0f%% of the last txs received were invalid", ratio*100),
+			}:
+			case <-r.closeCh:
+			}
+		}()
+
+	case r.config.InvalidTxRatioBad > 0 && ratio >= r.config.InvalidTxRatioBad:
+		r.Logger.Debug("reporting peer sending invalid txs as bad", "peer", peerID, "ratio", ratio)
+		go r.peerUpdates.SendUpdate(p2p.PeerUpdate{
+			NodeID: peerID,
+			Status: p2p.PeerStatusBad,
+		})
+	}
+}
@@ -395,3 +395,135 @@ func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 		NodeID: secondary,
 	}
 }
+
+func TestReactorResumesGossip(t *testing.T) {
+	config := cfg.TestConfig()
+
+	rts := setup(t, config.Mempool, 2, 0)
+
+	primary := rts.nodes[0]
+	secondary := rts.nodes[1]
+	reactor := rts.reactors[primary]
+
+	rts.start(t)
+
+	txs := checkTxs(t, reactor.mempool, 3, UnknownPeerID)
+	rts.waitForTxns(t, txs, secondary)
+
+	// disconnecting the peer saves the cursor at the last tx gossiped to it
+	rts.peerChans[primary] <- p2p.PeerUpdate{
+		Status: p2p.PeerStatusDown,
+		NodeID: secondary,
+	}
+	require.Eventually(t, func() bool {
+		reactor.mtx.Lock()
+		defer reactor.mtx.Unlock()
+		_, ok := reactor.gossipCursors[secondary]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	reactor.mtx.Lock()
+	require.Equal(t, TxKey(txs[2]), reactor.gossipCursors[secondary].key)
+	reactor.mtx.Unlock()
+
+	// the cursor is used once
+	e := reactor.resumeGossip(secondary)
+	require.NotNil(t, e)
+	require.Equal(t, txs[2], e.Value.(*mempoolTx).tx)
+	require.Nil(t, reactor.resumeGossip(secondary))
+
+	// the cursor is not used if the tx left the mempool
+	reactor.mtx.Lock()
+	reactor.saveGossipCursor(secondary, types.Tx("removed"))
+	reactor.mtx.Unlock()
+	require.Nil(t, reactor.resumeGossip(secondary))
+
+	// the cursor expires
+	reactor.mtx.Lock()
+	reactor.gossipCursors[secondary] = gossipCursor{
+		key:   TxKey(txs[2]),
+		saved: time.Now().Add(-config.Mempool.GossipResumeTimeout - time.Second),
+	}
+	reactor.mtx.Unlock()
+	require.Nil(t, reactor.resumeGossip(secondary))
+}
+
+func TestReactorPenalizesPeersSendingInvalidTxs(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.InvalidTxWindow = 4
+	config.Mempool.InvalidTxRatioBad = 0.5
+	config.Mempool.InvalidTxRatioDisconnect = 0.9
+
+	rts := setup(t, config.Mempool, 2, 0)
+
+	primary := rts.nodes[0]
+	secondary := rts.nodes[1]
+	reactor := rts.reactors[primary]
+	peerManager := rts.network.Nodes[primary].PeerManager
+
+	rts.start(t)
+
+	// raise the score first, since it can't go below 0
+	for i := 0; i < 2; i++ {
+		rts.peerUpdates[primary].SendUpdate(p2p.PeerUpdate{NodeID: secondary, Status: p2p.PeerStatusGood})
+	}
+	require.Eventually(t, func() bool {
+		return peerManager.Scores()[secondary] == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// a few invalid txs are tolerated
+	for _, valid := range []bool{true, true, true, false} {
+		reactor.recordPeerTx(secondary, valid)
+	}
+	// half of the txs being invalid lowers the score
+	for _, valid := range []bool{true, false, true, false} {
+		reactor.recordPeerTx(secondary, valid)
+	}
+	require.Eventually(t, func() bool {
+		return peerManager.Scores()[secondary] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// almost all txs being invalid disconnects the peer
+	sub := peerManager.Subscribe()
+	defer sub.Close()
+	for i := 0; i < 4; i++ {
+		reactor.recordPeerTx(secondary, false)
+	}
+	select {
+	case update := <-sub.Updates():
+		require.Equal(t, p2p.PeerUpdate{NodeID: secondary, Status: p2p.PeerStatusDown}, update)
+	case <-time.After(time.Second):
+		require.Fail(t, "peer was not disconnected")
+	}
+}
+
+func TestReactorNoBroadcastToLaggingPeer(t *testing.T) {
+	numTxs := 10
+	numNodes := 2
+	config := cfg.TestConfig()
+
+	rts := setup(t, config.Mempool, numNodes, 0)
+
+	primary := rts.nodes[0]
+	secondary := rts.nodes[1]
+
+	rts.start(t)
+
+	// the secondary reports a height well behind the txs of the primary
+	peerManager := rts.network.Nodes[primary].PeerManager
+	require.NoError(t, peerManager.SetHeight(secondary, 2))
+
+	mempool := rts.mempools[primary]
+	mempool.Lock()
+	require.NoError(t, mempool.Update(10, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil))
+	mempool.Unlock()
+
+	txs := checkTxs(t, mempool, numTxs, UnknownPeerID)
+
+	time.Sleep(500 * time.Millisecond)
+	require.Zero(t, rts.mempools[secondary].Size())
+
+	// the txs are gossiped once the secondary caught up
+	require.NoError(t, peerManager.SetHeight(secondary, 9))
+	rts.waitForTxns(t, txs, secondary)
+}
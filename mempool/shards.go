@@ -0,0 +1,116 @@
+package mempool
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+// txShards partitions the txs in the mempool into shards by size or gas class,
+// each with its own capacity budget, so that a flood of txs of one class can't
+// crowd out the txs of another. The limits of the whole mempool still apply.
+//
+// Safe for concurrent use by multiple goroutines.
+type txShards struct {
+	byGas    bool
+	bounds   []int64 // inclusive upper bounds of all shards but the last
+	maxTxs   []int
+	maxBytes []int64
+
+	// Atomic integers
+	txs      []int64
+	txsBytes []int64
+}
+
+// newTxShards returns the shards configured in config, or nil if sharding is
+// disabled.
+func newTxShards(config *cfg.MempoolConfig) *txShards {
+	if config.ShardBy == "" || len(config.ShardBounds) == 0 {
+		return nil
+	}
+
+	n := len(config.ShardBounds) + 1
+	s := &txShards{
+		byGas:    config.ShardBy == cfg.MempoolShardByGas,
+		bounds:   config.ShardBounds,
+		maxTxs:   make([]int, n),
+		maxBytes: make([]int64, n),
+		txs:      make([]int64, n),
+		txsBytes: make([]int64, n),
+	}
+	for i := 0; i < n; i++ {
+		capacity := 1 / float64(n)
+		if len(config.ShardCapacities) == n {
+			capacity = config.ShardCapacities[i]
+		}
+		s.maxTxs[i] = int(capacity * float64(config.Size))
+		s.maxBytes[i] = int64(capacity * float64(config.MaxTxsBytes))
+	}
+	return s
+}
+
+// shard returns the shard of a tx with the given size and gas wanted.
+func (s *txShards) shard(txSize int, gasWanted int64) int {
+	class := int64(txSize)
+	if s.byGas {
+		class = gasWanted
+	}
+	return sort.Search(len(s.bounds), func(i int) bool { return s.bounds[i] >= class })
+}
+
+// isFull returns an error if a tx of the given size doesn't fit in the shard.
+func (s *txShards) isFull(shard int, txSize int) error {
+	var (
+		numTxs   = int(atomic.LoadInt64(&s.txs[shard]))
+		txsBytes = atomic.LoadInt64(&s.txsBytes[shard])
+	)
+
+	if numTxs >= s.maxTxs[shard] || int64(txSize)+txsBytes > s.maxBytes[shard] {
+		return ErrMempoolShardIsFull{
+			s.name(shard),
+			numTxs, s.maxTxs[shard],
+			txsBytes, s.maxBytes[shard],
+		}
+	}
+	return nil
+}
+
+// add accounts for a tx of the given size added to the shard.
+func (s *txShards) add(shard int, txSize int) {
+	atomic.AddInt64(&s.txs[shard], 1)
+	atomic.AddInt64(&s.txsBytes[shard], int64(txSize))
+}
+
+// remove accounts for a tx of the given size removed from the shard.
+func (s *txShards) remove(shard int, txSize int) {
+	atomic.AddInt64(&s.txs[shard], -1)
+	atomic.AddInt64(&s.txsBytes[shard], -int64(txSize))
+}
+
+// reset empties all shards.
+func (s *txShards) reset() {
+	for i := range s.txs {
+		atomic.StoreInt64(&s.txs[i], 0)
+		atomic.StoreInt64(&s.txsBytes[i], 0)
+	}
+}
+
+// size returns the number of txs in the shard.
+func (s *txShards) size(shard int) int {
+	return int(atomic.LoadInt64(&s.txs[shard]))
+}
+
+// name returns the name of the shard for errors and metrics, i.e. the range
+// of sizes or gas of its txs, e.g. "0-1024" or "65537+".
+func (s *txShards) name(shard int) string {
+	lower := int64(0)
+	if shard > 0 {
+		lower = s.bounds[shard-1] + 1
+	}
+	if shard == len(s.bounds) {
+		return fmt.Sprintf("%d+", lower)
+	}
+	return fmt.Sprintf("%d-%d", lower, s.bounds[shard])
+}
@@ -0,0 +1,71 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestTxShards(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	require.Nil(t, newTxShards(config))
+
+	config.Size = 100
+	config.MaxTxsBytes = 1000
+	config.ShardBy = cfg.MempoolShardBySize
+	config.ShardBounds = []int64{10, 100}
+	config.ShardCapacities = []float64{0.5, 0.3, 0.2}
+	shards := newTxShards(config)
+	require.NotNil(t, shards)
+
+	testcases := []struct {
+		txSize int
+		shard  int
+	}{
+		{0, 0},
+		{10, 0},
+		{11, 1},
+		{100, 1},
+		{101, 2},
+		{1000, 2},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.shard, shards.shard(tc.txSize, 1), "tx size %v", tc.txSize)
+	}
+	assert.Equal(t, []string{"0-10", "11-100", "101+"},
+		[]string{shards.name(0), shards.name(1), shards.name(2)})
+
+	// the last shard is limited to 20 txs and 200 bytes
+	require.NoError(t, shards.isFull(2, 200))
+	require.Error(t, shards.isFull(2, 201))
+	for i := 0; i < 20; i++ {
+		shards.add(2, 1)
+	}
+	assert.Equal(t, 20, shards.size(2))
+	require.Error(t, shards.isFull(2, 1))
+	require.NoError(t, shards.isFull(1, 1))
+
+	shards.remove(2, 1)
+	require.NoError(t, shards.isFull(2, 1))
+
+	shards.reset()
+	assert.Equal(t, 0, shards.size(2))
+}
+
+func TestTxShards_ByGas(t *testing.T) {
+	config := cfg.TestMempoolConfig()
+	config.ShardBy = cfg.MempoolShardByGas
+	config.ShardBounds = []int64{1000}
+	shards := newTxShards(config)
+
+	// txs are sharded by gas regardless of their size
+	assert.Equal(t, 0, shards.shard(5000, 1000))
+	assert.Equal(t, 1, shards.shard(1, 1001))
+
+	// without capacities, the mempool is split evenly
+	assert.Equal(t, config.Size/2, shards.maxTxs[0])
+	assert.Equal(t, config.MaxTxsBytes/2, shards.maxBytes[1])
+}
@@ -0,0 +1,106 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// maxTxEncodingDepth bounds how deep checkTxEncoding looks into a tx when
+// the nesting depth isn't limited, so that checking a tx stays linear in its
+// size.
+const maxTxEncodingDepth = 100
+
+// Protobuf wire types, but for the deprecated groups.
+const (
+	wireVarint          = 0
+	wireFixed64         = 1
+	wireLengthDelimited = 2
+	wireFixed32         = 5
+)
+
+var errNotProtobuf = errors.New("not a protobuf message")
+
+// checkTxEncoding rejects a protobuf encoded tx whose messages are nested
+// deeper than maxDepth, or which repeat a field more than maxRepeated times in
+// a message, before the app decodes it. 0 disables either limit.
+//
+// Txs are opaque to Tendermint, so the check is a heuristic: a
+// length-delimited field is taken for a nested message if its bytes decode as
+// one, and for bytes or a string otherwise. Txs which aren't protobuf messages
+// pass.
+func checkTxEncoding(tx []byte, maxDepth, maxRepeated int) error {
+	if maxDepth == 0 && maxRepeated == 0 {
+		return nil
+	}
+	err := checkMessageEncoding(tx, 1, maxDepth, maxRepeated)
+	if errors.Is(err, errNotProtobuf) {
+		return nil
+	}
+	return err
+}
+
+// checkMessageEncoding checks the message encoded in b at the given depth. It
+// returns errNotProtobuf if b isn't a protobuf message.
+func checkMessageEncoding(b []byte, depth, maxDepth, maxRepeated int) error {
+	// the messages within b, checked once b turns out to be a message
+	var nested [][]byte
+	repeated := make(map[uint64]int)
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errNotProtobuf
+		}
+		b = b[n:]
+		field, wireType := key>>3, key&7
+		if field == 0 {
+			return errNotProtobuf
+		}
+
+		switch wireType {
+		case wireVarint:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return errNotProtobuf
+			}
+		case wireFixed64:
+			n = 8
+		case wireFixed32:
+			n = 4
+		case wireLengthDelimited:
+			size, m := binary.Uvarint(b)
+			if m <= 0 || size > uint64(len(b)-m) {
+				return errNotProtobuf
+			}
+			if size > 0 {
+				nested = append(nested, b[m:m+int(size)])
+			}
+			n = m + int(size)
+		default:
+			// groups are deprecated, and no app encodes txs with them
+			return errNotProtobuf
+		}
+		if n > len(b) {
+			return errNotProtobuf
+		}
+		b = b[n:]
+
+		repeated[field]++
+		if maxRepeated > 0 && repeated[field] > maxRepeated {
+			return fmt.Errorf("field %d is repeated more than %d times at depth %d", field, maxRepeated, depth)
+		}
+	}
+
+	if maxDepth > 0 && depth > maxDepth {
+		return fmt.Errorf("messages are nested deeper than %d", maxDepth)
+	}
+	if depth == maxTxEncodingDepth {
+		return nil
+	}
+	for _, msg := range nested {
+		err := checkMessageEncoding(msg, depth+1, maxDepth, maxRepeated)
+		if err != nil && !errors.Is(err, errNotProtobuf) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// protoField encodes a length-delimited protobuf field.
+func protoField(field uint64, value []byte) []byte {
+	b := make([]byte, 2*binary.MaxVarintLen64, 2*binary.MaxVarintLen64+len(value))
+	n := binary.PutUvarint(b, field<<3|wireLengthDelimited)
+	n += binary.PutUvarint(b[n:], uint64(len(value)))
+	return append(b[:n], value...)
+}
+
+// nestedTx returns a tx of messages nested depth deep.
+func nestedTx(depth int) []byte {
+	msg := []byte{1<<3 | wireVarint, 42}
+	for i := 1; i < depth; i++ {
+		msg = protoField(1, msg)
+	}
+	return msg
+}
+
+// repeatedTx returns a tx repeating a field n times.
+func repeatedTx(n int) []byte {
+	var msg []byte
+	for i := 0; i < n; i++ {
+		msg = append(msg, protoField(2, []byte("value"))...)
+	}
+	return msg
+}
+
+func TestCheckTxEncoding(t *testing.T) {
+	testcases := map[string]struct {
+		tx          []byte
+		maxDepth    int
+		maxRepeated int
+		expectErr   bool
+	}{
+		"no limits":               {nestedTx(50), 0, 0, false},
+		"depth within limit":      {nestedTx(5), 5, 0, false},
+		"depth exceeding limit":   {nestedTx(6), 5, 0, true},
+		"depth beyond lookahead":  {nestedTx(maxTxEncodingDepth + 10), 0, 1, false},
+		"repeated within limit":   {repeatedTx(3), 0, 3, false},
+		"repeated exceeding":      {repeatedTx(4), 0, 3, true},
+		"repeated in nested":      {protoField(1, repeatedTx(4)), 0, 3, true},
+		"bytes aren't nested":     {protoField(1, []byte("key=value")), 1, 0, false},
+		"empty bytes":             {protoField(1, nil), 1, 0, false},
+		"not protobuf":            {[]byte("key=value"), 1, 1, false},
+		"truncated length prefix": {protoField(1, nestedTx(3))[:4], 1, 0, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := checkTxEncoding(tc.tx, tc.maxDepth, tc.maxRepeated)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMempool_TxEncodingLimits(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.MaxTxDepth = 3
+	config.Mempool.MaxTxRepeatedFields = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewApplication()), config)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx(types.Tx(nestedTx(3)), nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(types.Tx(repeatedTx(2)), nil, TxInfo{}))
+	assert.IsType(t, ErrTxMalformed{}, mempool.CheckTx(types.Tx(nestedTx(4)), nil, TxInfo{}))
+	assert.IsType(t, ErrTxMalformed{}, mempool.CheckTx(types.Tx(repeatedTx(3)), nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+}
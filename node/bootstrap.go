@@ -0,0 +1,240 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// chainRegistryTimeout is the max time to fetch the entry and the genesis
+	// file from the chain registry.
+	chainRegistryTimeout = 5 * time.Minute
+
+	// maxChainRegistryEntrySize is the max size of a chain registry entry.
+	maxChainRegistryEntrySize = 1 << 20 // 1MB
+)
+
+// ChainRegistryEntry describes how to join a network. A chain registry serves
+// the entry of each chain at <registry>/<chain-id>.json, as a
+// SignedChainRegistryEntry.
+type ChainRegistryEntry struct {
+	ChainID string `json:"chain_id"`
+	// SHA-256 hash of the canonical JSON of the genesis doc, as returned by
+	// types.GenesisDocHash.
+	GenesisHash tmbytes.HexBytes `json:"genesis_hash"`
+	// URL of the genesis file, either absolute or relative to the entry.
+	GenesisURL      string   `json:"genesis_url"`
+	Seeds           []string `json:"seeds"`
+	PersistentPeers []string `json:"persistent_peers"`
+}
+
+// SignedChainRegistryEntry is a ChainRegistryEntry signed by the registry.
+type SignedChainRegistryEntry struct {
+	// JSON of the ChainRegistryEntry. The signature is over these exact bytes.
+	Entry json.RawMessage `json:"entry"`
+	// ed25519 signature of Entry by the registry key
+	Signature []byte `json:"signature"`
+}
+
+// BootstrapFromChainRegistry fetches the entry of the configured chain from
+// the chain registry, if any, and verifies the registry's signature of it. If
+// the genesis file doesn't exist yet, it's downloaded from the entry's genesis
+// URL; either way it must match the entry's genesis hash. The entry's seeds
+// and persistent peers are added to the config.
+//
+// If the node already has a genesis file, it has joined the network before and
+// can start without the registry, so an unreachable registry is only logged.
+func BootstrapFromChainRegistry(config *cfg.Config, logger log.Logger) error {
+	if config.ChainRegistry == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), chainRegistryTimeout)
+	defer cancel()
+
+	genFile := config.GenesisFile()
+	haveGenesis := tmos.FileExists(genFile)
+
+	entry, entryURL, err := fetchChainRegistryEntry(ctx, config)
+	if err != nil {
+		if haveGenesis {
+			logger.Error("failed to fetch the chain registry entry, starting with the configured peers",
+				"registry", config.ChainRegistry, "err", err)
+			return nil
+		}
+		return fmt.Errorf("failed to fetch the chain registry entry: %w", err)
+	}
+
+	if haveGenesis {
+		genDocJSON, err := ioutil.ReadFile(genFile)
+		if err != nil {
+			return fmt.Errorf("can't read genesis file: %w", err)
+		}
+		if err := verifyRegistryGenesis(genDocJSON, entry); err != nil {
+			return fmt.Errorf("genesis file %s doesn't match the chain registry: %w", genFile, err)
+		}
+	} else {
+		genDocJSON, err := fetchRegistryGenesis(ctx, entryURL, entry)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the genesis file from the chain registry: %w", err)
+		}
+		if err := tmos.EnsureDir(filepath.Dir(genFile), 0700); err != nil {
+			return err
+		}
+		if err := tempfile.WriteFileAtomic(genFile, genDocJSON, 0644); err != nil {
+			return fmt.Errorf("can't write genesis file: %w", err)
+		}
+		logger.Info("Downloaded genesis file from the chain registry",
+			"path", genFile, "hash", entry.GenesisHash)
+	}
+
+	config.P2P.Seeds = mergePeers(config.P2P.Seeds, entry.Seeds)
+	config.P2P.PersistentPeers = mergePeers(config.P2P.PersistentPeers, entry.PersistentPeers)
+	logger.Info("Bootstrapped from the chain registry", "chainID", entry.ChainID,
+		"seeds", len(entry.Seeds), "persistentPeers", len(entry.PersistentPeers))
+	return nil
+}
+
+// fetchChainRegistryEntry fetches and verifies the entry of the configured
+// chain, and returns it along with its URL.
+func fetchChainRegistryEntry(ctx context.Context, config *cfg.Config) (*ChainRegistryEntry, string, error) {
+	entryURL := strings.TrimSuffix(config.ChainRegistry, "/") + "/" +
+		url.PathEscape(config.ChainRegistryChainID) + ".json"
+	bz, err := httpGet(ctx, entryURL, maxChainRegistryEntrySize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var signed SignedChainRegistryEntry
+	if err := json.Unmarshal(bz, &signed); err != nil {
+		return nil, "", fmt.Errorf("invalid chain registry entry: %w", err)
+	}
+	pubKey, err := hex.DecodeString(config.ChainRegistryPubKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid chain registry pubkey: %w", err)
+	}
+	if !ed25519.PubKey(pubKey).VerifySignature(signed.Entry, signed.Signature) {
+		return nil, "", errors.New("invalid chain registry signature")
+	}
+
+	var entry ChainRegistryEntry
+	if err := json.Unmarshal(signed.Entry, &entry); err != nil {
+		return nil, "", fmt.Errorf("invalid chain registry entry: %w", err)
+	}
+	// The chain ID is checked so that the registry's signed entry of another
+	// chain can't be served instead.
+	if entry.ChainID != config.ChainRegistryChainID {
+		return nil, "", fmt.Errorf("chain registry returned the entry of chain %q instead of %q",
+			entry.ChainID, config.ChainRegistryChainID)
+	}
+	if len(entry.GenesisHash) != tmhash.Size {
+		return nil, "", fmt.Errorf("chain registry entry has an invalid genesis hash %X", entry.GenesisHash)
+	}
+	if entry.GenesisURL == "" {
+		return nil, "", errors.New("chain registry entry has no genesis URL")
+	}
+	return &entry, entryURL, nil
+}
+
+// fetchRegistryGenesis downloads the genesis file of the entry and verifies it.
+func fetchRegistryGenesis(ctx context.Context, entryURL string, entry *ChainRegistryEntry) ([]byte, error) {
+	base, err := url.Parse(entryURL)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := url.Parse(entry.GenesisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis URL: %w", err)
+	}
+	genDocJSON, err := httpGet(ctx, base.ResolveReference(ref).String(), -1)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyRegistryGenesis(genDocJSON, entry); err != nil {
+		return nil, err
+	}
+	return genDocJSON, nil
+}
+
+// verifyRegistryGenesis checks that the genesis doc is valid, and matches the
+// chain ID and the genesis hash of the entry.
+func verifyRegistryGenesis(genDocJSON []byte, entry *ChainRegistryEntry) error {
+	hash, err := types.GenesisDocHash(genDocJSON)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, entry.GenesisHash) {
+		return fmt.Errorf("genesis hash %X doesn't match the expected hash %X", hash, entry.GenesisHash)
+	}
+	genDoc, err := types.GenesisDocFromJSON(genDocJSON)
+	if err != nil {
+		return err
+	}
+	if genDoc.ChainID != entry.ChainID {
+		return fmt.Errorf("genesis is for chain %q, expected %q", genDoc.ChainID, entry.ChainID)
+	}
+	return nil
+}
+
+// httpGet fetches the body of the URL, which must be at most limit bytes
+// unless limit is negative.
+func httpGet(ctx context.Context, rawURL string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+
+	var body io.Reader = resp.Body
+	if limit >= 0 {
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+	bz, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if limit >= 0 && int64(len(bz)) > limit {
+		return nil, fmt.Errorf("GET %s: response exceeds %d bytes", rawURL, limit)
+	}
+	return bz, nil
+}
+
+// mergePeers adds the peers to the comma-separated list of peers, skipping the
+// ones already in it.
+func mergePeers(list string, peers []string) string {
+	merged := tmstrings.SplitAndTrimEmpty(list, ",", " ")
+	for _, peer := range peers {
+		peer = strings.TrimSpace(peer)
+		if peer != "" && !tmstrings.StringInSlice(peer, merged) {
+			merged = append(merged, peer)
+		}
+	}
+	return strings.Join(merged, ",")
+}
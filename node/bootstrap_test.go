@@ -0,0 +1,153 @@
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+const registryChainID = "registry-chain"
+
+// startChainRegistry starts a chain registry serving the entry, signed with
+// privKey, as the entry of registryChainID, and the genesis file.
+func startChainRegistry(
+	t *testing.T,
+	privKey ed25519.PrivKey,
+	entry ChainRegistryEntry,
+	genDocJSON []byte,
+) *httptest.Server {
+	entryJSON, err := json.Marshal(entry)
+	require.NoError(t, err)
+	sig, err := privKey.Sign(entryJSON)
+	require.NoError(t, err)
+	signedJSON, err := json.Marshal(SignedChainRegistryEntry{Entry: entryJSON, Signature: sig})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/registry/"+registryChainID+".json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(signedJSON)
+	})
+	mux.HandleFunc("/registry/genesis.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(genDocJSON)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBootstrapFromChainRegistry(t *testing.T) {
+	const chainID = registryChainID
+	genDoc := types.GenesisDoc{
+		ChainID:         chainID,
+		GenesisTime:     time.Now().UTC().Truncate(time.Second),
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	genDocJSON, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	genesisHash, err := types.GenesisDocHash(genDocJSON)
+	require.NoError(t, err)
+
+	registryKey := ed25519.GenPrivKey()
+	entry := ChainRegistryEntry{
+		ChainID:         chainID,
+		GenesisHash:     genesisHash,
+		GenesisURL:      "genesis.json",
+		Seeds:           []string{"seed@127.0.0.1:26656"},
+		PersistentPeers: []string{"peer1@127.0.0.1:26656", "peer2@127.0.0.1:26656"},
+	}
+
+	makeConfig := func(t *testing.T, registryURL string) *cfg.Config {
+		config := cfg.ResetTestRoot("node_bootstrap_test")
+		t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+		require.NoError(t, os.Remove(config.GenesisFile()))
+		config.ChainRegistry = registryURL + "/registry/"
+		config.ChainRegistryChainID = chainID
+		config.ChainRegistryPubKey = hex.EncodeToString(registryKey.PubKey().Bytes())
+		config.P2P.PersistentPeers = "peer1@127.0.0.1:26656,peer3@127.0.0.1:26656"
+		return config
+	}
+
+	t.Run("downloads genesis and adds peers", func(t *testing.T) {
+		srv := startChainRegistry(t, registryKey, entry, genDocJSON)
+		config := makeConfig(t, srv.URL)
+
+		require.NoError(t, BootstrapFromChainRegistry(config, log.TestingLogger()))
+		bz, err := ioutil.ReadFile(config.GenesisFile())
+		require.NoError(t, err)
+		assert.Equal(t, genDocJSON, bz)
+		assert.Equal(t, "seed@127.0.0.1:26656", config.P2P.Seeds)
+		assert.Equal(t, "peer1@127.0.0.1:26656,peer3@127.0.0.1:26656,peer2@127.0.0.1:26656",
+			config.P2P.PersistentPeers)
+
+		// Restarting verifies the existing genesis file.
+		require.NoError(t, BootstrapFromChainRegistry(config, log.TestingLogger()))
+	})
+
+	t.Run("rejects entries not signed by the registry key", func(t *testing.T) {
+		srv := startChainRegistry(t, ed25519.GenPrivKey(), entry, genDocJSON)
+		config := makeConfig(t, srv.URL)
+
+		err := BootstrapFromChainRegistry(config, log.TestingLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid chain registry signature")
+		assert.NoFileExists(t, config.GenesisFile())
+	})
+
+	t.Run("rejects entries of another chain", func(t *testing.T) {
+		other := entry
+		other.ChainID = "other-chain"
+		srv := startChainRegistry(t, registryKey, other, genDocJSON)
+		config := makeConfig(t, srv.URL)
+
+		err := BootstrapFromChainRegistry(config, log.TestingLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "instead of")
+		assert.NoFileExists(t, config.GenesisFile())
+	})
+
+	t.Run("rejects genesis files not matching the hash", func(t *testing.T) {
+		tampered := genDoc
+		tampered.InitialHeight = 2
+		tamperedJSON, err := tmjson.Marshal(tampered)
+		require.NoError(t, err)
+		srv := startChainRegistry(t, registryKey, entry, tamperedJSON)
+		config := makeConfig(t, srv.URL)
+
+		err = BootstrapFromChainRegistry(config, log.TestingLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't match the expected hash")
+		assert.NoFileExists(t, config.GenesisFile())
+
+		// An existing genesis file must match the hash too.
+		require.NoError(t, ioutil.WriteFile(config.GenesisFile(), tamperedJSON, 0644))
+		err = BootstrapFromChainRegistry(config, log.TestingLogger())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't match the chain registry")
+	})
+
+	t.Run("starts without the registry once joined", func(t *testing.T) {
+		srv := startChainRegistry(t, registryKey, entry, genDocJSON)
+		config := makeConfig(t, srv.URL)
+		srv.Close()
+
+		require.Error(t, BootstrapFromChainRegistry(config, log.TestingLogger()))
+
+		require.NoError(t, ioutil.WriteFile(config.GenesisFile(), genDocJSON, 0644))
+		require.NoError(t, BootstrapFromChainRegistry(config, log.TestingLogger()))
+		assert.Equal(t, "peer1@127.0.0.1:26656,peer3@127.0.0.1:26656", config.P2P.PersistentPeers)
+	})
+}
@@ -0,0 +1,374 @@
+package node
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
+	bcv2 "github.com/tendermint/tendermint/blockchain/v2"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/statesync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Names of the built-in reactors which can be disabled or replaced with a
+// Builder. They are also the names of the reactors on the legacy switch.
+const (
+	ReactorMempool   = "MEMPOOL"
+	ReactorBlockSync = "BLOCKCHAIN"
+	ReactorStateSync = "STATESYNC"
+	ReactorEvidence  = "EVIDENCE"
+	ReactorPEX       = "PEX"
+)
+
+var builderReactors = []string{
+	ReactorMempool, ReactorBlockSync, ReactorStateSync, ReactorEvidence, ReactorPEX,
+}
+
+// Builder builds a Node, with the defaults of DefaultNewNode for anything not
+// set. Chains use it to customize the node, e.g. to disable the built-in
+// reactors they don't need, replace them with their own or add their own
+// gossip protocols, without forking NewNode:
+//
+//	n, err := node.NewBuilder(config, logger).
+//		WithClientCreator(proxy.NewLocalClientCreator(app)).
+//		DisableReactor(node.ReactorStateSync).
+//		ReplaceReactor(node.ReactorMempool, customMempoolReactor).
+//		AddAppReactor("ORDERBOOK", orderBookReactor, p2p.AppChannel{
+//			ID: 0x80, MinVersion: 1, MaxVersion: 2,
+//		}).
+//		Build()
+type Builder struct {
+	config             *cfg.Config
+	logger             log.Logger
+	privValidator      types.PrivValidator
+	nodeKey            *p2p.NodeKey
+	clientCreator      proxy.ClientCreator
+	genesisDocProvider GenesisDocProvider
+	dbProvider         DBProvider
+	metricsProvider    MetricsProvider
+	reactors           reactorOverrides
+	options            []Option
+}
+
+// NewBuilder returns a builder of a node with the given config.
+func NewBuilder(config *cfg.Config, logger log.Logger) *Builder {
+	return &Builder{
+		config: config,
+		logger: logger,
+		reactors: reactorOverrides{
+			disabled: make(map[string]bool),
+			replaced: make(map[string]p2p.Reactor),
+		},
+	}
+}
+
+// WithPrivValidator sets the private validator. Defaults to the file private
+// validator of the config, in validator mode.
+func (b *Builder) WithPrivValidator(privValidator types.PrivValidator) *Builder {
+	b.privValidator = privValidator
+	return b
+}
+
+// WithNodeKey sets the node key. Defaults to the node key file of the config,
+// which is generated if it doesn't exist.
+func (b *Builder) WithNodeKey(nodeKey p2p.NodeKey) *Builder {
+	b.nodeKey = &nodeKey
+	return b
+}
+
+// WithClientCreator sets the creator of the ABCI clients, e.g. of an
+// in-process app. Defaults to the proxy-app of the config.
+func (b *Builder) WithClientCreator(clientCreator proxy.ClientCreator) *Builder {
+	b.clientCreator = clientCreator
+	return b
+}
+
+// WithGenesisDocProvider sets the genesis doc provider. Defaults to the
+// genesis file of the config.
+func (b *Builder) WithGenesisDocProvider(genesisDocProvider GenesisDocProvider) *Builder {
+	b.genesisDocProvider = genesisDocProvider
+	return b
+}
+
+// WithDBProvider sets the database provider. Defaults to DefaultDBProvider.
+func (b *Builder) WithDBProvider(dbProvider DBProvider) *Builder {
+	b.dbProvider = dbProvider
+	return b
+}
+
+// WithMetricsProvider sets the metrics provider. Defaults to
+// DefaultMetricsProvider.
+func (b *Builder) WithMetricsProvider(metricsProvider MetricsProvider) *Builder {
+	b.metricsProvider = metricsProvider
+	return b
+}
+
+// WithOptions adds options, which are applied to the node once built.
+func (b *Builder) WithOptions(options ...Option) *Builder {
+	b.options = append(b.options, options...)
+	return b
+}
+
+// DisableReactor disables one of the built-in reactors, e.g. ReactorPEX. The
+// node doesn't start it, nor advertise its channels to peers. Disabling the
+// block sync or the state sync reactor disables fast sync or state sync.
+// The pools the reactors gossip, i.e. the mempool and the evidence pool, are
+// still used by consensus.
+func (b *Builder) DisableReactor(name string) *Builder {
+	b.reactors.disabled[name] = true
+	return b
+}
+
+// ReplaceReactor replaces one of the built-in reactors with a custom one,
+// which the node starts instead. Replacing reactors requires the legacy p2p
+// stack.
+func (b *Builder) ReplaceReactor(name string, reactor p2p.Reactor) *Builder {
+	b.reactors.replaced[name] = reactor
+	return b
+}
+
+// AddAppReactor adds a reactor of the application, e.g. for gossiping an order
+// book, which the node starts along with the built-in reactors. Its channels
+// must be in the range reserved for app reactors, from p2p.MinAppChannelID to
+// p2p.MaxAppChannelID, and each must be given with the versions of its
+// protocol the node speaks. The node only uses a channel with the peers which
+// speak a common version of it; the reactor gets the version to use with a
+// peer with AppChannel.NegotiateVersion. Adding reactors requires the legacy
+// p2p stack.
+func (b *Builder) AddAppReactor(name string, reactor p2p.Reactor, channels ...p2p.AppChannel) *Builder {
+	b.reactors.apps = append(b.reactors.apps, appReactor{
+		name:     name,
+		reactor:  reactor,
+		channels: channels,
+	})
+	return b
+}
+
+// Build builds the node.
+func (b *Builder) Build() (*Node, error) {
+	for name := range b.reactors.disabled {
+		if !isBuilderReactor(name) {
+			return nil, fmt.Errorf("unknown reactor %q", name)
+		}
+	}
+	for name := range b.reactors.replaced {
+		if !isBuilderReactor(name) {
+			return nil, fmt.Errorf("unknown reactor %q", name)
+		}
+	}
+	if err := b.reactors.validateApps(); err != nil {
+		return nil, err
+	}
+	overridden := len(b.reactors.disabled) > 0 || len(b.reactors.replaced) > 0 || len(b.reactors.apps) > 0
+	if len(b.reactors.replaced) > 0 && b.config.P2P.DisableLegacy {
+		return nil, errors.New("replacing reactors requires the legacy p2p stack")
+	}
+	if len(b.reactors.apps) > 0 && b.config.P2P.DisableLegacy {
+		return nil, errors.New("adding app reactors requires the legacy p2p stack")
+	}
+
+	var nodeKey p2p.NodeKey
+	if b.nodeKey != nil {
+		nodeKey = *b.nodeKey
+	} else {
+		var err error
+		nodeKey, err = p2p.LoadOrGenNodeKey(b.config.NodeKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load or gen node key %s: %w", b.config.NodeKeyFile(), err)
+		}
+	}
+	dbProvider := b.dbProvider
+	if dbProvider == nil {
+		dbProvider = DefaultDBProvider
+	}
+	genesisDocProvider := b.genesisDocProvider
+	if genesisDocProvider == nil {
+		genesisDocProvider = DefaultGenesisDocProviderFunc(b.config)
+	}
+
+	switch b.config.Mode {
+	case cfg.ModeSeed, cfg.ModeHeader:
+		if overridden {
+			return nil, fmt.Errorf("can't disable or replace reactors in %s mode", b.config.Mode)
+		}
+		if b.config.Mode == cfg.ModeSeed {
+			return NewSeedNode(b.config, dbProvider, nodeKey, genesisDocProvider, b.logger, b.options...)
+		}
+		return NewHeaderNode(b.config, dbProvider, nodeKey, genesisDocProvider, b.logger, b.options...)
+	}
+
+	privValidator := b.privValidator
+	if privValidator == nil && b.config.Mode == cfg.ModeValidator {
+		pval, err := privval.LoadOrGenFilePV(b.config.PrivValidatorKeyFile(), b.config.PrivValidatorStateFile())
+		if err != nil {
+			return nil, err
+		}
+		privValidator = pval
+	}
+	clientCreator := b.clientCreator
+	if clientCreator == nil {
+		clientCreator, _ = proxy.DefaultClientCreator(b.config.ProxyApp, b.config.ABCI, b.config.DBDir())
+	}
+	metricsProvider := b.metricsProvider
+	if metricsProvider == nil {
+		metricsProvider = DefaultMetricsProvider(b.config.Instrumentation)
+	}
+
+	options := b.options
+	if len(b.reactors.replaced) > 0 {
+		options = append([]Option{CustomReactors(b.reactors.replaced)}, options...)
+	}
+	return makeNode(b.config, privValidator, nodeKey, clientCreator, genesisDocProvider,
+		dbProvider, metricsProvider, b.logger, b.reactors, options...)
+}
+
+func isBuilderReactor(name string) bool {
+	for _, r := range builderReactors {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// reactorOverrides are the built-in reactors disabled or replaced with a
+// Builder, by name, and the app reactors added with it.
+type reactorOverrides struct {
+	disabled map[string]bool
+	replaced map[string]p2p.Reactor
+	apps     []appReactor
+}
+
+// appReactor is a reactor of the application, with the versions of its
+// channels.
+type appReactor struct {
+	name     string
+	reactor  p2p.Reactor
+	channels []p2p.AppChannel
+}
+
+// validateApps checks that the app reactors have unique names, and channels
+// in the reserved range which aren't used by another app reactor and have
+// versions.
+func (o reactorOverrides) validateApps() error {
+	names := make(map[string]bool)
+	used := make(map[p2p.ChannelID]string)
+	for _, app := range o.apps {
+		if isBuilderReactor(app.name) || app.name == "CONSENSUS" || app.name == "METADATA" {
+			return fmt.Errorf("app reactor %q has the name of a built-in reactor", app.name)
+		}
+		if names[app.name] {
+			return fmt.Errorf("duplicate app reactor %q", app.name)
+		}
+		names[app.name] = true
+
+		versions := make(map[p2p.ChannelID]bool)
+		for _, c := range app.channels {
+			if err := c.Validate(); err != nil {
+				return fmt.Errorf("app reactor %q: %w", app.name, err)
+			}
+			versions[c.ID] = true
+		}
+		descs := app.reactor.GetChannels()
+		if len(descs) != len(versions) {
+			return fmt.Errorf("app reactor %q has %d channels, but versions of %d",
+				app.name, len(descs), len(versions))
+		}
+		for _, desc := range descs {
+			id := p2p.ChannelID(desc.ID)
+			if !versions[id] {
+				return fmt.Errorf("app reactor %q has no versions of channel %#x", app.name, desc.ID)
+			}
+			if other, ok := used[id]; ok {
+				return fmt.Errorf("app reactors %q and %q both use channel %#x", other, app.name, desc.ID)
+			}
+			used[id] = app.name
+		}
+	}
+	return nil
+}
+
+// appChannels returns the channels of the app reactors.
+func (o reactorOverrides) appChannels() []p2p.AppChannel {
+	var channels []p2p.AppChannel
+	for _, app := range o.apps {
+		channels = append(channels, app.channels...)
+	}
+	return channels
+}
+
+// active returns true if the node runs the built-in reactor.
+func (o reactorOverrides) active(name string) bool {
+	return !o.disabled[name] && o.replaced[name] == nil
+}
+
+// isDisabled returns true if the reactor is disabled and not replaced, so
+// that none of its channels are used.
+func (o reactorOverrides) isDisabled(name string) bool {
+	return o.disabled[name] && o.replaced[name] == nil
+}
+
+// withoutDisabledChannels returns the channels without the ones of the
+// disabled reactors.
+func (o reactorOverrides) withoutDisabledChannels(channels []byte) []byte {
+	var disabled []byte
+	for name := range o.disabled {
+		if o.isDisabled(name) {
+			disabled = append(disabled, reactorChannelIDs(name)...)
+		}
+	}
+	filtered := make([]byte, 0, len(channels))
+	for _, ch := range channels {
+		if !bytes.Contains(disabled, []byte{ch}) {
+			filtered = append(filtered, ch)
+		}
+	}
+	return filtered
+}
+
+// reactorChannelIDs returns the IDs of the channels of a built-in reactor.
+func reactorChannelIDs(name string) []byte {
+	switch name {
+	case ReactorMempool:
+		return []byte{byte(mempl.MempoolChannel)}
+	case ReactorBlockSync:
+		return []byte{byte(bcv0.BlockchainChannel), bcv2.BlockchainChannel}
+	case ReactorStateSync:
+		return []byte{byte(statesync.SnapshotChannel), byte(statesync.ChunkChannel)}
+	case ReactorEvidence:
+		return []byte{byte(evidence.EvidenceChannel)}
+	case ReactorPEX:
+		return []byte{pex.PexChannel}
+	default:
+		return nil
+	}
+}
+
+// disabledReactor takes the place of a disabled reactor on the legacy switch.
+// It drops the messages of the reactor's channels, which the node doesn't
+// advertise, so that peers sending them anyway aren't disconnected for using
+// an unknown channel.
+type disabledReactor struct {
+	p2p.BaseReactor
+	channels []*p2p.ChannelDescriptor
+}
+
+func newDisabledReactor(name string, channels []*p2p.ChannelDescriptor) *disabledReactor {
+	r := &disabledReactor{channels: channels}
+	r.BaseReactor = *p2p.NewBaseReactor(name, r)
+	return r
+}
+
+// GetChannels implements p2p.Reactor.
+func (r *disabledReactor) GetChannels() []*p2p.ChannelDescriptor {
+	return r.channels
+}
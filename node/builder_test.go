@@ -0,0 +1,133 @@
+package node
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	p2pmock "github.com/tendermint/tendermint/p2p/mock"
+	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/statesync"
+)
+
+func TestBuilderDisableReactors(t *testing.T) {
+	config := cfg.ResetTestRoot("node_builder_disable_test")
+	defer os.RemoveAll(config.RootDir)
+	config.StateSync.Enable = true
+
+	n, err := NewBuilder(config, log.TestingLogger()).
+		DisableReactor(ReactorStateSync).
+		DisableReactor(ReactorPEX).
+		DisableReactor(ReactorMempool).
+		Build()
+	require.NoError(t, err)
+	assert.False(t, n.stateSync, "state sync must be disabled along with its reactor")
+	assert.Nil(t, n.pexReactor)
+
+	channels := n.nodeInfo.Channels
+	for _, ch := range []byte{pex.PexChannel, byte(mempl.MempoolChannel), byte(statesync.SnapshotChannel)} {
+		assert.NotContains(t, channels, ch)
+	}
+	assert.Contains(t, channels, byte(evidence.EvidenceChannel))
+
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, n.evidenceReactor.IsRunning())
+	assert.False(t, n.mempoolReactor.IsRunning())
+	assert.False(t, n.stateSyncReactor.IsRunning())
+	assert.IsType(t, &disabledReactor{}, n.Switch().Reactor(ReactorMempool))
+	assert.IsType(t, &disabledReactor{}, n.Switch().Reactor(ReactorPEX))
+}
+
+func TestBuilderReplaceReactor(t *testing.T) {
+	config := cfg.ResetTestRoot("node_builder_replace_test")
+	defer os.RemoveAll(config.RootDir)
+
+	mempoolReactor := p2pmock.NewReactor()
+	n, err := NewBuilder(config, log.TestingLogger()).
+		ReplaceReactor(ReactorMempool, mempoolReactor).
+		Build()
+	require.NoError(t, err)
+
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	assert.True(t, mempoolReactor.IsRunning())
+	assert.Equal(t, mempoolReactor, n.Switch().Reactor(ReactorMempool))
+	assert.False(t, n.mempoolReactor.IsRunning())
+}
+
+func TestBuilderErrors(t *testing.T) {
+	config := cfg.ResetTestRoot("node_builder_errors_test")
+	defer os.RemoveAll(config.RootDir)
+
+	_, err := NewBuilder(config, log.TestingLogger()).DisableReactor("CONSENSUS").Build()
+	assert.Error(t, err)
+
+	config.P2P.DisableLegacy = true
+	_, err = NewBuilder(config, log.TestingLogger()).
+		ReplaceReactor(ReactorMempool, p2pmock.NewReactor()).
+		Build()
+	assert.Error(t, err)
+
+	config.P2P.DisableLegacy = false
+	config.Mode = cfg.ModeSeed
+	_, err = NewBuilder(config, log.TestingLogger()).DisableReactor(ReactorPEX).Build()
+	assert.Error(t, err)
+}
+
+func TestBuilderAddAppReactor(t *testing.T) {
+	config := cfg.ResetTestRoot("node_builder_app_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+
+	appChannel := p2p.AppChannel{ID: p2p.MinAppChannelID, MinVersion: 1, MaxVersion: 2}
+	reactor := newDisabledReactor("ORDERBOOK", []*p2p.ChannelDescriptor{{ID: byte(appChannel.ID)}})
+	n, err := NewBuilder(config, log.TestingLogger()).
+		AddAppReactor("ORDERBOOK", reactor, appChannel).
+		Build()
+	require.NoError(t, err)
+	assert.Contains(t, n.nodeInfo.Channels, byte(appChannel.ID))
+	assert.Equal(t, []p2p.AppChannel{appChannel}, n.nodeInfo.AppChannels)
+	require.NoError(t, n.nodeInfo.Validate())
+
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+	assert.True(t, reactor.IsRunning())
+	assert.Equal(t, reactor, n.Switch().Reactor("ORDERBOOK"))
+}
+
+func TestBuilderAddAppReactorErrors(t *testing.T) {
+	config := cfg.ResetTestRoot("node_builder_app_reactor_errors_test")
+	defer os.RemoveAll(config.RootDir)
+
+	appChannel := p2p.AppChannel{ID: p2p.MinAppChannelID, MinVersion: 1, MaxVersion: 1}
+	newReactor := func(id byte) p2p.Reactor {
+		return newDisabledReactor("APP", []*p2p.ChannelDescriptor{{ID: id}})
+	}
+	testCases := map[string]*Builder{
+		"built-in name": NewBuilder(config, log.TestingLogger()).
+			AddAppReactor(ReactorMempool, newReactor(0x80), appChannel),
+		"reserved channel": NewBuilder(config, log.TestingLogger()).
+			AddAppReactor("APP", newReactor(0x30), p2p.AppChannel{ID: 0x30}),
+		"missing versions": NewBuilder(config, log.TestingLogger()).
+			AddAppReactor("APP", newReactor(0x80)),
+		"shared channel": NewBuilder(config, log.TestingLogger()).
+			AddAppReactor("APP", newReactor(0x80), appChannel).
+			AddAppReactor("APP2", newReactor(0x80), appChannel),
+		"duplicate name": NewBuilder(config, log.TestingLogger()).
+			AddAppReactor("APP", newReactor(0x80), appChannel).
+			AddAppReactor("APP", newReactor(0x81), p2p.AppChannel{ID: 0x81}),
+	}
+	for name, b := range testCases {
+		_, err := b.Build()
+		assert.Error(t, err, name)
+	}
+}
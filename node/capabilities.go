@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/statesync"
+)
+
+// queryAppCapabilities returns the capabilities the application advertises in
+// its Info response, or nil if it advertises none.
+func queryAppCapabilities(proxyApp proxy.AppConns, logger log.Logger) (*abci.Capabilities, error) {
+	res, err := proxyApp.Query().InfoSync(context.Background(), proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Info: %v", err)
+	}
+	caps := res.Capabilities
+	if caps == nil {
+		logger.Info("The application doesn't advertise its capabilities")
+		return nil, nil
+	}
+	logger.Info("ABCI capabilities",
+		"snapshots", caps.Snapshots,
+		"queryProofs", caps.QueryProofs,
+		"prioritizedMempool", caps.PrioritizedMempool,
+		"maxTxBytes", caps.MaxTxBytes,
+	)
+	return caps, nil
+}
+
+// adaptToAppCapabilities adapts the node to the capabilities the application
+// advertises: the mempool rejects the txs larger than the application accepts
+// and builds blocks by priority if the application sets it, and state sync
+// doesn't serve snapshots if the application doesn't take any. The node keeps
+// its configured behavior if the application advertises nothing. Queries with
+// proofs are rejected by the RPC, which reads the capabilities itself.
+func adaptToAppCapabilities(
+	caps *abci.Capabilities,
+	config *cfg.Config,
+	mempool *mempl.CListMempool,
+	stateSyncReactor *statesync.Reactor,
+	logger log.Logger,
+) {
+	if caps == nil {
+		return
+	}
+	if caps.MaxTxBytes > 0 && caps.MaxTxBytes < int64(config.Mempool.MaxTxBytes) {
+		logger.Info("Lowering the max tx size of the mempool to the one of the application",
+			"maxTxBytes", caps.MaxTxBytes, "configured", config.Mempool.MaxTxBytes)
+		config.Mempool.MaxTxBytes = int(caps.MaxTxBytes)
+	}
+	if caps.PrioritizedMempool {
+		logger.Info("Building blocks by tx priority, as set by the application")
+		mempool.SetBlockBuilder(mempl.PriorityBlockBuilder{})
+	}
+	if !caps.Snapshots {
+		logger.Info("Not serving state sync snapshots; the application doesn't take any")
+		stateSyncReactor.DisableServing()
+	}
+}
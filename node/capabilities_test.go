@@ -0,0 +1,62 @@
+package node
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+// capabilitiesApp is a kvstore advertising capabilities in Info.
+type capabilitiesApp struct {
+	*kvstore.Application
+	caps *abci.Capabilities
+}
+
+func (app capabilitiesApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	res := app.Application.Info(req)
+	res.Capabilities = app.caps
+	return res
+}
+
+func TestNodeAdaptsToAppCapabilities(t *testing.T) {
+	config := cfg.ResetTestRoot("node_app_capabilities_test")
+	defer os.RemoveAll(config.RootDir)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	pval, err := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, err)
+
+	app := capabilitiesApp{
+		Application: kvstore.NewApplication(),
+		caps:        &abci.Capabilities{PrioritizedMempool: true, MaxTxBytes: 100},
+	}
+	n, err := NewNode(config,
+		pval,
+		nodeKey,
+		proxy.NewLocalClientCreator(app),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+	)
+	require.NoError(t, err)
+
+	// the mempool rejects the txs larger than the app accepts
+	assert.Equal(t, 100, config.Mempool.MaxTxBytes)
+	err = n.Mempool().CheckTx(tmrand.Bytes(101), nil, mempl.TxInfo{})
+	assert.IsType(t, mempl.ErrTxTooLarge{}, err)
+	assert.NoError(t, n.Mempool().CheckTx(tmrand.Bytes(100), nil, mempl.TxInfo{}))
+}
@@ -36,5 +36,36 @@ To replace the built-in p2p.Reactor, use the CustomReactors option:
 
 The list of existing reactors can be found in CustomReactors documentation.
 
+Disabling or replacing built-in p2p.Reactor(s) with a Builder
+
+The Builder builds a node with the defaults of DefaultNewNode for anything not
+set. Unlike CustomReactors, it doesn't start the built-in reactors it replaces,
+and it can disable the mempool, block sync, state sync, evidence and PEX
+reactors altogether:
+
+		node, err := NewBuilder(config, logger).
+				WithClientCreator(clientCreator).
+				DisableReactor(ReactorStateSync).
+				ReplaceReactor(ReactorMempool, customMempoolReactor).
+				Build()
+
+The channels of the disabled reactors aren't advertised to peers.
+
+Adding app reactors
+
+Applications add their own gossip protocols with Builder.AddAppReactor. App
+reactors use the channel IDs reserved for them, from p2p.MinAppChannelID to
+p2p.MaxAppChannelID, and advertise the versions of the protocol of each
+channel. Peers use the highest version they both speak, and don't use a
+channel with each other if there is none:
+
+		orderBookChannel := p2p.AppChannel{ID: 0x80, MinVersion: 1, MaxVersion: 2}
+		node, err := NewBuilder(config, logger).
+				AddAppReactor("ORDERBOOK", orderBookReactor, orderBookChannel).
+				Build()
+
+The reactor gets the version to use with a peer with
+orderBookChannel.NegotiateVersion(peer.NodeInfo()).
+
 */
 package node
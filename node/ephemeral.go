@@ -0,0 +1,64 @@
+package node
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// NewEphemeralNode returns the single validator of a throwaway chain for
+// demos and CI, which runs the built-in kvstore app and keeps all its data in
+// memory: the block, state and evidence stores, the indexer and the peer
+// store use in-memory databases, the consensus WAL is disabled, and the node
+// key, validator key and genesis are generated on the fly. Everything is lost
+// when the node stops.
+//
+// It overrides the parts of config which would touch the disk.
+func NewEphemeralNode(config *cfg.Config, logger log.Logger) (*Node, error) {
+	config.Mode = cfg.ModeValidator
+	config.ProxyApp = "kvstore"
+	config.DBBackend = string(dbm.MemDBBackend)
+	config.FastSyncMode = false
+	config.StateSync.Enable = false
+	config.Preflight.Enable = false
+	config.P2P.DisableLegacy = true
+	config.P2P.PexReactor = false
+	config.TxIndex.Indexer = []string{"kv"}
+
+	pv := types.NewMockPV()
+	pubKey := pv.PrivKey.PubKey()
+	genDoc := &types.GenesisDoc{
+		ChainID:         fmt.Sprintf("ephemeral-%v", tmrand.Str(6)),
+		GenesisTime:     tmtime.Now(),
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators: []types.GenesisValidator{{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   10,
+		}},
+	}
+
+	appClient, _ := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, "")
+	n, err := NewNode(config,
+		pv,
+		p2p.GenNodeKey(),
+		appClient,
+		func() (*types.GenesisDoc, error) { return genDoc, nil },
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.consensusState.DisableWAL()
+	return n, nil
+}
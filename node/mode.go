@@ -0,0 +1,77 @@
+package node
+
+import (
+	"strings"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// modeReactors returns the names of the built-in reactors the node runs in
+// its mode.
+func modeReactors(config *cfg.Config, reactors reactorOverrides) []string {
+	switch config.Mode {
+	case cfg.ModeHeader:
+		return nil
+	case cfg.ModeSeed:
+		return []string{ReactorPEX}
+	}
+
+	names := []string{"CONSENSUS"}
+	for _, name := range builderReactors {
+		if name == ReactorPEX && !config.P2P.PexReactor {
+			continue
+		}
+		if reactors.active(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// modeSigner describes what signs the votes and proposals of the node.
+func modeSigner(config *cfg.Config) string {
+	if config.Mode != cfg.ModeValidator {
+		return "none"
+	}
+	if config.PrivValidatorListenAddr == "" {
+		return "local"
+	}
+	protocol, _ := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr)
+	if protocol == "grpc" {
+		return "remote (grpc)"
+	}
+	return "remote (socket)"
+}
+
+// modeRPC describes the RPC the node serves in its mode.
+func modeRPC(config *cfg.Config) string {
+	switch {
+	case config.Mode == cfg.ModeSeed, config.RPC.ListenAddress == "":
+		return "none"
+	case config.Mode == cfg.ModeHeader:
+		return "header routes"
+	case config.RPC.Unsafe:
+		return "all routes, including unsafe ones"
+	}
+	return "all routes"
+}
+
+// logNodeMode logs what the node runs in its mode: the reactors, the signer
+// and the RPC. It warns about a private validator key which a node not in
+// validator mode leaves unused.
+func logNodeMode(config *cfg.Config, reactors reactorOverrides, logger log.Logger) {
+	logger.Info("Node mode",
+		"mode", config.Mode,
+		"reactors", strings.Join(modeReactors(config, reactors), ","),
+		"signer", modeSigner(config),
+		"rpc", modeRPC(config),
+	)
+	if config.Mode != cfg.ModeValidator && tmos.FileExists(config.PrivValidatorKeyFile()) {
+		logger.Info("Found a private validator key, which a node in this mode doesn't use to sign; "+
+			"set mode = \"validator\" to run a validator",
+			"mode", config.Mode, "keyFile", config.PrivValidatorKeyFile())
+	}
+}
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestNodeMode(t *testing.T) {
+	testCases := []struct {
+		mode     string
+		laddr    string
+		reactors []string
+		signer   string
+		rpc      string
+	}{
+		{cfg.ModeValidator, "", []string{"CONSENSUS", ReactorMempool, ReactorBlockSync, ReactorStateSync,
+			ReactorEvidence, ReactorPEX}, "local", "all routes"},
+		{cfg.ModeValidator, "grpc://127.0.0.1:26659", []string{"CONSENSUS", ReactorMempool, ReactorBlockSync,
+			ReactorStateSync, ReactorEvidence, ReactorPEX}, "remote (grpc)", "all routes"},
+		{cfg.ModeFull, "", []string{"CONSENSUS", ReactorMempool, ReactorBlockSync, ReactorStateSync,
+			ReactorEvidence, ReactorPEX}, "none", "all routes"},
+		{cfg.ModeSeed, "", []string{ReactorPEX}, "none", "none"},
+		{cfg.ModeHeader, "", nil, "none", "header routes"},
+	}
+	for _, tc := range testCases {
+		config := cfg.TestConfig()
+		config.Mode = tc.mode
+		config.PrivValidatorListenAddr = tc.laddr
+		config.RPC.Unsafe = false
+		reactors := reactorOverrides{disabled: map[string]bool{}}
+
+		assert.Equal(t, tc.reactors, modeReactors(config, reactors), tc.mode)
+		assert.Equal(t, tc.signer, modeSigner(config), tc.mode)
+		assert.Equal(t, tc.rpc, modeRPC(config), tc.mode)
+	}
+
+	config := cfg.TestConfig()
+	config.RPC.Unsafe = true
+	assert.Equal(t, "all routes, including unsafe ones", modeRPC(config))
+
+	// disabled reactors aren't run
+	config.P2P.PexReactor = false
+	reactors := reactorOverrides{disabled: map[string]bool{ReactorStateSync: true}}
+	assert.Equal(t, []string{"CONSENSUS", ReactorMempool, ReactorBlockSync, ReactorEvidence},
+		modeReactors(config, reactors))
+}
+
+func TestNewNodeRejectsOtherModes(t *testing.T) {
+	for _, mode := range []string{cfg.ModeSeed, cfg.ModeHeader} {
+		config := cfg.TestConfig()
+		config.Mode = mode
+		_, err := NewNode(config, nil, p2p.NodeKey{}, nil, nil, nil, nil, log.TestingLogger())
+		require.Error(t, err, mode)
+		assert.Contains(t, err.Error(), mode)
+	}
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -14,13 +15,17 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	dbm "github.com/tendermint/tm-db"
+	"golang.org/x/net/netutil"
 
 	_ "github.com/lib/pq" // provide the psql db driver
 	abci "github.com/tendermint/tendermint/abci/types"
+	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
 	cfg "github.com/tendermint/tendermint/config"
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/headersync"
+	"github.com/tendermint/tendermint/internal/libs/sdnotify"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
@@ -30,6 +35,7 @@ import (
 	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
 	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
@@ -54,6 +60,7 @@ type Node struct {
 	config        *cfg.Config
 	genesisDoc    *types.GenesisDoc   // initial validator set
 	privValidator types.PrivValidator // local node's validator key
+	auditLog      *privval.AuditLog   // votes and proposals signed, if enabled
 
 	// network
 	transport   *p2p.MConnTransport
@@ -81,51 +88,27 @@ type Node struct {
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	pexReactorV2      *pex.ReactorV2          // for exchanging peer addresses
 	evidenceReactor   *evidence.Reactor
-	evidencePool      *evidence.Pool // tracking evidence
-	proxyApp          proxy.AppConns // connection to the application
-	rpcListeners      []net.Listener // rpc servers
+	metadataReactor   *metadata.Reactor  // for gossiping node metadata
+	evidencePool      *evidence.Pool     // tracking evidence
+	headerSyncer      *headersync.Syncer // for syncing headers in header mode
+	proxyApp          proxy.AppConns     // connection to the application
+	rpcListeners      []net.Listener     // rpc servers
+	mux               *tmnet.Mux         // splits the p2p port between p2p and rpc, if multiplexing
+	rpcEnv            *rpccore.Environment
 	eventSinks        []indexer.EventSink
 	indexerService    *indexer.Service
 	prometheusSrv     *http.Server
+	reactors          reactorOverrides // built-in reactors disabled or replaced with a Builder
+
+	cancelStoreMigration context.CancelFunc // stops the state store migration
+	storeMigrationDone   chan struct{}      // closed once the state store migration returns
 }
 
 // DefaultNewNode returns a Tendermint node with default settings for the
 // PrivValidator, ClientCreator, GenesisDoc, and DBProvider.
 // It implements NodeProvider.
 func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
-	if err != nil {
-		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
-	}
-	if config.Mode == cfg.ModeSeed {
-		return NewSeedNode(config,
-			DefaultDBProvider,
-			nodeKey,
-			DefaultGenesisDocProviderFunc(config),
-			logger,
-		)
-	}
-
-	var pval *privval.FilePV
-	if config.Mode == cfg.ModeValidator {
-		pval, err = privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		pval = nil
-	}
-
-	appClient, _ := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
-	return NewNode(config,
-		pval,
-		nodeKey,
-		appClient,
-		DefaultGenesisDocProviderFunc(config),
-		DefaultDBProvider,
-		DefaultMetricsProvider(config.Instrumentation),
-		logger,
-	)
+	return NewBuilder(config, logger).Build()
 }
 
 // NewNode returns a new, ready to go, Tendermint Node.
@@ -139,20 +122,57 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
+	return makeNode(config, privValidator, nodeKey, clientCreator, genesisDocProvider,
+		dbProvider, metricsProvider, logger, reactorOverrides{}, options...)
+}
+
+// makeNode makes a full or validator node, without the built-in reactors
+// disabled or replaced with a Builder.
+func makeNode(config *cfg.Config,
+	privValidator types.PrivValidator,
+	nodeKey p2p.NodeKey,
+	clientCreator proxy.ClientCreator,
+	genesisDocProvider GenesisDocProvider,
+	dbProvider DBProvider,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+	reactors reactorOverrides,
+	options ...Option) (*Node, error) {
+
+	if config.Mode != cfg.ModeFull && config.Mode != cfg.ModeValidator {
+		return nil, fmt.Errorf("can't make a node in %s mode with NewNode, use DefaultNewNode or a Builder",
+			config.Mode)
+	}
+	if err := runPreflightChecks(config, logger.With("module", "preflight")); err != nil {
+		return nil, err
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
 	}
 
 	stateStore := sm.NewStore(stateDB)
+	if err := checkStoreVersion(stateStore, config.AllowDowngrade, logger); err != nil {
+		return nil, err
+	}
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
 	if err != nil {
 		return nil, err
 	}
+	// A genesis doc with a streamed app state is saved without it, so the app
+	// state is read from the genesis file if InitChain must be called again.
+	if config.GenesisAppStateChunkSize > 0 && len(genDoc.AppState) == 0 && genDoc.AppStateFile == nil {
+		genDoc.AppStateFile = types.NewGenesisAppState(config.GenesisFile())
+	}
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, config, logger, genDoc.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	appCaps, err := queryAppCapabilities(proxyApp, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +186,13 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	indexerService, eventSinks, err := createAndStartIndexerService(config, dbProvider, eventBus, logger, genDoc.ChainID)
+	// The event schema is declared by the app during the handshake, and used to
+	// validate and index the events of blocks.
+	eventSchema := types.NewEventSchema()
+
+	indexerService, eventSinks, err := createAndStartIndexerService(
+		config, dbProvider, eventBus, logger, genDoc.ChainID, eventSchema, blockStore,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +209,7 @@ func NewNode(config *cfg.Config,
 				return nil, fmt.Errorf("error with private validator grpc client: %w", err)
 			}
 		default:
-			privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+			privValidator, err = createAndStartPrivValidatorSocketClient(config, genDoc.ChainID, logger)
 			if err != nil {
 				return nil, fmt.Errorf("error with private validator socket client: %w", err)
 			}
@@ -200,18 +226,41 @@ func NewNode(config *cfg.Config,
 		}
 	}
 
+	// Record the votes and proposals signed by the consensus, if enabled. The
+	// node keeps the unwrapped privValidator, to stop a remote signer.
+	csPrivValidator := privValidator
+	var auditLog *privval.AuditLog
+	if config.Mode == cfg.ModeValidator && config.PrivValidatorAuditLog != "" {
+		auditLog, err = privval.OpenAuditLog(config.PrivValidatorAuditLogFile())
+		if err != nil {
+			return nil, fmt.Errorf("can't open the priv validator audit log: %w", err)
+		}
+		csPrivValidator = privval.NewSignAuditor(privValidator, auditLog)
+	}
+
 	// Determine whether we should attempt state sync.
-	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
+	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey) && reactors.active(ReactorStateSync)
+	deltaSync := false
 	if stateSync && state.LastBlockHeight > 0 {
-		logger.Info("Found local state with non-zero height, skipping state sync")
-		stateSync = false
+		if config.StateSync.DeltaSync {
+			logger.Info("Found local state with non-zero height, state syncing with delta snapshots",
+				"height", state.LastBlockHeight)
+			deltaSync = true
+		} else {
+			logger.Info("Found local state with non-zero height, skipping state sync")
+			stateSync = false
+		}
 	}
 
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
-	// and replays any blocks as necessary to sync tendermint with the app.
+	// and replays any blocks as necessary to sync tendermint with the app. Delta snapshots
+	// are restored on top of the app state, so it must be in sync too.
 	consensusLogger := logger.With("module", "consensus")
-	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+	if !stateSync || deltaSync {
+		if err := doHandshake(
+			stateStore, state, blockStore, genDoc, eventBus, eventSchema, proxyApp,
+			config.GenesisAppStateChunkSize, consensusLogger,
+		); err != nil {
 			return nil, err
 		}
 
@@ -226,9 +275,10 @@ func NewNode(config *cfg.Config,
 
 	// Determine whether we should do fast sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
-	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, pubKey)
+	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, pubKey) && reactors.active(ReactorBlockSync)
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger, config.Mode)
+	logNodeMode(config, reactors, logger)
 
 	// TODO: Fetch and provide real options and do proper p2p bootstrapping.
 	// TODO: Use a persistent peer database.
@@ -236,6 +286,11 @@ func NewNode(config *cfg.Config,
 	if err != nil {
 		return nil, err
 	}
+	nodeInfo.Channels = reactors.withoutDisabledChannels(nodeInfo.Channels)
+	for _, c := range reactors.appChannels() {
+		nodeInfo.Channels = append(nodeInfo.Channels, byte(c.ID))
+		nodeInfo.AppChannels = append(nodeInfo.AppChannels, c)
+	}
 
 	p2pLogger := logger.With("module", "p2p")
 	transport := createTransport(p2pLogger, config)
@@ -254,16 +309,23 @@ func NewNode(config *cfg.Config,
 	}
 
 	mpReactorShim, mpReactor, mempool := createMempoolReactor(
-		config, proxyApp, state, memplMetrics, peerManager, router, logger,
+		config, proxyApp, proxy.TxSanityCheck(clientCreator), state, memplMetrics, eventBus, peerManager,
+		router, logger, reactors.active(ReactorMempool),
 	)
 
 	evReactorShim, evReactor, evPool, err := createEvidenceReactor(
 		config, dbProvider, stateDB, blockStore, peerManager, router, logger,
+		reactors.active(ReactorEvidence),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	mdReactorShim, mdReactor, err := createMetadataReactor(config, nodeKey, peerManager, router, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -272,19 +334,25 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evPool,
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithEventSchema(eventSchema),
+		sm.BlockExecutorWithCommitTimeout(
+			config.Consensus.AppCommitTimeout,
+			config.Consensus.AppCommitTimeoutHalt,
+			config.Consensus.AppCommitTimeoutDebugPath(),
+		),
 	)
 
 	csReactorShim, csReactor, csState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evPool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus,
+		csPrivValidator, csMetrics, stateSync || fastSync, eventBus,
 		peerManager, router, consensusLogger,
 	)
 
 	// Create the blockchain reactor. Note, we do not start fast sync if we're
 	// doing a state sync first.
 	bcReactorShim, bcReactor, err := createBlockchainReactor(
-		logger, config, state, blockExec, blockStore, csReactor,
-		peerManager, router, fastSync && !stateSync,
+		logger, config, state, blockExec, blockStore, csReactor, eventBus,
+		peerManager, router, fastSync && !stateSync, reactors.active(ReactorBlockSync),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
@@ -320,7 +388,7 @@ func NewNode(config *cfg.Config,
 
 	stateSyncReactorShim = p2p.NewReactorShim(logger.With("module", "statesync"), "StateSyncShim", statesync.ChannelShims)
 
-	if config.P2P.DisableLegacy {
+	if config.P2P.DisableLegacy && reactors.active(ReactorStateSync) {
 		channels = makeChannelsFromShims(router, statesync.ChannelShims)
 		peerUpdates = peerManager.Subscribe()
 	} else {
@@ -330,14 +398,16 @@ func NewNode(config *cfg.Config,
 
 	stateSyncReactor = statesync.NewReactor(
 		stateSyncReactorShim.Logger,
+		config.StateSync,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		channels[statesync.SnapshotChannel],
 		channels[statesync.ChunkChannel],
 		peerUpdates,
-		config.StateSync.TempDir,
 	)
 
+	adaptToAppCapabilities(appCaps, config, mempool, stateSyncReactor, logger)
+
 	// add the channel descriptors to both the transports
 	// FIXME: This should be removed when the legacy p2p stack is removed and
 	// transports can either be agnostic to channel descriptors or can be
@@ -347,6 +417,10 @@ func NewNode(config *cfg.Config,
 	transport.AddChannelDescriptors(csReactorShim.GetChannels())
 	transport.AddChannelDescriptors(evReactorShim.GetChannels())
 	transport.AddChannelDescriptors(stateSyncReactorShim.GetChannels())
+	transport.AddChannelDescriptors(mdReactorShim.GetChannels())
+	for _, app := range reactors.apps {
+		transport.AddChannelDescriptors(app.reactor.GetChannels())
+	}
 
 	// Optionally, start the pex reactor
 	//
@@ -373,15 +447,23 @@ func NewNode(config *cfg.Config,
 
 	if config.P2P.DisableLegacy {
 		addrBook = nil
-		pexReactorV2, err = createPEXReactorV2(config, logger, peerManager, router)
-		if err != nil {
-			return nil, err
+		if reactors.active(ReactorPEX) {
+			pexReactorV2, err = createPEXReactorV2(config, logger, peerManager, router, nodeInfo)
+			if err != nil {
+				return nil, err
+			}
 		}
 	} else {
+		// Only the legacy stack translates the messages of versioned channels,
+		// so peers of the new stack use version 0 with us.
+		nodeInfo.ChannelVersions = channelVersionsFromShims(nodeInfo,
+			mpReactorShim, bcReactorShim, csReactorShim, evReactorShim, stateSyncReactorShim, mdReactorShim)
+
 		// setup Transport and Switch
 		sw = createSwitch(
 			config, transport, p2pMetrics, mpReactorShim, bcReactorForSwitch,
-			stateSyncReactorShim, csReactorShim, evReactorShim, proxyApp, nodeInfo, nodeKey, p2pLogger,
+			stateSyncReactorShim, csReactorShim, evReactorShim, mdReactorShim, proxyApp, nodeInfo, nodeKey, p2pLogger,
+			reactors,
 		)
 
 		err = sw.AddPersistentPeers(strings.SplitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -399,7 +481,26 @@ func NewNode(config *cfg.Config,
 			return nil, fmt.Errorf("could not create addrbook: %w", err)
 		}
 
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		switch {
+		case reactors.active(ReactorPEX):
+			pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		case reactors.isDisabled(ReactorPEX):
+			sw.AddReactor(ReactorPEX, newDisabledReactor("PEX", []*p2p.ChannelDescriptor{&pexCh}))
+		}
+
+		for _, app := range reactors.apps {
+			sw.AddReactor(app.name, app.reactor)
+		}
+	}
+
+	// Let fast sync ask PEX for new peers when it stalls.
+	if bcR, ok := bcReactor.(*bcv0.Reactor); ok {
+		switch {
+		case pexReactorV2 != nil:
+			bcR.SetPeerRequester(pexReactorV2)
+		case pexReactor != nil:
+			bcR.SetPeerRequester(pexReactor)
+		}
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -413,6 +514,7 @@ func NewNode(config *cfg.Config,
 		config:        config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
+		auditLog:      auditLog,
 
 		transport:   transport,
 		sw:          sw,
@@ -435,11 +537,13 @@ func NewNode(config *cfg.Config,
 		pexReactor:       pexReactor,
 		pexReactorV2:     pexReactorV2,
 		evidenceReactor:  evReactor,
+		metadataReactor:  mdReactor,
 		evidencePool:     evPool,
 		proxyApp:         proxyApp,
 		indexerService:   indexerService,
 		eventBus:         eventBus,
 		eventSinks:       eventSinks,
+		reactors:         reactors,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -458,6 +562,11 @@ func NewSeedNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
+	logNodeMode(config, reactorOverrides{}, logger)
+	if err := runPreflightChecks(config, logger.With("module", "preflight")); err != nil {
+		return nil, err
+	}
+
 	genDoc, err := genesisDocProvider()
 	if err != nil {
 		return nil, err
@@ -479,7 +588,7 @@ func NewSeedNode(config *cfg.Config,
 	transport := createTransport(p2pLogger, config)
 	sw := createSwitch(
 		config, transport, p2pMetrics, nil, nil,
-		nil, nil, nil, nil, nodeInfo, nodeKey, p2pLogger,
+		nil, nil, nil, nil, nil, nodeInfo, nodeKey, p2pLogger, reactorOverrides{},
 	)
 
 	err = sw.AddPersistentPeers(strings.SplitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -520,7 +629,7 @@ func NewSeedNode(config *cfg.Config,
 	pexCh := pex.ChannelDescriptor()
 	transport.AddChannelDescriptors([]*p2p.ChannelDescriptor{&pexCh})
 	if config.P2P.DisableLegacy {
-		pexReactorV2, err = createPEXReactorV2(config, logger, peerManager, router)
+		pexReactorV2, err = createPEXReactorV2(config, logger, peerManager, router, nodeInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -559,6 +668,82 @@ func NewSeedNode(config *cfg.Config,
 	return node, nil
 }
 
+// NewHeaderNode returns a new header-only node, which syncs the headers,
+// commits and validator sets of the chain from the state sync RPC servers,
+// verifying them with a light client, and serves them over RPC, e.g. to
+// relayers. It stores neither the block data nor the app state, and has no p2p
+// stack nor ABCI app.
+func NewHeaderNode(config *cfg.Config,
+	dbProvider DBProvider,
+	nodeKey p2p.NodeKey,
+	genesisDocProvider GenesisDocProvider,
+	logger log.Logger,
+	options ...Option) (*Node, error) {
+
+	logNodeMode(config, reactorOverrides{}, logger)
+	if err := runPreflightChecks(config, logger.With("module", "preflight")); err != nil {
+		return nil, err
+	}
+
+	blockStore, stateDB, err := initDBs(config, dbProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	stateStore := sm.NewStore(stateDB)
+	if err := checkStoreVersion(stateStore, config.AllowDowngrade, logger); err != nil {
+		return nil, err
+	}
+
+	genDoc, err := genesisDocProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The node info is only reported over RPC.
+	nodeInfo, err := makeSeedNodeInfo(config, nodeKey, genDoc, state)
+	if err != nil {
+		return nil, err
+	}
+	nodeInfo.Channels = []byte{}
+
+	// The event bus has no publishers, but is used by the RPC server.
+	eventBus, err := createAndStartEventBus(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSyncer, err := createHeaderSyncer(config, dbProvider, genDoc.ChainID, stateStore, blockStore, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		config:     config,
+		genesisDoc: genDoc,
+
+		nodeInfo: nodeInfo,
+		nodeKey:  nodeKey,
+
+		eventBus:     eventBus,
+		stateStore:   stateStore,
+		blockStore:   blockStore,
+		headerSyncer: headerSyncer,
+	}
+	node.BaseService = *service.NewBaseService(logger, "HeaderNode", node)
+
+	for _, option := range options {
+		option(node)
+	}
+
+	return node, nil
+}
+
 // Option sets a parameter for the node.
 type Option func(*Node)
 
@@ -593,6 +778,29 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 	}
 }
 
+// BlockDataAvailability sets the extension which encodes the data of the
+// blocks the node proposes before they are gossiped, and verifies the data of
+// the proposal blocks it receives. Chains which sample the availability of
+// block data use it instead of patching consensus.
+func BlockDataAvailability(da cs.BlockDataAvailability) Option {
+	return func(n *Node) {
+		if n.consensusState != nil {
+			n.consensusState.SetBlockDataAvailability(da)
+		}
+	}
+}
+
+// BlockBuilder sets the policy which selects and orders the txs reaped from
+// the mempool for the blocks the node proposes, e.g.
+// mempool.PriorityBlockBuilder. Defaults to mempool.FIFOBlockBuilder.
+func BlockBuilder(builder mempl.BlockBuilder) Option {
+	return func(n *Node) {
+		if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+			mp.SetBlockBuilder(builder)
+		}
+	}
+}
+
 // StateProvider overrides the state provider used by state sync to retrieve trusted app hashes and
 // build a State object for bootstrapping the node.
 // WARNING: this interface is considered unstable and subject to change.
@@ -611,6 +819,12 @@ func (n *Node) OnStart() error {
 		time.Sleep(genTime.Sub(now))
 	}
 
+	if n.config.RPC.Multiplex {
+		if err := n.listenMux(); err != nil {
+			return err
+		}
+	}
+
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
 	if n.config.RPC.ListenAddress != "" && n.config.Mode != cfg.ModeSeed {
@@ -626,14 +840,34 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.config.Mode != cfg.ModeSeed {
+		n.startStoreMigration()
+	}
+
+	// Header nodes have no p2p stack, they only sync headers over RPC.
+	if n.config.Mode == cfg.ModeHeader {
+		if err := n.headerSyncer.Start(); err != nil {
+			return err
+		}
+		n.serveMux()
+		n.startSystemd()
+		return nil
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID, n.config.P2P.ListenAddress))
 	if err != nil {
 		return err
 	}
-	if err := n.transport.Listen(addr.Endpoint()); err != nil {
+	if n.mux != nil {
+		err = n.transport.ListenOn(n.mux.Default())
+	} else {
+		err = n.transport.Listen(addr.Endpoint())
+	}
+	if err != nil {
 		return err
 	}
+	n.serveMux()
 
 	n.isListening = true
 
@@ -651,7 +885,7 @@ func (n *Node) OnStart() error {
 	}
 
 	if n.config.Mode != cfg.ModeSeed {
-		if n.config.FastSync.Version == cfg.BlockchainV0 {
+		if n.config.FastSync.Version == cfg.BlockchainV0 && n.reactors.active(ReactorBlockSync) {
 			// Start the real blockchain reactor separately since the switch uses the shim.
 			if err := n.bcReactor.Start(); err != nil {
 				return err
@@ -664,24 +898,37 @@ func (n *Node) OnStart() error {
 		}
 
 		// Start the real state sync reactor separately since the switch uses the shim.
-		if err := n.stateSyncReactor.Start(); err != nil {
-			return err
+		if n.reactors.active(ReactorStateSync) {
+			if err := n.stateSyncReactor.Start(); err != nil {
+				return err
+			}
 		}
 
 		// Start the real mempool reactor separately since the switch uses the shim.
-		if err := n.mempoolReactor.Start(); err != nil {
-			return err
+		if n.reactors.active(ReactorMempool) {
+			if err := n.mempoolReactor.Start(); err != nil {
+				return err
+			}
 		}
 
 		// Start the real evidence reactor separately since the switch uses the shim.
-		if err := n.evidenceReactor.Start(); err != nil {
+		if n.reactors.active(ReactorEvidence) {
+			if err := n.evidenceReactor.Start(); err != nil {
+				return err
+			}
+		}
+
+		// Start the real metadata reactor separately since the switch uses the shim.
+		if err := n.metadataReactor.Start(); err != nil {
 			return err
 		}
 	}
 
-	if n.config.P2P.DisableLegacy && n.pexReactorV2 != nil {
-		if err := n.pexReactorV2.Start(); err != nil {
-			return err
+	if n.config.P2P.DisableLegacy {
+		if n.pexReactorV2 != nil {
+			if err := n.pexReactorV2.Start(); err != nil {
+				return err
+			}
 		}
 	} else {
 		// Always connect to persistent peers
@@ -699,80 +946,144 @@ func (n *Node) OnStart() error {
 			return fmt.Errorf("this blockchain reactor does not support switching from state sync")
 		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.consensusReactor, n.stateSyncProvider,
-			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.config.FastSyncMode && n.reactors.active(ReactorBlockSync), n.stateStore, n.blockStore, n.stateSyncGenesis)
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
 	}
 
+	n.startSystemd()
+
 	return nil
 }
 
+// startStoreMigration migrates the state store records written with an older
+// schema in the background. Only the records of heights below the last block
+// are migrated, since consensus writes the records above it, and the state
+// itself, in the current schema.
+func (n *Node) startStoreMigration() {
+	state, err := n.stateStore.Load()
+	if err != nil {
+		n.Logger.Error("failed to load state, not migrating the state store", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancelStoreMigration = cancel
+	n.storeMigrationDone = make(chan struct{})
+
+	go func() {
+		defer close(n.storeMigrationDone)
+
+		migrated, err := n.stateStore.MigrateSchema(ctx, state.LastBlockHeight)
+		switch {
+		case errors.Is(err, context.Canceled):
+			n.Logger.Info("Stopped state store migration", "migrated", migrated)
+		case err != nil:
+			n.Logger.Error("failed to migrate the state store", "migrated", migrated, "err", err)
+		case migrated > 0:
+			n.Logger.Info("Migrated state store", "migrated", migrated,
+				"schema_version", sm.StateSchemaVersion)
+		}
+	}()
+}
+
+// startSystemd starts the systemd watchdog, if enabled, and notifies systemd
+// that the node is ready.
+func (n *Node) startSystemd() {
+	interval, err := sdnotify.WatchdogInterval()
+	if err != nil {
+		n.Logger.Error("invalid systemd watchdog interval", "err", err)
+	} else if interval > 0 {
+		go n.systemdWatchdogRoutine(interval)
+	}
+	n.notifySystemd(sdnotify.Ready)
+}
+
 // OnStop stops the Node. It implements service.Service.
+//
+// The node shuts down in phases, so that it doesn't stop in the middle of
+// writing a block: it stops accepting txs over RPC, drains the in-flight ABCI
+// calls of the mempool, stops the reactors, which finishes the current
+// consensus step and flushes the WAL, and then closes its p2p connections,
+// the remaining services and its stores. Each drain phase takes at most
+// ShutdownDrainTimeout.
 func (n *Node) OnStop() {
 
 	n.Logger.Info("Stopping Node")
+	n.notifySystemd(sdnotify.Stopping)
 
-	// first stop the non-reactor services
-	if err := n.eventBus.Stop(); err != nil {
-		n.Logger.Error("Error closing eventBus", "err", err)
-	}
-	if err := n.indexerService.Stop(); err != nil {
-		n.Logger.Error("Error closing indexerService", "err", err)
+	// stop accepting new txs, so the mempool can be drained
+	if n.rpcEnv != nil {
+		n.rpcEnv.RejectTxs()
 	}
 
-	if n.config.Mode != cfg.ModeSeed {
-		// now stop the reactors
-		if n.config.FastSync.Version == cfg.BlockchainV0 {
+	// the stores are only closed if consensus stopped writing to them
+	closeStores := false
+
+	if n.config.Mode == cfg.ModeHeader {
+		closeStores = n.drain("header sync", n.headerSyncer.Stop)
+		if err := n.eventBus.Stop(); err != nil {
+			n.Logger.Error("Error closing eventBus", "err", err)
+		}
+	} else if n.config.Mode != cfg.ModeSeed {
+		n.drain("mempool", func() error {
+			n.mempool.Lock()
+			defer n.mempool.Unlock()
+			return n.mempool.FlushAppConn()
+		})
+
+		// Stop consensus first, so the current step is finished and the WAL is
+		// flushed while the other reactors still serve peers.
+		closeStores = n.drain("consensus", n.consensusReactor.Stop)
+
+		// now stop the other reactors
+		if n.config.FastSync.Version == cfg.BlockchainV0 && n.reactors.active(ReactorBlockSync) {
 			// Stop the real blockchain reactor separately since the switch uses the shim.
 			if err := n.bcReactor.Stop(); err != nil {
 				n.Logger.Error("failed to stop the blockchain reactor", "err", err)
 			}
 		}
 
-		// Stop the real consensus reactor separately since the switch uses the shim.
-		if err := n.consensusReactor.Stop(); err != nil {
-			n.Logger.Error("failed to stop the consensus reactor", "err", err)
-		}
-
 		// Stop the real state sync reactor separately since the switch uses the shim.
-		if err := n.stateSyncReactor.Stop(); err != nil {
-			n.Logger.Error("failed to stop the state sync reactor", "err", err)
+		if n.reactors.active(ReactorStateSync) {
+			if err := n.stateSyncReactor.Stop(); err != nil {
+				n.Logger.Error("failed to stop the state sync reactor", "err", err)
+			}
 		}
 
 		// Stop the real mempool reactor separately since the switch uses the shim.
-		if err := n.mempoolReactor.Stop(); err != nil {
-			n.Logger.Error("failed to stop the mempool reactor", "err", err)
+		if n.reactors.active(ReactorMempool) {
+			if err := n.mempoolReactor.Stop(); err != nil {
+				n.Logger.Error("failed to stop the mempool reactor", "err", err)
+			}
 		}
 
 		// Stop the real evidence reactor separately since the switch uses the shim.
-		if err := n.evidenceReactor.Stop(); err != nil {
-			n.Logger.Error("failed to stop the evidence reactor", "err", err)
+		if n.reactors.active(ReactorEvidence) {
+			if err := n.evidenceReactor.Stop(); err != nil {
+				n.Logger.Error("failed to stop the evidence reactor", "err", err)
+			}
 		}
-	}
 
-	if n.config.P2P.DisableLegacy && n.pexReactorV2 != nil {
-		if err := n.pexReactorV2.Stop(); err != nil {
-			n.Logger.Error("failed to stop the PEX v2 reactor", "err", err)
+		// Stop the real metadata reactor separately since the switch uses the shim.
+		if err := n.metadataReactor.Stop(); err != nil {
+			n.Logger.Error("failed to stop the metadata reactor", "err", err)
 		}
-	}
 
-	if n.config.P2P.DisableLegacy {
-		if err := n.router.Stop(); err != nil {
-			n.Logger.Error("failed to stop router", "err", err)
+		// stop the non-reactor services once consensus no longer publishes events
+		if err := n.eventBus.Stop(); err != nil {
+			n.Logger.Error("Error closing eventBus", "err", err)
 		}
-	} else {
-		if err := n.sw.Stop(); err != nil {
-			n.Logger.Error("failed to stop switch", "err", err)
+		if err := n.indexerService.Stop(); err != nil {
+			n.Logger.Error("Error closing indexerService", "err", err)
 		}
 	}
 
-	if err := n.transport.Close(); err != nil {
-		n.Logger.Error("Error closing transport", "err", err)
+	if n.config.Mode != cfg.ModeHeader {
+		n.stopP2P()
 	}
 
-	n.isListening = false
-
 	// finally stop the listeners / external services
 	for _, l := range n.rpcListeners {
 		n.Logger.Info("Closing rpc listener", "listener", l)
@@ -780,6 +1091,11 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Error closing listener", "listener", l, "err", err)
 		}
 	}
+	if n.mux != nil {
+		if err := n.mux.Close(); err != nil {
+			n.Logger.Error("Error closing multiplexed listener", "err", err)
+		}
+	}
 
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
@@ -787,20 +1103,85 @@ func (n *Node) OnStop() {
 		}
 	}
 
+	if n.auditLog != nil {
+		if err := n.auditLog.Close(); err != nil {
+			n.Logger.Error("Error closing private validator audit log", "err", err)
+		}
+	}
+
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
 			// Error from closing listeners, or context timeout:
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.cancelStoreMigration != nil {
+		n.cancelStoreMigration()
+		<-n.storeMigrationDone
+	}
+
+	if closeStores {
+		if err := n.blockStore.Close(); err != nil {
+			n.Logger.Error("Error closing block store", "err", err)
+		}
+		if err := n.stateStore.Close(); err != nil {
+			n.Logger.Error("Error closing state store", "err", err)
+		}
+	}
+}
+
+// stopP2P stops the PEX reactor, the router or switch, and the transport.
+func (n *Node) stopP2P() {
+	if n.config.P2P.DisableLegacy && n.pexReactorV2 != nil {
+		if err := n.pexReactorV2.Stop(); err != nil {
+			n.Logger.Error("failed to stop the PEX v2 reactor", "err", err)
+		}
+	}
+
+	if n.config.P2P.DisableLegacy {
+		if err := n.router.Stop(); err != nil {
+			n.Logger.Error("failed to stop router", "err", err)
+		}
+	} else {
+		if err := n.sw.Stop(); err != nil {
+			n.Logger.Error("failed to stop switch", "err", err)
+		}
+	}
+
+	if err := n.transport.Close(); err != nil {
+		n.Logger.Error("Error closing transport", "err", err)
+	}
+
+	n.isListening = false
+}
+
+// drain runs fn, one phase of the node shutdown, and waits for it to return
+// for at most ShutdownDrainTimeout. It returns false if fn timed out, in which
+// case the shutdown continues while fn keeps running in the background.
+func (n *Node) drain(phase string, fn func() error) bool {
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	timer := time.NewTimer(n.config.ShutdownDrainTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			n.Logger.Error("failed to drain", "phase", phase, "err", err)
+		}
+		return true
+	case <-timer.C:
+		n.Logger.Error("timed out draining; continuing shutdown", "phase", phase,
+			"timeout", n.config.ShutdownDrainTimeout)
+		return false
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
 func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 	rpcCoreEnv := rpccore.Environment{
-		ProxyAppQuery:   n.proxyApp.Query(),
-		ProxyAppMempool: n.proxyApp.Mempool(),
-
 		StateStore:     n.stateStore,
 		BlockStore:     n.blockStore,
 		EvidencePool:   n.evidencePool,
@@ -813,11 +1194,20 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		DBDir:            n.config.DBDir(),
 
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
 	}
+	// header nodes have no ABCI app
+	if n.proxyApp != nil {
+		rpcCoreEnv.ProxyAppQuery = n.proxyApp.Query()
+		rpcCoreEnv.ProxyAppMempool = n.proxyApp.Mempool()
+	}
+	if n.metadataReactor != nil {
+		rpcCoreEnv.NodeMetadata = n.metadataReactor.Book()
+	}
 	if n.config.Mode == cfg.ModeValidator {
 		pubKey, err := n.privValidator.GetPubKey(context.TODO())
 		if pubKey == nil || err != nil {
@@ -837,11 +1227,16 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	if err != nil {
 		return nil, err
 	}
+	n.rpcEnv = env
 
 	listenAddrs := strings.SplitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := env.GetRoutes()
 
-	if n.config.RPC.Unsafe {
+	if n.config.Mode == cfg.ModeHeader {
+		// header nodes only serve the routes which don't need the block data,
+		// the app nor the consensus state
+		routes = env.GetHeaderRoutes()
+	} else if n.config.RPC.Unsafe {
 		env.AddUnsafe(routes)
 	}
 
@@ -856,6 +1251,11 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	if n.mux != nil {
+		// the rpc is served once on the multiplexed p2p port
+		listenAddrs = listenAddrs[:1]
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -874,10 +1274,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
-		listener, err := rpcserver.Listen(
-			listenAddr,
-			config,
-		)
+		listener, err := n.listenRPC(listenAddr, config, tmnet.HTTP1Matcher)
 		if err != nil {
 			return nil, err
 		}
@@ -922,7 +1319,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 
 	// we expose a simplified api over grpc for convenience to app devs
 	grpcListenAddr := n.config.RPC.GRPCListenAddress
-	if grpcListenAddr != "" {
+	if grpcListenAddr != "" && n.config.Mode != cfg.ModeHeader {
 		config := rpcserver.DefaultConfig()
 		config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 		config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
@@ -934,7 +1331,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
 			config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 		}
-		listener, err := rpcserver.Listen(grpcListenAddr, config)
+		listener, err := n.listenRPC(grpcListenAddr, config, tmnet.HTTP2Matcher)
 		if err != nil {
 			return nil, err
 		}
@@ -951,6 +1348,56 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 
 }
 
+// muxSniffTimeout is how long the multiplexed p2p port waits for the first
+// bytes of a connection, which tell its protocol.
+const muxSniffTimeout = 10 * time.Second
+
+// listenMux listens on the p2p listen address, to serve the RPC and gRPC
+// servers on it along with the p2p connections.
+func (n *Node) listenMux() error {
+	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID, n.config.P2P.ListenAddress))
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", addr.DialString())
+	if err != nil {
+		return err
+	}
+	// sniff no more connections at once than the p2p transport and the RPC
+	// servers accept
+	maxPending := n.config.P2P.MaxNumInboundPeers +
+		len(strings.SplitAndTrimEmpty(n.config.P2P.UnconditionalPeerIDs, ",", " ")) +
+		n.config.RPC.MaxOpenConnections + n.config.RPC.GRPCMaxOpenConnections
+	n.mux = tmnet.NewMux(listener, muxSniffTimeout, maxPending)
+	return nil
+}
+
+// serveMux starts handing the connections of the multiplexed p2p port to the
+// p2p transport and the RPC servers, once they all listen, if multiplexing.
+func (n *Node) serveMux() {
+	if n.mux == nil {
+		return
+	}
+	go func() {
+		if err := n.mux.Serve(); err != nil {
+			n.Logger.Error("Error serving multiplexed listener", "err", err)
+		}
+	}()
+}
+
+// listenRPC listens on the address, or on the connections of the multiplexed
+// p2p port the matcher matches if multiplexing.
+func (n *Node) listenRPC(addr string, config *rpcserver.Config, matcher tmnet.Matcher) (net.Listener, error) {
+	if n.mux == nil {
+		return rpcserver.Listen(addr, config)
+	}
+	listener := n.mux.Match(matcher)
+	if config.MaxOpenConnections > 0 {
+		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
+	}
+	return listener, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -1077,18 +1524,43 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 				Period: config.TrustPeriod,
 				Height: config.TrustHeight,
 				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+			}, ssR.Logger.With("module", "light"),
+			statesync.WitnessQuorum(config.WitnessQuorum))
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
 	}
 
 	go func() {
-		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
+		// A node with local state restores delta snapshots on top of it, and falls back to
+		// catching up from it if that fails.
+		localState := state
+		state, commit, err := ssR.SyncDelta(stateProvider, localState.LastBlockHeight, config.DiscoveryTime)
+		if err != nil && localState.LastBlockHeight > 0 {
+			ssR.Logger.Error("Delta state sync failed, catching up from local state",
+				"height", localState.LastBlockHeight, "err", err)
+			conR.Metrics.StateSyncing.Set(0)
+			if fastSync {
+				conR.Metrics.FastSyncing.Set(1)
+				if err := bcR.SwitchToFastSync(localState); err != nil {
+					ssR.Logger.Error("Failed to switch to fast sync", "err", err)
+				}
+			} else {
+				conR.SwitchToConsensus(localState, false)
+			}
+			return
+		}
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
 			return
 		}
+		if localState.LastBlockHeight > 0 {
+			// the block store must be contiguous, so the blocks below the snapshot go
+			if _, err := blockStore.DiscardBlocks(); err != nil {
+				ssR.Logger.Error("Failed to discard the blocks below the snapshot", "err", err)
+				return
+			}
+		}
 		err = stateStore.Bootstrap(state)
 		if err != nil {
 			ssR.Logger.Error("Failed to bootstrap node with new state", "err", err)
@@ -1141,6 +1613,9 @@ type GenesisDocProvider func() (*types.GenesisDoc, error)
 // the GenesisDoc from the config.GenesisFile() on the filesystem.
 func DefaultGenesisDocProviderFunc(config *cfg.Config) GenesisDocProvider {
 	return func() (*types.GenesisDoc, error) {
+		if config.GenesisAppStateChunkSize > 0 {
+			return types.GenesisDocFromFileStreaming(config.GenesisFile())
+		}
 		return types.GenesisDocFromFile(config.GenesisFile())
 	}
 }
@@ -1235,17 +1710,24 @@ func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
 }
 
 func createAndStartPrivValidatorSocketClient(
-	listenAddr,
+	config *cfg.Config,
 	chainID string,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
 
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	pve, err := privval.NewSignerListener(config.PrivValidatorListenAddr, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
-	pvsc, err := privval.NewSignerClient(pve, chainID)
+	metrics := privval.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = privval.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+	pvsc, err := privval.NewSignerClient(pve, chainID,
+		privval.SignerClientWithMetrics(metrics),
+		privval.SignerClientWithMaxSignLatency(config.PrivValidatorMaxSignLatency),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -1286,7 +1768,13 @@ func createAndStartPrivValidatorGRPCClient(
 
 func getRouterConfig(conf *cfg.Config, proxyApp proxy.AppConns) p2p.RouterOptions {
 	opts := p2p.RouterOptions{
-		QueueType: conf.P2P.QueueType,
+		QueueType:        conf.P2P.QueueType,
+		DialTimeout:      conf.P2P.DialTimeout,
+		HandshakeTimeout: conf.P2P.HandshakeTimeout,
+	}
+
+	if maxDials := conf.P2P.MaxConcurrentDials; maxDials > 0 {
+		opts.NumConcurrentDials = func() int { return maxDials }
 	}
 
 	if conf.P2P.MaxNumInboundPeers > 0 {
@@ -1346,6 +1834,24 @@ func makeChannelsFromShims(
 	return channels
 }
 
+// channelVersionsFromShims returns the message versions of the versioned
+// channels of the reactor shims, for the channels the node advertises.
+func channelVersionsFromShims(nodeInfo p2p.NodeInfo, reactorShims ...*p2p.ReactorShim) []p2p.ChannelVersion {
+	var versions []p2p.ChannelVersion
+	for _, rs := range reactorShims {
+		if rs == nil {
+			continue
+		}
+		for _, cv := range rs.ChannelVersions() {
+			if bytes.IndexByte(nodeInfo.Channels, byte(cv.ChannelID)) >= 0 {
+				versions = append(versions, cv)
+			}
+		}
+	}
+
+	return versions
+}
+
 func getChannelsFromShim(reactorShim *p2p.ReactorShim) map[p2p.ChannelID]*p2p.Channel {
 	channels := map[p2p.ChannelID]*p2p.Channel{}
 	for chID := range reactorShim.Channels {
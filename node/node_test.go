@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -23,12 +25,17 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	"github.com/tendermint/tendermint/privval"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/store"
@@ -79,6 +86,63 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNewEphemeralNode(t *testing.T) {
+	// the node must not create its root dir
+	config := cfg.TestConfig()
+	config.SetRoot(filepath.Join(t.TempDir(), "root"))
+
+	n, err := NewEphemeralNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	// the node produces blocks with txs on its own
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryTx)
+	require.NoError(t, err)
+	_, err = n.rpcEnv.BroadcastTxAsync(&rpctypes.Context{}, types.Tx("foo=bar"))
+	require.NoError(t, err)
+	select {
+	case <-blocksSub.Out():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the node to commit the tx")
+	}
+
+	require.NoError(t, n.Stop())
+	require.NoDirExists(t, config.RootDir)
+}
+
+func TestNodeGracefulShutdown(t *testing.T) {
+	config := cfg.ResetTestRoot("node_graceful_shutdown_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	// wait for the node to produce a block
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+	select {
+	case <-blocksSub.Out():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the node to produce a block")
+	}
+
+	tx := types.Tx("foo=bar")
+	_, err = n.rpcEnv.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	require.NoError(t, err)
+
+	require.NoError(t, n.Stop())
+
+	// txs are rejected once the node shuts down
+	_, err = n.rpcEnv.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	require.ErrorIs(t, err, ctypes.ErrShuttingDown)
+
+	// the last block was fully committed
+	state, err := n.stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, n.blockStore.Height(), state.LastBlockHeight)
+}
+
 func TestNodeDelayedStart(t *testing.T) {
 	config := cfg.ResetTestRoot("node_delayed_start_test")
 	defer os.RemoveAll(config.RootDir)
@@ -117,6 +181,29 @@ func TestNodeSetAppVersion(t *testing.T) {
 	assert.Equal(t, n.nodeInfo.ProtocolVersion.App, appVersion)
 }
 
+func TestNodeCheckStoreVersion(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	current := sm.CurrentStoreVersion()
+
+	// the version is recorded on a new store
+	require.NoError(t, checkStoreVersion(stateStore, false, log.TestingLogger()))
+	stored, err := stateStore.LoadStoreVersion()
+	require.NoError(t, err)
+	assert.Equal(t, current, *stored)
+
+	// state written by a newer version is refused, unless downgrades are allowed
+	newer := tmstate.StoreVersion{Software: current.Software, StateFormat: current.StateFormat + 1}
+	require.NoError(t, stateStore.SaveStoreVersion(newer))
+	err = checkStoreVersion(stateStore, false, log.TestingLogger())
+	assert.ErrorAs(t, err, &sm.ErrStoreDowngrade{})
+	assert.Contains(t, err.Error(), "allow-downgrade")
+
+	require.NoError(t, checkStoreVersion(stateStore, true, log.TestingLogger()))
+	stored, err = stateStore.LoadStoreVersion()
+	require.NoError(t, err)
+	assert.Equal(t, current, *stored)
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -525,10 +612,69 @@ func TestNodeNewSeedNode(t *testing.T) {
 
 	err = n.Start()
 	require.NoError(t, err)
+	defer n.Stop() //nolint:errcheck // ignore for tests
 
 	assert.True(t, n.pexReactor.IsRunning())
 }
 
+func TestNodeNewHeaderNode(t *testing.T) {
+	// start a full node to sync the headers from
+	config := cfg.ResetTestRoot("node_new_header_node_test")
+	defer os.RemoveAll(config.RootDir)
+	rpcAddr := freeAddr(t)
+	config.P2P.ListenAddress = "tcp://" + freeAddr(t)
+	config.RPC.ListenAddress = "tcp://" + rpcAddr
+	config.RPC.GRPCListenAddress = ""
+
+	fullNode, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, fullNode.Start())
+	defer fullNode.Stop() //nolint:errcheck // ignore for tests
+	require.Eventually(t, func() bool {
+		return fullNode.BlockStore().Height() >= 3
+	}, 10*time.Second, 100*time.Millisecond)
+
+	headerConfig := cfg.ResetTestRoot("node_new_header_node_test")
+	defer os.RemoveAll(headerConfig.RootDir)
+	headerConfig.Mode = cfg.ModeHeader
+	headerConfig.RPC.ListenAddress = "tcp://" + freeAddr(t)
+	headerConfig.StateSync.RPCServers = []string{rpcAddr, rpcAddr}
+	// the first block has the genesis time, which is too old to be trusted
+	headerConfig.StateSync.TrustHeight = 2
+	headerConfig.StateSync.TrustHash = fullNode.BlockStore().LoadBlockMeta(2).BlockID.Hash.String()
+	require.NoError(t, headerConfig.ValidateBasic())
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(headerConfig.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := NewHeaderNode(headerConfig,
+		DefaultDBProvider,
+		nodeKey,
+		func() (*types.GenesisDoc, error) { return fullNode.GenesisDoc(), nil },
+		log.TestingLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// the headers, commits and validator sets are synced, but not the blocks
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Height() >= 3
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.EqualValues(t, 2, n.BlockStore().Base())
+	for h := int64(2); h <= 3; h++ {
+		assert.Equal(t, fullNode.BlockStore().LoadBlockMeta(h).BlockID, n.BlockStore().LoadBlockMeta(h).BlockID)
+		assert.NotNil(t, n.BlockStore().LoadBlockCommit(h))
+		assert.Nil(t, n.BlockStore().LoadBlock(h))
+		_, err := n.stateStore.LoadValidators(h)
+		assert.NoError(t, err)
+	}
+
+	// the header node has no p2p stack nor app
+	assert.Nil(t, n.transport)
+	assert.Nil(t, n.proxyApp)
+	assert.Nil(t, n.consensusReactor)
+}
+
 func TestNodeSetEventSink(t *testing.T) {
 	config := cfg.ResetTestRoot("node_app_version_test")
 	defer os.RemoveAll(config.RootDir)
@@ -622,6 +768,30 @@ func TestNodeSetEventSink(t *testing.T) {
 	assert.Equal(t, e, err)
 }
 
+func TestIndexerRetainHeight(t *testing.T) {
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	testCases := []struct {
+		name            string
+		pruneWithBlocks bool
+		retainBlocks    int64
+		height          int64
+		expected        int64
+	}{
+		{"with blocks, none pruned", true, 0, 10, 0},
+		{"retain blocks", false, 3, 10, 8},
+		{"retain more blocks than indexed", false, 30, 10, 0},
+		{"retain blocks and with blocks", true, 3, 10, 8},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := &cfg.TxIndexConfig{PruneWithBlocks: tc.pruneWithBlocks, RetainBlocks: tc.retainBlocks}
+			assert.Equal(t, tc.expected, indexerRetainHeight(config, blockStore)(tc.height))
+		})
+	}
+}
+
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	privVals := make([]types.PrivValidator, nVals)
 	vals := make([]types.GenesisValidator, nVals)
@@ -657,3 +827,32 @@ func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	}
 	return s, stateDB, privVals
 }
+
+func TestNodeMultiplex(t *testing.T) {
+	config := cfg.ResetTestRoot("node_multiplex_test")
+	defer os.RemoveAll(config.RootDir)
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	config.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", port)
+	config.RPC.Multiplex = true
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() // nolint:errcheck
+
+	// the p2p, RPC and gRPC connections share the p2p port
+	require.Len(t, n.transport.Endpoints(), 1)
+	assert.EqualValues(t, port, n.transport.Endpoints()[0].Port)
+
+	res, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/status", port))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	client := coregrpc.StartGRPCClient(fmt.Sprintf("tcp://127.0.0.1:%d", port))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = client.Ping(ctx, &coregrpc.RequestPing{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,258 @@
+package node
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+)
+
+// errPreflightUnsupported is returned by checks which aren't supported on
+// this platform. Such checks are skipped.
+var errPreflightUnsupported = errors.New("not supported on this platform")
+
+// preflightCheck is a check of the environment the node runs in. It returns
+// an error describing the problem and how to fix it if the check fails.
+type preflightCheck struct {
+	name  string
+	check func() error
+}
+
+// runPreflightChecks runs the preflight checks enabled in the config. Failed
+// checks are logged, and if the config is strict, an error listing them is
+// returned so that the node doesn't start.
+func runPreflightChecks(config *cfg.Config, logger log.Logger) error {
+	if !config.Preflight.Enable {
+		return nil
+	}
+
+	var failed []string
+	for _, c := range preflightChecks(config) {
+		err := c.check()
+		switch {
+		case err == nil:
+			logger.Debug("preflight check passed", "check", c.name)
+		case errors.Is(err, errPreflightUnsupported):
+			logger.Debug("skipping preflight check", "check", c.name, "err", err)
+		default:
+			logger.Error("preflight check failed", "check", c.name, "err", err)
+			failed = append(failed, fmt.Sprintf("%s: %v", c.name, err))
+		}
+	}
+
+	if len(failed) > 0 && config.Preflight.Strict {
+		return fmt.Errorf("preflight checks failed (set preflight.strict = false to start anyway):\n%s",
+			strings.Join(failed, "\n"))
+	}
+	return nil
+}
+
+// preflightChecks returns the checks enabled in the config.
+func preflightChecks(config *cfg.Config) []preflightCheck {
+	pc := config.Preflight
+
+	var checks []preflightCheck
+	if pc.NTPServer != "" {
+		checks = append(checks, preflightCheck{"clock", func() error {
+			return checkClockSkew(pc.NTPServer, pc.MaxClockSkew, pc.Timeout)
+		}})
+	}
+	if pc.MinFreeDiskSpace > 0 {
+		checks = append(checks, preflightCheck{"disk space", func() error {
+			return checkFreeDiskSpace(config.DBDir(), uint64(pc.MinFreeDiskSpace))
+		}})
+	}
+	if pc.MinOpenFiles > 0 {
+		checks = append(checks, preflightCheck{"open files", func() error {
+			return checkOpenFilesLimit(uint64(pc.MinOpenFiles))
+		}})
+	}
+	checks = append(checks, preflightCheck{"ports", func() error {
+		return checkListenAddrs(preflightListenAddrs(config))
+	}})
+
+	if protocol, addr := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr); protocol == "grpc" &&
+		config.Mode != cfg.ModeSeed && config.Mode != cfg.ModeHeader {
+		checks = append(checks, preflightCheck{"remote signer", func() error {
+			return checkReachable(addr, pc.Timeout)
+		}})
+	}
+	return checks
+}
+
+// preflightListenAddrs returns the addresses the node will listen on, with
+// their config keys.
+func preflightListenAddrs(config *cfg.Config) map[string]string {
+	addrs := map[string]string{}
+	// header nodes have no p2p stack
+	if config.Mode != cfg.ModeHeader {
+		addrs["p2p.laddr"] = config.P2P.ListenAddress
+	}
+	if config.Mode == cfg.ModeSeed {
+		return addrs
+	}
+
+	for i, addr := range tmstrings.SplitAndTrimEmpty(config.RPC.ListenAddress, ",", " ") {
+		addrs[fmt.Sprintf("rpc.laddr[%d]", i)] = addr
+	}
+	if config.Instrumentation.Prometheus {
+		addrs["instrumentation.prometheus-listen-addr"] = config.Instrumentation.PrometheusListenAddr
+	}
+	if config.Mode == cfg.ModeHeader {
+		return addrs
+	}
+	addrs["rpc.grpc-laddr"] = config.RPC.GRPCListenAddress
+	addrs["rpc.pprof-laddr"] = config.RPC.PprofListenAddress
+	// the node listens for connections of a remote signer, unless it is a
+	// gRPC server
+	if protocol, _ := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr); protocol != "grpc" {
+		addrs["priv-validator-laddr"] = config.PrivValidatorListenAddr
+	}
+	return addrs
+}
+
+// checkListenAddrs checks that the node can listen on the given TCP
+// addresses. Empty and non-TCP addresses are ignored.
+func checkListenAddrs(addrs map[string]string) error {
+	var unavailable []string
+	for key, protoAddr := range addrs {
+		if protoAddr == "" {
+			continue
+		}
+		protocol, addr := tmnet.ProtocolAndAddress(protoAddr)
+		if protocol != "tcp" {
+			continue
+		}
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			unavailable = append(unavailable, fmt.Sprintf("%s %s (%v)", key, addr, err))
+			continue
+		}
+		if err := ln.Close(); err != nil {
+			return err
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("can't listen on %s; stop the process using the port or change the address",
+			strings.Join(unavailable, ", "))
+	}
+	return nil
+}
+
+// checkReachable checks that a TCP connection can be opened to addr.
+func checkReachable(addr string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return fmt.Errorf("can't reach %s: %w; make sure the remote signer is running", addr, err)
+	}
+	return conn.Close()
+}
+
+// checkFreeDiskSpace checks the free space on the disk of dir. If dir
+// doesn't exist yet, its closest existing parent is checked.
+func checkFreeDiskSpace(dir string, minFree uint64) error {
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	free, err := freeDiskSpace(dir)
+	if err != nil {
+		return err
+	}
+	if free < minFree {
+		return fmt.Errorf("only %d bytes are free on the disk of %s, need at least %d", free, dir, minFree)
+	}
+	return nil
+}
+
+// checkOpenFilesLimit checks the limit of open file descriptors.
+func checkOpenFilesLimit(minLimit uint64) error {
+	limit, err := openFilesLimit()
+	if err != nil {
+		return err
+	}
+	if limit < minLimit {
+		return fmt.Errorf("the open files limit is %d, need at least %d; raise it with `ulimit -n`",
+			limit, minLimit)
+	}
+	return nil
+}
+
+// checkClockSkew compares the local clock with the clock of an NTP server.
+func checkClockSkew(server string, maxSkew, timeout time.Duration) error {
+	offset, err := ntpOffset(server, timeout)
+	if err != nil {
+		return fmt.Errorf("can't query NTP server %s: %w", server, err)
+	}
+	if offset > maxSkew || offset < -maxSkew {
+		return fmt.Errorf("the clock is off by %v from NTP server %s, max is %v; sync it, e.g. with ntpd or chrony",
+			offset, server, maxSkew)
+	}
+	return nil
+}
+
+const ntpPacketSize = 48
+
+// ntpEpoch is the start of the NTP timescale.
+var ntpEpoch = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ntpOffset queries an NTP server with a single SNTP request and returns how
+// far the local clock is ahead of the server's.
+func ntpOffset(server string, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = 0x1b // no leap second warning, version 3, client mode
+
+	sent := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+
+	switch {
+	case n < ntpPacketSize:
+		return 0, fmt.Errorf("short response of %d bytes", n)
+	case resp[0]&0x7 != 4:
+		return 0, fmt.Errorf("response in mode %d, expected server mode", resp[0]&0x7)
+	case resp[1] == 0:
+		return 0, errors.New("server refused the request")
+	}
+
+	serverReceived := ntpTime(resp[32:40])
+	serverSent := ntpTime(resp[40:48])
+	return (sent.Sub(serverReceived) + received.Sub(serverSent)) / 2, nil
+}
+
+// ntpTime decodes an NTP timestamp.
+func ntpTime(b []byte) time.Time {
+	secs := binary.BigEndian.Uint32(b[0:4])
+	frac := binary.BigEndian.Uint32(b[4:8])
+	nanos := (uint64(frac) * uint64(time.Second)) >> 32
+	return ntpEpoch.Add(time.Duration(secs)*time.Second + time.Duration(nanos))
+}
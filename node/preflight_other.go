@@ -0,0 +1,11 @@
+// +build !linux,!darwin,!freebsd
+
+package node
+
+func freeDiskSpace(dir string) (uint64, error) {
+	return 0, errPreflightUnsupported
+}
+
+func openFilesLimit() (uint64, error) {
+	return 0, errPreflightUnsupported
+}
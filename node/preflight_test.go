@@ -0,0 +1,129 @@
+package node
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// startNTPServer starts a fake NTP server whose clock is ahead of the local
+// clock by offset.
+func startNTPServer(t *testing.T, offset time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	putTime := func(b []byte, t time.Time) {
+		d := t.Sub(ntpEpoch)
+		secs := d / time.Second
+		frac := (uint64(d%time.Second) << 32) / uint64(time.Second)
+		binary.BigEndian.PutUint32(b[0:4], uint32(secs))
+		binary.BigEndian.PutUint32(b[4:8], uint32(frac))
+	}
+
+	go func() {
+		buf := make([]byte, ntpPacketSize)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := make([]byte, ntpPacketSize)
+			resp[0] = 0x1c // version 3, server mode
+			resp[1] = 1    // stratum
+			now := time.Now().Add(offset)
+			putTime(resp[32:40], now)
+			putTime(resp[40:48], now)
+			if _, err := conn.WriteTo(resp, addr); err != nil {
+				return
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestNTPOffset(t *testing.T) {
+	addr := startNTPServer(t, 5*time.Second)
+
+	offset, err := ntpOffset(addr, time.Second)
+	require.NoError(t, err)
+	assert.InDelta(t, -5*time.Second, offset, float64(100*time.Millisecond))
+
+	assert.Error(t, checkClockSkew(addr, time.Second, time.Second))
+	assert.NoError(t, checkClockSkew(addr, 10*time.Second, time.Second))
+}
+
+func TestCheckListenAddrs(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	err = checkListenAddrs(map[string]string{
+		"p2p.laddr": "tcp://127.0.0.1:0",
+		"rpc.laddr": "unix:///tmp/rpc.sock",
+		"rpc.grpc":  "",
+	})
+	require.NoError(t, err)
+
+	err = checkListenAddrs(map[string]string{
+		"p2p.laddr": "tcp://" + ln.Addr().String(),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "p2p.laddr")
+}
+
+func TestCheckFreeDiskSpace(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data", "missing")
+
+	err := checkFreeDiskSpace(dir, 1)
+	if err == errPreflightUnsupported {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+	assert.Error(t, checkFreeDiskSpace(dir, math.MaxUint64))
+}
+
+func TestCheckOpenFilesLimit(t *testing.T) {
+	err := checkOpenFilesLimit(1)
+	if err == errPreflightUnsupported {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+	assert.Error(t, checkOpenFilesLimit(math.MaxUint64))
+}
+
+func TestRunPreflightChecks(t *testing.T) {
+	config := cfg.ResetTestRoot("node_preflight_test")
+	defer os.RemoveAll(config.RootDir)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	config.P2P.ListenAddress = "tcp://" + ln.Addr().String()
+	config.RPC.ListenAddress = ""
+	config.Preflight.MinFreeDiskSpace = 0
+	config.Preflight.MinOpenFiles = 0
+
+	// failed checks are only logged by default
+	require.NoError(t, runPreflightChecks(config, log.TestingLogger()))
+
+	config.Preflight.Strict = true
+	err = runPreflightChecks(config, log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "p2p.laddr")
+
+	config.Preflight.Enable = false
+	require.NoError(t, runPreflightChecks(config, log.TestingLogger()))
+}
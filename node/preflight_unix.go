@@ -0,0 +1,24 @@
+// +build linux darwin freebsd
+
+package node
+
+import "syscall"
+
+// freeDiskSpace returns the number of bytes available to unprivileged users
+// on the disk of dir.
+func freeDiskSpace(dir string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
+
+// openFilesLimit returns the soft limit of open file descriptors.
+func openFilesLimit() (uint64, error) {
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return 0, err
+	}
+	return uint64(limit.Cur), nil
+}
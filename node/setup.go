@@ -3,8 +3,11 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"net"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
@@ -20,11 +23,16 @@ import (
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/evidence"
+	"github.com/tendermint/tendermint/headersync"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	tmStrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/light"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
 	"github.com/tendermint/tendermint/p2p/pex"
 	protop2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
@@ -32,10 +40,13 @@ import (
 	"github.com/tendermint/tendermint/state/indexer"
 	kv "github.com/tendermint/tendermint/state/indexer/sink/kv"
 	null "github.com/tendermint/tendermint/state/indexer/sink/null"
+	"github.com/tendermint/tendermint/state/indexer/sink/parquet"
 	psql "github.com/tendermint/tendermint/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/state/indexer/sink/stream"
 	"github.com/tendermint/tendermint/statesync"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 	"github.com/tendermint/tendermint/version"
 )
 
@@ -45,14 +56,63 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	blockStore = store.NewBlockStore(blockStoreDB,
+		store.WithSyncPolicy(blockStoreSyncPolicy(config.BlockStoreSync), config.BlockStoreSyncInterval))
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+// blockStoreSyncPolicy returns the block store sync policy of the config.
+func blockStoreSyncPolicy(policy string) store.SyncPolicy {
+	switch policy {
+	case cfg.BlockStoreSyncInterval:
+		return store.SyncInterval
+	case cfg.BlockStoreSyncNever:
+		return store.SyncNever
+	default:
+		return store.SyncAlways
+	}
+}
+
+// checkStoreVersion refuses to run on state written by a newer version,
+// unless downgrades are allowed, and records the current version.
+func checkStoreVersion(stateStore sm.Store, allowDowngrade bool, logger log.Logger) error {
+	stored, err := stateStore.LoadStoreVersion()
+	if err != nil {
+		return fmt.Errorf("failed to load the state store version: %w", err)
+	}
+
+	current := sm.CurrentStoreVersion()
+	if err := sm.CheckStoreVersion(stored, current); err != nil {
+		if !allowDowngrade {
+			return fmt.Errorf("%w. Upgrade to version %s or newer, or set allow-downgrade to start anyway",
+				err, stored.Software)
+		}
+		logger.Error("Starting on state written by a newer version, as allow-downgrade is set", "err", err)
+	}
+
+	if stored == nil || *stored != current {
+		if err := stateStore.SaveStoreVersion(current); err != nil {
+			return fmt.Errorf("failed to save the state store version: %w", err)
+		}
+	}
+	return nil
+}
+
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	config *cfg.Config,
+	logger log.Logger,
+	chainID string,
+) (proxy.AppConns, error) {
+	metrics := proxy.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = proxy.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+	proxyApp := proxy.NewAppConns(clientCreator,
+		proxy.MempoolConnections(config.Mempool.CheckTxConcurrency),
+		proxy.WithMetrics(metrics))
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -69,12 +129,31 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
+// indexerRetainHeight returns the height below which the indexed events are
+// pruned, given the latest indexed height: the base of the block store if they
+// are pruned with the blocks, or the first of the latest RetainBlocks heights,
+// whichever is higher.
+func indexerRetainHeight(config *cfg.TxIndexConfig, blockStore *store.BlockStore) func(int64) int64 {
+	return func(height int64) int64 {
+		var retainHeight int64
+		if config.PruneWithBlocks {
+			retainHeight = blockStore.Base()
+		}
+		if config.RetainBlocks > 0 && height-config.RetainBlocks+1 > retainHeight {
+			retainHeight = height - config.RetainBlocks + 1
+		}
+		return retainHeight
+	}
+}
+
 func createAndStartIndexerService(
 	config *cfg.Config,
 	dbProvider DBProvider,
 	eventBus *types.EventBus,
 	logger log.Logger,
 	chainID string,
+	eventSchema *types.EventSchema,
+	blockStore *store.BlockStore,
 ) (*indexer.Service, []indexer.EventSink, error) {
 
 	eventSinks := []indexer.EventSink{}
@@ -101,7 +180,9 @@ loop:
 			if err != nil {
 				return nil, nil, err
 			}
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
+			es := kv.NewEventSink(store)
+			es.(*kv.EventSink).SetEventSchema(eventSchema)
+			eventSinks = append(eventSinks, es)
 		case string(indexer.PSQL):
 			conn := config.TxIndex.PsqlConn
 			if conn == "" {
@@ -112,6 +193,34 @@ loop:
 				return nil, nil, err
 			}
 			eventSinks = append(eventSinks, es)
+		case string(indexer.STREAM):
+			if config.TxIndex.StreamURL == "" {
+				return nil, nil, errors.New("the stream url cannot be empty")
+			}
+			options, err := natsOptions(config.TxIndex)
+			if err != nil {
+				return nil, nil, err
+			}
+			publisher, err := stream.NewNATSPublisher(config.TxIndex.StreamURL, options...)
+			if err != nil {
+				return nil, nil, err
+			}
+			eventSinks = append(eventSinks, stream.NewEventSink(
+				publisher,
+				stream.Topics{
+					Block:            config.TxIndex.StreamBlockTopic,
+					Tx:               config.TxIndex.StreamTxTopic,
+					ValidatorUpdates: config.TxIndex.StreamValidatorUpdatesTopic,
+				},
+				chainID,
+				logger.With("module", "txindex", "sink", indexer.STREAM),
+			))
+		case string(indexer.PARQUET):
+			es, err := parquet.NewEventSink(config.TxIndex.ParquetPath(), chainID, config.TxIndex.ParquetRotateBlocks)
+			if err != nil {
+				return nil, nil, err
+			}
+			eventSinks = append(eventSinks, es)
 		default:
 			return nil, nil, errors.New("unsupported event sink type")
 		}
@@ -121,7 +230,12 @@ loop:
 		eventSinks = []indexer.EventSink{null.NewEventSink()}
 	}
 
-	indexerService := indexer.NewIndexerService(eventSinks, eventBus)
+	var options []indexer.ServiceOption
+	if config.TxIndex.PruneWithBlocks || config.TxIndex.RetainBlocks > 0 {
+		options = append(options, indexer.WithRetainHeight(indexerRetainHeight(config.TxIndex, blockStore)))
+	}
+
+	indexerService := indexer.NewIndexerService(eventSinks, eventBus, options...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
@@ -131,18 +245,43 @@ loop:
 	return indexerService, eventSinks, nil
 }
 
+// natsOptions returns the options of the NATS publisher of the "stream" event
+// sink.
+func natsOptions(config *cfg.TxIndexConfig) ([]stream.NATSOption, error) {
+	path := config.StreamTLSCAPath()
+	if path == "" {
+		return nil, nil
+	}
+
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the stream tls ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in the stream tls ca file %s", path)
+	}
+	return []stream.NATSOption{
+		stream.WithNATSTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}),
+	}, nil
+}
+
 func doHandshake(
 	stateStore sm.Store,
 	state sm.State,
 	blockStore sm.BlockStore,
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
+	eventSchema *types.EventSchema,
 	proxyApp proxy.AppConns,
+	appStateChunkSize int,
 	consensusLogger log.Logger) error {
 
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetEventSchema(eventSchema)
+	handshaker.SetAppStateChunkSize(appStateChunkSize)
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -191,24 +330,31 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 func createMempoolReactor(
 	config *cfg.Config,
 	proxyApp proxy.AppConns,
+	txSanityCheck func([]byte) error,
 	state sm.State,
 	memplMetrics *mempl.Metrics,
+	eventBus *types.EventBus,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
+	active bool,
 ) (*p2p.ReactorShim, *mempl.Reactor, *mempl.CListMempool) {
 
 	logger = logger.With("module", "mempool")
-	mempool := mempl.NewCListMempool(
-		config.Mempool,
-		proxyApp.Mempool(),
-		state.LastBlockHeight,
+	options := []mempl.CListMempoolOption{
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
-	)
+	}
+	if txSanityCheck != nil {
+		options = append(options, mempl.WithTxSanityCheck(func(tx types.Tx) error {
+			return txSanityCheck(tx)
+		}))
+	}
+	mempool := mempl.NewCListMempool(config.Mempool, proxyApp.Mempool(), state.LastBlockHeight, options...)
 
 	mempool.SetLogger(logger)
+	mempool.SetEventBus(eventBus)
 
 	channelShims := mempl.GetChannelShims(config.Mempool)
 	reactorShim := p2p.NewReactorShim(logger, "MempoolShim", channelShims)
@@ -218,7 +364,7 @@ func createMempoolReactor(
 		peerUpdates *p2p.PeerUpdates
 	)
 
-	if config.P2P.DisableLegacy {
+	if config.P2P.DisableLegacy && active {
 		channels = makeChannelsFromShims(router, channelShims)
 		peerUpdates = peerManager.Subscribe()
 	} else {
@@ -235,7 +381,7 @@ func createMempoolReactor(
 		peerUpdates,
 	)
 
-	if config.Consensus.WaitForTxs() {
+	if config.Consensus.WaitForTxs() || config.Consensus.DevMode {
 		mempool.EnableTxsAvailable()
 	}
 
@@ -250,6 +396,7 @@ func createEvidenceReactor(
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
+	active bool,
 ) (*p2p.ReactorShim, *evidence.Reactor, *evidence.Pool, error) {
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
@@ -269,7 +416,7 @@ func createEvidenceReactor(
 		peerUpdates *p2p.PeerUpdates
 	)
 
-	if config.P2P.DisableLegacy {
+	if config.P2P.DisableLegacy && active {
 		channels = makeChannelsFromShims(router, evidence.ChannelShims)
 		peerUpdates = peerManager.Subscribe()
 	} else {
@@ -287,6 +434,59 @@ func createEvidenceReactor(
 	return reactorShim, evidenceReactor, evidencePool, nil
 }
 
+func createMetadataReactor(
+	config *cfg.Config,
+	nodeKey p2p.NodeKey,
+	peerManager *p2p.PeerManager,
+	router *p2p.Router,
+	logger log.Logger,
+) (*p2p.ReactorShim, *metadata.Reactor, error) {
+	logger = logger.With("module", "metadata")
+	reactorShim := p2p.NewReactorShim(logger, "MetadataShim", metadata.ChannelShims)
+
+	var own *metadata.SignedNodeMetadata
+	if config.P2P.PublishMetadata {
+		record, err := metadata.Sign(metadata.NodeMetadata{
+			Moniker:   config.Moniker,
+			Website:   config.P2P.MetadataWebsite,
+			Contact:   config.P2P.MetadataContact,
+			Version:   version.TMVersion,
+			Timestamp: tmtime.Now(),
+		}, nodeKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign node metadata: %w", err)
+		}
+		own = &record
+	}
+
+	var (
+		channels    map[p2p.ChannelID]*p2p.Channel
+		peerUpdates *p2p.PeerUpdates
+	)
+
+	if config.P2P.DisableLegacy {
+		channels = makeChannelsFromShims(router, metadata.ChannelShims)
+		peerUpdates = peerManager.Subscribe()
+	} else {
+		channels = getChannelsFromShim(reactorShim)
+		peerUpdates = reactorShim.PeerUpdates
+	}
+
+	metadataReactor, err := metadata.NewReactor(
+		logger,
+		channels[metadata.MetadataChannel],
+		peerUpdates,
+		peerManager,
+		metadata.NewBook(metadata.DefaultMaxBookSize),
+		own,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reactorShim, metadataReactor, nil
+}
+
 func createBlockchainReactor(
 	logger log.Logger,
 	config *cfg.Config,
@@ -294,9 +494,11 @@ func createBlockchainReactor(
 	blockExec *sm.BlockExecutor,
 	blockStore *store.BlockStore,
 	csReactor *cs.Reactor,
+	eventBus *types.EventBus,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	fastSync bool,
+	active bool,
 ) (*p2p.ReactorShim, service.Service, error) {
 
 	logger = logger.With("module", "blockchain")
@@ -310,7 +512,7 @@ func createBlockchainReactor(
 			peerUpdates *p2p.PeerUpdates
 		)
 
-		if config.P2P.DisableLegacy {
+		if config.P2P.DisableLegacy && active {
 			channels = makeChannelsFromShims(router, bcv0.ChannelShims)
 			peerUpdates = peerManager.Subscribe()
 		} else {
@@ -321,10 +523,13 @@ func createBlockchainReactor(
 		reactor, err := bcv0.NewReactor(
 			logger, state.Copy(), blockExec, blockStore, csReactor,
 			channels[bcv0.BlockchainChannel], peerUpdates, fastSync,
+			bcv0.ReactorPeerHeights(peerManager),
+			bcv0.ReactorStallTimeout(config.FastSync.StallTimeout),
 		)
 		if err != nil {
 			return nil, nil, err
 		}
+		reactor.SetEventBus(eventBus)
 
 		return reactorShim, reactor, nil
 
@@ -339,6 +544,25 @@ func createBlockchainReactor(
 	}
 }
 
+// checkEmptyBlocksInterval warns if an idle chain could go without a block for
+// longer than light clients trust a header for. The trusting period is
+// expected to be below the evidence max age.
+func checkEmptyBlocksInterval(config *cfg.ConsensusConfig, params types.ConsensusParams, logger log.Logger) {
+	if !config.WaitForTxs() {
+		return
+	}
+
+	switch interval := config.CreateEmptyBlocksInterval; {
+	case interval == 0:
+		logger.Info("empty blocks are disabled without an interval; " +
+			"light clients may have to re-establish trust if the chain is idle for too long")
+	case interval >= params.Evidence.MaxAgeDuration:
+		logger.Error("create-empty-blocks-interval exceeds the evidence max age; "+
+			"light clients may have to re-establish trust if the chain is idle for too long",
+			"interval", interval, "max_age_duration", params.Evidence.MaxAgeDuration)
+	}
+}
+
 func createConsensusReactor(
 	config *cfg.Config,
 	state sm.State,
@@ -363,8 +587,10 @@ func createConsensusReactor(
 		mempool,
 		evidencePool,
 		cs.StateMetrics(csMetrics),
+		cs.StateStopTimeout(config.ShutdownDrainTimeout),
 	)
 	consensusState.SetLogger(logger)
+	checkEmptyBlocksInterval(config.Consensus, state.ConsensusParams, logger)
 	if privValidator != nil && config.Mode == cfg.ModeValidator {
 		consensusState.SetPrivValidator(privValidator)
 	}
@@ -394,6 +620,7 @@ func createConsensusReactor(
 		peerUpdates,
 		waitSync,
 		cs.ReactorMetrics(csMetrics),
+		cs.ReactorPeerHeights(peerManager),
 	)
 
 	// Services which will be publishing and/or subscribing for messages (events)
@@ -410,6 +637,8 @@ func createTransport(logger log.Logger, config *cfg.Config) *p2p.MConnTransport
 			MaxAcceptedConnections: uint32(config.P2P.MaxNumInboundPeers +
 				len(tmStrings.SplitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " ")),
 			),
+			AuthenticateTimeout: config.P2P.AuthenticateTimeout,
+			AcceptTLS:           config.P2P.AcceptTLS,
 		},
 	)
 }
@@ -528,10 +757,12 @@ func createSwitch(
 	stateSyncReactor *p2p.ReactorShim,
 	consensusReactor *p2p.ReactorShim,
 	evidenceReactor *p2p.ReactorShim,
+	metadataReactor *p2p.ReactorShim,
 	proxyApp proxy.AppConns,
 	nodeInfo p2p.NodeInfo,
 	nodeKey p2p.NodeKey,
 	p2pLogger log.Logger,
+	reactors reactorOverrides,
 ) *p2p.Switch {
 
 	var (
@@ -592,12 +823,21 @@ func createSwitch(
 	)
 
 	sw.SetLogger(p2pLogger)
+	// The disabled reactors are replaced with stubs, which drop the messages
+	// of their channels.
+	addReactor := func(name string, reactor p2p.Reactor) {
+		if reactors.isDisabled(name) {
+			reactor = newDisabledReactor(name, reactor.GetChannels())
+		}
+		sw.AddReactor(name, reactor)
+	}
 	if config.Mode != cfg.ModeSeed {
-		sw.AddReactor("MEMPOOL", mempoolReactor)
-		sw.AddReactor("BLOCKCHAIN", bcReactor)
-		sw.AddReactor("CONSENSUS", consensusReactor)
-		sw.AddReactor("EVIDENCE", evidenceReactor)
-		sw.AddReactor("STATESYNC", stateSyncReactor)
+		addReactor(ReactorMempool, mempoolReactor)
+		addReactor(ReactorBlockSync, bcReactor)
+		addReactor("CONSENSUS", consensusReactor)
+		addReactor(ReactorEvidence, evidenceReactor)
+		addReactor(ReactorStateSync, stateSyncReactor)
+		addReactor("METADATA", metadataReactor)
 	}
 
 	sw.SetNodeInfo(nodeInfo)
@@ -610,7 +850,9 @@ func createSwitch(
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	p2pLogger log.Logger, nodeKey p2p.NodeKey) (pex.AddrBook, error) {
 
-	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict,
+		pex.AddrBookBackups(config.P2P.AddrBookBackupDir(), config.P2P.AddrBookBackupInterval,
+			config.P2P.AddrBookBackups))
 	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
 
 	// Add ourselves to addrbook to prevent dialing ourselves
@@ -647,6 +889,8 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 		// https://github.com/tendermint/tendermint/issues/3523
 		SeedDisconnectWaitPeriod:     28 * time.Hour,
 		PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+		DialLatencyProbe:             config.P2P.DialLatencyProbe,
+		LowLatencyPeerFraction:       config.P2P.LowLatencyPeerFraction,
 	}
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook, reactorConfig)
@@ -660,15 +904,33 @@ func createPEXReactorV2(
 	logger log.Logger,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
+	nodeInfo p2p.NodeInfo,
 ) (*pex.ReactorV2, error) {
 
+	// if we rotated our node key, publish a forwarding record from the
+	// previous one
+	var own *p2p.ForwardingRecord
+	if tmos.FileExists(config.PreviousNodeKeyFile()) {
+		previousKey, err := p2p.LoadNodeKey(config.PreviousNodeKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous node key %s: %w", config.PreviousNodeKeyFile(), err)
+		}
+		if previousKey.ID != nodeInfo.NodeID {
+			own, err = p2p.NewForwardingRecord(previousKey, nodeInfo)
+			if err != nil {
+				return nil, err
+			}
+			logger.Info("Publishing node key forwarding record", "previous_id", previousKey.ID, "id", nodeInfo.NodeID)
+		}
+	}
+
 	channel, err := router.OpenChannel(pex.ChannelDescriptor(), &protop2p.PexMessage{}, 4096)
 	if err != nil {
 		return nil, err
 	}
 
 	peerUpdates := peerManager.Subscribe()
-	return pex.NewReactorV2(logger, peerManager, channel, peerUpdates), nil
+	return pex.NewReactorV2(logger, peerManager, channel, peerUpdates, own), nil
 }
 
 func makeNodeInfo(
@@ -715,12 +977,14 @@ func makeNodeInfo(
 			byte(evidence.EvidenceChannel),
 			byte(statesync.SnapshotChannel),
 			byte(statesync.ChunkChannel),
+			byte(metadata.MetadataChannel),
 		},
 		Moniker: config.Moniker,
 		Other: p2p.NodeInfoOther{
 			TxIndex:    txIndexerStatus,
 			RPCAddress: config.RPC.ListenAddress,
 		},
+		Features: p2p.DefaultFeatures,
 	}
 
 	if config.P2P.PexReactor {
@@ -760,6 +1024,7 @@ func makeSeedNodeInfo(
 			TxIndex:    "off",
 			RPCAddress: config.RPC.ListenAddress,
 		},
+		Features: p2p.DefaultFeatures,
 	}
 
 	if config.P2P.PexReactor {
@@ -777,3 +1042,35 @@ func makeSeedNodeInfo(
 	err := nodeInfo.Validate()
 	return nodeInfo, err
 }
+
+// createHeaderSyncer creates the syncer of a header-only node, which verifies
+// the light blocks fetched from the state sync RPC servers with a light client.
+// The trusted store of the light client is persisted, so that the node resumes
+// syncing from its latest trusted header when restarted.
+func createHeaderSyncer(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	chainID string,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	logger log.Logger,
+) (*headersync.Syncer, error) {
+	lightDB, err := dbProvider(&DBContext{"light", config})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	servers := config.StateSync.RPCServers
+	lc, err := light.NewHTTPClient(ctx, chainID, light.TrustOptions{
+		Period: config.StateSync.TrustPeriod,
+		Height: config.StateSync.TrustHeight,
+		Hash:   config.StateSync.TrustHashBytes(),
+	}, servers[0], servers[1:], lightdb.New(lightDB), light.Logger(logger.With("module", "light")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up light client: %w", err)
+	}
+
+	return headersync.NewSyncer(lc, stateStore, blockStore, logger.With("module", "headersync")), nil
+}
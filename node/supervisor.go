@@ -0,0 +1,250 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/p2p"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// SupervisorConfig configures the servers of a Supervisor.
+type SupervisorConfig struct {
+	// Address the admin RPC server listens on. An empty address disables it.
+	RPCListenAddress string
+
+	// Address the Prometheus server, which serves the metrics of all nodes,
+	// listens on. An empty address disables the metrics.
+	PrometheusListenAddr string
+}
+
+// Supervisor runs several independent nodes, e.g. of different networks, in
+// one process. Each node has its own home and chain ID. The nodes share one
+// Prometheus server, and an admin RPC server lists the status of all nodes.
+type Supervisor struct {
+	service.BaseService
+
+	config        SupervisorConfig
+	nodes         []*Node
+	gatherers     prometheus.Gatherers
+	rpcListener   net.Listener
+	prometheusSrv *http.Server
+}
+
+// NewSupervisor creates a node for each of the given configs with the node
+// provider. The homes and chain IDs of the nodes must be unique.
+func NewSupervisor(
+	config SupervisorConfig,
+	nodeConfigs []*cfg.Config,
+	nodeProvider Provider,
+	logger log.Logger,
+) (*Supervisor, error) {
+	if len(nodeConfigs) == 0 {
+		return nil, errors.New("no nodes to supervise")
+	}
+
+	s := &Supervisor{
+		config:    config,
+		gatherers: prometheus.Gatherers{prometheus.DefaultGatherer},
+	}
+	homes := make(map[string]bool)
+	chainIDs := make(map[string]bool)
+
+	for _, nodeConfig := range nodeConfigs {
+		if homes[nodeConfig.RootDir] {
+			return nil, fmt.Errorf("home %v is used by several nodes", nodeConfig.RootDir)
+		}
+		homes[nodeConfig.RootDir] = true
+
+		n, err := s.newNode(nodeConfig, nodeProvider, logger.With("home", nodeConfig.RootDir))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create node of %v: %w", nodeConfig.RootDir, err)
+		}
+
+		chainID := n.GenesisDoc().ChainID
+		if chainIDs[chainID] {
+			return nil, fmt.Errorf("chain %v is run by several nodes", chainID)
+		}
+		chainIDs[chainID] = true
+
+		s.nodes = append(s.nodes, n)
+	}
+
+	s.BaseService = *service.NewBaseService(logger, "Supervisor", s)
+	return s, nil
+}
+
+// newNode creates a node which reports its metrics to the shared Prometheus
+// server, if enabled.
+func (s *Supervisor) newNode(config *cfg.Config, nodeProvider Provider, logger log.Logger) (*Node, error) {
+	if s.config.PrometheusListenAddr == "" {
+		return nodeProvider(config, logger)
+	}
+
+	// The metrics are served by the supervisor rather than by the node.
+	config.Instrumentation.Prometheus = true
+	config.Instrumentation.PrometheusListenAddr = ""
+
+	// The metrics of all nodes have the same names, only the chain_id label
+	// differs, and the nodes register them with the default registerer when
+	// they are created. So each node gets its own registry, and the supervisor
+	// gathers the metrics of all of them.
+	registry := prometheus.NewRegistry()
+	defaultRegisterer := prometheus.DefaultRegisterer
+	prometheus.DefaultRegisterer = registry
+	defer func() { prometheus.DefaultRegisterer = defaultRegisterer }()
+
+	n, err := nodeProvider(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	s.gatherers = append(s.gatherers, registry)
+	return n, nil
+}
+
+// Nodes returns the supervised nodes.
+func (s *Supervisor) Nodes() []*Node {
+	return s.nodes
+}
+
+// OnStart starts the nodes and then the supervisor's servers. If a node fails
+// to start, the nodes started before it are stopped.
+func (s *Supervisor) OnStart() error {
+	for i, n := range s.nodes {
+		if err := n.Start(); err != nil {
+			s.stopNodes(s.nodes[:i])
+			return fmt.Errorf("failed to start node of chain %v: %w", n.GenesisDoc().ChainID, err)
+		}
+		s.Logger.Info("Started node", "chain_id", n.GenesisDoc().ChainID, "home", n.Config().RootDir)
+	}
+
+	if s.config.PrometheusListenAddr != "" {
+		s.prometheusSrv = s.startPrometheusServer(s.config.PrometheusListenAddr)
+	}
+
+	if s.config.RPCListenAddress != "" {
+		listener, err := s.startRPC(s.config.RPCListenAddress)
+		if err != nil {
+			s.stopNodes(s.nodes)
+			return err
+		}
+		s.rpcListener = listener
+	}
+	return nil
+}
+
+// OnStop stops the servers and then the nodes.
+func (s *Supervisor) OnStop() {
+	if s.rpcListener != nil {
+		if err := s.rpcListener.Close(); err != nil {
+			s.Logger.Error("Error closing admin rpc listener", "err", err)
+		}
+	}
+	if s.prometheusSrv != nil {
+		if err := s.prometheusSrv.Close(); err != nil {
+			s.Logger.Error("Error closing Prometheus server", "err", err)
+		}
+	}
+	s.stopNodes(s.nodes)
+}
+
+// stopNodes stops the given nodes in reverse order.
+func (s *Supervisor) stopNodes(nodes []*Node) {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		if err := n.Stop(); err != nil {
+			s.Logger.Error("failed to stop node", "chain_id", n.GenesisDoc().ChainID, "err", err)
+		}
+	}
+}
+
+func (s *Supervisor) startPrometheusServer(addr string) *http.Server {
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: promhttp.HandlerFor(s.gatherers, promhttp.HandlerOpts{}),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			// Error starting or closing listener:
+			s.Logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
+func (s *Supervisor) startRPC(listenAddr string) (net.Listener, error) {
+	config := rpcserver.DefaultConfig()
+	rpcLogger := s.Logger.With("module", "admin-rpc-server")
+
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"nodes": rpcserver.NewRPCFunc(s.NodesStatus, "", false),
+	}, rpcLogger)
+
+	listener, err := rpcserver.Listen(listenAddr, config)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if err := rpcserver.Serve(listener, mux, rpcLogger, config); err != nil {
+			s.Logger.Error("Error serving admin rpc server", "err", err)
+		}
+	}()
+	return listener, nil
+}
+
+// SupervisedNodeStatus is the status of a node run by a Supervisor.
+type SupervisedNodeStatus struct {
+	ChainID           string     `json:"chain_id"`
+	Home              string     `json:"home"`
+	Moniker           string     `json:"moniker"`
+	NodeID            p2p.NodeID `json:"node_id"`
+	Mode              string     `json:"mode"`
+	RPCAddress        string     `json:"rpc_address"`
+	Running           bool       `json:"running"`
+	LatestBlockHeight int64      `json:"latest_block_height"`
+	CatchingUp        bool       `json:"catching_up"`
+}
+
+// ResultNodesStatus is the result of the nodes admin RPC route.
+type ResultNodesStatus struct {
+	Nodes []SupervisedNodeStatus `json:"nodes"`
+}
+
+// NodesStatus returns the status of all supervised nodes, sorted by chain ID.
+// It is served by the admin RPC server as the nodes route.
+func (s *Supervisor) NodesStatus(ctx *rpctypes.Context) (*ResultNodesStatus, error) {
+	statuses := make([]SupervisedNodeStatus, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		status := SupervisedNodeStatus{
+			ChainID:    n.GenesisDoc().ChainID,
+			Home:       n.Config().RootDir,
+			Moniker:    n.Config().Moniker,
+			NodeID:     n.NodeInfo().NodeID,
+			Mode:       n.Config().Mode,
+			RPCAddress: n.Config().RPC.ListenAddress,
+			Running:    n.IsRunning(),
+		}
+		if n.Config().Mode != cfg.ModeSeed {
+			status.LatestBlockHeight = n.BlockStore().Height()
+		}
+		if n.ConsensusReactor() != nil {
+			status.CatchingUp = n.ConsensusReactor().WaitSync()
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ChainID < statuses[j].ChainID
+	})
+	return &ResultNodesStatus{Nodes: statuses}, nil
+}
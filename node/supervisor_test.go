@@ -0,0 +1,102 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func freeAddr(t *testing.T) string {
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	return fmt.Sprintf("127.0.0.1:%d", port)
+}
+
+func TestSupervisor(t *testing.T) {
+	var configs []*cfg.Config
+	for _, chainID := range []string{"chain-b", "chain-a"} {
+		config := cfg.ResetTestRootWithChainID("node_supervisor_test", chainID)
+		defer os.RemoveAll(config.RootDir)
+		config.P2P.ListenAddress = "tcp://" + freeAddr(t)
+		config.RPC.ListenAddress = "tcp://" + freeAddr(t)
+		config.RPC.GRPCListenAddress = ""
+		configs = append(configs, config)
+	}
+
+	rpcAddr, prometheusAddr := freeAddr(t), freeAddr(t)
+	s, err := NewSupervisor(SupervisorConfig{
+		RPCListenAddress:     "tcp://" + rpcAddr,
+		PrometheusListenAddr: prometheusAddr,
+	}, configs, DefaultNewNode, log.TestingLogger())
+	require.NoError(t, err)
+	require.Len(t, s.Nodes(), 2)
+
+	require.NoError(t, s.Start())
+	defer s.Stop() //nolint:errcheck // ignore for tests
+
+	// wait for both nodes to produce a block
+	for _, n := range s.Nodes() {
+		sub, err := n.EventBus().Subscribe(context.Background(), "supervisor_test", types.EventQueryNewBlock)
+		require.NoError(t, err)
+		select {
+		case <-sub.Out():
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+
+	// the admin RPC lists both nodes
+	resp, err := http.Get("http://" + rpcAddr + "/nodes")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var rpcResp rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(body, &rpcResp))
+	require.Nil(t, rpcResp.Error)
+	var result ResultNodesStatus
+	require.NoError(t, tmjson.Unmarshal(rpcResp.Result, &result))
+	require.Len(t, result.Nodes, 2)
+	for i, chainID := range []string{"chain-a", "chain-b"} {
+		assert.Equal(t, chainID, result.Nodes[i].ChainID)
+		assert.True(t, result.Nodes[i].Running)
+		assert.Positive(t, result.Nodes[i].LatestBlockHeight)
+	}
+
+	// the metrics of both nodes are served
+	resp, err = http.Get("http://" + prometheusAddr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	metrics, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(metrics), `tendermint_consensus_height{chain_id="chain-a"}`)
+	assert.Contains(t, string(metrics), `tendermint_consensus_height{chain_id="chain-b"}`)
+}
+
+func TestSupervisorDuplicateChains(t *testing.T) {
+	var configs []*cfg.Config
+	for i := 0; i < 2; i++ {
+		config := cfg.ResetTestRootWithChainID("node_supervisor_test", "chain-a")
+		defer os.RemoveAll(config.RootDir)
+		configs = append(configs, config)
+	}
+
+	_, err := NewSupervisor(SupervisorConfig{}, configs, DefaultNewNode, log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chain-a")
+}
@@ -0,0 +1,100 @@
+package node
+
+import (
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/internal/libs/sdnotify"
+)
+
+// nodeProgress is a snapshot of how far the node got. The systemd watchdog
+// is only pinged while it changes.
+type nodeProgress struct {
+	storeHeight int64
+	height      int64
+	round       int32
+	step        cstypes.RoundStepType
+}
+
+// progressWatchdog decides whether the node is healthy, i.e. made progress
+// within maxStall. A maxStall of 0 disables the check.
+type progressWatchdog struct {
+	maxStall   time.Duration
+	last       nodeProgress
+	lastChange time.Time
+}
+
+func newProgressWatchdog(maxStall time.Duration, now time.Time) *progressWatchdog {
+	return &progressWatchdog{maxStall: maxStall, lastChange: now}
+}
+
+// healthy records the current progress and returns false if the node made
+// no progress for longer than maxStall. An idle node, e.g. one syncing or
+// waiting for txs, is always healthy.
+func (w *progressWatchdog) healthy(progress nodeProgress, idle bool, now time.Time) bool {
+	if progress != w.last || idle {
+		w.last = progress
+		w.lastChange = now
+	}
+	return w.maxStall == 0 || now.Sub(w.lastChange) <= w.maxStall
+}
+
+// progress returns the current progress of the node, and whether it is idle,
+// i.e. not expected to make progress in consensus.
+func (n *Node) progress() (nodeProgress, bool) {
+	if n.config.Mode == cfg.ModeSeed {
+		return nodeProgress{}, true
+	}
+	// header nodes make progress as they sync headers
+	if n.config.Mode == cfg.ModeHeader {
+		return nodeProgress{storeHeight: n.blockStore.Height()}, false
+	}
+
+	rs := n.consensusState.GetRoundState()
+	progress := nodeProgress{
+		storeHeight: n.blockStore.Height(),
+		height:      rs.Height,
+		round:       rs.Round,
+		step:        rs.Step,
+	}
+	// While state syncing, neither the store nor consensus make progress.
+	// Blocks which are fast synced do count as progress.
+	syncing := n.consensusReactor.WaitSync() && progress.storeHeight == 0
+	waitingForTxs := n.config.Consensus.WaitForTxs() && n.mempool.Size() == 0
+	return progress, syncing || waitingForTxs
+}
+
+// notifySystemd sends a notification state to systemd, if the node runs as a
+// systemd service.
+func (n *Node) notifySystemd(state string) {
+	if _, err := sdnotify.Notify(state); err != nil {
+		n.Logger.Error("failed to notify systemd", "state", state, "err", err)
+	}
+}
+
+// systemdWatchdogRoutine pings the systemd watchdog twice per interval while
+// the node makes progress, so that systemd restarts the node if it hangs
+// rather than only if the process dies.
+func (n *Node) systemdWatchdogRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+
+	watchdog := newProgressWatchdog(n.config.SystemdWatchdogMaxStall, time.Now())
+	for {
+		select {
+		case <-ticker.C:
+			progress, idle := n.progress()
+			if !watchdog.healthy(progress, idle, time.Now()) {
+				n.Logger.Error("node made no progress; not pinging the systemd watchdog",
+					"since", watchdog.lastChange, "height", progress.height, "round", progress.round,
+					"step", progress.step)
+				continue
+			}
+			n.notifySystemd(sdnotify.Watchdog)
+
+		case <-n.Quit():
+			return
+		}
+	}
+}
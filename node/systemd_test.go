@@ -0,0 +1,77 @@
+package node
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/internal/libs/sdnotify"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestProgressWatchdog(t *testing.T) {
+	start := time.Now()
+	w := newProgressWatchdog(time.Minute, start)
+
+	p := nodeProgress{storeHeight: 1, height: 2, step: cstypes.RoundStepPropose}
+	assert.True(t, w.healthy(p, false, start.Add(30*time.Second)))
+	assert.True(t, w.healthy(p, false, start.Add(90*time.Second)))
+	assert.False(t, w.healthy(p, false, start.Add(91*time.Second)))
+
+	// an idle node is healthy
+	assert.True(t, w.healthy(p, true, start.Add(3*time.Minute)))
+	assert.True(t, w.healthy(p, false, start.Add(4*time.Minute)))
+
+	// progress makes the node healthy again
+	assert.False(t, w.healthy(p, false, start.Add(5*time.Minute)))
+	p.step = cstypes.RoundStepPrevote
+	assert.True(t, w.healthy(p, false, start.Add(5*time.Minute)))
+
+	// a max stall of 0 disables the check
+	w = newProgressWatchdog(0, start)
+	assert.True(t, w.healthy(p, false, start.Add(time.Hour)))
+}
+
+func TestNodeNotifiesSystemd(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, os.Setenv("NOTIFY_SOCKET", socket))
+	defer os.Unsetenv("NOTIFY_SOCKET")
+	require.NoError(t, os.Setenv("WATCHDOG_USEC", "200000"))
+	defer os.Unsetenv("WATCHDOG_USEC")
+
+	config := cfg.ResetTestRoot("node_systemd_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	read := func() string {
+		buf := make([]byte, 64)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+	assert.Equal(t, sdnotify.Ready, read())
+	assert.Equal(t, sdnotify.Watchdog, read())
+
+	require.NoError(t, n.Stop())
+	for {
+		if state := read(); state != sdnotify.Watchdog {
+			assert.Equal(t, sdnotify.Stopping, state)
+			break
+		}
+	}
+}
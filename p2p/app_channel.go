@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"fmt"
+
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// Channel IDs reserved for the channels of app reactors, i.e. the gossip
+// protocols of the application, e.g. for an order book, registered along with
+// the built-in reactors. Built-in reactors never use them.
+const (
+	MinAppChannelID = ChannelID(0x80)
+	MaxAppChannelID = ChannelID(0xFF)
+
+	maxNumAppChannels = 16
+)
+
+// IsAppChannel returns true if the channel ID is reserved for app reactors.
+func IsAppChannel(id ChannelID) bool {
+	return id >= MinAppChannelID && id <= MaxAppChannelID
+}
+
+// AppChannel is a channel of an app reactor, along with the range of versions
+// of its protocol the node speaks. Nodes advertise their app channels in their
+// NodeInfo, and two peers use the highest version they both speak. Peers
+// which don't speak a common version don't use the channel with each other,
+// so that an app protocol can be upgraded while parts of the network still
+// run older versions.
+type AppChannel struct {
+	ID         ChannelID `json:"id"`
+	MinVersion uint32    `json:"min_version"`
+	MaxVersion uint32    `json:"max_version"`
+}
+
+// Validate validates the channel ID and the version range.
+func (c AppChannel) Validate() error {
+	if !IsAppChannel(c.ID) {
+		return fmt.Errorf("app channel %#x outside of the reserved range %#x-%#x",
+			c.ID, MinAppChannelID, MaxAppChannelID)
+	}
+	if c.MinVersion > c.MaxVersion {
+		return fmt.Errorf("app channel %#x has min version %d above max version %d",
+			c.ID, c.MinVersion, c.MaxVersion)
+	}
+	return nil
+}
+
+// NegotiateVersion returns the version of the channel's protocol to use with
+// the peer, i.e. the highest version both speak. It returns false if the peer
+// doesn't have the channel or speaks none of our versions.
+func (c AppChannel) NegotiateVersion(peer NodeInfo) (uint32, bool) {
+	for _, pc := range peer.AppChannels {
+		if pc.ID != c.ID {
+			continue
+		}
+		version := c.MaxVersion
+		if pc.MaxVersion < version {
+			version = pc.MaxVersion
+		}
+		if version < c.MinVersion || version < pc.MinVersion {
+			return 0, false
+		}
+		return version, true
+	}
+	return 0, false
+}
+
+// negotiateAppChannels returns the channels of the peer without the app
+// channels it doesn't speak a common version of with us.
+func negotiateAppChannels(ours []AppChannel, peer NodeInfo) []byte {
+	channels := make([]byte, 0, len(peer.Channels))
+	for _, ch := range peer.Channels {
+		if IsAppChannel(ChannelID(ch)) {
+			ok := false
+			for _, c := range ours {
+				if c.ID == ChannelID(ch) {
+					_, ok = c.NegotiateVersion(peer)
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+		}
+		channels = append(channels, ch)
+	}
+	return channels
+}
+
+func (c AppChannel) toProto() tmp2p.AppChannel {
+	return tmp2p.AppChannel{
+		ID:         uint32(c.ID),
+		MinVersion: c.MinVersion,
+		MaxVersion: c.MaxVersion,
+	}
+}
+
+func appChannelFromProto(pb tmp2p.AppChannel) AppChannel {
+	return AppChannel{
+		ID:         ChannelID(pb.ID),
+		MinVersion: pb.MinVersion,
+		MaxVersion: pb.MaxVersion,
+	}
+}
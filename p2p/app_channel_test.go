@@ -0,0 +1,80 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppChannelNegotiateVersion(t *testing.T) {
+	ours := AppChannel{ID: 0x80, MinVersion: 2, MaxVersion: 4}
+
+	testCases := []struct {
+		name    string
+		peer    []AppChannel
+		version uint32
+		ok      bool
+	}{
+		{"no app channels", nil, 0, false},
+		{"other channel", []AppChannel{{ID: 0x81, MinVersion: 1, MaxVersion: 4}}, 0, false},
+		{"newer peer", []AppChannel{{ID: 0x80, MinVersion: 3, MaxVersion: 5}}, 4, true},
+		{"older peer", []AppChannel{{ID: 0x80, MinVersion: 1, MaxVersion: 2}}, 2, true},
+		{"too old peer", []AppChannel{{ID: 0x80, MinVersion: 0, MaxVersion: 1}}, 0, false},
+		{"too new peer", []AppChannel{{ID: 0x80, MinVersion: 5, MaxVersion: 6}}, 0, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			version, ok := ours.NegotiateVersion(NodeInfo{AppChannels: tc.peer})
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.version, version)
+		})
+	}
+}
+
+func TestAppChannelValidate(t *testing.T) {
+	require.NoError(t, AppChannel{ID: MinAppChannelID, MinVersion: 1, MaxVersion: 1}.Validate())
+	require.NoError(t, AppChannel{ID: MaxAppChannelID}.Validate())
+	require.Error(t, AppChannel{ID: 0x30, MinVersion: 1, MaxVersion: 1}.Validate())
+	require.Error(t, AppChannel{ID: 0x80, MinVersion: 2, MaxVersion: 1}.Validate())
+}
+
+func TestNegotiateAppChannels(t *testing.T) {
+	ours := []AppChannel{
+		{ID: 0x80, MinVersion: 1, MaxVersion: 1},
+		{ID: 0x81, MinVersion: 2, MaxVersion: 2},
+	}
+	peer := NodeInfo{
+		Channels: []byte{0x20, 0x80, 0x81, 0x82},
+		AppChannels: []AppChannel{
+			{ID: 0x80, MinVersion: 1, MaxVersion: 3},
+			{ID: 0x81, MinVersion: 1, MaxVersion: 1},
+			{ID: 0x82, MinVersion: 1, MaxVersion: 1},
+		},
+	}
+	// 0x81 has no common version and we don't have 0x82.
+	require.Equal(t, []byte{0x20, 0x80}, negotiateAppChannels(ours, peer))
+}
+
+func TestNodeInfoAppChannels(t *testing.T) {
+	ni := testNodeInfo(GenNodeKey().ID, "node")
+	ni.Channels = append(ni.Channels, 0x80)
+	ni.AppChannels = []AppChannel{{ID: 0x80, MinVersion: 1, MaxVersion: 2}}
+	require.NoError(t, ni.Validate())
+
+	pb := ni.ToProto()
+	ni2, err := NodeInfoFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, ni.AppChannels, ni2.AppChannels)
+
+	ni.AppChannels = append(ni.AppChannels, AppChannel{ID: 0x81, MinVersion: 1, MaxVersion: 1})
+	require.Error(t, ni.Validate(), "app channel not in channels")
+
+	// App channels don't count towards the max number of channels.
+	for ch := byte(0x81); ch < 0x80+maxNumAppChannels; ch++ {
+		ni.Channels = append(ni.Channels, ch)
+	}
+	require.NoError(t, ni.Validate())
+	ni.Channels = append(ni.Channels, 0x80+maxNumAppChannels)
+	require.Error(t, ni.Validate(), "too many app channels")
+}
@@ -0,0 +1,113 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrChaosDisabled is returned when setting a chaos policy on a node built
+// without the chaos build tag.
+var ErrChaosDisabled = errors.New("chaos hooks are disabled, build with the chaos build tag to enable them")
+
+// ChaosPolicy makes the switch drop, delay or reorder the messages it receives,
+// to test the resilience of a network to packet loss and latency. The first
+// rule matching the peer and the channel of a message applies to it; messages
+// matching no rule are delivered to their reactor as usual.
+//
+// Chaos hooks are only available in nodes built with the chaos build tag, and
+// are not meant for production networks.
+type ChaosPolicy struct {
+	Rules []ChaosRule `json:"rules"`
+}
+
+// ChaosRule describes what happens to the messages received from a peer on a
+// set of channels.
+type ChaosRule struct {
+	// Peer whose messages the rule applies to. Empty for all peers.
+	Peer NodeID `json:"peer,omitempty"`
+	// Channels the rule applies to. Empty for all channels.
+	Channels []ChannelID `json:"channels,omitempty"`
+
+	// Probability of dropping a message, between 0 and 1.
+	Drop float64 `json:"drop,omitempty"`
+	// Delay of every message, plus a random delay of up to Jitter, which makes
+	// the delayed messages overtake each other.
+	Delay  time.Duration `json:"delay,omitempty"`
+	Jitter time.Duration `json:"jitter,omitempty"`
+	// Probability of holding a message back until the next message from the
+	// peer on its channel is delivered, between 0 and 1. A held message is
+	// delivered after chaosHoldTimeout if no other message arrives.
+	Reorder float64 `json:"reorder,omitempty"`
+}
+
+// ValidateBasic performs basic validation.
+func (p ChaosPolicy) ValidateBasic() error {
+	for i, rule := range p.Rules {
+		if err := rule.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid rule #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation.
+func (r ChaosRule) ValidateBasic() error {
+	if r.Peer != "" {
+		if err := r.Peer.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Drop < 0 || r.Drop > 1 {
+		return fmt.Errorf("drop must be between 0 and 1, got %v", r.Drop)
+	}
+	if r.Reorder < 0 || r.Reorder > 1 {
+		return fmt.Errorf("reorder must be between 0 and 1, got %v", r.Reorder)
+	}
+	if r.Delay < 0 {
+		return errors.New("delay can't be negative")
+	}
+	if r.Jitter < 0 {
+		return errors.New("jitter can't be negative")
+	}
+	return nil
+}
+
+// match returns the first rule applying to the messages received from the
+// peer on the channel, or nil if there is none.
+func (p ChaosPolicy) match(peerID NodeID, chID ChannelID) *ChaosRule {
+	for i, rule := range p.Rules {
+		if rule.Peer != "" && rule.Peer != peerID {
+			continue
+		}
+		if len(rule.Channels) == 0 {
+			return &p.Rules[i]
+		}
+		for _, ch := range rule.Channels {
+			if ch == chID {
+				return &p.Rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// SetChaosPolicy replaces the chaos policy applied to the messages received
+// from peers. An empty policy delivers all messages as usual. It returns
+// ErrChaosDisabled unless the node is built with the chaos build tag.
+func (sw *Switch) SetChaosPolicy(policy ChaosPolicy) error {
+	if err := policy.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := sw.chaos.setPolicy(policy); err != nil {
+		return err
+	}
+	sw.Logger.Info("Set chaos policy", "rules", len(policy.Rules))
+	return nil
+}
+
+// ChaosPolicy returns the chaos policy applied to the messages received from
+// peers, and whether chaos hooks are enabled.
+func (sw *Switch) ChaosPolicy() (ChaosPolicy, bool) {
+	return sw.chaos.getPolicy(), ChaosEnabled
+}
@@ -0,0 +1,118 @@
+// +build chaos
+
+package p2p
+
+import (
+	mrand "math/rand"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// ChaosEnabled is true if the node is built with the chaos build tag.
+const ChaosEnabled = true
+
+// chaosHoldTimeout is how long a message held back to be reordered waits for
+// the next message on its channel before it is delivered anyway.
+const chaosHoldTimeout = time.Second
+
+type chaosKey struct {
+	peerID NodeID
+	chID   ChannelID
+}
+
+// heldMessage is a message held back until the next one on its channel.
+type heldMessage struct {
+	deliver func()
+}
+
+// chaosHooks applies the chaos policy to the messages received from peers.
+type chaosHooks struct {
+	mtx    tmsync.Mutex
+	policy ChaosPolicy
+	held   map[chaosKey]*heldMessage
+}
+
+func newChaosHooks() *chaosHooks {
+	return &chaosHooks{held: make(map[chaosKey]*heldMessage)}
+}
+
+func (c *chaosHooks) setPolicy(policy ChaosPolicy) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.policy = policy
+	return nil
+}
+
+func (c *chaosHooks) getPolicy() ChaosPolicy {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.policy
+}
+
+// receive delivers, drops, delays or holds back a message received from the
+// peer on the channel, as the first matching rule of the policy says.
+func (c *chaosHooks) receive(peerID NodeID, chID byte, deliver func()) {
+	if c == nil {
+		deliver()
+		return
+	}
+	key := chaosKey{peerID: peerID, chID: ChannelID(chID)}
+
+	c.mtx.Lock()
+	rule := c.policy.match(key.peerID, key.chID)
+	if rule == nil {
+		c.mtx.Unlock()
+		deliver()
+		return
+	}
+	if chance(rule.Drop) {
+		c.mtx.Unlock()
+		return
+	}
+	if held, ok := c.held[key]; ok {
+		// deliver the message held back after this one
+		delete(c.held, key)
+		first := deliver
+		deliver = func() {
+			first()
+			held.deliver()
+		}
+	} else if chance(rule.Reorder) {
+		held := &heldMessage{deliver: deliver}
+		c.held[key] = held
+		c.mtx.Unlock()
+		time.AfterFunc(chaosHoldTimeout, func() { c.release(key, held) })
+		return
+	}
+	delay := rule.Delay
+	if rule.Jitter > 0 {
+		// nolint:gosec // G404: Use of weak random number generator
+		delay += time.Duration(mrand.Int63n(int64(rule.Jitter)))
+	}
+	c.mtx.Unlock()
+
+	if delay == 0 {
+		deliver()
+		return
+	}
+	time.AfterFunc(delay, deliver)
+}
+
+// release delivers a held message which no other message overtook.
+func (c *chaosHooks) release(key chaosKey, held *heldMessage) {
+	c.mtx.Lock()
+	if c.held[key] != held {
+		c.mtx.Unlock()
+		return
+	}
+	delete(c.held, key)
+	c.mtx.Unlock()
+	held.deliver()
+}
+
+// chance returns true with probability p.
+func chance(p float64) bool {
+	// nolint:gosec // G404: Use of weak random number generator
+	return p > 0 && mrand.Float64() < p
+}
@@ -0,0 +1,81 @@
+// +build chaos
+
+package p2p
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chaosRecorder records the order in which messages are delivered.
+type chaosRecorder struct {
+	mtx       sync.Mutex
+	delivered []int
+}
+
+func (r *chaosRecorder) deliver(i int) func() {
+	return func() {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.delivered = append(r.delivered, i)
+	}
+}
+
+func (r *chaosRecorder) list() []int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]int(nil), r.delivered...)
+}
+
+func TestChaosHooks(t *testing.T) {
+	peerID := NodeID("0000000000000000000000000000000000000000")
+	other := NodeID("1111111111111111111111111111111111111111")
+
+	t.Run("drop", func(t *testing.T) {
+		c := newChaosHooks()
+		require.NoError(t, c.setPolicy(ChaosPolicy{Rules: []ChaosRule{{Peer: peerID, Drop: 1}}}))
+		r := &chaosRecorder{}
+		c.receive(peerID, 0x20, r.deliver(1))
+		c.receive(other, 0x20, r.deliver(2))
+		assert.Equal(t, []int{2}, r.list())
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		c := newChaosHooks()
+		require.NoError(t, c.setPolicy(ChaosPolicy{Rules: []ChaosRule{
+			{Channels: []ChannelID{0x20}, Delay: 50 * time.Millisecond},
+		}}))
+		r := &chaosRecorder{}
+		c.receive(peerID, 0x20, r.deliver(1))
+		c.receive(peerID, 0x21, r.deliver(2))
+		assert.Equal(t, []int{2}, r.list())
+		assert.Eventually(t, func() bool { return len(r.list()) == 2 }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []int{2, 1}, r.list())
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		c := newChaosHooks()
+		require.NoError(t, c.setPolicy(ChaosPolicy{Rules: []ChaosRule{{Reorder: 1}}}))
+		r := &chaosRecorder{}
+		// the first message is held back, and delivered after the second one
+		c.receive(peerID, 0x20, r.deliver(1))
+		assert.Empty(t, r.list())
+		c.receive(peerID, 0x20, r.deliver(2))
+		assert.Equal(t, []int{2, 1}, r.list())
+
+		// a held message is delivered after a timeout if nothing overtakes it
+		c.receive(peerID, 0x20, r.deliver(3))
+		assert.Eventually(t, func() bool { return len(r.list()) == 3 }, 2*chaosHoldTimeout, 50*time.Millisecond)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		c := newChaosHooks()
+		r := &chaosRecorder{}
+		c.receive(peerID, 0x20, r.deliver(1))
+		assert.Equal(t, []int{1}, r.list())
+	})
+}
@@ -0,0 +1,72 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestChaosPolicy_ValidateBasic(t *testing.T) {
+	peerID := NodeID("0000000000000000000000000000000000000000")
+	testcases := map[string]struct {
+		rule      ChaosRule
+		expectErr bool
+	}{
+		"empty":            {ChaosRule{}, false},
+		"valid":            {ChaosRule{Peer: peerID, Channels: []ChannelID{0x20}, Drop: 0.1, Delay: time.Second}, false},
+		"invalid peer":     {ChaosRule{Peer: "foo"}, true},
+		"drop above 1":     {ChaosRule{Drop: 1.5}, true},
+		"negative reorder": {ChaosRule{Reorder: -0.1}, true},
+		"negative delay":   {ChaosRule{Delay: -time.Second}, true},
+		"negative jitter":  {ChaosRule{Jitter: -time.Second}, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := ChaosPolicy{Rules: []ChaosRule{tc.rule}}.ValidateBasic()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChaosPolicy_Match(t *testing.T) {
+	a := NodeID("0000000000000000000000000000000000000000")
+	b := NodeID("1111111111111111111111111111111111111111")
+	policy := ChaosPolicy{Rules: []ChaosRule{
+		{Peer: a, Channels: []ChannelID{0x20, 0x21}, Drop: 1},
+		{Peer: a, Delay: time.Second},
+		{Channels: []ChannelID{0x30}, Reorder: 1},
+	}}
+
+	assert.Equal(t, &policy.Rules[0], policy.match(a, 0x21))
+	assert.Equal(t, &policy.Rules[1], policy.match(a, 0x30))
+	assert.Equal(t, &policy.Rules[2], policy.match(b, 0x30))
+	assert.Nil(t, policy.match(b, 0x20))
+	assert.Nil(t, ChaosPolicy{}.match(a, 0x20))
+}
+
+func TestSwitch_SetChaosPolicy(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "127.0.0.1", "123.123.123", initSwitchFunc, log.TestingLogger())
+	policy := ChaosPolicy{Rules: []ChaosRule{{Drop: 0.5}}}
+
+	assert.Error(t, sw.SetChaosPolicy(ChaosPolicy{Rules: []ChaosRule{{Drop: 2}}}))
+
+	err := sw.SetChaosPolicy(policy)
+	current, enabled := sw.ChaosPolicy()
+	assert.Equal(t, ChaosEnabled, enabled)
+	if !ChaosEnabled {
+		require.ErrorIs(t, err, ErrChaosDisabled)
+		assert.Empty(t, current.Rules)
+		return
+	}
+	require.NoError(t, err)
+	assert.Equal(t, policy, current)
+}
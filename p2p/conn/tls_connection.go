@@ -0,0 +1,152 @@
+package conn
+
+import (
+	stded25519 "crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// TLSProtocolName is the ALPN protocol negotiated by TLS connections, so that
+// firewalls and middleboxes can identify the traffic.
+const TLSProtocolName = "tendermint-p2p"
+
+// tlsRecordTypeHandshake is the first byte of a TLS connection, which starts
+// with a handshake record.
+const tlsRecordTypeHandshake = 0x16
+
+// TLSConnection is a TLS 1.3 connection between two nodes, authenticated with
+// their node keys. It is an alternative to the SecretConnection, for
+// deployments which require a standard protocol.
+//
+// Each end presents a self-signed certificate of its node key, with its node
+// ID as the common name, so that the certificate is pinned to the node ID: a
+// certificate is only accepted if it is signed by the key the node ID is
+// derived from. As with the SecretConnection, consumers are responsible for
+// authenticating the remote peer's pubkey against the node ID they expect.
+type TLSConnection struct {
+	*tls.Conn
+	remPubKey crypto.PubKey
+}
+
+// MakeTLSConnection performs the TLS handshake over conn, as the server if
+// server is true, and returns the authenticated connection. The node key must
+// be an ed25519 key.
+func MakeTLSConnection(conn net.Conn, locPrivKey crypto.PrivKey, server bool) (*TLSConnection, error) {
+	privKey, ok := locPrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("TLS requires an ed25519 node key, got %s", locPrivKey.Type())
+	}
+	cert, err := nodeCertificate(stded25519.PrivateKey(privKey))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		NextProtos:   []string{TLSProtocolName},
+		// The certificates are self-signed, so the chain verification is
+		// replaced with verifyNodeCertificate.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyNodeCertificate,
+	}
+	var tlsConn *tls.Conn
+	if server {
+		config.ClientAuth = tls.RequireAnyClientCert
+		tlsConn = tls.Server(conn, config)
+	} else {
+		tlsConn = tls.Client(conn, config)
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+
+	peerCerts := tlsConn.ConnectionState().PeerCertificates
+	if len(peerCerts) == 0 {
+		return nil, errors.New("peer presented no certificate")
+	}
+	remPubKey, ok := peerCerts[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return nil, errors.New("peer certificate has no ed25519 key")
+	}
+	return &TLSConnection{Conn: tlsConn, remPubKey: ed25519.PubKey(remPubKey)}, nil
+}
+
+// RemotePubKey returns the authenticated remote pubkey.
+func (c *TLSConnection) RemotePubKey() crypto.PubKey {
+	return c.remPubKey
+}
+
+// IsTLSHandshake reports whether the first byte read from a connection starts
+// a TLS handshake rather than a SecretConnection handshake, which starts with
+// the length of the ephemeral key.
+func IsTLSHandshake(firstByte byte) bool {
+	return firstByte == tlsRecordTypeHandshake
+}
+
+// nodeCertificate returns a self-signed certificate of the node key.
+func nodeCertificate(privKey stded25519.PrivateKey) (tls.Certificate, error) {
+	pubKey := privKey.Public().(stded25519.PublicKey)
+	serial, err := crand.Int(crand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: nodeIDFromKey(pubKey)},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(crand.Reader, template, template, pubKey, privKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create node certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privKey}, nil
+}
+
+// verifyNodeCertificate checks that the peer presented a single valid
+// certificate, self-signed by the ed25519 key its common name, the node ID, is
+// derived from.
+func verifyNodeCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected a single peer certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	pubKey, ok := cert.PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return errors.New("peer certificate has no ed25519 key")
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return fmt.Errorf("peer certificate is not self-signed: %w", err)
+	}
+	if now := time.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return errors.New("peer certificate is expired or not yet valid")
+	}
+	if id := nodeIDFromKey(pubKey); cert.Subject.CommonName != id {
+		return fmt.Errorf("peer certificate is for node %q, but its key is of node %q",
+			cert.Subject.CommonName, id)
+	}
+	return nil
+}
+
+// nodeIDFromKey returns the node ID of the key, as p2p.NodeIDFromPubKey.
+func nodeIDFromKey(pubKey stded25519.PublicKey) string {
+	return hex.EncodeToString(ed25519.PubKey(pubKey).Address())
+}
@@ -0,0 +1,78 @@
+package conn
+
+import (
+	stded25519 "crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+func TestTLSConnectionHandshake(t *testing.T) {
+	fooConn, barConn := net.Pipe()
+	t.Cleanup(func() {
+		_ = fooConn.Close()
+		_ = barConn.Close()
+	})
+	fooPrvKey, barPrvKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+
+	type result struct {
+		conn *TLSConnection
+		err  error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		conn, err := MakeTLSConnection(barConn, barPrvKey, true)
+		resCh <- result{conn, err}
+	}()
+
+	fooTLSConn, err := MakeTLSConnection(fooConn, fooPrvKey, false)
+	require.NoError(t, err)
+	res := <-resCh
+	require.NoError(t, res.err)
+	barTLSConn := res.conn
+
+	assert.Equal(t, barPrvKey.PubKey(), fooTLSConn.RemotePubKey())
+	assert.Equal(t, fooPrvKey.PubKey(), barTLSConn.RemotePubKey())
+	assert.Equal(t, TLSProtocolName, fooTLSConn.ConnectionState().NegotiatedProtocol)
+
+	go func() {
+		_, _ = fooTLSConn.Write([]byte("hello"))
+	}()
+	buf := make([]byte, 5)
+	_, err = barTLSConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+}
+
+func TestTLSConnectionNonEd25519Key(t *testing.T) {
+	fooConn, _ := net.Pipe()
+	_, err := MakeTLSConnection(fooConn, sr25519.GenPrivKey(), false)
+	require.Error(t, err)
+}
+
+func TestVerifyNodeCertificate(t *testing.T) {
+	privKey := stded25519.PrivateKey(ed25519.GenPrivKey())
+	cert, err := nodeCertificate(privKey)
+	require.NoError(t, err)
+	require.NoError(t, verifyNodeCertificate(cert.Certificate, nil))
+
+	// a certificate self-signed by the node key, but for another node ID
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	parsed.RawSubject, parsed.RawIssuer = nil, nil
+	parsed.Subject.CommonName = nodeIDFromKey(ed25519.GenPrivKey().PubKey().Bytes())
+	forged, err := x509.CreateCertificate(crand.Reader, parsed, parsed, privKey.Public(), privKey)
+	require.NoError(t, err)
+	assert.Error(t, verifyNodeCertificate([][]byte{forged}, nil))
+
+	assert.Error(t, verifyNodeCertificate(nil, nil))
+	assert.Error(t, verifyNodeCertificate([][]byte{cert.Certificate[0], forged}, nil))
+	assert.Error(t, verifyNodeCertificate([][]byte{[]byte("garbage")}, nil))
+}
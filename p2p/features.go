@@ -0,0 +1,123 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// Features is a bitfield of optional protocol features a node supports. Nodes
+// advertise their features in their NodeInfo, so that reactors can enable a
+// feature per peer, and a new protocol feature can be rolled out while parts
+// of the network still run older versions.
+type Features uint64
+
+// Protocol features. Bits must never be reused for a different feature.
+const (
+	// FeatureVoteExtensions is set if the node sends and accepts vote
+	// extensions.
+	FeatureVoteExtensions Features = 1 << iota
+	// FeatureCompactBlocks is set if the node can rebuild blocks from compact
+	// block messages and the txs in its mempool.
+	FeatureCompactBlocks
+	// FeatureBatchedMempoolGossip is set if the node sends and accepts
+	// several txs per mempool message.
+	FeatureBatchedMempoolGossip
+	// FeatureSnapshotFormats is set if the node advertises the snapshot
+	// formats it supports to state syncing peers.
+	FeatureSnapshotFormats
+	// FeatureNodeKeyForwarding is set if the node accepts forwarding records
+	// of peers that have rotated their node key.
+	FeatureNodeKeyForwarding
+)
+
+// DefaultFeatures are the features this version of Tendermint supports.
+const DefaultFeatures = FeatureNodeKeyForwarding
+
+var featureNames = []struct {
+	feature Features
+	name    string
+}{
+	{FeatureVoteExtensions, "vote-extensions"},
+	{FeatureCompactBlocks, "compact-blocks"},
+	{FeatureBatchedMempoolGossip, "batched-mempool-gossip"},
+	{FeatureSnapshotFormats, "snapshot-formats"},
+	{FeatureNodeKeyForwarding, "node-key-forwarding"},
+}
+
+// Has returns true if all given features are set.
+func (f Features) Has(features Features) bool {
+	return f&features == features
+}
+
+// String returns the names of the set features, e.g.
+// "compact-blocks,vote-extensions". Unknown features are listed as bit
+// numbers.
+func (f Features) String() string {
+	var names []string
+	for _, fn := range featureNames {
+		if f.Has(fn.feature) {
+			names = append(names, fn.name)
+			f &^= fn.feature
+		}
+	}
+	for bit := 0; f != 0; bit++ {
+		if f&1 == 1 {
+			names = append(names, fmt.Sprintf("bit%d", bit))
+		}
+		f >>= 1
+	}
+	return strings.Join(names, ",")
+}
+
+// PeerFeatures tracks the features of connected peers, for reactors which
+// enable a feature per peer. Reactors pass it the peer updates they receive.
+// It is safe for concurrent use.
+type PeerFeatures struct {
+	mtx   tmsync.RWMutex
+	peers map[NodeID]Features
+}
+
+// NewPeerFeatures returns a new PeerFeatures without any peers.
+func NewPeerFeatures() *PeerFeatures {
+	return &PeerFeatures{peers: make(map[NodeID]Features)}
+}
+
+// Update records the features of peers which are up and forgets peers which
+// are down.
+func (pf *PeerFeatures) Update(peerUpdate PeerUpdate) {
+	pf.mtx.Lock()
+	defer pf.mtx.Unlock()
+
+	switch peerUpdate.Status {
+	case PeerStatusUp:
+		pf.peers[peerUpdate.NodeID] = peerUpdate.Features
+	case PeerStatusDown:
+		delete(pf.peers, peerUpdate.NodeID)
+	}
+}
+
+// Has returns true if the peer is up and advertised all given features.
+func (pf *PeerFeatures) Has(peerID NodeID, features Features) bool {
+	pf.mtx.RLock()
+	defer pf.mtx.RUnlock()
+
+	f, ok := pf.peers[peerID]
+	return ok && f.Has(features)
+}
+
+// Peers returns the peers which are up and advertised all given features, in
+// arbitrary order.
+func (pf *PeerFeatures) Peers(features Features) []NodeID {
+	pf.mtx.RLock()
+	defer pf.mtx.RUnlock()
+
+	peers := make([]NodeID, 0, len(pf.peers))
+	for peerID, f := range pf.peers {
+		if f.Has(features) {
+			peers = append(peers, peerID)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,47 @@
+package p2p_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestFeatures(t *testing.T) {
+	f := p2p.FeatureVoteExtensions | p2p.FeatureBatchedMempoolGossip
+
+	require.True(t, f.Has(p2p.FeatureVoteExtensions))
+	require.True(t, f.Has(p2p.FeatureVoteExtensions|p2p.FeatureBatchedMempoolGossip))
+	require.False(t, f.Has(p2p.FeatureCompactBlocks))
+	require.False(t, f.Has(p2p.FeatureVoteExtensions|p2p.FeatureCompactBlocks))
+
+	require.Equal(t, "vote-extensions,batched-mempool-gossip", f.String())
+	require.Equal(t, "compact-blocks,bit63", (p2p.FeatureCompactBlocks | 1<<63).String())
+	require.Equal(t, "", p2p.Features(0).String())
+}
+
+func TestPeerFeatures(t *testing.T) {
+	pf := p2p.NewPeerFeatures()
+	a := p2p.NodeID("a")
+
+	require.False(t, pf.Has(a, 0))
+
+	pf.Update(p2p.PeerUpdate{NodeID: a, Status: p2p.PeerStatusUp, Features: p2p.FeatureCompactBlocks})
+	require.True(t, pf.Has(a, p2p.FeatureCompactBlocks))
+	require.False(t, pf.Has(a, p2p.FeatureVoteExtensions))
+	require.Equal(t, []p2p.NodeID{a}, pf.Peers(p2p.FeatureCompactBlocks))
+	require.Empty(t, pf.Peers(p2p.FeatureVoteExtensions))
+
+	pf.Update(p2p.PeerUpdate{NodeID: a, Status: p2p.PeerStatusDown})
+	require.False(t, pf.Has(a, p2p.FeatureCompactBlocks))
+	require.Empty(t, pf.Peers(p2p.FeatureCompactBlocks))
+}
+
+func TestNodeInfo_FeaturesProto(t *testing.T) {
+	info := p2p.NodeInfo{NodeID: "a", Features: p2p.FeatureSnapshotFormats}
+
+	decoded, err := p2p.NodeInfoFromProto(info.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, p2p.FeatureSnapshotFormats, decoded.Features)
+}
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// ForwardingRecord announces that a node has rotated its node key. It is
+// signed by the node's old key and contains its new NodeInfo, such that peers
+// can move the addresses and configuration they have for the old node ID over
+// to the new node ID rather than treating the node as a brand new peer.
+type ForwardingRecord struct {
+	OldPubKey   crypto.PubKey
+	NewNodeInfo NodeInfo
+	Timestamp   time.Time
+	Signature   []byte
+}
+
+// NewForwardingRecord creates a forwarding record from oldKey to the node
+// described by newNodeInfo, signed by oldKey.
+func NewForwardingRecord(oldKey NodeKey, newNodeInfo NodeInfo) (*ForwardingRecord, error) {
+	record := &ForwardingRecord{
+		OldPubKey:   oldKey.PubKey(),
+		NewNodeInfo: newNodeInfo,
+		Timestamp:   tmtime.Now(),
+	}
+	signBytes, err := record.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	record.Signature, err = oldKey.PrivKey.Sign(signBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign forwarding record: %w", err)
+	}
+	return record, nil
+}
+
+// OldID returns the node ID that is being forwarded.
+func (r *ForwardingRecord) OldID() NodeID {
+	return NodeIDFromPubKey(r.OldPubKey)
+}
+
+// NewID returns the node ID that the old node ID is forwarded to.
+func (r *ForwardingRecord) NewID() NodeID {
+	return r.NewNodeInfo.NodeID
+}
+
+// SignBytes returns the bytes signed by the old node key, i.e. the encoded
+// record without the signature.
+func (r *ForwardingRecord) SignBytes() ([]byte, error) {
+	pb, err := r.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pb.Signature = nil
+	return pb.Marshal()
+}
+
+// Validate validates the forwarding record, including its signature.
+func (r *ForwardingRecord) Validate() error {
+	if r.OldPubKey == nil {
+		return errors.New("no old public key")
+	}
+	if err := r.NewID().Validate(); err != nil {
+		return fmt.Errorf("invalid new node ID: %w", err)
+	}
+	if r.NewID() == r.OldID() {
+		return errors.New("old and new node IDs are the same")
+	}
+	if err := r.NewNodeInfo.Validate(); err != nil {
+		return fmt.Errorf("invalid new node info: %w", err)
+	}
+	if r.Timestamp.IsZero() {
+		return errors.New("no timestamp")
+	}
+	signBytes, err := r.SignBytes()
+	if err != nil {
+		return err
+	}
+	if !r.OldPubKey.VerifySignature(signBytes, r.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ToProto converts the forwarding record to its Protobuf representation.
+func (r *ForwardingRecord) ToProto() (*p2pproto.PexForwardingRecord, error) {
+	pubKey, err := encoding.PubKeyToProto(r.OldPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &p2pproto.PexForwardingRecord{
+		OldPubKey:   pubKey,
+		NewNodeInfo: *r.NewNodeInfo.ToProto(),
+		Timestamp:   r.Timestamp,
+		Signature:   r.Signature,
+	}, nil
+}
+
+// ForwardingRecordFromProto converts a Protobuf forwarding record to a
+// ForwardingRecord. It does not validate it.
+func ForwardingRecordFromProto(pb *p2pproto.PexForwardingRecord) (*ForwardingRecord, error) {
+	if pb == nil {
+		return nil, errors.New("nil forwarding record")
+	}
+	pubKey, err := encoding.PubKeyFromProto(pb.OldPubKey)
+	if err != nil {
+		return nil, err
+	}
+	nodeInfo, err := NodeInfoFromProto(&pb.NewNodeInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardingRecord{
+		OldPubKey:   pubKey,
+		NewNodeInfo: nodeInfo,
+		Timestamp:   pb.Timestamp,
+		Signature:   pb.Signature,
+	}, nil
+}
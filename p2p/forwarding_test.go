@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardingRecord(t *testing.T) {
+	oldKey := GenNodeKey()
+	newKey := GenNodeKey()
+
+	record, err := NewForwardingRecord(oldKey, testNodeInfo(newKey.ID, "new"))
+	require.NoError(t, err)
+	require.Equal(t, oldKey.ID, record.OldID())
+	require.Equal(t, newKey.ID, record.NewID())
+	require.NoError(t, record.Validate())
+
+	// The record should survive a Protobuf roundtrip.
+	pb, err := record.ToProto()
+	require.NoError(t, err)
+	decoded, err := ForwardingRecordFromProto(pb)
+	require.NoError(t, err)
+	require.NoError(t, decoded.Validate())
+	require.Equal(t, record.NewNodeInfo, decoded.NewNodeInfo)
+
+	_, err = ForwardingRecordFromProto(nil)
+	require.Error(t, err)
+}
+
+func TestForwardingRecord_Validate(t *testing.T) {
+	oldKey := GenNodeKey()
+	newKey := GenNodeKey()
+
+	testcases := map[string]struct {
+		malleate func(*ForwardingRecord)
+		ok       bool
+	}{
+		"valid":         {func(r *ForwardingRecord) {}, true},
+		"no pubkey":     {func(r *ForwardingRecord) { r.OldPubKey = nil }, false},
+		"other pubkey":  {func(r *ForwardingRecord) { r.OldPubKey = GenNodeKey().PubKey() }, false},
+		"no new ID":     {func(r *ForwardingRecord) { r.NewNodeInfo.NodeID = "" }, false},
+		"same ID":       {func(r *ForwardingRecord) { r.NewNodeInfo.NodeID = oldKey.ID }, false},
+		"changed info":  {func(r *ForwardingRecord) { r.NewNodeInfo.Moniker = "changed" }, false},
+		"invalid info":  {func(r *ForwardingRecord) { r.NewNodeInfo.Moniker = "" }, false},
+		"no timestamp":  {func(r *ForwardingRecord) { r.Timestamp = time.Time{} }, false},
+		"no signature":  {func(r *ForwardingRecord) { r.Signature = nil }, false},
+		"bad signature": {func(r *ForwardingRecord) { r.Signature[0] ^= 0xff }, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			record, err := NewForwardingRecord(oldKey, testNodeInfo(newKey.ID, "new"))
+			require.NoError(t, err)
+			tc.malleate(record)
+			if tc.ok {
+				require.NoError(t, record.Validate())
+			} else {
+				require.Error(t, record.Validate())
+			}
+		})
+	}
+}
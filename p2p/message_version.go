@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// MessageTranslator translates the messages of a channel between a version and
+// the version below it. Translators let a channel's protocol change, e.g. to
+// gossip batches of txs or compact blocks, while parts of the network still
+// run older versions: the reactor only handles messages of the latest version,
+// and messages to and from peers which speak an older version are translated
+// on the way.
+type MessageTranslator interface {
+	// Upgrade translates a message of the version below into messages of this
+	// version. It may return no messages if the message has no equivalent.
+	Upgrade(msg proto.Message) ([]proto.Message, error)
+
+	// Downgrade translates a message of this version into messages of the
+	// version below, e.g. a batch of txs into one message per tx. It may
+	// return no messages if peers speaking the version below can't make use of
+	// the message, e.g. a compact block, in which case the reactor must check
+	// the peer's version or features and send them something else.
+	Downgrade(msg proto.Message) ([]proto.Message, error)
+}
+
+// MessageVersions are the versions of the messages of a channel. Version 0 is
+// the original protocol, whose messages are sent as they are; messages of
+// later versions are sent in a VersionedMessage envelope, so that the receiver
+// knows how to translate them. Translators[v-1] translates between version v
+// and v-1, and the latest version is the number of translators.
+type MessageVersions struct {
+	Translators []MessageTranslator
+}
+
+// Latest returns the latest version, i.e. the version the reactor handles.
+func (mv *MessageVersions) Latest() uint32 {
+	if mv == nil {
+		return 0
+	}
+	return uint32(len(mv.Translators))
+}
+
+// Upgrade translates a message of the given version into messages of the
+// latest version.
+func (mv *MessageVersions) Upgrade(msg proto.Message, from uint32) ([]proto.Message, error) {
+	if from > mv.Latest() {
+		return nil, fmt.Errorf("unknown message version %d, latest is %d", from, mv.Latest())
+	}
+	msgs := []proto.Message{msg}
+	for v := from + 1; v <= mv.Latest(); v++ {
+		var err error
+		msgs, err = translateMessages(msgs, mv.Translators[v-1].Upgrade)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade message to version %d: %w", v, err)
+		}
+	}
+	return msgs, nil
+}
+
+// Downgrade translates a message of the latest version into messages of the
+// given version.
+func (mv *MessageVersions) Downgrade(msg proto.Message, to uint32) ([]proto.Message, error) {
+	if to > mv.Latest() {
+		return nil, fmt.Errorf("unknown message version %d, latest is %d", to, mv.Latest())
+	}
+	msgs := []proto.Message{msg}
+	for v := mv.Latest(); v > to; v-- {
+		var err error
+		msgs, err = translateMessages(msgs, mv.Translators[v-1].Downgrade)
+		if err != nil {
+			return nil, fmt.Errorf("failed to downgrade message to version %d: %w", v-1, err)
+		}
+	}
+	return msgs, nil
+}
+
+func translateMessages(
+	msgs []proto.Message,
+	translate func(proto.Message) ([]proto.Message, error),
+) ([]proto.Message, error) {
+	translated := make([]proto.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		out, err := translate(msg)
+		if err != nil {
+			return nil, err
+		}
+		translated = append(translated, out...)
+	}
+	return translated, nil
+}
+
+// ChannelVersion is the latest version of the messages of a channel a node
+// speaks. Nodes advertise the versions of their versioned channels in their
+// NodeInfo; channels without a version are at version 0.
+type ChannelVersion struct {
+	ChannelID ChannelID `json:"channel_id"`
+	Version   uint32    `json:"version"`
+}
+
+// NegotiateMessageVersion returns the version of the channel's messages to use
+// with the peer, i.e. the latest version both speak.
+func NegotiateMessageVersion(chID ChannelID, ours uint32, peer NodeInfo) uint32 {
+	for _, cv := range peer.ChannelVersions {
+		if cv.ChannelID == chID {
+			if cv.Version < ours {
+				return cv.Version
+			}
+			return ours
+		}
+	}
+	return 0
+}
+
+// encodeVersionedMessage wraps an encoded message of the given version in a
+// VersionedMessage envelope, unless it is of version 0.
+func encodeVersionedMessage(version uint32, bz []byte) ([]byte, error) {
+	if version == 0 {
+		return bz, nil
+	}
+	return proto.Marshal(&tmp2p.VersionedMessage{Version: version, Msg: bz})
+}
+
+// decodeVersionedMessage unwraps an encoded message from a peer with which the
+// given version was negotiated, and returns the version of the message.
+func decodeVersionedMessage(version uint32, bz []byte) (uint32, []byte, error) {
+	if version == 0 {
+		return 0, bz, nil
+	}
+	var vm tmp2p.VersionedMessage
+	if err := proto.Unmarshal(bz, &vm); err != nil {
+		return 0, nil, err
+	}
+	if vm.Version > version {
+		return 0, nil, fmt.Errorf("message version %d above negotiated version %d", vm.Version, version)
+	}
+	return vm.Version, vm.Msg, nil
+}
+
+func (cv ChannelVersion) toProto() tmp2p.ChannelVersion {
+	return tmp2p.ChannelVersion{
+		ChannelID: uint32(cv.ChannelID),
+		Version:   cv.Version,
+	}
+}
+
+func channelVersionFromProto(pb tmp2p.ChannelVersion) ChannelVersion {
+	return ChannelVersion{
+		ChannelID: ChannelID(pb.ChannelID),
+		Version:   pb.Version,
+	}
+}
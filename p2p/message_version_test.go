@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// batchTranslator translates between batches of txs (this version) and one tx
+// per message (the version below).
+type batchTranslator struct{}
+
+func (batchTranslator) Upgrade(msg proto.Message) ([]proto.Message, error) {
+	return []proto.Message{msg}, nil
+}
+
+func (batchTranslator) Downgrade(msg proto.Message) ([]proto.Message, error) {
+	var msgs []proto.Message
+	for _, tx := range msg.(*tmp2p.VersionedMessage).Msg {
+		msgs = append(msgs, &tmp2p.VersionedMessage{Msg: []byte{tx}})
+	}
+	return msgs, nil
+}
+
+func TestMessageVersions(t *testing.T) {
+	var unversioned *MessageVersions
+	require.EqualValues(t, 0, unversioned.Latest())
+
+	mv := &MessageVersions{Translators: []MessageTranslator{batchTranslator{}}}
+	require.EqualValues(t, 1, mv.Latest())
+
+	batch := &tmp2p.VersionedMessage{Msg: []byte{1, 2, 3}}
+	msgs, err := mv.Downgrade(batch, 0)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	require.Equal(t, []byte{3}, msgs[2].(*tmp2p.VersionedMessage).Msg)
+
+	msgs, err = mv.Downgrade(batch, 1)
+	require.NoError(t, err)
+	require.Equal(t, []proto.Message{batch}, msgs)
+
+	msgs, err = mv.Upgrade(batch, 0)
+	require.NoError(t, err)
+	require.Equal(t, []proto.Message{batch}, msgs)
+
+	_, err = mv.Upgrade(batch, 2)
+	require.Error(t, err)
+	_, err = mv.Downgrade(batch, 2)
+	require.Error(t, err)
+}
+
+func TestNegotiateMessageVersion(t *testing.T) {
+	peer := NodeInfo{ChannelVersions: []ChannelVersion{{ChannelID: 0x30, Version: 2}}}
+
+	require.EqualValues(t, 2, NegotiateMessageVersion(0x30, 3, peer))
+	require.EqualValues(t, 1, NegotiateMessageVersion(0x30, 1, peer))
+	require.EqualValues(t, 0, NegotiateMessageVersion(0x20, 3, peer))
+}
+
+func TestVersionedMessageEncoding(t *testing.T) {
+	bz := []byte("message")
+
+	encoded, err := encodeVersionedMessage(0, bz)
+	require.NoError(t, err)
+	require.Equal(t, bz, encoded)
+
+	encoded, err = encodeVersionedMessage(2, bz)
+	require.NoError(t, err)
+
+	version, decoded, err := decodeVersionedMessage(3, encoded)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, version)
+	require.Equal(t, bz, decoded)
+
+	_, _, err = decodeVersionedMessage(1, encoded)
+	require.Error(t, err, "above negotiated version")
+}
+
+func TestNodeInfoChannelVersions(t *testing.T) {
+	ni := testNodeInfo(GenNodeKey().ID, "node")
+	ni.ChannelVersions = []ChannelVersion{{ChannelID: ChannelID(ni.Channels[0]), Version: 1}}
+	require.NoError(t, ni.Validate())
+
+	ni2, err := NodeInfoFromProto(ni.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, ni.ChannelVersions, ni2.ChannelVersions)
+
+	ni.ChannelVersions = append(ni.ChannelVersions, ni.ChannelVersions[0])
+	require.Error(t, ni.Validate(), "duplicate channel")
+
+	ni.ChannelVersions = []ChannelVersion{{ChannelID: 0x99, Version: 1}}
+	require.Error(t, ni.Validate(), "unknown channel")
+}
@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"sort"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// DefaultMaxBookSize is the default max number of nodes a Book keeps records
+// of.
+const DefaultMaxBookSize = 1000
+
+// Book keeps the latest metadata record of every known node. Once full, the
+// records of the nodes seen least recently are evicted for the records of new
+// nodes. It is safe for concurrent use.
+type Book struct {
+	mtx     tmsync.RWMutex
+	records map[p2p.NodeID]bookEntry
+	maxSize int
+}
+
+type bookEntry struct {
+	record   SignedNodeMetadata
+	lastSeen time.Time // when a record of the node was last added or received
+	pinned   bool      // never evicted, e.g. the node's own record
+}
+
+// NewBook returns a new, empty Book, which keeps records of at most maxSize
+// nodes.
+func NewBook(maxSize int) *Book {
+	return &Book{
+		records: make(map[p2p.NodeID]bookEntry),
+		maxSize: maxSize,
+	}
+}
+
+// Add verifies the record and adds it, unless a record of the node with the
+// same or a later timestamp is known already. It returns true if the record
+// was added. Records with a timestamp too far in the future are ignored. If
+// the book is full, the record of the node seen least recently is evicted.
+func (b *Book) Add(record SignedNodeMetadata) (bool, error) {
+	return b.add(record, false)
+}
+
+// add adds the record as Add does, and pins it if pinned is true.
+func (b *Book) add(record SignedNodeMetadata, pinned bool) (bool, error) {
+	if err := record.Verify(); err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if record.Timestamp.After(now.Add(maxClockDrift)) {
+		return false, nil
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	known, ok := b.records[record.NodeID]
+	if ok {
+		known.lastSeen = now
+		b.records[record.NodeID] = known
+		if !record.Timestamp.After(known.record.Timestamp) {
+			return false, nil
+		}
+	} else if len(b.records) >= b.maxSize && !b.evict() {
+		return false, nil
+	}
+
+	b.records[record.NodeID] = bookEntry{
+		record:   record,
+		lastSeen: now,
+		pinned:   pinned || known.pinned,
+	}
+	return true, nil
+}
+
+// evict removes the unpinned record of the node seen least recently. It
+// returns false if all records are pinned.
+func (b *Book) evict() bool {
+	var (
+		oldest p2p.NodeID
+		found  bool
+	)
+	for id, entry := range b.records {
+		if entry.pinned {
+			continue
+		}
+		if !found || entry.lastSeen.Before(b.records[oldest].lastSeen) {
+			oldest, found = id, true
+		}
+	}
+	if found {
+		delete(b.records, oldest)
+	}
+	return found
+}
+
+// Get returns the record of the given node, if known.
+func (b *Book) Get(id p2p.NodeID) (SignedNodeMetadata, bool) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	entry, ok := b.records[id]
+	return entry.record, ok
+}
+
+// List returns all records, sorted by node ID.
+func (b *Book) List() []SignedNodeMetadata {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	records := make([]SignedNodeMetadata, 0, len(b.records))
+	for _, entry := range b.records {
+		records = append(records, entry.record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].NodeID < records[j].NodeID
+	})
+	return records
+}
+
+// Size returns the number of known nodes.
+func (b *Book) Size() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return len(b.records)
+}
@@ -0,0 +1,72 @@
+package metadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
+)
+
+func TestBook_Add(t *testing.T) {
+	book := metadata.NewBook(2)
+	nodeKey := p2p.GenNodeKey()
+	now := time.Now()
+
+	record := makeRecord(t, nodeKey, "first", now)
+	added, err := book.Add(record)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// the same or an earlier record is ignored
+	added, err = book.Add(record)
+	require.NoError(t, err)
+	require.False(t, added)
+	added, err = book.Add(makeRecord(t, nodeKey, "earlier", now.Add(-time.Second)))
+	require.NoError(t, err)
+	require.False(t, added)
+
+	// a later record replaces it
+	added, err = book.Add(makeRecord(t, nodeKey, "later", now.Add(time.Second)))
+	require.NoError(t, err)
+	require.True(t, added)
+	got, ok := book.Get(nodeKey.ID)
+	require.True(t, ok)
+	require.Equal(t, "later", got.Moniker)
+
+	// records too far in the future are ignored
+	added, err = book.Add(makeRecord(t, nodeKey, "future", now.Add(time.Hour)))
+	require.NoError(t, err)
+	require.False(t, added)
+
+	// invalid records are rejected
+	invalid := makeRecord(t, nodeKey, "invalid", now.Add(2*time.Second))
+	invalid.Moniker = "tampered"
+	_, err = book.Add(invalid)
+	require.Error(t, err)
+
+	second := makeRecord(t, p2p.GenNodeKey(), "second", now)
+	added, err = book.Add(second)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	records := book.List()
+	require.Len(t, records, 2)
+	require.True(t, records[0].NodeID < records[1].NodeID)
+
+	// once full, the record of the node seen least recently is evicted
+	time.Sleep(time.Millisecond)
+	added, err = book.Add(record)
+	require.NoError(t, err)
+	require.False(t, added)
+	added, err = book.Add(makeRecord(t, p2p.GenNodeKey(), "third", now))
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, 2, book.Size())
+	_, ok = book.Get(second.NodeID)
+	require.False(t, ok)
+	_, ok = book.Get(nodeKey.ID)
+	require.True(t, ok)
+}
@@ -0,0 +1,166 @@
+// Package metadata implements the gossip of optional, signed node metadata
+// records, e.g. the moniker and website of a node, between peers.
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	"github.com/tendermint/tendermint/p2p"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+const (
+	// MaxFieldLength is the max length of the moniker, website, contact and
+	// version of a NodeMetadata.
+	MaxFieldLength = 256
+
+	// maxClockDrift is how far in the future the timestamp of a record may be.
+	// Later records replace earlier ones, so a record far in the future could
+	// not be replaced for a long time.
+	maxClockDrift = 10 * time.Minute
+)
+
+// NodeMetadata is optional information published by a node about itself, so
+// that explorers can tell which nodes belong to which operator.
+type NodeMetadata struct {
+	NodeID    p2p.NodeID `json:"node_id"`
+	Moniker   string     `json:"moniker"`
+	Website   string     `json:"website"`
+	Contact   string     `json:"contact"`
+	Version   string     `json:"version"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// ValidateBasic performs basic validation.
+func (m NodeMetadata) ValidateBasic() error {
+	if err := m.NodeID.Validate(); err != nil {
+		return fmt.Errorf("invalid node ID: %w", err)
+	}
+	fields := []struct {
+		name, value string
+	}{
+		{"moniker", m.Moniker},
+		{"website", m.Website},
+		{"contact", m.Contact},
+		{"version", m.Version},
+	}
+	for _, f := range fields {
+		if len(f.value) > MaxFieldLength {
+			return fmt.Errorf("%s is longer than %d bytes", f.name, MaxFieldLength)
+		}
+		if f.value != "" && !tmstrings.IsASCIIText(f.value) {
+			return fmt.Errorf("%s must be ASCII text without tabs", f.name)
+		}
+	}
+	if m.Timestamp.IsZero() {
+		return errors.New("missing timestamp")
+	}
+	return nil
+}
+
+// ToProto converts NodeMetadata to protobuf.
+func (m NodeMetadata) ToProto() tmp2p.NodeMetadata {
+	return tmp2p.NodeMetadata{
+		NodeID:    string(m.NodeID),
+		Moniker:   m.Moniker,
+		Website:   m.Website,
+		Contact:   m.Contact,
+		Version:   m.Version,
+		Timestamp: m.Timestamp,
+	}
+}
+
+// SignBytes returns the bytes which are signed.
+func (m NodeMetadata) SignBytes() []byte {
+	pb := m.ToProto()
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// NodeMetadataFromProto converts protobuf to NodeMetadata.
+func NodeMetadataFromProto(pb tmp2p.NodeMetadata) NodeMetadata {
+	return NodeMetadata{
+		NodeID:    p2p.NodeID(pb.NodeID),
+		Moniker:   pb.Moniker,
+		Website:   pb.Website,
+		Contact:   pb.Contact,
+		Version:   pb.Version,
+		Timestamp: pb.Timestamp,
+	}
+}
+
+// SignedNodeMetadata is a NodeMetadata signed with the key of the node. Nodes
+// relay records of other nodes, the signature proves the record is authentic.
+type SignedNodeMetadata struct {
+	NodeMetadata `json:"metadata"`
+	PubKey       crypto.PubKey `json:"pub_key"`
+	Signature    []byte        `json:"signature"`
+}
+
+// Sign signs the metadata with the node key. It sets the node ID of the
+// metadata.
+func Sign(m NodeMetadata, nodeKey p2p.NodeKey) (SignedNodeMetadata, error) {
+	m.NodeID = nodeKey.ID
+	sig, err := nodeKey.PrivKey.Sign(m.SignBytes())
+	if err != nil {
+		return SignedNodeMetadata{}, err
+	}
+	return SignedNodeMetadata{
+		NodeMetadata: m,
+		PubKey:       nodeKey.PubKey(),
+		Signature:    sig,
+	}, nil
+}
+
+// Verify validates the metadata and checks that it was signed by the node
+// it describes.
+func (s SignedNodeMetadata) Verify() error {
+	if err := s.NodeMetadata.ValidateBasic(); err != nil {
+		return err
+	}
+	if s.PubKey == nil {
+		return errors.New("missing public key")
+	}
+	if id := p2p.NodeIDFromPubKey(s.PubKey); id != s.NodeID {
+		return fmt.Errorf("public key belongs to node %v, not %v", id, s.NodeID)
+	}
+	if !s.PubKey.VerifySignature(s.SignBytes(), s.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ToProto converts SignedNodeMetadata to protobuf.
+func (s SignedNodeMetadata) ToProto() (tmp2p.SignedNodeMetadata, error) {
+	pk, err := cryptoenc.PubKeyToProto(s.PubKey)
+	if err != nil {
+		return tmp2p.SignedNodeMetadata{}, err
+	}
+	return tmp2p.SignedNodeMetadata{
+		Metadata:  s.NodeMetadata.ToProto(),
+		PubKey:    pk,
+		Signature: s.Signature,
+	}, nil
+}
+
+// SignedNodeMetadataFromProto converts protobuf to SignedNodeMetadata. It
+// does not verify the signature.
+func SignedNodeMetadataFromProto(pb tmp2p.SignedNodeMetadata) (SignedNodeMetadata, error) {
+	pk, err := cryptoenc.PubKeyFromProto(pb.PubKey)
+	if err != nil {
+		return SignedNodeMetadata{}, err
+	}
+	return SignedNodeMetadata{
+		NodeMetadata: NodeMetadataFromProto(pb.Metadata),
+		PubKey:       pk,
+		Signature:    pb.Signature,
+	}, nil
+}
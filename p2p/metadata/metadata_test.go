@@ -0,0 +1,72 @@
+package metadata_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
+)
+
+func makeRecord(t *testing.T, nodeKey p2p.NodeKey, moniker string, timestamp time.Time) metadata.SignedNodeMetadata {
+	t.Helper()
+
+	record, err := metadata.Sign(metadata.NodeMetadata{
+		Moniker:   moniker,
+		Website:   "https://example.com",
+		Contact:   "ops@example.com",
+		Version:   "0.34.10",
+		Timestamp: timestamp,
+	}, nodeKey)
+	require.NoError(t, err)
+	return record
+}
+
+func TestSignedNodeMetadata_Verify(t *testing.T) {
+	nodeKey := p2p.GenNodeKey()
+	otherKey := p2p.GenNodeKey()
+
+	testcases := map[string]struct {
+		modify    func(*metadata.SignedNodeMetadata)
+		expectErr bool
+	}{
+		"valid":              {func(r *metadata.SignedNodeMetadata) {}, false},
+		"tampered moniker":   {func(r *metadata.SignedNodeMetadata) { r.Moniker = "evil" }, true},
+		"other node ID":      {func(r *metadata.SignedNodeMetadata) { r.NodeID = otherKey.ID }, true},
+		"other pubkey":       {func(r *metadata.SignedNodeMetadata) { r.PubKey = otherKey.PubKey() }, true},
+		"missing pubkey":     {func(r *metadata.SignedNodeMetadata) { r.PubKey = nil }, true},
+		"missing signature":  {func(r *metadata.SignedNodeMetadata) { r.Signature = nil }, true},
+		"missing timestamp":  {func(r *metadata.SignedNodeMetadata) { r.Timestamp = time.Time{} }, true},
+		"moniker too long":   {func(r *metadata.SignedNodeMetadata) { r.Moniker = strings.Repeat("a", 257) }, true},
+		"website with a tab": {func(r *metadata.SignedNodeMetadata) { r.Website = "a\tb" }, true},
+	}
+	for desc, tc := range testcases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			record := makeRecord(t, nodeKey, "node", time.Now())
+			tc.modify(&record)
+
+			err := record.Verify()
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignedNodeMetadata_ProtoRoundTrip(t *testing.T) {
+	record := makeRecord(t, p2p.GenNodeKey(), "node", time.Now().UTC())
+
+	pb, err := record.ToProto()
+	require.NoError(t, err)
+	decoded, err := metadata.SignedNodeMetadataFromProto(pb)
+	require.NoError(t, err)
+
+	require.Equal(t, record, decoded)
+	require.NoError(t, decoded.Verify())
+}
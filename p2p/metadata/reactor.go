@@ -0,0 +1,253 @@
+package metadata
+
+import (
+	"fmt"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/p2p"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+var (
+	_ service.Service = (*Reactor)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		MetadataChannel: {
+			MsgType: new(tmp2p.NodeMetadataList),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(MetadataChannel),
+				Priority:            1,
+				SendQueueCapacity:   10,
+				RecvMessageCapacity: maxMsgSize,
+
+				MaxSendBytes: 200,
+			},
+		},
+	}
+)
+
+const (
+	MetadataChannel = p2p.ChannelID(0x70)
+
+	maxMsgSize = 1048576 // 1MB
+
+	// maxRecordsPerMsg is the max number of records sent in one message.
+	maxRecordsPerMsg = 100
+)
+
+// Reactor gossips signed node metadata records. A node sends all records it
+// knows of, including its own if it publishes one, to every new peer, and
+// relays records it didn't know of to all its peers. Only the records of known
+// nodes, i.e. connected peers and the peers of the peer manager, are accepted,
+// so that minted node keys can't flood the book.
+type Reactor struct {
+	service.BaseService
+
+	book        *Book
+	peerManager *p2p.PeerManager
+	metadataCh  *p2p.Channel
+	peerUpdates *p2p.PeerUpdates
+	closeCh     chan struct{}
+
+	mtx   tmsync.RWMutex
+	peers map[p2p.NodeID]struct{} // connected peers
+}
+
+// NewReactor returns a reference to a new metadata reactor. It accepts a p2p
+// Channel dedicated for handling envelopes with NodeMetadataList messages. If
+// own is not nil, it is added to the book, never to be evicted, and published
+// to peers. The peer manager may be nil, in which case only the records of
+// connected peers are accepted.
+func NewReactor(
+	logger log.Logger,
+	metadataCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdates,
+	peerManager *p2p.PeerManager,
+	book *Book,
+	own *SignedNodeMetadata,
+) (*Reactor, error) {
+	if own != nil {
+		if _, err := book.add(*own, true); err != nil {
+			return nil, fmt.Errorf("invalid node metadata: %w", err)
+		}
+	}
+
+	r := &Reactor{
+		book:        book,
+		peerManager: peerManager,
+		metadataCh:  metadataCh,
+		peerUpdates: peerUpdates,
+		closeCh:     make(chan struct{}),
+		peers:       make(map[p2p.NodeID]struct{}),
+	}
+
+	r.BaseService = *service.NewBaseService(logger, "Metadata", r)
+	return r, nil
+}
+
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. The caller must be sure to execute
+// OnStop to ensure the outbound p2p Channels are closed. No error is returned.
+func (r *Reactor) OnStart() error {
+	go r.processMetadataCh()
+	go r.processPeerUpdates()
+
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	close(r.closeCh)
+
+	<-r.metadataCh.Done()
+	<-r.peerUpdates.Done()
+}
+
+// Book returns the book of known node metadata records.
+func (r *Reactor) Book() *Book {
+	return r.book
+}
+
+// handleMessage handles an Envelope sent from a peer on the MetadataChannel.
+// It returns an error if the message is unknown or contains an invalid
+// record. It will handle any possible panics gracefully.
+func (r *Reactor) handleMessage(envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+		}
+	}()
+
+	r.Logger.Debug("received message", "message", envelope.Message, "peer", envelope.From)
+
+	msg, ok := envelope.Message.(*tmp2p.NodeMetadataList)
+	if !ok {
+		return fmt.Errorf("received unknown message: %T", envelope.Message)
+	}
+	if len(msg.Records) > maxRecordsPerMsg {
+		return fmt.Errorf("received %d records, max is %d", len(msg.Records), maxRecordsPerMsg)
+	}
+
+	var added []SignedNodeMetadata
+	for _, pb := range msg.Records {
+		record, err := SignedNodeMetadataFromProto(pb)
+		if err != nil {
+			return fmt.Errorf("invalid node metadata: %w", err)
+		}
+		if record.NodeID != envelope.From && !r.isKnown(record.NodeID) {
+			continue
+		}
+		ok, err := r.book.Add(record)
+		if err != nil {
+			return fmt.Errorf("invalid node metadata of %v: %w", record.NodeID, err)
+		}
+		if ok {
+			added = append(added, record)
+		}
+	}
+
+	// relay the records we didn't know of
+	r.send(p2p.Envelope{Broadcast: true}, added)
+	return nil
+}
+
+// isKnown returns true if the node is a connected peer, or a peer of the peer
+// manager.
+func (r *Reactor) isKnown(id p2p.NodeID) bool {
+	r.mtx.RLock()
+	_, ok := r.peers[id]
+	r.mtx.RUnlock()
+	return ok || (r.peerManager != nil && r.peerManager.Known(id))
+}
+
+// send sends the records in batches of maxRecordsPerMsg.
+func (r *Reactor) send(envelope p2p.Envelope, records []SignedNodeMetadata) {
+	for len(records) > 0 {
+		n := len(records)
+		if n > maxRecordsPerMsg {
+			n = maxRecordsPerMsg
+		}
+
+		msg := &tmp2p.NodeMetadataList{Records: make([]tmp2p.SignedNodeMetadata, 0, n)}
+		for _, record := range records[:n] {
+			pb, err := record.ToProto()
+			if err != nil {
+				r.Logger.Error("failed to convert node metadata", "node", record.NodeID, "err", err)
+				continue
+			}
+			msg.Records = append(msg.Records, pb)
+		}
+		records = records[n:]
+
+		envelope.Message = msg
+		select {
+		case r.metadataCh.Out <- envelope:
+		case <-r.closeCh:
+			return
+		}
+	}
+}
+
+// processMetadataCh implements a blocking event loop where we listen for p2p
+// Envelope messages from the metadataCh.
+func (r *Reactor) processMetadataCh() {
+	defer r.metadataCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.metadataCh.In:
+			if err := r.handleMessage(envelope); err != nil {
+				r.Logger.Error("failed to process message", "ch_id", r.metadataCh.ID, "envelope", envelope, "err", err)
+				r.metadataCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    err,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on metadata channel; closing...")
+			return
+		}
+	}
+}
+
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. New peers are sent all known records. When the reactor
+// is stopped, we will catch the signal and close the p2p PeerUpdatesCh
+// gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.Logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
+			switch peerUpdate.Status {
+			case p2p.PeerStatusUp:
+				r.mtx.Lock()
+				r.peers[peerUpdate.NodeID] = struct{}{}
+				r.mtx.Unlock()
+				r.send(p2p.Envelope{To: peerUpdate.NodeID}, r.book.List())
+			case p2p.PeerStatusDown:
+				r.mtx.Lock()
+				delete(r.peers, peerUpdate.NodeID)
+				r.mtx.Unlock()
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
+}
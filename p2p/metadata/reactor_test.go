@@ -0,0 +1,152 @@
+package metadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+type reactorTestSuite struct {
+	reactor     *metadata.Reactor
+	book        *metadata.Book
+	peerManager *p2p.PeerManager
+
+	inCh  chan p2p.Envelope
+	outCh chan p2p.Envelope
+	errCh chan p2p.PeerError
+
+	peerUpdateCh chan p2p.PeerUpdate
+}
+
+func setup(t *testing.T, own *metadata.SignedNodeMetadata) *reactorTestSuite {
+	t.Helper()
+
+	rts := &reactorTestSuite{
+		book:         metadata.NewBook(metadata.DefaultMaxBookSize),
+		inCh:         make(chan p2p.Envelope, 10),
+		outCh:        make(chan p2p.Envelope, 10),
+		errCh:        make(chan p2p.PeerError, 10),
+		peerUpdateCh: make(chan p2p.PeerUpdate, 10),
+	}
+
+	ch := p2p.NewChannel(metadata.MetadataChannel, new(tmp2p.NodeMetadataList), rts.inCh, rts.outCh, rts.errCh)
+	peerUpdates := p2p.NewPeerUpdates(rts.peerUpdateCh, 10)
+
+	var err error
+	rts.peerManager, err = p2p.NewPeerManager(p2p.GenNodeKey().ID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	rts.reactor, err = metadata.NewReactor(log.TestingLogger(), ch, peerUpdates, rts.peerManager, rts.book, own)
+	require.NoError(t, err)
+	require.NoError(t, rts.reactor.Start())
+	t.Cleanup(func() {
+		require.NoError(t, rts.reactor.Stop())
+	})
+
+	return rts
+}
+
+func toList(t *testing.T, records ...metadata.SignedNodeMetadata) *tmp2p.NodeMetadataList {
+	msg := &tmp2p.NodeMetadataList{}
+	for _, record := range records {
+		pb, err := record.ToProto()
+		require.NoError(t, err)
+		msg.Records = append(msg.Records, pb)
+	}
+	return msg
+}
+
+func requireOut(t *testing.T, rts *reactorTestSuite, expect p2p.Envelope) {
+	t.Helper()
+
+	select {
+	case envelope := <-rts.outCh:
+		require.Equal(t, expect, envelope)
+	case <-time.After(time.Second):
+		require.Fail(t, "no message sent")
+	}
+}
+
+func requireNoOut(t *testing.T, rts *reactorTestSuite) {
+	t.Helper()
+
+	select {
+	case envelope := <-rts.outCh:
+		require.Fail(t, "unexpected message", "%v", envelope)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestReactor_SendsRecordsToNewPeers(t *testing.T) {
+	own := makeRecord(t, p2p.GenNodeKey(), "own", time.Now())
+	rts := setup(t, &own)
+
+	peer := p2p.GenNodeKey().ID
+	rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: peer, Status: p2p.PeerStatusUp}
+
+	requireOut(t, rts, p2p.Envelope{To: peer, Message: toList(t, own)})
+}
+
+func TestReactor_RelaysNewRecords(t *testing.T) {
+	rts := setup(t, nil)
+
+	nodeKey := p2p.GenNodeKey()
+	rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: nodeKey.ID, Status: p2p.PeerStatusUp}
+	requireNoOut(t, rts)
+
+	record := makeRecord(t, nodeKey, "other", time.Now())
+	rts.inCh <- p2p.Envelope{From: "peer", Message: toList(t, record)}
+	requireOut(t, rts, p2p.Envelope{Broadcast: true, Message: toList(t, record)})
+
+	got, ok := rts.book.Get(record.NodeID)
+	require.True(t, ok)
+	require.Equal(t, "other", got.Moniker)
+
+	// known records are not relayed again
+	rts.inCh <- p2p.Envelope{From: "peer", Message: toList(t, record)}
+	requireNoOut(t, rts)
+}
+
+func TestReactor_IgnoresUnknownNodes(t *testing.T) {
+	rts := setup(t, nil)
+
+	// records of unknown nodes are ignored
+	unknown := makeRecord(t, p2p.GenNodeKey(), "unknown", time.Now())
+	rts.inCh <- p2p.Envelope{From: "peer", Message: toList(t, unknown)}
+	requireNoOut(t, rts)
+	require.Zero(t, rts.book.Size())
+
+	// but not the records of the sender, nor of the peers of the peer manager
+	sender := makeRecord(t, p2p.GenNodeKey(), "sender", time.Now())
+	rts.inCh <- p2p.Envelope{From: sender.NodeID, Message: toList(t, sender)}
+	requireOut(t, rts, p2p.Envelope{Broadcast: true, Message: toList(t, sender)})
+
+	known := makeRecord(t, p2p.GenNodeKey(), "known", time.Now())
+	_, err := rts.peerManager.Add(p2p.NodeAddress{NodeID: known.NodeID, Protocol: "memory"})
+	require.NoError(t, err)
+	rts.inCh <- p2p.Envelope{From: "peer", Message: toList(t, known)}
+	requireOut(t, rts, p2p.Envelope{Broadcast: true, Message: toList(t, known)})
+	require.Equal(t, 2, rts.book.Size())
+}
+
+func TestReactor_InvalidRecord(t *testing.T) {
+	rts := setup(t, nil)
+
+	record := makeRecord(t, p2p.GenNodeKey(), "other", time.Now())
+	record.Moniker = "forged"
+	rts.inCh <- p2p.Envelope{From: record.NodeID, Message: toList(t, record)}
+
+	select {
+	case peerErr := <-rts.errCh:
+		require.Equal(t, record.NodeID, peerErr.NodeID)
+	case <-time.After(time.Second):
+		require.Fail(t, "no peer error")
+	}
+	require.Zero(t, rts.book.Size())
+}
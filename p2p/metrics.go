@@ -43,6 +43,22 @@ type Metrics struct {
 	// PeerQueueMsgSize defines the average size of messages sent over a peer's
 	// queue for a specific flow (i.e. Channel).
 	PeerQueueMsgSize metrics.Gauge
+
+	// RouterDialAttempts defines the number of attempts to dial and handshake
+	// a peer, by result: ok, or the failed step (dial or handshake) suffixed
+	// with _timeout or _error.
+	RouterDialAttempts metrics.Counter
+
+	// RouterDialDuration defines the time taken to dial a peer, in seconds.
+	RouterDialDuration metrics.Histogram
+
+	// RouterHandshakeDuration defines the time taken to handshake with a
+	// peer, including the authentication, in seconds.
+	RouterHandshakeDuration metrics.Histogram
+
+	// RouterDialsInProgress defines the number of peers being dialed or
+	// handshaked, out of max-concurrent-dials.
+	RouterDialsInProgress metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -118,6 +134,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "router_channel_queue_msg_size",
 			Help:      "The size of messages sent over a peer's queue for a specific p2p Channel.",
 		}, append(labels, "ch_id")).With(labelsAndValues...),
+
+		RouterDialAttempts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_dial_attempts",
+			Help:      "The number of attempts to dial and handshake a peer, by result.",
+		}, append(labels, "result")).With(labelsAndValues...),
+
+		RouterDialDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_dial_duration",
+			Help:      "The time taken to dial a peer, in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, labels).With(labelsAndValues...),
+
+		RouterHandshakeDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_handshake_duration",
+			Help:      "The time taken to handshake with a peer, including the authentication, in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, labels).With(labelsAndValues...),
+
+		RouterDialsInProgress: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_dials_in_progress",
+			Help:      "The number of peers being dialed or handshaked.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -133,5 +179,10 @@ func NopMetrics() *Metrics {
 		RouterChannelQueueSend: discard.NewHistogram(),
 		PeerQueueDroppedMsgs:   discard.NewCounter(),
 		PeerQueueMsgSize:       discard.NewGauge(),
+
+		RouterDialAttempts:      discard.NewCounter(),
+		RouterDialDuration:      discard.NewHistogram(),
+		RouterHandshakeDuration: discard.NewHistogram(),
+		RouterDialsInProgress:   discard.NewGauge(),
 	}
 }
@@ -64,6 +64,12 @@ type NodeInfo struct {
 	// ASCIIText fields
 	Moniker string        `json:"moniker"` // arbitrary moniker
 	Other   NodeInfoOther `json:"other"`   // other application specific data
+
+	Features Features `json:"features"` // optional protocol features this node supports
+
+	AppChannels []AppChannel `json:"app_channels"` // versions of the app channels this node speaks
+
+	ChannelVersions []ChannelVersion `json:"channel_versions"` // latest message versions of versioned channels
 }
 
 // NodeInfoOther is the misc. applcation specific data
@@ -109,10 +115,9 @@ func (info NodeInfo) Validate() error {
 		return fmt.Errorf("info.Version must be valid ASCII text without tabs, but got %v", info.Version)
 	}
 
-	// Validate Channels - ensure max and check for duplicates.
-	if len(info.Channels) > maxNumChannels {
-		return fmt.Errorf("info.Channels is too long (%v). Max is %v", len(info.Channels), maxNumChannels)
-	}
+	// Validate Channels - ensure max and check for duplicates. App channels
+	// have their own max.
+	numAppChannels := 0
 	channels := make(map[byte]struct{})
 	for _, ch := range info.Channels {
 		_, ok := channels[ch]
@@ -120,6 +125,39 @@ func (info NodeInfo) Validate() error {
 			return fmt.Errorf("info.Channels contains duplicate channel id %v", ch)
 		}
 		channels[ch] = struct{}{}
+		if IsAppChannel(ChannelID(ch)) {
+			numAppChannels++
+		}
+	}
+	if len(info.Channels)-numAppChannels > maxNumChannels {
+		return fmt.Errorf("info.Channels is too long (%v). Max is %v",
+			len(info.Channels)-numAppChannels, maxNumChannels)
+	}
+	if numAppChannels > maxNumAppChannels {
+		return fmt.Errorf("info.Channels has too many app channels (%v). Max is %v",
+			numAppChannels, maxNumAppChannels)
+	}
+
+	// Validate AppChannels - each must be one of the channels.
+	for _, c := range info.AppChannels {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if _, ok := channels[byte(c.ID)]; !ok {
+			return fmt.Errorf("info.AppChannels contains unknown channel id %v", c.ID)
+		}
+	}
+
+	// Validate ChannelVersions - each must be one of the channels, once.
+	versioned := make(map[ChannelID]struct{})
+	for _, cv := range info.ChannelVersions {
+		if _, ok := channels[byte(cv.ChannelID)]; !ok {
+			return fmt.Errorf("info.ChannelVersions contains unknown channel id %v", cv.ChannelID)
+		}
+		if _, ok := versioned[cv.ChannelID]; ok {
+			return fmt.Errorf("info.ChannelVersions contains duplicate channel id %v", cv.ChannelID)
+		}
+		versioned[cv.ChannelID] = struct{}{}
 	}
 
 	// Validate Moniker.
@@ -208,6 +246,13 @@ func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	dni.Features = uint64(info.Features)
+	for _, c := range info.AppChannels {
+		dni.AppChannels = append(dni.AppChannels, c.toProto())
+	}
+	for _, cv := range info.ChannelVersions {
+		dni.ChannelVersions = append(dni.ChannelVersions, cv.toProto())
+	}
 
 	return dni
 }
@@ -232,6 +277,13 @@ func NodeInfoFromProto(pb *tmp2p.NodeInfo) (NodeInfo, error) {
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
 		},
+		Features: Features(pb.Features),
+	}
+	for _, c := range pb.AppChannels {
+		dni.AppChannels = append(dni.AppChannels, appChannelFromProto(c))
+	}
+	for _, cv := range pb.ChannelVersions {
+		dni.ChannelVersions = append(dni.ChannelVersions, channelVersionFromProto(cv))
 	}
 
 	return dni, nil
@@ -90,10 +90,28 @@ type peer struct {
 
 	metrics       *Metrics
 	metricsTicker *time.Ticker
+
+	chaos *chaosHooks
 }
 
 type PeerOption func(*peer)
 
+// PeerAppChannels sets our app channels, so that only the app channels the
+// peer speaks a common version of are used with it.
+func PeerAppChannels(channels []AppChannel) PeerOption {
+	return func(p *peer) {
+		p.channels = negotiateAppChannels(channels, p.nodeInfo)
+	}
+}
+
+// peerChaosHooks applies the chaos policy of the switch to the messages
+// received from the peer.
+func peerChaosHooks(chaos *chaosHooks) PeerOption {
+	return func(p *peer) {
+		p.chaos = chaos
+	}
+}
+
 func newPeer(
 	nodeInfo NodeInfo,
 	pc peerConn,
@@ -174,7 +192,16 @@ func (p *peer) processMessages() {
 			p.onError(fmt.Errorf("unknown channel %v", chID))
 			return
 		}
-		reactor.Receive(byte(chID), p, msg)
+		// Drop the messages of app channels we don't speak a common version of.
+		if IsAppChannel(chID) && !p.hasChannel(byte(chID)) {
+			continue
+		}
+		p.chaos.receive(p.ID(), byte(chID), func() {
+			// a delayed message may arrive after the peer was removed
+			if p.IsRunning() {
+				reactor.Receive(byte(chID), p, msg)
+			}
+		})
 	}
 }
 
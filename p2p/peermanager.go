@@ -48,6 +48,10 @@ const (
 type PeerUpdate struct {
 	NodeID NodeID
 	Status PeerStatus
+
+	// Features are the protocol features the peer advertised, set for
+	// PeerStatusUp.
+	Features Features
 }
 
 // PeerUpdates is a peer update subscription with notifications about peer
@@ -432,6 +436,67 @@ func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 	return true, nil
 }
 
+// Forward moves a peer that has rotated its node key from oldID to newID,
+// typically following a verified ForwardingRecord. The old peer's addresses are
+// added to the new peer, which also takes over its persistence and score, and
+// the old peer is removed unless it is currently connected. It returns false
+// if the old peer is unknown, in which case nothing is done.
+func (m *PeerManager) Forward(oldID, newID NodeID) (bool, error) {
+	if err := newID.Validate(); err != nil {
+		return false, err
+	}
+	if oldID == m.selfID || newID == m.selfID {
+		return false, nil
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	oldPeer, ok := m.store.Get(oldID)
+	if !ok {
+		return false, nil
+	}
+	peer, ok := m.store.Get(newID)
+	if !ok {
+		peer = m.newPeerInfo(newID)
+	}
+
+	for address := range oldPeer.AddressInfo {
+		address.NodeID = newID
+		if _, ok := peer.AddressInfo[address]; !ok {
+			peer.AddressInfo[address] = &peerAddressInfo{Address: address}
+		}
+	}
+	peer.Persistent = peer.Persistent || oldPeer.Persistent
+	if oldPeer.FixedScore > peer.FixedScore {
+		peer.FixedScore = oldPeer.FixedScore
+	}
+	if oldPeer.MutableScore > peer.MutableScore {
+		peer.MutableScore = oldPeer.MutableScore
+	}
+	if oldPeer.LastConnected.After(peer.LastConnected) {
+		peer.LastConnected = oldPeer.LastConnected
+	}
+	if err := m.store.Set(peer); err != nil {
+		return false, err
+	}
+
+	// If we're still connected to the old peer we leave it be, but make sure
+	// we don't keep redialing it once it goes away.
+	if m.connected[oldID] || m.dialing[oldID] {
+		oldPeer.Persistent = false
+		oldPeer.FixedScore = 0
+		if err := m.store.Set(oldPeer); err != nil {
+			return true, err
+		}
+	} else if err := m.store.Delete(oldID); err != nil {
+		return true, err
+	}
+
+	m.dialWaker.Wake()
+	return true, nil
+}
+
 // PeerRatio returns the ratio of peer addresses stored to the maximum size.
 func (m *PeerManager) PeerRatio() float64 {
 	if m.options.MaxPeers == 0 {
@@ -680,19 +745,20 @@ func (m *PeerManager) Accepted(peerID NodeID) error {
 	return nil
 }
 
-// Ready marks a peer as ready, broadcasting status updates to subscribers. The
-// peer must already be marked as connected. This is separate from Dialed() and
-// Accepted() to allow the router to set up its internal queues before reactors
-// start sending messages.
-func (m *PeerManager) Ready(peerID NodeID) {
+// Ready marks a peer as ready, broadcasting status updates with the features
+// the peer advertised to subscribers. The peer must already be marked as
+// connected. This is separate from Dialed() and Accepted() to allow the router
+// to set up its internal queues before reactors start sending messages.
+func (m *PeerManager) Ready(peerID NodeID, features Features) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
 	if m.connected[peerID] {
 		m.ready[peerID] = true
 		m.broadcast(PeerUpdate{
-			NodeID: peerID,
-			Status: PeerStatusUp,
+			NodeID:   peerID,
+			Status:   PeerStatusUp,
+			Features: features,
 		})
 	}
 }
@@ -946,6 +1012,15 @@ func (m *PeerManager) Peers() []NodeID {
 	return peers
 }
 
+// Known returns true if the peer is in the peer store, i.e. its address is
+// known.
+func (m *PeerManager) Known(peerID NodeID) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	_, ok := m.store.Get(peerID)
+	return ok
+}
+
 // Scores returns the peer scores for all known peers, primarily for testing.
 func (m *PeerManager) Scores() map[NodeID]PeerScore {
 	m.mtx.Lock()
@@ -1047,6 +1122,11 @@ func (m *PeerManager) SetHeight(peerID NodeID, height int64) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	// Heights are reported several times per block, so known peers are only
+	// updated in memory, the height isn't persisted anyway.
+	if m.store.SetHeight(peerID, height) {
+		return nil
+	}
 	peer, ok := m.store.Get(peerID)
 	if !ok {
 		peer = m.newPeerInfo(peerID)
@@ -1151,6 +1231,17 @@ func (s *peerStore) Set(peer peerInfo) error {
 	return nil
 }
 
+// SetHeight sets the height of a peer without saving it to the database, since
+// heights aren't persisted. It returns false if the peer does not exist.
+func (s *peerStore) SetHeight(id NodeID, height int64) bool {
+	peer, ok := s.peers[id]
+	if !ok {
+		return false
+	}
+	peer.Height = height
+	return true
+}
+
 // Delete deletes a peer, or does nothing if it does not exist.
 func (s *peerStore) Delete(id NodeID) error {
 	if _, ok := s.peers[id]; !ok {
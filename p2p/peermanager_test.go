@@ -271,6 +271,78 @@ func TestPeerManager_Add(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPeerManager_Forward(t *testing.T) {
+	aID := p2p.NodeID(strings.Repeat("a", 40))
+	bID := p2p.NodeID(strings.Repeat("b", 40))
+	cID := p2p.NodeID(strings.Repeat("c", 40))
+	dID := p2p.NodeID(strings.Repeat("d", 40))
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []p2p.NodeID{aID},
+	})
+	require.NoError(t, err)
+
+	a := p2p.NodeAddress{Protocol: "tcp", NodeID: aID, Hostname: "localhost", Port: 26656}
+	added, err := peerManager.Add(a)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// Forwarding an unknown peer should be a noop.
+	forwarded, err := peerManager.Forward(cID, dID)
+	require.NoError(t, err)
+	require.False(t, forwarded)
+	require.Empty(t, peerManager.Addresses(dID))
+
+	// Forwarding a to b should move its addresses and persistence to b, and
+	// remove a.
+	forwarded, err = peerManager.Forward(aID, bID)
+	require.NoError(t, err)
+	require.True(t, forwarded)
+
+	b := a
+	b.NodeID = bID
+	require.Equal(t, []p2p.NodeAddress{b}, peerManager.Addresses(bID))
+	require.Equal(t, []p2p.NodeID{bID}, peerManager.Peers())
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[bID])
+
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, dial)
+
+	// Forwarding to or from ourself should be a noop.
+	forwarded, err = peerManager.Forward(bID, selfID)
+	require.NoError(t, err)
+	require.False(t, forwarded)
+
+	// Forwarding to an invalid ID should error.
+	_, err = peerManager.Forward(bID, "foo")
+	require.Error(t, err)
+}
+
+func TestPeerManager_Forward_Connected(t *testing.T) {
+	aID := p2p.NodeID(strings.Repeat("a", 40))
+	bID := p2p.NodeID(strings.Repeat("b", 40))
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []p2p.NodeID{aID},
+	})
+	require.NoError(t, err)
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: aID}
+	added, err := peerManager.Add(a)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.NoError(t, peerManager.Accepted(aID))
+
+	// The old peer should be kept while connected, but lose its persistence.
+	forwarded, err := peerManager.Forward(aID, bID)
+	require.NoError(t, err)
+	require.True(t, forwarded)
+	require.ElementsMatch(t, []p2p.NodeID{aID, bID}, peerManager.Peers())
+	require.Equal(t, p2p.PeerScore(0), peerManager.Scores()[aID])
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[bID])
+}
+
 func TestPeerManager_DialNext(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: p2p.NodeID(strings.Repeat("a", 40))}
 
@@ -1269,7 +1341,7 @@ func TestPeerManager_Ready(t *testing.T) {
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(a.NodeID))
 
 	// Marking a as ready should transition it to PeerStatusUp and send an update.
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 	require.Equal(t, p2p.PeerStatusUp, peerManager.Status(a.NodeID))
 	require.Equal(t, p2p.PeerUpdate{
 		NodeID: a.NodeID,
@@ -1281,7 +1353,7 @@ func TestPeerManager_Ready(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(b.NodeID))
-	peerManager.Ready(b.NodeID)
+	peerManager.Ready(b.NodeID, 0)
 	require.Equal(t, p2p.PeerStatusDown, peerManager.Status(b.NodeID))
 	require.Empty(t, sub.Updates())
 }
@@ -1297,7 +1369,7 @@ func TestPeerManager_EvictNext(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	// Since there are no peers to evict, EvictNext should block until timeout.
 	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
@@ -1330,7 +1402,7 @@ func TestPeerManager_EvictNext_WakeOnError(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	// Spawn a goroutine to error a peer after a delay.
 	go func() {
@@ -1362,7 +1434,7 @@ func TestPeerManager_EvictNext_WakeOnUpgradeDialed(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	// Spawn a goroutine to upgrade to b with a delay.
 	go func() {
@@ -1400,7 +1472,7 @@ func TestPeerManager_EvictNext_WakeOnUpgradeAccepted(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	// Spawn a goroutine to upgrade b with a delay.
 	go func() {
@@ -1432,7 +1504,7 @@ func TestPeerManager_TryEvictNext(t *testing.T) {
 
 	// Connecting to a won't evict anything either.
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	// But if a errors it should be evicted.
 	peerManager.Errored(a.NodeID, errors.New("foo"))
@@ -1477,7 +1549,7 @@ func TestPeerManager_Disconnected(t *testing.T) {
 	_, err = peerManager.Add(a)
 	require.NoError(t, err)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 	require.Equal(t, p2p.PeerStatusUp, peerManager.Status(a.NodeID))
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{
@@ -1529,7 +1601,7 @@ func TestPeerManager_Errored(t *testing.T) {
 	require.Zero(t, evict)
 
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 	evict, err = peerManager.TryEvictNext()
 	require.NoError(t, err)
 	require.Zero(t, evict)
@@ -1560,7 +1632,7 @@ func TestPeerManager_Subscribe(t *testing.T) {
 	require.NoError(t, peerManager.Accepted(a.NodeID))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
 
@@ -1577,9 +1649,13 @@ func TestPeerManager_Subscribe(t *testing.T) {
 	require.NoError(t, peerManager.Dialed(a))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, p2p.FeatureCompactBlocks)
 	require.NotEmpty(t, sub.Updates())
-	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
+	require.Equal(t, p2p.PeerUpdate{
+		NodeID:   a.NodeID,
+		Status:   p2p.PeerStatusUp,
+		Features: p2p.FeatureCompactBlocks,
+	}, <-sub.Updates())
 
 	peerManager.Errored(a.NodeID, errors.New("foo"))
 	require.Empty(t, sub.Updates())
@@ -1617,7 +1693,7 @@ func TestPeerManager_Subscribe_Close(t *testing.T) {
 	require.NoError(t, peerManager.Accepted(a.NodeID))
 	require.Empty(t, sub.Updates())
 
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 	require.NotEmpty(t, sub.Updates())
 	require.Equal(t, p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}, <-sub.Updates())
 
@@ -1647,7 +1723,7 @@ func TestPeerManager_Subscribe_Broadcast(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, added)
 	require.NoError(t, peerManager.Accepted(a.NodeID))
-	peerManager.Ready(a.NodeID)
+	peerManager.Ready(a.NodeID, 0)
 
 	expectUp := p2p.PeerUpdate{NodeID: a.NodeID, Status: p2p.PeerStatusUp}
 	require.NotEmpty(t, s1)
@@ -102,10 +102,27 @@ type addrBook struct {
 	key               string // random prefix for bucket placement
 	routabilityStrict bool
 	hashKey           []byte
+	backupDir         string
+	backupInterval    time.Duration
+	backupKeep        int
 
 	wg sync.WaitGroup
 }
 
+// AddrBookOption sets an optional parameter on the address book.
+type AddrBookOption func(*addrBook)
+
+// AddrBookBackups makes the address book write a compressed backup of itself
+// to dir every interval, keeping the latest keep backups. Backups can be
+// exported or imported like the address book itself.
+func AddrBookBackups(dir string, interval time.Duration, keep int) AddrBookOption {
+	return func(a *addrBook) {
+		a.backupDir = dir
+		a.backupInterval = interval
+		a.backupKeep = keep
+	}
+}
+
 func newHashKey() []byte {
 	result := make([]byte, highwayhash.Size)
 	crand.Read(result) //nolint:errcheck // ignore error
@@ -114,7 +131,7 @@ func newHashKey() []byte {
 
 // NewAddrBook creates a new address book.
 // Use Start to begin processing asynchronous address updates.
-func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
+func NewAddrBook(filePath string, routabilityStrict bool, options ...AddrBookOption) AddrBook {
 	am := &addrBook{
 		ourAddrs:          make(map[string]struct{}),
 		privateIDs:        make(map[p2p.NodeID]struct{}),
@@ -124,6 +141,9 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 		routabilityStrict: routabilityStrict,
 		hashKey:           newHashKey(),
 	}
+	for _, option := range options {
+		option(am)
+	}
 	am.init()
 	am.BaseService = *service.NewBaseService(nil, "AddrBook", am)
 	return am
@@ -493,11 +513,19 @@ func (a *addrBook) saveRoutine() {
 	defer a.wg.Done()
 
 	saveFileTicker := time.NewTicker(dumpAddressInterval)
+	var backupC <-chan time.Time
+	if a.backupInterval > 0 {
+		backupTicker := time.NewTicker(a.backupInterval)
+		defer backupTicker.Stop()
+		backupC = backupTicker.C
+	}
 out:
 	for {
 		select {
 		case <-saveFileTicker.C:
 			a.saveToFile(a.filePath)
+		case <-backupC:
+			a.backup()
 		case <-a.Quit():
 			break out
 		}
@@ -8,6 +8,7 @@ import (
 	mrand "math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -684,6 +685,83 @@ func TestAddrBookGroupKey(t *testing.T) {
 	}
 }
 
+func TestAddrBookExportImport(t *testing.T) {
+	book, fname := createAddrBookWithMOldAndNNewAddrs(t, 10, 20)
+	book.Save()
+
+	exportFile := func(filter AddrBookFilter) (string, int) {
+		exportName := createTempFileName(t, "addrbook_export")
+		f, err := os.Create(exportName)
+		require.NoError(t, err)
+		defer f.Close()
+		n, err := ExportAddrBook(fname, filter, f)
+		require.NoError(t, err)
+		return exportName, n
+	}
+
+	all, n := exportFile(AddrBookFilter{})
+	assert.Equal(t, 30, n)
+	good, n := exportFile(AddrBookFilter{MinScore: 100})
+	assert.Equal(t, 10, n)
+	_, n = exportFile(AddrBookFilter{SeenWithin: time.Hour})
+	assert.Equal(t, 10, n)
+
+	// Importing replaces the address book, keeping whether the addresses
+	// are old or new.
+	fname2 := createTempFileName(t, "addrbook_test")
+	n, err := ImportAddrBook(fname2, good, AddrBookFilter{}, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	book2 := NewAddrBook(fname2, true).(*addrBook)
+	book2.SetLogger(log.TestingLogger())
+	require.True(t, book2.loadFromFile(fname2))
+	assert.Equal(t, 10, book2.nOld)
+	assert.Equal(t, 0, book2.nNew)
+	assert.NotEqual(t, book.key, book2.key)
+
+	// Merging only adds the unknown addresses.
+	n, err = ImportAddrBook(fname2, all, AddrBookFilter{}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+
+	book2 = NewAddrBook(fname2, true).(*addrBook)
+	book2.SetLogger(log.TestingLogger())
+	require.True(t, book2.loadFromFile(fname2))
+	assert.Equal(t, 30, book2.Size())
+	assert.Equal(t, 10, book2.nOld)
+}
+
+func TestAddrBookBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrbook_backups")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	// Older backups beyond the number to keep are removed.
+	for _, ts := range []string{"20200101T000000Z", "20200102T000000Z"} {
+		require.NoError(t, ioutil.WriteFile(
+			filepath.Join(dir, backupFilePrefix+ts+backupFileSuffix), []byte("{}"), 0644))
+	}
+
+	fname := createTempFileName(t, "addrbook_test")
+	book := NewAddrBook(fname, true, AddrBookBackups(dir, time.Hour, 2)).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+	for _, addrSrc := range randNetAddressPairs(t, 5) {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	book.backup()
+
+	backups, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, backupFilePrefix+"20200102T000000Z"+backupFileSuffix, filepath.Base(backups[0]))
+
+	// Backups are compressed, and can be exported like the address book.
+	n, err := ExportAddrBook(backups[1], AddrBookFilter{}, ioutil.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+}
+
 func assertMOldAndNNewAddrsInSelection(t *testing.T, m, n int, addrs []*p2p.NetAddress, book *addrBook) {
 	nOld, nNew := countOldAndNewAddrsInSelection(addrs, book)
 	assert.Equal(t, m, nOld, "old addresses")
@@ -1,26 +1,57 @@
 package pex
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmos "github.com/tendermint/tendermint/libs/os"
 )
 
 /* Loading & Saving */
 
+const (
+	// backupFilePrefix and backupFileSuffix surround the time of a backup in
+	// its file name, e.g. addrbook-20210102T150405Z.json.gz, so that the file
+	// names sort chronologically.
+	backupFilePrefix = "addrbook-"
+	backupFileSuffix = ".json.gz"
+	backupTimeFormat = "20060102T150405Z"
+)
+
 type addrBookJSON struct {
 	Key   string          `json:"key"`
 	Addrs []*knownAddress `json:"addrs"`
 }
 
 func (a *addrBook) saveToFile(filePath string) {
+	a.Logger.Info("Saving AddrBook to file", "size", a.Size())
+
+	if err := a.writeFile(filePath); err != nil {
+		a.Logger.Error("Failed to save AddrBook to file", "file", filePath, "err", err)
+	}
+}
+
+func (a *addrBook) writeFile(filePath string) error {
+	jsonBytes, err := a.marshalJSON()
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, jsonBytes, 0644)
+}
+
+func (a *addrBook) marshalJSON() ([]byte, error) {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
-	a.Logger.Info("Saving AddrBook to file", "size", a.size())
-
 	addrs := make([]*knownAddress, 0, len(a.addrLookup))
 	for _, ka := range a.addrLookup {
 		addrs = append(addrs, ka)
@@ -29,15 +60,51 @@ func (a *addrBook) saveToFile(filePath string) {
 		Key:   a.key,
 		Addrs: addrs,
 	}
+	return json.MarshalIndent(aJSON, "", "\t")
+}
 
-	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
+// backup writes a compressed copy of the address book to the backup dir, and
+// removes the oldest backups beyond the number to keep.
+func (a *addrBook) backup() {
+	jsonBytes, err := a.marshalJSON()
 	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "err", err)
+		a.Logger.Error("Failed to back up AddrBook", "err", err)
+		return
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(jsonBytes); err != nil {
+		a.Logger.Error("Failed to back up AddrBook", "err", err)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		a.Logger.Error("Failed to back up AddrBook", "err", err)
+		return
+	}
+
+	if err := tmos.EnsureDir(a.backupDir, 0700); err != nil {
+		a.Logger.Error("Failed to back up AddrBook", "err", err)
+		return
+	}
+	backupPath := filepath.Join(a.backupDir,
+		backupFilePrefix+time.Now().UTC().Format(backupTimeFormat)+backupFileSuffix)
+	if err := tempfile.WriteFileAtomic(backupPath, buf.Bytes(), 0644); err != nil {
+		a.Logger.Error("Failed to back up AddrBook", "file", backupPath, "err", err)
 		return
 	}
-	err = tempfile.WriteFileAtomic(filePath, jsonBytes, 0644)
+	a.Logger.Debug("Backed up AddrBook", "file", backupPath)
+
+	backups, err := filepath.Glob(filepath.Join(a.backupDir, backupFilePrefix+"*"+backupFileSuffix))
 	if err != nil {
-		a.Logger.Error("Failed to save AddrBook to file", "file", filePath, "err", err)
+		a.Logger.Error("Failed to list AddrBook backups", "err", err)
+		return
+	}
+	sort.Strings(backups)
+	for len(backups) > a.backupKeep {
+		if err := os.Remove(backups[0]); err != nil {
+			a.Logger.Error("Failed to remove AddrBook backup", "file", backups[0], "err", err)
+		}
+		backups = backups[1:]
 	}
 }
 
@@ -50,20 +117,16 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 		return false
 	}
 
-	// Load addrBookJSON{}
-	r, err := os.Open(filePath)
+	aJSON, err := readAddrBookFile(filePath)
 	if err != nil {
-		panic(fmt.Sprintf("Error opening file %s: %v", filePath, err))
-	}
-	defer r.Close()
-	aJSON := &addrBookJSON{}
-	dec := json.NewDecoder(r)
-	err = dec.Decode(aJSON)
-	if err != nil {
-		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
+		panic(err)
 	}
+	a.restore(aJSON)
+	return true
+}
 
-	// Restore all the fields...
+// restore restores the key and the buckets from the JSON of the address book.
+func (a *addrBook) restore(aJSON *addrBookJSON) {
 	// Restore the key
 	a.key = aJSON.Key
 	// Restore .bucketsNew & .bucketsOld
@@ -79,5 +142,156 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 			a.nOld++
 		}
 	}
+}
+
+// importAddress adds an address exported from another address book, along
+// with its connection history, unless the address is already known. It
+// returns true if the address was added.
+func (a *addrBook) importAddress(imported *knownAddress) bool {
+	if imported.Addr == nil {
+		return false
+	}
+	if _, ok := a.addrLookup[imported.ID()]; ok {
+		return false
+	}
+	src := imported.Src
+	if src == nil {
+		src = imported.Addr
+	}
+	if err := a.addAddress(imported.Addr, src); err != nil {
+		a.Logger.Debug("Skipping imported address", "addr", imported.Addr, "err", err)
+		return false
+	}
+	ka := a.addrLookup[imported.ID()]
+	if ka == nil {
+		return false
+	}
+	ka.Attempts = imported.Attempts
+	ka.LastAttempt = imported.LastAttempt
+	ka.LastSuccess = imported.LastSuccess
+	if imported.isOld() {
+		if err := a.moveToOld(ka); err != nil {
+			a.Logger.Debug("Failed to move imported address to old bucket", "addr", ka.Addr, "err", err)
+		}
+	}
+	return true
+}
+
+// readAddrBookFile reads an address book, or a compressed backup of it.
+func readAddrBookFile(filePath string) (*addrBookJSON, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %w", filePath, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	aJSON := &addrBookJSON{}
+	if err := json.NewDecoder(r).Decode(aJSON); err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+	return aJSON, nil
+}
+
+// AddrBookFilter selects the addresses to export from or import into an
+// address book.
+type AddrBookFilter struct {
+	// Min score of the addresses, from 0 to 100. Addresses we connected to
+	// and which haven't failed since score 100, unconfirmed addresses 50,
+	// and each failed attempt costs 10.
+	MinScore int
+	// If non-zero, only the addresses we connected to within this duration
+	// are selected.
+	SeenWithin time.Duration
+}
+
+func (f AddrBookFilter) match(ka *knownAddress, now time.Time) bool {
+	if ka.Addr == nil || ka.score() < f.MinScore {
+		return false
+	}
+	if f.SeenWithin > 0 && ka.LastSuccess.Before(now.Add(-f.SeenWithin)) {
+		return false
+	}
 	return true
 }
+
+// ExportAddrBook writes the addresses of the address book at filePath, or of
+// a compressed backup of it, which match the filter to w. The addresses are
+// written in the address book format, without the bucket key, and can be
+// imported into another address book with ImportAddrBook. It returns the
+// number of exported addresses.
+func ExportAddrBook(filePath string, filter AddrBookFilter, w io.Writer) (int, error) {
+	aJSON, err := readAddrBookFile(filePath)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	exported := &addrBookJSON{Addrs: []*knownAddress{}}
+	for _, ka := range aJSON.Addrs {
+		if filter.match(ka, now) {
+			exported.Addrs = append(exported.Addrs, ka)
+		}
+	}
+	jsonBytes, err := json.MarshalIndent(exported, "", "\t")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(jsonBytes); err != nil {
+		return 0, err
+	}
+	return len(exported.Addrs), nil
+}
+
+// ImportAddrBook adds the addresses of the address book at srcPath, e.g. an
+// export, another node's address book or a backup, which match the filter to
+// the address book at filePath. Addresses already in the address book are kept
+// as they are. If replace is true, the address book is replaced instead, with
+// a new bucket key. It returns the number of added addresses.
+//
+// The node must not be running, since it overwrites the address book on exit.
+func ImportAddrBook(
+	filePath, srcPath string,
+	filter AddrBookFilter,
+	routabilityStrict, replace bool,
+) (int, error) {
+	src, err := readAddrBookFile(srcPath)
+	if err != nil {
+		return 0, err
+	}
+
+	book := NewAddrBook(filePath, routabilityStrict).(*addrBook)
+	if !replace {
+		if _, err := os.Stat(filePath); err == nil {
+			aJSON, err := readAddrBookFile(filePath)
+			if err != nil {
+				return 0, err
+			}
+			book.restore(aJSON)
+		} else if !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+
+	now := time.Now()
+	added := 0
+	for _, ka := range src.Addrs {
+		if filter.match(ka, now) && book.importAddress(ka) {
+			added++
+		}
+	}
+	if err := book.writeFile(filePath); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
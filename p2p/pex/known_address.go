@@ -65,6 +65,25 @@ func (ka *knownAddress) isBanned() bool {
 	return ka.LastBanTime.After(time.Now())
 }
 
+// score rates how likely the address is to be a good peer, from 0 to 100.
+// Addresses we connected to successfully (old) start at 100 and unconfirmed
+// (new) ones at 50, and each failed attempt since the last success costs 10.
+// Banned and bad addresses score 0.
+func (ka *knownAddress) score() int {
+	if ka.isBanned() || ka.isBad() {
+		return 0
+	}
+	score := 50
+	if ka.isOld() {
+		score = 100
+	}
+	score -= 10 * int(ka.Attempts)
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 func (ka *knownAddress) addBucketRef(bucketIdx int) int {
 	for _, bucket := range ka.Buckets {
 		if bucket == bucketIdx {
@@ -0,0 +1,132 @@
+package pex
+
+import (
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// latencyProbeCandidates is the number of candidates probed per peer to
+	// dial, when probing the latency of peers before dialing them.
+	latencyProbeCandidates = 3
+
+	// latencyProbeTimeout is the time to wait for a candidate to accept the
+	// probe connection. Candidates which don't are left out.
+	latencyProbeTimeout = 2 * time.Second
+)
+
+// probeLatency returns the time it takes to open a TCP connection to the
+// address.
+func probeLatency(addr *p2p.NetAddress) (time.Duration, error) {
+	start := time.Now()
+	c, err := net.DialTimeout("tcp", addr.DialString(), latencyProbeTimeout)
+	if err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+	_ = c.Close()
+	return latency, nil
+}
+
+// probedAddr is a candidate to dial, with its latency.
+type probedAddr struct {
+	addr    *p2p.NetAddress
+	latency time.Duration
+}
+
+// probeCandidates probes the latency of the candidates concurrently, and
+// returns those which answered, by increasing latency.
+func (r *Reactor) probeCandidates(candidates []*p2p.NetAddress) []probedAddr {
+	var (
+		mtx    sync.Mutex
+		wg     sync.WaitGroup
+		probed = make([]probedAddr, 0, len(candidates))
+	)
+	for _, addr := range candidates {
+		wg.Add(1)
+		go func(addr *p2p.NetAddress) {
+			defer wg.Done()
+			latency, err := r.probeLatency(addr)
+			if err != nil {
+				r.Logger.Debug("Latency probe failed", "addr", addr, "err", err)
+				return
+			}
+			mtx.Lock()
+			probed = append(probed, probedAddr{addr: addr, latency: latency})
+			mtx.Unlock()
+		}(addr)
+	}
+	wg.Wait()
+
+	sort.Slice(probed, func(i, j int) bool { return probed[i].latency < probed[j].latency })
+	return probed
+}
+
+// selectByLatency picks n of the probed candidates, sorted by increasing
+// latency. The lowest-latency candidates make up lowLatencyFraction of them.
+// The others are picked for diversity: the lowest-latency candidates of
+// network groups no connected or picked peer is in, given by groups, and then
+// the lowest-latency candidates left.
+func selectByLatency(
+	probed []probedAddr,
+	n int,
+	lowLatencyFraction float64,
+	groups map[string]bool,
+) []*p2p.NetAddress {
+	if n > len(probed) {
+		n = len(probed)
+	}
+	var (
+		selected = make([]*p2p.NetAddress, 0, n)
+		picked   = make([]bool, len(probed))
+		pick     = func(i int) {
+			picked[i] = true
+			selected = append(selected, probed[i].addr)
+			groups[groupKeyFor(probed[i].addr, false)] = true
+		}
+	)
+
+	lowLatency := int(math.Round(float64(n) * lowLatencyFraction))
+	for i := 0; i < lowLatency; i++ {
+		pick(i)
+	}
+	for i := range probed {
+		if len(selected) == n {
+			return selected
+		}
+		if !picked[i] && !groups[groupKeyFor(probed[i].addr, false)] {
+			pick(i)
+		}
+	}
+	for i := range probed {
+		if len(selected) == n {
+			break
+		}
+		if !picked[i] {
+			pick(i)
+		}
+	}
+	return selected
+}
+
+// selectLatencyAware probes the latency of the candidates, and returns n of
+// them to dial, see selectByLatency.
+func (r *Reactor) selectLatencyAware(candidates []*p2p.NetAddress, n int) []*p2p.NetAddress {
+	groups := make(map[string]bool)
+	for _, peer := range r.Switch.Peers().List() {
+		if addr := peer.SocketAddr(); addr != nil {
+			groups[groupKeyFor(addr, false)] = true
+		}
+	}
+
+	probed := r.probeCandidates(candidates)
+	selected := selectByLatency(probed, n, r.config.LowLatencyPeerFraction, groups)
+	r.Logger.Debug("Selected peers by latency",
+		"candidates", len(candidates), "answered", len(probed), "selected", len(selected))
+	return selected
+}
@@ -0,0 +1,95 @@
+package pex
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestSelectByLatency(t *testing.T) {
+	addr := func(ip string) *p2p.NetAddress {
+		return p2p.NewNetAddressIPPort(net.ParseIP(ip), 26656)
+	}
+	a, b, c, d, e := addr("1.1.0.1"), addr("1.1.0.2"), addr("2.2.0.1"), addr("1.1.0.3"), addr("3.3.0.1")
+	probed := []probedAddr{
+		{a, 10 * time.Millisecond},
+		{b, 20 * time.Millisecond},
+		{c, 30 * time.Millisecond},
+		{d, 40 * time.Millisecond},
+		{e, 50 * time.Millisecond},
+	}
+
+	testCases := map[string]struct {
+		n        int
+		fraction float64
+		selected []*p2p.NetAddress
+	}{
+		// b and d are in the group of a, e in the group of a connected peer
+		"low latency and diverse": {3, 0.34, []*p2p.NetAddress{a, c, b}},
+		"low latency only":        {3, 1, []*p2p.NetAddress{a, b, c}},
+		"diverse only":            {2, 0, []*p2p.NetAddress{a, c}},
+		"all":                     {6, 0.5, []*p2p.NetAddress{a, b, c, d, e}},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			groups := map[string]bool{"3.3.0.0": true}
+			assert.Equal(t, tc.selected, selectByLatency(probed, tc.n, tc.fraction, groups))
+		})
+	}
+}
+
+func TestProbeCandidates(t *testing.T) {
+	r := NewReactor(nil, &ReactorConfig{DialLatencyProbe: true})
+	latencies := map[string]time.Duration{
+		"1.1.0.1": 30 * time.Millisecond,
+		"2.2.0.1": 10 * time.Millisecond,
+		"3.3.0.1": 20 * time.Millisecond,
+	}
+	r.probeLatency = func(addr *p2p.NetAddress) (time.Duration, error) {
+		latency, ok := latencies[addr.IP.String()]
+		if !ok {
+			return 0, errors.New("connection refused")
+		}
+		return latency, nil
+	}
+
+	var candidates []*p2p.NetAddress
+	for _, ip := range []string{"1.1.0.1", "2.2.0.1", "4.4.0.1", "3.3.0.1"} {
+		candidates = append(candidates, p2p.NewNetAddressIPPort(net.ParseIP(ip), 26656))
+	}
+	probed := r.probeCandidates(candidates)
+
+	ips := make([]string, 0, len(probed))
+	for _, p := range probed {
+		ips = append(ips, p.addr.IP.String())
+	}
+	assert.Equal(t, []string{"2.2.0.1", "3.3.0.1", "1.1.0.1"}, ips)
+}
+
+func TestProbeLatency(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+
+	tcpAddr := l.Addr().(*net.TCPAddr)
+	latency, err := probeLatency(p2p.NewNetAddressIPPort(tcpAddr.IP, uint16(tcpAddr.Port)))
+	assert.NoError(t, err)
+	assert.Positive(t, int64(latency))
+}
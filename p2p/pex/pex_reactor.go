@@ -96,6 +96,9 @@ type Reactor struct {
 
 	attemptsToDial sync.Map // address (string) -> {number of attempts (int), last time dialed (time.Time)}
 
+	// probes the latency of candidates to dial, if DialLatencyProbe is set
+	probeLatency func(*p2p.NetAddress) (time.Duration, error)
+
 	// seed/crawled mode fields
 	crawlPeerInfos map[p2p.NodeID]crawlPeerInfo
 }
@@ -122,6 +125,13 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// Probe the latency of several candidates from the addrbook per peer to
+	// dial, and dial the lowest-latency ones for LowLatencyPeerFraction of
+	// the peers. The others are picked from network groups no other peer is
+	// in, to keep the peers diverse.
+	DialLatencyProbe       bool
+	LowLatencyPeerFraction float64
 }
 
 type _attemptsToDial struct {
@@ -138,6 +148,7 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 		requestsSent:         cmap.NewCMap(),
 		lastReceivedRequests: cmap.NewCMap(),
 		crawlPeerInfos:       make(map[p2p.NodeID]crawlPeerInfo),
+		probeLatency:         probeLatency,
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("PEX", r)
 	return r
@@ -450,6 +461,14 @@ func (r *Reactor) ensurePeersRoutine() {
 	}
 }
 
+// RequestPeers tries to connect to more peers right away rather than at the
+// next ensurePeersPeriod, e.g. because fast sync stalled on its peers.
+func (r *Reactor) RequestPeers() {
+	if r.IsRunning() {
+		go r.ensurePeers()
+	}
+}
+
 // ensurePeers ensures that sufficient peers are connected. (once)
 //
 // heuristic that we haven't perfected yet, or, perhaps is manually edited by
@@ -477,11 +496,17 @@ func (r *Reactor) ensurePeers() {
 	// NOTE: range here is [10, 90]. Too high ?
 	newBias := tmmath.MinInt(out, 8)*10 + 10
 
+	// pick more candidates to probe their latency, if enabled
+	numCandidates := numToDial
+	if r.config.DialLatencyProbe {
+		numCandidates *= latencyProbeCandidates
+	}
+
 	toDial := make(map[p2p.NodeID]*p2p.NetAddress)
-	// Try maxAttempts times to pick numToDial addresses to dial
-	maxAttempts := numToDial * 3
+	// Try maxAttempts times to pick numCandidates addresses to dial
+	maxAttempts := numCandidates * 3
 
-	for i := 0; i < maxAttempts && len(toDial) < numToDial; i++ {
+	for i := 0; i < maxAttempts && len(toDial) < numCandidates; i++ {
 		try := r.book.PickAddress(newBias)
 		if try == nil {
 			continue
@@ -495,12 +520,23 @@ func (r *Reactor) ensurePeers() {
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialing again, or have dialed too many times already
-		r.Logger.Info("Will dial address", "addr", try)
 		toDial[try.ID] = try
 	}
 
+	if r.config.DialLatencyProbe && len(toDial) > 0 {
+		candidates := make([]*p2p.NetAddress, 0, len(toDial))
+		for _, addr := range toDial {
+			candidates = append(candidates, addr)
+		}
+		toDial = make(map[p2p.NodeID]*p2p.NetAddress, numToDial)
+		for _, addr := range r.selectLatencyAware(candidates, numToDial) {
+			toDial[addr.ID] = addr
+		}
+	}
+
 	// Dial picked addresses
 	for _, addr := range toDial {
+		r.Logger.Info("Will dial address", "addr", addr)
 		go func(addr *p2p.NetAddress) {
 			err := r.dialPeer(addr)
 			if err != nil {
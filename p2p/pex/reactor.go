@@ -38,6 +38,11 @@ const (
 	// The reactor should still look to add new peers in order to flush out low
 	// scoring peers that are still in the peer store
 	fullCapacityInterval = 10 * time.Minute
+
+	// how far in the future the timestamp of a forwarding record may be. Later
+	// records replace earlier ones, so a record far in the future could not be
+	// replaced for a long time.
+	maxForwardingClockDrift = 10 * time.Minute
 )
 
 // TODO: We should decide whether we want channel descriptors to be housed
@@ -94,6 +99,8 @@ type ReactorV2 struct {
 
 	// the time when another request will be sent
 	nextRequestTime time.Time
+	// requestNowCh triggers a request before nextRequestTime
+	requestNowCh chan struct{}
 
 	// keep track of how many new peers to existing peers we have received to
 	// extrapolate the size of the network
@@ -104,14 +111,22 @@ type ReactorV2 struct {
 	// This is multiplied by the minimum duration to calculate how long to wait
 	// between each request.
 	discoveryRatio float32
+
+	// forwardingRecords are the forwarding records of peers that rotated their
+	// node key, keyed by their old node ID, including our own if we rotated
+	// ours. They are sent to every new peer which supports them.
+	forwardingRecords map[p2p.NodeID]*p2p.ForwardingRecord
+	peerFeatures      *p2p.PeerFeatures
 }
 
-// NewReactor returns a reference to a new reactor.
+// NewReactor returns a reference to a new reactor. If own is not nil, it is
+// the forwarding record of our previous node key, which is published to peers.
 func NewReactorV2(
 	logger log.Logger,
 	peerManager *p2p.PeerManager,
 	pexCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
+	own *p2p.ForwardingRecord,
 ) *ReactorV2 {
 
 	r := &ReactorV2{
@@ -119,9 +134,15 @@ func NewReactorV2(
 		pexCh:                pexCh,
 		peerUpdates:          peerUpdates,
 		closeCh:              make(chan struct{}),
+		requestNowCh:         make(chan struct{}, 1),
 		availablePeers:       make(map[p2p.NodeID]struct{}),
 		requestsSent:         make(map[p2p.NodeID]struct{}),
 		lastReceivedRequests: make(map[p2p.NodeID]time.Time),
+		forwardingRecords:    make(map[p2p.NodeID]*p2p.ForwardingRecord),
+		peerFeatures:         p2p.NewPeerFeatures(),
+	}
+	if own != nil {
+		r.forwardingRecords[own.OldID()] = own
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "PEX", r)
@@ -167,6 +188,9 @@ func (r *ReactorV2) processPexCh() {
 		case <-r.waitUntilNextRequest():
 			r.sendRequestForPeers()
 
+		case <-r.requestNowCh:
+			r.sendRequestForPeers()
+
 		// inbound requests for new peers or responses to requests sent by this
 		// reactor
 		case envelope := <-r.pexCh.In:
@@ -303,6 +327,16 @@ func (r *ReactorV2) handlePexMessage(envelope p2p.Envelope) error {
 			r.totalPeers++
 		}
 
+	case *protop2p.PexForwardingRecord:
+		record, err := p2p.ForwardingRecordFromProto(msg)
+		if err != nil {
+			return fmt.Errorf("invalid forwarding record: %w", err)
+		}
+		if err := record.Validate(); err != nil {
+			return fmt.Errorf("invalid forwarding record: %w", err)
+		}
+		return r.handleForwardingRecord(envelope.From, record)
+
 	default:
 		return fmt.Errorf("received unknown message: %T", msg)
 	}
@@ -310,6 +344,54 @@ func (r *ReactorV2) handlePexMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// handleForwardingRecord handles a verified forwarding record of a peer that
+// rotated its node key. If we know of the peer under its old node ID, the
+// peer manager moves it to the new node ID, and the record is relayed to our
+// peers. Records of unknown peers, and records we have already seen, are
+// ignored.
+func (r *ReactorV2) handleForwardingRecord(from p2p.NodeID, record *p2p.ForwardingRecord) error {
+	oldID, newID := record.OldID(), record.NewID()
+	if record.Timestamp.After(time.Now().Add(maxForwardingClockDrift)) {
+		return nil
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if known, ok := r.forwardingRecords[oldID]; ok && !record.Timestamp.After(known.Timestamp) {
+		return nil
+	}
+	forwarded, err := r.peerManager.Forward(oldID, newID)
+	if err != nil {
+		return fmt.Errorf("failed to forward peer %v to %v: %w", oldID, newID, err)
+	}
+	if !forwarded {
+		return nil
+	}
+	r.forwardingRecords[oldID] = record
+	r.Logger.Info("peer rotated its node key", "old_id", oldID, "new_id", newID, "from", from)
+
+	for _, peerID := range r.peerFeatures.Peers(p2p.FeatureNodeKeyForwarding) {
+		if peerID != from {
+			r.sendForwardingRecord(peerID, record)
+		}
+	}
+	return nil
+}
+
+// sendForwardingRecord sends a forwarding record to a peer.
+func (r *ReactorV2) sendForwardingRecord(peerID p2p.NodeID, record *p2p.ForwardingRecord) {
+	pb, err := record.ToProto()
+	if err != nil {
+		r.Logger.Error("failed to convert forwarding record", "old_id", record.OldID(), "err", err)
+		return
+	}
+	r.pexCh.Out <- p2p.Envelope{
+		To:      peerID,
+		Message: pb,
+	}
+}
+
 // resolve resolves a set of peer addresses into PEX addresses.
 //
 // FIXME: This is necessary because the current PEX protocol only supports
@@ -383,16 +465,24 @@ func (r *ReactorV2) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (er
 }
 
 // processPeerUpdate processes a PeerUpdate. For added peers, PeerStatusUp, we
-// send a request for addresses.
+// send a request for addresses, and send them the forwarding records we know
+// of if they support them.
 func (r *ReactorV2) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	r.Logger.Debug("received PEX peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
 
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
+	r.peerFeatures.Update(peerUpdate)
+
 	switch peerUpdate.Status {
 	case p2p.PeerStatusUp:
 		r.availablePeers[peerUpdate.NodeID] = struct{}{}
+		if peerUpdate.Features.Has(p2p.FeatureNodeKeyForwarding) {
+			for _, record := range r.forwardingRecords {
+				r.sendForwardingRecord(peerUpdate.NodeID, record)
+			}
+		}
 	case p2p.PeerStatusDown:
 		delete(r.availablePeers, peerUpdate.NodeID)
 		delete(r.requestsSent, peerUpdate.NodeID)
@@ -401,6 +491,15 @@ func (r *ReactorV2) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	}
 }
 
+// RequestPeers sends a request for peer addresses right away rather than at
+// the next scheduled time, e.g. because fast sync stalled on its peers.
+func (r *ReactorV2) RequestPeers() {
+	select {
+	case r.requestNowCh <- struct{}{}:
+	default:
+	}
+}
+
 func (r *ReactorV2) waitUntilNextRequest() <-chan time.Time {
 	return time.After(time.Until(r.nextRequestTime))
 }
@@ -90,6 +90,68 @@ func TestReactorSendsRequestsTooOften(t *testing.T) {
 	require.Equal(t, badNode, peerErr.NodeID)
 }
 
+func TestReactorForwardingRecord(t *testing.T) {
+	r := setupSingle(t)
+
+	peerID := newNodeID(t, "b")
+	otherID := newNodeID(t, "c")
+	oldKey, newKey := p2p.GenNodeKey(), p2p.GenNodeKey()
+
+	added, err := r.manager.Add(p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: oldKey.ID})
+	require.NoError(t, err)
+	require.True(t, added)
+
+	record, err := p2p.NewForwardingRecord(oldKey, p2p.NodeInfo{
+		NodeID:     newKey.ID,
+		ListenAddr: "0.0.0.0:0",
+		Moniker:    "new",
+	})
+	require.NoError(t, err)
+	pb, err := record.ToProto()
+	require.NoError(t, err)
+
+	// The peer manager should move the old node ID over to the new one.
+	r.pexInCh <- p2p.Envelope{From: peerID, Message: pb}
+	require.Eventually(t, func() bool {
+		return len(r.manager.Addresses(newKey.ID)) == 1
+	}, shortWait, checkFrequency)
+	require.Equal(t, []p2p.NodeAddress{{Protocol: p2p.MemoryProtocol, NodeID: newKey.ID}},
+		r.manager.Addresses(newKey.ID))
+	require.Empty(t, r.manager.Addresses(oldKey.ID))
+
+	// Peers which support forwarding records should be sent the record,
+	// either when it is relayed or when they come up.
+	r.peerCh <- p2p.PeerUpdate{
+		NodeID:   otherID,
+		Status:   p2p.PeerStatusUp,
+		Features: p2p.FeatureNodeKeyForwarding,
+	}
+	timer := time.NewTimer(shortWait)
+	defer timer.Stop()
+	for received := false; !received; {
+		select {
+		case envelope := <-r.pexOutCh:
+			if msg, ok := envelope.Message.(*proto.PexForwardingRecord); ok {
+				require.Equal(t, otherID, envelope.To)
+				require.Equal(t, pb.Signature, msg.Signature)
+				received = true
+			}
+		case <-timer.C:
+			require.Fail(t, "timed out waiting for forwarding record")
+		}
+	}
+
+	// A record with an invalid signature should error.
+	invalid := *pb
+	invalid.Signature = []byte("invalid")
+	r.pexInCh <- p2p.Envelope{From: peerID, Message: &invalid}
+
+	peerErr := <-r.pexErrCh
+	require.Error(t, peerErr.Err)
+	require.Contains(t, peerErr.Err.Error(), "invalid forwarding record")
+	require.Equal(t, peerID, peerErr.NodeID)
+}
+
 func TestReactorSendsResponseWithoutRequest(t *testing.T) {
 	testNet := setupNetwork(t, testOptions{
 		MockNodes:  1,
@@ -300,7 +362,7 @@ func setupSingle(t *testing.T) *singleTestReactor {
 	peerManager, err := p2p.NewPeerManager(nodeID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
 	require.NoError(t, err)
 
-	reactor := pex.NewReactorV2(log.TestingLogger(), peerManager, pexCh, peerUpdates)
+	reactor := pex.NewReactorV2(log.TestingLogger(), peerManager, pexCh, peerUpdates, nil)
 	require.NoError(t, reactor.Start())
 	t.Cleanup(func() {
 		err := reactor.Stop()
@@ -398,6 +460,7 @@ func setupNetwork(t *testing.T, opts testOptions) *reactorTestSuite {
 				rts.network.Nodes[nodeID].PeerManager,
 				rts.pexChannels[nodeID],
 				rts.peerUpdates[nodeID],
+				nil,
 			)
 		}
 		rts.nodes = append(rts.nodes, nodeID)
@@ -456,6 +519,7 @@ func (r *reactorTestSuite) addNodes(t *testing.T, nodes int) {
 			r.network.Nodes[nodeID].PeerManager,
 			r.pexChannels[nodeID],
 			r.peerUpdates[nodeID],
+			nil,
 		)
 		r.nodes = append(r.nodes, nodeID)
 		r.total++
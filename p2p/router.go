@@ -616,7 +616,7 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 		return
 	}
 
-	r.routePeer(peerInfo.NodeID, conn)
+	r.routePeer(peerInfo.NodeID, peerInfo.Features, conn)
 }
 
 // dialPeers maintains outbound connections to peers by dialing them.
@@ -679,11 +679,17 @@ LOOP:
 }
 
 func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
+	r.metrics.RouterDialsInProgress.Add(1)
+	defer r.metrics.RouterDialsInProgress.Add(-1)
+
+	start := time.Now()
 	conn, err := r.dialPeer(ctx, address)
+	r.metrics.RouterDialDuration.Observe(time.Since(start).Seconds())
 	switch {
 	case errors.Is(err, context.Canceled):
 		return
 	case err != nil:
+		r.metrics.RouterDialAttempts.With("result", attemptResult("dial", err)).Add(1)
 		r.logger.Error("failed to dial peer", "peer", address, "err", err)
 		if err = r.peerManager.DialFailed(address); err != nil {
 			r.logger.Error("failed to report dial failure", "peer", address, "err", err)
@@ -691,12 +697,13 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	}
 
-	_, _, err = r.handshakePeer(ctx, conn, address.NodeID)
+	peerInfo, _, err := r.handshakePeer(ctx, conn, address.NodeID)
 	switch {
 	case errors.Is(err, context.Canceled):
 		conn.Close()
 		return
 	case err != nil:
+		r.metrics.RouterDialAttempts.With("result", attemptResult("handshake", err)).Add(1)
 		r.logger.Error("failed to handshake with peer", "peer", address, "err", err)
 		if err = r.peerManager.DialFailed(address); err != nil {
 			r.logger.Error("failed to report dial failure", "peer", address, "err", err)
@@ -713,8 +720,19 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 
 	}
 
+	r.metrics.RouterDialAttempts.With("result", "ok").Add(1)
 	// routePeer (also) calls connection close
-	go r.routePeer(address.NodeID, conn)
+	go r.routePeer(address.NodeID, peerInfo.Features, conn)
+}
+
+// attemptResult returns the result of a failed dial attempt for the metrics,
+// i.e. the step which failed, suffixed with "_timeout" or "_error".
+func attemptResult(step string, err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return step + "_timeout"
+	}
+	return step + "_error"
 }
 
 func (r *Router) getOrMakeQueue(peerID NodeID) queue {
@@ -789,7 +807,9 @@ func (r *Router) handshakePeer(ctx context.Context, conn Connection, expectID No
 		defer cancel()
 	}
 
+	start := time.Now()
 	peerInfo, peerKey, err := conn.Handshake(ctx, r.nodeInfo, r.privKey)
+	r.metrics.RouterHandshakeDuration.Observe(time.Since(start).Seconds())
 	if err != nil {
 		return peerInfo, peerKey, err
 	}
@@ -817,9 +837,9 @@ func (r *Router) runWithPeerMutex(fn func() error) error {
 // routePeer routes inbound and outbound messages between a peer and the reactor
 // channels. It will close the given connection and send queue when done, or if
 // they are closed elsewhere it will cause this method to shut down and return.
-func (r *Router) routePeer(peerID NodeID, conn Connection) {
+func (r *Router) routePeer(peerID NodeID, features Features, conn Connection) {
 	r.metrics.Peers.Add(1)
-	r.peerManager.Ready(peerID)
+	r.peerManager.Ready(peerID, features)
 
 	sendQueue := r.getOrMakeQueue(peerID)
 	defer func() {
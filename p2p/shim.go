@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/gogo/protobuf/proto"
@@ -39,6 +40,7 @@ type (
 	ChannelShim struct {
 		Descriptor *ChannelDescriptor
 		Channel    *Channel
+		Versions   *MessageVersions
 		inCh       chan<- Envelope
 		outCh      <-chan Envelope
 		errCh      <-chan PeerError
@@ -48,9 +50,13 @@ type (
 	// and the proto.Message the new p2p Channel is responsible for handling.
 	// A ChannelDescriptorShim is not contained in ReactorShim, but is rather
 	// used to construct a ReactorShim.
+	//
+	// Versions are the versions of the channel's messages, if any; messages to
+	// and from peers which speak an older version are translated.
 	ChannelDescriptorShim struct {
 		MsgType    proto.Message
 		Descriptor *ChannelDescriptor
+		Versions   *MessageVersions
 	}
 )
 
@@ -80,6 +86,7 @@ func NewChannelShim(cds *ChannelDescriptorShim, buf uint) *ChannelShim {
 	errCh := make(chan PeerError, buf)
 	return &ChannelShim{
 		Descriptor: cds.Descriptor,
+		Versions:   cds.Versions,
 		Channel: NewChannel(
 			ChannelID(cds.Descriptor.ID),
 			cds.MsgType,
@@ -101,36 +108,20 @@ func (rs *ReactorShim) proxyPeerEnvelopes() {
 	for _, cs := range rs.Channels {
 		go func(cs *ChannelShim) {
 			for e := range cs.outCh {
-				msg := proto.Clone(cs.Channel.messageType)
-				msg.Reset()
-
-				wrapper, ok := msg.(Wrapper)
-				if ok {
-					if err := wrapper.Wrap(e.Message); err != nil {
-						rs.Logger.Error(
-							"failed to proxy envelope; failed to wrap message",
-							"ch_id", cs.Descriptor.ID,
-							"err", err,
-						)
+				switch {
+				case e.Broadcast && cs.Versions.Latest() == 0:
+					bz, err := rs.encodeMessage(cs, e.Message)
+					if err != nil {
+						rs.Logger.Error("failed to proxy envelope", "ch_id", cs.Descriptor.ID, "err", err)
 						continue
 					}
-				} else {
-					msg = e.Message
-				}
-
-				bz, err := proto.Marshal(msg)
-				if err != nil {
-					rs.Logger.Error(
-						"failed to proxy envelope; failed to encode message",
-						"ch_id", cs.Descriptor.ID,
-						"err", err,
-					)
-					continue
-				}
+					rs.Switch.Broadcast(cs.Descriptor.ID, bz)
 
-				switch {
 				case e.Broadcast:
-					rs.Switch.Broadcast(cs.Descriptor.ID, bz)
+					// peers may speak different versions of the messages
+					for _, src := range rs.Switch.peers.List() {
+						go rs.sendToPeer(cs, src, e.Message)
+					}
 
 				case e.To != "":
 					src := rs.Switch.peers.Get(e.To)
@@ -143,16 +134,7 @@ func (rs *ReactorShim) proxyPeerEnvelopes() {
 						continue
 					}
 
-					if !src.Send(cs.Descriptor.ID, bz) {
-						// This usually happens when we try to send across a channel
-						// that the peer doesn't have open. To avoid bloating the
-						// logs we set this to be Debug
-						rs.Logger.Debug(
-							"failed to proxy message to peer",
-							"ch_id", cs.Descriptor.ID,
-							"peer", e.To,
-						)
-					}
+					rs.sendToPeer(cs, src, e.Message)
 
 				default:
 					rs.Logger.Error("failed to proxy envelope; missing peer ID", "ch_id", cs.Descriptor.ID)
@@ -162,6 +144,71 @@ func (rs *ReactorShim) proxyPeerEnvelopes() {
 	}
 }
 
+// sendToPeer sends a message to the peer, translated to the version of the
+// channel's messages the peer speaks.
+func (rs *ReactorShim) sendToPeer(cs *ChannelShim, src Peer, msg proto.Message) {
+	version := NegotiateMessageVersion(ChannelID(cs.Descriptor.ID), cs.Versions.Latest(), src.NodeInfo())
+
+	msgs := []proto.Message{msg}
+	if version < cs.Versions.Latest() {
+		var err error
+		msgs, err = cs.Versions.Downgrade(msg, version)
+		if err != nil {
+			rs.Logger.Error(
+				"failed to proxy envelope",
+				"ch_id", cs.Descriptor.ID,
+				"peer", src.ID(),
+				"err", err,
+			)
+			return
+		}
+	}
+
+	for _, msg := range msgs {
+		bz, err := rs.encodeMessage(cs, msg)
+		if err == nil {
+			bz, err = encodeVersionedMessage(version, bz)
+		}
+		if err != nil {
+			rs.Logger.Error("failed to proxy envelope", "ch_id", cs.Descriptor.ID, "err", err)
+			return
+		}
+
+		if !src.Send(cs.Descriptor.ID, bz) {
+			// This usually happens when we try to send across a channel
+			// that the peer doesn't have open. To avoid bloating the
+			// logs we set this to be Debug
+			rs.Logger.Debug(
+				"failed to proxy message to peer",
+				"ch_id", cs.Descriptor.ID,
+				"peer", src.ID(),
+			)
+		}
+	}
+}
+
+// encodeMessage wraps the message in the channel's message type, if it is a
+// Wrapper, and encodes it.
+func (rs *ReactorShim) encodeMessage(cs *ChannelShim, msg proto.Message) ([]byte, error) {
+	wrapped := proto.Clone(cs.Channel.messageType)
+	wrapped.Reset()
+
+	wrapper, ok := wrapped.(Wrapper)
+	if ok {
+		if err := wrapper.Wrap(msg); err != nil {
+			return nil, fmt.Errorf("failed to wrap message: %w", err)
+		}
+	} else {
+		wrapped = msg
+	}
+
+	bz, err := proto.Marshal(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	return bz, nil
+}
+
 // handlePeerErrors iterates over each p2p Channel and starts a separate go-routine
 // where we listen for peer errors. For each peer error, we find the peer from
 // the legacy p2p Switch and execute a StopPeerForError call with the corresponding
@@ -228,12 +275,32 @@ func (rs *ReactorShim) GetChannels() []*ChannelDescriptor {
 	return descriptors
 }
 
+// ChannelVersions returns the latest versions of the messages of the
+// versioned channels, for the node to advertise in its NodeInfo.
+func (rs *ReactorShim) ChannelVersions() []ChannelVersion {
+	var versions []ChannelVersion
+	for _, cd := range rs.GetChannels() {
+		cs := rs.Channels[ChannelID(cd.ID)]
+		if cs.Versions.Latest() > 0 {
+			versions = append(versions, ChannelVersion{
+				ChannelID: cs.Channel.ID,
+				Version:   cs.Versions.Latest(),
+			})
+		}
+	}
+	return versions
+}
+
 // AddPeer sends a PeerUpdate with status PeerStatusUp on the PeerUpdateCh.
 // The embedding reactor must be sure to listen for messages on this channel to
 // handle adding a peer.
 func (rs *ReactorShim) AddPeer(peer Peer) {
 	select {
-	case rs.PeerUpdates.reactorUpdatesCh <- PeerUpdate{NodeID: peer.ID(), Status: PeerStatusUp}:
+	case rs.PeerUpdates.reactorUpdatesCh <- PeerUpdate{
+		NodeID:   peer.ID(),
+		Status:   PeerStatusUp,
+		Features: peer.NodeInfo().Features,
+	}:
 		rs.Logger.Debug("sent peer update", "reactor", rs.Name, "peer", peer.ID(), "status", PeerStatusUp)
 
 	case <-rs.PeerUpdates.Done():
@@ -289,6 +356,14 @@ func (rs *ReactorShim) Receive(chID byte, src Peer, msgBytes []byte) {
 		return
 	}
 
+	negotiated := NegotiateMessageVersion(cID, channelShim.Versions.Latest(), src.NodeInfo())
+	version, msgBytes, err := decodeVersionedMessage(negotiated, msgBytes)
+	if err != nil {
+		rs.Logger.Error("error decoding message", "peer", src, "ch_id", cID, "err", err)
+		rs.Switch.StopPeerForError(src, err)
+		return
+	}
+
 	msg := proto.Clone(channelShim.Channel.messageType)
 	msg.Reset()
 
@@ -309,8 +384,6 @@ func (rs *ReactorShim) Receive(chID byte, src Peer, msgBytes []byte) {
 
 	wrapper, ok := msg.(Wrapper)
 	if ok {
-		var err error
-
 		msg, err = wrapper.Unwrap()
 		if err != nil {
 			rs.Logger.Error("failed to unwrap message", "peer", src, "ch_id", chID, "err", err)
@@ -318,9 +391,29 @@ func (rs *ReactorShim) Receive(chID byte, src Peer, msgBytes []byte) {
 		}
 	}
 
+	msgs := []proto.Message{msg}
+	if version < channelShim.Versions.Latest() {
+		msgs, err = channelShim.Versions.Upgrade(msg, version)
+		if err != nil {
+			rs.Logger.Error("failed to upgrade message", "peer", src, "ch_id", chID, "err", err)
+			return
+		}
+	}
+
+	for _, msg := range msgs {
+		if !rs.proxyInbound(channelShim, src, msg) {
+			return
+		}
+	}
+}
+
+// proxyInbound sends an inbound message on the p2p Channel. It returns false
+// if the Channel is closed.
+func (rs *ReactorShim) proxyInbound(channelShim *ChannelShim, src Peer, msg proto.Message) bool {
 	select {
 	case channelShim.inCh <- Envelope{From: src.ID(), Message: msg}:
-		rs.Logger.Debug("proxied envelope", "reactor", rs.Name, "ch_id", cID, "peer", src.ID())
+		rs.Logger.Debug("proxied envelope", "reactor", rs.Name, "ch_id", channelShim.Channel.ID, "peer", src.ID())
+		return true
 
 	case <-channelShim.Channel.Done():
 		// NOTE: We explicitly DO NOT close the p2p Channel's inbound go channel.
@@ -331,4 +424,6 @@ func (rs *ReactorShim) Receive(chID byte, src Peer, msgBytes []byte) {
 		// be certain there are NO listeners on the inbound channel when closing or
 		// stopping.
 	}
+
+	return false
 }
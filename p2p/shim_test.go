@@ -11,6 +11,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
 	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 )
 
@@ -50,8 +51,18 @@ type reactorShimTestSuite struct {
 func setup(t *testing.T, peers []p2p.Peer) *reactorShimTestSuite {
 	t.Helper()
 
+	return setupWithShims(t, peers, testChannelShims)
+}
+
+func setupWithShims(
+	t *testing.T,
+	peers []p2p.Peer,
+	chShims map[p2p.ChannelID]*p2p.ChannelDescriptorShim,
+) *reactorShimTestSuite {
+	t.Helper()
+
 	rts := &reactorShimTestSuite{
-		shim: p2p.NewReactorShim(log.TestingLogger(), "TestShim", testChannelShims),
+		shim: p2p.NewReactorShim(log.TestingLogger(), "TestShim", chShims),
 	}
 
 	rts.sw = p2p.MakeSwitch(p2pCfg, 1, "testing", "123.123.123", func(_ int, sw *p2p.Switch) *p2p.Switch {
@@ -83,6 +94,7 @@ func simplePeer(t *testing.T, id string) (*p2pmocks.Peer, p2p.NodeID) {
 	peerID := p2p.NodeID(id)
 	peer := &p2pmocks.Peer{}
 	peer.On("ID").Return(peerID)
+	peer.On("NodeInfo").Return(p2p.NodeInfo{NodeID: peerID, Features: p2p.FeatureCompactBlocks}).Maybe()
 
 	return peer, peerID
 }
@@ -125,6 +137,7 @@ func TestReactorShim_AddPeer(t *testing.T) {
 
 	require.Equal(t, peerIDA, peerUpdate.NodeID)
 	require.Equal(t, p2p.PeerStatusUp, peerUpdate.Status)
+	require.Equal(t, p2p.FeatureCompactBlocks, peerUpdate.Features)
 }
 
 func TestReactorShim_RemovePeer(t *testing.T) {
@@ -204,3 +217,80 @@ func TestReactorShim_Receive(t *testing.T) {
 
 	peerA.AssertExpectations(t)
 }
+
+// formatTranslator translates chunk requests of version 1 to version 0, which
+// numbered snapshot formats from 0 rather than 1.
+type formatTranslator struct{}
+
+func (formatTranslator) Upgrade(msg proto.Message) ([]proto.Message, error) {
+	req := *msg.(*ssproto.ChunkRequest)
+	req.Format++
+	return []proto.Message{&req}, nil
+}
+
+func (formatTranslator) Downgrade(msg proto.Message) ([]proto.Message, error) {
+	req := *msg.(*ssproto.ChunkRequest)
+	req.Format--
+	return []proto.Message{&req}, nil
+}
+
+func TestReactorShim_VersionedChannel(t *testing.T) {
+	chShims := map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		p2p.ChannelID(channelID1): {
+			MsgType:    new(ssproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{ID: channelID1, RecvMessageCapacity: int(4e6)},
+			Versions:   &p2p.MessageVersions{Translators: []p2p.MessageTranslator{formatTranslator{}}},
+		},
+	}
+
+	// peer A speaks version 0, peer B version 1
+	peerA, peerIDA := simplePeer(t, "aa")
+	peerIDB := p2p.NodeID("bb")
+	peerB := &p2pmocks.Peer{}
+	peerB.On("ID").Return(peerIDB)
+	peerB.On("NodeInfo").Return(p2p.NodeInfo{
+		NodeID:          peerIDB,
+		ChannelVersions: []p2p.ChannelVersion{{ChannelID: p2p.ChannelID(channelID1), Version: 1}},
+	})
+
+	rts := setupWithShims(t, []p2p.Peer{peerA, peerB}, chShims)
+	require.Equal(t, []p2p.ChannelVersion{{ChannelID: p2p.ChannelID(channelID1), Version: 1}},
+		rts.shim.ChannelVersions())
+	p2pCh := rts.shim.Channels[p2p.ChannelID(channelID1)]
+
+	encode := func(format uint32) []byte {
+		bz, err := proto.Marshal(&ssproto.Message{
+			Sum: &ssproto.Message_ChunkRequest{ChunkRequest: &ssproto.ChunkRequest{Height: 1, Format: format}},
+		})
+		require.NoError(t, err)
+		return bz
+	}
+	versioned := func(bz []byte) []byte {
+		bz, err := proto.Marshal(&tmp2p.VersionedMessage{Version: 1, Msg: bz})
+		require.NoError(t, err)
+		return bz
+	}
+
+	// messages from both peers are upgraded to version 1
+	go rts.shim.Receive(channelID1, peerA, encode(0))
+	e := <-p2pCh.Channel.In
+	require.Equal(t, peerIDA, e.From)
+	require.Equal(t, &ssproto.ChunkRequest{Height: 1, Format: 1}, e.Message)
+
+	go rts.shim.Receive(channelID1, peerB, versioned(encode(1)))
+	e = <-p2pCh.Channel.In
+	require.Equal(t, peerIDB, e.From)
+	require.Equal(t, &ssproto.ChunkRequest{Height: 1, Format: 1}, e.Message)
+
+	// broadcasts are downgraded for peer A and sent in an envelope to peer B
+	var wg sync.WaitGroup
+	wg.Add(2)
+	peerA.On("Send", channelID1, encode(0)).Run(func(mock.Arguments) { wg.Done() }).Return(true)
+	peerB.On("Send", channelID1, versioned(encode(1))).Run(func(mock.Arguments) { wg.Done() }).Return(true)
+
+	p2pCh.Channel.Out <- p2p.Envelope{Broadcast: true, Message: &ssproto.ChunkRequest{Height: 1, Format: 1}}
+	wg.Wait()
+
+	peerA.AssertExpectations(t)
+	peerB.AssertExpectations(t)
+}
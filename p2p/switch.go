@@ -116,6 +116,9 @@ type Switch struct {
 	connFilters   []ConnFilterFunc
 	conns         ConnSet
 
+	// drop, delay or reorder received messages, see ChaosPolicy
+	chaos *chaosHooks
+
 	metrics *Metrics
 }
 
@@ -156,6 +159,7 @@ func NewSwitch(
 		unconditionalPeerIDs: make(map[NodeID]struct{}),
 		filterTimeout:        defaultFilterTimeout,
 		conns:                NewConnSet(),
+		chaos:                newChaosHooks(),
 	}
 
 	// Ensure PRNG is reseeded.
@@ -745,6 +749,8 @@ func (sw *Switch) acceptRoutine() {
 			sw.reactorsByCh,
 			sw.StopPeerForError,
 			PeerMetrics(sw.metrics),
+			PeerAppChannels(sw.nodeInfo.AppChannels),
+			peerChaosHooks(sw.chaos),
 		)
 
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
@@ -841,6 +847,8 @@ func (sw *Switch) addOutboundPeerWithConfig(
 		sw.reactorsByCh,
 		sw.StopPeerForError,
 		PeerMetrics(sw.metrics),
+		PeerAppChannels(sw.nodeInfo.AppChannels),
+		peerChaosHooks(sw.chaos),
 	)
 
 	if err := sw.addPeer(p); err != nil {
@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/net/netutil"
 
@@ -22,6 +24,7 @@ import (
 const (
 	MConnProtocol Protocol = "mconn"
 	TCPProtocol   Protocol = "tcp"
+	TLSProtocol   Protocol = "tls"
 )
 
 // MConnTransportOptions sets options for MConnTransport.
@@ -34,6 +37,16 @@ type MConnTransportOptions struct {
 	// Router, since it will need to do e.g. rate limiting and such as well.
 	// But it might also make sense to have per-transport limits.
 	MaxAcceptedConnections uint32
+
+	// AuthenticateTimeout is the timeout for the secret connection handshake,
+	// which authenticates the peer, within the connection handshake. 0 means
+	// no timeout.
+	AuthenticateTimeout time.Duration
+
+	// AcceptTLS accepts inbound connections authenticated with TLS, as well
+	// as with the secret connection. Outbound connections use TLS if dialed
+	// with the tls protocol.
+	AcceptTLS bool
 }
 
 // MConnTransport is a Transport implementation using the current multiplexed
@@ -72,9 +85,11 @@ func (m *MConnTransport) String() string {
 	return string(MConnProtocol)
 }
 
-// Protocols implements Transport. We support tcp for backwards-compatibility.
+// Protocols implements Transport. We support tcp for backwards-compatibility,
+// and tls for peers which must be dialed with TLS instead of the secret
+// connection.
 func (m *MConnTransport) Protocols() []Protocol {
-	return []Protocol{MConnProtocol, TCPProtocol}
+	return []Protocol{MConnProtocol, TCPProtocol, TLSProtocol}
 }
 
 // Endpoints implements Transport.
@@ -117,6 +132,17 @@ func (m *MConnTransport) Listen(endpoint Endpoint) error {
 	if err != nil {
 		return err
 	}
+	return m.ListenOn(listener)
+}
+
+// ListenOn makes the transport accept inbound connections from the given
+// listener, e.g. one sharing a port with other protocols, instead of listening
+// itself. Like Listen, it must be called exactly once before calling Accept(),
+// and Close() closes the listener.
+func (m *MConnTransport) ListenOn(listener net.Listener) error {
+	if m.listener != nil {
+		return errors.New("transport is already listening")
+	}
 	if m.options.MaxAcceptedConnections > 0 {
 		// FIXME: This will establish the inbound connection but simply hang it
 		// until another connection is released. It would probably be better to
@@ -146,7 +172,9 @@ func (m *MConnTransport) Accept() (Connection, error) {
 		}
 	}
 
-	return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+	c := m.newConnection(tcpConn)
+	c.acceptTLS = m.options.AcceptTLS
+	return c, nil
 }
 
 // Dial implements Transport.
@@ -170,7 +198,16 @@ func (m *MConnTransport) Dial(ctx context.Context, endpoint Endpoint) (Connectio
 		}
 	}
 
-	return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+	c := m.newConnection(tcpConn)
+	c.dialTLS = endpoint.Protocol == TLSProtocol
+	return c, nil
+}
+
+// newConnection returns an mConnConnection over the TCP connection.
+func (m *MConnTransport) newConnection(tcpConn net.Conn) *mConnConnection {
+	c := newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs)
+	c.authenticateTimeout = m.options.AuthenticateTimeout
+	return c
 }
 
 // Close implements Transport.
@@ -201,7 +238,8 @@ func (m *MConnTransport) validateEndpoint(endpoint Endpoint) error {
 	if err := endpoint.Validate(); err != nil {
 		return err
 	}
-	if endpoint.Protocol != MConnProtocol && endpoint.Protocol != TCPProtocol {
+	if endpoint.Protocol != MConnProtocol && endpoint.Protocol != TCPProtocol &&
+		endpoint.Protocol != TLSProtocol {
 		return fmt.Errorf("unsupported protocol %q", endpoint.Protocol)
 	}
 	if len(endpoint.IP) == 0 {
@@ -224,6 +262,10 @@ type mConnConnection struct {
 	closeCh      chan struct{}
 	closeOnce    sync.Once
 
+	authenticateTimeout time.Duration
+	dialTLS             bool // authenticate with TLS, as the client
+	acceptTLS           bool // authenticate with TLS, as the server, if the peer starts a TLS handshake
+
 	mconn *conn.MConnection // set during Handshake()
 }
 
@@ -309,9 +351,19 @@ func (c *mConnConnection) handshake(
 		return nil, NodeInfo{}, nil, errors.New("connection is already handshaked")
 	}
 
-	secretConn, err := conn.MakeSecretConnection(c.conn, privKey)
+	if c.authenticateTimeout > 0 {
+		if err := c.conn.SetDeadline(time.Now().Add(c.authenticateTimeout)); err != nil {
+			return nil, NodeInfo{}, nil, err
+		}
+	}
+	secretConn, peerKey, err := c.authenticate(privKey)
 	if err != nil {
-		return nil, NodeInfo{}, nil, err
+		return nil, NodeInfo{}, nil, fmt.Errorf("failed to authenticate peer: %w", err)
+	}
+	if c.authenticateTimeout > 0 {
+		if err := c.conn.SetDeadline(time.Time{}); err != nil {
+			return nil, NodeInfo{}, nil, err
+		}
 	}
 
 	var pbPeerInfo p2pproto.NodeInfo
@@ -343,7 +395,49 @@ func (c *mConnConnection) handshake(
 	)
 	mconn.SetLogger(c.logger.With("peer", c.RemoteEndpoint().NodeAddress(peerInfo.NodeID)))
 
-	return mconn, peerInfo, secretConn.RemotePubKey(), nil
+	return mconn, peerInfo, peerKey, nil
+}
+
+// authenticate establishes an encrypted connection with the peer, with the
+// secret connection or TLS, and returns it with the authenticated peer key.
+func (c *mConnConnection) authenticate(privKey crypto.PrivKey) (net.Conn, crypto.PubKey, error) {
+	rawConn := c.conn
+	useTLS := c.dialTLS
+	if c.acceptTLS {
+		// the peer chose the protocol, so peek at its first byte to find out
+		// which one, without consuming it
+		peeked := &peekedConn{Conn: c.conn, reader: bufio.NewReader(c.conn)}
+		firstByte, err := peeked.reader.Peek(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		rawConn = peeked
+		useTLS = conn.IsTLSHandshake(firstByte[0])
+	}
+
+	if useTLS {
+		tlsConn, err := conn.MakeTLSConnection(rawConn, privKey, !c.dialTLS)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tlsConn, tlsConn.RemotePubKey(), nil
+	}
+	secretConn, err := conn.MakeSecretConnection(rawConn, privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secretConn, secretConn.RemotePubKey(), nil
+}
+
+// peekedConn is a net.Conn whose reads go through a buffered reader, which may
+// hold bytes already peeked at.
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
 }
 
 // onReceive is a callback for MConnection received messages.
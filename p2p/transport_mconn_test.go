@@ -1,7 +1,9 @@
 package p2p_test
 
 import (
+	"errors"
 	"io"
+	"io/ioutil"
 	"net"
 	"testing"
 	"time"
@@ -123,6 +125,97 @@ func TestMConnTransport_AcceptMaxAcceptedConnections(t *testing.T) {
 	require.Equal(t, dial3.LocalEndpoint(), accept3.RemoteEndpoint())
 }
 
+func TestMConnTransport_TLS(t *testing.T) {
+	makeTransport := func(options p2p.MConnTransportOptions) *p2p.MConnTransport {
+		transport := p2p.NewMConnTransport(
+			log.TestingLogger(),
+			conn.DefaultMConnConfig(),
+			[]*p2p.ChannelDescriptor{{ID: byte(chID), Priority: 1}},
+			options,
+		)
+		t.Cleanup(func() {
+			_ = transport.Close()
+		})
+		return transport
+	}
+	a := makeTransport(p2p.MConnTransportOptions{})
+	b := makeTransport(p2p.MConnTransportOptions{AcceptTLS: true})
+	require.NoError(t, b.Listen(p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.IPv4(127, 0, 0, 1)}))
+	require.NotEmpty(t, b.Endpoints())
+	endpoint := b.Endpoints()[0]
+
+	// b accepts both TLS and secret connections, as chosen by the dialer
+	for _, protocol := range []p2p.Protocol{p2p.TLSProtocol, p2p.MConnProtocol} {
+		endpoint.Protocol = protocol
+
+		acceptCh := make(chan p2p.Connection, 1)
+		go func() {
+			c, err := b.Accept()
+			if err == nil {
+				acceptCh <- c
+			}
+		}()
+		ab, err := a.Dial(ctx, endpoint)
+		require.NoError(t, err, protocol)
+		ba := <-acceptCh
+
+		errCh := make(chan error, 1)
+		go func() {
+			peerInfo, key, err := ba.Handshake(ctx, peerInfo, peerKey)
+			if err == nil && (peerInfo.NodeID != selfID || !key.Equals(selfKey.PubKey())) {
+				err = errors.New("unexpected peer")
+			}
+			errCh <- err
+		}()
+		info, key, err := ab.Handshake(ctx, selfInfo, selfKey)
+		require.NoError(t, err, protocol)
+		require.Equal(t, peerID, info.NodeID)
+		require.Equal(t, peerKey.PubKey(), key)
+		require.NoError(t, <-errCh, protocol)
+
+		_, err = ab.SendMessage(chID, []byte("foo"))
+		require.NoError(t, err)
+		_, msg, err := ba.ReceiveMessage()
+		require.NoError(t, err)
+		require.Equal(t, []byte("foo"), msg)
+
+		require.NoError(t, ab.Close())
+		require.NoError(t, ba.Close())
+	}
+}
+
+func TestMConnTransport_AuthenticateTimeout(t *testing.T) {
+	// a peer which accepts connections, but never authenticates
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		c, err := listener.Accept()
+		if err == nil {
+			defer c.Close()
+			_, _ = io.Copy(ioutil.Discard, c)
+		}
+	}()
+
+	transport := p2p.NewMConnTransport(
+		log.TestingLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: byte(chID), Priority: 1}},
+		p2p.MConnTransportOptions{AuthenticateTimeout: 100 * time.Millisecond},
+	)
+	addr := listener.Addr().(*net.TCPAddr)
+	c, err := transport.Dial(ctx, p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: addr.IP, Port: uint16(addr.Port)})
+	require.NoError(t, err)
+	defer c.Close()
+
+	start := time.Now()
+	_, _, err = c.Handshake(ctx, selfInfo, selfKey)
+	require.Error(t, err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr) && netErr.Timeout(), "expected a timeout, got %v", err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
 func TestMConnTransport_Listen(t *testing.T) {
 	testcases := []struct {
 		endpoint p2p.Endpoint
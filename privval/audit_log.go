@@ -0,0 +1,298 @@
+package privval
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Types of the signed messages recorded in an AuditLog.
+const (
+	AuditTypePrevote   = "prevote"
+	AuditTypePrecommit = "precommit"
+	AuditTypeProposal  = "proposal"
+)
+
+// auditLogTailSize is the size of the tail of an audit log read to find its
+// last entry. Entries are far smaller.
+const auditLogTailSize = 64 * 1024
+
+// AuditEntry is a signed vote or proposal recorded in an AuditLog. The sign
+// bytes and the signature let anyone holding the validator's public key check
+// what was signed.
+type AuditEntry struct {
+	Index     int64            `json:"index"`
+	Time      time.Time        `json:"time"` // when it was signed
+	Type      string           `json:"type"` // prevote, precommit or proposal
+	ChainID   string           `json:"chain_id"`
+	Height    int64            `json:"height"`
+	Round     int32            `json:"round"`
+	BlockHash tmbytes.HexBytes `json:"block_hash"` // empty for a nil vote
+	Timestamp time.Time        `json:"timestamp"`  // of the vote or proposal
+	SignBytes tmbytes.HexBytes `json:"sign_bytes"`
+	Signature []byte           `json:"signature"`
+	PrevHash  tmbytes.HexBytes `json:"prev_hash"`
+	Hash      tmbytes.HexBytes `json:"hash"`
+}
+
+// hash returns the hash of the entry, which covers all fields but the hash
+// itself, including the hash of the previous entry.
+func (e AuditEntry) hash() ([]byte, error) {
+	e.Hash = nil
+	bz, err := tmjson.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return tmhash.Sum(bz), nil
+}
+
+// AuditLog is an append-only log of the votes and proposals a validator
+// signed, one JSON entry per line. Each entry contains the hash of the
+// previous one, so that removing or altering entries breaks the chain of
+// hashes, see AuditLogVerifier. It is separate from the consensus WAL, which is
+// pruned and only covers the latest heights.
+type AuditLog struct {
+	mtx      tmsync.Mutex
+	file     *os.File
+	next     int64 // index of the next entry
+	lastHash []byte
+}
+
+// OpenAuditLog opens the audit log at the given path, creating it if it
+// doesn't exist. An entry torn by a crash while appending it is truncated.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l := &AuditLog{file: file}
+	if err := l.loadLast(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("audit log %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// loadLast reads the last entry of the log, to continue the chain of hashes.
+func (l *AuditLog) loadLast() error {
+	info, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	offset := size - auditLogTailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, size-offset)
+	if _, err := l.file.ReadAt(tail, offset); err != nil {
+		return err
+	}
+
+	// truncate a torn entry
+	end := bytes.LastIndexByte(tail, '\n') + 1
+	if end < len(tail) {
+		if end == 0 && offset > 0 {
+			return errors.New("last entry is too large")
+		}
+		if err := l.file.Truncate(offset + int64(end)); err != nil {
+			return err
+		}
+		tail = tail[:end]
+	}
+	if _, err := l.file.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if len(tail) == 0 {
+		return nil
+	}
+
+	line := tail[bytes.LastIndexByte(tail[:len(tail)-1], '\n')+1:]
+	var last AuditEntry
+	if err := tmjson.Unmarshal(line, &last); err != nil {
+		return fmt.Errorf("invalid last entry: %w", err)
+	}
+	l.next = last.Index + 1
+	l.lastHash = last.Hash
+	return nil
+}
+
+// Append chains the entry to the log, setting its index and hashes, and
+// writes it to disk.
+func (l *AuditLog) Append(entry AuditEntry) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	entry.Index = l.next
+	entry.PrevHash = l.lastHash
+	hash, err := entry.hash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	bz, err := tmjson.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(bz, '\n')); err != nil {
+		return err
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	l.next++
+	l.lastHash = hash
+	return nil
+}
+
+// Close closes the log.
+func (l *AuditLog) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.file.Close()
+}
+
+// ReadAuditLog reads all entries of the audit log at the given path, calling
+// fn for each one in order. It stops at the first error returned by fn.
+func ReadAuditLog(path string, fn func(AuditEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		bz, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(bz) > 0 {
+				return fmt.Errorf("line %d: torn entry", line)
+			}
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var entry AuditEntry
+		if err := tmjson.Unmarshal(bz, &entry); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// AuditLogVerifier verifies the entries of an audit log in order: that they
+// form an unbroken chain of hashes from the first entry on, and, given the
+// validator's public key, that their signatures are valid.
+type AuditLogVerifier struct {
+	pubKey   crypto.PubKey
+	next     int64
+	lastHash []byte
+}
+
+// NewAuditLogVerifier returns a verifier of an audit log. A nil pubKey skips
+// verifying the signatures.
+func NewAuditLogVerifier(pubKey crypto.PubKey) *AuditLogVerifier {
+	return &AuditLogVerifier{pubKey: pubKey}
+}
+
+// Verify verifies the next entry of the log.
+func (v *AuditLogVerifier) Verify(entry AuditEntry) error {
+	if entry.Index != v.next {
+		return fmt.Errorf("entry %d: expected index %d", entry.Index, v.next)
+	}
+	if !bytes.Equal(entry.PrevHash, v.lastHash) {
+		return fmt.Errorf("entry %d: previous hash %v doesn't match the hash %X of the previous entry",
+			entry.Index, entry.PrevHash, v.lastHash)
+	}
+	hash, err := entry.hash()
+	if err != nil {
+		return fmt.Errorf("entry %d: %w", entry.Index, err)
+	}
+	if !bytes.Equal(entry.Hash, hash) {
+		return fmt.Errorf("entry %d: hash %v doesn't match its content (%X)", entry.Index, entry.Hash, hash)
+	}
+	if v.pubKey != nil && !v.pubKey.VerifySignature(entry.SignBytes, entry.Signature) {
+		return fmt.Errorf("entry %d: invalid signature", entry.Index)
+	}
+	v.next++
+	v.lastHash = hash
+	return nil
+}
+
+// SignAuditor wraps a PrivValidator, recording every vote and proposal it
+// signs in an AuditLog. A signature which can't be recorded isn't returned, so
+// that the node never sends a vote or proposal missing from the log.
+type SignAuditor struct {
+	types.PrivValidator
+
+	log *AuditLog
+}
+
+var _ types.PrivValidator = (*SignAuditor)(nil)
+
+// NewSignAuditor returns a SignAuditor recording the signatures of pv in log.
+func NewSignAuditor(pv types.PrivValidator, log *AuditLog) *SignAuditor {
+	return &SignAuditor{PrivValidator: pv, log: log}
+}
+
+// SignVote signs the vote, and records it in the audit log.
+func (sa *SignAuditor) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	if err := sa.PrivValidator.SignVote(ctx, chainID, vote); err != nil {
+		return err
+	}
+	voteType := AuditTypePrevote
+	if vote.Type == tmproto.PrecommitType {
+		voteType = AuditTypePrecommit
+	}
+	return sa.record(AuditEntry{
+		Type:      voteType,
+		ChainID:   chainID,
+		Height:    vote.Height,
+		Round:     vote.Round,
+		BlockHash: vote.BlockID.Hash,
+		Timestamp: vote.Timestamp,
+		SignBytes: types.VoteSignBytes(chainID, vote),
+		Signature: vote.Signature,
+	})
+}
+
+// SignProposal signs the proposal, and records it in the audit log.
+func (sa *SignAuditor) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	if err := sa.PrivValidator.SignProposal(ctx, chainID, proposal); err != nil {
+		return err
+	}
+	return sa.record(AuditEntry{
+		Type:      AuditTypeProposal,
+		ChainID:   chainID,
+		Height:    proposal.Height,
+		Round:     proposal.Round,
+		BlockHash: proposal.BlockID.Hash,
+		Timestamp: proposal.Timestamp,
+		SignBytes: types.ProposalSignBytes(chainID, proposal),
+		Signature: proposal.Signature,
+	})
+}
+
+func (sa *SignAuditor) record(entry AuditEntry) error {
+	entry.Time = time.Now().UTC()
+	if err := sa.log.Append(entry); err != nil {
+		return fmt.Errorf("can't record the signature in the audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package privval
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func readAuditEntries(t *testing.T, path string) []AuditEntry {
+	var entries []AuditEntry
+	require.NoError(t, ReadAuditLog(path, func(e AuditEntry) error {
+		entries = append(entries, e)
+		return nil
+	}))
+	return entries
+}
+
+func verifyAuditEntries(entries []AuditEntry) error {
+	v := NewAuditLogVerifier(nil)
+	for _, e := range entries {
+		if err := v.Verify(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAuditLogReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	log, err := OpenAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, log.Append(AuditEntry{Type: AuditTypePrevote, Height: 1}))
+	require.NoError(t, log.Append(AuditEntry{Type: AuditTypePrecommit, Height: 1}))
+	require.NoError(t, log.Close())
+
+	// a torn entry is truncated on reopening
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"index":"2","type":"prev`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Error(t, ReadAuditLog(path, func(AuditEntry) error { return nil }))
+
+	log, err = OpenAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, log.Append(AuditEntry{Type: AuditTypePrevote, Height: 2}))
+	require.NoError(t, log.Close())
+
+	entries := readAuditEntries(t, path)
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		assert.EqualValues(t, i, e.Index)
+	}
+	assert.EqualValues(t, 2, entries[2].Height)
+	assert.NoError(t, verifyAuditEntries(entries))
+}
+
+func TestAuditLogVerifierTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log, err := OpenAuditLog(path)
+	require.NoError(t, err)
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, log.Append(AuditEntry{Type: AuditTypePrecommit, Height: h}))
+	}
+	require.NoError(t, log.Close())
+	entries := readAuditEntries(t, path)
+	require.NoError(t, verifyAuditEntries(entries))
+
+	testCases := map[string]func([]AuditEntry) []AuditEntry{
+		"altered": func(entries []AuditEntry) []AuditEntry {
+			entries[1].Height = 7
+			return entries
+		},
+		"altered and rehashed": func(entries []AuditEntry) []AuditEntry {
+			entries[1].Height = 7
+			entries[1].Hash, _ = entries[1].hash()
+			return entries
+		},
+		"removed": func(entries []AuditEntry) []AuditEntry {
+			return append(entries[:1], entries[2:]...)
+		},
+		"reindexed after removal": func(entries []AuditEntry) []AuditEntry {
+			entries = append(entries[:1], entries[2:]...)
+			entries[1].Index = 1
+			return entries
+		},
+	}
+	for name, tamper := range testCases {
+		tamper := tamper
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, verifyAuditEntries(tamper(append([]AuditEntry(nil), entries...))))
+		})
+	}
+}
+
+func TestSignAuditor(t *testing.T) {
+	ctx := context.Background()
+	const chainID = "audit-chain"
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log, err := OpenAuditLog(path)
+	require.NoError(t, err)
+
+	pv := types.NewMockPV()
+	auditor := NewSignAuditor(pv, log)
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block"))}
+
+	proposal := types.NewProposal(1, 0, -1, blockID).ToProto()
+	require.NoError(t, auditor.SignProposal(ctx, chainID, proposal))
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1, BlockID: blockID.ToProto()}
+	require.NoError(t, auditor.SignVote(ctx, chainID, vote))
+	require.NoError(t, log.Close())
+
+	entries := readAuditEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, AuditTypeProposal, entries[0].Type)
+	assert.Equal(t, proposal.Signature, entries[0].Signature)
+	assert.Equal(t, AuditTypePrecommit, entries[1].Type)
+	assert.Equal(t, vote.Signature, entries[1].Signature)
+	assert.EqualValues(t, blockID.Hash, entries[1].BlockHash)
+
+	pubKey, err := pv.GetPubKey(ctx)
+	require.NoError(t, err)
+	verifier := NewAuditLogVerifier(pubKey)
+	for _, e := range entries {
+		assert.NoError(t, verifier.Verify(e))
+	}
+
+	// a signature by another key doesn't verify
+	entries[0].Signature, err = types.NewMockPV().PrivKey.Sign(entries[0].SignBytes)
+	require.NoError(t, err)
+	entries[0].Hash, err = entries[0].hash()
+	require.NoError(t, err)
+	assert.Error(t, NewAuditLogVerifier(pubKey).Verify(entries[0]))
+}
@@ -25,5 +25,11 @@ SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
 
+SignGuard
+
+SignGuard wraps the PrivValidator of a remote signer, refusing to sign for heights
+outside a window around the height trusted by the signer, e.g. with a light client,
+or above a given rate. It limits what an attacker who compromised the node can sign.
+
 */
 package privval
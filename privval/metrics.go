@@ -0,0 +1,67 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Histogram of the time taken by the remote signer to answer a request,
+	// in seconds, labelled by request.
+	RequestLatency metrics.Histogram
+	// Number of failed requests to the remote signer, labelled by request
+	// and error type.
+	RequestErrors metrics.Counter
+	// Number of votes and proposals the remote signer took longer than the
+	// max sign latency to sign, labelled by request.
+	SlowSigns metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RequestLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "request_latency",
+			Help:      "Time taken by the remote signer to answer a request, in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, append(labels, "request")).With(labelsAndValues...),
+		RequestErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "request_errors",
+			Help:      "Number of failed requests to the remote signer.",
+		}, append(labels, "request", "type")).With(labelsAndValues...),
+		SlowSigns: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "slow_signs",
+			Help:      "Number of signatures which took longer than the max sign latency.",
+		}, append(labels, "request")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RequestLatency: discard.NewHistogram(),
+		RequestErrors:  discard.NewCounter(),
+		SlowSigns:      discard.NewCounter(),
+	}
+}
@@ -0,0 +1,147 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrSignRateExceeded is returned by SignGuard when the signatures exceed the
+// rate limit.
+var ErrSignRateExceeded = errors.New("sign rate limit exceeded")
+
+// HeightWindowError is returned by SignGuard when asked to sign for a height
+// outside the window around the trusted height.
+type HeightWindowError struct {
+	Height        int64
+	TrustedHeight int64
+}
+
+func (e HeightWindowError) Error() string {
+	return fmt.Sprintf("height %d is outside the signing window around the trusted height %d",
+		e.Height, e.TrustedHeight)
+}
+
+// TrustedHeightProvider provides the latest height of the chain the signer
+// trusts, independently of the node asking for signatures. A light client
+// following the chain, i.e. a *light.Client which is regularly updated,
+// implements it.
+type TrustedHeightProvider interface {
+	// LastTrustedHeight returns the latest trusted height, or -1 if there is
+	// none.
+	LastTrustedHeight() (int64, error)
+}
+
+// SignGuard wraps a PrivValidator, refusing to sign votes and proposals which
+// an honest node would not ask for. It is meant to run in the remote signer,
+// in front of the key, so that an attacker who compromised the node can't
+// obtain signatures for far-future heights, or sign at a high rate.
+//
+// Without options, SignGuard signs everything the wrapped PrivValidator
+// signs.
+type SignGuard struct {
+	types.PrivValidator
+
+	heights   TrustedHeightProvider
+	maxAhead  int64
+	maxBehind int64
+
+	maxSigns     int
+	signInterval time.Duration
+
+	mtx   tmsync.Mutex
+	signs []time.Time // times of the signatures in the last signInterval
+}
+
+var _ types.PrivValidator = (*SignGuard)(nil)
+
+// SignGuardOption sets an optional parameter on the SignGuard.
+type SignGuardOption func(*SignGuard)
+
+// SignGuardWithHeightWindow only allows signing for heights between
+// maxBehind below and maxAhead above the height trusted by the provider.
+// Signing is refused if the provider has no trusted height.
+func SignGuardWithHeightWindow(heights TrustedHeightProvider, maxAhead, maxBehind int64) SignGuardOption {
+	return func(sg *SignGuard) {
+		sg.heights = heights
+		sg.maxAhead = maxAhead
+		sg.maxBehind = maxBehind
+	}
+}
+
+// SignGuardWithRateLimit allows at most maxSigns signatures, of votes and
+// proposals, in any interval.
+func SignGuardWithRateLimit(maxSigns int, interval time.Duration) SignGuardOption {
+	return func(sg *SignGuard) {
+		sg.maxSigns = maxSigns
+		sg.signInterval = interval
+	}
+}
+
+// NewSignGuard returns a SignGuard wrapping the PrivValidator.
+func NewSignGuard(privVal types.PrivValidator, options ...SignGuardOption) *SignGuard {
+	sg := &SignGuard{PrivValidator: privVal}
+	for _, option := range options {
+		option(sg)
+	}
+	return sg
+}
+
+// SignVote signs the vote with the wrapped PrivValidator, if the guard allows
+// it.
+func (sg *SignGuard) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	if err := sg.allow(vote.Height); err != nil {
+		return fmt.Errorf("refusing to sign vote: %w", err)
+	}
+	return sg.PrivValidator.SignVote(ctx, chainID, vote)
+}
+
+// SignProposal signs the proposal with the wrapped PrivValidator, if the guard
+// allows it.
+func (sg *SignGuard) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	if err := sg.allow(proposal.Height); err != nil {
+		return fmt.Errorf("refusing to sign proposal: %w", err)
+	}
+	return sg.PrivValidator.SignProposal(ctx, chainID, proposal)
+}
+
+// allow checks the height against the window, then counts the signature
+// against the rate limit.
+func (sg *SignGuard) allow(height int64) error {
+	if sg.heights != nil {
+		trusted, err := sg.heights.LastTrustedHeight()
+		if err != nil {
+			return fmt.Errorf("can't get the trusted height: %w", err)
+		}
+		if trusted < 0 {
+			return errors.New("no trusted height yet")
+		}
+		if height > trusted+sg.maxAhead || height < trusted-sg.maxBehind {
+			return HeightWindowError{Height: height, TrustedHeight: trusted}
+		}
+	}
+
+	if sg.maxSigns <= 0 {
+		return nil
+	}
+
+	sg.mtx.Lock()
+	defer sg.mtx.Unlock()
+
+	now := time.Now()
+	i := 0
+	for i < len(sg.signs) && now.Sub(sg.signs[i]) >= sg.signInterval {
+		i++
+	}
+	sg.signs = sg.signs[i:]
+	if len(sg.signs) >= sg.maxSigns {
+		return ErrSignRateExceeded
+	}
+	sg.signs = append(sg.signs, now)
+	return nil
+}
@@ -0,0 +1,96 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/light"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// a light client following the chain provides the trusted height
+var _ TrustedHeightProvider = (*light.Client)(nil)
+
+type fixedHeightProvider struct {
+	height int64
+	err    error
+}
+
+func (p *fixedHeightProvider) LastTrustedHeight() (int64, error) { return p.height, p.err }
+
+func TestSignGuardHeightWindow(t *testing.T) {
+	heights := &fixedHeightProvider{height: 10}
+	sg := NewSignGuard(types.NewMockPV(), SignGuardWithHeightWindow(heights, 2, 1))
+
+	testCases := []struct {
+		height  int64
+		allowed bool
+	}{
+		{8, false},
+		{9, true},
+		{10, true},
+		{12, true},
+		{13, false},
+		{1000000, false},
+	}
+	for _, tc := range testCases {
+		vote := &tmproto.Vote{Type: tmproto.PrevoteType, Height: tc.height, Timestamp: time.Now()}
+		err := sg.SignVote(context.Background(), "test-chain", vote)
+		proposal := &tmproto.Proposal{Type: tmproto.ProposalType, Height: tc.height, Timestamp: time.Now()}
+		proposalErr := sg.SignProposal(context.Background(), "test-chain", proposal)
+		if tc.allowed {
+			assert.NoError(t, err, tc.height)
+			assert.NotEmpty(t, vote.Signature)
+			assert.NoError(t, proposalErr, tc.height)
+		} else {
+			assert.ErrorAs(t, err, &HeightWindowError{}, tc.height)
+			assert.Empty(t, vote.Signature)
+			assert.ErrorAs(t, proposalErr, &HeightWindowError{}, tc.height)
+		}
+	}
+
+	// the window moves with the trusted height
+	heights.height = 1000000
+	vote := &tmproto.Vote{Type: tmproto.PrevoteType, Height: 1000001, Timestamp: time.Now()}
+	assert.NoError(t, sg.SignVote(context.Background(), "test-chain", vote))
+
+	// without a trusted height, signing is refused
+	heights.height = -1
+	assert.Error(t, sg.SignVote(context.Background(), "test-chain", vote))
+	heights.height, heights.err = 10, errors.New("light client failure")
+	assert.Error(t, sg.SignVote(context.Background(), "test-chain", vote))
+}
+
+func TestSignGuardRateLimit(t *testing.T) {
+	const interval = 100 * time.Millisecond
+	sg := NewSignGuard(types.NewMockPV(), SignGuardWithRateLimit(2, interval))
+
+	sign := func() error {
+		vote := &tmproto.Vote{Type: tmproto.PrevoteType, Height: 1, Timestamp: time.Now()}
+		return sg.SignVote(context.Background(), "test-chain", vote)
+	}
+	require.NoError(t, sign())
+	require.NoError(t, sign())
+	assert.ErrorIs(t, sign(), ErrSignRateExceeded)
+
+	time.Sleep(interval)
+	assert.NoError(t, sign())
+}
+
+func TestSignGuardPassThrough(t *testing.T) {
+	pv := types.NewMockPV()
+	sg := NewSignGuard(pv)
+
+	pubKey, err := sg.GetPubKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, pv.PrivKey.PubKey(), pubKey)
+
+	vote := &tmproto.Vote{Type: tmproto.PrevoteType, Height: 1000000, Timestamp: time.Now()}
+	assert.NoError(t, sg.SignVote(context.Background(), "test-chain", vote))
+}
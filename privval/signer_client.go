@@ -2,6 +2,7 @@ package privval
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,20 +18,54 @@ import (
 type SignerClient struct {
 	endpoint *SignerListenerEndpoint
 	chainID  string
+
+	metrics        *Metrics
+	maxSignLatency time.Duration
 }
 
 var _ types.PrivValidator = (*SignerClient)(nil)
 
+// Requests to the remote signer, as labelled in the metrics.
+const (
+	requestPing         = "ping"
+	requestPubKey       = "pub_key"
+	requestSignVote     = "sign_vote"
+	requestSignProposal = "sign_proposal"
+)
+
+// SignerClientOption sets an optional parameter on the SignerClient.
+type SignerClientOption func(*SignerClient)
+
+// SignerClientWithMetrics sets the metrics.
+func SignerClientWithMetrics(metrics *Metrics) SignerClientOption {
+	return func(sc *SignerClient) { sc.metrics = metrics }
+}
+
+// SignerClientWithMaxSignLatency sets the max time the remote signer may take
+// to sign a vote or a proposal. Slower signatures are logged as errors and
+// counted in the SlowSigns metric. 0 disables the check.
+func SignerClientWithMaxSignLatency(maxSignLatency time.Duration) SignerClientOption {
+	return func(sc *SignerClient) { sc.maxSignLatency = maxSignLatency }
+}
+
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
-func NewSignerClient(endpoint *SignerListenerEndpoint, chainID string) (*SignerClient, error) {
+func NewSignerClient(
+	endpoint *SignerListenerEndpoint,
+	chainID string,
+	options ...SignerClientOption,
+) (*SignerClient, error) {
 	if !endpoint.IsRunning() {
 		if err := endpoint.Start(); err != nil {
 			return nil, fmt.Errorf("failed to start listener endpoint: %w", err)
 		}
 	}
 
-	return &SignerClient{endpoint: endpoint, chainID: chainID}, nil
+	sc := &SignerClient{endpoint: endpoint, chainID: chainID, metrics: NopMetrics()}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc, nil
 }
 
 // Close closes the underlying connection
@@ -53,23 +88,31 @@ func (sc *SignerClient) WaitForConnection(maxWait time.Duration) error {
 
 // Ping sends a ping request to the remote signer
 func (sc *SignerClient) Ping() error {
+	start := time.Now()
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.PingRequest{}))
 	if err != nil {
+		sc.observe(requestPing, time.Since(start), err)
 		sc.endpoint.Logger.Error("SignerClient::Ping", "err", err)
 		return nil
 	}
 
 	pb := response.GetPingResponse()
 	if pb == nil {
+		sc.observe(requestPing, time.Since(start), ErrUnexpectedResponse)
 		return err
 	}
 
+	sc.observe(requestPing, time.Since(start), nil)
 	return nil
 }
 
 // GetPubKey retrieves a public key from a remote signer
 // returns an error if client is not able to provide the key
-func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+func (sc *SignerClient) GetPubKey(ctx context.Context) (_ crypto.PubKey, err error) {
+	defer func(start time.Time) {
+		sc.observe(requestPubKey, time.Since(start), err)
+	}(time.Now())
+
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.PubKeyRequest{ChainId: sc.chainID}))
 	if err != nil {
 		return nil, fmt.Errorf("send: %w", err)
@@ -92,7 +135,12 @@ func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 }
 
 // SignVote requests a remote signer to sign a vote
-func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) (err error) {
+	defer func(start time.Time) {
+		sc.observe(requestSignVote, time.Since(start), err,
+			"height", vote.Height, "round", vote.Round, "type", vote.Type)
+	}(time.Now())
+
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
 	if err != nil {
 		return err
@@ -112,7 +160,12 @@ func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmpr
 }
 
 // SignProposal requests a remote signer to sign a proposal
-func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) (err error) {
+	defer func(start time.Time) {
+		sc.observe(requestSignProposal, time.Since(start), err,
+			"height", proposal.Height, "round", proposal.Round)
+	}(time.Now())
+
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(
 		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
 	))
@@ -132,3 +185,40 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// observe records the latency and the error, if any, of a request to the
+// remote signer. A vote or a proposal signed slower than the max sign latency
+// is logged as an error: the consensus waits for the signature, so a slow
+// signer can make the validator miss blocks.
+func (sc *SignerClient) observe(request string, latency time.Duration, err error, keyvals ...interface{}) {
+	sc.metrics.RequestLatency.With("request", request).Observe(latency.Seconds())
+	if err != nil {
+		sc.metrics.RequestErrors.With("request", request, "type", requestErrorType(err)).Add(1)
+	}
+
+	if request != requestSignVote && request != requestSignProposal {
+		return
+	}
+	if sc.maxSignLatency > 0 && latency > sc.maxSignLatency {
+		sc.metrics.SlowSigns.With("request", request).Add(1)
+		sc.endpoint.Logger.Error("Remote signer exceeded the max sign latency",
+			append([]interface{}{"request", request, "latency", latency, "max", sc.maxSignLatency}, keyvals...)...)
+	}
+}
+
+// requestErrorType returns the type of the error, as labelled in the metrics.
+func requestErrorType(err error) string {
+	var remoteErr *RemoteSignerError
+	switch {
+	case errors.As(err, &remoteErr):
+		return "remote_signer"
+	case errors.Is(err, ErrUnexpectedResponse):
+		return "unexpected_response"
+	case errors.Is(err, ErrNoConnection):
+		return "no_connection"
+	case errors.Is(err, ErrConnectionTimeout), errors.Is(err, ErrReadTimeout), errors.Is(err, ErrWriteTimeout):
+		return "timeout"
+	default:
+		return "connection"
+	}
+}
@@ -2,10 +2,13 @@ package privval
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -438,3 +441,92 @@ func TestSignerUnexpectedResponse(t *testing.T) {
 		assert.EqualError(t, e, "empty response")
 	}
 }
+
+// labelledCounter counts the additions by label values.
+type labelledCounter struct {
+	counts map[string]float64
+	lvs    string
+}
+
+func (c *labelledCounter) With(labelValues ...string) metrics.Counter {
+	return &labelledCounter{counts: c.counts, lvs: strings.Join(labelValues, ",")}
+}
+
+func (c *labelledCounter) Add(delta float64) { c.counts[c.lvs] += delta }
+
+// labelledHistogram counts the observations by label values.
+type labelledHistogram struct {
+	labelledCounter
+}
+
+func (h *labelledHistogram) With(labelValues ...string) metrics.Histogram {
+	return &labelledHistogram{labelledCounter{counts: h.counts, lvs: strings.Join(labelValues, ",")}}
+}
+
+func (h *labelledHistogram) Observe(float64) { h.counts[h.lvs]++ }
+
+func TestSignerClientMetrics(t *testing.T) {
+	const signDelay = 50 * time.Millisecond
+
+	for _, dtc := range getDialerTestCases(t) {
+		chainID := tmrand.Str(12)
+		latencies := &labelledHistogram{labelledCounter{counts: make(map[string]float64)}}
+		errs := &labelledCounter{counts: make(map[string]float64)}
+		slowSigns := &labelledCounter{counts: make(map[string]float64)}
+		m := NopMetrics()
+		m.RequestLatency, m.RequestErrors, m.SlowSigns = latencies, errs, slowSigns
+
+		sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+		sc, err := NewSignerClient(sl, chainID,
+			SignerClientWithMetrics(m), SignerClientWithMaxSignLatency(signDelay/2))
+		require.NoError(t, err)
+		ss := NewSignerServer(sd, chainID, types.NewMockPV())
+		// a slow signer, which fails the proposals
+		ss.SetRequestHandler(func(ctx context.Context, privVal types.PrivValidator, request privvalproto.Message,
+			chainID string) (privvalproto.Message, error) {
+			switch request.Sum.(type) {
+			case *privvalproto.Message_SignVoteRequest:
+				time.Sleep(signDelay)
+			case *privvalproto.Message_SignProposalRequest:
+				return mustWrapMsg(&privvalproto.PingResponse{}), nil
+			}
+			return DefaultValidationRequestHandler(ctx, privVal, request, chainID)
+		})
+		require.NoError(t, ss.Start())
+		t.Cleanup(func() {
+			if err := ss.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := sc.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		_, err = sc.GetPubKey(context.Background())
+		require.NoError(t, err)
+		vote := &types.Vote{Type: tmproto.PrecommitType, Height: 1, Timestamp: time.Now()}
+		require.NoError(t, sc.SignVote(context.Background(), chainID, vote.ToProto()))
+		proposal := &types.Proposal{Type: tmproto.ProposalType, Height: 1, Timestamp: time.Now()}
+		require.Equal(t, ErrUnexpectedResponse, sc.SignProposal(context.Background(), chainID, proposal.ToProto()))
+
+		assert.Equal(t, map[string]float64{"request,pub_key": 1, "request,sign_vote": 1, "request,sign_proposal": 1},
+			latencies.counts)
+		assert.Equal(t, map[string]float64{"request,sign_proposal,type,unexpected_response": 1}, errs.counts)
+		assert.Equal(t, map[string]float64{"request,sign_vote": 1}, slowSigns.counts)
+	}
+}
+
+func TestRequestErrorType(t *testing.T) {
+	testCases := map[string]error{
+		"remote_signer":       &RemoteSignerError{Code: 1, Description: "failed"},
+		"unexpected_response": ErrUnexpectedResponse,
+		"no_connection":       fmt.Errorf("send: %w", ErrNoConnection),
+		"timeout":             ErrReadTimeout,
+		"connection":          errors.New("connection reset by peer"),
+	}
+	for expected, err := range testCases {
+		assert.Equal(t, expected, requestErrorType(err), err.Error())
+	}
+}
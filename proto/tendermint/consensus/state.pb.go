@@ -0,0 +1,3120 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/consensus/state.proto
+
+package consensus
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	bits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// StateDump is the consensus state returned by /dump_consensus_state_v2.
+//
+// Fields are only ever added to this schema. Removing or changing the meaning
+// of a field requires bumping version.
+type StateDump struct {
+	// Version of the schema, currently 2.
+	Version    uint32          `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	RoundState RoundStateDump  `protobuf:"bytes,2,opt,name=round_state,json=roundState,proto3" json:"round_state"`
+	Peers      []PeerStateDump `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers"`
+}
+
+func (m *StateDump) Reset()         { *m = StateDump{} }
+func (m *StateDump) String() string { return proto.CompactTextString(m) }
+func (*StateDump) ProtoMessage()    {}
+func (*StateDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{0}
+}
+func (m *StateDump) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateDump.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateDump.Merge(m, src)
+}
+func (m *StateDump) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateDump proto.InternalMessageInfo
+
+func (m *StateDump) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *StateDump) GetRoundState() RoundStateDump {
+	if m != nil {
+		return m.RoundState
+	}
+	return RoundStateDump{}
+}
+
+func (m *StateDump) GetPeers() []PeerStateDump {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// RoundStateDump is the round state of the node itself.
+type RoundStateDump struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// Step of the round: 1 NewHeight, 2 NewRound, 3 Propose, 4 Prevote,
+	// 5 PrevoteWait, 6 Precommit, 7 PrecommitWait, 8 Commit.
+	Step            uint32         `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	StartTime       time.Time      `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	CommitTime      time.Time      `protobuf:"bytes,5,opt,name=commit_time,json=commitTime,proto3,stdtime" json:"commit_time"`
+	ProposerAddress []byte         `protobuf:"bytes,6,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	Proposal        ProposalStatus `protobuf:"bytes,7,opt,name=proposal,proto3" json:"proposal"`
+	// Block the node is locked on.
+	Locked LockInfo `protobuf:"bytes,8,opt,name=locked,proto3" json:"locked"`
+	// Last known block with a POL.
+	Valid LockInfo     `protobuf:"bytes,9,opt,name=valid,proto3" json:"valid"`
+	Votes []RoundVotes `protobuf:"bytes,10,rep,name=votes,proto3" json:"votes"`
+	// Round the block was committed in, -1 if none yet.
+	CommitRound int32 `protobuf:"varint,11,opt,name=commit_round,json=commitRound,proto3" json:"commit_round,omitempty"`
+	// Precommits for the previous height.
+	LastCommit                *bits.BitArray `protobuf:"bytes,12,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	TriggeredTimeoutPrecommit bool           `protobuf:"varint,13,opt,name=triggered_timeout_precommit,json=triggeredTimeoutPrecommit,proto3" json:"triggered_timeout_precommit,omitempty"`
+}
+
+func (m *RoundStateDump) Reset()         { *m = RoundStateDump{} }
+func (m *RoundStateDump) String() string { return proto.CompactTextString(m) }
+func (*RoundStateDump) ProtoMessage()    {}
+func (*RoundStateDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{1}
+}
+func (m *RoundStateDump) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundStateDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundStateDump.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundStateDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundStateDump.Merge(m, src)
+}
+func (m *RoundStateDump) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundStateDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundStateDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundStateDump proto.InternalMessageInfo
+
+func (m *RoundStateDump) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *RoundStateDump) GetCommitTime() time.Time {
+	if m != nil {
+		return m.CommitTime
+	}
+	return time.Time{}
+}
+
+func (m *RoundStateDump) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetProposal() ProposalStatus {
+	if m != nil {
+		return m.Proposal
+	}
+	return ProposalStatus{}
+}
+
+func (m *RoundStateDump) GetLocked() LockInfo {
+	if m != nil {
+		return m.Locked
+	}
+	return LockInfo{}
+}
+
+func (m *RoundStateDump) GetValid() LockInfo {
+	if m != nil {
+		return m.Valid
+	}
+	return LockInfo{}
+}
+
+func (m *RoundStateDump) GetVotes() []RoundVotes {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetCommitRound() int32 {
+	if m != nil {
+		return m.CommitRound
+	}
+	return 0
+}
+
+func (m *RoundStateDump) GetLastCommit() *bits.BitArray {
+	if m != nil {
+		return m.LastCommit
+	}
+	return nil
+}
+
+func (m *RoundStateDump) GetTriggeredTimeoutPrecommit() bool {
+	if m != nil {
+		return m.TriggeredTimeoutPrecommit
+	}
+	return false
+}
+
+// ProposalStatus describes the proposal of the current round.
+type ProposalStatus struct {
+	HasProposal bool           `protobuf:"varint,1,opt,name=has_proposal,json=hasProposal,proto3" json:"has_proposal,omitempty"`
+	BlockID     types1.BlockID `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+	// POL round of the proposal, -1 if none.
+	PolRound int32 `protobuf:"varint,3,opt,name=pol_round,json=polRound,proto3" json:"pol_round,omitempty"`
+	// Block parts received so far.
+	BlockParts *bits.BitArray `protobuf:"bytes,4,opt,name=block_parts,json=blockParts,proto3" json:"block_parts,omitempty"`
+	// True once all block parts were received.
+	Complete bool `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+}
+
+func (m *ProposalStatus) Reset()         { *m = ProposalStatus{} }
+func (m *ProposalStatus) String() string { return proto.CompactTextString(m) }
+func (*ProposalStatus) ProtoMessage()    {}
+func (*ProposalStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{2}
+}
+func (m *ProposalStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProposalStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProposalStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProposalStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposalStatus.Merge(m, src)
+}
+func (m *ProposalStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProposalStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposalStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposalStatus proto.InternalMessageInfo
+
+func (m *ProposalStatus) GetHasProposal() bool {
+	if m != nil {
+		return m.HasProposal
+	}
+	return false
+}
+
+func (m *ProposalStatus) GetBlockID() types1.BlockID {
+	if m != nil {
+		return m.BlockID
+	}
+	return types1.BlockID{}
+}
+
+func (m *ProposalStatus) GetPolRound() int32 {
+	if m != nil {
+		return m.PolRound
+	}
+	return 0
+}
+
+func (m *ProposalStatus) GetBlockParts() *bits.BitArray {
+	if m != nil {
+		return m.BlockParts
+	}
+	return nil
+}
+
+func (m *ProposalStatus) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// LockInfo is a round and the block the node saw a POL for in that round.
+type LockInfo struct {
+	// -1 if none.
+	Round   int32          `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	BlockID types1.BlockID `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+}
+
+func (m *LockInfo) Reset()         { *m = LockInfo{} }
+func (m *LockInfo) String() string { return proto.CompactTextString(m) }
+func (*LockInfo) ProtoMessage()    {}
+func (*LockInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{3}
+}
+func (m *LockInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LockInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockInfo.Merge(m, src)
+}
+func (m *LockInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockInfo proto.InternalMessageInfo
+
+func (m *LockInfo) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LockInfo) GetBlockID() types1.BlockID {
+	if m != nil {
+		return m.BlockID
+	}
+	return types1.BlockID{}
+}
+
+// RoundVotes are the votes received in a round.
+type RoundVotes struct {
+	Round      int32          `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Prevotes   *bits.BitArray `protobuf:"bytes,2,opt,name=prevotes,proto3" json:"prevotes,omitempty"`
+	Precommits *bits.BitArray `protobuf:"bytes,3,opt,name=precommits,proto3" json:"precommits,omitempty"`
+	// Block with +2/3 prevotes, unset if none.
+	PrevotesMaj23 *types1.BlockID `protobuf:"bytes,4,opt,name=prevotes_maj23,json=prevotesMaj23,proto3" json:"prevotes_maj23,omitempty"`
+	// Block with +2/3 precommits, unset if none.
+	PrecommitsMaj23 *types1.BlockID `protobuf:"bytes,5,opt,name=precommits_maj23,json=precommitsMaj23,proto3" json:"precommits_maj23,omitempty"`
+}
+
+func (m *RoundVotes) Reset()         { *m = RoundVotes{} }
+func (m *RoundVotes) String() string { return proto.CompactTextString(m) }
+func (*RoundVotes) ProtoMessage()    {}
+func (*RoundVotes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{4}
+}
+func (m *RoundVotes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundVotes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundVotes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundVotes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundVotes.Merge(m, src)
+}
+func (m *RoundVotes) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundVotes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundVotes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundVotes proto.InternalMessageInfo
+
+func (m *RoundVotes) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundVotes) GetPrevotes() *bits.BitArray {
+	if m != nil {
+		return m.Prevotes
+	}
+	return nil
+}
+
+func (m *RoundVotes) GetPrecommits() *bits.BitArray {
+	if m != nil {
+		return m.Precommits
+	}
+	return nil
+}
+
+func (m *RoundVotes) GetPrevotesMaj23() *types1.BlockID {
+	if m != nil {
+		return m.PrevotesMaj23
+	}
+	return nil
+}
+
+func (m *RoundVotes) GetPrecommitsMaj23() *types1.BlockID {
+	if m != nil {
+		return m.PrecommitsMaj23
+	}
+	return nil
+}
+
+// PeerStateDump is the known round state of a peer.
+type PeerStateDump struct {
+	NodeID    string    `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Address   string    `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Height    int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Round     int32     `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	Step      uint32    `protobuf:"varint,5,opt,name=step,proto3" json:"step,omitempty"`
+	StartTime time.Time `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	// True if the peer has the proposal of the round.
+	Proposal                   bool                 `protobuf:"varint,7,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposalBlockPartSetHeader types1.PartSetHeader `protobuf:"bytes,8,opt,name=proposal_block_part_set_header,json=proposalBlockPartSetHeader,proto3" json:"proposal_block_part_set_header"`
+	ProposalBlockParts         *bits.BitArray       `protobuf:"bytes,9,opt,name=proposal_block_parts,json=proposalBlockParts,proto3" json:"proposal_block_parts,omitempty"`
+	ProposalPolRound           int32                `protobuf:"varint,10,opt,name=proposal_pol_round,json=proposalPolRound,proto3" json:"proposal_pol_round,omitempty"`
+	ProposalPol                *bits.BitArray       `protobuf:"bytes,11,opt,name=proposal_pol,json=proposalPol,proto3" json:"proposal_pol,omitempty"`
+	Prevotes                   *bits.BitArray       `protobuf:"bytes,12,opt,name=prevotes,proto3" json:"prevotes,omitempty"`
+	Precommits                 *bits.BitArray       `protobuf:"bytes,13,opt,name=precommits,proto3" json:"precommits,omitempty"`
+	LastCommitRound            int32                `protobuf:"varint,14,opt,name=last_commit_round,json=lastCommitRound,proto3" json:"last_commit_round,omitempty"`
+	LastCommit                 *bits.BitArray       `protobuf:"bytes,15,opt,name=last_commit,json=lastCommit,proto3" json:"last_commit,omitempty"`
+	CatchupCommitRound         int32                `protobuf:"varint,16,opt,name=catchup_commit_round,json=catchupCommitRound,proto3" json:"catchup_commit_round,omitempty"`
+	CatchupCommit              *bits.BitArray       `protobuf:"bytes,17,opt,name=catchup_commit,json=catchupCommit,proto3" json:"catchup_commit,omitempty"`
+}
+
+func (m *PeerStateDump) Reset()         { *m = PeerStateDump{} }
+func (m *PeerStateDump) String() string { return proto.CompactTextString(m) }
+func (*PeerStateDump) ProtoMessage()    {}
+func (*PeerStateDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5cc34a9a0947b6e, []int{5}
+}
+func (m *PeerStateDump) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerStateDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerStateDump.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerStateDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStateDump.Merge(m, src)
+}
+func (m *PeerStateDump) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerStateDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStateDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStateDump proto.InternalMessageInfo
+
+func (m *PeerStateDump) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *PeerStateDump) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *PeerStateDump) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *PeerStateDump) GetProposal() bool {
+	if m != nil {
+		return m.Proposal
+	}
+	return false
+}
+
+func (m *PeerStateDump) GetProposalBlockPartSetHeader() types1.PartSetHeader {
+	if m != nil {
+		return m.ProposalBlockPartSetHeader
+	}
+	return types1.PartSetHeader{}
+}
+
+func (m *PeerStateDump) GetProposalBlockParts() *bits.BitArray {
+	if m != nil {
+		return m.ProposalBlockParts
+	}
+	return nil
+}
+
+func (m *PeerStateDump) GetProposalPolRound() int32 {
+	if m != nil {
+		return m.ProposalPolRound
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetProposalPol() *bits.BitArray {
+	if m != nil {
+		return m.ProposalPol
+	}
+	return nil
+}
+
+func (m *PeerStateDump) GetPrevotes() *bits.BitArray {
+	if m != nil {
+		return m.Prevotes
+	}
+	return nil
+}
+
+func (m *PeerStateDump) GetPrecommits() *bits.BitArray {
+	if m != nil {
+		return m.Precommits
+	}
+	return nil
+}
+
+func (m *PeerStateDump) GetLastCommitRound() int32 {
+	if m != nil {
+		return m.LastCommitRound
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetLastCommit() *bits.BitArray {
+	if m != nil {
+		return m.LastCommit
+	}
+	return nil
+}
+
+func (m *PeerStateDump) GetCatchupCommitRound() int32 {
+	if m != nil {
+		return m.CatchupCommitRound
+	}
+	return 0
+}
+
+func (m *PeerStateDump) GetCatchupCommit() *bits.BitArray {
+	if m != nil {
+		return m.CatchupCommit
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StateDump)(nil), "tendermint.consensus.StateDump")
+	proto.RegisterType((*RoundStateDump)(nil), "tendermint.consensus.RoundStateDump")
+	proto.RegisterType((*ProposalStatus)(nil), "tendermint.consensus.ProposalStatus")
+	proto.RegisterType((*LockInfo)(nil), "tendermint.consensus.LockInfo")
+	proto.RegisterType((*RoundVotes)(nil), "tendermint.consensus.RoundVotes")
+	proto.RegisterType((*PeerStateDump)(nil), "tendermint.consensus.PeerStateDump")
+}
+
+func init() { proto.RegisterFile("tendermint/consensus/state.proto", fileDescriptor_f5cc34a9a0947b6e) }
+
+var fileDescriptor_f5cc34a9a0947b6e = []byte{
+	// 963 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xc9, 0x8e, 0x1a, 0x47,
+	0x18, 0x9e, 0x1e, 0xb6, 0xe6, 0x67, 0x80, 0x71, 0x09, 0x45, 0x65, 0x1c, 0x01, 0xc1, 0x39, 0x90,
+	0x28, 0x6a, 0xa2, 0xf1, 0xcd, 0xb1, 0xec, 0x0c, 0x9e, 0x89, 0x82, 0xb2, 0x08, 0xb5, 0x9d, 0x1c,
+	0x72, 0x69, 0x35, 0x74, 0x19, 0x3a, 0xa6, 0xa9, 0x56, 0x55, 0x31, 0x92, 0x8f, 0x79, 0x03, 0x3f,
+	0x45, 0x6e, 0x39, 0xe5, 0x25, 0x7c, 0xf4, 0x31, 0xa7, 0x49, 0xc4, 0xbc, 0x42, 0x1e, 0x20, 0xaa,
+	0xa5, 0x17, 0x3c, 0xd8, 0x66, 0x92, 0x5c, 0x50, 0x2d, 0xdf, 0xf7, 0xfd, 0xf5, 0xaf, 0x34, 0xf4,
+	0x04, 0x59, 0x05, 0x84, 0x45, 0xe1, 0x4a, 0x0c, 0x67, 0x74, 0xc5, 0xc9, 0x8a, 0xaf, 0xf9, 0x90,
+	0x0b, 0x5f, 0x10, 0x27, 0x66, 0x54, 0x50, 0xd4, 0xca, 0x10, 0x4e, 0x8a, 0x68, 0xb7, 0xe6, 0x74,
+	0x4e, 0x15, 0x60, 0x28, 0x57, 0x1a, 0xdb, 0xee, 0xce, 0x29, 0x9d, 0x2f, 0xc9, 0x50, 0xed, 0xa6,
+	0xeb, 0x67, 0x43, 0x11, 0x46, 0x84, 0x0b, 0x3f, 0x8a, 0x0d, 0xe0, 0xc3, 0x9c, 0x39, 0xf1, 0x22,
+	0x26, 0x5c, 0xff, 0x9a, 0xdb, 0xfc, 0x63, 0x96, 0xe1, 0x94, 0x0f, 0xa7, 0xa1, 0xd8, 0x42, 0xf4,
+	0x7f, 0xb7, 0xa0, 0xfa, 0x44, 0x3e, 0xee, 0x6c, 0x1d, 0xc5, 0x08, 0x43, 0xe5, 0x82, 0x30, 0x1e,
+	0xd2, 0x15, 0xb6, 0x7a, 0xd6, 0xa0, 0xee, 0x26, 0x5b, 0xf4, 0x0d, 0xd4, 0x18, 0x5d, 0xaf, 0x02,
+	0x4f, 0x79, 0x82, 0x0f, 0x7b, 0xd6, 0xa0, 0x76, 0xf2, 0xb1, 0xb3, 0xcb, 0x15, 0xc7, 0x95, 0xc0,
+	0x54, 0x74, 0x54, 0x7c, 0x75, 0xd9, 0x3d, 0x70, 0x81, 0xa5, 0xa7, 0xe8, 0x11, 0x94, 0x62, 0x42,
+	0x18, 0xc7, 0x85, 0x5e, 0x61, 0x50, 0x3b, 0xb9, 0xbb, 0x5b, 0x66, 0x42, 0x08, 0x7b, 0x53, 0x45,
+	0xf3, 0xfa, 0xbf, 0x95, 0xa0, 0xb1, 0x6d, 0x05, 0x7d, 0x00, 0xe5, 0x05, 0x09, 0xe7, 0x0b, 0xa1,
+	0x5e, 0x5e, 0x70, 0xcd, 0x0e, 0xb5, 0xa0, 0xa4, 0x2c, 0xab, 0x27, 0x97, 0x5c, 0xbd, 0x41, 0x08,
+	0x8a, 0x5c, 0x90, 0x18, 0x17, 0x94, 0x97, 0x6a, 0x8d, 0x1e, 0x03, 0x70, 0xe1, 0x33, 0xe1, 0xc9,
+	0x18, 0xe3, 0xa2, 0xf2, 0xb0, 0xed, 0xe8, 0x04, 0x38, 0x49, 0x02, 0x9c, 0xa7, 0x49, 0x02, 0x46,
+	0xb6, 0x7c, 0xd1, 0xcb, 0x3f, 0xbb, 0x96, 0x5b, 0x55, 0x3c, 0x79, 0x83, 0xce, 0xa1, 0x36, 0xa3,
+	0x51, 0x14, 0x1a, 0x95, 0xd2, 0x0d, 0x54, 0x40, 0x13, 0x95, 0xcc, 0x27, 0x70, 0x1c, 0x33, 0x1a,
+	0x53, 0x4e, 0x98, 0xe7, 0x07, 0x01, 0x23, 0x9c, 0xe3, 0x72, 0xcf, 0x1a, 0x1c, 0xb9, 0xcd, 0xe4,
+	0xfc, 0x54, 0x1f, 0xa3, 0xaf, 0xc0, 0xd6, 0x47, 0xfe, 0x12, 0x57, 0xde, 0x95, 0x96, 0x89, 0x41,
+	0xc9, 0x98, 0xad, 0xb9, 0x09, 0x68, 0xca, 0x45, 0x0f, 0xa0, 0xbc, 0xa4, 0xb3, 0xe7, 0x24, 0xc0,
+	0xb6, 0x52, 0xe9, 0xec, 0x56, 0xf9, 0x96, 0xce, 0x9e, 0x8f, 0x57, 0xcf, 0xa8, 0xe1, 0x1b, 0x0e,
+	0xba, 0x0f, 0xa5, 0x0b, 0x7f, 0x19, 0x06, 0xb8, 0x7a, 0x03, 0xb2, 0xa6, 0xa0, 0x07, 0x50, 0xba,
+	0xa0, 0x82, 0x70, 0x0c, 0xaa, 0x1c, 0x7a, 0xef, 0xa8, 0xaa, 0x1f, 0x25, 0x2e, 0x65, 0xcb, 0x0d,
+	0xfa, 0x08, 0x8e, 0x4c, 0xc4, 0x75, 0x9e, 0x6b, 0x2a, 0xcf, 0x26, 0x0b, 0x8a, 0x84, 0x1e, 0x41,
+	0x6d, 0xe9, 0x73, 0xe1, 0xe9, 0x33, 0x7c, 0x74, 0xfd, 0x89, 0xb2, 0x39, 0x1c, 0xd9, 0x1c, 0xce,
+	0x28, 0x14, 0xa7, 0x8c, 0xf9, 0x2f, 0x5c, 0x90, 0x94, 0xc7, 0x8a, 0x81, 0x1e, 0xc2, 0x1d, 0xc1,
+	0xc2, 0xf9, 0x9c, 0x30, 0x12, 0xa8, 0xc4, 0xd2, 0xb5, 0xf0, 0x62, 0x46, 0x8c, 0x60, 0xbd, 0x67,
+	0x0d, 0x6c, 0xf7, 0x76, 0x0a, 0x79, 0xaa, 0x11, 0x93, 0x04, 0xd0, 0xff, 0xdb, 0x82, 0xc6, 0x76,
+	0xf8, 0xe5, 0xb3, 0x17, 0x3e, 0xf7, 0xd2, 0xd4, 0x59, 0x4a, 0xa3, 0xb6, 0xf0, 0x79, 0x02, 0x44,
+	0xe7, 0x60, 0x4f, 0x65, 0x78, 0xbd, 0x30, 0x30, 0x0d, 0x77, 0x3b, 0xff, 0x66, 0xdd, 0xc6, 0x23,
+	0x89, 0x18, 0x9f, 0x8d, 0x9a, 0x32, 0x26, 0x9b, 0xcb, 0x6e, 0xc5, 0x1c, 0xb8, 0x15, 0xc5, 0x1d,
+	0x07, 0xe8, 0x0e, 0x54, 0x63, 0xba, 0x34, 0xd1, 0x29, 0xa8, 0xe8, 0xd8, 0x31, 0x5d, 0xa6, 0xa1,
+	0xd1, 0x36, 0x62, 0x9f, 0x09, 0x8e, 0x8b, 0xfb, 0x85, 0x46, 0x51, 0x26, 0x92, 0x81, 0xda, 0x60,
+	0xcf, 0x68, 0x14, 0x2f, 0x89, 0xd0, 0xd5, 0x6e, 0xbb, 0xe9, 0xbe, 0x3f, 0x07, 0x3b, 0xc9, 0x78,
+	0xd6, 0x87, 0x56, 0xbe, 0x0f, 0xff, 0x1f, 0x17, 0xfb, 0xbf, 0x1e, 0x02, 0x64, 0xf5, 0xf1, 0x16,
+	0x5b, 0xf7, 0x65, 0xa3, 0x10, 0x5d, 0x69, 0x87, 0x7b, 0xf9, 0x99, 0xe2, 0xd1, 0x43, 0x80, 0x34,
+	0xdd, 0x1c, 0x17, 0xf6, 0x62, 0xe7, 0x18, 0xe8, 0x4b, 0x68, 0x24, 0x5a, 0x5e, 0xe4, 0xff, 0x7c,
+	0x72, 0x0f, 0x17, 0xdf, 0xe3, 0xad, 0x5b, 0x4f, 0x08, 0xdf, 0x49, 0x3c, 0x3a, 0x83, 0xe3, 0x4c,
+	0xcf, 0x68, 0x94, 0xde, 0xa7, 0xd1, 0xcc, 0x28, 0x4a, 0xa5, 0xff, 0x4b, 0x05, 0xea, 0x5b, 0x73,
+	0x15, 0xdd, 0x85, 0xca, 0x8a, 0x06, 0xc4, 0x0b, 0x75, 0xb4, 0xaa, 0x23, 0xd8, 0x5c, 0x76, 0xcb,
+	0xdf, 0xd3, 0x80, 0x8c, 0xcf, 0xdc, 0xb2, 0xbc, 0x1a, 0x07, 0xf2, 0x7f, 0x21, 0x99, 0x42, 0x32,
+	0x72, 0x55, 0x37, 0xd9, 0xe6, 0xc6, 0x6e, 0x61, 0xf7, 0xd8, 0x2d, 0xee, 0x1a, 0xbb, 0xa5, 0xb7,
+	0x8e, 0xdd, 0xf2, 0xbf, 0x1b, 0xbb, 0xed, 0x37, 0x86, 0xa0, 0x9d, 0x1b, 0x6c, 0x21, 0x74, 0x92,
+	0xb5, 0x97, 0xd5, 0xba, 0xc7, 0x89, 0xf0, 0x16, 0xc4, 0x0f, 0x08, 0x33, 0x03, 0xaf, 0x7b, 0x3d,
+	0x8e, 0xb2, 0xc4, 0x9f, 0x10, 0xf1, 0xb5, 0x82, 0x99, 0xb1, 0xd3, 0x4e, 0xc4, 0x46, 0x49, 0x13,
+	0xa4, 0x08, 0x34, 0x81, 0xd6, 0x0e, 0x53, 0x1c, 0x57, 0xf7, 0x2a, 0x18, 0x74, 0x4d, 0x99, 0xa3,
+	0xcf, 0x20, 0x3d, 0xf5, 0xb2, 0x2e, 0x06, 0x15, 0xd4, 0xe3, 0xe4, 0x66, 0x92, 0x74, 0xf3, 0x29,
+	0x1c, 0xe5, 0xd1, 0xb8, 0xb6, 0x97, 0xdd, 0x5a, 0x4e, 0x67, 0xab, 0x4b, 0x8e, 0xfe, 0x53, 0x97,
+	0xd4, 0x6f, 0xdc, 0x25, 0x9f, 0xc2, 0xad, 0xdc, 0x9c, 0x36, 0xbe, 0x36, 0x94, 0xaf, 0xcd, 0x6c,
+	0x1a, 0xef, 0x9c, 0xe9, 0xcd, 0x1b, 0xcf, 0xf4, 0xcf, 0xa1, 0x35, 0xf3, 0xc5, 0x6c, 0xb1, 0x8e,
+	0xb7, 0xed, 0x1d, 0x2b, 0x7b, 0xc8, 0xdc, 0xe5, 0x4d, 0x9e, 0x43, 0x63, 0x9b, 0x81, 0x6f, 0xed,
+	0x65, 0xb5, 0xbe, 0xa5, 0x35, 0xfa, 0xe1, 0xd5, 0xa6, 0x63, 0xbd, 0xde, 0x74, 0xac, 0xbf, 0x36,
+	0x1d, 0xeb, 0xe5, 0x55, 0xe7, 0xe0, 0xf5, 0x55, 0xe7, 0xe0, 0x8f, 0xab, 0xce, 0xc1, 0x4f, 0x5f,
+	0xcc, 0x43, 0xb1, 0x58, 0x4f, 0x9d, 0x19, 0x8d, 0x86, 0xf9, 0xef, 0xba, 0x6c, 0xa9, 0x3f, 0x10,
+	0x77, 0x7d, 0x62, 0x4e, 0xcb, 0xea, 0xee, 0xde, 0x3f, 0x03, 0x00, 0x60, 0xa1, 0xbb, 0xe7, 0x81,
+	0x0a, 0x00, 0x00,
+}
+
+func (m *StateDump) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateDump) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateDump) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintState(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	{
+		size, err := m.RoundState.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.Version != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoundStateDump) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundStateDump) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundStateDump) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TriggeredTimeoutPrecommit {
+		i--
+		if m.TriggeredTimeoutPrecommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.LastCommit != nil {
+		{
+			size, err := m.LastCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.CommitRound != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.CommitRound))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintState(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	{
+		size, err := m.Valid.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	{
+		size, err := m.Locked.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	{
+		size, err := m.Proposal.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintState(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CommitTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.CommitTime):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintState(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x2a
+	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err7 != nil {
+		return 0, err7
+	}
+	i -= n7
+	i = encodeVarintState(dAtA, i, uint64(n7))
+	i--
+	dAtA[i] = 0x22
+	if m.Step != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProposalStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProposalStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposalStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Complete {
+		i--
+		if m.Complete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.BlockParts != nil {
+		{
+			size, err := m.BlockParts.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PolRound != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.PolRound))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size, err := m.BlockID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.HasProposal {
+		i--
+		if m.HasProposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.BlockID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.Round != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoundVotes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundVotes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundVotes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PrecommitsMaj23 != nil {
+		{
+			size, err := m.PrecommitsMaj23.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.PrevotesMaj23 != nil {
+		{
+			size, err := m.PrevotesMaj23.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Precommits != nil {
+		{
+			size, err := m.Precommits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Prevotes != nil {
+		{
+			size, err := m.Prevotes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Round != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerStateDump) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerStateDump) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerStateDump) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CatchupCommit != nil {
+		{
+			size, err := m.CatchupCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.CatchupCommitRound != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.CatchupCommitRound))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.LastCommit != nil {
+		{
+			size, err := m.LastCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.LastCommitRound != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.LastCommitRound))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Precommits != nil {
+		{
+			size, err := m.Precommits.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Prevotes != nil {
+		{
+			size, err := m.Prevotes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.ProposalPol != nil {
+		{
+			size, err := m.ProposalPol.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.ProposalPolRound != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.ProposalPolRound))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ProposalBlockParts != nil {
+		{
+			size, err := m.ProposalBlockParts.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	{
+		size, err := m.ProposalBlockPartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintState(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.Proposal {
+		i--
+		if m.Proposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	n22, err22 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime):])
+	if err22 != nil {
+		return 0, err22
+	}
+	i -= n22
+	i = encodeVarintState(dAtA, i, uint64(n22))
+	i--
+	dAtA[i] = 0x32
+	if m.Step != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Round != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Height != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintState(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintState(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintState(dAtA []byte, offset int, v uint64) int {
+	offset -= sovState(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StateDump) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovState(uint64(m.Version))
+	}
+	l = m.RoundState.Size()
+	n += 1 + l + sovState(uint64(l))
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovState(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RoundStateDump) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovState(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovState(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovState(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovState(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.CommitTime)
+	n += 1 + l + sovState(uint64(l))
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	l = m.Proposal.Size()
+	n += 1 + l + sovState(uint64(l))
+	l = m.Locked.Size()
+	n += 1 + l + sovState(uint64(l))
+	l = m.Valid.Size()
+	n += 1 + l + sovState(uint64(l))
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovState(uint64(l))
+		}
+	}
+	if m.CommitRound != 0 {
+		n += 1 + sovState(uint64(m.CommitRound))
+	}
+	if m.LastCommit != nil {
+		l = m.LastCommit.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.TriggeredTimeoutPrecommit {
+		n += 2
+	}
+	return n
+}
+
+func (m *ProposalStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HasProposal {
+		n += 2
+	}
+	l = m.BlockID.Size()
+	n += 1 + l + sovState(uint64(l))
+	if m.PolRound != 0 {
+		n += 1 + sovState(uint64(m.PolRound))
+	}
+	if m.BlockParts != nil {
+		l = m.BlockParts.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.Complete {
+		n += 2
+	}
+	return n
+}
+
+func (m *LockInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovState(uint64(m.Round))
+	}
+	l = m.BlockID.Size()
+	n += 1 + l + sovState(uint64(l))
+	return n
+}
+
+func (m *RoundVotes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovState(uint64(m.Round))
+	}
+	if m.Prevotes != nil {
+		l = m.Prevotes.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.Precommits != nil {
+		l = m.Precommits.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.PrevotesMaj23 != nil {
+		l = m.PrevotesMaj23.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.PrecommitsMaj23 != nil {
+		l = m.PrecommitsMaj23.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	return n
+}
+
+func (m *PeerStateDump) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovState(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovState(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovState(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovState(uint64(l))
+	if m.Proposal {
+		n += 2
+	}
+	l = m.ProposalBlockPartSetHeader.Size()
+	n += 1 + l + sovState(uint64(l))
+	if m.ProposalBlockParts != nil {
+		l = m.ProposalBlockParts.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.ProposalPolRound != 0 {
+		n += 1 + sovState(uint64(m.ProposalPolRound))
+	}
+	if m.ProposalPol != nil {
+		l = m.ProposalPol.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.Prevotes != nil {
+		l = m.Prevotes.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.Precommits != nil {
+		l = m.Precommits.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.LastCommitRound != 0 {
+		n += 1 + sovState(uint64(m.LastCommitRound))
+	}
+	if m.LastCommit != nil {
+		l = m.LastCommit.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.CatchupCommitRound != 0 {
+		n += 2 + sovState(uint64(m.CatchupCommitRound))
+	}
+	if m.CatchupCommit != nil {
+		l = m.CatchupCommit.Size()
+		n += 2 + l + sovState(uint64(l))
+	}
+	return n
+}
+
+func sovState(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozState(x uint64) (n int) {
+	return sovState(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *StateDump) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StateDump: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StateDump: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoundState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RoundState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, PeerStateDump{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoundStateDump) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundStateDump: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundStateDump: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CommitTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locked", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Locked.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Valid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, RoundVotes{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitRound", wireType)
+			}
+			m.CommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastCommit == nil {
+				m.LastCommit = &bits.BitArray{}
+			}
+			if err := m.LastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggeredTimeoutPrecommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TriggeredTimeoutPrecommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProposalStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProposalStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProposalStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasProposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasProposal = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PolRound", wireType)
+			}
+			m.PolRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PolRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockParts == nil {
+				m.BlockParts = &bits.BitArray{}
+			}
+			if err := m.BlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Complete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Complete = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoundVotes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundVotes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundVotes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Prevotes == nil {
+				m.Prevotes = &bits.BitArray{}
+			}
+			if err := m.Prevotes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Precommits == nil {
+				m.Precommits = &bits.BitArray{}
+			}
+			if err := m.Precommits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevotesMaj23", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PrevotesMaj23 == nil {
+				m.PrevotesMaj23 = &types1.BlockID{}
+			}
+			if err := m.PrevotesMaj23.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitsMaj23", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PrecommitsMaj23 == nil {
+				m.PrecommitsMaj23 = &types1.BlockID{}
+			}
+			if err := m.PrecommitsMaj23.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerStateDump) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerStateDump: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerStateDump: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Proposal = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlockPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ProposalBlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalBlockParts == nil {
+				m.ProposalBlockParts = &bits.BitArray{}
+			}
+			if err := m.ProposalBlockParts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPolRound", wireType)
+			}
+			m.ProposalPolRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposalPolRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalPol", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalPol == nil {
+				m.ProposalPol = &bits.BitArray{}
+			}
+			if err := m.ProposalPol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prevotes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Prevotes == nil {
+				m.Prevotes = &bits.BitArray{}
+			}
+			if err := m.Prevotes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precommits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Precommits == nil {
+				m.Precommits = &bits.BitArray{}
+			}
+			if err := m.Precommits.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitRound", wireType)
+			}
+			m.LastCommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastCommit == nil {
+				m.LastCommit = &bits.BitArray{}
+			}
+			if err := m.LastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CatchupCommitRound", wireType)
+			}
+			m.CatchupCommitRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CatchupCommitRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CatchupCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CatchupCommit == nil {
+				m.CatchupCommit = &bits.BitArray{}
+			}
+			if err := m.CatchupCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipState(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthState
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupState
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthState
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthState        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowState          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupState = fmt.Errorf("proto: unexpected end of group")
+)
@@ -17,6 +17,8 @@ func (m *PexMessage) Wrap(pb proto.Message) error {
 		m.Sum = &PexMessage_PexRequestV2{PexRequestV2: msg}
 	case *PexResponseV2:
 		m.Sum = &PexMessage_PexResponseV2{PexResponseV2: msg}
+	case *PexForwardingRecord:
+		m.Sum = &PexMessage_PexForwardingRecord{PexForwardingRecord: msg}
 	default:
 		return fmt.Errorf("unknown pex message: %T", msg)
 	}
@@ -35,6 +37,8 @@ func (m *PexMessage) Unwrap() (proto.Message, error) {
 		return msg.PexRequestV2, nil
 	case *PexMessage_PexResponseV2:
 		return msg.PexResponseV2, nil
+	case *PexMessage_PexForwardingRecord:
+		return msg.PexForwardingRecord, nil
 	default:
 		return nil, fmt.Errorf("unknown pex message: %T", msg)
 	}
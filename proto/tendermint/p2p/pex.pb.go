@@ -7,15 +7,20 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -287,12 +292,83 @@ func (m *PexResponseV2) GetAddresses() []PexAddressV2 {
 	return nil
 }
 
+// PexForwardingRecord announces that a node has rotated its node key. It is
+// signed by the old node key, and carries the node's new NodeInfo.
+type PexForwardingRecord struct {
+	OldPubKey   crypto.PublicKey `protobuf:"bytes,1,opt,name=old_pub_key,json=oldPubKey,proto3" json:"old_pub_key"`
+	NewNodeInfo NodeInfo         `protobuf:"bytes,2,opt,name=new_node_info,json=newNodeInfo,proto3" json:"new_node_info"`
+	Timestamp   time.Time        `protobuf:"bytes,3,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature   []byte           `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *PexForwardingRecord) Reset()         { *m = PexForwardingRecord{} }
+func (m *PexForwardingRecord) String() string { return proto.CompactTextString(m) }
+func (*PexForwardingRecord) ProtoMessage()    {}
+func (*PexForwardingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81c2f011fd13be57, []int{6}
+}
+func (m *PexForwardingRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PexForwardingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PexForwardingRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PexForwardingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PexForwardingRecord.Merge(m, src)
+}
+func (m *PexForwardingRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *PexForwardingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_PexForwardingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PexForwardingRecord proto.InternalMessageInfo
+
+func (m *PexForwardingRecord) GetOldPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.OldPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *PexForwardingRecord) GetNewNodeInfo() NodeInfo {
+	if m != nil {
+		return m.NewNodeInfo
+	}
+	return NodeInfo{}
+}
+
+func (m *PexForwardingRecord) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *PexForwardingRecord) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type PexMessage struct {
 	// Types that are valid to be assigned to Sum:
 	//	*PexMessage_PexRequest
 	//	*PexMessage_PexResponse
 	//	*PexMessage_PexRequestV2
 	//	*PexMessage_PexResponseV2
+	//	*PexMessage_PexForwardingRecord
 	Sum isPexMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -300,7 +376,7 @@ func (m *PexMessage) Reset()         { *m = PexMessage{} }
 func (m *PexMessage) String() string { return proto.CompactTextString(m) }
 func (*PexMessage) ProtoMessage()    {}
 func (*PexMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81c2f011fd13be57, []int{6}
+	return fileDescriptor_81c2f011fd13be57, []int{7}
 }
 func (m *PexMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -347,11 +423,15 @@ type PexMessage_PexRequestV2 struct {
 type PexMessage_PexResponseV2 struct {
 	PexResponseV2 *PexResponseV2 `protobuf:"bytes,4,opt,name=pex_response_v2,json=pexResponseV2,proto3,oneof" json:"pex_response_v2,omitempty"`
 }
+type PexMessage_PexForwardingRecord struct {
+	PexForwardingRecord *PexForwardingRecord `protobuf:"bytes,5,opt,name=pex_forwarding_record,json=pexForwardingRecord,proto3,oneof" json:"pex_forwarding_record,omitempty"`
+}
 
-func (*PexMessage_PexRequest) isPexMessage_Sum()    {}
-func (*PexMessage_PexResponse) isPexMessage_Sum()   {}
-func (*PexMessage_PexRequestV2) isPexMessage_Sum()  {}
-func (*PexMessage_PexResponseV2) isPexMessage_Sum() {}
+func (*PexMessage_PexRequest) isPexMessage_Sum()          {}
+func (*PexMessage_PexResponse) isPexMessage_Sum()         {}
+func (*PexMessage_PexRequestV2) isPexMessage_Sum()        {}
+func (*PexMessage_PexResponseV2) isPexMessage_Sum()       {}
+func (*PexMessage_PexForwardingRecord) isPexMessage_Sum() {}
 
 func (m *PexMessage) GetSum() isPexMessage_Sum {
 	if m != nil {
@@ -388,6 +468,13 @@ func (m *PexMessage) GetPexResponseV2() *PexResponseV2 {
 	return nil
 }
 
+func (m *PexMessage) GetPexForwardingRecord() *PexForwardingRecord {
+	if x, ok := m.GetSum().(*PexMessage_PexForwardingRecord); ok {
+		return x.PexForwardingRecord
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*PexMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -395,6 +482,7 @@ func (*PexMessage) XXX_OneofWrappers() []interface{} {
 		(*PexMessage_PexResponse)(nil),
 		(*PexMessage_PexRequestV2)(nil),
 		(*PexMessage_PexResponseV2)(nil),
+		(*PexMessage_PexForwardingRecord)(nil),
 	}
 }
 
@@ -405,39 +493,53 @@ func init() {
 	proto.RegisterType((*PexAddressV2)(nil), "tendermint.p2p.PexAddressV2")
 	proto.RegisterType((*PexRequestV2)(nil), "tendermint.p2p.PexRequestV2")
 	proto.RegisterType((*PexResponseV2)(nil), "tendermint.p2p.PexResponseV2")
+	proto.RegisterType((*PexForwardingRecord)(nil), "tendermint.p2p.PexForwardingRecord")
 	proto.RegisterType((*PexMessage)(nil), "tendermint.p2p.PexMessage")
 }
 
 func init() { proto.RegisterFile("tendermint/p2p/pex.proto", fileDescriptor_81c2f011fd13be57) }
 
 var fileDescriptor_81c2f011fd13be57 = []byte{
-	// 407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xdd, 0x8a, 0xda, 0x40,
-	0x14, 0xc7, 0xf3, 0x61, 0x2d, 0x9e, 0x44, 0x0b, 0x43, 0x29, 0xa9, 0x6d, 0xa3, 0xe4, 0xca, 0xde,
-	0x24, 0x30, 0xa5, 0x97, 0x2d, 0x36, 0x08, 0xb5, 0x50, 0xa9, 0x1d, 0xd8, 0x5c, 0xec, 0x8d, 0xe8,
-	0x66, 0xc8, 0x06, 0x56, 0x33, 0x9b, 0x49, 0x16, 0x1f, 0x63, 0xdf, 0x61, 0x5f, 0xc6, 0x4b, 0x2f,
-	0xf7, 0x4a, 0x96, 0xf8, 0x22, 0x8b, 0x13, 0x31, 0x23, 0xba, 0x7b, 0x37, 0xe7, 0x7f, 0xbe, 0x7e,
-	0xe7, 0xcc, 0x01, 0x2b, 0xa3, 0x8b, 0x90, 0xa6, 0xf3, 0x78, 0x91, 0x79, 0x0c, 0x33, 0x8f, 0xd1,
-	0xa5, 0xcb, 0xd2, 0x24, 0x4b, 0x50, 0xab, 0xf2, 0xb8, 0x0c, 0xb3, 0xf6, 0xfb, 0x28, 0x89, 0x12,
-	0xe1, 0xf2, 0x76, 0xaf, 0x32, 0xca, 0x19, 0x03, 0x8c, 0xe9, 0xf2, 0x57, 0x18, 0xa6, 0x94, 0x73,
-	0xf4, 0x01, 0xb4, 0x38, 0xb4, 0xd4, 0xae, 0xda, 0x6b, 0xf8, 0xf5, 0x62, 0xd3, 0xd1, 0xfe, 0x0c,
-	0x88, 0x16, 0x87, 0x42, 0x67, 0x96, 0x26, 0xe9, 0x63, 0xa2, 0xc5, 0x0c, 0x21, 0xa8, 0xb1, 0x24,
-	0xcd, 0x2c, 0xbd, 0xab, 0xf6, 0x9a, 0x44, 0xbc, 0x1d, 0x53, 0x54, 0x24, 0xf4, 0x36, 0xa7, 0x3c,
-	0x73, 0x46, 0x60, 0x08, 0x8b, 0xb3, 0x64, 0xc1, 0x29, 0xfa, 0x09, 0x8d, 0x69, 0xd9, 0x8b, 0x72,
-	0x4b, 0xed, 0xea, 0x3d, 0x03, 0xb7, 0xdd, 0x63, 0x50, 0xb7, 0xe2, 0xf1, 0x6b, 0xab, 0x4d, 0x47,
-	0x21, 0x55, 0x8a, 0xf3, 0x15, 0xcc, 0xca, 0x1d, 0x60, 0xf4, 0x11, 0xf4, 0x3c, 0xbd, 0xd9, 0x13,
-	0xbf, 0x2d, 0x36, 0x1d, 0xfd, 0x82, 0xfc, 0x25, 0x3b, 0xcd, 0x69, 0x89, 0xd0, 0x3d, 0x47, 0x80,
-	0x9d, 0xff, 0xd0, 0x94, 0x48, 0x02, 0x8c, 0xfa, 0xa7, 0x2c, 0x9f, 0x5f, 0x66, 0x09, 0xf0, 0x29,
-	0xcd, 0x83, 0x26, 0x66, 0x1d, 0x51, 0xce, 0xa7, 0x11, 0x45, 0x3f, 0xc0, 0x60, 0x74, 0x39, 0x49,
-	0xcb, 0x96, 0x02, 0xea, 0xfc, 0x78, 0x7b, 0xa8, 0xa1, 0x42, 0x80, 0x1d, 0x2c, 0xd4, 0x07, 0xb3,
-	0x4c, 0x2f, 0x09, 0xc5, 0xba, 0x0d, 0xfc, 0xe9, 0x6c, 0x7e, 0x19, 0x32, 0x54, 0x88, 0xc1, 0xa4,
-	0xed, 0x0e, 0xa0, 0x25, 0x01, 0x4c, 0xee, 0xb0, 0xf8, 0x98, 0xf3, 0x63, 0x1d, 0x16, 0x33, 0x54,
-	0x88, 0xc9, 0x24, 0x1b, 0xfd, 0x86, 0x77, 0x32, 0xc7, 0xae, 0x4c, 0x4d, 0x94, 0xf9, 0xf2, 0x0a,
-	0x8a, 0xa8, 0xd3, 0x64, 0xb2, 0xe0, 0xbf, 0x01, 0x9d, 0xe7, 0x73, 0xff, 0xdf, 0xaa, 0xb0, 0xd5,
-	0x75, 0x61, 0xab, 0x4f, 0x85, 0xad, 0xde, 0x6f, 0x6d, 0x65, 0xbd, 0xb5, 0x95, 0xc7, 0xad, 0xad,
-	0x5c, 0x7e, 0x8f, 0xe2, 0xec, 0x3a, 0x9f, 0xb9, 0x57, 0xc9, 0xdc, 0x93, 0xee, 0x58, 0x3e, 0x69,
-	0x71, 0xaf, 0xc7, 0x37, 0x3e, 0xab, 0x0b, 0xf5, 0xdb, 0x73, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9f,
-	0x9b, 0xfd, 0x75, 0xfc, 0x02, 0x00, 0x00,
+	// 610 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x4f, 0x6f, 0xd3, 0x4e,
+	0x10, 0xb5, 0x93, 0xb4, 0xbf, 0x5f, 0xd6, 0x49, 0x91, 0xb6, 0x80, 0x4c, 0x28, 0x49, 0x64, 0x2e,
+	0xe1, 0x62, 0x4b, 0x46, 0x1c, 0x41, 0xc5, 0xaa, 0x20, 0x55, 0x29, 0x84, 0x15, 0x44, 0x82, 0x8b,
+	0x95, 0xc4, 0x13, 0x63, 0x35, 0xf1, 0x2e, 0xbb, 0x36, 0x4d, 0xbe, 0x45, 0x3f, 0x56, 0x8f, 0x3d,
+	0x72, 0x2a, 0x28, 0xfd, 0x1e, 0x80, 0xbc, 0x76, 0x6a, 0xe7, 0x0f, 0xdc, 0x76, 0xde, 0xcc, 0xbc,
+	0x7d, 0x3b, 0xf3, 0xb4, 0x48, 0x8f, 0x20, 0xf4, 0x80, 0x4f, 0x83, 0x30, 0xb2, 0x98, 0xcd, 0x2c,
+	0x06, 0x33, 0x93, 0x71, 0x1a, 0x51, 0xbc, 0x97, 0x67, 0x4c, 0x66, 0xb3, 0xc6, 0x5d, 0x9f, 0xfa,
+	0x54, 0xa6, 0xac, 0xe4, 0x94, 0x56, 0x35, 0x5a, 0x3e, 0xa5, 0xfe, 0x04, 0x2c, 0x19, 0x0d, 0xe3,
+	0xb1, 0x15, 0x05, 0x53, 0x10, 0xd1, 0x60, 0xca, 0xb2, 0x82, 0x83, 0xc2, 0x05, 0x23, 0x3e, 0x67,
+	0x11, 0xb5, 0xce, 0x60, 0x2e, 0xb2, 0x6c, 0x63, 0xed, 0xfa, 0x68, 0xce, 0x20, 0xcb, 0x19, 0x3d,
+	0x84, 0x7a, 0x30, 0x7b, 0xe9, 0x79, 0x1c, 0x84, 0xc0, 0xf7, 0x51, 0x29, 0xf0, 0x74, 0xb5, 0xad,
+	0x76, 0xaa, 0xce, 0xee, 0xe2, 0xba, 0x55, 0x3a, 0x3e, 0x22, 0xa5, 0xc0, 0x93, 0x38, 0xd3, 0x4b,
+	0x05, 0xbc, 0x47, 0x4a, 0x01, 0xc3, 0x18, 0x55, 0x18, 0xe5, 0x91, 0x5e, 0x6e, 0xab, 0x9d, 0x3a,
+	0x91, 0x67, 0xa3, 0x26, 0x19, 0x09, 0x7c, 0x8d, 0x41, 0x44, 0xc6, 0x29, 0xd2, 0x64, 0x24, 0x18,
+	0x0d, 0x05, 0xe0, 0x17, 0xa8, 0x3a, 0x48, 0xef, 0x02, 0xa1, 0xab, 0xed, 0x72, 0x47, 0xb3, 0x1b,
+	0xe6, 0xea, 0x0c, 0xcc, 0x5c, 0x8f, 0x53, 0xb9, 0xbc, 0x6e, 0x29, 0x24, 0x6f, 0x31, 0x9e, 0xa0,
+	0x5a, 0x9e, 0xee, 0xdb, 0xf8, 0x01, 0x2a, 0xc7, 0x7c, 0x92, 0x29, 0xfe, 0x6f, 0x71, 0xdd, 0x2a,
+	0x7f, 0x24, 0x6f, 0x48, 0x82, 0x19, 0x7b, 0xa8, 0x96, 0xeb, 0xe8, 0xdb, 0xc6, 0x7b, 0x54, 0x2f,
+	0x28, 0xe9, 0xdb, 0xf8, 0x70, 0x53, 0xcb, 0xc1, 0xdf, 0xb5, 0xf4, 0xed, 0x4d, 0x35, 0xbf, 0x55,
+	0xb4, 0xdf, 0x83, 0xd9, 0x2b, 0xca, 0xcf, 0x07, 0xdc, 0x0b, 0x42, 0x9f, 0xc0, 0x88, 0x72, 0x0f,
+	0x3b, 0x48, 0xa3, 0x13, 0xcf, 0x65, 0xf1, 0xd0, 0x3d, 0x83, 0xb9, 0x54, 0xb7, 0xc6, 0x9d, 0x2e,
+	0xc9, 0xec, 0xc5, 0xc3, 0x49, 0x30, 0x3a, 0x81, 0xf9, 0x92, 0x9b, 0x4e, 0xbc, 0x5e, 0x3c, 0x3c,
+	0x81, 0x39, 0x76, 0x50, 0x3d, 0x84, 0x73, 0x37, 0xa4, 0x1e, 0xb8, 0x41, 0x38, 0xa6, 0x72, 0xfa,
+	0x9a, 0xad, 0xaf, 0x2b, 0x7c, 0x4b, 0x3d, 0x38, 0x0e, 0xc7, 0x34, 0x63, 0xd0, 0x42, 0x38, 0x5f,
+	0x42, 0xd8, 0x41, 0xd5, 0x5b, 0xa7, 0xc8, 0x1d, 0x25, 0xd3, 0x4e, 0xbd, 0x64, 0x2e, 0xbd, 0x64,
+	0x7e, 0x58, 0x56, 0x38, 0xff, 0x27, 0x0c, 0x17, 0x3f, 0x5a, 0x2a, 0xc9, 0xdb, 0xf0, 0x01, 0xaa,
+	0x8a, 0xc0, 0x0f, 0x07, 0x51, 0xcc, 0x41, 0xaf, 0xb4, 0xd5, 0x4e, 0x8d, 0xe4, 0x80, 0xf1, 0xab,
+	0x24, 0xb7, 0x7d, 0x0a, 0x42, 0x0c, 0x7c, 0xc0, 0xcf, 0x91, 0xc6, 0x60, 0xe6, 0xf2, 0x74, 0xe8,
+	0xd9, 0xc3, 0xb7, 0x2d, 0x38, 0x5b, 0x4b, 0x57, 0x21, 0x88, 0xdd, 0x46, 0xf8, 0x10, 0xd5, 0xd2,
+	0xf6, 0x74, 0x47, 0xd9, 0x93, 0x1f, 0x6e, 0xed, 0x4f, 0x4b, 0xba, 0x0a, 0xd1, 0x58, 0x1e, 0xe2,
+	0x23, 0xb4, 0x57, 0x10, 0xe0, 0x7e, 0xb3, 0xf5, 0xf2, 0xe6, 0xf0, 0x57, 0x35, 0xf4, 0xed, 0xae,
+	0x42, 0x6a, 0xac, 0x10, 0xe3, 0xd7, 0xe8, 0x4e, 0x51, 0x47, 0x42, 0x53, 0x91, 0x34, 0x8f, 0xfe,
+	0x21, 0x45, 0xf2, 0xd4, 0xd9, 0x8a, 0xc5, 0x3e, 0xa1, 0x7b, 0x09, 0xd1, 0xf8, 0xd6, 0x20, 0x2e,
+	0x97, 0x0e, 0xd1, 0x77, 0x24, 0xdd, 0xe3, 0x2d, 0x74, 0xeb, 0x66, 0xea, 0x2a, 0x64, 0x9f, 0x6d,
+	0xc2, 0xce, 0x0e, 0x2a, 0x8b, 0x78, 0xea, 0xbc, 0xbb, 0x5c, 0x34, 0xd5, 0xab, 0x45, 0x53, 0xfd,
+	0xb9, 0x68, 0xaa, 0x17, 0x37, 0x4d, 0xe5, 0xea, 0xa6, 0xa9, 0x7c, 0xbf, 0x69, 0x2a, 0x9f, 0x9f,
+	0xf9, 0x41, 0xf4, 0x25, 0x1e, 0x9a, 0x23, 0x3a, 0xb5, 0x0a, 0x1f, 0x40, 0xe1, 0x98, 0xfe, 0x33,
+	0xab, 0x9f, 0xc3, 0x70, 0x57, 0xa2, 0x4f, 0xff, 0x0c, 0x00, 0xa9, 0xb2, 0x02, 0xc1, 0xb4, 0x04,
+	0x00, 0x00,
 }
 
 func (m *PexAddress) Marshal() (dAtA []byte, err error) {
@@ -632,6 +734,64 @@ func (m *PexResponseV2) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PexForwardingRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PexForwardingRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PexForwardingRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintPex(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintPex(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.NewNodeInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintPex(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.OldPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintPex(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *PexMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -748,6 +908,27 @@ func (m *PexMessage_PexResponseV2) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	return len(dAtA) - i, nil
 }
+func (m *PexMessage_PexForwardingRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PexMessage_PexForwardingRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PexForwardingRecord != nil {
+		{
+			size, err := m.PexForwardingRecord.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintPex(dAtA []byte, offset int, v uint64) int {
 	offset -= sovPex(v)
 	base := offset
@@ -840,6 +1021,25 @@ func (m *PexResponseV2) Size() (n int) {
 	return n
 }
 
+func (m *PexForwardingRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.OldPubKey.Size()
+	n += 1 + l + sovPex(uint64(l))
+	l = m.NewNodeInfo.Size()
+	n += 1 + l + sovPex(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovPex(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovPex(uint64(l))
+	}
+	return n
+}
+
 func (m *PexMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -900,6 +1100,18 @@ func (m *PexMessage_PexResponseV2) Size() (n int) {
 	}
 	return n
 }
+func (m *PexMessage_PexForwardingRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PexForwardingRecord != nil {
+		l = m.PexForwardingRecord.Size()
+		n += 1 + l + sovPex(uint64(l))
+	}
+	return n
+}
 
 func sovPex(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1390,6 +1602,189 @@ func (m *PexResponseV2) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PexForwardingRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PexForwardingRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PexForwardingRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OldPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewNodeInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewNodeInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PexMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1559,6 +1954,41 @@ func (m *PexMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &PexMessage_PexResponseV2{v}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PexForwardingRecord", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PexForwardingRecord{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &PexMessage_PexForwardingRecord{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPex(dAtA[iNdEx:])
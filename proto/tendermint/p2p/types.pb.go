@@ -88,14 +88,17 @@ func (m *ProtocolVersion) GetApp() uint64 {
 }
 
 type NodeInfo struct {
-	ProtocolVersion ProtocolVersion `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
-	NodeID          string          `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	ListenAddr      string          `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
-	Network         string          `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
-	Version         string          `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
-	Channels        []byte          `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
-	Moniker         string          `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
-	Other           NodeInfoOther   `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ProtocolVersion ProtocolVersion  `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
+	NodeID          string           `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ListenAddr      string           `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	Network         string           `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	Version         string           `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Channels        []byte           `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	Moniker         string           `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	Other           NodeInfoOther    `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	Features        uint64           `protobuf:"varint,9,opt,name=features,proto3" json:"features,omitempty"`
+	AppChannels     []AppChannel     `protobuf:"bytes,10,rep,name=app_channels,json=appChannels,proto3" json:"app_channels"`
+	ChannelVersions []ChannelVersion `protobuf:"bytes,11,rep,name=channel_versions,json=channelVersions,proto3" json:"channel_versions"`
 }
 
 func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
@@ -187,6 +190,197 @@ func (m *NodeInfo) GetOther() NodeInfoOther {
 	return NodeInfoOther{}
 }
 
+func (m *NodeInfo) GetFeatures() uint64 {
+	if m != nil {
+		return m.Features
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetAppChannels() []AppChannel {
+	if m != nil {
+		return m.AppChannels
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetChannelVersions() []ChannelVersion {
+	if m != nil {
+		return m.ChannelVersions
+	}
+	return nil
+}
+
+// AppChannel is a channel of an app reactor, with the range of versions of its
+// protocol the node speaks.
+type AppChannel struct {
+	ID         uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MinVersion uint32 `protobuf:"varint,2,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	MaxVersion uint32 `protobuf:"varint,3,opt,name=max_version,json=maxVersion,proto3" json:"max_version,omitempty"`
+}
+
+func (m *AppChannel) Reset()         { *m = AppChannel{} }
+func (m *AppChannel) String() string { return proto.CompactTextString(m) }
+func (*AppChannel) ProtoMessage()    {}
+func (*AppChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{2}
+}
+func (m *AppChannel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AppChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AppChannel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AppChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppChannel.Merge(m, src)
+}
+func (m *AppChannel) XXX_Size() int {
+	return m.Size()
+}
+func (m *AppChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppChannel proto.InternalMessageInfo
+
+func (m *AppChannel) GetID() uint32 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *AppChannel) GetMinVersion() uint32 {
+	if m != nil {
+		return m.MinVersion
+	}
+	return 0
+}
+
+func (m *AppChannel) GetMaxVersion() uint32 {
+	if m != nil {
+		return m.MaxVersion
+	}
+	return 0
+}
+
+// ChannelVersion is the latest version of the messages of a channel the node
+// speaks.
+type ChannelVersion struct {
+	ChannelID uint32 `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Version   uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *ChannelVersion) Reset()         { *m = ChannelVersion{} }
+func (m *ChannelVersion) String() string { return proto.CompactTextString(m) }
+func (*ChannelVersion) ProtoMessage()    {}
+func (*ChannelVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{3}
+}
+func (m *ChannelVersion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelVersion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelVersion.Merge(m, src)
+}
+func (m *ChannelVersion) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelVersion proto.InternalMessageInfo
+
+func (m *ChannelVersion) GetChannelID() uint32 {
+	if m != nil {
+		return m.ChannelID
+	}
+	return 0
+}
+
+func (m *ChannelVersion) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// VersionedMessage wraps an encoded message of a channel, along with the
+// version of the message, for peers which negotiated a version above 0.
+type VersionedMessage struct {
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Msg     []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *VersionedMessage) Reset()         { *m = VersionedMessage{} }
+func (m *VersionedMessage) String() string { return proto.CompactTextString(m) }
+func (*VersionedMessage) ProtoMessage()    {}
+func (*VersionedMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{4}
+}
+func (m *VersionedMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersionedMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionedMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VersionedMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionedMessage.Merge(m, src)
+}
+func (m *VersionedMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionedMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionedMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionedMessage proto.InternalMessageInfo
+
+func (m *VersionedMessage) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *VersionedMessage) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
 type NodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -196,7 +390,7 @@ func (m *NodeInfoOther) Reset()         { *m = NodeInfoOther{} }
 func (m *NodeInfoOther) String() string { return proto.CompactTextString(m) }
 func (*NodeInfoOther) ProtoMessage()    {}
 func (*NodeInfoOther) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{2}
+	return fileDescriptor_c8a29e659aeca578, []int{5}
 }
 func (m *NodeInfoOther) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -249,7 +443,7 @@ func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
 func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()    {}
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{3}
+	return fileDescriptor_c8a29e659aeca578, []int{6}
 }
 func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -310,7 +504,7 @@ func (m *PeerAddressInfo) Reset()         { *m = PeerAddressInfo{} }
 func (m *PeerAddressInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerAddressInfo) ProtoMessage()    {}
 func (*PeerAddressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{4}
+	return fileDescriptor_c8a29e659aeca578, []int{7}
 }
 func (m *PeerAddressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -370,6 +564,9 @@ func (m *PeerAddressInfo) GetDialFailures() uint32 {
 func init() {
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*NodeInfo)(nil), "tendermint.p2p.NodeInfo")
+	proto.RegisterType((*AppChannel)(nil), "tendermint.p2p.AppChannel")
+	proto.RegisterType((*ChannelVersion)(nil), "tendermint.p2p.ChannelVersion")
+	proto.RegisterType((*VersionedMessage)(nil), "tendermint.p2p.VersionedMessage")
 	proto.RegisterType((*NodeInfoOther)(nil), "tendermint.p2p.NodeInfoOther")
 	proto.RegisterType((*PeerInfo)(nil), "tendermint.p2p.PeerInfo")
 	proto.RegisterType((*PeerAddressInfo)(nil), "tendermint.p2p.PeerAddressInfo")
@@ -378,46 +575,55 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x4e, 0x1b, 0x3d,
-	0x14, 0xcd, 0x24, 0x21, 0x09, 0x37, 0x84, 0xf0, 0x59, 0xe8, 0xd3, 0x10, 0xa9, 0x19, 0x14, 0x36,
-	0xac, 0x26, 0x52, 0xaa, 0x2e, 0xba, 0x64, 0x40, 0xad, 0x22, 0x55, 0x25, 0x9a, 0xa2, 0x2e, 0xda,
-	0xc5, 0x68, 0x32, 0x76, 0x82, 0xc5, 0xc4, 0xb6, 0x3c, 0x4e, 0x4b, 0xdf, 0x82, 0x37, 0xe9, 0x63,
-	0x94, 0x25, 0xcb, 0xae, 0xd2, 0x6a, 0xd8, 0xf6, 0x21, 0x2a, 0xdb, 0x33, 0x40, 0xa2, 0x2e, 0xd8,
-	0xf9, 0xdc, 0xe3, 0x73, 0xee, 0x8f, 0xad, 0x0b, 0x3d, 0x45, 0x18, 0x26, 0x72, 0x41, 0x99, 0x1a,
-	0x8a, 0x91, 0x18, 0xaa, 0x6f, 0x82, 0x64, 0xbe, 0x90, 0x5c, 0x71, 0xb4, 0xfb, 0xc8, 0xf9, 0x62,
-	0x24, 0x7a, 0xfb, 0x73, 0x3e, 0xe7, 0x86, 0x1a, 0xea, 0x93, 0xbd, 0xd5, 0xf3, 0xe6, 0x9c, 0xcf,
-	0x53, 0x32, 0x34, 0x68, 0xba, 0x9c, 0x0d, 0x15, 0x5d, 0x90, 0x4c, 0xc5, 0x0b, 0x61, 0x2f, 0x0c,
-	0x2e, 0xa0, 0x3b, 0xd1, 0x87, 0x84, 0xa7, 0x1f, 0x89, 0xcc, 0x28, 0x67, 0xe8, 0x00, 0x6a, 0x62,
-	0x24, 0x5c, 0xe7, 0xd0, 0x39, 0xae, 0x07, 0xcd, 0x7c, 0xe5, 0xd5, 0x26, 0xa3, 0x49, 0xa8, 0x63,
-	0x68, 0x1f, 0xb6, 0xa6, 0x29, 0x4f, 0xae, 0xdc, 0xaa, 0x26, 0x43, 0x0b, 0xd0, 0x1e, 0xd4, 0x62,
-	0x21, 0xdc, 0x9a, 0x89, 0xe9, 0xe3, 0xe0, 0x47, 0x15, 0x5a, 0xef, 0x39, 0x26, 0x63, 0x36, 0xe3,
-	0x68, 0x02, 0x7b, 0xa2, 0x48, 0x11, 0x7d, 0xb1, 0x39, 0x8c, 0x79, 0x7b, 0xe4, 0xf9, 0xeb, 0x4d,
-	0xf8, 0x1b, 0xa5, 0x04, 0xf5, 0xdb, 0x95, 0x57, 0x09, 0xbb, 0x62, 0xa3, 0xc2, 0x23, 0x68, 0x32,
-	0x8e, 0x49, 0x44, 0xb1, 0x29, 0x64, 0x3b, 0x80, 0x7c, 0xe5, 0x35, 0x4c, 0xc2, 0xb3, 0xb0, 0xa1,
-	0xa9, 0x31, 0x46, 0x1e, 0xb4, 0x53, 0x9a, 0x29, 0xc2, 0xa2, 0x18, 0x63, 0x69, 0xaa, 0xdb, 0x0e,
-	0xc1, 0x86, 0x4e, 0x30, 0x96, 0xc8, 0x85, 0x26, 0x23, 0xea, 0x2b, 0x97, 0x57, 0x6e, 0xdd, 0x90,
-	0x25, 0xd4, 0x4c, 0x59, 0xe8, 0x96, 0x65, 0x0a, 0x88, 0x7a, 0xd0, 0x4a, 0x2e, 0x63, 0xc6, 0x48,
-	0x9a, 0xb9, 0x8d, 0x43, 0xe7, 0x78, 0x27, 0x7c, 0xc0, 0x5a, 0xb5, 0xe0, 0x8c, 0x5e, 0x11, 0xe9,
-	0x36, 0xad, 0xaa, 0x80, 0xe8, 0x35, 0x6c, 0x71, 0x75, 0x49, 0xa4, 0xdb, 0x32, 0x6d, 0xbf, 0xd8,
-	0x6c, 0xbb, 0x1c, 0xd5, 0xb9, 0xbe, 0x54, 0x34, 0x6d, 0x15, 0x83, 0xcf, 0xd0, 0x59, 0x63, 0xd1,
-	0x01, 0xb4, 0xd4, 0x75, 0x44, 0x19, 0x26, 0xd7, 0x66, 0x8a, 0xdb, 0x61, 0x53, 0x5d, 0x8f, 0x35,
-	0x44, 0x43, 0x68, 0x4b, 0x91, 0x98, 0x76, 0x49, 0x96, 0x15, 0xa3, 0xd9, 0xcd, 0x57, 0x1e, 0x84,
-	0x93, 0xd3, 0x13, 0x1b, 0x0d, 0x41, 0x8a, 0xa4, 0x38, 0x0f, 0xbe, 0x3b, 0xd0, 0x9a, 0x10, 0x22,
-	0xcd, 0x33, 0xfd, 0x0f, 0x55, 0x8a, 0xad, 0x65, 0xd0, 0xc8, 0x57, 0x5e, 0x75, 0x7c, 0x16, 0x56,
-	0x29, 0x46, 0x01, 0xec, 0x14, 0x8e, 0x11, 0x65, 0x33, 0xee, 0x56, 0x0f, 0x6b, 0xff, 0x7c, 0x3a,
-	0x42, 0x64, 0xe1, 0xab, 0xed, 0xc2, 0x76, 0xfc, 0x08, 0xd0, 0x5b, 0xd8, 0x4d, 0xe3, 0x4c, 0x45,
-	0x09, 0x67, 0x8c, 0x24, 0x8a, 0x60, 0xf3, 0x1c, 0xed, 0x51, 0xcf, 0xb7, 0xff, 0xd3, 0x2f, 0xff,
-	0xa7, 0x7f, 0x51, 0xfe, 0xcf, 0xa0, 0x7e, 0xf3, 0xcb, 0x73, 0xc2, 0x8e, 0xd6, 0x9d, 0x96, 0xb2,
-	0xc1, 0x1f, 0x07, 0xba, 0x1b, 0x99, 0xf4, 0xdc, 0xcb, 0x96, 0x8b, 0x81, 0x14, 0x10, 0xbd, 0x83,
-	0xff, 0x4c, 0x5a, 0x4c, 0xe3, 0x34, 0xca, 0x96, 0x49, 0x52, 0x8e, 0xe5, 0x39, 0x99, 0xbb, 0x5a,
-	0x7a, 0x46, 0xe3, 0xf4, 0x83, 0x15, 0xae, 0xbb, 0xcd, 0x62, 0x9a, 0x2e, 0x25, 0x79, 0x76, 0x1f,
-	0x0f, 0x6e, 0x6f, 0xac, 0x10, 0x1d, 0x41, 0xe7, 0xa9, 0x51, 0x66, 0xfe, 0x60, 0x27, 0xdc, 0xc1,
-	0x8f, 0x77, 0xb2, 0xe0, 0xfc, 0x36, 0xef, 0x3b, 0x77, 0x79, 0xdf, 0xf9, 0x9d, 0xf7, 0x9d, 0x9b,
-	0xfb, 0x7e, 0xe5, 0xee, 0xbe, 0x5f, 0xf9, 0x79, 0xdf, 0xaf, 0x7c, 0x7a, 0x35, 0xa7, 0xea, 0x72,
-	0x39, 0xf5, 0x13, 0xbe, 0x18, 0x3e, 0xd9, 0x12, 0x4f, 0x17, 0x86, 0xd9, 0x05, 0xeb, 0x1b, 0x64,
-	0xda, 0x30, 0xd1, 0x97, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x0b, 0xe9, 0x56, 0xd3, 0x5a, 0x04,
-	0x00, 0x00,
+	// 764 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xeb, 0x44,
+	0x14, 0xae, 0xe3, 0x36, 0x3f, 0x27, 0x49, 0x5b, 0x46, 0x57, 0xc8, 0x37, 0x12, 0x71, 0xe5, 0xbb,
+	0xe9, 0x02, 0x25, 0x52, 0x10, 0x0b, 0x36, 0x48, 0x4d, 0x23, 0x50, 0x24, 0xa0, 0xd1, 0x70, 0x85,
+	0x10, 0x2c, 0x2c, 0xc7, 0x33, 0x49, 0x47, 0xb5, 0x67, 0x46, 0x1e, 0x07, 0xc2, 0x5b, 0xdc, 0x37,
+	0xe1, 0x35, 0xee, 0xb2, 0x4b, 0x56, 0x01, 0xb9, 0x5b, 0x5e, 0x80, 0x1d, 0x9a, 0xf1, 0x38, 0x89,
+	0x23, 0x16, 0xdc, 0xdd, 0xf9, 0xf9, 0xce, 0x77, 0xfe, 0x66, 0x0e, 0x0c, 0x72, 0xca, 0x09, 0xcd,
+	0x52, 0xc6, 0xf3, 0xb1, 0x9c, 0xc8, 0x71, 0xfe, 0x9b, 0xa4, 0x6a, 0x24, 0x33, 0x91, 0x0b, 0x74,
+	0x79, 0xf0, 0x8d, 0xe4, 0x44, 0x0e, 0x5e, 0xad, 0xc5, 0x5a, 0x18, 0xd7, 0x58, 0x4b, 0x25, 0x6a,
+	0xe0, 0xaf, 0x85, 0x58, 0x27, 0x74, 0x6c, 0xb4, 0xe5, 0x66, 0x35, 0xce, 0x59, 0x4a, 0x55, 0x1e,
+	0xa5, 0xb2, 0x04, 0x04, 0x6f, 0xe1, 0x6a, 0xa1, 0x85, 0x58, 0x24, 0x3f, 0xd0, 0x4c, 0x31, 0xc1,
+	0xd1, 0x6b, 0x70, 0xe5, 0x44, 0x7a, 0xce, 0x8d, 0x73, 0x7b, 0x3e, 0x6d, 0x15, 0x3b, 0xdf, 0x5d,
+	0x4c, 0x16, 0x58, 0xdb, 0xd0, 0x2b, 0xb8, 0x58, 0x26, 0x22, 0x7e, 0xf2, 0x1a, 0xda, 0x89, 0x4b,
+	0x05, 0x5d, 0x83, 0x1b, 0x49, 0xe9, 0xb9, 0xc6, 0xa6, 0xc5, 0xe0, 0x1f, 0x17, 0xda, 0xdf, 0x09,
+	0x42, 0xe7, 0x7c, 0x25, 0xd0, 0x02, 0xae, 0xa5, 0x4d, 0x11, 0xfe, 0x52, 0xe6, 0x30, 0xe4, 0xdd,
+	0x89, 0x3f, 0xaa, 0x37, 0x31, 0x3a, 0x29, 0x65, 0x7a, 0xfe, 0x7e, 0xe7, 0x9f, 0xe1, 0x2b, 0x79,
+	0x52, 0xe1, 0x1b, 0x68, 0x71, 0x41, 0x68, 0xc8, 0x88, 0x29, 0xa4, 0x33, 0x85, 0x62, 0xe7, 0x37,
+	0x4d, 0xc2, 0x19, 0x6e, 0x6a, 0xd7, 0x9c, 0x20, 0x1f, 0xba, 0x09, 0x53, 0x39, 0xe5, 0x61, 0x44,
+	0x48, 0x66, 0xaa, 0xeb, 0x60, 0x28, 0x4d, 0x77, 0x84, 0x64, 0xc8, 0x83, 0x16, 0xa7, 0xf9, 0xaf,
+	0x22, 0x7b, 0xf2, 0xce, 0x8d, 0xb3, 0x52, 0xb5, 0xa7, 0x2a, 0xf4, 0xa2, 0xf4, 0x58, 0x15, 0x0d,
+	0xa0, 0x1d, 0x3f, 0x46, 0x9c, 0xd3, 0x44, 0x79, 0xcd, 0x1b, 0xe7, 0xb6, 0x87, 0xf7, 0xba, 0x8e,
+	0x4a, 0x05, 0x67, 0x4f, 0x34, 0xf3, 0x5a, 0x65, 0x94, 0x55, 0xd1, 0x17, 0x70, 0x21, 0xf2, 0x47,
+	0x9a, 0x79, 0x6d, 0xd3, 0xf6, 0x27, 0xa7, 0x6d, 0x57, 0xa3, 0x7a, 0xd0, 0x20, 0xdb, 0x74, 0x19,
+	0xa1, 0x13, 0xae, 0x68, 0x94, 0x6f, 0x32, 0xaa, 0xbc, 0x8e, 0x19, 0xf0, 0x5e, 0x47, 0xf7, 0xd0,
+	0x8b, 0xa4, 0x0c, 0xf7, 0x05, 0xc1, 0x8d, 0x7b, 0xdb, 0x9d, 0x0c, 0x4e, 0xd9, 0xef, 0xa4, 0xbc,
+	0x2f, 0x21, 0x96, 0xba, 0x1b, 0xed, 0x2d, 0x0a, 0x3d, 0xc0, 0xb5, 0x25, 0xa8, 0x96, 0xa3, 0xbc,
+	0xae, 0x21, 0x1a, 0x9e, 0x12, 0xd9, 0x98, 0x93, 0xe5, 0xc4, 0x35, 0xab, 0x0a, 0x56, 0x00, 0x87,
+	0x8c, 0xe8, 0x63, 0x68, 0x30, 0x62, 0xd6, 0xdd, 0x9f, 0x36, 0x8b, 0x9d, 0xdf, 0x98, 0xcf, 0x70,
+	0x83, 0x99, 0xed, 0xa4, 0x8c, 0xef, 0xdf, 0x83, 0x5e, 0x63, 0x1f, 0x43, 0xca, 0x78, 0xb5, 0x63,
+	0x0d, 0x88, 0xb6, 0x7b, 0x80, 0x6b, 0x01, 0xd1, 0xd6, 0x02, 0x82, 0x1f, 0xe1, 0xb2, 0x5e, 0x10,
+	0xfa, 0x14, 0xa0, 0x6a, 0x65, 0x9f, 0xb3, 0x5f, 0xec, 0xfc, 0x8e, 0xc5, 0xcd, 0x67, 0xb8, 0x63,
+	0x01, 0x73, 0x72, 0xbc, 0xe4, 0x32, 0x7b, 0xa5, 0x06, 0x5f, 0xc2, 0xb5, 0xa5, 0xa4, 0xe4, 0x5b,
+	0xaa, 0x54, 0xb4, 0xa6, 0xc7, 0x68, 0xa7, 0x86, 0xd6, 0xaf, 0x3f, 0x55, 0x6b, 0xc3, 0xd1, 0xc3,
+	0x5a, 0x0c, 0x7e, 0x86, 0x7e, 0x6d, 0xa3, 0xe8, 0x35, 0xb4, 0xf3, 0x6d, 0xc8, 0x38, 0xa1, 0x5b,
+	0x13, 0xdd, 0xc1, 0xad, 0x7c, 0x3b, 0xd7, 0x2a, 0x1a, 0x43, 0x37, 0x93, 0xb1, 0x79, 0xa2, 0x54,
+	0x29, 0xfb, 0x9c, 0x2f, 0x8b, 0x9d, 0x0f, 0x78, 0x71, 0x7f, 0x57, 0x5a, 0x31, 0x64, 0x32, 0xb6,
+	0x72, 0xf0, 0xbb, 0x03, 0xed, 0x05, 0xa5, 0x99, 0xf9, 0x5a, 0x87, 0xe9, 0x76, 0x6a, 0xd3, 0x9d,
+	0x42, 0xcf, 0x32, 0x86, 0x8c, 0xaf, 0x84, 0xd7, 0xb8, 0x71, 0xff, 0xf3, 0xbb, 0x51, 0x9a, 0x59,
+	0x5e, 0x4d, 0x87, 0xbb, 0xd1, 0x41, 0x41, 0x5f, 0xc3, 0x65, 0x12, 0xa9, 0x3c, 0x8c, 0x05, 0xe7,
+	0x34, 0xce, 0x29, 0x31, 0x3b, 0xd0, 0xef, 0xab, 0xbc, 0x29, 0xa3, 0xea, 0xa6, 0x8c, 0xde, 0x56,
+	0x37, 0x65, 0x7a, 0xfe, 0xee, 0x4f, 0xdf, 0xc1, 0x7d, 0x1d, 0x77, 0x5f, 0x85, 0x05, 0x7f, 0x3b,
+	0x70, 0x75, 0x92, 0x49, 0x8f, 0xb3, 0x6a, 0xd9, 0x0e, 0xc4, 0xaa, 0xe8, 0x1b, 0xf8, 0xc8, 0xa4,
+	0x25, 0x2c, 0x4a, 0x42, 0xb5, 0x89, 0xe3, 0x6a, 0x2c, 0xff, 0x27, 0xf3, 0x95, 0x0e, 0x9d, 0xb1,
+	0x28, 0xf9, 0xbe, 0x0c, 0xac, 0xb3, 0xad, 0x22, 0x96, 0x6c, 0x32, 0xea, 0xb9, 0x1f, 0xca, 0xf6,
+	0x55, 0x19, 0x88, 0xde, 0x40, 0xff, 0x98, 0x48, 0x99, 0xbb, 0xd1, 0xc7, 0x3d, 0x72, 0xc0, 0xa8,
+	0xe9, 0xc3, 0xfb, 0x62, 0xe8, 0x3c, 0x17, 0x43, 0xe7, 0xaf, 0x62, 0xe8, 0xbc, 0x7b, 0x19, 0x9e,
+	0x3d, 0xbf, 0x0c, 0xcf, 0xfe, 0x78, 0x19, 0x9e, 0xfd, 0xf4, 0xf9, 0x9a, 0xe5, 0x8f, 0x9b, 0xe5,
+	0x28, 0x16, 0xe9, 0xf8, 0xe8, 0xb2, 0x1f, 0x89, 0xe5, 0xfd, 0xae, 0x5f, 0xfd, 0x65, 0xd3, 0x58,
+	0x3f, 0xfb, 0x77, 0x00, 0xaf, 0x1e, 0xd7, 0x03, 0x0e, 0x06, 0x00, 0x00,
 }
 
 func (m *ProtocolVersion) Marshal() (dAtA []byte, err error) {
@@ -478,6 +684,39 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ChannelVersions) > 0 {
+		for iNdEx := len(m.ChannelVersions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ChannelVersions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.AppChannels) > 0 {
+		for iNdEx := len(m.AppChannels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AppChannels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if m.Features != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Features))
+		i--
+		dAtA[i] = 0x48
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -543,6 +782,112 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AppChannel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppChannel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AppChannel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MinVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MinVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChannelID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChannelID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VersionedMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersionedMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersionedMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *NodeInfoOther) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -752,50 +1097,114 @@ func (m *NodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if m.Features != 0 {
+		n += 1 + sovTypes(uint64(m.Features))
+	}
+	if len(m.AppChannels) > 0 {
+		for _, e := range m.AppChannels {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ChannelVersions) > 0 {
+		for _, e := range m.ChannelVersions {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *NodeInfoOther) Size() (n int) {
+func (m *AppChannel) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.TxIndex)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.ID != 0 {
+		n += 1 + sovTypes(uint64(m.ID))
 	}
-	l = len(m.RPCAddress)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.MinVersion != 0 {
+		n += 1 + sovTypes(uint64(m.MinVersion))
+	}
+	if m.MaxVersion != 0 {
+		n += 1 + sovTypes(uint64(m.MaxVersion))
 	}
 	return n
 }
 
-func (m *PeerInfo) Size() (n int) {
+func (m *ChannelVersion) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if len(m.AddressInfo) > 0 {
-		for _, e := range m.AddressInfo {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
-		}
+	if m.ChannelID != 0 {
+		n += 1 + sovTypes(uint64(m.ChannelID))
 	}
-	if m.LastConnected != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected)
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Version != 0 {
+		n += 1 + sovTypes(uint64(m.Version))
 	}
 	return n
 }
 
-func (m *PeerAddressInfo) Size() (n int) {
+func (m *VersionedMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovTypes(uint64(m.Version))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *NodeInfoOther) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxIndex)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.RPCAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *PeerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.AddressInfo) > 0 {
+		for _, e := range m.AddressInfo {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.LastConnected != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected)
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *PeerAddressInfo) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1221,6 +1630,391 @@ func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			m.Features = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Features |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppChannels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppChannels = append(m.AppChannels, AppChannel{})
+			if err := m.AppChannels[len(m.AppChannels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelVersions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelVersions = append(m.ChannelVersions, ChannelVersion{})
+			if err := m.ChannelVersions[len(m.ChannelVersions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppChannel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppChannel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppChannel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersion", wireType)
+			}
+			m.MinVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersion", wireType)
+			}
+			m.MaxVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			m.ChannelID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChannelID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersionedMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersionedMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersionedMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
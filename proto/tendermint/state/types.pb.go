@@ -148,8 +148,9 @@ func (m *ValidatorsInfo) GetLastHeightChanged() int64 {
 
 // ConsensusParamsInfo represents the latest consensus params, or the last height it changed
 type ConsensusParamsInfo struct {
-	ConsensusParams   types1.ConsensusParams `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params"`
-	LastHeightChanged int64                  `protobuf:"varint,2,opt,name=last_height_changed,json=lastHeightChanged,proto3" json:"last_height_changed,omitempty"`
+	ConsensusParams          types1.ConsensusParams           `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params"`
+	LastHeightChanged        int64                            `protobuf:"varint,2,opt,name=last_height_changed,json=lastHeightChanged,proto3" json:"last_height_changed,omitempty"`
+	ScheduledConsensusParams []types.ScheduledConsensusParams `protobuf:"bytes,3,rep,name=scheduled_consensus_params,json=scheduledConsensusParams,proto3" json:"scheduled_consensus_params"`
 }
 
 func (m *ConsensusParamsInfo) Reset()         { *m = ConsensusParamsInfo{} }
@@ -199,6 +200,13 @@ func (m *ConsensusParamsInfo) GetLastHeightChanged() int64 {
 	return 0
 }
 
+func (m *ConsensusParamsInfo) GetScheduledConsensusParams() []types.ScheduledConsensusParams {
+	if m != nil {
+		return m.ScheduledConsensusParams
+	}
+	return nil
+}
+
 type Version struct {
 	Consensus version.Consensus `protobuf:"bytes,1,opt,name=consensus,proto3" json:"consensus"`
 	Software  string            `protobuf:"bytes,2,opt,name=software,proto3" json:"software,omitempty"`
@@ -278,6 +286,11 @@ type State struct {
 	LastResultsHash []byte `protobuf:"bytes,12,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// Consensus parameter updates scheduled at future heights, sorted by
+	// activation height.
+	ScheduledConsensusParams []types.ScheduledConsensusParams `protobuf:"bytes,15,rep,name=scheduled_consensus_params,json=scheduledConsensusParams,proto3" json:"scheduled_consensus_params"`
+	// Addresses of the validators of the next height jailed by the application.
+	JailedValidators [][]byte `protobuf:"bytes,16,rep,name=jailed_validators,json=jailedValidators,proto3" json:"jailed_validators,omitempty"`
 }
 
 func (m *State) Reset()         { *m = State{} }
@@ -411,66 +424,199 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+func (m *State) GetScheduledConsensusParams() []types.ScheduledConsensusParams {
+	if m != nil {
+		return m.ScheduledConsensusParams
+	}
+	return nil
+}
+
+func (m *State) GetJailedValidators() [][]byte {
+	if m != nil {
+		return m.JailedValidators
+	}
+	return nil
+}
+
+// StoreVersion is the version of the software which last wrote to the state
+// store, and the format of the state it wrote.
+type StoreVersion struct {
+	Software    string `protobuf:"bytes,1,opt,name=software,proto3" json:"software,omitempty"`
+	StateFormat uint64 `protobuf:"varint,2,opt,name=state_format,json=stateFormat,proto3" json:"state_format,omitempty"`
+}
+
+func (m *StoreVersion) Reset()         { *m = StoreVersion{} }
+func (m *StoreVersion) String() string { return proto.CompactTextString(m) }
+func (*StoreVersion) ProtoMessage()    {}
+func (*StoreVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{5}
+}
+func (m *StoreVersion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreVersion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreVersion.Merge(m, src)
+}
+func (m *StoreVersion) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreVersion proto.InternalMessageInfo
+
+func (m *StoreVersion) GetSoftware() string {
+	if m != nil {
+		return m.Software
+	}
+	return ""
+}
+
+func (m *StoreVersion) GetStateFormat() uint64 {
+	if m != nil {
+		return m.StateFormat
+	}
+	return 0
+}
+
+// Record is the envelope of the records written to the state store since
+// schema version 2. It carries the schema version the data was written with,
+// so that records can be migrated lazily as the schema changes.
+type Record struct {
+	SchemaVersion uint32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{6}
+}
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(m, src)
+}
+func (m *Record) XXX_Size() int {
+	return m.Size()
+}
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetSchemaVersion() uint32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+func (m *Record) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "tendermint.state.ABCIResponses")
 	proto.RegisterType((*ValidatorsInfo)(nil), "tendermint.state.ValidatorsInfo")
 	proto.RegisterType((*ConsensusParamsInfo)(nil), "tendermint.state.ConsensusParamsInfo")
 	proto.RegisterType((*Version)(nil), "tendermint.state.Version")
 	proto.RegisterType((*State)(nil), "tendermint.state.State")
+	proto.RegisterType((*StoreVersion)(nil), "tendermint.state.StoreVersion")
+	proto.RegisterType((*Record)(nil), "tendermint.state.Record")
 }
 
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 763 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0x6e, 0xe8, 0xb6, 0xb6, 0xce, 0xda, 0x0e, 0x8f, 0x43, 0xd6, 0xb1, 0xb4, 0x2b, 0x3f, 0x34,
-	0x71, 0x48, 0xa5, 0x71, 0x40, 0x5c, 0x26, 0x2d, 0x2d, 0x62, 0x95, 0x26, 0x04, 0xd9, 0xb4, 0x03,
-	0x97, 0xc8, 0x6d, 0xbc, 0x24, 0xa2, 0x4d, 0xa2, 0xd8, 0x2d, 0xe3, 0x0f, 0xe0, 0xbe, 0x2b, 0xff,
-	0xd1, 0x8e, 0x3b, 0x22, 0x0e, 0x03, 0xba, 0x7f, 0x04, 0xd9, 0xce, 0x0f, 0xb7, 0x65, 0xd2, 0x10,
-	0x37, 0xfb, 0x7d, 0xdf, 0xfb, 0xfc, 0xf9, 0xf9, 0x3d, 0x19, 0x3c, 0xa6, 0x38, 0x70, 0x70, 0x3c,
-	0xf6, 0x03, 0xda, 0x21, 0x14, 0x51, 0xdc, 0xa1, 0x5f, 0x22, 0x4c, 0x8c, 0x28, 0x0e, 0x69, 0x08,
-	0x37, 0x72, 0xd4, 0xe0, 0x68, 0xe3, 0x91, 0x1b, 0xba, 0x21, 0x07, 0x3b, 0x6c, 0x25, 0x78, 0x8d,
-	0x6d, 0x49, 0x05, 0x0d, 0x86, 0xbe, 0x2c, 0xd2, 0x90, 0x8f, 0xe0, 0xf1, 0x39, 0xb4, 0xb5, 0x84,
-	0x4e, 0xd1, 0xc8, 0x77, 0x10, 0x0d, 0xe3, 0x84, 0xb1, 0xb3, 0xc4, 0x88, 0x50, 0x8c, 0xc6, 0xa9,
-	0x80, 0x2e, 0xc1, 0x53, 0x1c, 0x13, 0x3f, 0x0c, 0xe6, 0x0e, 0x68, 0xba, 0x61, 0xe8, 0x8e, 0x70,
-	0x87, 0xef, 0x06, 0x93, 0xf3, 0x0e, 0xf5, 0xc7, 0x98, 0x50, 0x34, 0x8e, 0x04, 0xa1, 0xfd, 0x43,
-	0x01, 0xd5, 0x43, 0xb3, 0xdb, 0xb7, 0x30, 0x89, 0xc2, 0x80, 0x60, 0x02, 0xbb, 0x40, 0x75, 0xf0,
-	0xc8, 0x9f, 0xe2, 0xd8, 0xa6, 0x17, 0x44, 0x53, 0x5a, 0xc5, 0x3d, 0x75, 0xbf, 0x6d, 0x48, 0xc5,
-	0x60, 0x97, 0x34, 0xd2, 0x84, 0x9e, 0xe0, 0x9e, 0x5e, 0x58, 0xc0, 0x49, 0x97, 0x04, 0x1e, 0x80,
-	0x0a, 0x0e, 0x1c, 0x7b, 0x30, 0x0a, 0x87, 0x9f, 0xb4, 0x07, 0x2d, 0x65, 0x4f, 0xdd, 0xdf, 0xbd,
-	0x53, 0xe2, 0x4d, 0xe0, 0x98, 0x8c, 0x68, 0x95, 0x71, 0xb2, 0x82, 0x3d, 0xa0, 0x0e, 0xb0, 0xeb,
-	0x07, 0x89, 0x42, 0x91, 0x2b, 0x3c, 0xb9, 0x53, 0xc1, 0x64, 0x5c, 0xa1, 0x01, 0x06, 0xd9, 0xba,
-	0xfd, 0x55, 0x01, 0xb5, 0xb3, 0xb4, 0xa0, 0xa4, 0x1f, 0x9c, 0x87, 0xb0, 0x0b, 0xaa, 0x59, 0x89,
-	0x6d, 0x82, 0xa9, 0xa6, 0x70, 0x69, 0x5d, 0x96, 0x16, 0x05, 0xcc, 0x12, 0x4f, 0x30, 0xb5, 0xd6,
-	0xa7, 0xd2, 0x0e, 0x1a, 0x60, 0x73, 0x84, 0x08, 0xb5, 0x3d, 0xec, 0xbb, 0x1e, 0xb5, 0x87, 0x1e,
-	0x0a, 0x5c, 0xec, 0xf0, 0x7b, 0x16, 0xad, 0x87, 0x0c, 0x3a, 0xe2, 0x48, 0x57, 0x00, 0xed, 0x6f,
-	0x0a, 0xd8, 0xec, 0x32, 0x9f, 0x01, 0x99, 0x90, 0xf7, 0xfc, 0xfd, 0xb8, 0x19, 0x0b, 0x6c, 0x0c,
-	0xd3, 0xb0, 0x2d, 0xde, 0x35, 0xf1, 0xb3, 0xbb, 0xec, 0x67, 0x41, 0xc0, 0x5c, 0xb9, 0xba, 0x69,
-	0x16, 0xac, 0xfa, 0x70, 0x3e, 0xfc, 0xcf, 0xde, 0x3c, 0x50, 0x3a, 0x13, 0x8d, 0x03, 0x0f, 0x41,
-	0x25, 0x53, 0x4b, 0x7c, 0xec, 0xc8, 0x3e, 0x92, 0x06, 0xcb, 0x9d, 0x24, 0x1e, 0xf2, 0x2c, 0xd8,
-	0x00, 0x65, 0x12, 0x9e, 0xd3, 0xcf, 0x28, 0xc6, 0xfc, 0xc8, 0x8a, 0x95, 0xed, 0xdb, 0xbf, 0xd7,
-	0xc0, 0xea, 0x09, 0x9b, 0x23, 0xf8, 0x1a, 0x94, 0x12, 0xad, 0xe4, 0x98, 0x2d, 0x63, 0x71, 0xd6,
-	0x8c, 0xc4, 0x54, 0x72, 0x44, 0xca, 0x87, 0xcf, 0x41, 0x79, 0xe8, 0x21, 0x3f, 0xb0, 0x7d, 0x71,
-	0xa7, 0x8a, 0xa9, 0xce, 0x6e, 0x9a, 0xa5, 0x2e, 0x8b, 0xf5, 0x7b, 0x56, 0x89, 0x83, 0x7d, 0x07,
-	0x3e, 0x03, 0x35, 0x3f, 0xf0, 0xa9, 0x8f, 0x46, 0x49, 0x25, 0xb4, 0x1a, 0xaf, 0x40, 0x35, 0x89,
-	0x8a, 0x22, 0xc0, 0x17, 0x80, 0x97, 0x44, 0xb4, 0x59, 0xca, 0x2c, 0x72, 0x66, 0x9d, 0x01, 0xbc,
-	0x8f, 0x12, 0xae, 0x05, 0xaa, 0x12, 0xd7, 0x77, 0xb4, 0x95, 0x65, 0xef, 0xe2, 0xa9, 0x78, 0x56,
-	0xbf, 0x67, 0x6e, 0x32, 0xef, 0xb3, 0x9b, 0xa6, 0x7a, 0x9c, 0x4a, 0xf5, 0x7b, 0x96, 0x9a, 0xe9,
-	0xf6, 0x1d, 0x78, 0x0c, 0xea, 0x92, 0x26, 0x1b, 0x4e, 0x6d, 0x95, 0xab, 0x36, 0x0c, 0x31, 0xb9,
-	0x46, 0x3a, 0xb9, 0xc6, 0x69, 0x3a, 0xb9, 0x66, 0x99, 0xc9, 0x5e, 0xfe, 0x6c, 0x2a, 0x56, 0x35,
-	0xd3, 0x62, 0x28, 0x7c, 0x0b, 0xea, 0x01, 0xbe, 0xa0, 0x76, 0xd6, 0xac, 0x44, 0x5b, 0xbb, 0x57,
-	0x7b, 0xd7, 0x58, 0x5a, 0x3e, 0x29, 0xf0, 0x00, 0x00, 0x49, 0xa3, 0x74, 0x2f, 0x0d, 0x29, 0x83,
-	0x19, 0xe1, 0xd7, 0x92, 0x44, 0xca, 0xf7, 0x33, 0xc2, 0xd2, 0x24, 0x23, 0x5d, 0xa0, 0xcb, 0xdd,
-	0x9c, 0xeb, 0x65, 0x8d, 0x5d, 0xe1, 0x8f, 0xb5, 0x9d, 0x37, 0x76, 0x9e, 0x9d, 0xb4, 0xf8, 0x5f,
-	0xc7, 0x0c, 0xfc, 0xe7, 0x98, 0xbd, 0x03, 0x4f, 0xe7, 0xc6, 0x6c, 0x41, 0x3f, 0xb3, 0xa7, 0x72,
-	0x7b, 0x2d, 0x69, 0xee, 0xe6, 0x85, 0x52, 0x8f, 0x69, 0x23, 0xc6, 0x98, 0x4c, 0x46, 0x94, 0xd8,
-	0x1e, 0x22, 0x9e, 0xb6, 0xde, 0x52, 0xf6, 0xd6, 0x45, 0x23, 0x5a, 0x22, 0x7e, 0x84, 0x88, 0x07,
-	0xb7, 0x40, 0x19, 0x45, 0x91, 0xa0, 0x54, 0x39, 0xa5, 0x84, 0xa2, 0x88, 0x41, 0xe6, 0x87, 0xab,
-	0x99, 0xae, 0x5c, 0xcf, 0x74, 0xe5, 0xd7, 0x4c, 0x57, 0x2e, 0x6f, 0xf5, 0xc2, 0xf5, 0xad, 0x5e,
-	0xf8, 0x7e, 0xab, 0x17, 0x3e, 0xbe, 0x72, 0x7d, 0xea, 0x4d, 0x06, 0xc6, 0x30, 0x1c, 0x77, 0xe4,
-	0x3f, 0x25, 0x5f, 0x8a, 0x8f, 0x6d, 0xf1, 0x4b, 0x1c, 0xac, 0xf1, 0xf8, 0xcb, 0x3f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xa5, 0x17, 0xac, 0x23, 0x2d, 0x07, 0x00, 0x00,
+	// 893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x5f, 0x37, 0xdb, 0x4d, 0xf2, 0x9c, 0x6c, 0xb6, 0xb3, 0x1c, 0xdc, 0x94, 0x3a, 0x69, 0xf8,
+	0xa3, 0x05, 0x24, 0x47, 0x2a, 0x07, 0xc4, 0x05, 0xa9, 0x49, 0x80, 0x46, 0x2a, 0x08, 0x66, 0xab,
+	0x1e, 0xb8, 0x58, 0x13, 0x7b, 0x36, 0x36, 0x24, 0xb6, 0xe5, 0x99, 0x2c, 0xcb, 0x07, 0x40, 0xe2,
+	0xd8, 0x8f, 0xd5, 0x63, 0x8f, 0x88, 0xc3, 0x82, 0xb2, 0x9f, 0x03, 0x09, 0xcd, 0x1b, 0xdb, 0x99,
+	0x6c, 0xba, 0x68, 0x11, 0xbd, 0x8d, 0xdf, 0xef, 0xbd, 0xdf, 0xfb, 0xcd, 0x9b, 0xf7, 0x5e, 0x02,
+	0xef, 0x4a, 0x9e, 0x84, 0x3c, 0x5f, 0xc6, 0x89, 0x1c, 0x0a, 0xc9, 0x24, 0x1f, 0xca, 0x5f, 0x32,
+	0x2e, 0xbc, 0x2c, 0x4f, 0x65, 0x4a, 0x8e, 0x36, 0xa8, 0x87, 0x68, 0xf7, 0x9d, 0x79, 0x3a, 0x4f,
+	0x11, 0x1c, 0xaa, 0x93, 0xf6, 0xeb, 0x3e, 0x30, 0x58, 0xd8, 0x2c, 0x88, 0x4d, 0x92, 0xae, 0x99,
+	0x02, 0xed, 0x5b, 0x68, 0x7f, 0x07, 0x3d, 0x67, 0x8b, 0x38, 0x64, 0x32, 0xcd, 0x0b, 0x8f, 0x87,
+	0x3b, 0x1e, 0x19, 0xcb, 0xd9, 0xb2, 0x24, 0x70, 0x0d, 0xf8, 0x9c, 0xe7, 0x22, 0x4e, 0x93, 0xad,
+	0x04, 0xbd, 0x79, 0x9a, 0xce, 0x17, 0x7c, 0x88, 0x5f, 0xb3, 0xd5, 0xd9, 0x50, 0xc6, 0x4b, 0x2e,
+	0x24, 0x5b, 0x66, 0xda, 0x61, 0xf0, 0x87, 0x05, 0xed, 0x27, 0xa3, 0xf1, 0x94, 0x72, 0x91, 0xa5,
+	0x89, 0xe0, 0x82, 0x8c, 0xc1, 0x0e, 0xf9, 0x22, 0x3e, 0xe7, 0xb9, 0x2f, 0x2f, 0x84, 0x63, 0xf5,
+	0x6b, 0x27, 0xf6, 0xe3, 0x81, 0x67, 0x14, 0x43, 0x5d, 0xd2, 0x2b, 0x03, 0x26, 0xda, 0xf7, 0xf9,
+	0x05, 0x85, 0xb0, 0x3c, 0x0a, 0xf2, 0x05, 0x34, 0x79, 0x12, 0xfa, 0xb3, 0x45, 0x1a, 0xfc, 0xe4,
+	0xdc, 0xe9, 0x5b, 0x27, 0xf6, 0xe3, 0x47, 0x37, 0x52, 0x7c, 0x99, 0x84, 0x23, 0xe5, 0x48, 0x1b,
+	0xbc, 0x38, 0x91, 0x09, 0xd8, 0x33, 0x3e, 0x8f, 0x93, 0x82, 0xa1, 0x86, 0x0c, 0xef, 0xdd, 0xc8,
+	0x30, 0x52, 0xbe, 0x9a, 0x03, 0x66, 0xd5, 0x79, 0xf0, 0xab, 0x05, 0x87, 0x2f, 0xca, 0x82, 0x8a,
+	0x69, 0x72, 0x96, 0x92, 0x31, 0xb4, 0xab, 0x12, 0xfb, 0x82, 0x4b, 0xc7, 0x42, 0x6a, 0xd7, 0xa4,
+	0xd6, 0x05, 0xac, 0x02, 0x4f, 0xb9, 0xa4, 0xad, 0x73, 0xe3, 0x8b, 0x78, 0x70, 0xbc, 0x60, 0x42,
+	0xfa, 0x11, 0x8f, 0xe7, 0x91, 0xf4, 0x83, 0x88, 0x25, 0x73, 0x1e, 0xe2, 0x3d, 0x6b, 0xf4, 0x9e,
+	0x82, 0x9e, 0x22, 0x32, 0xd6, 0xc0, 0xe0, 0xb7, 0x3b, 0x70, 0x3c, 0x56, 0x3a, 0x13, 0xb1, 0x12,
+	0xdf, 0xe1, 0xfb, 0xa1, 0x18, 0x0a, 0x47, 0x41, 0x69, 0xf6, 0xf5, 0xbb, 0x3a, 0xd6, 0x6e, 0xb1,
+	0xb4, 0x9e, 0x6b, 0x04, 0xa3, 0xfd, 0x57, 0x97, 0xbd, 0x3d, 0xda, 0x09, 0xb6, 0xcd, 0xff, 0x55,
+	0x1b, 0x59, 0x42, 0x57, 0x04, 0x11, 0x0f, 0x57, 0x0b, 0x1e, 0xfa, 0x3b, 0x6a, 0x6a, 0xf8, 0xfa,
+	0x1f, 0xed, 0x14, 0xfe, 0xb4, 0x0c, 0x79, 0xb3, 0x2a, 0x47, 0xdc, 0x80, 0x0f, 0x22, 0xa8, 0xbf,
+	0xd0, 0x7d, 0x4a, 0x9e, 0x40, 0xb3, 0xca, 0x57, 0x5c, 0xfb, 0xa1, 0x99, 0xa8, 0xe8, 0xe7, 0xcd,
+	0xc5, 0x0b, 0xf2, 0x4d, 0x14, 0xe9, 0x42, 0x43, 0xa4, 0x67, 0xf2, 0x67, 0x96, 0x73, 0xbc, 0x61,
+	0x93, 0x56, 0xdf, 0x83, 0xbf, 0xeb, 0x70, 0xf7, 0x54, 0x8d, 0x2d, 0xf9, 0x1c, 0xea, 0x05, 0x57,
+	0x91, 0xe6, 0xbe, 0x77, 0x7d, 0xb4, 0xbd, 0x42, 0x54, 0x91, 0xa2, 0xf4, 0x27, 0x1f, 0x42, 0x23,
+	0x88, 0x58, 0x9c, 0xf8, 0xb1, 0x2e, 0x61, 0x73, 0x64, 0xaf, 0x2f, 0x7b, 0xf5, 0xb1, 0xb2, 0x4d,
+	0x27, 0xb4, 0x8e, 0xe0, 0x34, 0x24, 0x1f, 0xc0, 0x61, 0x9c, 0xc4, 0x32, 0x66, 0x8b, 0xa2, 0xf0,
+	0xce, 0x21, 0x16, 0xbc, 0x5d, 0x58, 0x75, 0xcd, 0xc9, 0xc7, 0x80, 0x2f, 0xa0, 0xbb, 0xba, 0xf4,
+	0xac, 0xa1, 0x67, 0x47, 0x01, 0xd8, 0xb6, 0x85, 0x2f, 0x85, 0xb6, 0xe1, 0x1b, 0x87, 0xce, 0xfe,
+	0xae, 0x76, 0xdd, 0x19, 0x18, 0x35, 0x9d, 0x8c, 0x8e, 0x95, 0xf6, 0xf5, 0x65, 0xcf, 0x7e, 0x56,
+	0x52, 0x4d, 0x27, 0xd4, 0xae, 0x78, 0xa7, 0x21, 0x79, 0x06, 0x1d, 0x83, 0x53, 0xed, 0x02, 0xe7,
+	0x2e, 0xb2, 0x76, 0x3d, 0xbd, 0x28, 0xbc, 0x72, 0x51, 0x78, 0xcf, 0xcb, 0x45, 0x31, 0x6a, 0x28,
+	0xda, 0x97, 0x7f, 0xf6, 0x2c, 0xda, 0xae, 0xb8, 0x14, 0x4a, 0xbe, 0x86, 0x4e, 0xc2, 0x2f, 0xa4,
+	0x5f, 0xcd, 0x86, 0x70, 0x0e, 0x6e, 0x35, 0x4d, 0x87, 0x2a, 0xac, 0xb2, 0xa8, 0x6d, 0x01, 0x06,
+	0x47, 0xfd, 0x56, 0x1c, 0x46, 0x84, 0x12, 0x82, 0xd7, 0x32, 0x48, 0x1a, 0xb7, 0x13, 0xa2, 0xc2,
+	0x0c, 0x21, 0x63, 0x70, 0xcd, 0xe1, 0xd9, 0xf0, 0x55, 0x73, 0xd4, 0xc4, 0xc7, 0x7a, 0xb0, 0x99,
+	0xa3, 0x4d, 0x74, 0x39, 0x51, 0x6f, 0x9a, 0x6a, 0xf8, 0x9f, 0x53, 0xfd, 0x2d, 0xbc, 0xbf, 0x35,
+	0xd5, 0xd7, 0xf8, 0x2b, 0x79, 0x36, 0xca, 0xeb, 0x1b, 0x63, 0xbe, 0x4d, 0x54, 0x6a, 0x2c, 0x1b,
+	0x31, 0xe7, 0x62, 0xb5, 0x90, 0xc2, 0x8f, 0x98, 0x88, 0x9c, 0x56, 0xdf, 0x3a, 0x69, 0xe9, 0x46,
+	0xa4, 0xda, 0xfe, 0x94, 0x89, 0x88, 0xdc, 0x87, 0x06, 0xcb, 0x32, 0xed, 0xd2, 0x46, 0x97, 0x3a,
+	0xcb, 0x32, 0x84, 0xfe, 0x7d, 0x79, 0x74, 0xde, 0xf2, 0xf2, 0x20, 0x9f, 0xc0, 0xbd, 0x1f, 0x59,
+	0xac, 0x72, 0x19, 0x2f, 0x7d, 0xd4, 0xaf, 0x9d, 0xb4, 0xe8, 0x91, 0x06, 0x36, 0xaf, 0x31, 0xf8,
+	0x06, 0x5a, 0xa7, 0x32, 0xcd, 0x79, 0xb9, 0x6e, 0xcc, 0x5d, 0x61, 0x6d, 0xef, 0x0a, 0xf2, 0x08,
+	0x5a, 0xb8, 0x06, 0xfc, 0xb3, 0x34, 0x5f, 0x32, 0x89, 0xa3, 0xbe, 0x4f, 0x6d, 0xb4, 0x7d, 0x85,
+	0xa6, 0xc1, 0x18, 0x0e, 0x28, 0x0f, 0xd2, 0x1c, 0x67, 0x5d, 0x29, 0x5c, 0x32, 0xdf, 0xdc, 0x2a,
+	0x6d, 0xda, 0xd6, 0xd6, 0x32, 0x1f, 0x81, 0xfd, 0x90, 0x49, 0x86, 0x5c, 0x2d, 0x8a, 0xe7, 0xd1,
+	0xf7, 0xaf, 0xd6, 0xae, 0xf5, 0x7a, 0xed, 0x5a, 0x7f, 0xad, 0x5d, 0xeb, 0xe5, 0x95, 0xbb, 0xf7,
+	0xfa, 0xca, 0xdd, 0xfb, 0xfd, 0xca, 0xdd, 0xfb, 0xe1, 0xb3, 0x79, 0x2c, 0xa3, 0xd5, 0xcc, 0x0b,
+	0xd2, 0xe5, 0xd0, 0xfc, 0xc9, 0xdf, 0x1c, 0xf5, 0xff, 0x8e, 0xeb, 0xff, 0x58, 0x66, 0x07, 0x68,
+	0xff, 0xf4, 0x9f, 0x01, 0x00, 0xf9, 0x95, 0x63, 0x75, 0xcc, 0x08, 0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -594,6 +740,20 @@ func (m *ConsensusParamsInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ScheduledConsensusParams) > 0 {
+		for iNdEx := len(m.ScheduledConsensusParams) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScheduledConsensusParams[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if m.LastHeightChanged != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.LastHeightChanged))
 		i--
@@ -672,6 +832,31 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.JailedValidators) > 0 {
+		for iNdEx := len(m.JailedValidators) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.JailedValidators[iNdEx])
+			copy(dAtA[i:], m.JailedValidators[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.JailedValidators[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.ScheduledConsensusParams) > 0 {
+		for iNdEx := len(m.ScheduledConsensusParams) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScheduledConsensusParams[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -790,6 +975,76 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StoreVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StateFormat != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StateFormat))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Software) > 0 {
+		i -= len(m.Software)
+		copy(dAtA[i:], m.Software)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Software)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Record) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Record) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SchemaVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SchemaVersion))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -851,6 +1106,12 @@ func (m *ConsensusParamsInfo) Size() (n int) {
 	if m.LastHeightChanged != 0 {
 		n += 1 + sovTypes(uint64(m.LastHeightChanged))
 	}
+	if len(m.ScheduledConsensusParams) > 0 {
+		for _, e := range m.ScheduledConsensusParams {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -919,6 +1180,50 @@ func (m *State) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if len(m.ScheduledConsensusParams) > 0 {
+		for _, e := range m.ScheduledConsensusParams {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.JailedValidators) > 0 {
+		for _, b := range m.JailedValidators {
+			l = len(b)
+			n += 2 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StoreVersion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Software)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.StateFormat != 0 {
+		n += 1 + sovTypes(uint64(m.StateFormat))
+	}
+	return n
+}
+
+func (m *Record) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SchemaVersion != 0 {
+		n += 1 + sovTypes(uint64(m.SchemaVersion))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1270,6 +1575,40 @@ func (m *ConsensusParamsInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduledConsensusParams = append(m.ScheduledConsensusParams, types.ScheduledConsensusParams{})
+			if err := m.ScheduledConsensusParams[len(m.ScheduledConsensusParams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1851,6 +2190,276 @@ func (m *State) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduledConsensusParams = append(m.ScheduledConsensusParams, types.ScheduledConsensusParams{})
+			if err := m.ScheduledConsensusParams[len(m.ScheduledConsensusParams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JailedValidators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JailedValidators = append(m.JailedValidators, make([]byte, postIndex-iNdEx))
+			copy(m.JailedValidators[len(m.JailedValidators)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Software", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Software = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateFormat", wireType)
+			}
+			m.StateFormat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StateFormat |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Record) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaVersion", wireType)
+			}
+			m.SchemaVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchemaVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
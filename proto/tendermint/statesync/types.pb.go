@@ -22,6 +22,31 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type ChunkCompression int32
+
+const (
+	ChunkCompression_CHUNK_COMPRESSION_NONE ChunkCompression = 0
+	ChunkCompression_CHUNK_COMPRESSION_GZIP ChunkCompression = 1
+)
+
+var ChunkCompression_name = map[int32]string{
+	0: "CHUNK_COMPRESSION_NONE",
+	1: "CHUNK_COMPRESSION_GZIP",
+}
+
+var ChunkCompression_value = map[string]int32{
+	"CHUNK_COMPRESSION_NONE": 0,
+	"CHUNK_COMPRESSION_GZIP": 1,
+}
+
+func (x ChunkCompression) String() string {
+	return proto.EnumName(ChunkCompression_name, int32(x))
+}
+
+func (ChunkCompression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a1c2869546ca7914, []int{0}
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_SnapshotsRequest
@@ -170,11 +195,13 @@ func (m *SnapshotsRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_SnapshotsRequest proto.InternalMessageInfo
 
 type SnapshotsResponse struct {
-	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
-	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
-	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Height      uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format      uint32   `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks      uint32   `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash        []byte   `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata    []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ChunkHashes [][]byte `protobuf:"bytes,6,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	BaseHeight  uint64   `protobuf:"varint,7,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *SnapshotsResponse) Reset()         { *m = SnapshotsResponse{} }
@@ -245,10 +272,28 @@ func (m *SnapshotsResponse) GetMetadata() []byte {
 	return nil
 }
 
+func (m *SnapshotsResponse) GetChunkHashes() [][]byte {
+	if m != nil {
+		return m.ChunkHashes
+	}
+	return nil
+}
+
+func (m *SnapshotsResponse) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
 type ChunkRequest struct {
-	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Index  uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Height       uint64           `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format       uint32           `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Index        uint32           `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Compression  ChunkCompression `protobuf:"varint,4,opt,name=compression,proto3,enum=tendermint.statesync.ChunkCompression" json:"compression,omitempty"`
+	MaxChunkSize uint32           `protobuf:"varint,5,opt,name=max_chunk_size,json=maxChunkSize,proto3" json:"max_chunk_size,omitempty"`
+	WindowSize   uint32           `protobuf:"varint,6,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	BaseHeight   uint64           `protobuf:"varint,7,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *ChunkRequest) Reset()         { *m = ChunkRequest{} }
@@ -305,12 +350,44 @@ func (m *ChunkRequest) GetIndex() uint32 {
 	return 0
 }
 
+func (m *ChunkRequest) GetCompression() ChunkCompression {
+	if m != nil {
+		return m.Compression
+	}
+	return ChunkCompression_CHUNK_COMPRESSION_NONE
+}
+
+func (m *ChunkRequest) GetMaxChunkSize() uint32 {
+	if m != nil {
+		return m.MaxChunkSize
+	}
+	return 0
+}
+
+func (m *ChunkRequest) GetWindowSize() uint32 {
+	if m != nil {
+		return m.WindowSize
+	}
+	return 0
+}
+
+func (m *ChunkRequest) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
 type ChunkResponse struct {
-	Height  uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format  uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Index   uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
-	Chunk   []byte `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
-	Missing bool   `protobuf:"varint,5,opt,name=missing,proto3" json:"missing,omitempty"`
+	Height      uint64           `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format      uint32           `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Index       uint32           `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk       []byte           `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Missing     bool             `protobuf:"varint,5,opt,name=missing,proto3" json:"missing,omitempty"`
+	Compression ChunkCompression `protobuf:"varint,6,opt,name=compression,proto3,enum=tendermint.statesync.ChunkCompression" json:"compression,omitempty"`
+	Offset      uint64           `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	ChunkSize   uint64           `protobuf:"varint,8,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	BaseHeight  uint64           `protobuf:"varint,9,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *ChunkResponse) Reset()         { *m = ChunkResponse{} }
@@ -381,7 +458,36 @@ func (m *ChunkResponse) GetMissing() bool {
 	return false
 }
 
+func (m *ChunkResponse) GetCompression() ChunkCompression {
+	if m != nil {
+		return m.Compression
+	}
+	return ChunkCompression_CHUNK_COMPRESSION_NONE
+}
+
+func (m *ChunkResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ChunkResponse) GetChunkSize() uint64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *ChunkResponse) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("tendermint.statesync.ChunkCompression", ChunkCompression_name, ChunkCompression_value)
 	proto.RegisterType((*Message)(nil), "tendermint.statesync.Message")
 	proto.RegisterType((*SnapshotsRequest)(nil), "tendermint.statesync.SnapshotsRequest")
 	proto.RegisterType((*SnapshotsResponse)(nil), "tendermint.statesync.SnapshotsResponse")
@@ -392,32 +498,43 @@ func init() {
 func init() { proto.RegisterFile("tendermint/statesync/types.proto", fileDescriptor_a1c2869546ca7914) }
 
 var fileDescriptor_a1c2869546ca7914 = []byte{
-	// 393 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6a, 0xdb, 0x40,
-	0x18, 0x94, 0xfc, 0xcf, 0x57, 0xab, 0xd8, 0x8b, 0x29, 0xa2, 0x07, 0x61, 0x54, 0x68, 0x7b, 0x92,
-	0xa0, 0x3d, 0xf6, 0xe6, 0x5e, 0x5c, 0x68, 0x2f, 0xdb, 0x18, 0x42, 0x2e, 0x61, 0x2d, 0x6f, 0x24,
-	0x11, 0xb4, 0x52, 0xf4, 0xad, 0x20, 0x7e, 0x80, 0x9c, 0x72, 0xc9, 0x63, 0xe5, 0xe8, 0x63, 0xc8,
-	0x29, 0xd8, 0x2f, 0x12, 0xb4, 0x92, 0x65, 0xc5, 0x31, 0x09, 0x81, 0xdc, 0x76, 0xc6, 0xe3, 0xd1,
-	0xcc, 0xc0, 0x07, 0x63, 0xc9, 0xc5, 0x82, 0xa7, 0x51, 0x28, 0xa4, 0x8b, 0x92, 0x49, 0x8e, 0x4b,
-	0xe1, 0xb9, 0x72, 0x99, 0x70, 0x74, 0x92, 0x34, 0x96, 0x31, 0x19, 0xed, 0x14, 0x4e, 0xa5, 0xb0,
-	0xef, 0x1b, 0xd0, 0xfd, 0xc7, 0x11, 0x99, 0xcf, 0xc9, 0x0c, 0x86, 0x28, 0x58, 0x82, 0x41, 0x2c,
-	0xf1, 0x34, 0xe5, 0x17, 0x19, 0x47, 0x69, 0xea, 0x63, 0xfd, 0xfb, 0x87, 0x1f, 0x5f, 0x9d, 0x43,
-	0xff, 0x76, 0xfe, 0x6f, 0xe5, 0xb4, 0x50, 0x4f, 0x35, 0x3a, 0xc0, 0x3d, 0x8e, 0x1c, 0x03, 0xa9,
-	0xdb, 0x62, 0x12, 0x0b, 0xe4, 0x66, 0x43, 0xf9, 0x7e, 0x7b, 0xd5, 0xb7, 0x90, 0x4f, 0x35, 0x3a,
-	0xc4, 0x7d, 0x92, 0xfc, 0x01, 0xc3, 0x0b, 0x32, 0x71, 0x5e, 0x85, 0x6d, 0x2a, 0x53, 0xfb, 0xb0,
-	0xe9, 0xef, 0x5c, 0xba, 0x0b, 0xda, 0xf7, 0x6a, 0x98, 0xfc, 0x85, 0x8f, 0x5b, 0xab, 0x32, 0x60,
-	0x4b, 0x79, 0x7d, 0x79, 0xd1, 0xab, 0x0a, 0x67, 0x78, 0x75, 0x62, 0xd2, 0x86, 0x26, 0x66, 0x91,
-	0x4d, 0x60, 0xb0, 0xbf, 0x90, 0x7d, 0xad, 0xc3, 0xf0, 0x59, 0x3d, 0xf2, 0x09, 0x3a, 0x01, 0x0f,
-	0xfd, 0xa0, 0xd8, 0xbb, 0x45, 0x4b, 0x94, 0xf3, 0x67, 0x71, 0x1a, 0x31, 0xa9, 0xf6, 0x32, 0x68,
-	0x89, 0x72, 0x5e, 0x7d, 0x11, 0x55, 0x65, 0x83, 0x96, 0x88, 0x10, 0x68, 0x05, 0x0c, 0x03, 0x15,
-	0xbe, 0x4f, 0xd5, 0x9b, 0x7c, 0x86, 0x5e, 0xc4, 0x25, 0x5b, 0x30, 0xc9, 0xcc, 0xb6, 0xe2, 0x2b,
-	0x6c, 0x1f, 0x41, 0xbf, 0x3e, 0xcb, 0x9b, 0x73, 0x8c, 0xa0, 0x1d, 0x8a, 0x05, 0xbf, 0x2c, 0x63,
-	0x14, 0xc0, 0xbe, 0xd2, 0xc1, 0x78, 0xb2, 0xd0, 0xfb, 0xf8, 0xe6, 0xac, 0xea, 0x59, 0xd6, 0x2b,
-	0x00, 0x31, 0xa1, 0x1b, 0x85, 0x88, 0xa1, 0xf0, 0x55, 0xbd, 0x1e, 0xdd, 0xc2, 0xc9, 0xec, 0x76,
-	0x6d, 0xe9, 0xab, 0xb5, 0xa5, 0x3f, 0xac, 0x2d, 0xfd, 0x66, 0x63, 0x69, 0xab, 0x8d, 0xa5, 0xdd,
-	0x6d, 0x2c, 0xed, 0xe4, 0x97, 0x1f, 0xca, 0x20, 0x9b, 0x3b, 0x5e, 0x1c, 0xb9, 0xb5, 0xcb, 0xa9,
-	0x3d, 0xd5, 0xd1, 0xb8, 0x87, 0xae, 0x6a, 0xde, 0x51, 0xbf, 0xfd, 0x7c, 0x0c, 0x00, 0x00, 0xff,
-	0xff, 0xcc, 0x16, 0xc2, 0x8b, 0x74, 0x03, 0x00, 0x00,
+	// 569 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x51, 0x6f, 0xd2, 0x50,
+	0x18, 0x6d, 0xd9, 0xe8, 0xb6, 0x0f, 0xba, 0xb0, 0x1b, 0x42, 0xc8, 0x12, 0x2b, 0xa2, 0xd1, 0xc5,
+	0x07, 0x48, 0xf4, 0xd1, 0xb7, 0x91, 0xc5, 0xa2, 0x0e, 0x96, 0x4b, 0x48, 0xcc, 0x5e, 0x9a, 0x52,
+	0x2e, 0xb4, 0x31, 0x6d, 0x91, 0xef, 0x92, 0xb1, 0xfd, 0x00, 0x9f, 0xfd, 0x17, 0xfe, 0x15, 0xdf,
+	0xdc, 0xa3, 0xf1, 0xc9, 0xc0, 0x1f, 0x31, 0xfd, 0x5a, 0xa0, 0x56, 0x9c, 0xd1, 0xf8, 0xd6, 0x73,
+	0xee, 0xb9, 0xdf, 0x3d, 0xe7, 0xf4, 0xe6, 0x42, 0x4d, 0x8a, 0x60, 0x28, 0xa6, 0xbe, 0x17, 0xc8,
+	0x26, 0x4a, 0x5b, 0x0a, 0xbc, 0x0e, 0x9c, 0xa6, 0xbc, 0x9e, 0x08, 0x6c, 0x4c, 0xa6, 0xa1, 0x0c,
+	0x59, 0x79, 0xa3, 0x68, 0xac, 0x15, 0xf5, 0x6f, 0x39, 0xd8, 0x3b, 0x17, 0x88, 0xf6, 0x58, 0xb0,
+	0x3e, 0x1c, 0x61, 0x60, 0x4f, 0xd0, 0x0d, 0x25, 0x5a, 0x53, 0xf1, 0x7e, 0x26, 0x50, 0x56, 0xd5,
+	0x9a, 0x7a, 0x52, 0x78, 0xf6, 0xb8, 0xb1, 0x6d, 0x77, 0xa3, 0xb7, 0x92, 0xf3, 0x58, 0x6d, 0x2a,
+	0xbc, 0x84, 0x19, 0x8e, 0xbd, 0x05, 0x96, 0x1e, 0x8b, 0x93, 0x30, 0x40, 0x51, 0xcd, 0xd1, 0xdc,
+	0x27, 0x7f, 0x9c, 0x1b, 0xcb, 0x4d, 0x85, 0x1f, 0x61, 0x96, 0x64, 0x6d, 0xd0, 0x1d, 0x77, 0x16,
+	0xbc, 0x5b, 0x9b, 0xdd, 0xa1, 0xa1, 0xf5, 0xed, 0x43, 0x5b, 0x91, 0x74, 0x63, 0xb4, 0xe8, 0xa4,
+	0x30, 0x7b, 0x03, 0x87, 0xab, 0x51, 0x89, 0xc1, 0x5d, 0x9a, 0xf5, 0xf0, 0xce, 0x59, 0x6b, 0x73,
+	0xba, 0x93, 0x26, 0x4e, 0xf3, 0xb0, 0x83, 0x33, 0xbf, 0xce, 0xa0, 0x94, 0x6d, 0xa8, 0xfe, 0x45,
+	0x85, 0xa3, 0x5f, 0xe2, 0xb1, 0x0a, 0x68, 0xae, 0xf0, 0xc6, 0x6e, 0xdc, 0xf7, 0x2e, 0x4f, 0x50,
+	0xc4, 0x8f, 0xc2, 0xa9, 0x6f, 0x4b, 0xea, 0x4b, 0xe7, 0x09, 0x8a, 0x78, 0x3a, 0x11, 0x29, 0xb2,
+	0xce, 0x13, 0xc4, 0x18, 0xec, 0xba, 0x36, 0xba, 0x64, 0xbe, 0xc8, 0xe9, 0x9b, 0x1d, 0xc3, 0xbe,
+	0x2f, 0xa4, 0x3d, 0xb4, 0xa5, 0x5d, 0xcd, 0x13, 0xbf, 0xc6, 0xec, 0x01, 0xc4, 0x35, 0x58, 0x91,
+	0x52, 0x60, 0x55, 0xab, 0xed, 0x9c, 0x14, 0x79, 0x81, 0x38, 0x93, 0x28, 0x76, 0x1f, 0x0a, 0x03,
+	0x1b, 0x85, 0x95, 0xf8, 0xdb, 0x23, 0x7f, 0x10, 0x51, 0x26, 0x31, 0xf5, 0x0f, 0x39, 0x28, 0xa6,
+	0xbb, 0xfd, 0xeb, 0x30, 0x65, 0xc8, 0x7b, 0xc1, 0x50, 0xcc, 0x93, 0x2c, 0x31, 0x60, 0x26, 0x14,
+	0x9c, 0xd0, 0x9f, 0x4c, 0x05, 0xa2, 0x17, 0x06, 0x94, 0xe8, 0xf0, 0x77, 0xf7, 0x90, 0x8e, 0x6f,
+	0x6d, 0xd4, 0x3c, 0xbd, 0x95, 0x3d, 0x82, 0x43, 0xdf, 0x9e, 0x5b, 0x71, 0x50, 0xf4, 0x6e, 0x04,
+	0xd5, 0xa0, 0xf3, 0xa2, 0x6f, 0xcf, 0x69, 0x67, 0xcf, 0xbb, 0x11, 0x51, 0xce, 0x2b, 0x2f, 0x18,
+	0x86, 0x57, 0xb1, 0x44, 0x23, 0x09, 0xc4, 0xd4, 0x4a, 0x70, 0x77, 0x11, 0x9f, 0x72, 0xa0, 0xff,
+	0x74, 0x31, 0xfe, 0x53, 0x13, 0x65, 0xc8, 0x93, 0xf7, 0xe4, 0xaf, 0xc6, 0x80, 0x55, 0x61, 0xcf,
+	0xf7, 0x10, 0xbd, 0x60, 0x4c, 0x71, 0xf6, 0xf9, 0x0a, 0x66, 0x9b, 0xd3, 0xfe, 0xbd, 0xb9, 0x0a,
+	0x68, 0xe1, 0x68, 0x84, 0x62, 0x95, 0x36, 0x41, 0xec, 0x1e, 0x40, 0xaa, 0xcd, 0x7d, 0x5a, 0x3b,
+	0x70, 0xd2, 0x55, 0xa6, 0x9b, 0x3a, 0xc8, 0x36, 0xf5, 0xf4, 0x15, 0x94, 0xb2, 0x07, 0xb3, 0x63,
+	0xa8, 0xb4, 0xcc, 0x7e, 0xe7, 0xb5, 0xd5, 0xea, 0x9e, 0x5f, 0xf0, 0xb3, 0x5e, 0xaf, 0xdd, 0xed,
+	0x58, 0x9d, 0x6e, 0xe7, 0xac, 0xa4, 0x6c, 0x5f, 0x7b, 0x79, 0xd9, 0xbe, 0x28, 0xa9, 0xa7, 0xfd,
+	0xcf, 0x0b, 0x43, 0xbd, 0x5d, 0x18, 0xea, 0xf7, 0x85, 0xa1, 0x7e, 0x5c, 0x1a, 0xca, 0xed, 0xd2,
+	0x50, 0xbe, 0x2e, 0x0d, 0xe5, 0xf2, 0xc5, 0xd8, 0x93, 0xee, 0x6c, 0xd0, 0x70, 0x42, 0xbf, 0x99,
+	0x7a, 0x1e, 0x53, 0x9f, 0xf4, 0x32, 0x36, 0xb7, 0x3d, 0x9d, 0x03, 0x8d, 0xd6, 0x9e, 0xff, 0x18,
+	0x00, 0xfc, 0x4f, 0xa0, 0x31, 0x59, 0x05, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -579,6 +696,20 @@ func (m *SnapshotsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.ChunkHashes) > 0 {
+		for iNdEx := len(m.ChunkHashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ChunkHashes[iNdEx])
+			copy(dAtA[i:], m.ChunkHashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ChunkHashes[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Metadata) > 0 {
 		i -= len(m.Metadata)
 		copy(dAtA[i:], m.Metadata)
@@ -631,6 +762,26 @@ func (m *ChunkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.WindowSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.WindowSize))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxChunkSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxChunkSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Compression != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Index != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
 		i--
@@ -669,6 +820,26 @@ func (m *ChunkResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.ChunkSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChunkSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Offset != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Compression != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.Missing {
 		i--
 		if m.Missing {
@@ -807,6 +978,15 @@ func (m *SnapshotsResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.ChunkHashes) > 0 {
+		for _, b := range m.ChunkHashes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
 	return n
 }
 
@@ -825,6 +1005,18 @@ func (m *ChunkRequest) Size() (n int) {
 	if m.Index != 0 {
 		n += 1 + sovTypes(uint64(m.Index))
 	}
+	if m.Compression != 0 {
+		n += 1 + sovTypes(uint64(m.Compression))
+	}
+	if m.MaxChunkSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxChunkSize))
+	}
+	if m.WindowSize != 0 {
+		n += 1 + sovTypes(uint64(m.WindowSize))
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
 	return n
 }
 
@@ -850,6 +1042,18 @@ func (m *ChunkResponse) Size() (n int) {
 	if m.Missing {
 		n += 2
 	}
+	if m.Compression != 0 {
+		n += 1 + sovTypes(uint64(m.Compression))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovTypes(uint64(m.Offset))
+	}
+	if m.ChunkSize != 0 {
+		n += 1 + sovTypes(uint64(m.ChunkSize))
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
 	return n
 }
 
@@ -1253,6 +1457,57 @@ func (m *SnapshotsResponse) Unmarshal(dAtA []byte) error {
 				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkHashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChunkHashes = append(m.ChunkHashes, make([]byte, postIndex-iNdEx))
+			copy(m.ChunkHashes[len(m.ChunkHashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1360,6 +1615,82 @@ func (m *ChunkRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= ChunkCompression(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxChunkSize", wireType)
+			}
+			m.MaxChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxChunkSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowSize", wireType)
+			}
+			m.WindowSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1521,6 +1852,82 @@ func (m *ChunkResponse) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Missing = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= ChunkCompression(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+			}
+			m.ChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -105,6 +105,9 @@ type BlockParams struct {
 	// Max gas per block.
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	// Size of each block part, in bytes.
+	// Note: zero means the default block part size (65536 bytes)
+	PartSizeBytes uint32 `protobuf:"varint,3,opt,name=part_size_bytes,json=partSizeBytes,proto3" json:"part_size_bytes,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
@@ -154,6 +157,13 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetPartSizeBytes() uint32 {
+	if m != nil {
+		return m.PartSizeBytes
+	}
+	return 0
+}
+
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	// Max age of evidence, in blocks.
@@ -322,8 +332,9 @@ func (m *VersionParams) GetAppVersion() uint64 {
 //
 // It is hashed into the Header.ConsensusHash.
 type HashedParams struct {
-	BlockMaxBytes int64 `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
-	BlockMaxGas   int64 `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+	BlockMaxBytes      int64  `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
+	BlockMaxGas        int64  `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+	BlockPartSizeBytes uint32 `protobuf:"varint,3,opt,name=block_part_size_bytes,json=blockPartSizeBytes,proto3" json:"block_part_size_bytes,omitempty"`
 }
 
 func (m *HashedParams) Reset()         { *m = HashedParams{} }
@@ -373,6 +384,13 @@ func (m *HashedParams) GetBlockMaxGas() int64 {
 	return 0
 }
 
+func (m *HashedParams) GetBlockPartSizeBytes() uint32 {
+	if m != nil {
+		return m.BlockPartSizeBytes
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.types.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.types.BlockParams")
@@ -385,39 +403,41 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 498 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xc1, 0x6a, 0xd4, 0x40,
-	0x1c, 0xc6, 0x77, 0x9a, 0xda, 0xee, 0xfe, 0xe3, 0x76, 0xcb, 0x20, 0x18, 0x2b, 0xcd, 0xae, 0x39,
-	0x48, 0x41, 0x48, 0xc4, 0x22, 0x22, 0x08, 0xe2, 0x56, 0xa9, 0x20, 0x15, 0x09, 0xea, 0xa1, 0x97,
-	0x30, 0xd9, 0x8c, 0x69, 0xe8, 0x4e, 0x66, 0xc8, 0x24, 0xcb, 0xee, 0xcd, 0x47, 0xf0, 0xe8, 0x23,
-	0xe8, 0x9b, 0xf4, 0xd8, 0xa3, 0x27, 0x95, 0xdd, 0x17, 0x91, 0x4c, 0x32, 0xa6, 0x9b, 0xf6, 0x36,
-	0x33, 0xdf, 0xef, 0x9b, 0xe1, 0xfb, 0x86, 0x3f, 0xec, 0xe7, 0x34, 0x8d, 0x68, 0xc6, 0x92, 0x34,
-	0xf7, 0xf2, 0x85, 0xa0, 0xd2, 0x13, 0x24, 0x23, 0x4c, 0xba, 0x22, 0xe3, 0x39, 0xc7, 0xbb, 0x8d,
-	0xec, 0x2a, 0x79, 0xef, 0x4e, 0xcc, 0x63, 0xae, 0x44, 0xaf, 0x5c, 0x55, 0xdc, 0x9e, 0x1d, 0x73,
-	0x1e, 0x4f, 0xa9, 0xa7, 0x76, 0x61, 0xf1, 0xc5, 0x8b, 0x8a, 0x8c, 0xe4, 0x09, 0x4f, 0x2b, 0xdd,
-	0xf9, 0xba, 0x01, 0x83, 0x23, 0x9e, 0x4a, 0x9a, 0xca, 0x42, 0x7e, 0x50, 0x2f, 0xe0, 0x43, 0xb8,
-	0x15, 0x4e, 0xf9, 0xe4, 0xdc, 0x42, 0x23, 0x74, 0x60, 0x3e, 0xd9, 0x77, 0xdb, 0x6f, 0xb9, 0xe3,
-	0x52, 0xae, 0x68, 0xbf, 0x62, 0xf1, 0x0b, 0xe8, 0xd2, 0x59, 0x12, 0xd1, 0x74, 0x42, 0xad, 0x0d,
-	0xe5, 0x1b, 0x5d, 0xf7, 0xbd, 0xa9, 0x89, 0xda, 0xfa, 0xdf, 0x81, 0x5f, 0x42, 0x6f, 0x46, 0xa6,
-	0x49, 0x44, 0x72, 0x9e, 0x59, 0x86, 0xb2, 0x3f, 0xb8, 0x6e, 0xff, 0xac, 0x91, 0xda, 0xdf, 0x78,
-	0xf0, 0x73, 0xd8, 0x9e, 0xd1, 0x4c, 0x26, 0x3c, 0xb5, 0x36, 0x95, 0x7d, 0x78, 0x83, 0xbd, 0x02,
-	0x6a, 0xb3, 0xe6, 0x9d, 0x23, 0x30, 0xaf, 0xe4, 0xc1, 0xf7, 0xa1, 0xc7, 0xc8, 0x3c, 0x08, 0x17,
-	0x39, 0x95, 0xaa, 0x01, 0xc3, 0xef, 0x32, 0x32, 0x1f, 0x97, 0x7b, 0x7c, 0x17, 0xb6, 0x4b, 0x31,
-	0x26, 0x52, 0x85, 0x34, 0xfc, 0x2d, 0x46, 0xe6, 0xc7, 0x44, 0x3a, 0x3f, 0x11, 0xec, 0xac, 0xa7,
-	0xc3, 0x8f, 0x00, 0x97, 0x2c, 0x89, 0x69, 0x90, 0x16, 0x2c, 0x50, 0x35, 0xe9, 0x1b, 0x07, 0x8c,
-	0xcc, 0x5f, 0xc5, 0xf4, 0x7d, 0xc1, 0xd4, 0xd3, 0x12, 0x9f, 0xc0, 0xae, 0x86, 0xf5, 0x0f, 0xd5,
-	0x35, 0xde, 0x73, 0xab, 0x2f, 0x74, 0xf5, 0x17, 0xba, 0xaf, 0x6b, 0x60, 0xdc, 0xbd, 0xf8, 0x3d,
-	0xec, 0x7c, 0xff, 0x33, 0x44, 0xfe, 0x4e, 0x75, 0x9f, 0x56, 0xd6, 0x43, 0x18, 0xeb, 0x21, 0x9c,
-	0xa7, 0x30, 0x68, 0x35, 0x89, 0x1d, 0xe8, 0x8b, 0x22, 0x0c, 0xce, 0xe9, 0x22, 0x50, 0x5d, 0x59,
-	0x68, 0x64, 0x1c, 0xf4, 0x7c, 0x53, 0x14, 0xe1, 0x3b, 0xba, 0xf8, 0x58, 0x1e, 0x39, 0x8f, 0xa1,
-	0xbf, 0xd6, 0x20, 0x1e, 0x82, 0x49, 0x84, 0x08, 0x74, 0xef, 0x65, 0xb2, 0x4d, 0x1f, 0x88, 0x10,
-	0x35, 0xe6, 0x9c, 0xc2, 0xed, 0xb7, 0x44, 0x9e, 0xd1, 0xa8, 0x36, 0x3c, 0x84, 0x81, 0x6a, 0x21,
-	0x68, 0x17, 0xdc, 0x57, 0xc7, 0x27, 0xba, 0x65, 0x07, 0xfa, 0x0d, 0xd7, 0x74, 0x6d, 0x6a, 0xea,
-	0x98, 0xc8, 0xf1, 0xa7, 0x1f, 0x4b, 0x1b, 0x5d, 0x2c, 0x6d, 0x74, 0xb9, 0xb4, 0xd1, 0xdf, 0xa5,
-	0x8d, 0xbe, 0xad, 0xec, 0xce, 0xe5, 0xca, 0xee, 0xfc, 0x5a, 0xd9, 0x9d, 0xd3, 0x67, 0x71, 0x92,
-	0x9f, 0x15, 0xa1, 0x3b, 0xe1, 0xcc, 0xbb, 0x3a, 0x48, 0xcd, 0xb2, 0x9a, 0x94, 0xf6, 0x90, 0x85,
-	0x5b, 0xea, 0xfc, 0xf0, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x18, 0x54, 0x4f, 0xe1, 0x7f, 0x03,
-	0x00, 0x00,
+	// 533 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4f, 0x6f, 0xd3, 0x30,
+	0x18, 0xc6, 0xeb, 0x75, 0x6c, 0xeb, 0x5b, 0xb2, 0x4e, 0x16, 0x88, 0x32, 0xb4, 0xb4, 0xe4, 0x30,
+	0x4d, 0x42, 0x4a, 0x80, 0x09, 0x21, 0x24, 0x24, 0x44, 0x01, 0x81, 0x84, 0x86, 0x50, 0xf8, 0x73,
+	0xe0, 0x12, 0x39, 0x8d, 0xc9, 0xa2, 0x36, 0xb1, 0x15, 0x27, 0x55, 0xbb, 0x13, 0x5f, 0x00, 0x89,
+	0x23, 0x1f, 0x01, 0xbe, 0xc9, 0x8e, 0x3b, 0x72, 0x02, 0xd4, 0x7e, 0x11, 0x64, 0xc7, 0x26, 0x6b,
+	0xb7, 0x5b, 0xec, 0xe7, 0xf9, 0xd9, 0x79, 0x9f, 0x47, 0x86, 0xbd, 0x82, 0x66, 0x11, 0xcd, 0xd3,
+	0x24, 0x2b, 0xbc, 0x62, 0xc6, 0xa9, 0xf0, 0x38, 0xc9, 0x49, 0x2a, 0x5c, 0x9e, 0xb3, 0x82, 0xe1,
+	0x9d, 0x5a, 0x76, 0x95, 0xbc, 0x7b, 0x2d, 0x66, 0x31, 0x53, 0xa2, 0x27, 0xbf, 0x2a, 0xdf, 0xae,
+	0x1d, 0x33, 0x16, 0x8f, 0xa9, 0xa7, 0x56, 0x61, 0xf9, 0xd9, 0x8b, 0xca, 0x9c, 0x14, 0x09, 0xcb,
+	0x2a, 0xdd, 0xf9, 0xb2, 0x06, 0x9d, 0x67, 0x2c, 0x13, 0x34, 0x13, 0xa5, 0x78, 0xab, 0x6e, 0xc0,
+	0x87, 0x70, 0x25, 0x1c, 0xb3, 0xe1, 0xa8, 0x8b, 0xfa, 0xe8, 0xa0, 0x7d, 0x7f, 0xcf, 0x5d, 0xbd,
+	0xcb, 0x1d, 0x48, 0xb9, 0x72, 0xfb, 0x95, 0x17, 0x3f, 0x86, 0x2d, 0x3a, 0x49, 0x22, 0x9a, 0x0d,
+	0x69, 0x77, 0x4d, 0x71, 0xfd, 0x8b, 0xdc, 0x0b, 0xed, 0xd0, 0xe8, 0x7f, 0x02, 0x3f, 0x81, 0xd6,
+	0x84, 0x8c, 0x93, 0x88, 0x14, 0x2c, 0xef, 0x36, 0x15, 0x7e, 0xfb, 0x22, 0xfe, 0xd1, 0x58, 0x34,
+	0x5f, 0x33, 0xf8, 0x11, 0x6c, 0x4e, 0x68, 0x2e, 0x12, 0x96, 0x75, 0xd7, 0x15, 0xde, 0xbb, 0x04,
+	0xaf, 0x0c, 0x1a, 0x36, 0x7e, 0x67, 0x04, 0xed, 0x73, 0xf3, 0xe0, 0x5b, 0xd0, 0x4a, 0xc9, 0x34,
+	0x08, 0x67, 0x05, 0x15, 0x2a, 0x81, 0xa6, 0xbf, 0x95, 0x92, 0xe9, 0x40, 0xae, 0xf1, 0x0d, 0xd8,
+	0x94, 0x62, 0x4c, 0x84, 0x1a, 0xb2, 0xe9, 0x6f, 0xa4, 0x64, 0xfa, 0x92, 0x08, 0xbc, 0x0f, 0x1d,
+	0x4e, 0xf2, 0x22, 0x10, 0xc9, 0x09, 0xd5, 0xac, 0x1c, 0xc3, 0xf2, 0x2d, 0xb9, 0xfd, 0x2e, 0x39,
+	0xa1, 0xea, 0x00, 0xe7, 0x27, 0x82, 0xed, 0xe5, 0x14, 0xf0, 0x1d, 0xc0, 0xf2, 0x4c, 0x12, 0xd3,
+	0x20, 0x2b, 0xd3, 0x40, 0xc5, 0x69, 0x6e, 0xee, 0xa4, 0x64, 0xfa, 0x34, 0xa6, 0x6f, 0xca, 0x54,
+	0xfd, 0xa2, 0xc0, 0x47, 0xb0, 0x63, 0xcc, 0xa6, 0x49, 0x1d, 0xf7, 0x4d, 0xb7, 0xaa, 0xda, 0x35,
+	0x55, 0xbb, 0xcf, 0xb5, 0x61, 0xb0, 0x75, 0xfa, 0xbb, 0xd7, 0xf8, 0xfe, 0xa7, 0x87, 0xfc, 0xed,
+	0xea, 0x3c, 0xa3, 0x2c, 0x0f, 0xdb, 0x5c, 0x1e, 0xd6, 0x79, 0x00, 0x9d, 0x95, 0xc4, 0xb1, 0x03,
+	0x16, 0x2f, 0xc3, 0x60, 0x44, 0x67, 0x81, 0xca, 0xb4, 0x8b, 0xfa, 0xcd, 0x83, 0x96, 0xdf, 0xe6,
+	0x65, 0xf8, 0x9a, 0xce, 0xde, 0xcb, 0x2d, 0xe7, 0x2e, 0x58, 0x4b, 0x49, 0xe3, 0x1e, 0xb4, 0x09,
+	0xe7, 0x81, 0xe9, 0x47, 0x4e, 0xb6, 0xee, 0x03, 0xe1, 0x5c, 0xdb, 0x9c, 0xaf, 0x08, 0xae, 0xbe,
+	0x22, 0xe2, 0x98, 0x46, 0x9a, 0xd8, 0x87, 0x8e, 0x8a, 0x21, 0x58, 0x6d, 0xc2, 0x52, 0xdb, 0x47,
+	0xa6, 0x0e, 0x07, 0xac, 0xda, 0x57, 0x97, 0xd2, 0x36, 0x2e, 0xd9, 0xcc, 0x3d, 0xb8, 0x5e, 0x79,
+	0x2e, 0xef, 0x07, 0x87, 0xba, 0xfb, 0xba, 0xa4, 0xc1, 0x87, 0x1f, 0x73, 0x1b, 0x9d, 0xce, 0x6d,
+	0x74, 0x36, 0xb7, 0xd1, 0xdf, 0xb9, 0x8d, 0xbe, 0x2d, 0xec, 0xc6, 0xd9, 0xc2, 0x6e, 0xfc, 0x5a,
+	0xd8, 0x8d, 0x4f, 0x0f, 0xe3, 0xa4, 0x38, 0x2e, 0x43, 0x77, 0xc8, 0x52, 0xef, 0xfc, 0x23, 0xad,
+	0x3f, 0xab, 0x57, 0xb8, 0xfa, 0x80, 0xc3, 0x0d, 0xb5, 0x7f, 0xf8, 0x6f, 0x00, 0xa4, 0xd1, 0xe9,
+	0x4b, 0xdb, 0x03, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -478,6 +498,9 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.MaxGas != that1.MaxGas {
 		return false
 	}
+	if this.PartSizeBytes != that1.PartSizeBytes {
+		return false
+	}
 	return true
 }
 func (this *EvidenceParams) Equal(that interface{}) bool {
@@ -588,6 +611,9 @@ func (this *HashedParams) Equal(that interface{}) bool {
 	if this.BlockMaxGas != that1.BlockMaxGas {
 		return false
 	}
+	if this.BlockPartSizeBytes != that1.BlockPartSizeBytes {
+		return false
+	}
 	return true
 }
 func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
@@ -681,6 +707,11 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PartSizeBytes))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.MaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.MaxGas))
 		i--
@@ -815,6 +846,11 @@ func (m *HashedParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockPartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BlockPartSizeBytes))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.BlockMaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.BlockMaxGas))
 		i--
@@ -876,6 +912,9 @@ func (m *BlockParams) Size() (n int) {
 	if m.MaxGas != 0 {
 		n += 1 + sovParams(uint64(m.MaxGas))
 	}
+	if m.PartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.PartSizeBytes))
+	}
 	return n
 }
 
@@ -935,6 +974,9 @@ func (m *HashedParams) Size() (n int) {
 	if m.BlockMaxGas != 0 {
 		n += 1 + sovParams(uint64(m.BlockMaxGas))
 	}
+	if m.BlockPartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.BlockPartSizeBytes))
+	}
 	return n
 }
 
@@ -1205,6 +1247,25 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSizeBytes", wireType)
+			}
+			m.PartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1565,6 +1626,25 @@ func (m *HashedParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSizeBytes", wireType)
+			}
+			m.BlockPartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockPartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
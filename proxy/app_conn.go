@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"context"
+	"sync/atomic"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/types"
@@ -57,6 +58,7 @@ type AppConnSnapshot interface {
 
 type appConnConsensus struct {
 	appConn abcicli.Client
+	sched   *callScheduler
 }
 
 func NewAppConnConsensus(appConn abcicli.Client) AppConnConsensus {
@@ -77,6 +79,11 @@ func (app *appConnConsensus) InitChainSync(
 	ctx context.Context,
 	req types.RequestInitChain,
 ) (*types.ResponseInitChain, error) {
+	release, err := app.sched.acquire(ctx, classConsensus)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.InitChainSync(ctx, req)
 }
 
@@ -84,10 +91,20 @@ func (app *appConnConsensus) BeginBlockSync(
 	ctx context.Context,
 	req types.RequestBeginBlock,
 ) (*types.ResponseBeginBlock, error) {
+	release, err := app.sched.acquire(ctx, classConsensus)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.BeginBlockSync(ctx, req)
 }
 
 func (app *appConnConsensus) DeliverTxAsync(ctx context.Context, req types.RequestDeliverTx) (*abcicli.ReqRes, error) {
+	release, err := app.sched.acquire(ctx, classConsensus)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.DeliverTxAsync(ctx, req)
 }
 
@@ -95,10 +112,20 @@ func (app *appConnConsensus) EndBlockSync(
 	ctx context.Context,
 	req types.RequestEndBlock,
 ) (*types.ResponseEndBlock, error) {
+	release, err := app.sched.acquire(ctx, classConsensus)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.EndBlockSync(ctx, req)
 }
 
 func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	release, err := app.sched.acquire(ctx, classConsensus)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.CommitSync(ctx)
 }
 
@@ -107,6 +134,7 @@ func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCom
 
 type appConnMempool struct {
 	appConn abcicli.Client
+	sched   *callScheduler
 }
 
 func NewAppConnMempool(appConn abcicli.Client) AppConnMempool {
@@ -132,18 +160,121 @@ func (app *appConnMempool) FlushSync(ctx context.Context) error {
 }
 
 func (app *appConnMempool) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abcicli.ReqRes, error) {
+	release, err := app.sched.acquire(ctx, classMempool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.CheckTxAsync(ctx, req)
 }
 
 func (app *appConnMempool) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	release, err := app.sched.acquire(ctx, classMempool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.CheckTxSync(ctx, req)
 }
 
+//------------------------------------------------
+// Implements AppConnMempool over multiple connections
+
+// appConnMempoolParallel spreads new txs over multiple connections to the
+// application, so they are checked in parallel. Rechecks are all sent over the
+// first connection, so the application receives them in the order they were
+// sent, like over a single connection.
+type appConnMempoolParallel struct {
+	appConns []abcicli.Client
+	next     uint32 // atomic, index of the next connection to use for new txs
+	sched    *callScheduler
+}
+
+// NewParallelAppConnMempool returns an AppConnMempool which checks new txs
+// concurrently over the given connections. The response callback is invoked
+// concurrently for responses received over different connections.
+func NewParallelAppConnMempool(appConns []abcicli.Client) AppConnMempool {
+	if len(appConns) == 0 {
+		panic("no mempool connections")
+	}
+	return &appConnMempoolParallel{
+		appConns: appConns,
+	}
+}
+
+func (app *appConnMempoolParallel) appConnFor(req types.RequestCheckTx) abcicli.Client {
+	if req.Type == types.CheckTxType_Recheck {
+		return app.appConns[0]
+	}
+	i := atomic.AddUint32(&app.next, 1) % uint32(len(app.appConns))
+	return app.appConns[i]
+}
+
+func (app *appConnMempoolParallel) SetResponseCallback(cb abcicli.Callback) {
+	for _, c := range app.appConns {
+		c.SetResponseCallback(cb)
+	}
+}
+
+func (app *appConnMempoolParallel) Error() error {
+	for _, c := range app.appConns {
+		if err := c.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlushAsync flushes all connections and returns the ReqRes of the first
+// connection, the one used for rechecks.
+func (app *appConnMempoolParallel) FlushAsync(ctx context.Context) (*abcicli.ReqRes, error) {
+	for _, c := range app.appConns[1:] {
+		if _, err := c.FlushAsync(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return app.appConns[0].FlushAsync(ctx)
+}
+
+func (app *appConnMempoolParallel) FlushSync(ctx context.Context) error {
+	for _, c := range app.appConns {
+		if err := c.FlushSync(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (app *appConnMempoolParallel) CheckTxAsync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*abcicli.ReqRes, error) {
+	release, err := app.sched.acquire(ctx, classMempool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return app.appConnFor(req).CheckTxAsync(ctx, req)
+}
+
+func (app *appConnMempoolParallel) CheckTxSync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*types.ResponseCheckTx, error) {
+	release, err := app.sched.acquire(ctx, classMempool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return app.appConnFor(req).CheckTxSync(ctx, req)
+}
+
 //------------------------------------------------
 // Implements AppConnQuery (subset of abcicli.Client)
 
 type appConnQuery struct {
 	appConn abcicli.Client
+	sched   *callScheduler
 }
 
 func NewAppConnQuery(appConn abcicli.Client) AppConnQuery {
@@ -157,14 +288,29 @@ func (app *appConnQuery) Error() error {
 }
 
 func (app *appConnQuery) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	release, err := app.sched.acquire(ctx, classQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.EchoSync(ctx, msg)
 }
 
 func (app *appConnQuery) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	release, err := app.sched.acquire(ctx, classQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.InfoSync(ctx, req)
 }
 
 func (app *appConnQuery) QuerySync(ctx context.Context, reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
+	release, err := app.sched.acquire(ctx, classQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.QuerySync(ctx, reqQuery)
 }
 
@@ -173,6 +319,7 @@ func (app *appConnQuery) QuerySync(ctx context.Context, reqQuery types.RequestQu
 
 type appConnSnapshot struct {
 	appConn abcicli.Client
+	sched   *callScheduler
 }
 
 func NewAppConnSnapshot(appConn abcicli.Client) AppConnSnapshot {
@@ -189,6 +336,11 @@ func (app *appConnSnapshot) ListSnapshotsSync(
 	ctx context.Context,
 	req types.RequestListSnapshots,
 ) (*types.ResponseListSnapshots, error) {
+	release, err := app.sched.acquire(ctx, classSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.ListSnapshotsSync(ctx, req)
 }
 
@@ -196,17 +348,32 @@ func (app *appConnSnapshot) OfferSnapshotSync(
 	ctx context.Context,
 	req types.RequestOfferSnapshot,
 ) (*types.ResponseOfferSnapshot, error) {
+	release, err := app.sched.acquire(ctx, classSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.OfferSnapshotSync(ctx, req)
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	ctx context.Context,
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	release, err := app.sched.acquire(ctx, classSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.LoadSnapshotChunkSync(ctx, req)
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	ctx context.Context,
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	release, err := app.sched.acquire(ctx, classSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	return app.appConn.ApplySnapshotChunkSync(ctx, req)
 }
@@ -6,7 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	abcicli "github.com/tendermint/tendermint/abci/client"
+	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/server"
 	"github.com/tendermint/tendermint/abci/types"
@@ -184,3 +187,41 @@ func TestInfo(t *testing.T) {
 		t.Error("Expected ResponseInfo with one element '{\"size\":0}' but got something else")
 	}
 }
+
+func TestParallelAppConnMempool(t *testing.T) {
+	ctx := context.Background()
+
+	clients := make([]*abcimocks.Client, 3)
+	conns := make([]abcicli.Client, 3)
+	for i := range clients {
+		clients[i] = &abcimocks.Client{}
+		conns[i] = clients[i]
+	}
+	appConn := NewParallelAppConnMempool(conns)
+
+	// new txs are spread over all connections
+	for i := 1; i <= 3; i++ {
+		req := types.RequestCheckTx{Tx: []byte{byte(i)}}
+		clients[i%3].On("CheckTxAsync", ctx, req).Return(&abcicli.ReqRes{}, nil).Once()
+		_, err := appConn.CheckTxAsync(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// rechecks are all sent over the first connection
+	for i := 1; i <= 3; i++ {
+		req := types.RequestCheckTx{Tx: []byte{byte(i)}, Type: types.CheckTxType_Recheck}
+		clients[0].On("CheckTxAsync", ctx, req).Return(&abcicli.ReqRes{}, nil).Once()
+		_, err := appConn.CheckTxAsync(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// all connections are flushed
+	for _, c := range clients {
+		c.On("FlushSync", ctx).Return(nil).Once()
+	}
+	require.NoError(t, appConn.FlushSync(ctx))
+
+	for _, c := range clients {
+		c.AssertExpectations(t)
+	}
+}
@@ -38,6 +38,19 @@ func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
+// TxSanityCheck returns the SanityCheckTx method of the app of a local client
+// creator, if the app implements types.TxSanityChecker, or nil.
+func TxSanityCheck(clientCreator ClientCreator) func(tx []byte) error {
+	local, ok := clientCreator.(*localClientCreator)
+	if !ok {
+		return nil
+	}
+	if checker, ok := local.app.(types.TxSanityChecker); ok {
+		return checker.SanityCheckTx
+	}
+	return nil
+}
+
 //---------------------------------------------------------------
 // remote proxy opens new connections to an external app process
 
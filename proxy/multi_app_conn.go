@@ -6,6 +6,7 @@ import (
 	"syscall"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -33,8 +34,8 @@ type AppConns interface {
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
 }
 
 // multiAppConn implements AppConns.
@@ -51,19 +52,66 @@ type multiAppConn struct {
 	snapshotConn  AppConnSnapshot
 
 	consensusConnClient abcicli.Client
-	mempoolConnClient   abcicli.Client
+	mempoolConnClients  []abcicli.Client
 	queryConnClient     abcicli.Client
 	snapshotConnClient  abcicli.Client
 
-	clientCreator ClientCreator
+	clientCreator      ClientCreator
+	mempoolConnections int
+	maxPendingQueries  int
+	metrics            *Metrics
+
+	// sched schedules the calls to a local app, which serializes them.
+	sched *callScheduler
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// MempoolConnections sets the number of mempool connections used to check txs
+// in parallel (default: 1). See NewParallelAppConnMempool.
+func MempoolConnections(n int) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.mempoolConnections = n
+	}
+}
+
+// MaxPendingQueries sets the number of queries which may wait for a local app,
+// while it is busy with consensus and mempool calls, before further queries are
+// rejected with ErrTooManyPendingQueries (default: 1000). 0 means no limit.
+func MaxPendingQueries(n int) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.maxPendingQueries = n
+	}
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.metrics = metrics
+	}
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+//
+// The calls to a local app are scheduled by connection, so that queries can't
+// starve consensus and the mempool: consensus calls go first, then mempool
+// calls, then snapshot calls, then queries.
+func NewMultiAppConn(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
-		clientCreator: clientCreator,
+		clientCreator:      clientCreator,
+		mempoolConnections: 1,
+		maxPendingQueries:  defaultMaxPendingQueries,
+		metrics:            NopMetrics(),
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
+	if local, ok := clientCreator.(*localClientCreator); ok {
+		multiAppConn.sched = newCallScheduler(types.IsConcurrencySafe(local.app),
+			multiAppConn.maxPendingQueries, multiAppConn.metrics)
+	}
 	return multiAppConn
 }
 
@@ -89,7 +137,7 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c)
+	app.queryConn = &appConnQuery{appConn: c, sched: app.sched}
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
@@ -97,15 +145,21 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c)
+	app.snapshotConn = &appConnSnapshot{appConn: c, sched: app.sched}
 
-	c, err = app.abciClientFor(connMempool)
-	if err != nil {
-		app.stopAllClients()
-		return err
+	for i := 0; i < app.mempoolConnections; i++ {
+		c, err = app.abciClientFor(connMempool)
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.mempoolConnClients = append(app.mempoolConnClients, c)
+	}
+	if len(app.mempoolConnClients) == 1 {
+		app.mempoolConn = &appConnMempool{appConn: app.mempoolConnClients[0], sched: app.sched}
+	} else {
+		app.mempoolConn = &appConnMempoolParallel{appConns: app.mempoolConnClients, sched: app.sched}
 	}
-	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -113,10 +167,13 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 	app.consensusConnClient = c
-	app.consensusConn = NewAppConnConsensus(c)
+	app.consensusConn = &appConnConsensus{appConn: c, sched: app.sched}
 
 	// Kill Tendermint if the ABCI application crashes.
 	go app.killTMOnClientError()
+	for _, c := range app.mempoolConnClients[1:] {
+		go app.killTMOnMempoolClientError(c)
+	}
 
 	return nil
 }
@@ -125,23 +182,23 @@ func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
 }
 
-func (app *multiAppConn) killTMOnClientError() {
-	killFn := func(conn string, err error, logger tmlog.Logger) {
-		logger.Error(
-			fmt.Sprintf("%s connection terminated. Did the application crash? Please restart tendermint", conn),
-			"err", err)
-		if killErr := kill(); killErr != nil {
-			logger.Error("Failed to kill this process - please do so manually", "err", killErr)
-		}
+func killFn(conn string, err error, logger tmlog.Logger) {
+	logger.Error(
+		fmt.Sprintf("%s connection terminated. Did the application crash? Please restart tendermint", conn),
+		"err", err)
+	if killErr := kill(); killErr != nil {
+		logger.Error("Failed to kill this process - please do so manually", "err", killErr)
 	}
+}
 
+func (app *multiAppConn) killTMOnClientError() {
 	select {
 	case <-app.consensusConnClient.Quit():
 		if err := app.consensusConnClient.Error(); err != nil {
 			killFn(connConsensus, err, app.Logger)
 		}
-	case <-app.mempoolConnClient.Quit():
-		if err := app.mempoolConnClient.Error(); err != nil {
+	case <-app.mempoolConnClients[0].Quit():
+		if err := app.mempoolConnClients[0].Error(); err != nil {
 			killFn(connMempool, err, app.Logger)
 		}
 	case <-app.queryConnClient.Quit():
@@ -155,14 +212,23 @@ func (app *multiAppConn) killTMOnClientError() {
 	}
 }
 
+// killTMOnMempoolClientError watches one of the additional mempool
+// connections.
+func (app *multiAppConn) killTMOnMempoolClientError(c abcicli.Client) {
+	<-c.Quit()
+	if err := c.Error(); err != nil {
+		killFn(connMempool, err, app.Logger)
+	}
+}
+
 func (app *multiAppConn) stopAllClients() {
 	if app.consensusConnClient != nil {
 		if err := app.consensusConnClient.Stop(); err != nil {
 			app.Logger.Error("error while stopping consensus client", "error", err)
 		}
 	}
-	if app.mempoolConnClient != nil {
-		if err := app.mempoolConnClient.Stop(); err != nil {
+	for _, c := range app.mempoolConnClients {
+		if err := c.Stop(); err != nil {
 			app.Logger.Error("error while stopping mempool client", "error", err)
 		}
 	}
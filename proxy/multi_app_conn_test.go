@@ -41,6 +41,33 @@ func TestAppConns_Start_Stop(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
+func TestAppConns_MempoolConnections(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	clientCreatorMock := &mocks.ClientCreator{}
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("SetLogger", mock.Anything).Return().Times(6)
+	clientMock.On("Start").Return(nil).Times(6)
+	clientMock.On("Stop").Return(nil).Times(6)
+	clientMock.On("Quit").Return(quitCh).Times(6)
+
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(6)
+
+	appConns := NewAppConns(clientCreatorMock, MempoolConnections(3))
+
+	err := appConns.Start()
+	require.NoError(t, err)
+	require.IsType(t, &appConnMempoolParallel{}, appConns.Mempool())
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = appConns.Stop()
+	require.NoError(t, err)
+
+	clientMock.AssertExpectations(t)
+}
+
 // Upon failure, we call tmos.Kill
 func TestAppConns_Failure(t *testing.T) {
 	ok := make(chan struct{})
@@ -0,0 +1,176 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// callClass classifies the calls to the app by the connection they are made
+// over. The classes are in the order of their priority.
+type callClass int
+
+const (
+	classConsensus callClass = iota
+	classMempool
+	classSnapshot
+	classQuery
+	numCallClasses
+)
+
+var callClassConns = [numCallClasses]string{connConsensus, connMempool, connSnapshot, connQuery}
+
+const (
+	// maxOvertakes is the number of calls of other classes which may overtake
+	// the oldest waiting call of a class before that call goes first.
+	maxOvertakes = 16
+
+	// defaultMaxPendingQueries is the default number of queries which may
+	// wait for the app before further queries are rejected.
+	defaultMaxPendingQueries = 1000
+)
+
+// ErrTooManyPendingQueries is returned by the query connection when too many
+// queries are waiting for the app already.
+var ErrTooManyPendingQueries = errors.New("too many pending ABCI queries")
+
+type pendingCall struct {
+	granted   chan struct{}
+	overtaken int
+}
+
+// callScheduler schedules the calls of the connections to an app which
+// serializes them, i.e. a local app, so that queries can't starve consensus
+// and the mempool. Waiting calls are let through by priority: consensus, then
+// mempool, then snapshot calls, then queries. A call which was overtaken by
+// maxOvertakes calls goes first, so that lower priorities are slowed down but
+// don't starve. Queries run concurrently with each other, like they do in the
+// local client, and so do mempool calls to a concurrency-safe app; all other
+// calls run alone.
+//
+// A nil *callScheduler lets all calls through at once.
+type callScheduler struct {
+	mtx               sync.Mutex
+	pending           [numCallClasses][]*pendingCall
+	shared            [numCallClasses]bool // whether the calls of a class run concurrently
+	exclusive         bool                 // whether a call of a non-shared class is running
+	sharing           int                  // number of running calls of shared classes
+	maxPendingQueries int
+	metrics           *Metrics
+}
+
+// newCallScheduler returns a scheduler for a local app. Mempool calls share the
+// app with queries if concurrent is true, see types.ConcurrentApplication.
+func newCallScheduler(concurrent bool, maxPendingQueries int, metrics *Metrics) *callScheduler {
+	s := &callScheduler{
+		maxPendingQueries: maxPendingQueries,
+		metrics:           metrics,
+	}
+	s.shared[classQuery] = true
+	s.shared[classMempool] = concurrent
+	return s
+}
+
+// acquire waits until a call of the given class may run, and returns the
+// function to call once it is done. It fails if ctx is done first, or if too
+// many queries are pending already.
+func (s *callScheduler) acquire(ctx context.Context, class callClass) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+
+	s.mtx.Lock()
+	if class == classQuery && s.maxPendingQueries > 0 && len(s.pending[classQuery]) >= s.maxPendingQueries {
+		s.mtx.Unlock()
+		s.metrics.ShedQueries.Add(1)
+		return nil, ErrTooManyPendingQueries
+	}
+	call := &pendingCall{granted: make(chan struct{})}
+	s.pending[class] = append(s.pending[class], call)
+	s.dispatch()
+	s.mtx.Unlock()
+
+	select {
+	case <-call.granted:
+	case <-ctx.Done():
+		s.mtx.Lock()
+		select {
+		case <-call.granted:
+			// granted meanwhile, so give it back
+			s.releaseLocked(class)
+		default:
+			s.remove(class, call)
+			s.dispatch()
+		}
+		s.mtx.Unlock()
+		return nil, ctx.Err()
+	}
+
+	s.metrics.WaitTime.With("connection", callClassConns[class]).Observe(time.Since(start).Seconds())
+	return func() {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		s.releaseLocked(class)
+	}, nil
+}
+
+func (s *callScheduler) releaseLocked(class callClass) {
+	if s.shared[class] {
+		s.sharing--
+	} else {
+		s.exclusive = false
+	}
+	s.dispatch()
+}
+
+// dispatch lets the next pending calls through, as far as the running calls
+// allow. The caller must hold the mutex.
+func (s *callScheduler) dispatch() {
+	for {
+		class, ok := s.next()
+		if !ok || s.exclusive || (!s.shared[class] && s.sharing > 0) {
+			return
+		}
+
+		call := s.pending[class][0]
+		s.pending[class] = s.pending[class][1:]
+		for c := range s.pending {
+			if callClass(c) != class && len(s.pending[c]) > 0 {
+				s.pending[c][0].overtaken++
+			}
+		}
+
+		if s.shared[class] {
+			s.sharing++
+		} else {
+			s.exclusive = true
+		}
+		close(call.granted)
+	}
+}
+
+// next returns the class of the next call to let through, if any.
+func (s *callScheduler) next() (callClass, bool) {
+	for class := callClass(0); class < numCallClasses; class++ {
+		if len(s.pending[class]) > 0 && s.pending[class][0].overtaken >= maxOvertakes {
+			return class, true
+		}
+	}
+	for class := callClass(0); class < numCallClasses; class++ {
+		if len(s.pending[class]) > 0 {
+			return class, true
+		}
+	}
+	return 0, false
+}
+
+func (s *callScheduler) remove(class callClass, call *pendingCall) {
+	for i, c := range s.pending[class] {
+		if c == call {
+			s.pending[class] = append(s.pending[class][:i], s.pending[class][i+1:]...)
+			return
+		}
+	}
+}
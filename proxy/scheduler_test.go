@@ -0,0 +1,196 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires the scheduler for a call of the given class in a
+// goroutine, and sends the class on granted once it runs.
+func acquireAsync(
+	ctx context.Context, s *callScheduler, class callClass, granted chan<- callClass,
+) <-chan func() {
+	release := make(chan func(), 1)
+	go func() {
+		r, err := s.acquire(ctx, class)
+		if err != nil {
+			return
+		}
+		granted <- class
+		release <- r
+	}()
+	return release
+}
+
+// waitPending waits until the given number of calls of the class are pending.
+func waitPending(t *testing.T, s *callScheduler, class callClass, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		return len(s.pending[class]) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestCallScheduler_Priority(t *testing.T) {
+	s := newCallScheduler(false, 0, NopMetrics())
+	ctx := context.Background()
+
+	release, err := s.acquire(ctx, classConsensus)
+	require.NoError(t, err)
+
+	granted := make(chan callClass, 4)
+	var releases []<-chan func()
+	for _, class := range []callClass{classQuery, classSnapshot, classMempool, classConsensus} {
+		releases = append(releases, acquireAsync(ctx, s, class, granted))
+		waitPending(t, s, class, 1)
+	}
+
+	release()
+	for i, want := range []callClass{classConsensus, classMempool, classSnapshot, classQuery} {
+		select {
+		case class := <-granted:
+			assert.Equal(t, want, class)
+		case <-time.After(time.Second):
+			t.Fatalf("call %d not granted", i)
+		}
+		(<-releases[len(releases)-1-i])()
+	}
+}
+
+func TestCallScheduler_SharedQueries(t *testing.T) {
+	s := newCallScheduler(false, 0, NopMetrics())
+	ctx := context.Background()
+
+	release1, err := s.acquire(ctx, classQuery)
+	require.NoError(t, err)
+	release2, err := s.acquire(ctx, classQuery)
+	require.NoError(t, err)
+
+	// a consensus call waits for the running queries, and new queries wait for it
+	granted := make(chan callClass, 2)
+	releaseConsensus := acquireAsync(ctx, s, classConsensus, granted)
+	waitPending(t, s, classConsensus, 1)
+	releaseQuery := acquireAsync(ctx, s, classQuery, granted)
+	waitPending(t, s, classQuery, 1)
+
+	release1()
+	release2()
+	assert.Equal(t, classConsensus, <-granted)
+	(<-releaseConsensus)()
+	assert.Equal(t, classQuery, <-granted)
+	(<-releaseQuery)()
+}
+
+func TestCallScheduler_Aging(t *testing.T) {
+	s := newCallScheduler(false, 0, NopMetrics())
+	ctx := context.Background()
+
+	release, err := s.acquire(ctx, classConsensus)
+	require.NoError(t, err)
+	granted := make(chan callClass, 1)
+	releaseQuery := acquireAsync(ctx, s, classQuery, granted)
+	waitPending(t, s, classQuery, 1)
+
+	// the query is overtaken by maxOvertakes consensus calls, then goes first
+	for i := 0; i < maxOvertakes; i++ {
+		next := make(chan func())
+		go func() {
+			r, err := s.acquire(ctx, classConsensus)
+			if err == nil {
+				next <- r
+			}
+		}()
+		waitPending(t, s, classConsensus, 1)
+		release()
+		release = <-next
+	}
+
+	releaseConsensus := acquireAsync(ctx, s, classConsensus, granted)
+	waitPending(t, s, classConsensus, 1)
+	release()
+	assert.Equal(t, classQuery, <-granted)
+	(<-releaseQuery)()
+	assert.Equal(t, classConsensus, <-granted)
+	(<-releaseConsensus)()
+}
+
+func TestCallScheduler_ShedQueries(t *testing.T) {
+	s := newCallScheduler(false, 2, NopMetrics())
+	ctx := context.Background()
+
+	release, err := s.acquire(ctx, classConsensus)
+	require.NoError(t, err)
+
+	granted := make(chan callClass, 2)
+	releases := []<-chan func(){
+		acquireAsync(ctx, s, classQuery, granted),
+		acquireAsync(ctx, s, classQuery, granted),
+	}
+	waitPending(t, s, classQuery, 2)
+
+	_, err = s.acquire(ctx, classQuery)
+	assert.Equal(t, ErrTooManyPendingQueries, err)
+
+	// other calls are never shed
+	releaseMempool := acquireAsync(ctx, s, classMempool, make(chan callClass, 1))
+	waitPending(t, s, classMempool, 1)
+
+	release()
+	(<-releaseMempool)()
+	for _, r := range releases {
+		(<-r)()
+	}
+}
+
+func TestCallScheduler_Cancel(t *testing.T) {
+	s := newCallScheduler(false, 0, NopMetrics())
+
+	release, err := s.acquire(context.Background(), classConsensus)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(ctx, classMempool)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	waitPending(t, s, classMempool, 0)
+
+	release()
+	release, err = s.acquire(context.Background(), classMempool)
+	require.NoError(t, err)
+	release()
+}
+
+func TestCallScheduler_Nil(t *testing.T) {
+	var s *callScheduler
+	release, err := s.acquire(context.Background(), classConsensus)
+	require.NoError(t, err)
+	release()
+}
+
+func TestCallScheduler_ConcurrentMempool(t *testing.T) {
+	s := newCallScheduler(true, 0, NopMetrics())
+	ctx := context.Background()
+
+	// mempool calls and queries to a concurrency-safe app run at once
+	releaseMempool, err := s.acquire(ctx, classMempool)
+	require.NoError(t, err)
+	releaseMempool2, err := s.acquire(ctx, classMempool)
+	require.NoError(t, err)
+	releaseQuery, err := s.acquire(ctx, classQuery)
+	require.NoError(t, err)
+
+	granted := make(chan callClass, 1)
+	releaseConsensus := acquireAsync(ctx, s, classConsensus, granted)
+	waitPending(t, s, classConsensus, 1)
+
+	releaseMempool()
+	releaseMempool2()
+	releaseQuery()
+	assert.Equal(t, classConsensus, <-granted)
+	(<-releaseConsensus)()
+}
@@ -133,12 +133,18 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		result, err := c.BroadcastEvidence(context.Background(), correct)
 		require.NoError(t, err, "BroadcastEvidence(%s) failed", correct)
 		assert.Equal(t, correct.Hash(), result.Hash, "expected result hash to match evidence hash")
+		assert.Contains(t, []string{"pending", "committed"}, result.Status)
 
 		status, err := c.Status(context.Background())
 		require.NoError(t, err)
 		err = client.WaitForHeight(c, status.SyncInfo.LatestBlockHeight+2, nil)
 		require.NoError(t, err)
 
+		evStatus, err := c.Evidence(context.Background(), result.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, "committed", evStatus.Status)
+		assert.Positive(t, evStatus.Height)
+
 		ed25519pub := pv.Key.PubKey.(ed25519.PubKey)
 		rawpub := ed25519pub.Bytes()
 		result2, err := c.ABCIQuery(context.Background(), "/val", rawpub)
@@ -163,6 +169,14 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 	}
 }
 
+func TestEvidenceUnknown(t *testing.T) {
+	for _, c := range GetClients(t, NodeSuite(t)) {
+		result, err := c.Evidence(context.Background(), []byte("unknown"))
+		require.NoError(t, err)
+		assert.Equal(t, "unknown", result.Status)
+	}
+}
+
 func TestBroadcastEmptyEvidence(t *testing.T) {
 	for _, c := range GetClients(t, NodeSuite(t)) {
 		_, err := c.BroadcastEvidence(context.Background(), nil)
@@ -274,6 +274,18 @@ func (c *baseRPCClient) BroadcastTxSync(
 	return c.broadcastTX(ctx, "broadcast_tx_sync", tx)
 }
 
+func (c *baseRPCClient) BroadcastTxBatch(
+	ctx context.Context,
+	txs types.Txs,
+) (*ctypes.ResultBroadcastTxBatch, error) {
+	result := new(ctypes.ResultBroadcastTxBatch)
+	_, err := c.caller.Call(ctx, "broadcast_tx_batch", map[string]interface{}{"txs": txs}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) broadcastTX(
 	ctx context.Context,
 	route string,
@@ -339,6 +351,15 @@ func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultD
 	return result, nil
 }
 
+func (c *baseRPCClient) DumpConsensusStateV2(ctx context.Context) (*ctypes.ResultDumpConsensusStateV2, error) {
+	result := new(ctypes.ResultDumpConsensusStateV2)
+	_, err := c.caller.Call(ctx, "dump_consensus_state_v2", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	result := new(ctypes.ResultConsensusState)
 	_, err := c.caller.Call(ctx, "consensus_state", map[string]interface{}{}, result)
@@ -388,6 +409,46 @@ func (c *baseRPCClient) BlockchainInfo(
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockchainInfoWithOptions(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	opts rpcclient.BlockchainInfoOptions,
+) (*ctypes.ResultBlockchainInfo, error) {
+	result := new(ctypes.ResultBlockchainInfo)
+	_, err := c.caller.Call(ctx, "blockchain",
+		map[string]interface{}{
+			"minHeight": minHeight,
+			"maxHeight": maxHeight,
+			"proposer":  opts.Proposer,
+			"has_txs":   opts.HasTxs,
+			"min_time":  opts.MinTime,
+			"max_time":  opts.MaxTime,
+			"order_by":  opts.OrderBy,
+			"cursor":    opts.Cursor,
+		},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) LightBlocks(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+) (*ctypes.ResultLightBlocks, error) {
+	result := new(ctypes.ResultLightBlocks)
+	_, err := c.caller.Call(ctx, "light_blocks",
+		map[string]interface{}{"minHeight": minHeight, "maxHeight": maxHeight},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	result := new(ctypes.ResultGenesis)
 	_, err := c.caller.Call(ctx, "genesis", map[string]interface{}{}, result)
@@ -460,6 +521,19 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	return result, nil
 }
 
+func (c *baseRPCClient) LightBlock(ctx context.Context, height *int64) (*ctypes.ResultLightBlock, error) {
+	result := new(ctypes.ResultLightBlock)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "light_block", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -473,6 +547,15 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctype
 	return result, nil
 }
 
+func (c *baseRPCClient) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	result := new(ctypes.ResultTxProof)
+	_, err := c.caller.Call(ctx, "tx_proof", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
@@ -556,6 +639,23 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorChanges(
+	ctx context.Context,
+	from int64,
+	to *int64,
+) (*ctypes.ResultValidatorChanges, error) {
+	result := new(ctypes.ResultValidatorChanges)
+	params := map[string]interface{}{"from": from}
+	if to != nil {
+		params["to"] = to
+	}
+	_, err := c.caller.Call(ctx, "validator_changes", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -567,3 +667,12 @@ func (c *baseRPCClient) BroadcastEvidence(
 	}
 	return result, nil
 }
+
+func (c *baseRPCClient) Evidence(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	result := new(ctypes.ResultEvidence)
+	_, err := c.caller.Call(ctx, "evidence", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
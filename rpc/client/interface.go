@@ -59,6 +59,9 @@ type ABCIClient interface {
 	BroadcastTxCommit(context.Context, types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 	BroadcastTxAsync(context.Context, types.Tx) (*ctypes.ResultBroadcastTx, error)
 	BroadcastTxSync(context.Context, types.Tx) (*ctypes.ResultBroadcastTx, error)
+	// BroadcastTxBatch checks a batch of txs in one call, and returns the
+	// CheckTx result of each in the order of the txs.
+	BroadcastTxBatch(context.Context, types.Txs) (*ctypes.ResultBroadcastTxBatch, error)
 }
 
 // SignClient groups together the functionality needed to get valid signatures
@@ -68,9 +71,17 @@ type SignClient interface {
 	BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	LightBlock(ctx context.Context, height *int64) (*ctypes.ResultLightBlock, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
+	// ValidatorChanges returns the changes of the validator set between the
+	// heights from and to, or the latest height if to is nil.
+	ValidatorChanges(ctx context.Context, from int64, to *int64) (*ctypes.ResultValidatorChanges, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
+	// TxProof returns a tx and its result, with the proofs of their inclusion
+	// in the block of the tx.
+	TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error)
+
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// DeliverTx event search criteria.
 	TxSearch(
@@ -96,6 +107,11 @@ type HistoryClient interface {
 	Genesis(context.Context) (*ctypes.ResultGenesis, error)
 	GenesisChunked(context.Context, uint) (*ctypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	// BlockchainInfoWithOptions returns the headers matching the filters of
+	// opts, page by page, see ResultBlockchainInfo.NextCursor.
+	BlockchainInfoWithOptions(ctx context.Context, minHeight, maxHeight int64,
+		opts BlockchainInfoOptions) (*ctypes.ResultBlockchainInfo, error)
+	LightBlocks(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error)
 }
 
 // StatusClient provides access to general chain info.
@@ -108,6 +124,7 @@ type StatusClient interface {
 type NetworkClient interface {
 	NetInfo(context.Context) (*ctypes.ResultNetInfo, error)
 	DumpConsensusState(context.Context) (*ctypes.ResultDumpConsensusState, error)
+	DumpConsensusStateV2(context.Context) (*ctypes.ResultDumpConsensusStateV2, error)
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
 	Health(context.Context) (*ctypes.ResultHealth, error)
@@ -141,6 +158,7 @@ type MempoolClient interface {
 // behavior.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	Evidence(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
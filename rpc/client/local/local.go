@@ -97,6 +97,10 @@ func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resul
 	return c.env.BroadcastTxSync(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	return c.env.BroadcastTxBatch(c.ctx, txs)
+}
+
 func (c *Local) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.env.UnconfirmedTxs(c.ctx, limit)
 }
@@ -117,6 +121,10 @@ func (c *Local) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConse
 	return c.env.DumpConsensusState(c.ctx)
 }
 
+func (c *Local) DumpConsensusStateV2(ctx context.Context) (*ctypes.ResultDumpConsensusStateV2, error) {
+	return c.env.DumpConsensusStateV2(c.ctx)
+}
+
 func (c *Local) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	return c.env.GetConsensusState(c.ctx)
 }
@@ -144,7 +152,17 @@ func (c *Local) DialPeers(
 }
 
 func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
-	return c.env.BlockchainInfo(c.ctx, minHeight, maxHeight)
+	return c.BlockchainInfoWithOptions(ctx, minHeight, maxHeight, rpcclient.BlockchainInfoOptions{})
+}
+
+func (c *Local) BlockchainInfoWithOptions(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	opts rpcclient.BlockchainInfoOptions,
+) (*ctypes.ResultBlockchainInfo, error) {
+	return c.env.BlockchainInfo(c.ctx, minHeight, maxHeight,
+		opts.Proposer, opts.HasTxs, opts.MinTime, opts.MaxTime, opts.OrderBy, opts.Cursor)
 }
 
 func (c *Local) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
@@ -171,14 +189,30 @@ func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 	return c.env.Commit(c.ctx, height)
 }
 
+func (c *Local) LightBlock(ctx context.Context, height *int64) (*ctypes.ResultLightBlock, error) {
+	return c.env.LightBlock(c.ctx, height)
+}
+
+func (c *Local) LightBlocks(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error) {
+	return c.env.LightBlocks(c.ctx, minHeight, maxHeight)
+}
+
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(c.ctx, height, page, perPage)
 }
 
+func (c *Local) ValidatorChanges(ctx context.Context, from int64, to *int64) (*ctypes.ResultValidatorChanges, error) {
+	return c.env.ValidatorChanges(c.ctx, &from, to)
+}
+
 func (c *Local) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	return c.env.Tx(c.ctx, hash, prove)
 }
 
+func (c *Local) TxProof(ctx context.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	return c.env.TxProof(c.ctx, hash)
+}
+
 func (c *Local) TxSearch(
 	_ context.Context,
 	query string,
@@ -203,6 +237,10 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctyp
 	return c.env.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) Evidence(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return c.env.Evidence(c.ctx, hash)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
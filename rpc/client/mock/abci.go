@@ -90,6 +90,22 @@ func (a ABCIApp) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	}, nil
 }
 
+func (a ABCIApp) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	results := make([]ctypes.ResultBroadcastBatchTx, 0, len(txs))
+	for _, tx := range txs {
+		res, err := a.BroadcastTxSync(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		status := ctypes.BatchTxAccepted
+		if res.Code != abci.CodeTypeOK {
+			status = ctypes.BatchTxRejected
+		}
+		results = append(results, ctypes.ResultBroadcastBatchTx{Status: status, ResultBroadcastTx: *res})
+	}
+	return &ctypes.ResultBroadcastTxBatch{Txs: results}, nil
+}
+
 // ABCIMock will send all abci related request to the named app,
 // so you can test app behavior from a client without needing
 // an entire tendermint node
@@ -98,6 +114,7 @@ type ABCIMock struct {
 	Query           Call
 	BroadcastCommit Call
 	Broadcast       Call
+	BroadcastBatch  Call
 }
 
 func (m ABCIMock) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
@@ -149,6 +166,14 @@ func (m ABCIMock) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Res
 	return res.(*ctypes.ResultBroadcastTx), nil
 }
 
+func (m ABCIMock) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	res, err := m.BroadcastBatch.GetResponse(txs)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ctypes.ResultBroadcastTxBatch), nil
+}
+
 // ABCIRecorder can wrap another type (ABCIApp, ABCIMock, or Client)
 // and record all ABCI related calls.
 type ABCIRecorder struct {
@@ -239,3 +264,14 @@ func (r *ABCIRecorder) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctype
 	})
 	return res, err
 }
+
+func (r *ABCIRecorder) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	res, err := r.Client.BroadcastTxBatch(ctx, txs)
+	r.addCall(Call{
+		Name:     "broadcast_tx_batch",
+		Args:     txs,
+		Response: res,
+		Error:    err,
+	})
+	return res, err
+}
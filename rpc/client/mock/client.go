@@ -116,6 +116,10 @@ func (c Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resul
 	return c.env.BroadcastTxSync(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxBatch(ctx context.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	return c.env.BroadcastTxBatch(&rpctypes.Context{}, txs)
+}
+
 func (c Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.env.CheckTx(&rpctypes.Context{}, tx)
 }
@@ -132,6 +136,10 @@ func (c Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConse
 	return c.env.DumpConsensusState(&rpctypes.Context{})
 }
 
+func (c Client) DumpConsensusStateV2(ctx context.Context) (*ctypes.ResultDumpConsensusStateV2, error) {
+	return c.env.DumpConsensusStateV2(&rpctypes.Context{})
+}
+
 func (c Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	return c.env.ConsensusParams(&rpctypes.Context{}, height)
 }
@@ -155,7 +163,17 @@ func (c Client) DialPeers(
 }
 
 func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
-	return c.env.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
+	return c.BlockchainInfoWithOptions(ctx, minHeight, maxHeight, client.BlockchainInfoOptions{})
+}
+
+func (c Client) BlockchainInfoWithOptions(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	opts client.BlockchainInfoOptions,
+) (*ctypes.ResultBlockchainInfo, error) {
+	return c.env.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight,
+		opts.Proposer, opts.HasTxs, opts.MinTime, opts.MaxTime, opts.OrderBy, opts.Cursor)
 }
 
 func (c Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
@@ -174,10 +192,26 @@ func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit
 	return c.env.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) LightBlock(ctx context.Context, height *int64) (*ctypes.ResultLightBlock, error) {
+	return c.env.LightBlock(&rpctypes.Context{}, height)
+}
+
+func (c Client) LightBlocks(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error) {
+	return c.env.LightBlocks(&rpctypes.Context{}, minHeight, maxHeight)
+}
+
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
 	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
 }
 
+func (c Client) ValidatorChanges(ctx context.Context, from int64, to *int64) (*ctypes.ResultValidatorChanges, error) {
+	return c.env.ValidatorChanges(&rpctypes.Context{}, &from, to)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) Evidence(ctx context.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	return c.env.Evidence(&rpctypes.Context{}, hash)
+}
@@ -206,6 +206,29 @@ func (_m *Client) BlockchainInfo(ctx context.Context, minHeight int64, maxHeight
 	return r0, r1
 }
 
+// BlockchainInfoWithOptions provides a mock function with given fields: ctx, minHeight, maxHeight, opts
+func (_m *Client) BlockchainInfoWithOptions(ctx context.Context, minHeight int64, maxHeight int64, opts client.BlockchainInfoOptions) (*coretypes.ResultBlockchainInfo, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight, opts)
+
+	var r0 *coretypes.ResultBlockchainInfo
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, client.BlockchainInfoOptions) *coretypes.ResultBlockchainInfo); ok {
+		r0 = rf(ctx, minHeight, maxHeight, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockchainInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, client.BlockchainInfoOptions) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastEvidence provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastEvidence(_a0 context.Context, _a1 types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	ret := _m.Called(_a0, _a1)
@@ -275,6 +298,52 @@ func (_m *Client) BroadcastTxCommit(_a0 context.Context, _a1 types.Tx) (*coretyp
 	return r0, r1
 }
 
+// BroadcastTxBatch provides a mock function with given fields: _a0, _a1
+func (_m *Client) BroadcastTxBatch(_a0 context.Context, _a1 types.Txs) (*coretypes.ResultBroadcastTxBatch, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *coretypes.ResultBroadcastTxBatch
+	if rf, ok := ret.Get(0).(func(context.Context, types.Txs) *coretypes.ResultBroadcastTxBatch); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTxBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Txs) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidatorChanges provides a mock function with given fields: ctx, from, to
+func (_m *Client) ValidatorChanges(ctx context.Context, from int64, to *int64) (*coretypes.ResultValidatorChanges, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 *coretypes.ResultValidatorChanges
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64) *coretypes.ResultValidatorChanges); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorChanges)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *int64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastTxSync provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastTxSync(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	ret := _m.Called(_a0, _a1)
@@ -413,6 +482,52 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// DumpConsensusStateV2 provides a mock function with given fields: _a0
+func (_m *Client) DumpConsensusStateV2(_a0 context.Context) (*coretypes.ResultDumpConsensusStateV2, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultDumpConsensusStateV2
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultDumpConsensusStateV2); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultDumpConsensusStateV2)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Evidence provides a mock function with given fields: ctx, hash
+func (_m *Client) Evidence(ctx context.Context, hash []byte) (*coretypes.ResultEvidence, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultEvidence); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -496,6 +611,52 @@ func (_m *Client) IsRunning() bool {
 	return r0
 }
 
+// LightBlock provides a mock function with given fields: ctx, height
+func (_m *Client) LightBlock(ctx context.Context, height *int64) (*coretypes.ResultLightBlock, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultLightBlock
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultLightBlock); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultLightBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LightBlocks provides a mock function with given fields: ctx, minHeight, maxHeight
+func (_m *Client) LightBlocks(ctx context.Context, minHeight int64, maxHeight int64) (*coretypes.ResultLightBlocks, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight)
+
+	var r0 *coretypes.ResultLightBlocks
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *coretypes.ResultLightBlocks); ok {
+		r0 = rf(ctx, minHeight, maxHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultLightBlocks)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -728,6 +889,29 @@ func (_m *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.R
 	return r0, r1
 }
 
+// TxProof provides a mock function with given fields: ctx, hash
+func (_m *Client) TxProof(ctx context.Context, hash []byte) (*coretypes.ResultTxProof, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxProof
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultTxProof); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
 func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
@@ -150,6 +150,20 @@ func TestDumpConsensusState(t *testing.T) {
 	}
 }
 
+func TestDumpConsensusStateV2(t *testing.T) {
+	for i, c := range GetClients(t, NodeSuite(t)) {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		cons, err := nc.DumpConsensusStateV2(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.EqualValues(t, ctypes.DumpConsensusStateVersion, cons.State.Version)
+		assert.NotZero(t, cons.State.RoundState.Height)
+		assert.NotEmpty(t, cons.State.RoundState.ProposerAddress)
+		assert.NotEmpty(t, cons.State.RoundState.Votes)
+		assert.Empty(t, cons.State.Peers)
+	}
+}
+
 func TestConsensusState(t *testing.T) {
 	for i, c := range GetClients(t, NodeSuite(t)) {
 		// FIXME: fix server so it doesn't panic on invalid input
@@ -192,6 +206,15 @@ func TestGenesisAndValidators(t *testing.T) {
 		// make sure the current set is also the genesis set
 		assert.Equal(t, gval.Power, val.VotingPower)
 		assert.Equal(t, gval.PubKey, val.PubKey)
+
+		// the validator set hasn't changed since
+		changes, err := c.ValidatorChanges(context.Background(), h, nil)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, h, changes.FromHeight)
+		assert.GreaterOrEqual(t, changes.ToHeight, h)
+		assert.Empty(t, changes.Joined)
+		assert.Empty(t, changes.Left)
+		assert.Empty(t, changes.PowerChanges)
 	}
 }
 
@@ -365,6 +388,88 @@ func TestBlockchainInfo(t *testing.T) {
 		require.NotNil(t, err)
 		assert.Nil(t, res)
 		assert.Contains(t, err.Error(), "can't be greater than max")
+
+		// the blocks 2 to 4 without txs, by time and in ascending order
+		res, err = c.BlockchainInfo(context.Background(), 1, 4)
+		require.NoError(t, err)
+		require.Len(t, res.BlockMetas, 4)
+		want := []int64{}
+		for i := len(res.BlockMetas) - 2; i >= 0; i-- {
+			if res.BlockMetas[i].NumTxs == 0 {
+				want = append(want, res.BlockMetas[i].Header.Height)
+			}
+		}
+		noTxs := false
+		res, err = c.BlockchainInfoWithOptions(context.Background(), 0, 0, client.BlockchainInfoOptions{
+			Proposer: res.BlockMetas[2].Header.ProposerAddress,
+			HasTxs:   &noTxs,
+			MinTime:  res.BlockMetas[2].Header.Time,
+			MaxTime:  res.BlockMetas[0].Header.Time,
+			OrderBy:  "asc",
+		})
+		require.NoError(t, err, "%d", i)
+		heights := []int64{}
+		for _, m := range res.BlockMetas {
+			heights = append(heights, m.Header.Height)
+		}
+		assert.Equal(t, want, heights)
+		assert.Zero(t, res.NextCursor)
+	}
+}
+
+func TestLightBlock(t *testing.T) {
+	for i, c := range GetClients(t, NodeSuite(t)) {
+		err := client.WaitForHeight(c, 3, nil)
+		require.NoError(t, err)
+
+		h := int64(2)
+		res, err := c.LightBlock(context.Background(), &h)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.EqualValues(t, h, res.Height)
+		lb, err := res.DecodeLightBlock()
+		require.NoError(t, err)
+		require.NoError(t, lb.ValidateBasic(lb.ChainID))
+
+		commit, err := c.Commit(context.Background(), &h)
+		require.NoError(t, err)
+		assert.Equal(t, commit.Header.Hash(), lb.Hash())
+		vals, err := c.Validators(context.Background(), &h, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, vals.Validators, lb.ValidatorSet.Validators)
+
+		// latest height
+		res, err = c.LightBlock(context.Background(), nil)
+		require.Nil(t, err, "%d: %+v", i, err)
+		_, err = res.DecodeLightBlock()
+		require.NoError(t, err)
+	}
+}
+
+func TestLightBlocks(t *testing.T) {
+	for i, c := range GetClients(t, NodeSuite(t)) {
+		err := client.WaitForHeight(c, 5, nil)
+		require.NoError(t, err)
+
+		res, err := c.LightBlocks(context.Background(), 2, 4)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, res.LastHeight >= 5)
+		require.Len(t, res.LightBlocks, 3)
+		for j, r := range res.LightBlocks {
+			assert.EqualValues(t, 2+j, r.Height)
+			lb, err := r.DecodeLightBlock()
+			require.NoError(t, err)
+			require.NoError(t, lb.ValidateBasic(lb.ChainID))
+			assert.EqualValues(t, 2+j, lb.Height)
+		}
+
+		res, err = c.LightBlocks(context.Background(), 1, 10000)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, len(res.LightBlocks) <= 20)
+
+		res, err = c.LightBlocks(context.Background(), 10000, 1)
+		require.NotNil(t, err)
+		assert.Nil(t, res)
+		assert.Contains(t, err.Error(), "can't be greater than max")
 	}
 }
 
@@ -391,6 +496,42 @@ func TestBroadcastTxSync(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxBatch(t *testing.T) {
+	n := NodeSuite(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mempool := n.Mempool()
+	initMempoolSize := mempool.Size()
+
+	for i, c := range GetClients(t, n) {
+		_, _, tx1 := MakeTxKV()
+		_, _, tx2 := MakeTxKV()
+		bres, err := c.BroadcastTxBatch(ctx, types.Txs{tx1, tx2})
+		require.NoError(t, err, "%d", i)
+		require.Len(t, bres.Txs, 2)
+		for j, tx := range []types.Tx{tx1, tx2} {
+			assert.Equal(t, ctypes.BatchTxAccepted, bres.Txs[j].Status)
+			assert.Equal(t, abci.CodeTypeOK, bres.Txs[j].Code)
+			assert.EqualValues(t, tx.Hash(), bres.Txs[j].Hash)
+		}
+		require.Equal(t, initMempoolSize+2, mempool.Size())
+
+		// resubmitting a tx
+		_, _, tx3 := MakeTxKV()
+		bres, err = c.BroadcastTxBatch(ctx, types.Txs{tx3, tx1})
+		require.NoError(t, err, "%d", i)
+		assert.Equal(t, ctypes.BatchTxAccepted, bres.Txs[0].Status)
+		assert.Equal(t, ctypes.BatchTxCached, bres.Txs[1].Status)
+		require.Equal(t, initMempoolSize+3, mempool.Size())
+
+		_, err = c.BroadcastTxBatch(ctx, types.Txs{})
+		assert.Error(t, err)
+
+		mempool.Flush()
+	}
+}
+
 func TestBroadcastTxCommit(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -547,6 +688,39 @@ func TestTx(t *testing.T) {
 	}
 }
 
+func TestTxProof(t *testing.T) {
+	n := NodeSuite(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := getHTTPClient(t, n)
+	_, _, tx := MakeTxKV()
+	bres, err := c.BroadcastTxCommit(ctx, tx)
+	require.NoError(t, err)
+
+	// the results are in the header of the next block
+	require.NoError(t, client.WaitForHeight(c, bres.Height+1, nil))
+	block, err := c.Block(ctx, &bres.Height)
+	require.NoError(t, err)
+	nextHeight := bres.Height + 1
+	next, err := c.Block(ctx, &nextHeight)
+	require.NoError(t, err)
+
+	for i, c := range GetClients(t, n) {
+		t.Logf("client %d", i)
+		res, err := c.TxProof(ctx, bres.Hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, bres.Height, res.Height)
+		assert.EqualValues(t, tx, res.Tx)
+		assert.True(t, res.TxResult.IsOK())
+		assert.EqualValues(t, next.Block.LastResultsHash, res.ResultsHash)
+		assert.NoError(t, res.Verify(block.Block.DataHash, next.Block.LastResultsHash))
+
+		_, err = c.TxProof(ctx, types.Tx("a different tx").Hash())
+		assert.Error(t, err)
+	}
+}
+
 func TestTxSearchWithTimeout(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
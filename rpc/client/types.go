@@ -1,5 +1,11 @@
 package client
 
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+)
+
 // ABCIQueryOptions can be used to provide options for ABCIQuery call other
 // than the DefaultABCIQueryOptions.
 type ABCIQueryOptions struct {
@@ -9,3 +15,15 @@ type ABCIQueryOptions struct {
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
 var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
+
+// BlockchainInfoOptions filter and order the headers returned by
+// BlockchainInfoWithOptions. The zero value filters nothing and orders the
+// headers by descending height.
+type BlockchainInfoOptions struct {
+	Proposer bytes.HexBytes // only the blocks proposed by this validator
+	HasTxs   *bool          // only the blocks with txs if true, without if false
+	MinTime  time.Time      // only the blocks at or after this time
+	MaxTime  time.Time      // only the blocks at or before this time
+	OrderBy  string         // "asc" or "desc"
+	Cursor   int64          // height to resume from, the NextCursor of the previous result
+}
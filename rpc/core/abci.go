@@ -1,6 +1,9 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/proxy"
@@ -9,6 +12,13 @@ import (
 )
 
 // ABCIQuery queries the application for some information.
+//
+// If the application supports historical queries, queries without a height are
+// sent for the latest committed height, so they see a consistent state of the
+// application, and queries for heights which were not committed yet are
+// rejected. Queries with proofs are rejected if the application advertised
+// that it doesn't return them. Identical concurrent queries are sent to the
+// application once.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_query
 func (env *Environment) ABCIQuery(
 	ctx *rpctypes.Context,
@@ -17,7 +27,25 @@ func (env *Environment) ABCIQuery(
 	height int64,
 	prove bool,
 ) (*ctypes.ResultABCIQuery, error) {
-	resQuery, err := env.ProxyAppQuery.QuerySync(ctx.Context(), abci.RequestQuery{
+	querier := env.abciQuerier()
+	if prove && !querier.queryProofs(ctx.Context()) {
+		return nil, errors.New("the application doesn't return proofs of query results")
+	}
+	if querier.historicalQueries(ctx.Context()) {
+		state, err := env.StateStore.Load()
+		if err != nil {
+			return nil, err
+		}
+		switch lastHeight := state.LastBlockHeight; {
+		case height == 0:
+			height = lastHeight
+		case height > lastHeight:
+			return nil, fmt.Errorf("%w (requested height: %d, committed height: %d)",
+				ctypes.ErrHeightExceedsChainHead, height, lastHeight)
+		}
+	}
+
+	resQuery, err := querier.query(ctx.Context(), abci.RequestQuery{
 		Path:   path,
 		Data:   data,
 		Height: height,
@@ -26,10 +54,24 @@ func (env *Environment) ABCIQuery(
 	if err != nil {
 		return nil, err
 	}
+	// If the application failed to answer a query for a height the node pruned
+	// the block of, the application most likely pruned its state too.
+	if resQuery.IsErr() && height > 0 {
+		if err := env.checkBlockPruned(height); err != nil {
+			return nil, fmt.Errorf("%w (query failed: %s)", err, resQuery.Log)
+		}
+	}
 	env.Logger.Info("ABCIQuery", "path", path, "data", data, "result", resQuery)
 	return &ctypes.ResultABCIQuery{Response: *resQuery}, nil
 }
 
+func (env *Environment) abciQuerier() *abciQuerier {
+	env.querierOnce.Do(func() {
+		env.querier = newABCIQuerier(env.ProxyAppQuery, env.Config.MaxConcurrentABCIQueries)
+	})
+	return env.querier
+}
+
 // ABCIInfo gets some info about the application.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_info
 func (env *Environment) ABCIInfo(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+type abciQueryKey struct {
+	path   string
+	data   string
+	height int64
+	prove  bool
+}
+
+type abciQueryCall struct {
+	done chan struct{}
+	res  *abci.ResponseQuery
+	err  error
+}
+
+// abciQuerier sends queries to the application. Identical concurrent queries
+// are coalesced into a single one, and the number of queries the application
+// handles concurrently can be limited.
+type abciQuerier struct {
+	app proxy.AppConnQuery
+	// nil if the number of concurrent queries is unlimited
+	sem chan struct{}
+
+	mtx      sync.Mutex
+	inflight map[abciQueryKey]*abciQueryCall
+	// nil until the application answered Info
+	info *abci.ResponseInfo
+}
+
+func newABCIQuerier(app proxy.AppConnQuery, maxConcurrent int) *abciQuerier {
+	q := &abciQuerier{
+		app:      app,
+		inflight: make(map[abciQueryKey]*abciQueryCall),
+	}
+	if maxConcurrent > 0 {
+		q.sem = make(chan struct{}, maxConcurrent)
+	}
+	return q
+}
+
+// appInfo returns the Info response of the application, which is cached once
+// known. It returns nil if the application failed to answer.
+func (q *abciQuerier) appInfo(ctx context.Context) *abci.ResponseInfo {
+	q.mtx.Lock()
+	info := q.info
+	q.mtx.Unlock()
+	if info != nil {
+		return info
+	}
+
+	info, err := q.app.InfoSync(ctx, proxy.RequestInfo)
+	if err != nil {
+		return nil
+	}
+
+	q.mtx.Lock()
+	q.info = info
+	q.mtx.Unlock()
+	return info
+}
+
+// historicalQueries returns whether the application can answer queries at
+// past heights, as reported by Info.
+func (q *abciQuerier) historicalQueries(ctx context.Context) bool {
+	return q.appInfo(ctx).GetHistoricalQueries()
+}
+
+// queryProofs returns false if the application advertised in Info that it
+// doesn't return proofs of query results, and true if it did or didn't
+// advertise its capabilities.
+func (q *abciQuerier) queryProofs(ctx context.Context) bool {
+	caps := q.appInfo(ctx).GetCapabilities()
+	return caps == nil || caps.QueryProofs
+}
+
+// query sends req to the application, unless an identical query is in flight,
+// in which case it waits for and returns its response.
+func (q *abciQuerier) query(ctx context.Context, req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	key := abciQueryKey{path: req.Path, data: string(req.Data), height: req.Height, prove: req.Prove}
+
+	q.mtx.Lock()
+	if call, ok := q.inflight[key]; ok {
+		q.mtx.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// the query was cancelled by the caller which sent it, not by us
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			return q.query(ctx, req)
+		}
+		return call.res, call.err
+	}
+
+	call := &abciQueryCall{done: make(chan struct{})}
+	q.inflight[key] = call
+	q.mtx.Unlock()
+
+	call.res, call.err = q.send(ctx, req)
+
+	q.mtx.Lock()
+	delete(q.inflight, key)
+	q.mtx.Unlock()
+	close(call.done)
+
+	return call.res, call.err
+}
+
+func (q *abciQuerier) send(ctx context.Context, req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	if q.sem != nil {
+		select {
+		case q.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-q.sem }()
+	}
+	return q.app.QuerySync(ctx, req)
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
+)
+
+func TestABCIQueryHeight(t *testing.T) {
+	testCases := []struct {
+		name       string
+		historical bool
+		height     int64
+		sentHeight int64
+		err        error
+	}{
+		{"latest", false, 0, 0, nil},
+		{"at height", false, 3, 3, nil},
+		{"future height", false, 7, 7, nil},
+		{"historical latest", true, 0, 5, nil},
+		{"historical at height", true, 3, 3, nil},
+		{"historical future height", true, 7, 0, ctypes.ErrHeightExceedsChainHead},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			app := &proxymocks.AppConnQuery{}
+			app.On("InfoSync", mock.Anything, mock.Anything).
+				Return(&abci.ResponseInfo{HistoricalQueries: tc.historical}, nil)
+			app.On("QuerySync", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, req abci.RequestQuery) *abci.ResponseQuery {
+					return &abci.ResponseQuery{Height: req.Height}
+				}, nil)
+			stateStore := &smmocks.Store{}
+			stateStore.On("Load").Return(sm.State{LastBlockHeight: 5}, nil)
+
+			env := &Environment{ProxyAppQuery: app, StateStore: stateStore, Logger: log.TestingLogger()}
+			res, err := env.ABCIQuery(&rpctypes.Context{}, "/key", []byte("a"), tc.height, false)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.sentHeight, res.Response.Height)
+		})
+	}
+}
+
+func TestABCIQueryProofs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		caps   *abci.Capabilities
+		prove  bool
+		hasErr bool
+	}{
+		{"no capabilities", nil, true, false},
+		{"with proofs", &abci.Capabilities{QueryProofs: true}, true, false},
+		{"without proofs", &abci.Capabilities{}, true, true},
+		{"without proofs, not proven", &abci.Capabilities{}, false, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			app := &proxymocks.AppConnQuery{}
+			app.On("InfoSync", mock.Anything, mock.Anything).
+				Return(&abci.ResponseInfo{Capabilities: tc.caps}, nil)
+			app.On("QuerySync", mock.Anything, mock.Anything).
+				Return(&abci.ResponseQuery{}, nil)
+
+			env := &Environment{ProxyAppQuery: app, Logger: log.TestingLogger()}
+			_, err := env.ABCIQuery(&rpctypes.Context{}, "/key", []byte("a"), 0, tc.prove)
+			if tc.hasErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestABCIQuerierCoalescesQueries(t *testing.T) {
+	release := make(chan struct{})
+	app := &proxymocks.AppConnQuery{}
+	app.On("QuerySync", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(&abci.ResponseQuery{Value: []byte("b")}, nil).
+		Once()
+
+	q := newABCIQuerier(app, 0)
+	req := abci.RequestQuery{Path: "/key", Data: []byte("a"), Height: 1}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := q.query(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("b"), res.Value)
+		}()
+	}
+
+	// give the queries time to arrive before the first one is answered
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	app.AssertNumberOfCalls(t, "QuerySync", 1)
+}
+
+func TestABCIQuerierLimitsConcurrency(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		running int
+		maxRuns int
+	)
+	app := &proxymocks.AppConnQuery{}
+	app.On("QuerySync", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			mtx.Lock()
+			running++
+			if running > maxRuns {
+				maxRuns = running
+			}
+			mtx.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mtx.Lock()
+			running--
+			mtx.Unlock()
+		}).
+		Return(&abci.ResponseQuery{}, nil)
+
+	q := newABCIQuerier(app, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(height int64) {
+			defer wg.Done()
+			_, err := q.query(context.Background(), abci.RequestQuery{Path: "/key", Height: height})
+			assert.NoError(t, err)
+		}(int64(i))
+	}
+	wg.Wait()
+
+	app.AssertNumberOfCalls(t, "QuerySync", 6)
+	assert.LessOrEqual(t, maxRuns, 2)
+
+	// a query waiting for a slot gives up when its context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	q.sem <- struct{}{}
+	q.sem <- struct{}{}
+	cancel()
+	_, err := q.query(ctx, abci.RequestQuery{Path: "/key"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"github.com/btcsuite/btcutil/bech32"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// bech32ValidatorAddress renders a validator address in bech32 with the
+// configured prefix. It returns an empty string if no prefix is configured.
+func (env *Environment) bech32ValidatorAddress(address []byte) string {
+	return env.bech32(env.Config.Bech32ValidatorPrefix, address)
+}
+
+// bech32NodeID renders a node ID in bech32 with the configured prefix. It
+// returns an empty string if no prefix is configured.
+func (env *Environment) bech32NodeID(id p2p.NodeID) string {
+	if env.Config.Bech32NodeIDPrefix == "" {
+		return ""
+	}
+	bz, err := id.Bytes()
+	if err != nil {
+		env.Logger.Error("failed to decode node ID", "id", id, "err", err)
+		return ""
+	}
+	return env.bech32(env.Config.Bech32NodeIDPrefix, bz)
+}
+
+func (env *Environment) bech32(prefix string, data []byte) string {
+	if prefix == "" {
+		return ""
+	}
+	converted, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		env.Logger.Error("failed to convert to bech32", "err", err)
+		return ""
+	}
+	encoded, err := bech32.Encode(prefix, converted)
+	if err != nil {
+		env.Logger.Error("failed to encode bech32", "prefix", prefix, "err", err)
+		return ""
+	}
+	return encoded
+}
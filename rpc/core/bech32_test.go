@@ -0,0 +1,33 @@
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestBech32(t *testing.T) {
+	address, err := hex.DecodeString("5D6A51A8E9899C44079C6AF90618BA0369070E6E")
+	require.NoError(t, err)
+	nodeID := p2p.NodeID("d51fb70907db1c6c2d5237e78379b25cf1a37ab4")
+
+	env := &Environment{Logger: log.TestingLogger()}
+	assert.Empty(t, env.bech32ValidatorAddress(address))
+	assert.Empty(t, env.bech32NodeID(nodeID))
+
+	env.Config = cfg.RPCConfig{
+		Bech32ValidatorPrefix: "tmvaloper",
+		Bech32NodeIDPrefix:    "tmnode",
+	}
+	assert.Equal(t, "tmvaloper1t449r28f3xwygpuudtusvx96qd5swrnwhjj4nk", env.bech32ValidatorAddress(address))
+	assert.Equal(t, "tmnode1650mwzg8mvwxct2jxlncx7djtnc6x745aeypqs", env.bech32NodeID(nodeID))
+
+	// invalid node IDs are not rendered
+	assert.Empty(t, env.bech32NodeID(p2p.NodeID("zz")))
+}
@@ -1,13 +1,18 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"sort"
+	"time"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/types"
 )
@@ -18,39 +23,117 @@ import (
 // returned. If minHeight does not exist (due to pruning), earliest existing
 // height will be used.
 //
+// The headers can be filtered by proposer, by whether the block has txs and
+// by time range. Block times increase with the height, so the time range
+// narrows the height range down before the other filters are applied.
+//
 // At most 20 items will be returned. Block headers are returned in descending
-// order (highest first).
+// order (highest first), or ascending order if orderBy is "asc". If there are
+// more headers to return, or the node stopped looking for matching headers
+// after scanning maxBlockchainScan blocks, NextCursor is set to the height to
+// pass as cursor to get the next ones.
 //
 // More: https://docs.tendermint.com/master/rpc/#/Info/blockchain
 func (env *Environment) BlockchainInfo(
 	ctx *rpctypes.Context,
-	minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	minHeight, maxHeight int64,
+	proposer tmbytes.HexBytes,
+	hasTxs *bool,
+	minTime, maxTime time.Time,
+	orderBy string,
+	cursor int64,
+) (*ctypes.ResultBlockchainInfo, error) {
 
-	const limit int64 = 20
+	const limit int = 20
 
+	// the range isn't limited, only the number of headers returned is
 	var err error
 	minHeight, maxHeight, err = filterMinMax(
 		env.BlockStore.Base(),
 		env.BlockStore.Height(),
 		minHeight,
 		maxHeight,
-		limit)
+		math.MaxInt64)
 	if err != nil {
 		return nil, err
 	}
+
+	var desc bool
+	switch orderBy {
+	case "desc", "":
+		desc = true
+	case "asc":
+		desc = false
+	default:
+		return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", ctypes.ErrInvalidRequest)
+	}
+
+	if cursor < 0 {
+		return nil, fmt.Errorf("%w: negative cursor", ctypes.ErrInvalidRequest)
+	}
+	if cursor > 0 {
+		if desc {
+			maxHeight = tmmath.MinInt64(maxHeight, cursor)
+		} else {
+			minHeight = tmmath.MaxInt64(minHeight, cursor)
+		}
+	}
+
+	if !minTime.IsZero() {
+		minHeight = env.searchBlockTime(minHeight, maxHeight, func(t time.Time) bool { return !t.Before(minTime) })
+	}
+	if !maxTime.IsZero() {
+		maxHeight = env.searchBlockTime(minHeight, maxHeight, func(t time.Time) bool { return t.After(maxTime) }) - 1
+	}
 	env.Logger.Debug("BlockchainInfo", "maxHeight", maxHeight, "minHeight", minHeight)
 
-	blockMetas := make([]*types.BlockMeta, 0, maxHeight-minHeight+1)
-	for height := maxHeight; height >= minHeight; height-- {
+	height, step := minHeight, int64(1)
+	if desc {
+		height, step = maxHeight, -1
+	}
+	blockMetas := make([]*types.BlockMeta, 0, limit)
+	for scanned := 0; height >= minHeight && height <= maxHeight; height += step {
+		if len(blockMetas) == limit || scanned == maxBlockchainScan {
+			break
+		}
+		scanned++
 		blockMeta := env.BlockStore.LoadBlockMeta(height)
-		if blockMeta != nil {
-			blockMetas = append(blockMetas, blockMeta)
+		if blockMeta == nil {
+			continue
 		}
+		if len(proposer) > 0 && !bytes.Equal(blockMeta.Header.ProposerAddress, proposer) {
+			continue
+		}
+		if hasTxs != nil && (blockMeta.NumTxs > 0) != *hasTxs {
+			continue
+		}
+		blockMetas = append(blockMetas, blockMeta)
+	}
+
+	var nextCursor int64
+	if height >= minHeight && height <= maxHeight {
+		nextCursor = height
 	}
 
 	return &ctypes.ResultBlockchainInfo{
 		LastHeight: env.BlockStore.Height(),
-		BlockMetas: blockMetas}, nil
+		BlockMetas: blockMetas,
+		NextCursor: nextCursor}, nil
+}
+
+// searchBlockTime returns the lowest height in [min, max] of the block whose
+// time satisfies f, or max+1 if there is none. f must be false for the times
+// of the blocks below that height and true from it on, which holds for
+// conditions on the time since block times increase with the height.
+func (env *Environment) searchBlockTime(min, max int64, f func(time.Time) bool) int64 {
+	if min > max {
+		return max + 1
+	}
+	i := sort.Search(int(max-min+1), func(i int) bool {
+		blockMeta := env.BlockStore.LoadBlockMeta(min + int64(i))
+		return blockMeta == nil || f(blockMeta.Header.Time)
+	})
+	return min + int64(i)
 }
 
 // error if either min or max are negative or min > max
@@ -143,6 +226,100 @@ func (env *Environment) Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes
 	return ctypes.NewResultCommit(&header, commit, true), nil
 }
 
+// LightBlock gets the signed header and validator set at a given height in a
+// single protobuf encoded tendermint.types.LightBlock.
+// If no height is provided, it will fetch the light block for the latest block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/light_block
+func (env *Environment) LightBlock(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultLightBlock, error) {
+	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := env.loadLightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.NewResultLightBlock(lb)
+}
+
+// LightBlocks gets the light blocks for minHeight <= height <= maxHeight.
+//
+// If maxHeight does not yet exist, light blocks up to the current height will
+// be returned. If minHeight does not exist (due to pruning), earliest existing
+// height will be used.
+//
+// At most 20 items will be returned. Light blocks are returned in ascending
+// order (lowest first).
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/light_blocks
+func (env *Environment) LightBlocks(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64) (*ctypes.ResultLightBlocks, error) {
+
+	const limit int64 = 20
+
+	var err error
+	minHeight, maxHeight, err = filterMinMax(
+		env.BlockStore.Base(),
+		env.BlockStore.Height(),
+		minHeight,
+		maxHeight,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	lightBlocks := make([]ctypes.ResultLightBlock, 0, maxHeight-minHeight+1)
+	for height := minHeight; height <= maxHeight; height++ {
+		lb, err := env.loadLightBlock(height)
+		if err != nil {
+			return nil, err
+		}
+		res, err := ctypes.NewResultLightBlock(lb)
+		if err != nil {
+			return nil, err
+		}
+		lightBlocks = append(lightBlocks, *res)
+	}
+
+	return &ctypes.ResultLightBlocks{
+		LastHeight:  env.BlockStore.Height(),
+		LightBlocks: lightBlocks}, nil
+}
+
+// loadLightBlock loads the header, commit and validator set at height. Like
+// Commit, it uses the seen commit for the latest height.
+func (env *Environment) loadLightBlock(height int64) (*types.LightBlock, error) {
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+
+	var commit *types.Commit
+	if height == env.BlockStore.Height() {
+		commit = env.BlockStore.LoadSeenCommit(height)
+	} else {
+		commit = env.BlockStore.LoadBlockCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("commit at height %d not found", height)
+	}
+
+	vals, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &blockMeta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}, nil
+}
+
 // BlockResults gets ABCIResults at a given height.
 // If no height is provided, it will fetch results for the latest block.
 //
@@ -155,15 +332,21 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 	if err != nil {
 		return nil, err
 	}
+	if err := env.checkResultsPruned(height); err != nil {
+		return nil, err
+	}
 
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
 		return nil, err
 	}
 
+	gasWanted, gasUsed := sm.BlockGas(results)
 	return &ctypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
+		TotalGasWanted:        gasWanted,
+		TotalGasUsed:          gasUsed,
 		BeginBlockEvents:      results.BeginBlock.Events,
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +12,10 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -69,12 +75,124 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 }
 
+func TestBlockchainInfoFilters(t *testing.T) {
+	genesisTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	proposerA, proposerB := tmrand.Bytes(crypto.AddressSize), tmrand.Bytes(crypto.AddressSize)
+
+	// the blocks of height divisible by 3 are proposed by A, the even ones have txs
+	store := metaBlockStore{mockBlockStore: mockBlockStore{height: 30}, metas: map[int64]*types.BlockMeta{}}
+	for height := int64(1); height <= 30; height++ {
+		meta := &types.BlockMeta{Header: types.Header{
+			Height:          height,
+			Time:            genesisTime.Add(time.Duration(height) * time.Second),
+			ProposerAddress: proposerB,
+		}}
+		if height%3 == 0 {
+			meta.Header.ProposerAddress = proposerA
+		}
+		if height%2 == 0 {
+			meta.NumTxs = 1
+		}
+		store.metas[height] = meta
+	}
+	env := &Environment{BlockStore: store, Logger: log.TestingLogger()}
+
+	blockTime := func(height int64) time.Time { return genesisTime.Add(time.Duration(height) * time.Second) }
+	withTxs := true
+
+	testCases := []struct {
+		name       string
+		min, max   int64
+		proposer   []byte
+		hasTxs     *bool
+		minTime    time.Time
+		maxTime    time.Time
+		orderBy    string
+		cursor     int64
+		heights    []int64
+		nextCursor int64
+	}{
+		{"first page", 0, 0, nil, nil, time.Time{}, time.Time{}, "", 0, heightRange(30, 11), 10},
+		{"next page", 0, 0, nil, nil, time.Time{}, time.Time{}, "", 10, heightRange(10, 1), 0},
+		{"ascending", 0, 0, nil, nil, time.Time{}, time.Time{}, "asc", 0, heightRange(1, 20), 21},
+		{"ascending next page", 0, 0, nil, nil, time.Time{}, time.Time{}, "asc", 21, heightRange(21, 30), 0},
+		{"proposer and txs", 0, 0, proposerA, &withTxs, time.Time{}, time.Time{}, "asc", 0,
+			[]int64{6, 12, 18, 24, 30}, 0},
+		{"time range", 0, 0, nil, nil, blockTime(5), blockTime(8), "", 0, heightRange(8, 5), 0},
+		{"time range between blocks", 0, 0, nil, nil, blockTime(5).Add(-time.Millisecond),
+			blockTime(8).Add(time.Millisecond), "asc", 0, heightRange(5, 8), 0},
+		{"time range and heights", 6, 20, nil, nil, blockTime(5), blockTime(8), "", 0, heightRange(8, 6), 0},
+		{"time range after the last block", 0, 0, nil, nil, blockTime(31), time.Time{}, "", 0, nil, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := env.BlockchainInfo(&rpctypes.Context{}, tc.min, tc.max,
+				tc.proposer, tc.hasTxs, tc.minTime, tc.maxTime, tc.orderBy, tc.cursor)
+			require.NoError(t, err)
+			heights := []int64{}
+			for _, meta := range res.BlockMetas {
+				heights = append(heights, meta.Header.Height)
+			}
+			if tc.heights == nil {
+				tc.heights = []int64{}
+			}
+			assert.Equal(t, tc.heights, heights)
+			assert.Equal(t, tc.nextCursor, res.NextCursor)
+			assert.EqualValues(t, 30, res.LastHeight)
+		})
+	}
+
+	_, err := env.BlockchainInfo(&rpctypes.Context{}, 0, 0, nil, nil, time.Time{}, time.Time{}, "random", 0)
+	assert.ErrorIs(t, err, ctypes.ErrInvalidRequest)
+	_, err = env.BlockchainInfo(&rpctypes.Context{}, 0, 0, nil, nil, time.Time{}, time.Time{}, "", -1)
+	assert.ErrorIs(t, err, ctypes.ErrInvalidRequest)
+}
+
+func TestBlockchainInfoScanLimit(t *testing.T) {
+	// only the first block has txs
+	const height = maxBlockchainScan + 500
+	store := metaBlockStore{mockBlockStore: mockBlockStore{height: height}, metas: map[int64]*types.BlockMeta{}}
+	for h := int64(1); h <= height; h++ {
+		store.metas[h] = &types.BlockMeta{Header: types.Header{Height: h}}
+	}
+	store.metas[1].NumTxs = 1
+	env := &Environment{BlockStore: store, Logger: log.TestingLogger()}
+	withTxs := true
+
+	// the node stops looking after scanning maxBlockchainScan blocks
+	res, err := env.BlockchainInfo(&rpctypes.Context{}, 0, 0, nil, &withTxs, time.Time{}, time.Time{}, "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, res.BlockMetas)
+	assert.EqualValues(t, height-maxBlockchainScan, res.NextCursor)
+
+	res, err = env.BlockchainInfo(&rpctypes.Context{}, 0, 0, nil, &withTxs, time.Time{}, time.Time{}, "",
+		res.NextCursor)
+	require.NoError(t, err)
+	require.Len(t, res.BlockMetas, 1)
+	assert.EqualValues(t, 1, res.BlockMetas[0].Header.Height)
+	assert.Zero(t, res.NextCursor)
+}
+
+// heightRange returns the heights from one to the other, included.
+func heightRange(from, to int64) []int64 {
+	heights := []int64{}
+	step := int64(1)
+	if from > to {
+		step = -1
+	}
+	for h := from; h != to+step; h += step {
+		heights = append(heights, h)
+	}
+	return heights
+}
+
 func TestBlockResults(t *testing.T) {
 	results := &tmstate.ABCIResponses{
 		DeliverTxs: []*abci.ResponseDeliverTx{
-			{Code: 0, Data: []byte{0x01}, Log: "ok"},
-			{Code: 0, Data: []byte{0x02}, Log: "ok"},
-			{Code: 1, Log: "not ok"},
+			{Code: 0, Data: []byte{0x01}, Log: "ok", GasWanted: 10, GasUsed: 8},
+			{Code: 0, Data: []byte{0x02}, Log: "ok", GasWanted: 10, GasUsed: 10},
+			{Code: 1, Log: "not ok", GasWanted: 5, GasUsed: 1},
 		},
 		EndBlock:   &abci.ResponseEndBlock{},
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -97,6 +215,8 @@ func TestBlockResults(t *testing.T) {
 		{100, false, &ctypes.ResultBlockResults{
 			Height:                100,
 			TxsResults:            results.DeliverTxs,
+			TotalGasWanted:        25,
+			TotalGasUsed:          19,
 			BeginBlockEvents:      results.BeginBlock.Events,
 			EndBlockEvents:        results.EndBlock.Events,
 			ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
@@ -115,11 +235,87 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestHeightPruned(t *testing.T) {
+	results := &tmstate.ABCIResponses{
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}
+
+	// the node state synced to height 10, so it has no results for it
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, env.StateStore.SaveABCIResponses(11, results))
+	env.BlockStore = mockBlockStore{base: 10, height: 11}
+
+	testCases := []struct {
+		height         int64
+		earliestBlock  int64
+		earliestResult int64
+	}{
+		{5, 10, 10},
+		{10, 0, 11},
+		{11, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		_, err := env.Block(&rpctypes.Context{}, &tc.height)
+		checkHeightPruned(t, err, tc.height, tc.earliestBlock)
+
+		_, err = env.BlockResults(&rpctypes.Context{}, &tc.height)
+		checkHeightPruned(t, err, tc.height, tc.earliestResult)
+	}
+}
+
+func TestHeaderOnlyNode(t *testing.T) {
+	// header-only nodes have no consensus, and store the validator sets of the
+	// synced heights only
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	env.BlockStore = mockBlockStore{base: 10, height: 11}
+	vals, _ := factory.RandValidatorSet(2, 10)
+	require.NoError(t, env.StateStore.SaveValidatorSet(10, vals))
+	require.NoError(t, env.StateStore.SaveValidatorSet(11, vals.CopyIncrementProposerPriority(1)))
+
+	res, err := env.Validators(&rpctypes.Context{}, nil, nil, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, res.BlockHeight)
+	assert.Equal(t, vals.CopyIncrementProposerPriority(1).Validators, res.Validators)
+
+	routes := env.GetHeaderRoutes()
+	for _, name := range []string{"status", "commit", "light_block", "light_blocks", "validators"} {
+		assert.Contains(t, routes, name)
+	}
+	for _, name := range []string{"block", "block_results", "tx", "broadcast_tx_sync", "abci_query", "subscribe"} {
+		assert.NotContains(t, routes, name)
+	}
+}
+
+func checkHeightPruned(t *testing.T, err error, height, earliest int64) {
+	t.Helper()
+
+	if earliest == 0 {
+		assert.NoError(t, err)
+		return
+	}
+	var pruned ctypes.ErrHeightPruned
+	require.True(t, errors.As(err, &pruned), err)
+	assert.Equal(t, ctypes.ErrHeightPruned{Height: height, EarliestHeight: earliest}, pruned)
+	assert.ErrorIs(t, err, ctypes.ErrHeightNotAvailable)
+}
+
 type mockBlockStore struct {
+	base   int64
 	height int64
 }
 
-func (mockBlockStore) Base() int64                                       { return 1 }
+func (store mockBlockStore) Base() int64 {
+	if store.base == 0 {
+		return 1
+	}
+	return store.base
+}
+
 func (store mockBlockStore) Height() int64                               { return store.height }
 func (store mockBlockStore) Size() int64                                 { return store.height }
 func (mockBlockStore) LoadBaseMeta() *types.BlockMeta                    { return nil }
@@ -127,8 +323,17 @@ func (mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta       { retur
 func (mockBlockStore) LoadBlock(height int64) *types.Block               { return nil }
 func (mockBlockStore) LoadBlockByHash(hash []byte) *types.Block          { return nil }
 func (mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
+func (mockBlockStore) LoadTxLocation(hash []byte) (int64, uint32, bool)  { return 0, 0, false }
 func (mockBlockStore) LoadBlockCommit(height int64) *types.Commit        { return nil }
 func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { return nil }
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
+
+// metaBlockStore is a mockBlockStore holding block metas.
+type metaBlockStore struct {
+	mockBlockStore
+	metas map[int64]*types.BlockMeta
+}
+
+func (store metaBlockStore) LoadBlockMeta(height int64) *types.BlockMeta { return store.metas[height] }
@@ -1,8 +1,11 @@
 package core
 
 import (
+	"fmt"
+
 	cm "github.com/tendermint/tendermint/consensus"
 	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -42,11 +45,58 @@ func (env *Environment) Validators(
 
 	v := validators.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
 
+	var addressesBech32 []string
+	if env.Config.Bech32ValidatorPrefix != "" {
+		addressesBech32 = make([]string, len(v))
+		for i, val := range v {
+			addressesBech32[i] = env.bech32ValidatorAddress(val.Address)
+		}
+	}
+
 	return &ctypes.ResultValidators{
-		BlockHeight: height,
-		Validators:  v,
-		Count:       len(v),
-		Total:       totalCount}, nil
+		BlockHeight:     height,
+		Validators:      v,
+		Count:           len(v),
+		Total:           totalCount,
+		AddressesBech32: addressesBech32}, nil
+}
+
+// ValidatorChanges gets the validators which joined or left the validator set,
+// and whose voting power changed, between the given heights.
+//
+// If no to height is provided, it compares with the latest validator set.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_changes
+func (env *Environment) ValidatorChanges(
+	ctx *rpctypes.Context,
+	fromPtr, toPtr *int64) (*ctypes.ResultValidatorChanges, error) {
+
+	if fromPtr == nil {
+		return nil, fmt.Errorf("%w: from height is required", ctypes.ErrInvalidRequest)
+	}
+	latest := env.latestUncommittedHeight()
+	from, err := env.getHeight(latest, fromPtr)
+	if err != nil {
+		return nil, err
+	}
+	to, err := env.getHeight(latest, toPtr)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("%w: from height %d is greater than to height %d",
+			ctypes.ErrInvalidRequest, from, to)
+	}
+
+	fromVals, err := env.StateStore.LoadValidators(from)
+	if err != nil {
+		return nil, err
+	}
+	toVals, err := env.StateStore.LoadValidators(to)
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.NewResultValidatorChanges(from, to, fromVals, toVals), nil
 }
 
 // DumpConsensusState dumps consensus state.
@@ -82,6 +132,32 @@ func (env *Environment) DumpConsensusState(ctx *rpctypes.Context) (*ctypes.Resul
 		Peers:      peerStates}, nil
 }
 
+// DumpConsensusStateV2 dumps the consensus state in a versioned schema, which
+// is only ever extended in a backwards compatible way.
+// More: https://docs.tendermint.com/master/rpc/#/Info/dump_consensus_state_v2
+func (env *Environment) DumpConsensusStateV2(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusStateV2, error) {
+	peers := env.P2PPeers.Peers().List()
+	peerStates := make([]tmcons.PeerStateDump, 0, len(peers))
+	for _, peer := range peers {
+		peerState, ok := peer.Get(types.PeerStateKey).(*cm.PeerState)
+		if !ok { // peer does not have a state yet
+			continue
+		}
+		psd := peerState.GetRoundState().ToProto()
+		psd.NodeID = string(peer.ID())
+		psd.Address = peer.SocketAddr().String()
+		peerStates = append(peerStates, psd)
+	}
+
+	return &ctypes.ResultDumpConsensusStateV2{
+		State: tmcons.StateDump{
+			Version:    ctypes.DumpConsensusStateVersion,
+			RoundState: env.ConsensusState.GetRoundStateDump(),
+			Peers:      peerStates,
+		},
+	}, nil
+}
+
 // ConsensusState returns a concise summary of the consensus state.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_state
@@ -91,7 +167,8 @@ func (env *Environment) GetConsensusState(ctx *rpctypes.Context) (*ctypes.Result
 	return &ctypes.ResultConsensusState{RoundState: bz}, err
 }
 
-// ConsensusParams gets the consensus parameters at the given block height.
+// ConsensusParams gets the consensus parameters at the given block height, and
+// the updates scheduled at later heights as of it.
 // If no height is provided, it will fetch the latest consensus params.
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_params
 func (env *Environment) ConsensusParams(
@@ -109,8 +186,13 @@ func (env *Environment) ConsensusParams(
 	if err != nil {
 		return nil, err
 	}
+	scheduled, err := env.StateStore.LoadScheduledConsensusParams(height)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ctypes.ResultConsensusParams{
-		BlockHeight:     height,
-		ConsensusParams: consensusParams}, nil
+		BlockHeight:              height,
+		ConsensusParams:          consensusParams,
+		ScheduledConsensusParams: scheduled}, nil
 }
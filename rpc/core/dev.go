@@ -10,3 +10,20 @@ func (env *Environment) UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.Resul
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeProduceBlock makes the node propose and commit the next block right
+// away, even if the mempool is empty. Only available in consensus dev mode.
+func (env *Environment) UnsafeProduceBlock(ctx *rpctypes.Context) (*ctypes.ResultUnsafeProduceBlock, error) {
+	if err := env.ConsensusState.ProduceBlock(); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsafeProduceBlock{}, nil
+}
+
+// UnsafeStopWaitingForPeers makes the node participate in consensus right
+// away, even if it has not seen consensus.min-peers-to-start peers yet.
+func (env *Environment) UnsafeStopWaitingForPeers(
+	ctx *rpctypes.Context) (*ctypes.ResultUnsafeStopWaitingForPeers, error) {
+	env.ConsensusState.StopWaitingForPeers()
+	return &ctypes.ResultUnsafeStopWaitingForPeers{}, nil
+}
@@ -18,13 +18,17 @@ curl 'localhost:26657'
 Available endpoints:
 /abci_info
 /dump_consensus_state
+/dump_consensus_state_v2
 /genesis
 /net_info
 /num_unconfirmed_txs
 /status
 /health
 /unconfirmed_txs
+/unsafe_chaos_policy
 /unsafe_flush_mempool
+/unsafe_produce_block
+/unsafe_stop_waiting_for_peers
 /validators
 
 Endpoints that require arguments:
@@ -32,14 +36,20 @@ Endpoints that require arguments:
 /block?height=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
+/broadcast_tx_batch?txs=_
 /broadcast_tx_commit?tx=_
 /broadcast_tx_sync?tx=_
 /commit?height=_
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
+/evidence?hash=_
+/light_block?height=_
+/light_blocks?minHeight=_&maxHeight=_
 /subscribe?event=_
 /tx?hash=_&prove=_
+/unsafe_set_chaos_policy?policy=_
 /unsubscribe?event=_
+/validator_changes?from=_&to=_
 ```
 */
 package core
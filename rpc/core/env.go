@@ -2,16 +2,22 @@ package core
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/evidence"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	"github.com/tendermint/tendermint/proxy"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	sm "github.com/tendermint/tendermint/state"
@@ -31,6 +37,10 @@ const (
 	// genesisChunkSize is the maximum size, in bytes, of each
 	// chunk in the genesis structure for the chunked API
 	genesisChunkSize = 16 * 1024 * 1024 // 16
+
+	// maxBlockchainScan is the maximum number of blocks /blockchain looks at
+	// for headers matching its filters in one request
+	maxBlockchainScan = 1000
 )
 
 //----------------------------------------------
@@ -42,6 +52,9 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetRoundStateDump() tmcons.RoundStateDump
+	ProduceBlock() error
+	StopWaitingForPeers()
 }
 
 type transport interface {
@@ -50,12 +63,19 @@ type transport interface {
 	NodeInfo() p2p.NodeInfo
 }
 
+type evidencePool interface {
+	AddEvidence(types.Evidence) error
+	EvidenceStatus(hash []byte) evidence.EvidenceStatus
+}
+
 type peers interface {
 	AddPersistentPeers([]string) error
 	AddUnconditionalPeerIDs([]string) error
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	SetChaosPolicy(p2p.ChaosPolicy) error
+	ChaosPolicy() (p2p.ChaosPolicy, bool)
 }
 
 //----------------------------------------------
@@ -69,7 +89,7 @@ type Environment struct {
 	// interfaces defined in types and above
 	StateStore     sm.Store
 	BlockStore     sm.BlockStore
-	EvidencePool   sm.EvidencePool
+	EvidencePool   evidencePool
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
@@ -81,6 +101,10 @@ type Environment struct {
 	ConsensusReactor *consensus.Reactor
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
+	NodeMetadata     *metadata.Book
+
+	// the directory of the databases, for their disk usage in /status
+	DBDir string
 
 	Logger log.Logger
 
@@ -88,6 +112,30 @@ type Environment struct {
 
 	// cache of chunked genesis data.
 	genChunks []string
+
+	querierOnce sync.Once
+	querier     *abciQuerier
+
+	// set to 1 once the node starts shutting down
+	rejectingTxs int32
+
+	// cache of the disk usage of the databases
+	diskUsageMtx     sync.Mutex
+	diskUsage        map[string]int64
+	diskUsageUpdated time.Time
+}
+
+// RejectTxs makes the broadcast_tx_* routes reject all further txs. The node
+// calls it when it starts shutting down.
+func (env *Environment) RejectTxs() {
+	atomic.StoreInt32(&env.rejectingTxs, 1)
+}
+
+func (env *Environment) checkAcceptingTxs() error {
+	if atomic.LoadInt32(&env.rejectingTxs) == 1 {
+		return ctypes.ErrShuttingDown
+	}
+	return nil
 }
 
 //----------------------------------------------
@@ -179,16 +227,47 @@ func (env *Environment) getHeight(latestHeight int64, heightPtr *int64) (int64,
 			return 0, fmt.Errorf("%w (requested height: %d, blockchain height: %d)",
 				ctypes.ErrHeightExceedsChainHead, height, latestHeight)
 		}
-		base := env.BlockStore.Base()
-		if height < base {
-			return 0, fmt.Errorf("%w (requested height: %d, base height: %d)", ctypes.ErrHeightNotAvailable, height, base)
+		if err := env.checkBlockPruned(height); err != nil {
+			return 0, err
 		}
 		return height, nil
 	}
 	return latestHeight, nil
 }
 
+// checkBlockPruned returns ErrHeightPruned if the block at the given height was
+// pruned.
+func (env *Environment) checkBlockPruned(height int64) error {
+	if base := env.BlockStore.Base(); height < base {
+		return ctypes.ErrHeightPruned{Height: height, EarliestHeight: base}
+	}
+	return nil
+}
+
+// checkResultsPruned returns ErrHeightPruned if the block results at the given
+// height were pruned. Block results are pruned along with blocks, but a node
+// which state synced has no results for the height it synced to, i.e. the
+// base of its block store.
+func (env *Environment) checkResultsPruned(height int64) error {
+	if err := env.checkBlockPruned(height); err != nil {
+		return err
+	}
+	if base := env.BlockStore.Base(); height == base {
+		_, err := env.StateStore.LoadABCIResponses(base)
+		if errors.As(err, &sm.ErrNoABCIResponsesForHeight{}) {
+			return ctypes.ErrHeightPruned{Height: height, EarliestHeight: base + 1}
+		}
+	}
+	return nil
+}
+
+// latestUncommittedHeight returns the height of the block being decided by
+// consensus. Header-only nodes have no consensus, and only know the validators
+// up to the latest stored height.
 func (env *Environment) latestUncommittedHeight() int64 {
+	if env.ConsensusReactor == nil {
+		return env.BlockStore.Height()
+	}
 	nodeIsSyncing := env.ConsensusReactor.WaitSync()
 	if nodeIsSyncing {
 		return env.BlockStore.Height()
@@ -2,23 +2,39 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
 	// Buffer on the Tendermint (server) side to allow some slowness in clients.
 	subBufferSize = 100
+
+	// Number of heights searched at a time when replaying indexed events.
+	replayBatchHeights = 100
 )
 
 // Subscribe for events via WebSocket.
+//
+// If fromHeight is positive, the tx events matching the query from that height
+// on are first replayed from the tx indexer, followed by the live events. Each
+// event is delivered exactly once.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	fromHeight int64,
+) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -33,6 +49,14 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
+	if fromHeight < 0 {
+		return nil, fmt.Errorf("from_height can't be negative: %w", ctypes.ErrInvalidRequest)
+	}
+	if fromHeight > 0 {
+		if err := env.validateReplayQuery(q); err != nil {
+			return nil, err
+		}
+	}
 
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
@@ -42,12 +66,55 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 		return nil, err
 	}
 
+	// The events of the heights up to replayTo are replayed from the tx
+	// indexer, the live events of these heights are dropped. The block store
+	// height is read after subscribing: the events of the heights below it
+	// were published before, the events of the heights above it will be
+	// published after, and the events of the height itself may be either, so
+	// they are taken from the indexer.
+	var replayTo int64
+	if fromHeight > 0 {
+		replayTo = env.BlockStore.Height()
+		if replayTo >= fromHeight {
+			if err := env.waitForIndexedHeight(subCtx, replayTo); err != nil {
+				_ = env.EventBus.Unsubscribe(context.Background(), addr, q)
+				return nil, err
+			}
+			if ok, _ := env.kvSink().HasBlock(fromHeight); !ok {
+				_ = env.EventBus.Unsubscribe(context.Background(), addr, q)
+				return nil, fmt.Errorf("events of height %d are not indexed: %w", fromHeight,
+					ctypes.ErrInvalidRequest)
+			}
+		} else {
+			replayTo = fromHeight - 1
+		}
+	}
+
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
 	go func() {
+		if replayTo >= fromHeight && fromHeight > 0 {
+			err := env.replayTxEvents(ctx.WSConn.Context(), q, fromHeight, replayTo, func(ev *ctypes.ResultEvent) error {
+				ev.Query = query
+				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				return ctx.WSConn.WriteRPCResponse(writeCtx, rpctypes.NewRPCSuccessResponse(subscriptionID, ev))
+			})
+			if err != nil {
+				env.Logger.Info("Can't replay events", "to", addr, "subscriptionID", subscriptionID, "err", err)
+				resp := rpctypes.RPCServerError(subscriptionID, fmt.Errorf("failed to replay events: %w", err))
+				ctx.WSConn.TryWriteRPCResponse(resp)
+				_ = env.EventBus.Unsubscribe(context.Background(), addr, q)
+				return
+			}
+		}
+
 		for {
 			select {
 			case msg := <-sub.Out():
+				if data, ok := msg.Data().(types.EventDataTx); ok && data.Height <= replayTo {
+					continue
+				}
 				var (
 					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
 					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
@@ -83,6 +150,119 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// validateReplayQuery returns an error if the events matching the query can't
+// be replayed from the tx indexer, which only indexes tx events.
+func (env *Environment) validateReplayQuery(q *tmquery.Query) error {
+	if env.kvSink() == nil {
+		return errors.New("replaying events is disabled due to no kvEventSink")
+	}
+	conditions, err := q.Conditions()
+	if err != nil {
+		return err
+	}
+	for _, c := range conditions {
+		if c.CompositeKey == types.EventTypeKey && c.Op == tmquery.OpEqual && c.Operand == types.EventTx {
+			return nil
+		}
+	}
+	return fmt.Errorf("from_height is only supported for queries with %s = '%s': %w",
+		types.EventTypeKey, types.EventTx, ctypes.ErrInvalidRequest)
+}
+
+// kvSink returns the kv event sink, or nil if it is disabled.
+func (env *Environment) kvSink() indexer.EventSink {
+	for _, sink := range env.EventSinks {
+		if sink.Type() == indexer.KV {
+			return sink
+		}
+	}
+	return nil
+}
+
+// waitForIndexedHeight waits until the events of the given height are indexed.
+func (env *Environment) waitForIndexedHeight(ctx context.Context, height int64) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		ok, err := env.kvSink().HasBlock(height)
+		if err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("events of height %d are not indexed yet: %w", height, ctx.Err())
+		}
+	}
+}
+
+// replayTxEvents passes the indexed tx events matching the query between the
+// given heights to fn, in the order they were published.
+func (env *Environment) replayTxEvents(
+	ctx context.Context,
+	q *tmquery.Query,
+	fromHeight, toHeight int64,
+	fn func(*ctypes.ResultEvent) error,
+) error {
+	sink := env.kvSink()
+	for from := fromHeight; from <= toHeight; from += replayBatchHeights {
+		to := from + replayBatchHeights - 1
+		if to > toHeight {
+			to = toHeight
+		}
+		results, err := sink.SearchTxEvents(ctx, tmquery.MustParse(fmt.Sprintf("%s >= %d AND %s <= %d",
+			types.TxHeightKey, from, types.TxHeightKey, to)))
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Height == results[j].Height {
+				return results[i].Index < results[j].Index
+			}
+			return results[i].Height < results[j].Height
+		})
+
+		for _, r := range results {
+			events := txResultEvents(r)
+			if ok, err := q.Matches(events); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			if err := fn(&ctypes.ResultEvent{Data: types.EventDataTx{TxResult: *r}, Events: events}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// txResultEvents returns the events of the tx as published by the event bus.
+func txResultEvents(r *abci.TxResult) map[string][]string {
+	events := make(map[string][]string)
+	for _, event := range r.Result.Events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+			events[compositeTag] = append(events[compositeTag], attr.Value)
+		}
+	}
+	events[types.EventTypeKey] = append(events[types.EventTypeKey], types.EventTx)
+	events[types.TxHashKey] = append(events[types.TxHashKey], fmt.Sprintf("%X", types.Tx(r.Tx).Hash()))
+	events[types.TxHeightKey] = append(events[types.TxHeightKey], fmt.Sprintf("%d", r.Height))
+	return events
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+type testWSConn struct {
+	responses chan rpctypes.RPCResponse
+}
+
+func (c *testWSConn) GetRemoteAddr() string { return "test" }
+
+func (c *testWSConn) WriteRPCResponse(ctx context.Context, resp rpctypes.RPCResponse) error {
+	c.responses <- resp
+	return nil
+}
+
+func (c *testWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool {
+	c.responses <- resp
+	return true
+}
+
+func (c *testWSConn) Context() context.Context { return context.Background() }
+
+func TestSubscribeFromHeight(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	sink := kv.NewEventSink(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	env := &Environment{
+		BlockStore: blockStore,
+		EventSinks: []indexer.EventSink{sink},
+		EventBus:   eventBus,
+		Config:     *cfg.DefaultRPCConfig(),
+		Logger:     log.TestingLogger(),
+	}
+
+	txResult := func(height int64, index uint32, owner string) *abci.TxResult {
+		return &abci.TxResult{
+			Height: height,
+			Index:  index,
+			Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", height, index)),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{{
+				Type:       "account",
+				Attributes: []abci.EventAttribute{{Key: "owner", Value: owner, Index: true}},
+			}}},
+		}
+	}
+
+	// heights 1 to 3 are committed and indexed
+	for height := int64(1); height <= 3; height++ {
+		block := types.MakeBlock(height, nil, new(types.Commit), nil)
+		_, err := factory.MakeHeader(&block.Header)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), new(types.Commit))
+
+		require.NoError(t, sink.IndexBlockEvents(types.EventDataNewBlockHeader{Header: types.Header{Height: height}}))
+		require.NoError(t, sink.IndexTxEvents([]*abci.TxResult{
+			txResult(height, 0, "Ivan"),
+			txResult(height, 1, "Bob"),
+		}))
+	}
+
+	conn := &testWSConn{responses: make(chan rpctypes.RPCResponse, 10)}
+	ctx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(1)}, WSConn: conn}
+	query := "tm.event = 'Tx' AND account.owner = 'Ivan'"
+	_, err := env.Subscribe(ctx, query, 2)
+	require.NoError(t, err)
+
+	// the live events of the replayed heights are dropped
+	for _, r := range []*abci.TxResult{txResult(3, 0, "Ivan"), txResult(4, 0, "Ivan"), txResult(4, 1, "Bob")} {
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: *r}))
+	}
+
+	for _, height := range []int64{2, 3, 4} {
+		select {
+		case resp := <-conn.responses:
+			require.Nil(t, resp.Error)
+			var event ctypes.ResultEvent
+			require.NoError(t, tmjson.Unmarshal(resp.Result, &event))
+			assert.Equal(t, query, event.Query)
+			data := event.Data.(types.EventDataTx)
+			assert.Equal(t, height, data.Height)
+			assert.EqualValues(t, 0, data.Index)
+		case <-time.After(time.Second):
+			t.Fatalf("no event of height %d", height)
+		}
+	}
+	select {
+	case resp := <-conn.responses:
+		t.Fatalf("unexpected response %v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// only tx events can be replayed
+	_, err = env.Subscribe(ctx, "tm.event = 'NewBlock'", 2)
+	assert.Error(t, err)
+
+	// the events of pruned heights can't be replayed
+	require.NoError(t, sink.Prune(2))
+	_, err = env.Subscribe(ctx, "tm.event = 'Tx'", 1)
+	assert.Error(t, err)
+}
@@ -25,5 +25,25 @@ func (env *Environment) BroadcastEvidence(
 	if err := env.EvidencePool.AddEvidence(ev); err != nil {
 		return nil, fmt.Errorf("failed to add evidence: %w", err)
 	}
-	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
+
+	status := env.EvidencePool.EvidenceStatus(ev.Hash())
+	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash(), Status: status.Status.String()}, nil
+}
+
+// Evidence gets the status of evidence by its hash: pending, committed at a
+// height, rejected with a reason or unknown. Rejections are only remembered
+// for the most recent evidence and not across restarts.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/evidence
+func (env *Environment) Evidence(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	if len(hash) == 0 {
+		return nil, fmt.Errorf("%w: no evidence hash was provided", ctypes.ErrInvalidRequest)
+	}
+
+	status := env.EvidencePool.EvidenceStatus(hash)
+	return &ctypes.ResultEvidence{
+		Hash:   hash,
+		Status: status.Status.String(),
+		Height: status.Height,
+		Reason: status.Reason,
+	}, nil
 }
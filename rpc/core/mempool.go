@@ -10,6 +10,7 @@ import (
 	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -20,6 +21,10 @@ import (
 // CheckTx nor DeliverTx results.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_async
 func (env *Environment) BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := env.checkAcceptingTxs(); err != nil {
+		return nil, err
+	}
+
 	err := env.Mempool.CheckTx(tx, nil, mempl.TxInfo{Context: ctx.Context()})
 
 	if err != nil {
@@ -29,9 +34,15 @@ func (env *Environment) BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*c
 }
 
 // BroadcastTxSync returns with the response from CheckTx. Does not wait for
-// DeliverTx result.
+// DeliverTx result. Once the mempool reached its soft high-water mark, low
+// priority txs are rejected with the code mempl.CodeTypeMempoolCongested of
+// the mempl.CodespaceMempool codespace, and can be submitted again later.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_sync
 func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := env.checkAcceptingTxs(); err != nil {
+		return nil, err
+	}
+
 	resCh := make(chan *abci.Response, 1)
 	err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
 		resCh <- res
@@ -50,9 +61,76 @@ func (env *Environment) BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ct
 	}, nil
 }
 
+// BroadcastTxBatch checks a batch of up to rpc.max-broadcast-tx-batch txs and
+// returns with the result of each, in the order of the txs. Does not wait for
+// DeliverTx results. Each tx is either accepted, cached if the mempool already
+// has or recently had it, or rejected by the app or the mempool, with the code
+// and log of the rejection. The txs the mempool rejects before the app checks
+// them have the code mempl.CodeTypeTxRejected of the mempl.CodespaceMempool
+// codespace.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_batch
+func (env *Environment) BroadcastTxBatch(ctx *rpctypes.Context, txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	if err := env.checkAcceptingTxs(); err != nil {
+		return nil, err
+	}
+	if env.Config.MaxBroadcastTxBatch == 0 {
+		return nil, errors.New("broadcast_tx_batch is disabled, see rpc.max-broadcast-tx-batch")
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("%w: no txs provided", ctypes.ErrInvalidRequest)
+	}
+	if len(txs) > env.Config.MaxBroadcastTxBatch {
+		return nil, fmt.Errorf("%w: batch of %d txs exceeds the maximum of %d",
+			ctypes.ErrInvalidRequest, len(txs), env.Config.MaxBroadcastTxBatch)
+	}
+
+	// Submit all txs before waiting for the first CheckTx response, so that the
+	// app checks them in a row.
+	results := make([]ctypes.ResultBroadcastBatchTx, len(txs))
+	resChs := make([]chan *abci.Response, len(txs))
+	for i, tx := range txs {
+		results[i].Hash = tx.Hash()
+		resCh := make(chan *abci.Response, 1)
+		err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
+			resCh <- res
+		}, mempl.TxInfo{Context: ctx.Context()})
+		switch {
+		case errors.Is(err, mempl.ErrTxInCache):
+			results[i].Status = ctypes.BatchTxCached
+		case err != nil:
+			results[i].Status = ctypes.BatchTxRejected
+			results[i].Code = mempl.CodeTypeTxRejected
+			results[i].Codespace = mempl.CodespaceMempool
+			results[i].Log = err.Error()
+		default:
+			resChs[i] = resCh
+		}
+	}
+
+	for i, resCh := range resChs {
+		if resCh == nil {
+			continue
+		}
+		r := (<-resCh).GetCheckTx()
+		results[i].Status = ctypes.BatchTxAccepted
+		if r.Code != abci.CodeTypeOK {
+			results[i].Status = ctypes.BatchTxRejected
+		}
+		results[i].Code = r.Code
+		results[i].Data = r.Data
+		results[i].Log = r.Log
+		results[i].Codespace = r.Codespace
+	}
+	return &ctypes.ResultBroadcastTxBatch{Txs: results}, nil
+}
+
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
 func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	if err := env.checkAcceptingTxs(); err != nil {
+		return nil, err
+	}
+
 	subscriber := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -138,21 +216,33 @@ func (env *Environment) UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*c
 	// reuse per_page validator
 	limit := env.validatePerPage(limitPtr)
 
+	gasUtilization, err := sm.GasUtilization(env.StateStore, env.BlockStore.Height())
+	if err != nil {
+		return nil, err
+	}
+
 	txs := env.Mempool.ReapMaxTxs(limit)
 	return &ctypes.ResultUnconfirmedTxs{
-		Count:      len(txs),
-		Total:      env.Mempool.Size(),
-		TotalBytes: env.Mempool.TxsBytes(),
-		Txs:        txs}, nil
+		Count:               len(txs),
+		Total:               env.Mempool.Size(),
+		TotalBytes:          env.Mempool.TxsBytes(),
+		Txs:                 txs,
+		BlockGasUtilization: gasUtilization}, nil
 }
 
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/num_unconfirmed_txs
 func (env *Environment) NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	gasUtilization, err := sm.GasUtilization(env.StateStore, env.BlockStore.Height())
+	if err != nil {
+		return nil, err
+	}
+
 	return &ctypes.ResultUnconfirmedTxs{
-		Count:      env.Mempool.Size(),
-		Total:      env.Mempool.Size(),
-		TotalBytes: env.Mempool.TxsBytes()}, nil
+		Count:               env.Mempool.Size(),
+		Total:               env.Mempool.Size(),
+		TotalBytes:          env.Mempool.TxsBytes(),
+		BlockGasUtilization: gasUtilization}, nil
 }
 
 // CheckTx checks the transaction without executing it. The transaction won't
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/mempool/mock"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBroadcastTxRejectedOnShutdown(t *testing.T) {
+	env := &Environment{Mempool: mock.Mempool{}}
+	tx := types.Tx("foo")
+
+	_, err := env.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	require.NoError(t, err)
+
+	env.RejectTxs()
+
+	_, err = env.BroadcastTxAsync(&rpctypes.Context{}, tx)
+	require.ErrorIs(t, err, ctypes.ErrShuttingDown)
+	_, err = env.BroadcastTxSync(&rpctypes.Context{}, tx)
+	require.ErrorIs(t, err, ctypes.ErrShuttingDown)
+	_, err = env.BroadcastTxCommit(&rpctypes.Context{}, tx)
+	require.ErrorIs(t, err, ctypes.ErrShuttingDown)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -21,16 +22,22 @@ func (env *Environment) NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, e
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
+			NodeIDBech32:     env.bech32NodeID(peer.ID()),
 		})
 	}
+	var nodeMetadata []metadata.SignedNodeMetadata
+	if env.NodeMetadata != nil {
+		nodeMetadata = env.NodeMetadata.List()
+	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
 	// PRO: useful info
 	// CON: privacy
 	return &ctypes.ResultNetInfo{
-		Listening: env.P2PTransport.IsListening(),
-		Listeners: env.P2PTransport.Listeners(),
-		NPeers:    len(peers),
-		Peers:     peers,
+		Listening:    env.P2PTransport.IsListening(),
+		Listeners:    env.P2PTransport.Listeners(),
+		NPeers:       len(peers),
+		Peers:        peers,
+		NodeMetadata: nodeMetadata,
 	}, nil
 }
 
@@ -90,6 +97,27 @@ func (env *Environment) UnsafeDialPeers(
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeChaosPolicy returns the chaos policy the node applies to the messages
+// it receives from peers.
+func (env *Environment) UnsafeChaosPolicy(ctx *rpctypes.Context) (*ctypes.ResultChaosPolicy, error) {
+	policy, enabled := env.P2PPeers.ChaosPolicy()
+	return &ctypes.ResultChaosPolicy{Enabled: enabled, Policy: policy}, nil
+}
+
+// UnsafeSetChaosPolicy replaces the chaos policy the node applies to the
+// messages it receives from peers, dropping, delaying or reordering them. It
+// fails unless the node is built with the chaos build tag.
+func (env *Environment) UnsafeSetChaosPolicy(
+	ctx *rpctypes.Context,
+	policy p2p.ChaosPolicy) (*ctypes.ResultChaosPolicy, error) {
+
+	if err := env.P2PPeers.SetChaosPolicy(policy); err != nil {
+		return nil, fmt.Errorf("%w: %v", ctypes.ErrInvalidRequest, err)
+	}
+	env.Logger.Info("SetChaosPolicy", "policy", policy)
+	return env.UnsafeChaosPolicy(ctx)
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
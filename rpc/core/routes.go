@@ -12,35 +12,41 @@ type RoutesMap map[string]*rpc.RPCFunc
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query,from_height"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
 		// info API
-		"health":               rpc.NewRPCFunc(env.Health, "", false),
-		"status":               rpc.NewRPCFunc(env.Status, "", false),
-		"net_info":             rpc.NewRPCFunc(env.NetInfo, "", false),
-		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", true),
-		"genesis":              rpc.NewRPCFunc(env.Genesis, "", true),
-		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", true),
-		"block":                rpc.NewRPCFunc(env.Block, "height", true),
-		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", true),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", true),
-		"commit":               rpc.NewRPCFunc(env.Commit, "height", true),
-		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx", true),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", true),
-		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", true),
-		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, "", false),
-		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, "", false),
-		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", true),
-		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", false),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", false),
+		"health":                  rpc.NewRPCFunc(env.Health, "", false),
+		"status":                  rpc.NewRPCFunc(env.Status, "", false),
+		"net_info":                rpc.NewRPCFunc(env.NetInfo, "", false),
+		"blockchain":              rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight,proposer,has_txs,min_time,max_time,order_by,cursor", true), //nolint:lll
+		"genesis":                 rpc.NewRPCFunc(env.Genesis, "", true),
+		"genesis_chunked":         rpc.NewRPCFunc(env.GenesisChunked, "chunk", true),
+		"block":                   rpc.NewRPCFunc(env.Block, "height", true),
+		"block_by_hash":           rpc.NewRPCFunc(env.BlockByHash, "hash", true),
+		"block_results":           rpc.NewRPCFunc(env.BlockResults, "height", true),
+		"commit":                  rpc.NewRPCFunc(env.Commit, "height", true),
+		"light_block":             rpc.NewRPCFunc(env.LightBlock, "height", true),
+		"light_blocks":            rpc.NewRPCFunc(env.LightBlocks, "minHeight,maxHeight", true),
+		"check_tx":                rpc.NewRPCFunc(env.CheckTx, "tx", true),
+		"tx":                      rpc.NewRPCFunc(env.Tx, "hash,prove", true),
+		"tx_proof":                rpc.NewRPCFunc(env.TxProof, "hash", true),
+		"tx_search":               rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
+		"block_search":            rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
+		"validators":              rpc.NewRPCFunc(env.Validators, "height,page,per_page", true),
+		"validator_changes":       rpc.NewRPCFunc(env.ValidatorChanges, "from,to", false),
+		"dump_consensus_state":    rpc.NewRPCFunc(env.DumpConsensusState, "", false),
+		"dump_consensus_state_v2": rpc.NewRPCFunc(env.DumpConsensusStateV2, "", false),
+		"consensus_state":         rpc.NewRPCFunc(env.GetConsensusState, "", false),
+		"consensus_params":        rpc.NewRPCFunc(env.ConsensusParams, "height", true),
+		"unconfirmed_txs":         rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", false),
+		"num_unconfirmed_txs":     rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", false),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", false),
 		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx", false),
+		"broadcast_tx_batch":  rpc.NewRPCFunc(env.BroadcastTxBatch, "txs", false),
 		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", false),
 
 		// abci API
@@ -49,13 +55,44 @@ func (env *Environment) GetRoutes() RoutesMap {
 
 		// evidence API
 		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence", false),
+		"evidence":           rpc.NewRPCFunc(env.Evidence, "hash", false),
 	}
 }
 
+// headerRouteNames are the routes served by header-only nodes, which only
+// store the headers, commits and validator sets of the chain.
+var headerRouteNames = []string{
+	"health",
+	"status",
+	"blockchain",
+	"genesis",
+	"genesis_chunked",
+	"commit",
+	"light_block",
+	"light_blocks",
+	"validators",
+	"validator_changes",
+}
+
+// GetHeaderRoutes returns the subset of the routes served by header-only
+// nodes. The other routes need the block data, the app or the consensus state.
+func (env *Environment) GetHeaderRoutes() RoutesMap {
+	routes := env.GetRoutes()
+	headerRoutes := make(RoutesMap, len(headerRouteNames))
+	for _, name := range headerRouteNames {
+		headerRoutes[name] = routes[name]
+	}
+	return headerRoutes
+}
+
 // AddUnsafeRoutes adds unsafe routes.
 func (env *Environment) AddUnsafe(routes RoutesMap) {
 	// control API
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds", false)
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private", false)
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "", false)
+	routes["unsafe_produce_block"] = rpc.NewRPCFunc(env.UnsafeProduceBlock, "", false)
+	routes["unsafe_stop_waiting_for_peers"] = rpc.NewRPCFunc(env.UnsafeStopWaitingForPeers, "", false)
+	routes["unsafe_chaos_policy"] = rpc.NewRPCFunc(env.UnsafeChaosPolicy, "", false)
+	routes["unsafe_set_chaos_policy"] = rpc.NewRPCFunc(env.UnsafeSetChaosPolicy, "policy", false)
 }
@@ -2,12 +2,19 @@ package core
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 // Status returns Tendermint status including node info, pubkey, latest block
@@ -53,13 +60,25 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, err
 	validatorInfo := ctypes.ValidatorInfo{}
 	if env.PubKey != nil {
 		validatorInfo = ctypes.ValidatorInfo{
-			Address:     env.PubKey.Address(),
-			PubKey:      env.PubKey,
-			VotingPower: votingPower,
+			Address:       env.PubKey.Address(),
+			PubKey:        env.PubKey,
+			VotingPower:   votingPower,
+			AddressBech32: env.bech32ValidatorAddress(env.PubKey.Address()),
 		}
 	}
+	earliestStateHeight, err := env.StateStore.Base()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo := env.P2PTransport.NodeInfo()
+	// header-only nodes have no consensus reactor, and don't fast sync
+	catchingUp := false
+	if env.ConsensusReactor != nil {
+		catchingUp = env.ConsensusReactor.WaitSync()
+	}
 	result := &ctypes.ResultStatus{
-		NodeInfo: env.P2PTransport.NodeInfo(),
+		NodeInfo: nodeInfo,
 		SyncInfo: ctypes.SyncInfo{
 			LatestBlockHash:     latestBlockHash,
 			LatestAppHash:       latestAppHash,
@@ -69,14 +88,79 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, err
 			EarliestAppHash:     earliestAppHash,
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
-			CatchingUp:          env.ConsensusReactor.WaitSync(),
+			EarliestStateHeight: earliestStateHeight,
+			CatchingUp:          catchingUp,
 		},
 		ValidatorInfo: validatorInfo,
+		NodeIDBech32:  env.bech32NodeID(nodeInfo.NodeID),
+		BuildInfo: ctypes.BuildInfo{
+			Version:   version.TMVersion,
+			GitCommit: version.GitCommit,
+			GoVersion: runtime.Version(),
+			BuildTags: tmstrings.SplitAndTrimEmpty(version.BuildTags, ",", " "),
+		},
+		Features:  tmstrings.SplitAndTrimEmpty(nodeInfo.Features.String(), ",", " "),
+		StoreInfo: env.storeInfo(),
 	}
 
 	return result, nil
 }
 
+// diskUsageCacheTTL is how long the disk usage of the databases is cached,
+// since walking them is slow on a large node.
+const diskUsageCacheTTL = time.Minute
+
+// storeInfo returns the disk usage of the databases, i.e. of the files and
+// directories ending in .db in the databases directory.
+func (env *Environment) storeInfo() ctypes.StoreInfo {
+	env.diskUsageMtx.Lock()
+	defer env.diskUsageMtx.Unlock()
+
+	if env.DBDir != "" && time.Since(env.diskUsageUpdated) > diskUsageCacheTTL {
+		diskUsage, err := dbDiskUsage(env.DBDir)
+		if err != nil {
+			env.Logger.Error("failed to get the disk usage of the databases", "err", err)
+		} else {
+			env.diskUsage = diskUsage
+			env.diskUsageUpdated = time.Now()
+		}
+	}
+
+	info := ctypes.StoreInfo{DiskUsage: make(map[string]int64, len(env.diskUsage))}
+	for name, size := range env.diskUsage {
+		info.DiskUsage[name] = size
+		info.TotalDiskUsage += size
+	}
+	return info
+}
+
+func dbDiskUsage(dir string) (map[string]int64, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	diskUsage := make(map[string]int64)
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".db")
+		if name == entry.Name() {
+			continue
+		}
+		err := filepath.Walk(filepath.Join(dir, entry.Name()), func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				diskUsage[name] += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return diskUsage, nil
+}
+
 func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	valsWithH, err := env.StateStore.LoadValidators(h)
 	if err != nil {
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestStoreInfo(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blockstore.db", "sub"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "blockstore.db", "000001.ldb"), make([]byte, 100), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "blockstore.db", "sub", "LOG"), make([]byte, 10), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "state.db"), make([]byte, 50), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other"), make([]byte, 1000), 0600))
+
+	env := &Environment{DBDir: dir, Logger: log.TestingLogger()}
+	info := env.storeInfo()
+	assert.Equal(t, map[string]int64{"blockstore": 110, "state": 50}, info.DiskUsage)
+	assert.EqualValues(t, 160, info.TotalDiskUsage)
+
+	// the disk usage is cached
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "evidence.db"), make([]byte, 50), 0600))
+	assert.Equal(t, info, env.storeInfo())
+
+	// without a databases directory, e.g. with in-memory databases
+	env = &Environment{Logger: log.TestingLogger()}
+	assert.Empty(t, env.storeInfo().DiskUsage)
+}
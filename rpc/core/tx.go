@@ -18,17 +18,15 @@ import (
 // place.
 // More: https://docs.tendermint.com/master/rpc/#/Info/tx
 func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	// if index is disabled, return error
-
-	if !indexer.KVSinkEnabled(env.EventSinks) {
-		return nil, errors.New("transaction querying is disabled due to no kvEventSink")
-	}
-
+	// look the tx up in the tx indexer, which records the tx latency, and fall
+	// back to the block store, e.g. if the tx indexer is disabled
+	notFoundErr := errors.New("transaction querying is disabled due to no kvEventSink")
 	for _, sink := range env.EventSinks {
 		if sink.Type() == indexer.KV {
 			r, err := sink.GetTxByHash(hash)
 			if r == nil {
-				return nil, fmt.Errorf("tx (%X) not found, err: %w", hash, err)
+				notFoundErr = fmt.Errorf("tx (%X) not found, err: %w", hash, err)
+				break
 			}
 
 			height := r.Height
@@ -36,6 +34,9 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*cty
 
 			var proof types.TxProof
 			if prove {
+				if err := env.checkBlockPruned(height); err != nil {
+					return nil, fmt.Errorf("can't prove tx (%X): %w", hash, err)
+				}
 				block := env.BlockStore.LoadBlock(height)
 				proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 			}
@@ -47,11 +48,80 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*cty
 				TxResult: r.Result,
 				Tx:       r.Tx,
 				Proof:    proof,
+				Latency:  r.Latency,
 			}, nil
 		}
 	}
 
-	return nil, fmt.Errorf("transaction querying is disabled on this node due to the KV event sink being disabled")
+	if res := env.txFromBlockStore(hash, prove); res != nil {
+		return res, nil
+	}
+	return nil, notFoundErr
+}
+
+// txFromBlockStore returns the tx with the given hash and its result, using
+// the tx hash index of the block store. It returns nil if the tx, its block or
+// its result isn't stored.
+func (env *Environment) txFromBlockStore(hash []byte, prove bool) *ctypes.ResultTx {
+	height, index, found := env.BlockStore.LoadTxLocation(hash)
+	if !found {
+		return nil
+	}
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil || int(index) >= len(block.Txs) {
+		return nil
+	}
+	results, err := env.StateStore.LoadABCIResponses(height)
+	if err != nil || int(index) >= len(results.DeliverTxs) || results.DeliverTxs[index] == nil {
+		return nil
+	}
+
+	var proof types.TxProof
+	if prove {
+		proof = block.Data.Txs.Proof(int(index))
+	}
+
+	return &ctypes.ResultTx{
+		Hash:     hash,
+		Height:   height,
+		Index:    index,
+		TxResult: *results.DeliverTxs[index],
+		Tx:       block.Txs[index],
+		Proof:    proof,
+	}
+}
+
+// TxProof returns the transaction with the given hash and its result, with
+// the proofs of the inclusion of the transaction in the DataHash of the header
+// of its block, and of the result in the LastResultsHash of the header of the
+// next block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/tx_proof
+func (env *Environment) TxProof(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxProof, error) {
+	res, err := env.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
+	}
+
+	abciResponses, err := env.StateStore.LoadABCIResponses(res.Height)
+	if err != nil {
+		return nil, fmt.Errorf("can't prove the result of tx (%X): %w", hash, err)
+	}
+	results := types.NewResults(abciResponses.DeliverTxs)
+	if int(res.Index) >= len(results) {
+		return nil, fmt.Errorf("can't prove the result of tx (%X): block %d has %d results",
+			hash, res.Height, len(results))
+	}
+
+	return &ctypes.ResultTxProof{
+		Hash:        hash,
+		Height:      res.Height,
+		Index:       res.Index,
+		Tx:          res.Tx,
+		TxResult:    res.TxResult,
+		TxProof:     res.Proof,
+		ResultProof: results.ProveResult(int(res.Index)),
+		ResultsHash: results.Hash(),
+	}, nil
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
@@ -130,6 +200,7 @@ func (env *Environment) TxSearch(
 					TxResult: r.Result,
 					Tx:       r.Tx,
 					Proof:    proof,
+					Latency:  r.Latency,
 				})
 			}
 
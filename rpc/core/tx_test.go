@@ -0,0 +1,118 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxFromBlockStore(t *testing.T) {
+	// no tx indexer is needed to look txs up by hash
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	env.BlockStore = blockStore
+
+	txs := []types.Tx{types.Tx("a"), types.Tx("b")}
+	block := types.MakeBlock(1, txs, new(types.Commit), nil)
+	_, err := factory.MakeHeader(&block.Header)
+	require.NoError(t, err)
+	blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), new(types.Commit))
+	require.NoError(t, env.StateStore.SaveABCIResponses(1, &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 0, Data: []byte("a")}, {Code: 1, Data: []byte("b")}},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}))
+
+	res, err := env.Tx(&rpctypes.Context{}, txs[1].Hash(), true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Height)
+	assert.EqualValues(t, 1, res.Index)
+	assert.Equal(t, txs[1], res.Tx)
+	assert.EqualValues(t, 1, res.TxResult.Code)
+	assert.NoError(t, res.Proof.Validate(block.DataHash))
+
+	// unknown txs are looked up in the tx indexer, which is disabled
+	_, err = env.Tx(&rpctypes.Context{}, types.Tx("c").Hash(), false)
+	assert.Error(t, err)
+}
+
+func TestTxProof(t *testing.T) {
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	env.BlockStore = blockStore
+
+	txs := []types.Tx{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+	block := types.MakeBlock(1, txs, new(types.Commit), nil)
+	_, err := factory.MakeHeader(&block.Header)
+	require.NoError(t, err)
+	blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), new(types.Commit))
+	deliverTxs := []*abci.ResponseDeliverTx{
+		{Code: 0, Data: []byte("a")},
+		{Code: 1, Data: []byte("b"), Log: "not deterministic"},
+		{Code: 0, Data: []byte("c")},
+	}
+	require.NoError(t, env.StateStore.SaveABCIResponses(1, &tmstate.ABCIResponses{
+		DeliverTxs: deliverTxs,
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}))
+	// the LastResultsHash of the header at height 2
+	lastResultsHash := types.NewResults(deliverTxs).Hash()
+
+	res, err := env.TxProof(&rpctypes.Context{}, txs[1].Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Height)
+	assert.EqualValues(t, 1, res.Index)
+	assert.Equal(t, txs[1], res.Tx)
+	assert.EqualValues(t, lastResultsHash, res.ResultsHash)
+	assert.NoError(t, res.Verify(block.DataHash, lastResultsHash))
+
+	// proofs of another tx or result don't verify
+	other, err := env.TxProof(&rpctypes.Context{}, txs[2].Hash())
+	require.NoError(t, err)
+	forged := *res
+	forged.TxProof = other.TxProof
+	assert.Error(t, forged.Verify(block.DataHash, lastResultsHash))
+	forged = *res
+	forged.ResultProof = other.ResultProof
+	assert.Error(t, forged.Verify(block.DataHash, lastResultsHash))
+	forged = *res
+	forged.TxResult.Code = 0
+	assert.Error(t, forged.Verify(block.DataHash, lastResultsHash))
+	assert.Error(t, res.Verify(block.DataHash, block.LastResultsHash))
+
+	_, err = env.TxProof(&rpctypes.Context{}, types.Tx("d").Hash())
+	assert.Error(t, err)
+}
+
+func TestTxLatency(t *testing.T) {
+	// the tx indexer records the latency of the txs
+	sink := kv.NewEventSink(dbm.NewMemDB())
+	env := &Environment{EventSinks: []indexer.EventSink{sink}}
+	tx := types.Tx("a")
+	require.NoError(t, sink.IndexTxEvents([]*abci.TxResult{{
+		Height:  1,
+		Tx:      tx,
+		Latency: 3 * time.Second,
+	}}))
+
+	res, err := env.Tx(&rpctypes.Context{}, tx.Hash(), false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Height)
+	assert.Equal(t, 3*time.Second, res.Latency)
+}
@@ -3,12 +3,16 @@ package coretypes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/metadata"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -23,12 +27,37 @@ var (
 	// ErrInvalidRequest is used as a wrapper to cover more specific cases where the user has
 	// made an invalid request
 	ErrInvalidRequest = errors.New("invalid request")
+	// ErrShuttingDown is returned for txs broadcast while the node shuts down
+	ErrShuttingDown = errors.New("node is shutting down")
 )
 
+// ErrHeightPruned is returned when the data of the requested height was pruned.
+// It wraps ErrHeightNotAvailable.
+type ErrHeightPruned struct {
+	Height int64
+	// Earliest height the data is available for
+	EarliestHeight int64
+}
+
+func (e ErrHeightPruned) Error() string {
+	return fmt.Sprintf("%v: height %d was pruned, earliest available height is %d",
+		ErrHeightNotAvailable, e.Height, e.EarliestHeight)
+}
+
+func (e ErrHeightPruned) Unwrap() error {
+	return ErrHeightNotAvailable
+}
+
+// DumpConsensusStateVersion is the version of the ResultDumpConsensusStateV2
+// schema.
+const DumpConsensusStateVersion = 2
+
 // List of blocks
 type ResultBlockchainInfo struct {
 	LastHeight int64              `json:"last_height"`
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
+	// height to pass as cursor to get the next headers, 0 if there are none
+	NextCursor int64 `json:"next_cursor,omitempty"`
 }
 
 // Genesis file
@@ -58,10 +87,47 @@ type ResultCommit struct {
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// ResultLightBlock is the signed header and validator set at a height, as a
+// protobuf encoded tendermint.types.LightBlock.
+type ResultLightBlock struct {
+	Height     int64  `json:"height"`
+	LightBlock []byte `json:"light_block"`
+}
+
+// NewResultLightBlock encodes the given light block.
+func NewResultLightBlock(lb *types.LightBlock) (*ResultLightBlock, error) {
+	pb, err := lb.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &ResultLightBlock{Height: lb.Height, LightBlock: bz}, nil
+}
+
+// DecodeLightBlock decodes the light block. It does not validate it.
+func (r *ResultLightBlock) DecodeLightBlock() (*types.LightBlock, error) {
+	pb := new(tmproto.LightBlock)
+	if err := pb.Unmarshal(r.LightBlock); err != nil {
+		return nil, err
+	}
+	return types.LightBlockFromProto(pb)
+}
+
+// List of light blocks, in ascending order
+type ResultLightBlocks struct {
+	LastHeight  int64              `json:"last_height"`
+	LightBlocks []ResultLightBlock `json:"light_blocks"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
 	TxsResults            []*abci.ResponseDeliverTx `json:"txs_results"`
+	TotalGasWanted        int64                     `json:"total_gas_wanted"`
+	TotalGasUsed          int64                     `json:"total_gas_used"`
 	BeginBlockEvents      []abci.Event              `json:"begin_block_events"`
 	EndBlockEvents        []abci.Event              `json:"end_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
@@ -94,6 +160,9 @@ type SyncInfo struct {
 	EarliestBlockHeight int64          `json:"earliest_block_height"`
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
+	// the earliest height whose state, i.e. its block results, is stored
+	EarliestStateHeight int64 `json:"earliest_state_height"`
+
 	CatchingUp bool `json:"catching_up"`
 }
 
@@ -102,6 +171,8 @@ type ValidatorInfo struct {
 	Address     bytes.HexBytes `json:"address"`
 	PubKey      crypto.PubKey  `json:"pub_key"`
 	VotingPower int64          `json:"voting_power"`
+	// set if a bech32 validator prefix is configured
+	AddressBech32 string `json:"address_bech32,omitempty"`
 }
 
 // Node Status
@@ -109,6 +180,27 @@ type ResultStatus struct {
 	NodeInfo      p2p.NodeInfo  `json:"node_info"`
 	SyncInfo      SyncInfo      `json:"sync_info"`
 	ValidatorInfo ValidatorInfo `json:"validator_info"`
+	// set if a bech32 node ID prefix is configured
+	NodeIDBech32 string    `json:"node_id_bech32,omitempty"`
+	BuildInfo    BuildInfo `json:"build_info"`
+	// the names of the protocol features the node supports
+	Features  []string  `json:"features"`
+	StoreInfo StoreInfo `json:"store_info"`
+}
+
+// Info about the build of the node's binary
+type BuildInfo struct {
+	Version   string   `json:"version"`
+	GitCommit string   `json:"git_commit"`
+	GoVersion string   `json:"go_version"`
+	BuildTags []string `json:"build_tags"`
+}
+
+// Info about the node's databases
+type StoreInfo struct {
+	// disk usage of each database, in bytes, keyed by name, e.g. "blockstore"
+	DiskUsage      map[string]int64 `json:"disk_usage"`
+	TotalDiskUsage int64            `json:"total_disk_usage"`
 }
 
 // Is TxIndexing enabled
@@ -125,6 +217,9 @@ type ResultNetInfo struct {
 	Listeners []string `json:"listeners"`
 	NPeers    int      `json:"n_peers"`
 	Peers     []Peer   `json:"peers"`
+	// Metadata records published by nodes, including this one, and gossiped
+	// to this node.
+	NodeMetadata []metadata.SignedNodeMetadata `json:"node_metadata"`
 }
 
 // Log from dialing seeds
@@ -137,12 +232,21 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Chaos policy applied to the messages received from peers, and whether the
+// node is built with chaos hooks
+type ResultChaosPolicy struct {
+	Enabled bool            `json:"enabled"`
+	Policy  p2p.ChaosPolicy `json:"policy"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.NodeInfo         `json:"node_info"`
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// set if a bech32 node ID prefix is configured
+	NodeIDBech32 string `json:"node_id_bech32,omitempty"`
 }
 
 // Validators for a height.
@@ -153,12 +257,73 @@ type ResultValidators struct {
 	Count int `json:"count"`
 	// Total number of validators
 	Total int `json:"total"`
+	// Bech32 addresses of the validators, in the same order. Set if a bech32
+	// validator prefix is configured.
+	AddressesBech32 []string `json:"addresses_bech32,omitempty"`
+}
+
+// ResultValidatorChanges lists the changes of the validator set between two
+// heights, without the intermediate validator sets.
+type ResultValidatorChanges struct {
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+	// Validators in the set at ToHeight but not at FromHeight, in the order of
+	// the set at ToHeight
+	Joined []*types.Validator `json:"joined"`
+	// Validators in the set at FromHeight but not at ToHeight, in the order of
+	// the set at FromHeight
+	Left []*types.Validator `json:"left"`
+	// Validators in both sets whose voting power changed, in the order of the
+	// set at ToHeight
+	PowerChanges []ValidatorPowerChange `json:"power_changes"`
 }
 
-// ConsensusParams for given height
+// ValidatorPowerChange is a change of the voting power of a validator.
+type ValidatorPowerChange struct {
+	Address   types.Address `json:"address"`
+	FromPower int64         `json:"from_power"`
+	ToPower   int64         `json:"to_power"`
+}
+
+// NewResultValidatorChanges compares the validator sets at two heights.
+func NewResultValidatorChanges(
+	fromHeight, toHeight int64,
+	from, to *types.ValidatorSet,
+) *ResultValidatorChanges {
+	res := &ResultValidatorChanges{
+		FromHeight:   fromHeight,
+		ToHeight:     toHeight,
+		Joined:       []*types.Validator{},
+		Left:         []*types.Validator{},
+		PowerChanges: []ValidatorPowerChange{},
+	}
+	for _, val := range to.Validators {
+		_, prev := from.GetByAddress(val.Address)
+		switch {
+		case prev == nil:
+			res.Joined = append(res.Joined, val)
+		case prev.VotingPower != val.VotingPower:
+			res.PowerChanges = append(res.PowerChanges, ValidatorPowerChange{
+				Address:   val.Address,
+				FromPower: prev.VotingPower,
+				ToPower:   val.VotingPower,
+			})
+		}
+	}
+	for _, val := range from.Validators {
+		if !to.HasAddress(val.Address) {
+			res.Left = append(res.Left, val)
+		}
+	}
+	return res
+}
+
+// ConsensusParams for given height, and the updates scheduled at later
+// heights as of it
 type ResultConsensusParams struct {
-	BlockHeight     int64                 `json:"block_height"`
-	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+	BlockHeight              int64                           `json:"block_height"`
+	ConsensusParams          types.ConsensusParams           `json:"consensus_params"`
+	ScheduledConsensusParams []abci.ScheduledConsensusParams `json:"scheduled_consensus_params,omitempty"`
 }
 
 // Info about the consensus state.
@@ -174,6 +339,12 @@ type PeerStateInfo struct {
 	PeerState   json.RawMessage `json:"peer_state"`
 }
 
+// Versioned consensus state, see proto/tendermint/consensus/state.proto for
+// the schema.
+type ResultDumpConsensusStateV2 struct {
+	State tmcons.StateDump `json:"state"`
+}
+
 // UNSTABLE
 type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
@@ -189,6 +360,28 @@ type ResultBroadcastTx struct {
 	Hash bytes.HexBytes `json:"hash"`
 }
 
+// Status of a tx of a batch broadcast with /broadcast_tx_batch
+const (
+	// the mempool accepted the tx
+	BatchTxAccepted = "accepted"
+	// the mempool already has or recently had the tx
+	BatchTxCached = "cached"
+	// the app or the mempool rejected the tx, with the code and log of the
+	// rejection
+	BatchTxRejected = "rejected"
+)
+
+// CheckTx results of a batch of txs, in the order of the txs
+type ResultBroadcastTxBatch struct {
+	Txs []ResultBroadcastBatchTx `json:"txs"`
+}
+
+// CheckTx result of a tx of a batch
+type ResultBroadcastBatchTx struct {
+	Status string `json:"status"`
+	ResultBroadcastTx
+}
+
 // CheckTx and DeliverTx results
 type ResultBroadcastTxCommit struct {
 	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
@@ -210,6 +403,47 @@ type ResultTx struct {
 	TxResult abci.ResponseDeliverTx `json:"tx_result"`
 	Tx       types.Tx               `json:"tx"`
 	Proof    types.TxProof          `json:"proof,omitempty"`
+	// time from the tx being added to the mempool of the node to the tx being
+	// committed, if recorded by the tx indexer
+	Latency time.Duration `json:"latency"`
+}
+
+// ResultTxProof is a tx and its result, with the proofs of their inclusion in
+// the block of the tx, for /tx_proof.
+type ResultTxProof struct {
+	Hash     bytes.HexBytes         `json:"hash"`
+	Height   int64                  `json:"height"`
+	Index    uint32                 `json:"index"`
+	Tx       types.Tx               `json:"tx"`
+	TxResult abci.ResponseDeliverTx `json:"tx_result"`
+	// Proof of the tx against the DataHash of the header at Height.
+	TxProof types.TxProof `json:"tx_proof"`
+	// Proof of the result against ResultsHash, the LastResultsHash of the
+	// header at Height+1.
+	ResultProof merkle.Proof   `json:"result_proof"`
+	ResultsHash bytes.HexBytes `json:"results_hash"`
+}
+
+// Verify verifies the proofs against the DataHash of the header at Height and
+// the LastResultsHash of the header at Height+1, which the caller must trust.
+func (r *ResultTxProof) Verify(dataHash, lastResultsHash []byte) error {
+	if string(r.TxProof.Data) != string(r.Tx) {
+		return errors.New("tx proof doesn't prove the tx")
+	}
+	if r.TxProof.Proof.Index != int64(r.Index) || r.ResultProof.Index != int64(r.Index) {
+		return fmt.Errorf("proofs don't prove the tx at index %d", r.Index)
+	}
+	if err := r.TxProof.Validate(dataHash); err != nil {
+		return fmt.Errorf("invalid tx proof: %w", err)
+	}
+	result, err := types.NewResults([]*abci.ResponseDeliverTx{&r.TxResult})[0].Marshal()
+	if err != nil {
+		return err
+	}
+	if err := r.ResultProof.Verify(lastResultsHash, result); err != nil {
+		return fmt.Errorf("invalid result proof: %w", err)
+	}
+	return nil
 }
 
 // Result of searching for txs
@@ -230,6 +464,9 @@ type ResultUnconfirmedTxs struct {
 	Total      int        `json:"total"`
 	TotalBytes int64      `json:"total_bytes"`
 	Txs        []types.Tx `json:"txs"`
+	// fraction of the max block gas used by the last blocks, averaged over
+	// state.GasUtilizationWindow blocks
+	BlockGasUtilization float64 `json:"block_gas_utilization"`
 }
 
 // Info abci msg
@@ -245,15 +482,31 @@ type ResultABCIQuery struct {
 // Result of broadcasting evidence
 type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
+	// Status of the evidence, pending or committed. The hash can be passed to
+	// the evidence route to track the status.
+	Status string `json:"status"`
+}
+
+// Status of evidence, looked up by hash
+type ResultEvidence struct {
+	Hash bytes.HexBytes `json:"hash"`
+	// pending, committed, rejected or unknown
+	Status string `json:"status"`
+	// height of the block the evidence was committed in
+	Height int64 `json:"height,omitempty"`
+	// reason the evidence was rejected
+	Reason string `json:"reason,omitempty"`
 }
 
 // empty results
 type (
-	ResultUnsafeFlushMempool struct{}
-	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
-	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
+	ResultUnsafeFlushMempool        struct{}
+	ResultUnsafeProduceBlock        struct{}
+	ResultUnsafeStopWaitingForPeers struct{}
+	ResultUnsafeProfile             struct{}
+	ResultSubscribe                 struct{}
+	ResultUnsubscribe               struct{}
+	ResultHealth                    struct{}
 )
 
 // Event data from a subscription
@@ -5,7 +5,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestStatusIndexer(t *testing.T) {
@@ -33,3 +35,31 @@ func TestStatusIndexer(t *testing.T) {
 		assert.Equal(t, tc.expected, status.TxIndexEnabled())
 	}
 }
+
+func TestNewResultValidatorChanges(t *testing.T) {
+	stay := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	grow := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	leave := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	join := types.NewValidator(ed25519.GenPrivKey().PubKey(), 5)
+	grown := grow.Copy()
+	grown.VotingPower = 20
+
+	from := types.NewValidatorSet([]*types.Validator{stay, grow, leave})
+	to := types.NewValidatorSet([]*types.Validator{stay.Copy(), grown, join})
+
+	res := NewResultValidatorChanges(1, 3, from, to)
+	assert.EqualValues(t, 1, res.FromHeight)
+	assert.EqualValues(t, 3, res.ToHeight)
+	if assert.Len(t, res.Joined, 1) {
+		assert.Equal(t, join.Address, res.Joined[0].Address)
+	}
+	if assert.Len(t, res.Left, 1) {
+		assert.Equal(t, leave.Address, res.Left[0].Address)
+	}
+	assert.Equal(t, []ValidatorPowerChange{{Address: grow.Address, FromPower: 10, ToPower: 20}}, res.PowerChanges)
+
+	res = NewResultValidatorChanges(1, 1, from, from)
+	assert.Empty(t, res.Joined)
+	assert.Empty(t, res.Left)
+	assert.Empty(t, res.PowerChanges)
+}
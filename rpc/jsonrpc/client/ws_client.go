@@ -509,6 +509,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFromHeight subscribes to a query like Subscribe, but the server
+// first replays the indexed events of the query from the given height. Note
+// the server must support the "from_height" param of the "subscribe" route.
+func (c *WSClient) SubscribeFromHeight(ctx context.Context, query string, fromHeight int64) error {
+	params := map[string]interface{}{"query": query, "from_height": fromHeight}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
@@ -0,0 +1,41 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"time"
+)
+
+// writeDebugBundle writes the goroutine stacks and the heap profile of the
+// node to a new directory in dir, named after the reason and the height, so
+// that operators can investigate why the node got stuck. It returns the
+// directory. The files are named as by `tendermint debug dump`.
+func writeDebugBundle(dir, reason string, height int64) (string, error) {
+	bundleDir := filepath.Join(dir, fmt.Sprintf("%s-%d-%d", reason, height, time.Now().Unix()))
+	if err := os.MkdirAll(bundleDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create debug bundle directory: %w", err)
+	}
+
+	for _, profile := range []string{"goroutine", "heap"} {
+		if err := writeProfile(filepath.Join(bundleDir, profile+".out"), profile); err != nil {
+			return bundleDir, err
+		}
+	}
+	return bundleDir, nil
+}
+
+func writeProfile(path, profile string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s profile: %w", profile, err)
+	}
+	defer f.Close()
+
+	// debug=2 prints the goroutine stacks as an unrecovered panic would
+	if err := pprof.Lookup(profile).WriteTo(f, 2); err != nil {
+		return fmt.Errorf("failed to write %s profile: %w", profile, err)
+	}
+	return nil
+}
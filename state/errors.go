@@ -1,6 +1,11 @@
 package state
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
 
 type (
 	ErrInvalidBlock error
@@ -48,6 +53,16 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrStoreDowngrade struct {
+		Stored  tmstate.StoreVersion
+		Current tmstate.StoreVersion
+	}
+
+	ErrAppCommitTimeout struct {
+		Height  int64
+		Timeout time.Duration
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -99,3 +114,15 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
+
+func (e ErrStoreDowngrade) Error() string {
+	return fmt.Sprintf(
+		"the state was written by version %s (state format %d), which is newer than this version %s "+
+			"(state format %d); running an older version on newer state can corrupt it",
+		e.Stored.Software, e.Stored.StateFormat, e.Current.Software, e.Current.StateFormat,
+	)
+}
+
+func (e ErrAppCommitTimeout) Error() string {
+	return fmt.Sprintf("app did not answer Commit at height %d within %v; halting", e.Height, e.Timeout)
+}
@@ -1,12 +1,15 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/internal/libs/fail"
 	"github.com/tendermint/tendermint/libs/log"
@@ -40,8 +43,20 @@ type BlockExecutor struct {
 	logger  log.Logger
 	metrics *Metrics
 
+	// validate the events of executed blocks against this, if set
+	eventSchema *types.EventSchema
+
 	// cache the verification results over a single height
 	cache map[string]struct{}
+
+	// the moving average of the block gas utilization, for the metrics
+	gasUtilization gasUtilizationAverage
+
+	// the deadline for the app to answer Commit, 0 for none, and what to do
+	// on a breach
+	commitTimeout       time.Duration
+	commitTimeoutHalt   bool
+	commitTimeoutBundle string // directory for the debug bundles, "" for none
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -52,6 +67,28 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithEventSchema makes the executor validate the events emitted
+// by the app while executing blocks against the schema. Events which don't
+// conform to the schema are logged, but don't fail the block.
+func BlockExecutorWithEventSchema(schema *types.EventSchema) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.eventSchema = schema
+	}
+}
+
+// BlockExecutorWithCommitTimeout sets a deadline for the app to answer ABCI
+// Commit. On a breach, the executor logs an error, publishes an
+// AppCommitTimeout event and writes a debug bundle to bundleDir, unless it's
+// empty. Then, if halt is true, Commit fails, which halts consensus, otherwise
+// it keeps waiting for the app.
+func BlockExecutorWithCommitTimeout(timeout time.Duration, halt bool, bundleDir string) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.commitTimeout = timeout
+		blockExec.commitTimeoutHalt = halt
+		blockExec.commitTimeoutBundle = bundleDir
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -154,7 +191,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, blockExec.metrics,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -169,6 +206,12 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, 0, err
 	}
 
+	_, gasUsed := BlockGas(abciResponses)
+	blockExec.metrics.BlockGasUtilization.Set(
+		blockExec.gasUtilization.add(gasUtilization(gasUsed, state.ConsensusParams.Block.MaxGas)))
+
+	blockExec.validateEvents(block.Height, abciResponses)
+
 	fail.Fail() // XXX
 
 	// validate the validator updates and convert to tendermint types
@@ -192,11 +235,17 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
 
+	// Look up when the txs were added to the mempool, before the mempool
+	// update removes them.
+	firstSeen := blockExec.txsFirstSeen(block.Txs)
+
 	// Lock mempool, commit app state, update mempoool.
 	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
 	if err != nil {
-		return state, 0, fmt.Errorf("commit failed for application: %v", err)
+		return state, 0, fmt.Errorf("commit failed for application: %w", err)
 	}
+	txLatencies := blockExec.observeTxLatencies(firstSeen, time.Now())
+	blockExec.metrics.observePhase(phaseAppHashWait, block.Height, time.Duration(time.Now().UnixNano()-endTime))
 
 	// Update evpool with the latest state.
 	blockExec.evpool.Update(state, block.Evidence.Evidence)
@@ -216,11 +265,36 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates, txLatencies)
 
 	return state, retainHeight, nil
 }
 
+// txsFirstSeen returns the time each tx was added to the mempool, or the zero
+// time for the txs which are not in the mempool.
+func (blockExec *BlockExecutor) txsFirstSeen(txs types.Txs) []time.Time {
+	firstSeen := make([]time.Time, len(txs))
+	for i, tx := range txs {
+		firstSeen[i], _ = blockExec.mempool.TxFirstSeen(tx)
+	}
+	return firstSeen
+}
+
+// observeTxLatencies records the time from the txs being added to the mempool
+// to being committed, and returns it. The latency of the txs which were not in
+// the mempool is 0 and not recorded.
+func (blockExec *BlockExecutor) observeTxLatencies(firstSeen []time.Time, committed time.Time) []time.Duration {
+	latencies := make([]time.Duration, len(firstSeen))
+	for i, seen := range firstSeen {
+		if seen.IsZero() {
+			continue
+		}
+		latencies[i] = committed.Sub(seen)
+		blockExec.metrics.TxLatency.Observe(latencies[i].Seconds())
+	}
+	return latencies
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -244,11 +318,13 @@ func (blockExec *BlockExecutor) Commit(
 	}
 
 	// Commit block, get hash back
-	res, err := blockExec.proxyApp.CommitSync(context.Background())
+	commitStart := time.Now()
+	res, err := blockExec.commitApp(block.Height)
 	if err != nil {
 		blockExec.logger.Error("client error during proxyAppConn.CommitSync", "err", err)
 		return nil, 0, err
 	}
+	blockExec.metrics.observePhase(phaseCommit, block.Height, time.Since(commitStart))
 
 	// ResponseCommit has no error code - just data
 	blockExec.logger.Info(
@@ -270,6 +346,71 @@ func (blockExec *BlockExecutor) Commit(
 	return res.Data, res.RetainHeight, err
 }
 
+// commitApp calls Commit on the app, enforcing the commit timeout.
+func (blockExec *BlockExecutor) commitApp(height int64) (*abci.ResponseCommit, error) {
+	if blockExec.commitTimeout <= 0 {
+		return blockExec.proxyApp.CommitSync(context.Background())
+	}
+
+	type result struct {
+		res *abci.ResponseCommit
+		err error
+	}
+	// buffered, so the call can complete after we stopped waiting on a halt
+	resCh := make(chan result, 1)
+	go func() {
+		res, err := blockExec.proxyApp.CommitSync(context.Background())
+		resCh <- result{res, err}
+	}()
+
+	timer := time.NewTimer(blockExec.commitTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-resCh:
+		return r.res, r.err
+	case <-timer.C:
+	}
+
+	blockExec.onCommitTimeout(height)
+	if blockExec.commitTimeoutHalt {
+		return nil, ErrAppCommitTimeout{Height: height, Timeout: blockExec.commitTimeout}
+	}
+	r := <-resCh
+	blockExec.logger.Info("app answered Commit after the deadline",
+		"height", height, "timeout", blockExec.commitTimeout)
+	return r.res, r.err
+}
+
+// onCommitTimeout reports the app breaching the commit timeout.
+func (blockExec *BlockExecutor) onCommitTimeout(height int64) {
+	blockExec.metrics.AppCommitTimeouts.Add(1)
+
+	var bundle string
+	if blockExec.commitTimeoutBundle != "" {
+		var err error
+		bundle, err = writeDebugBundle(blockExec.commitTimeoutBundle, "app-commit-timeout", height)
+		if err != nil {
+			blockExec.logger.Error("failed to write debug bundle", "err", err)
+		}
+	}
+
+	blockExec.logger.Error("CRITICAL: app did not answer Commit within the deadline; it may be deadlocked",
+		"height", height,
+		"timeout", blockExec.commitTimeout,
+		"debug_bundle", bundle,
+		"halt", blockExec.commitTimeoutHalt,
+	)
+
+	if err := blockExec.eventBus.PublishEventAppCommitTimeout(types.EventDataAppCommitTimeout{
+		Height:      height,
+		Timeout:     blockExec.commitTimeout,
+		DebugBundle: bundle,
+		Halt:        blockExec.commitTimeoutHalt,
+	}); err != nil {
+		blockExec.logger.Error("failed publishing app commit timeout", "err", err)
+	}
+}
+
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
@@ -281,6 +422,7 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	metrics *Metrics,
 ) (*tmstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -289,6 +431,11 @@ func execBlockOnProxyApp(
 	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
 	abciResponses.DeliverTxs = dtxs
 
+	// DeliverTxs are pipelined, so a tx is processed from when it was sent or
+	// the previous tx was answered, whichever is later, until it is answered.
+	txSentAt := make([]time.Time, len(block.Txs))
+	var lastTxDone time.Time
+
 	// Execute transactions and get hash.
 	proxyCb := func(req *abci.Request, res *abci.Response) {
 		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
@@ -304,6 +451,15 @@ func execBlockOnProxyApp(
 			}
 
 			abciResponses.DeliverTxs[txIndex] = txRes
+
+			now := time.Now()
+			start := txSentAt[txIndex]
+			if lastTxDone.After(start) {
+				start = lastTxDone
+			}
+			metrics.observePhase(phaseDeliverTx, block.Height, now.Sub(start))
+			lastTxDone = now
+
 			txIndex++
 		}
 	}
@@ -325,6 +481,7 @@ func execBlockOnProxyApp(
 		return nil, errors.New("nil header")
 	}
 
+	beginBlockStart := time.Now()
 	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(
 		ctx,
 		abci.RequestBeginBlock{
@@ -332,26 +489,36 @@ func execBlockOnProxyApp(
 			Header:              *pbh,
 			LastCommitInfo:      commitInfo,
 			ByzantineValidators: byzVals,
+			Randomness:          block.Randomness(),
 		},
 	)
 	if err != nil {
 		logger.Error("error in proxyAppConn.BeginBlock", "err", err)
 		return nil, err
 	}
+	metrics.observePhase(phaseBeginBlock, block.Height, time.Since(beginBlockStart))
 
 	// run txs of block
-	for _, tx := range block.Txs {
+	for i, tx := range block.Txs {
+		txSentAt[i] = time.Now()
 		_, err = proxyAppConn.DeliverTxAsync(ctx, abci.RequestDeliverTx{Tx: tx})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// EndBlockSync flushes the connection, so all DeliverTxs are answered
+	// before EndBlock is processed.
+	endBlockStart := time.Now()
 	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(ctx, abci.RequestEndBlock{Height: block.Height})
 	if err != nil {
 		logger.Error("error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
 	}
+	if lastTxDone.After(endBlockStart) {
+		endBlockStart = lastTxDone
+	}
+	metrics.observePhase(phaseEndBlock, block.Height, time.Since(endBlockStart))
 
 	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
 	return abciResponses, nil
@@ -466,6 +633,30 @@ func updateState(
 		lastHeightParamsChanged = header.Height + 1
 	}
 
+	// Schedule the params updates for future heights, and apply the ones which
+	// activate at the next height.
+	scheduledParams, err := scheduleConsensusParams(state.ScheduledConsensusParams,
+		abciResponses.EndBlock.ScheduledConsensusParamUpdates, nextParams, header.Height)
+	if err != nil {
+		return state, fmt.Errorf("error scheduling consensus params: %v", err)
+	}
+	for len(scheduledParams) > 0 && scheduledParams[0].ActivationHeight == header.Height+1 {
+		nextParams = nextParams.UpdateConsensusParams(scheduledParams[0].Params)
+		if err := nextParams.ValidateConsensusParams(); err != nil {
+			return state, fmt.Errorf("error activating consensus params scheduled at height %d: %v",
+				header.Height+1, err)
+		}
+		scheduledParams = scheduledParams[1:]
+
+		state.Version.Consensus.App = nextParams.Version.AppVersion
+		lastHeightParamsChanged = header.Height + 1
+	}
+
+	jailedVals, err := jailedValidators(abciResponses.EndBlock.JailedValidators, state.NextValidators)
+	if err != nil {
+		return state, fmt.Errorf("error jailing validators: %v", err)
+	}
+
 	nextVersion := state.Version
 
 	// NOTE: the AppHash has not been populated.
@@ -483,11 +674,96 @@ func updateState(
 		LastHeightValidatorsChanged:      lastHeightValsChanged,
 		ConsensusParams:                  nextParams,
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
+		ScheduledConsensusParams:         scheduledParams,
+		JailedValidators:                 jailedVals,
 		LastResultsHash:                  ABCIResponsesResultsHash(abciResponses),
 		AppHash:                          nil,
 	}, nil
 }
 
+// jailedValidators returns the addresses of the validators jailed by EndBlock
+// which are in the validator set of the next height, dropping the others. The
+// votes of the jailed validators are ignored at the next height, so they must
+// have less than 1/3 of its voting power for the others to commit a block.
+func jailedValidators(addresses [][]byte, valSet *types.ValidatorSet) ([][]byte, error) {
+	var (
+		jailed      [][]byte
+		jailedPower int64
+	)
+	for _, address := range addresses {
+		if len(address) != crypto.AddressSize {
+			return nil, fmt.Errorf("invalid address %X: expected %d bytes, got %d",
+				address, crypto.AddressSize, len(address))
+		}
+		_, val := valSet.GetByAddress(address)
+		if val == nil || containsAddress(jailed, address) {
+			continue
+		}
+		jailed = append(jailed, address)
+		jailedPower += val.VotingPower
+	}
+	if jailedPower*3 >= valSet.TotalVotingPower() {
+		return nil, fmt.Errorf("jailed validators have %d of the %d voting power of the next height, "+
+			"must be less than 1/3", jailedPower, valSet.TotalVotingPower())
+	}
+	return jailed, nil
+}
+
+// containsAddress returns true if addresses contains address.
+func containsAddress(addresses [][]byte, address []byte) bool {
+	for _, a := range addresses {
+		if bytes.Equal(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleConsensusParams adds the params updates returned by EndBlock of the
+// given height to the scheduled ones, keeping them sorted by activation
+// height. Updates with the same activation height are applied in the order in
+// which they were scheduled. An update must activate after the next height,
+// whose params are updated immediately, and applying the updates to params in
+// order must result in valid params at every step.
+func scheduleConsensusParams(
+	scheduled []abci.ScheduledConsensusParams,
+	updates []abci.ScheduledConsensusParams,
+	params types.ConsensusParams,
+	height int64,
+) ([]abci.ScheduledConsensusParams, error) {
+	if len(updates) == 0 {
+		return scheduled, nil
+	}
+
+	for i, update := range updates {
+		if update.Params == nil {
+			return nil, fmt.Errorf("update #%d has no params", i)
+		}
+		if update.ActivationHeight <= height+1 {
+			return nil, fmt.Errorf("update #%d activates at height %d, must be after the next height %d",
+				i, update.ActivationHeight, height+1)
+		}
+	}
+
+	// NOTE: must not mutate the scheduled params of the previous state
+	next := make([]abci.ScheduledConsensusParams, 0, len(scheduled)+len(updates))
+	next = append(next, scheduled...)
+	next = append(next, updates...)
+	sort.SliceStable(next, func(i, j int) bool {
+		return next[i].ActivationHeight < next[j].ActivationHeight
+	})
+
+	// Check the params resulting from each update, taking the updates scheduled
+	// before it into account.
+	for _, update := range next {
+		params = params.UpdateConsensusParams(update.Params)
+		if err := params.ValidateConsensusParams(); err != nil {
+			return nil, fmt.Errorf("update at height %d results in invalid params: %v", update.ActivationHeight, err)
+		}
+	}
+	return next, nil
+}
+
 // Fire NewBlock, NewBlockHeader.
 // Fire TxEvent for every tx.
 // NOTE: if Tendermint crashes before commit, some or all of these events may be published again.
@@ -498,6 +774,7 @@ func fireEvents(
 	blockID types.BlockID,
 	abciResponses *tmstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
+	txLatencies []time.Duration,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:            block,
@@ -529,11 +806,16 @@ func fireEvents(
 	}
 
 	for i, tx := range block.Data.Txs {
+		var latency time.Duration
+		if i < len(txLatencies) {
+			latency = txLatencies[i]
+		}
 		if err := eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
-			Height: block.Height,
-			Index:  uint32(i),
-			Tx:     tx,
-			Result: *(abciResponses.DeliverTxs[i]),
+			Height:  block.Height,
+			Index:   uint32(i),
+			Tx:      tx,
+			Result:  *(abciResponses.DeliverTxs[i]),
+			Latency: latency,
 		}}); err != nil {
 			logger.Error("failed publishing event TX", "err", err)
 		}
@@ -561,7 +843,11 @@ func ExecCommitBlock(
 	initialHeight int64,
 	s State,
 ) ([]byte, error) {
-	abciResponses, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	metrics := NopMetrics()
+	if be != nil {
+		metrics = be.metrics
+	}
+	abciResponses, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight, metrics)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -581,8 +867,8 @@ func ExecCommitBlock(
 			return nil, err
 		}
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
-		fireEvents(be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(s.ConsensusParams.Block.PartSize()).Header()}
+		fireEvents(be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates, nil)
 	}
 
 	// Commit block, get hash back
@@ -595,3 +881,23 @@ func ExecCommitBlock(
 	// ResponseCommit has no error or log, just data
 	return res.Data, nil
 }
+
+// validateEvents logs the events of the block which don't conform to the event
+// schema, if any.
+func (blockExec *BlockExecutor) validateEvents(height int64, abciResponses *tmstate.ABCIResponses) {
+	if blockExec.eventSchema == nil {
+		return
+	}
+
+	if err := blockExec.eventSchema.ValidateEvents(abciResponses.BeginBlock.Events); err != nil {
+		blockExec.logger.Error("invalid BeginBlock events", "height", height, "err", err)
+	}
+	for i, txRes := range abciResponses.DeliverTxs {
+		if err := blockExec.eventSchema.ValidateEvents(txRes.Events); err != nil {
+			blockExec.logger.Error("invalid DeliverTx events", "height", height, "index", i, "err", err)
+		}
+	}
+	if err := blockExec.eventSchema.ValidateEvents(abciResponses.EndBlock.Events); err != nil {
+		blockExec.logger.Error("invalid EndBlock events", "height", height, "err", err)
+	}
+}
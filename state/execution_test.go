@@ -2,9 +2,14 @@ package state_test
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -55,6 +60,196 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// phaseHistogram records the observations of the ABCIPhaseTime histogram per
+// label values.
+type phaseHistogram struct {
+	lvs      []string
+	observed map[string]int
+}
+
+func (h *phaseHistogram) With(labelValues ...string) metrics.Histogram {
+	return &phaseHistogram{lvs: append(h.lvs, labelValues...), observed: h.observed}
+}
+
+func (h *phaseHistogram) Observe(float64) {
+	h.observed[strings.Join(h.lvs, ",")]++
+}
+
+func TestApplyBlockPhaseMetrics(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	phaseTime := &phaseHistogram{observed: make(map[string]int)}
+	m := sm.NopMetrics()
+	m.ABCIPhaseTime = phaseTime
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithMetrics(m))
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.Nil(t, err)
+
+	assert.Equal(t, map[string]int{
+		"phase,begin_block,height_mod,1":   1,
+		"phase,deliver_tx,height_mod,1":    len(block.Txs),
+		"phase,end_block,height_mod,1":     1,
+		"phase,commit,height_mod,1":        1,
+		"phase,app_hash_wait,height_mod,1": 1,
+	}, phaseTime.observed)
+}
+
+// firstSeenMempool is an empty mempool which reports the txs as added at the
+// given time.
+type firstSeenMempool struct {
+	mmock.Mempool
+	firstSeen time.Time
+}
+
+func (m firstSeenMempool) TxFirstSeen(types.Tx) (time.Time, bool) {
+	return m.firstSeen, true
+}
+
+// latencyHistogram records the observations of the TxLatency histogram.
+type latencyHistogram struct {
+	observed []float64
+}
+
+func (h *latencyHistogram) With(...string) metrics.Histogram { return h }
+
+func (h *latencyHistogram) Observe(v float64) {
+	h.observed = append(h.observed, v)
+}
+
+func TestApplyBlockTxLatency(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	txLatency := &latencyHistogram{}
+	m := sm.NopMetrics()
+	m.TxLatency = txLatency
+	mempool := firstSeenMempool{firstSeen: time.Now().Add(-time.Minute)}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mempool, sm.EmptyEvidencePool{}, sm.BlockExecutorWithMetrics(m))
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	blockExec.SetEventBus(eventBus)
+
+	txSub, err := eventBus.Subscribe(context.Background(), "TestApplyBlockTxLatency", types.EventQueryTx,
+		len(makeTxs(1)))
+	require.NoError(t, err)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.Nil(t, err)
+
+	// the latencies are recorded and published with the tx results
+	require.Len(t, txLatency.observed, len(block.Txs))
+	for _, v := range txLatency.observed {
+		assert.GreaterOrEqual(t, v, time.Minute.Seconds())
+	}
+	for range block.Txs {
+		select {
+		case msg := <-txSub.Out():
+			event, ok := msg.Data().(types.EventDataTx)
+			require.True(t, ok, "Expected event of type EventDataTx, got %T", msg.Data())
+			assert.GreaterOrEqual(t, event.Latency, time.Minute)
+		case <-time.After(1 * time.Second):
+			t.Fatal("Did not receive EventTx within 1 sec.")
+		}
+	}
+}
+
+// stuckCommitApp is a testApp whose Commit blocks until released.
+type stuckCommitApp struct {
+	testApp
+	release chan struct{}
+}
+
+func (app *stuckCommitApp) Commit() abci.ResponseCommit {
+	<-app.release
+	return app.testApp.Commit()
+}
+
+func TestApplyBlockCommitTimeout(t *testing.T) {
+	for _, halt := range []bool{false, true} {
+		halt := halt
+		t.Run(fmt.Sprintf("halt=%v", halt), func(t *testing.T) {
+			app := &stuckCommitApp{release: make(chan struct{})}
+			cc := proxy.NewLocalClientCreator(app)
+			proxyApp := proxy.NewAppConns(cc)
+			require.NoError(t, proxyApp.Start())
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			stateStore := sm.NewStore(stateDB)
+
+			bundleDir := t.TempDir()
+			blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mmock.Mempool{}, sm.EmptyEvidencePool{},
+				sm.BlockExecutorWithCommitTimeout(100*time.Millisecond, halt, bundleDir))
+
+			eventBus := types.NewEventBus()
+			require.NoError(t, eventBus.Start())
+			defer eventBus.Stop() //nolint:errcheck // ignore for tests
+			blockExec.SetEventBus(eventBus)
+			sub, err := eventBus.Subscribe(context.Background(), "TestApplyBlockCommitTimeout",
+				types.EventQueryAppCommitTimeout)
+			require.NoError(t, err)
+
+			block := makeBlock(state, 1)
+			blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+			errCh := make(chan error, 1)
+			go func() {
+				_, _, err := blockExec.ApplyBlock(state, blockID, block)
+				errCh <- err
+			}()
+
+			var event types.EventDataAppCommitTimeout
+			select {
+			case msg := <-sub.Out():
+				event = msg.Data().(types.EventDataAppCommitTimeout)
+			case <-time.After(5 * time.Second):
+				t.Fatal("Did not receive EventAppCommitTimeout within 5 sec.")
+			}
+			assert.EqualValues(t, 1, event.Height)
+			assert.Equal(t, halt, event.Halt)
+			assert.FileExists(t, filepath.Join(event.DebugBundle, "goroutine.out"))
+			assert.FileExists(t, filepath.Join(event.DebugBundle, "heap.out"))
+
+			if halt {
+				err = <-errCh
+				assert.ErrorAs(t, err, &sm.ErrAppCommitTimeout{})
+				close(app.release)
+				return
+			}
+			// without halting, the block is applied once the app answers
+			close(app.release)
+			assert.NoError(t, <-errCh)
+		})
+	}
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
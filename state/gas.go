@@ -0,0 +1,85 @@
+package state
+
+import (
+	"errors"
+
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+// GasUtilizationWindow is the number of blocks the block gas utilization is
+// averaged over.
+const GasUtilizationWindow = 10
+
+// BlockGas returns the gas wanted and used by all txs of a block.
+func BlockGas(abciResponses *tmstate.ABCIResponses) (wanted, used int64) {
+	for _, res := range abciResponses.DeliverTxs {
+		if res == nil {
+			continue
+		}
+		wanted += res.GasWanted
+		used += res.GasUsed
+	}
+	return wanted, used
+}
+
+// gasUtilization returns the fraction of the max block gas a block used, or 0
+// if the block gas is unlimited (max gas -1).
+func gasUtilization(used, maxGas int64) float64 {
+	if maxGas <= 0 {
+		return 0
+	}
+	return float64(used) / float64(maxGas)
+}
+
+// GasUtilization returns the block gas utilization at the given height: the
+// fraction of the max block gas used, averaged over the last
+// GasUtilizationWindow blocks up to the height. Blocks whose ABCI responses
+// aren't stored (yet or anymore) are left out; it is 0 if there are none.
+func GasUtilization(store Store, height int64) (float64, error) {
+	var (
+		sum    float64
+		blocks int
+	)
+	for h := height; h > 0 && h > height-GasUtilizationWindow; h-- {
+		abciResponses, err := store.LoadABCIResponses(h)
+		if errors.As(err, &ErrNoABCIResponsesForHeight{}) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+		params, err := store.LoadConsensusParams(h)
+		if err != nil {
+			return 0, err
+		}
+		_, used := BlockGas(abciResponses)
+		sum += gasUtilization(used, params.Block.MaxGas)
+		blocks++
+	}
+	if blocks == 0 {
+		return 0, nil
+	}
+	return sum / float64(blocks), nil
+}
+
+// gasUtilizationAverage is the moving average of the gas utilization of the
+// last GasUtilizationWindow blocks.
+type gasUtilizationAverage struct {
+	utilizations [GasUtilizationWindow]float64
+	next         int
+	blocks       int
+}
+
+// add records the gas utilization of the next block, and returns the average.
+func (a *gasUtilizationAverage) add(utilization float64) float64 {
+	a.utilizations[a.next] = utilization
+	a.next = (a.next + 1) % GasUtilizationWindow
+	if a.blocks < GasUtilizationWindow {
+		a.blocks++
+	}
+
+	var sum float64
+	for _, u := range a.utilizations[:a.blocks] {
+		sum += u
+	}
+	return sum / float64(a.blocks)
+}
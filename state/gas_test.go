@@ -0,0 +1,66 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBlockGas(t *testing.T) {
+	wanted, used := sm.BlockGas(&tmstate.ABCIResponses{DeliverTxs: []*abci.ResponseDeliverTx{
+		{GasWanted: 10, GasUsed: 7},
+		nil,
+		{GasWanted: 5, GasUsed: 5},
+	}})
+	assert.EqualValues(t, 15, wanted)
+	assert.EqualValues(t, 12, used)
+}
+
+func TestGasUtilization(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	params := types.DefaultConsensusParams()
+	params.Block.MaxGas = 100
+
+	// block h uses 5*h gas, over two txs
+	for h := int64(1); h <= 12; h++ {
+		require.NoError(t, stateStore.Save(makeRandomStateFromConsensusParams(params, h, 1)))
+		require.NoError(t, stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{GasUsed: 2 * h}, {GasUsed: 3 * h}},
+			EndBlock:   &abci.ResponseEndBlock{},
+			BeginBlock: &abci.ResponseBeginBlock{},
+		}))
+	}
+
+	testCases := []struct {
+		height      int64
+		utilization float64
+	}{
+		{2, 0.075},  // blocks 1 and 2
+		{12, 0.375}, // blocks 3 to 12
+		{13, 0.4},   // blocks 4 to 12, block 13 has no results yet
+	}
+	for _, tc := range testCases {
+		utilization, err := sm.GasUtilization(stateStore, tc.height)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.utilization, utilization, 1e-9, "height %d", tc.height)
+	}
+
+	// unlimited block gas
+	params.Block.MaxGas = -1
+	require.NoError(t, stateStore.Save(makeRandomStateFromConsensusParams(params, 13, 13)))
+	require.NoError(t, stateStore.SaveABCIResponses(13, &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{GasUsed: 50}},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}))
+	utilization, err := sm.GasUtilization(stateStore, 13)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.36, utilization, 1e-9)
+}
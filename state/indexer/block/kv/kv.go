@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/google/orderedcode"
@@ -23,7 +22,8 @@ var _ indexer.BlockIndexer = (*BlockerIndexer)(nil)
 // events with an underlying KV store. Block events are indexed by their height,
 // such that matching search criteria returns the respective block height(s).
 type BlockerIndexer struct {
-	store dbm.DB
+	store  dbm.DB
+	schema *types.EventSchema
 }
 
 func New(store dbm.DB) *BlockerIndexer {
@@ -32,6 +32,13 @@ func New(store dbm.DB) *BlockerIndexer {
 	}
 }
 
+// SetEventSchema sets the schema of the event attributes, whose values are
+// then indexed and searched in the canonical form of their declared type, and
+// compared as floats in range queries if declared as FLOAT.
+func (idx *BlockerIndexer) SetEventSchema(schema *types.EventSchema) {
+	idx.schema = schema
+}
+
 // Has returns true if the given height has been indexed. An error is returned
 // upon database query failure.
 func (idx *BlockerIndexer) Has(height int64) (bool, error) {
@@ -49,6 +56,7 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 // primary key: encode(block.height | height) => encode(height)
 // BeginBlock events: encode(eventType.eventAttr|eventValue|height|begin_block) => encode(height)
 // EndBlock events: encode(eventType.eventAttr|eventValue|height|end_block) => encode(height)
+// event keys: encode(block.event_keys | height) => encode(event keys), to prune them
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	batch := idx.store.NewBatch()
 	defer batch.Close()
@@ -65,15 +73,24 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	}
 
 	// 2. index BeginBlock events
-	if err := idx.indexEvents(batch, bh.ResultBeginBlock.Events, "begin_block", height); err != nil {
+	beginKeys, err := idx.indexEvents(batch, bh.ResultBeginBlock.Events, "begin_block", height)
+	if err != nil {
 		return fmt.Errorf("failed to index BeginBlock events: %w", err)
 	}
 
 	// 3. index EndBlock events
-	if err := idx.indexEvents(batch, bh.ResultEndBlock.Events, "end_block", height); err != nil {
+	endKeys, err := idx.indexEvents(batch, bh.ResultEndBlock.Events, "end_block", height)
+	if err != nil {
 		return fmt.Errorf("failed to index EndBlock events: %w", err)
 	}
 
+	// 4. record the event keys, so that they can be pruned with the height
+	if len(beginKeys)+len(endKeys) > 0 {
+		if err := idx.setEventKeys(batch, height, append(beginKeys, endKeys...)); err != nil {
+			return fmt.Errorf("failed to record event keys: %w", err)
+		}
+	}
+
 	return batch.WriteSync()
 }
 
@@ -95,6 +112,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query conditions: %w", err)
 	}
+	conditions = indexer.NormalizeConditions(conditions, idx.schema)
 
 	// If there is an exact height query, return the result immediately
 	// (if it exists).
@@ -231,8 +249,6 @@ func (idx *BlockerIndexer) matchRange(
 	}
 
 	tmpHeights := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(idx.store, startKey)
 	if err != nil {
@@ -240,7 +256,6 @@ func (idx *BlockerIndexer) matchRange(
 	}
 	defer it.Close()
 
-LOOP:
 	for ; it.Valid(); it.Next() {
 		var (
 			eventValue string
@@ -257,24 +272,8 @@ LOOP:
 			continue
 		}
 
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(eventValue, 10, 64)
-			if err != nil {
-				continue LOOP
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHeights[string(it.Value())] = it.Value()
-			}
+		if qr.MatchValue(eventValue, idx.schema) {
+			tmpHeights[string(it.Value())] = it.Value()
 		}
 
 		select {
@@ -453,8 +452,14 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
-func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
+func (idx *BlockerIndexer) indexEvents(
+	batch dbm.Batch,
+	events []abci.Event,
+	typ string,
+	height int64,
+) ([][]byte, error) {
 	heightBz := int64ToBytes(height)
+	var keys [][]byte
 
 	for _, event := range events {
 		// only index events with a non-empty type
@@ -469,22 +474,119 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 
 			// index iff the event specified index:true and it's not a reserved event
 			compositeKey := fmt.Sprintf("%s.%s", event.Type, attr.Key)
-			if compositeKey == types.BlockHeightKey {
-				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
+			if compositeKey == types.BlockHeightKey || compositeKey == eventKeysCompositeKey {
+				return nil, fmt.Errorf(
+					"event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
 			}
 
 			if attr.GetIndex() {
-				key, err := eventKey(compositeKey, typ, attr.Value, height)
+				value := attr.Value
+				if idx.schema != nil {
+					if t, ok := idx.schema.Type(compositeKey); ok {
+						value = types.NormalizeEventValue(t, value)
+					}
+				}
+				key, err := eventKey(compositeKey, typ, value, height)
 				if err != nil {
-					return fmt.Errorf("failed to create block index key: %w", err)
+					return nil, fmt.Errorf("failed to create block index key: %w", err)
 				}
 
 				if err := batch.Set(key, heightBz); err != nil {
-					return err
+					return nil, err
 				}
+				keys = append(keys, key)
 			}
 		}
 	}
 
-	return nil
+	return keys, nil
+}
+
+func (idx *BlockerIndexer) setEventKeys(batch dbm.Batch, height int64, keys [][]byte) error {
+	key, err := eventKeysKey(height)
+	if err != nil {
+		return err
+	}
+	items := make([]interface{}, len(keys))
+	for i, k := range keys {
+		items[i] = string(k)
+	}
+	value, err := orderedcode.Append(nil, items...)
+	if err != nil {
+		return err
+	}
+	return batch.Set(key, value)
+}
+
+// Heights returns up to limit indexed heights below the given height, in
+// ascending order.
+func (idx *BlockerIndexer) Heights(below int64, limit int) ([]int64, error) {
+	start, err := heightKey(1)
+	if err != nil {
+		return nil, err
+	}
+	end, err := heightKey(below)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := idx.store.Iterator(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create height iterator: %w", err)
+	}
+	defer it.Close()
+
+	var heights []int64
+	for ; it.Valid() && len(heights) < limit; it.Next() {
+		var (
+			compositeKey string
+			height       int64
+		)
+		if _, err := orderedcode.Parse(string(it.Key()), &compositeKey, &height); err != nil {
+			return nil, fmt.Errorf("failed to parse height key: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	return heights, it.Error()
+}
+
+// PruneHeight deletes the given height and the keys of its events. The keys
+// of the events of heights indexed by earlier versions, which didn't record
+// them, are kept; since their height is deleted, they're no longer matched
+// by searches.
+func (idx *BlockerIndexer) PruneHeight(height int64) error {
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+
+	ekKey, err := eventKeysKey(height)
+	if err != nil {
+		return err
+	}
+	bz, err := idx.store.Get(ekKey)
+	if err != nil {
+		return err
+	}
+	for remaining := string(bz); len(remaining) > 0; {
+		var key string
+		remaining, err = orderedcode.Parse(remaining, &key)
+		if err != nil {
+			return fmt.Errorf("failed to parse event keys of height %d: %w", height, err)
+		}
+		if err := batch.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Delete(ekKey); err != nil {
+		return err
+	}
+
+	key, err := heightKey(height)
+	if err != nil {
+		return err
+	}
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+
+	return batch.Write()
 }
@@ -139,3 +139,68 @@ func TestBlockIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerWithEventSchema(t *testing.T) {
+	schema := types.NewEventSchema()
+	require.NoError(t, schema.Set([]abci.EventAttributeSchema{
+		{EventType: "end_event", Key: "ratio", Type: abci.EventAttributeType_FLOAT},
+	}))
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+	indexer.SetEventSchema(schema)
+
+	for i, ratio := range []string{"1.25", "1.50", "2.0", "2.75"} {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: int64(i + 1)},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					{
+						Type: "end_event",
+						Attributes: []abci.EventAttribute{
+							{
+								Key:   "ratio",
+								Value: ratio,
+								Index: true,
+							},
+						},
+					},
+				},
+			},
+		}))
+	}
+
+	testCases := map[string]struct {
+		q       *query.Query
+		results []int64
+	}{
+		"end_event.ratio = 1.5": {
+			q:       query.MustParse("end_event.ratio = 1.5"),
+			results: []int64{2},
+		},
+		"end_event.ratio = 2": {
+			q:       query.MustParse("end_event.ratio = 2"),
+			results: []int64{3},
+		},
+		"end_event.ratio > 1.5": {
+			q:       query.MustParse("end_event.ratio > 1.5"),
+			results: []int64{3, 4},
+		},
+		"end_event.ratio >= 1.3 AND end_event.ratio < 2.75": {
+			q:       query.MustParse("end_event.ratio >= 1.3 AND end_event.ratio < 2.75"),
+			results: []int64{2, 3},
+		},
+		"end_event.ratio <= 2": {
+			q:       query.MustParse("end_event.ratio <= 2"),
+			results: []int64{1, 2, 3},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), tc.q)
+			require.NoError(t, err)
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
@@ -39,6 +39,18 @@ func heightKey(height int64) ([]byte, error) {
 	)
 }
 
+// eventKeysCompositeKey is the reserved composite key under which the keys of
+// the events of each height are recorded.
+const eventKeysCompositeKey = "block.event_keys"
+
+func eventKeysKey(height int64) ([]byte, error) {
+	return orderedcode.Append(
+		nil,
+		eventKeysCompositeKey,
+		height,
+	)
+}
+
 func eventKey(compositeKey, typ, eventValue string, height int64) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
@@ -11,9 +11,11 @@ import (
 type EventSinkType string
 
 const (
-	NULL EventSinkType = "null"
-	KV   EventSinkType = "kv"
-	PSQL EventSinkType = "psql"
+	NULL   EventSinkType = "null"
+	KV     EventSinkType = "kv"
+	PSQL   EventSinkType = "psql"
+	STREAM  EventSinkType = "stream"
+	PARQUET EventSinkType = "parquet"
 )
 
 // EventSink interface is defined the APIs for the IndexerService to interact with the data store,
@@ -46,6 +48,11 @@ type EventSink interface {
 	// supported by the kvEventSink.
 	HasBlock(int64) (bool, error)
 
+	// Prune deletes the indexed events of the heights below retainHeight. This
+	// function is only supported by the kvEventSink and the psqlEventSink, the
+	// other sinks have nothing to prune.
+	Prune(retainHeight int64) error
+
 	// Type checks the eventsink structure type.
 	Type() EventSinkType
 
@@ -20,13 +20,29 @@ type Service struct {
 
 	eventSinks []EventSink
 	eventBus   *types.EventBus
+
+	retainHeight func(height int64) int64
+	pruneCh      chan int64 // latest retain height to prune to
+}
+
+// ServiceOption sets an optional parameter on the Service.
+type ServiceOption func(*Service)
+
+// WithRetainHeight prunes the events indexed below the height returned by fn,
+// which is called with the height of each indexed block. fn returns 0 to
+// retain all heights.
+func WithRetainHeight(fn func(height int64) int64) ServiceOption {
+	return func(is *Service) { is.retainHeight = fn }
 }
 
 // NewIndexerService returns a new service instance.
-func NewIndexerService(es []EventSink, eventBus *types.EventBus) *Service {
+func NewIndexerService(es []EventSink, eventBus *types.EventBus, options ...ServiceOption) *Service {
 
-	is := &Service{eventSinks: es, eventBus: eventBus}
+	is := &Service{eventSinks: es, eventBus: eventBus, pruneCh: make(chan int64, 1)}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
+	for _, option := range options {
+		option(is)
+	}
 	return is
 }
 
@@ -49,6 +65,10 @@ func (is *Service) OnStart() error {
 		return err
 	}
 
+	if is.retainHeight != nil {
+		go is.pruneRoutine()
+	}
+
 	go func() {
 		for {
 			msg := <-blockHeadersSub.Out()
@@ -91,11 +111,54 @@ func (is *Service) OnStart() error {
 					}
 				}
 			}
+
+			if is.retainHeight != nil {
+				is.schedulePrune(is.retainHeight(height))
+			}
 		}
 	}()
 	return nil
 }
 
+// schedulePrune schedules pruning to the given retain height, replacing any
+// retain height not pruned to yet.
+func (is *Service) schedulePrune(retainHeight int64) {
+	if retainHeight <= 0 {
+		return
+	}
+	select {
+	case <-is.pruneCh:
+	default:
+	}
+	is.pruneCh <- retainHeight
+}
+
+// pruneRoutine prunes the event sinks to the scheduled retain heights, apart
+// from indexing so that a long pruning doesn't hold it up.
+func (is *Service) pruneRoutine() {
+	var prunedTo int64
+	for {
+		select {
+		case retainHeight := <-is.pruneCh:
+			if retainHeight <= prunedTo {
+				continue
+			}
+			for _, sink := range is.eventSinks {
+				if err := sink.Prune(retainHeight); err != nil {
+					is.Logger.Error("failed to prune indexed events", "retain_height", retainHeight,
+						"sink", sink.Type(), "err", err)
+					continue
+				}
+				is.Logger.Debug("pruned indexed events", "retain_height", retainHeight, "sink", sink.Type())
+			}
+			prunedTo = retainHeight
+
+		case <-is.Quit():
+			return
+		}
+	}
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions and
 // close the eventsink.
 func (is *Service) OnStop() {
@@ -124,7 +187,7 @@ func KVSinkEnabled(sinks []EventSink) bool {
 // IndexingEnabled returns the given eventSinks is supporting the indexing services.
 func IndexingEnabled(sinks []EventSink) bool {
 	for _, sink := range sinks {
-		if sink.Type() == KV || sink.Type() == PSQL {
+		if sink.Type() == KV || sink.Type() == PSQL || sink.Type() == STREAM {
 			return true
 		}
 	}
@@ -110,6 +110,56 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.Nil(t, teardown(t, pool))
 }
 
+func TestIndexerServicePrunes(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(tmlog.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	sink := kv.NewEventSink(db.NewMemDB())
+	// retain the latest 2 heights
+	service := indexer.NewIndexerService([]indexer.EventSink{sink}, eventBus,
+		indexer.WithRetainHeight(func(height int64) int64 { return height - 1 }))
+	service.SetLogger(tmlog.TestingLogger())
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	for h := int64(1); h <= 4; h++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			NumTxs: 1,
+		}))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: h,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", h)),
+		}}))
+	}
+
+	require.Eventually(t, func() bool {
+		ok, err := sink.HasBlock(2)
+		require.NoError(t, err)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	for h := int64(1); h <= 4; h++ {
+		ok, err := sink.HasBlock(h)
+		require.NoError(t, err)
+		assert.Equal(t, h >= 3, ok, h)
+
+		res, err := sink.GetTxByHash(types.Tx(fmt.Sprintf("tx%d", h)).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, h >= 3, res != nil, h)
+	}
+}
+
 func readSchema() ([]*schema.Migration, error) {
 	filename := "./sink/psql/schema.sql"
 	contents, err := ioutil.ReadFile(filename)
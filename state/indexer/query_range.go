@@ -1,9 +1,12 @@
 package indexer
 
 import (
+	"strconv"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
 )
 
 // QueryRanges defines a mapping between a composite event key and a QueryRange.
@@ -13,8 +16,8 @@ type QueryRanges map[string]QueryRange
 
 // QueryRange defines a range within a query condition.
 type QueryRange struct {
-	LowerBound        interface{} // int || time.Time
-	UpperBound        interface{} // int || time.Time
+	LowerBound        interface{} // int || float || time.Time
+	UpperBound        interface{} // int || float || time.Time
 	Key               string
 	IncludeLowerBound bool
 	IncludeUpperBound bool
@@ -75,6 +78,70 @@ func (qr QueryRange) UpperBoundValue() interface{} {
 	}
 }
 
+// MatchValue returns whether an event attribute value is within the range.
+// Values are compared as integers, or as floats if either bound is a float or
+// the attribute is declared as FLOAT in the schema, which may be nil. Values
+// of attributes declared with a non-numeric type never match, and neither do
+// values compared to time bounds.
+func (qr QueryRange) MatchValue(value string, schema *types.EventSchema) bool {
+	var asFloat bool
+	if schema != nil {
+		if t, ok := schema.Type(qr.Key); ok {
+			switch t {
+			case abci.EventAttributeType_INT:
+			case abci.EventAttributeType_FLOAT:
+				asFloat = true
+			default:
+				return false
+			}
+		}
+	}
+	for _, bound := range []interface{}{qr.LowerBound, qr.UpperBound} {
+		switch bound.(type) {
+		case nil, int64:
+		case float64:
+			asFloat = true
+		default:
+			return false
+		}
+	}
+
+	if !asFloat {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		lowerBound, upperBound := qr.LowerBoundValue(), qr.UpperBoundValue()
+		return (lowerBound == nil || v >= lowerBound.(int64)) &&
+			(upperBound == nil || v <= upperBound.(int64))
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	if qr.LowerBound != nil {
+		lowerBound := toFloat64(qr.LowerBound)
+		if v < lowerBound || (v == lowerBound && !qr.IncludeLowerBound) {
+			return false
+		}
+	}
+	if qr.UpperBound != nil {
+		upperBound := toFloat64(qr.UpperBound)
+		if v > upperBound || (v == upperBound && !qr.IncludeUpperBound) {
+			return false
+		}
+	}
+	return true
+}
+
+func toFloat64(bound interface{}) float64 {
+	if i, ok := bound.(int64); ok {
+		return float64(i)
+	}
+	return bound.(float64)
+}
+
 // LookForRanges returns a mapping of QueryRanges and the matching indexes in
 // the provided query conditions.
 func LookForRanges(conditions []query.Condition) (ranges QueryRanges, indexes []int) {
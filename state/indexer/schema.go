@@ -0,0 +1,31 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
+)
+
+// NormalizeConditions returns the conditions with the operands of equality
+// conditions on attributes declared in the schema, which may be nil, in the
+// canonical form of their type, so that they match the values indexed in the
+// form returned by types.NormalizeEventValue, e.g. "amount = 1.50" matches an
+// amount of "1.5".
+func NormalizeConditions(conditions []query.Condition, schema *types.EventSchema) []query.Condition {
+	if schema == nil {
+		return conditions
+	}
+
+	normalized := make([]query.Condition, len(conditions))
+	for i, c := range conditions {
+		normalized[i] = c
+		if c.Op != query.OpEqual {
+			continue
+		}
+		if t, ok := schema.Type(c.CompositeKey); ok {
+			normalized[i].Operand = types.NormalizeEventValue(t, fmt.Sprintf("%v", c.Operand))
+		}
+	}
+	return normalized
+}
@@ -2,6 +2,7 @@ package kv
 
 import (
 	"context"
+	"fmt"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
@@ -14,6 +15,9 @@ import (
 
 var _ indexer.EventSink = (*EventSink)(nil)
 
+// pruneBatchSize is the number of heights Prune looks up at a time.
+const pruneBatchSize = 100
+
 // The EventSink is an aggregator for redirecting the call path of the tx/block kvIndexer.
 // For the implementation details please see the kv.go in the indexer/block and indexer/tx folder.
 type EventSink struct {
@@ -28,6 +32,13 @@ func NewEventSink(store dbm.DB) indexer.EventSink {
 	}
 }
 
+// SetEventSchema sets the schema of the event attributes on the tx and block
+// indexers.
+func (kves *EventSink) SetEventSchema(schema *types.EventSchema) {
+	kves.txi.SetEventSchema(schema)
+	kves.bi.SetEventSchema(schema)
+}
+
 func (kves *EventSink) Type() indexer.EventSinkType {
 	return indexer.KV
 }
@@ -56,6 +67,30 @@ func (kves *EventSink) HasBlock(h int64) (bool, error) {
 	return kves.bi.Has(h)
 }
 
+// Prune deletes the txs and blocks indexed below retainHeight, a batch of
+// heights at a time. The txs of a height are deleted before the height itself,
+// so that pruning resumes where it stopped on failure.
+func (kves *EventSink) Prune(retainHeight int64) error {
+	for {
+		heights, err := kves.bi.Heights(retainHeight, pruneBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(heights) == 0 {
+			return nil
+		}
+
+		for _, h := range heights {
+			if err := kves.txi.PruneHeight(h); err != nil {
+				return fmt.Errorf("failed to prune txs of height %d: %w", h, err)
+			}
+			if err := kves.bi.PruneHeight(h); err != nil {
+				return fmt.Errorf("failed to prune block of height %d: %w", h, err)
+			}
+		}
+	}
+}
+
 func (kves *EventSink) Stop() error {
 	return nil
 }
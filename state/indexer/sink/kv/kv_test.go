@@ -335,6 +335,79 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestPrune(t *testing.T) {
+	indexHeight := func(sink indexer.EventSink, height int64) {
+		require.NoError(t, sink.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{{
+					Type:       "end_event",
+					Attributes: []abci.EventAttribute{{Key: "foo", Value: fmt.Sprint(height), Index: true}},
+				}},
+			},
+		}))
+
+		txr := txResultWithEvents([]abci.Event{{
+			Type:       "account",
+			Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}},
+		}})
+		txr.Height = height
+		txr.Tx = types.Tx(fmt.Sprintf("tx%d", height))
+		require.NoError(t, sink.IndexTxEvents([]*abci.TxResult{txr}))
+	}
+
+	store := db.NewMemDB()
+	sink := NewEventSink(store)
+	for h := int64(1); h <= 5; h++ {
+		indexHeight(sink, h)
+	}
+
+	require.NoError(t, sink.Prune(4))
+
+	for h := int64(1); h <= 5; h++ {
+		ok, err := sink.HasBlock(h)
+		require.NoError(t, err)
+		assert.Equal(t, h >= 4, ok, h)
+
+		txr, err := sink.GetTxByHash(types.Tx(fmt.Sprintf("tx%d", h)).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, h >= 4, txr != nil, h)
+	}
+
+	txs, err := sink.SearchTxEvents(context.Background(), query.MustParse("account.owner = 'Ivan'"))
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	heights, err := sink.SearchBlockEvents(context.Background(), query.MustParse("end_event.foo EXISTS"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 5}, heights)
+
+	// nothing of the pruned heights is left
+	expected := db.NewMemDB()
+	expectedSink := NewEventSink(expected)
+	for h := int64(4); h <= 5; h++ {
+		indexHeight(expectedSink, h)
+	}
+	assert.Equal(t, dumpDB(t, expected), dumpDB(t, store))
+
+	// pruning again is a no-op
+	require.NoError(t, sink.Prune(4))
+	assert.Equal(t, dumpDB(t, expected), dumpDB(t, store))
+}
+
+func dumpDB(t *testing.T, store db.DB) map[string]string {
+	it, err := store.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+
+	kvs := make(map[string]string)
+	for ; it.Valid(); it.Next() {
+		kvs[string(it.Key())] = string(it.Value())
+	}
+	require.NoError(t, it.Error())
+	return kvs
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
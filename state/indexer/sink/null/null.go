@@ -46,6 +46,10 @@ func (nes *EventSink) HasBlock(h int64) (bool, error) {
 	return false, nil
 }
 
+func (nes *EventSink) Prune(retainHeight int64) error {
+	return nil
+}
+
 func (nes *EventSink) Stop() error {
 	return nil
 }
@@ -0,0 +1,306 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// This file implements the subset of the Parquet file format the sink needs:
+// flat schemas of required INT64 and BYTE_ARRAY columns, stored uncompressed
+// with the PLAIN encoding, in a single row group of one data page per column.
+// See https://github.com/apache/parquet-format.
+//
+// The encoding is only tested against the decoder in file_test.go, not against
+// the readers of a Parquet implementation. It should give way to a maintained
+// Parquet library once the module can depend on one.
+
+const magic = "PAR1"
+
+// Physical types.
+const (
+	typeInt64     int32 = 2
+	typeByteArray int32 = 6
+)
+
+// Converted types, i.e. logical types of the physical types.
+const (
+	convertedNone            int32 = -1
+	convertedUTF8            int32 = 0
+	convertedTimestampMicros int32 = 10
+)
+
+const (
+	repetitionRequired int32 = 0
+	encodingPlain      int32 = 0
+	encodingRLE        int32 = 3
+	codecUncompressed  int32 = 0
+	pageTypeData       int32 = 0
+)
+
+// columnSchema describes a column of a table.
+type columnSchema struct {
+	name      string
+	typ       int32
+	converted int32
+}
+
+func int64Column(name string) columnSchema {
+	return columnSchema{name: name, typ: typeInt64, converted: convertedNone}
+}
+
+func stringColumn(name string) columnSchema {
+	return columnSchema{name: name, typ: typeByteArray, converted: convertedUTF8}
+}
+
+func timestampColumn(name string) columnSchema {
+	return columnSchema{name: name, typ: typeInt64, converted: convertedTimestampMicros}
+}
+
+// table buffers the rows of a Parquet file, encoded column by column.
+type table struct {
+	schema  []columnSchema
+	columns []bytes.Buffer
+	numRows int64
+}
+
+func newTable(schema ...columnSchema) *table {
+	return &table{
+		schema:  schema,
+		columns: make([]bytes.Buffer, len(schema)),
+	}
+}
+
+// appendRow appends a row of int64 values for the INT64 columns, and string
+// values for the BYTE_ARRAY columns. It panics if the values don't match the
+// schema.
+func (t *table) appendRow(values ...interface{}) {
+	if len(values) != len(t.schema) {
+		panic(fmt.Sprintf("expected %d values, got %d", len(t.schema), len(values)))
+	}
+	var b [8]byte
+	for i, v := range values {
+		switch t.schema[i].typ {
+		case typeInt64:
+			binary.LittleEndian.PutUint64(b[:], uint64(v.(int64)))
+			t.columns[i].Write(b[:8])
+		case typeByteArray:
+			s := v.(string)
+			binary.LittleEndian.PutUint32(b[:], uint32(len(s)))
+			t.columns[i].Write(b[:4])
+			t.columns[i].WriteString(s)
+		}
+	}
+	t.numRows++
+}
+
+// reset removes all rows.
+func (t *table) reset() {
+	for i := range t.columns {
+		t.columns[i].Reset()
+	}
+	t.numRows = 0
+}
+
+// writeTo writes the rows as a Parquet file.
+func (t *table) writeTo(w io.Writer) error {
+	var (
+		file   bytes.Buffer
+		chunks []columnChunk
+	)
+	file.WriteString(magic)
+	for i, col := range t.schema {
+		data := t.columns[i].Bytes()
+		header := encodePageHeader(int32(len(data)), int32(t.numRows))
+		chunks = append(chunks, columnChunk{
+			schema: col,
+			offset: int64(file.Len()),
+			size:   int64(len(header) + len(data)),
+		})
+		file.Write(header)
+		file.Write(data)
+	}
+
+	footer := t.encodeFileMetaData(chunks)
+	file.Write(footer)
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(footer)))
+	file.Write(size[:])
+	file.WriteString(magic)
+
+	_, err := file.WriteTo(w)
+	return err
+}
+
+// columnChunk locates the data of a column in the file.
+type columnChunk struct {
+	schema columnSchema
+	offset int64
+	size   int64
+}
+
+func encodePageHeader(size, numValues int32) []byte {
+	w := newThriftWriter()
+	w.i32(1, pageTypeData)
+	w.i32(2, size)
+	w.i32(3, size)
+	w.beginStruct(5) // data_page_header
+	w.i32(1, numValues)
+	w.i32(2, encodingPlain)
+	w.i32(3, encodingRLE)
+	w.i32(4, encodingRLE)
+	w.endStruct()
+	return w.end()
+}
+
+func (t *table) encodeFileMetaData(chunks []columnChunk) []byte {
+	w := newThriftWriter()
+	w.i32(1, 1) // version
+	w.beginList(2, len(t.schema)+1, thriftStruct)
+	w.beginElement()
+	w.binary(4, "schema")
+	w.i32(5, int32(len(t.schema)))
+	w.endStruct()
+	for _, col := range t.schema {
+		w.beginElement()
+		w.i32(1, col.typ)
+		w.i32(3, repetitionRequired)
+		w.binary(4, col.name)
+		if col.converted != convertedNone {
+			w.i32(6, col.converted)
+		}
+		w.endStruct()
+	}
+	w.i64(3, t.numRows)
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+	w.beginList(4, 1, thriftStruct) // row_groups
+	w.beginElement()
+	w.beginList(1, len(chunks), thriftStruct)
+	for _, chunk := range chunks {
+		w.beginElement()
+		w.i64(2, chunk.offset)
+		w.beginStruct(3) // meta_data
+		w.i32(1, chunk.schema.typ)
+		w.beginList(2, 1, thriftI32)
+		w.listI32(encodingPlain)
+		w.beginList(3, 1, thriftBinary)
+		w.listBinary(chunk.schema.name)
+		w.i32(4, codecUncompressed)
+		w.i64(5, t.numRows)
+		w.i64(6, chunk.size)
+		w.i64(7, chunk.size)
+		w.i64(9, chunk.offset)
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64(2, totalSize)
+	w.i64(3, t.numRows)
+	w.endStruct()
+
+	w.binary(6, "tendermint")
+	return w.end()
+}
+
+// Types of the Thrift compact protocol.
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter encodes a struct with the Thrift compact protocol, which the
+// Parquet metadata is encoded with.
+type thriftWriter struct {
+	buf     []byte
+	scratch [binary.MaxVarintLen64]byte
+	// the last field ID written in each struct being written
+	lastIDs []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastIDs: []int16{0}}
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// varint appends a zigzag encoded varint.
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1 ^ v>>63))
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	n := binary.PutUvarint(w.scratch[:], v)
+	w.buf = append(w.buf, w.scratch[:n]...)
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(id int16, s string) {
+	w.fieldHeader(id, thriftBinary)
+	w.listBinary(s)
+}
+
+func (w *thriftWriter) beginStruct(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.beginElement()
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+// beginList writes the header of a list field, whose size elements follow.
+func (w *thriftWriter) beginList(id int16, size int, elemType byte) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.uvarint(uint64(size))
+	}
+}
+
+// beginElement begins a struct element of a list, ended by endStruct.
+func (w *thriftWriter) beginElement() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) listI32(v int32) {
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) listBinary(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// end ends the struct and returns its encoding.
+func (w *thriftWriter) end() []byte {
+	w.endStruct()
+	return w.buf
+}
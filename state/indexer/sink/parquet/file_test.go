@@ -0,0 +1,137 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes the Thrift compact protocol into maps of field IDs to
+// int64, string, []interface{} and map[int16]interface{} values.
+type thriftReader struct {
+	b []byte
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		panic("invalid varint")
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := r.uvarint()
+		s := string(r.b[:n])
+		r.b = r.b[n:]
+		return s
+	case thriftList:
+		header := r.b[0]
+		r.b = r.b[1:]
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		fields := make(map[int16]interface{})
+		var id int16
+		for {
+			header := r.b[0]
+			r.b = r.b[1:]
+			if header == 0 {
+				return fields
+			}
+			if delta := int16(header >> 4); delta != 0 {
+				id += delta
+			} else {
+				id = int16(r.varint())
+			}
+			fields[id] = r.value(header & 0x0f)
+		}
+	default:
+		panic(fmt.Sprintf("unexpected type %d", typ))
+	}
+}
+
+// readTable reads the rows of a Parquet file written by table.writeTo, and
+// returns its metadata.
+func readTable(t *testing.T, file []byte) (map[int16]interface{}, [][]interface{}) {
+	require.Equal(t, magic, string(file[:4]))
+	require.Equal(t, magic, string(file[len(file)-4:]))
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := &thriftReader{file[len(file)-8-size : len(file)-8]}
+	meta := footer.value(thriftStruct).(map[int16]interface{})
+	require.Empty(t, footer.b)
+
+	schema := meta[2].([]interface{})[1:]
+	numRows := int(meta[3].(int64))
+	rows := make([][]interface{}, numRows)
+	chunks := meta[4].([]interface{})[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, len(schema))
+	for i, chunk := range chunks {
+		colMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		page := &thriftReader{file[colMeta[9].(int64):]}
+		header := page.value(thriftStruct).(map[int16]interface{})
+		require.EqualValues(t, numRows, header[5].(map[int16]interface{})[1])
+
+		data := page.b[:header[2].(int64)]
+		for j := range rows {
+			switch schema[i].(map[int16]interface{})[1].(int64) {
+			case int64(typeInt64):
+				rows[j] = append(rows[j], int64(binary.LittleEndian.Uint64(data)))
+				data = data[8:]
+			case int64(typeByteArray):
+				n := binary.LittleEndian.Uint32(data)
+				rows[j] = append(rows[j], string(data[4:4+n]))
+				data = data[4+n:]
+			}
+		}
+		require.Empty(t, data)
+	}
+	return meta, rows
+}
+
+func TestTableWriteTo(t *testing.T) {
+	tbl := newTable(stringColumn("name"), int64Column("value"), timestampColumn("time"))
+	var expected [][]interface{}
+	for i := int64(0); i < 20; i++ {
+		row := []interface{}{fmt.Sprintf("row %d", i), i * -1000, i}
+		tbl.appendRow(row...)
+		expected = append(expected, row)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.writeTo(&buf))
+	meta, rows := readTable(t, buf.Bytes())
+	assert.Equal(t, expected, rows)
+
+	schema := meta[2].([]interface{})
+	require.Len(t, schema, 4)
+	assert.EqualValues(t, 3, schema[0].(map[int16]interface{})[5])
+	assert.Equal(t, "time", schema[3].(map[int16]interface{})[4])
+	assert.EqualValues(t, convertedTimestampMicros, schema[3].(map[int16]interface{})[6])
+	assert.NotContains(t, schema[2].(map[int16]interface{}), int16(6))
+
+	tbl.reset()
+	assert.Zero(t, tbl.numRows)
+	assert.Panics(t, func() { tbl.appendRow("too few values") })
+}
@@ -0,0 +1,252 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ indexer.EventSink = (*EventSink)(nil)
+
+// Values of the source column of the events table.
+const (
+	SourceBeginBlock = "begin_block"
+	SourceEndBlock   = "end_block"
+	SourceTx         = "tx"
+)
+
+// EventSink writes the blocks, tx results and events it indexes to Parquet
+// files, so that they can be loaded into a data warehouse without running an
+// indexer service. It writes three tables, each to its own files:
+//
+//   - blocks: a row per block, with the fields of its header
+//   - txs: a row per tx result, with its code and gas
+//   - events: a row per attribute of the events of the blocks and txs
+//
+// Rows are buffered in memory, and written to a new set of files, named after
+// the table and the first and last height of the rows, every rotateBlocks
+// blocks and when the sink is stopped. A file is only renamed to its final
+// name once it is complete. Tables without rows since the last rotation are
+// not written. The rows buffered when the node crashes are lost.
+type EventSink struct {
+	dir          string
+	chainID      string
+	rotateBlocks int64
+
+	mtx         tmsync.Mutex
+	blocks      *table
+	txs         *table
+	events      *table
+	firstHeight int64
+	lastHeight  int64
+	numBlocks   int64
+}
+
+// NewEventSink returns a new Parquet EventSink writing to the given directory,
+// which is created if needed.
+func NewEventSink(dir, chainID string, rotateBlocks int64) (*EventSink, error) {
+	if rotateBlocks <= 0 {
+		return nil, errors.New("the number of blocks per file must be positive")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating parquet directory: %w", err)
+	}
+	return &EventSink{
+		dir:          dir,
+		chainID:      chainID,
+		rotateBlocks: rotateBlocks,
+		blocks: newTable(
+			stringColumn("chain_id"),
+			int64Column("height"),
+			timestampColumn("time"),
+			stringColumn("hash"),
+			stringColumn("proposer_address"),
+			stringColumn("app_hash"),
+			int64Column("num_txs"),
+		),
+		txs: newTable(
+			stringColumn("chain_id"),
+			int64Column("height"),
+			int64Column("index"),
+			stringColumn("hash"),
+			int64Column("code"),
+			stringColumn("codespace"),
+			int64Column("gas_wanted"),
+			int64Column("gas_used"),
+		),
+		events: newTable(
+			stringColumn("chain_id"),
+			int64Column("height"),
+			stringColumn("source"),
+			int64Column("tx_index"),
+			stringColumn("type"),
+			stringColumn("key"),
+			stringColumn("value"),
+		),
+	}, nil
+}
+
+func (es *EventSink) Type() indexer.EventSinkType {
+	return indexer.PARQUET
+}
+
+// IndexBlockEvents buffers the rows of the block and its events, after
+// writing the buffered rows to files if they span rotateBlocks blocks.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	if es.numBlocks >= es.rotateBlocks {
+		if err := es.rotate(); err != nil {
+			return err
+		}
+	}
+
+	height := h.Header.Height
+	es.blocks.appendRow(
+		es.chainID,
+		height,
+		h.Header.Time.UnixNano()/1e3,
+		h.Header.Hash().String(),
+		h.Header.ProposerAddress.String(),
+		h.Header.AppHash.String(),
+		h.NumTxs,
+	)
+	es.appendEvents(height, SourceBeginBlock, -1, h.ResultBeginBlock.Events)
+	es.appendEvents(height, SourceEndBlock, -1, h.ResultEndBlock.Events)
+
+	if es.numBlocks == 0 {
+		es.firstHeight = height
+	}
+	es.lastHeight = height
+	es.numBlocks++
+	return nil
+}
+
+// IndexTxEvents buffers the rows of the tx results and their events.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	for _, txr := range txrs {
+		es.txs.appendRow(
+			es.chainID,
+			txr.Height,
+			int64(txr.Index),
+			fmt.Sprintf("%X", types.Tx(txr.Tx).Hash()),
+			int64(txr.Result.Code),
+			txr.Result.Codespace,
+			txr.Result.GasWanted,
+			txr.Result.GasUsed,
+		)
+		es.appendEvents(txr.Height, SourceTx, int64(txr.Index), txr.Result.Events)
+	}
+	return nil
+}
+
+func (es *EventSink) appendEvents(height int64, source string, txIndex int64, events []abci.Event) {
+	for _, event := range events {
+		for _, attr := range event.Attributes {
+			es.events.appendRow(es.chainID, height, source, txIndex, event.Type, attr.Key, attr.Value)
+		}
+	}
+}
+
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	return nil, errors.New("block search is not supported via the parquet event sink")
+}
+
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return nil, errors.New("tx search is not supported via the parquet event sink")
+}
+
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	return nil, errors.New("getTxByHash is not supported via the parquet event sink")
+}
+
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	return false, errors.New("hasBlock is not supported via the parquet event sink")
+}
+
+// Prune does nothing, since the written files are left to the warehouse to
+// load and remove.
+func (es *EventSink) Prune(retainHeight int64) error {
+	return nil
+}
+
+// Stop writes the buffered rows to files.
+func (es *EventSink) Stop() error {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+	return es.rotate()
+}
+
+// rotate writes the buffered rows to a new set of files. The rows are kept
+// buffered if it fails, to be written again on the next rotation.
+func (es *EventSink) rotate() error {
+	if es.numBlocks == 0 {
+		return nil
+	}
+	tables := []struct {
+		name  string
+		table *table
+	}{
+		{"blocks", es.blocks},
+		{"txs", es.txs},
+		{"events", es.events},
+	}
+	// write all files before renaming any, so that a failure leaves none
+	renames := make(map[string]string)
+	defer func() {
+		for tmp := range renames {
+			os.Remove(tmp)
+		}
+	}()
+	for _, t := range tables {
+		if t.table.numRows == 0 {
+			continue
+		}
+		path := filepath.Join(es.dir, fmt.Sprintf("%s-%012d-%012d.parquet", t.name, es.firstHeight, es.lastHeight))
+		if err := writeFile(path+".tmp", t.table); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		renames[path+".tmp"] = path
+	}
+	for tmp, path := range renames {
+		if err := os.Rename(tmp, path); err != nil {
+			return err
+		}
+		delete(renames, tmp)
+	}
+
+	for _, t := range tables {
+		t.table.reset()
+	}
+	es.numBlocks = 0
+	return nil
+}
+
+// writeFile writes the table to a file, and syncs it.
+func writeFile(path string, t *table) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := t.writeTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
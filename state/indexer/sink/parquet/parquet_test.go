@@ -0,0 +1,112 @@
+package parquet
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newBlockHeader(height int64, numTxs int64) types.EventDataNewBlockHeader {
+	return types.EventDataNewBlockHeader{
+		Header: types.Header{
+			Height: height,
+			Time:   time.Unix(height, 0),
+		},
+		NumTxs: numTxs,
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{{
+				Type:       "end",
+				Attributes: []abci.EventAttribute{{Key: "height", Value: "h"}},
+			}},
+		},
+	}
+}
+
+func newTxResult(height int64, index uint32) *abci.TxResult {
+	return &abci.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx("tx"),
+		Result: abci.ResponseDeliverTx{
+			Code:      1,
+			GasWanted: 10,
+			GasUsed:   7,
+			Events: []abci.Event{{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: "sender", Value: "alice"},
+					{Key: "recipient", Value: "bob"},
+				},
+			}},
+		},
+	}
+}
+
+func readFile(t *testing.T, path string) [][]interface{} {
+	file, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	_, rows := readTable(t, file)
+	return rows
+}
+
+func TestEventSink(t *testing.T) {
+	dir := t.TempDir()
+	es, err := NewEventSink(dir, "test-chain", 2)
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, es.IndexBlockEvents(newBlockHeader(height, 1)))
+		require.NoError(t, es.IndexTxEvents([]*abci.TxResult{newTxResult(height, 0)}))
+	}
+
+	// heights 1 and 2 are written once height 3 is indexed
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "blocks-000000000001-000000000002.parquet"),
+		filepath.Join(dir, "events-000000000001-000000000002.parquet"),
+		filepath.Join(dir, "txs-000000000001-000000000002.parquet"),
+	}, files)
+
+	blocks := readFile(t, files[0])
+	require.Len(t, blocks, 2)
+	assert.Equal(t, []interface{}{"test-chain", int64(2), int64(2_000_000)}, blocks[1][:3])
+	assert.Equal(t, int64(1), blocks[1][6])
+
+	txs := readFile(t, files[2])
+	require.Len(t, txs, 2)
+	assert.Equal(t, []interface{}{"test-chain", int64(1), int64(0)}, txs[0][:3])
+	assert.Equal(t, []interface{}{int64(1), "", int64(10), int64(7)}, txs[0][4:])
+
+	events := readFile(t, files[1])
+	assert.Equal(t, [][]interface{}{
+		{"test-chain", int64(1), SourceEndBlock, int64(-1), "end", "height", "h"},
+		{"test-chain", int64(1), SourceTx, int64(0), "transfer", "sender", "alice"},
+		{"test-chain", int64(1), SourceTx, int64(0), "transfer", "recipient", "bob"},
+		{"test-chain", int64(2), SourceEndBlock, int64(-1), "end", "height", "h"},
+		{"test-chain", int64(2), SourceTx, int64(0), "transfer", "sender", "alice"},
+		{"test-chain", int64(2), SourceTx, int64(0), "transfer", "recipient", "bob"},
+	}, events)
+
+	// stopping writes height 3, which has no txs file without txs
+	require.NoError(t, es.IndexBlockEvents(newBlockHeader(4, 0)))
+	require.NoError(t, es.Stop())
+	blocks = readFile(t, filepath.Join(dir, "blocks-000000000003-000000000004.parquet"))
+	assert.Len(t, blocks, 2)
+	txs = readFile(t, filepath.Join(dir, "txs-000000000003-000000000004.parquet"))
+	assert.Len(t, txs, 1)
+
+	files, err = filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = NewEventSink(dir, "test-chain", 0)
+	assert.Error(t, err)
+}
@@ -158,6 +158,32 @@ func (es *EventSink) HasBlock(h int64) (bool, error) {
 	return false, errors.New("hasBlock is not supported via the postgres event sink")
 }
 
+// Prune deletes the block and tx events, and the tx results, of the heights
+// below retainHeight. The tx events are deleted along with their tx results.
+func (es *EventSink) Prune(retainHeight int64) error {
+	_, err := sq.Delete(TableResultTx).
+		Where(sq.Expr(
+			"id IN (SELECT tx_result_id FROM "+TableEventTx+" WHERE height < ? AND chain_id = ?)",
+			retainHeight, es.chainID)).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(es.store).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("failed to prune tx results: %w", err)
+	}
+
+	_, err = sq.Delete(TableEventBlock).
+		Where(sq.Lt{"height": retainHeight}).
+		Where(sq.Eq{"chain_id": es.chainID}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(es.store).
+		Exec()
+	if err != nil {
+		return fmt.Errorf("failed to prune block events: %w", err)
+	}
+	return nil
+}
+
 func indexBlockEvents(
 	sqlStmt sq.InsertBuilder,
 	events []abci.Event,
@@ -0,0 +1,263 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ indexer.EventSink = (*EventSink)(nil)
+
+const (
+	// queueSize is the number of messages buffered before indexing blocks.
+	queueSize = 1000
+
+	minRetryDelay = 100 * time.Millisecond
+	maxRetryDelay = 10 * time.Second
+
+	// flushTimeout is how long Stop waits for the queued messages to be
+	// published.
+	flushTimeout = 10 * time.Second
+)
+
+var errStopped = errors.New("stream event sink is stopped")
+
+// Publisher publishes messages to a topic of a message bus. Publish must only
+// return nil once the message bus acknowledged the message.
+//
+// The only message bus supported is NATS, see NATSPublisher.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, msg []byte) error
+	Close() error
+}
+
+// Topics maps the forwarded events to topics of the message bus. Events with an
+// empty topic are not forwarded.
+type Topics struct {
+	Block            string
+	Tx               string
+	ValidatorUpdates string
+}
+
+// BlockMessage is the message published for every new block.
+type BlockMessage struct {
+	ChainID string                        `json:"chain_id"`
+	Block   types.EventDataNewBlockHeader `json:"block"`
+}
+
+// TxMessage is the message published for every tx result.
+type TxMessage struct {
+	ChainID  string        `json:"chain_id"`
+	TxResult abci.TxResult `json:"tx_result"`
+}
+
+// ValidatorUpdatesMessage is the message published for every block which
+// updates the validator set.
+type ValidatorUpdatesMessage struct {
+	ChainID          string                 `json:"chain_id"`
+	Height           int64                  `json:"height"`
+	ValidatorUpdates []abci.ValidatorUpdate `json:"validator_updates"`
+}
+
+type message struct {
+	topic string
+	data  []byte
+}
+
+// EventSink forwards block, tx and validator set update events to a message
+// bus. Messages are published in order, and retried until the message bus
+// acknowledges them, so they are delivered at least once unless the message
+// bus stays unavailable while the node stops. Consumers should deduplicate
+// them by chain ID and height (plus index for txs).
+type EventSink struct {
+	publisher    Publisher
+	topics       Topics
+	chainID      string
+	logger       log.Logger
+	flushTimeout time.Duration
+
+	mtx    sync.RWMutex // guards sends on queue
+	queue  chan message
+	stopCh chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewEventSink returns a new stream EventSink and starts publishing messages
+// in the background.
+func NewEventSink(publisher Publisher, topics Topics, chainID string, logger log.Logger) *EventSink {
+	ctx, cancel := context.WithCancel(context.Background())
+	es := &EventSink{
+		publisher:    publisher,
+		topics:       topics,
+		chainID:      chainID,
+		logger:       logger,
+		flushTimeout: flushTimeout,
+		queue:        make(chan message, queueSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		stopCh:       make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	go es.publishRoutine()
+
+	return es
+}
+
+func (es *EventSink) Type() indexer.EventSinkType {
+	return indexer.STREAM
+}
+
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	if err := es.enqueue(es.topics.Block, BlockMessage{ChainID: es.chainID, Block: h}); err != nil {
+		return err
+	}
+
+	if len(h.ResultEndBlock.ValidatorUpdates) == 0 {
+		return nil
+	}
+
+	return es.enqueue(es.topics.ValidatorUpdates, ValidatorUpdatesMessage{
+		ChainID:          es.chainID,
+		Height:           h.Header.Height,
+		ValidatorUpdates: h.ResultEndBlock.ValidatorUpdates,
+	})
+}
+
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	for _, txr := range txrs {
+		if err := es.enqueue(es.topics.Tx, TxMessage{ChainID: es.chainID, TxResult: *txr}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	return nil, errors.New("block search is not supported via the stream event sink")
+}
+
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	return nil, errors.New("tx search is not supported via the stream event sink")
+}
+
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	return nil, errors.New("getTxByHash is not supported via the stream event sink")
+}
+
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	return false, errors.New("hasBlock is not supported via the stream event sink")
+}
+
+// Prune does nothing, since the published events are not retained by the sink.
+func (es *EventSink) Prune(retainHeight int64) error {
+	return nil
+}
+
+// Stop stops accepting events, waits up to flushTimeout for the queued
+// messages to be published and closes the publisher. Messages which have not
+// been acknowledged by then are dropped and logged.
+func (es *EventSink) Stop() error {
+	// abort the enqueues in progress, then close the queue so that
+	// publishRoutine drains it
+	close(es.stopCh)
+	es.mtx.Lock()
+	close(es.queue)
+	es.mtx.Unlock()
+
+	timer := time.NewTimer(es.flushTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-es.done:
+	case <-timer.C:
+	}
+	// give up on the messages which have not been published by now
+	es.cancel()
+	<-es.done
+
+	return es.publisher.Close()
+}
+
+// enqueue queues msg for publishing, blocking while the queue is full.
+func (es *EventSink) enqueue(topic string, msg interface{}) error {
+	if topic == "" {
+		return nil
+	}
+
+	bz, err := tmjson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	// select picks a random ready case, so check for a stop first
+	select {
+	case <-es.stopCh:
+		return errStopped
+	default:
+	}
+
+	select {
+	case es.queue <- message{topic: topic, data: bz}:
+		return nil
+	case <-es.stopCh:
+		return errStopped
+	}
+}
+
+// publishRoutine publishes the queued messages until the queue is closed and
+// drained, or publishing is canceled.
+func (es *EventSink) publishRoutine() {
+	defer close(es.done)
+
+	for msg := range es.queue {
+		if es.publish(es.ctx, msg) {
+			continue
+		}
+
+		dropped := 1
+		for range es.queue {
+			dropped++
+		}
+		es.logger.Error("stopped before publishing all events", "dropped", dropped)
+		return
+	}
+}
+
+// publish publishes msg, retrying with an exponential backoff until it
+// succeeds. It returns false if publishing was canceled in the meantime.
+func (es *EventSink) publish(ctx context.Context, msg message) bool {
+	delay := minRetryDelay
+	for {
+		err := es.publisher.Publish(ctx, msg.topic, msg.data)
+		if err == nil {
+			return true
+		}
+
+		es.logger.Error("failed to publish event, retrying", "topic", msg.topic, "delay", delay, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
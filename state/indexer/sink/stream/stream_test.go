@@ -0,0 +1,177 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+type published struct {
+	topic string
+	data  []byte
+}
+
+// mockPublisher fails the first failures calls to Publish.
+type mockPublisher struct {
+	mtx      sync.Mutex
+	failures int
+	msgs     []published
+	closed   bool
+}
+
+func (p *mockPublisher) Publish(ctx context.Context, topic string, msg []byte) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.msgs = append(p.msgs, published{topic: topic, data: msg})
+	return nil
+}
+
+func (p *mockPublisher) Close() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.closed = true
+	return nil
+}
+
+func (p *mockPublisher) published() []published {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return append([]published(nil), p.msgs...)
+}
+
+var testTopics = Topics{
+	Block:            "blocks",
+	Tx:               "txs",
+	ValidatorUpdates: "validator_updates",
+}
+
+func TestType(t *testing.T) {
+	es := NewEventSink(&mockPublisher{}, testTopics, "test-chain", log.TestingLogger())
+	defer es.Stop() //nolint:errcheck // ignore for tests
+
+	assert.Equal(t, indexer.STREAM, es.Type())
+}
+
+func TestStop(t *testing.T) {
+	pub := &mockPublisher{}
+	es := NewEventSink(pub, testTopics, "test-chain", log.TestingLogger())
+
+	require.NoError(t, es.Stop())
+	assert.True(t, pub.closed)
+	assert.Error(t, es.IndexTxEvents([]*abci.TxResult{{Height: 1}}))
+}
+
+func TestStop_FlushesQueuedMessages(t *testing.T) {
+	pub := &mockPublisher{failures: 2}
+	es := NewEventSink(pub, testTopics, "test-chain", log.TestingLogger())
+
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{
+		{Height: 1, Index: 0},
+		{Height: 1, Index: 1},
+		{Height: 1, Index: 2},
+	}))
+	require.NoError(t, es.Stop())
+
+	assert.Len(t, pub.published(), 3)
+	assert.True(t, pub.closed)
+}
+
+func TestStop_FlushTimeout(t *testing.T) {
+	pub := &mockPublisher{failures: 1000}
+	es := NewEventSink(pub, testTopics, "test-chain", log.TestingLogger())
+	es.flushTimeout = 50 * time.Millisecond
+
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{{Height: 1, Index: 0}, {Height: 1, Index: 1}}))
+
+	start := time.Now()
+	require.NoError(t, es.Stop())
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	assert.Empty(t, pub.published())
+	assert.True(t, pub.closed)
+}
+
+func TestPublishInOrderWithRetries(t *testing.T) {
+	pub := &mockPublisher{failures: 2}
+	es := NewEventSink(pub, testTopics, "test-chain", log.TestingLogger())
+	defer es.Stop() //nolint:errcheck // ignore for tests
+
+	require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultEndBlock: abci.ResponseEndBlock{
+			ValidatorUpdates: []abci.ValidatorUpdate{{Power: 10}},
+		},
+	}))
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{
+		{Height: 1, Index: 0, Tx: types.Tx("foo")},
+		{Height: 1, Index: 1, Tx: types.Tx("bar")},
+	}))
+
+	require.Eventually(t, func() bool { return len(pub.published()) == 4 }, 5*time.Second, 10*time.Millisecond)
+	msgs := pub.published()
+
+	assert.Equal(t, "blocks", msgs[0].topic)
+	var block BlockMessage
+	require.NoError(t, tmjson.Unmarshal(msgs[0].data, &block))
+	assert.Equal(t, "test-chain", block.ChainID)
+	assert.EqualValues(t, 1, block.Block.Header.Height)
+
+	assert.Equal(t, "validator_updates", msgs[1].topic)
+	var vals ValidatorUpdatesMessage
+	require.NoError(t, tmjson.Unmarshal(msgs[1].data, &vals))
+	assert.EqualValues(t, 1, vals.Height)
+	require.Len(t, vals.ValidatorUpdates, 1)
+	assert.EqualValues(t, 10, vals.ValidatorUpdates[0].Power)
+
+	for i, tx := range []string{"foo", "bar"} {
+		assert.Equal(t, "txs", msgs[2+i].topic)
+		var txm TxMessage
+		require.NoError(t, tmjson.Unmarshal(msgs[2+i].data, &txm))
+		assert.Equal(t, "test-chain", txm.ChainID)
+		assert.EqualValues(t, i, txm.TxResult.Index)
+		assert.Equal(t, tx, string(txm.TxResult.Tx))
+	}
+}
+
+func TestEmptyTopicIsSkipped(t *testing.T) {
+	pub := &mockPublisher{}
+	es := NewEventSink(pub, Topics{Tx: "txs"}, "test-chain", log.TestingLogger())
+	defer es.Stop() //nolint:errcheck // ignore for tests
+
+	require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{Header: types.Header{Height: 1}}))
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{{Height: 1}}))
+
+	require.Eventually(t, func() bool { return len(pub.published()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "txs", pub.published()[0].topic)
+}
+
+func TestUnsupportedQueries(t *testing.T) {
+	es := NewEventSink(&mockPublisher{}, testTopics, "test-chain", log.TestingLogger())
+	defer es.Stop() //nolint:errcheck // ignore for tests
+
+	_, err := es.SearchBlockEvents(context.Background(), nil)
+	assert.Error(t, err)
+	_, err = es.SearchTxEvents(context.Background(), nil)
+	assert.Error(t, err)
+	_, err = es.GetTxByHash(nil)
+	assert.Error(t, err)
+	_, err = es.HasBlock(1)
+	assert.Error(t, err)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -24,7 +23,8 @@ var _ indexer.TxIndexer = (*TxIndex)(nil)
 // 1. txhash - result  (primary key)
 // 2. event - txhash   (secondary key)
 type TxIndex struct {
-	store dbm.DB
+	store  dbm.DB
+	schema *types.EventSchema
 }
 
 // NewTxIndex creates new KV indexer.
@@ -34,6 +34,13 @@ func NewTxIndex(store dbm.DB) *TxIndex {
 	}
 }
 
+// SetEventSchema sets the schema of the event attributes, whose values are
+// then indexed and searched in the canonical form of their declared type, and
+// compared as floats in range queries if declared as FLOAT.
+func (txi *TxIndex) SetEventSchema(schema *types.EventSchema) {
+	txi.schema = schema
+}
+
 // Get gets transaction from the TxIndex storage and returns it or nil if the
 // transaction is not found.
 func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
@@ -96,6 +103,21 @@ func (txi *TxIndex) Index(results []*abci.TxResult) error {
 }
 
 func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Batch) error {
+	keys, err := txi.eventKeys(result)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := store.Set(key, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventKeys returns the keys the events of the tx result are indexed with.
+func (txi *TxIndex) eventKeys(result *abci.TxResult) ([][]byte, error) {
+	var keys [][]byte
 	for _, event := range result.Result.Events {
 		// only index events with a non-empty type
 		if len(event.Type) == 0 {
@@ -111,18 +133,77 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 			compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
 			// ensure event does not conflict with a reserved prefix key
 			if compositeTag == types.TxHashKey || compositeTag == types.TxHeightKey {
-				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeTag)
+				return nil, fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeTag)
 			}
 			if attr.GetIndex() {
-				err := store.Set(keyFromEvent(compositeTag, attr.Value, result), hash)
-				if err != nil {
+				value := attr.Value
+				if txi.schema != nil {
+					if t, ok := txi.schema.Type(compositeTag); ok {
+						value = types.NormalizeEventValue(t, value)
+					}
+				}
+				keys = append(keys, keyFromEvent(compositeTag, value, result))
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// PruneHeight deletes the txs indexed at the given height, along with the
+// keys of their events. A tx indexed again at a later height, which the hash
+// now refers to, is kept.
+func (txi *TxIndex) PruneHeight(height int64) error {
+	heightStr := fmt.Sprintf("%d", height)
+	it, err := dbm.IteratePrefix(txi.store, prefixFromCompositeKeyAndValue(types.TxHeightKey, heightStr))
+	if err != nil {
+		return err
+	}
+
+	var heightKeys, hashes [][]byte
+	for ; it.Valid(); it.Next() {
+		heightKeys = append(heightKeys, append([]byte(nil), it.Key()...))
+		hashes = append(hashes, append([]byte(nil), it.Value()...))
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	if err := it.Close(); err != nil {
+		return err
+	}
+	if len(heightKeys) == 0 {
+		return nil
+	}
+
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	for i, hash := range hashes {
+		result, err := txi.Get(hash)
+		if err != nil {
+			return err
+		}
+		if result != nil && result.Height == height {
+			keys, err := txi.eventKeys(result)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if err := b.Delete(key); err != nil {
 					return err
 				}
 			}
+			if err := b.Delete(primaryKey(hash)); err != nil {
+				return err
+			}
+		}
+		if err := b.Delete(heightKeys[i]); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return b.Write()
 }
 
 // Search performs a search using the given query.
@@ -152,6 +233,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	if err != nil {
 		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
 	}
+	conditions = indexer.NormalizeConditions(conditions, txi.schema)
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
@@ -224,7 +306,10 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
 		}
-		results = append(results, res)
+		// the tx may have been pruned since it matched
+		if res != nil {
+			results = append(results, res)
+		}
 
 		// Potentially exit early.
 		select {
@@ -403,8 +488,6 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -412,37 +495,14 @@ func (txi *TxIndex) matchRange(
 	}
 	defer it.Close()
 
-LOOP:
 	for ; it.Valid(); it.Next() {
 		value, err := parseValueFromKey(it.Key())
 		if err != nil {
 			continue
 		}
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				continue LOOP
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
-			}
-
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		// XXX: passing time in a ABCI Events is not yet implemented
+		if qr.MatchValue(value, txi.schema) {
+			tmpHashes[string(it.Value())] = it.Value()
 		}
 
 		// Potentially exit early.
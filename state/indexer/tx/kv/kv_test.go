@@ -146,6 +146,56 @@ func TestTxSearch(t *testing.T) {
 	}
 }
 
+func TestTxSearchWithEventSchema(t *testing.T) {
+	schema := types.NewEventSchema()
+	require.NoError(t, schema.Set([]abci.EventAttributeSchema{
+		{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_FLOAT},
+		{EventType: "transfer", Key: "fee", Type: abci.EventAttributeType_INT},
+		{EventType: "transfer", Key: "memo", Type: abci.EventAttributeType_STRING},
+	}))
+	indexer := NewTxIndex(db.NewMemDB())
+	indexer.SetEventSchema(schema)
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: "amount", Value: "2.50", Index: true},
+			{Key: "fee", Value: "+010", Index: true},
+			{Key: "memo", Value: "12", Index: true},
+		}},
+	})
+	err := indexer.Index([]*abci.TxResult{txResult})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		q             string
+		resultsLength int
+	}{
+		{"transfer.amount = 2.5", 1},
+		{"transfer.amount = 2.50", 1},
+		{"transfer.amount > 2.4", 1},
+		{"transfer.amount >= 2.5 AND transfer.amount <= 2.5", 1},
+		{"transfer.amount > 2.5", 0},
+		{"transfer.amount > 2", 1},
+		{"transfer.amount < 3", 1},
+		{"transfer.fee = 10", 1},
+		{"transfer.fee >= 10 AND transfer.fee < 11", 1},
+		{"transfer.fee > 10", 0},
+		{"transfer.memo = '12'", 1},
+		{"transfer.memo > 11", 0},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustParse(tc.q))
+			require.NoError(t, err)
+			assert.Len(t, results, tc.resultsLength)
+		})
+	}
+}
+
 func TestTxSearchWithCancelation(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
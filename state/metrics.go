@@ -1,6 +1,9 @@
 package state
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/discard"
 	"github.com/go-kit/kit/metrics/prometheus"
@@ -11,12 +14,37 @@ const (
 	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
 	// package.
 	MetricsSubsystem = "state"
+
+	// phaseTimeHeightModulo is the number of height_mod label values of the
+	// ABCIPhaseTime histogram. Comparing them tells apart apps which are slow
+	// only every n blocks, e.g. because they persist state periodically.
+	phaseTimeHeightModulo = 10
+)
+
+// Block execution phases, used as the phase label of ABCIPhaseTime.
+const (
+	phaseBeginBlock  = "begin_block"
+	phaseDeliverTx   = "deliver_tx"
+	phaseEndBlock    = "end_block"
+	phaseCommit      = "commit"
+	phaseAppHashWait = "app_hash_wait"
 )
 
 // Metrics contains metrics exposed by this package.
 type Metrics struct {
 	// Time between BeginBlock and EndBlock.
 	BlockProcessingTime metrics.Histogram
+	// Time the app took for each block execution phase: BeginBlock, every
+	// DeliverTx, EndBlock and Commit, plus the time between EndBlock and
+	// receiving the app hash from Commit.
+	ABCIPhaseTime metrics.Histogram
+	// Time from a tx being added to the mempool to the tx being committed.
+	TxLatency metrics.Histogram
+	// Number of ABCI Commits the app took longer than the deadline to answer.
+	AppCommitTimeouts metrics.Counter
+	// Fraction of the max block gas used by the last blocks, averaged over
+	// GasUtilizationWindow blocks. 0 if the block gas is unlimited.
+	BlockGasUtilization metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -35,6 +63,33 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Time between BeginBlock and EndBlock in ms.",
 			Buckets:   stdprometheus.LinearBuckets(1, 10, 10),
 		}, labels).With(labelsAndValues...),
+		ABCIPhaseTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "abci_phase_time",
+			Help:      "Time the app took for a block execution phase in ms.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.1, 2, 16),
+		}, append(labels, "phase", "height_mod")).With(labelsAndValues...),
+		TxLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_latency",
+			Help:      "Time from a tx being added to the mempool to the tx being committed in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.1, 2, 14),
+		}, labels).With(labelsAndValues...),
+		AppCommitTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "app_commit_timeouts",
+			Help:      "Number of ABCI Commits the app took longer than the deadline to answer.",
+		}, labels).With(labelsAndValues...),
+		BlockGasUtilization: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_gas_utilization",
+			Help: "Fraction of the max block gas used by the last blocks, averaged over " +
+				strconv.Itoa(GasUtilizationWindow) + " blocks.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -42,5 +97,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		BlockProcessingTime: discard.NewHistogram(),
+		ABCIPhaseTime:       discard.NewHistogram(),
+		TxLatency:           discard.NewHistogram(),
+		AppCommitTimeouts:   discard.NewCounter(),
+		BlockGasUtilization: discard.NewGauge(),
 	}
 }
+
+// observePhase records the duration of a block execution phase at the given
+// height.
+func (m *Metrics) observePhase(phase string, height int64, d time.Duration) {
+	m.ABCIPhaseTime.With(
+		"phase", phase,
+		"height_mod", strconv.FormatInt(height%phaseTimeHeightModulo, 10),
+	).Observe(float64(d) / float64(time.Millisecond))
+}
@@ -3,6 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+
 	mock "github.com/stretchr/testify/mock"
 	state "github.com/tendermint/tendermint/state"
 
@@ -16,6 +20,27 @@ type Store struct {
 	mock.Mock
 }
 
+// Base provides a mock function with given fields:
+func (_m *Store) Base() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Bootstrap provides a mock function with given fields: _a0
 func (_m *Store) Bootstrap(_a0 state.State) error {
 	ret := _m.Called(_a0)
@@ -30,6 +55,20 @@ func (_m *Store) Bootstrap(_a0 state.State) error {
 	return r0
 }
 
+// Close provides a mock function with given fields:
+func (_m *Store) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Load provides a mock function with given fields:
 func (_m *Store) Load() (state.State, error) {
 	ret := _m.Called()
@@ -137,6 +176,52 @@ func (_m *Store) LoadFromDBOrGenesisFile(_a0 string) (state.State, error) {
 	return r0, r1
 }
 
+// LoadScheduledConsensusParams provides a mock function with given fields: _a0
+func (_m *Store) LoadScheduledConsensusParams(_a0 int64) ([]abcitypes.ScheduledConsensusParams, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []abcitypes.ScheduledConsensusParams
+	if rf, ok := ret.Get(0).(func(int64) []abcitypes.ScheduledConsensusParams); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]abcitypes.ScheduledConsensusParams)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadStoreVersion provides a mock function with given fields:
+func (_m *Store) LoadStoreVersion() (*tendermintstate.StoreVersion, error) {
+	ret := _m.Called()
+
+	var r0 *tendermintstate.StoreVersion
+	if rf, ok := ret.Get(0).(func() *tendermintstate.StoreVersion); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tendermintstate.StoreVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -160,6 +245,27 @@ func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	return r0, r1
 }
 
+// MigrateSchema provides a mock function with given fields: _a0, _a1
+func (_m *Store) MigrateSchema(_a0 context.Context, _a1 int64) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PruneStates provides a mock function with given fields: _a0
 func (_m *Store) PruneStates(_a0 int64) error {
 	ret := _m.Called(_a0)
@@ -201,3 +307,31 @@ func (_m *Store) SaveABCIResponses(_a0 int64, _a1 *tendermintstate.ABCIResponses
 
 	return r0
 }
+
+// SaveStoreVersion provides a mock function with given fields: _a0
+func (_m *Store) SaveStoreVersion(_a0 tendermintstate.StoreVersion) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(tendermintstate.StoreVersion) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveValidatorSet provides a mock function with given fields: _a0, _a1
+func (_m *Store) SaveValidatorSet(_a0 int64, _a1 *types.ValidatorSet) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *types.ValidatorSet) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -28,6 +28,7 @@ type BlockStore interface {
 
 	LoadBlockByHash(hash []byte) *types.Block
 	LoadBlockPart(height int64, index int) *types.Part
+	LoadTxLocation(hash []byte) (height int64, index uint32, found bool)
 
 	LoadBlockCommit(height int64) *types.Commit
 	LoadSeenCommit(height int64) *types.Commit
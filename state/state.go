@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/types"
@@ -92,6 +93,17 @@ type State struct {
 	ConsensusParams                  types.ConsensusParams
 	LastHeightConsensusParamsChanged int64
 
+	// Consensus param updates returned by EndBlock for future heights, sorted
+	// by activation height. They are applied like immediate updates once the
+	// height before their activation height is committed, and committed to in
+	// Header.ConsensusHash together with the ConsensusParams.
+	ScheduledConsensusParams []abci.ScheduledConsensusParams
+
+	// Addresses of the validators of the next height which the application
+	// jailed, as returned by EndBlock. Their votes at the next height are
+	// ignored.
+	JailedValidators [][]byte
+
 	// Merkle root of the results from executing prev block
 	LastResultsHash []byte
 
@@ -118,6 +130,8 @@ func (state State) Copy() State {
 
 		ConsensusParams:                  state.ConsensusParams,
 		LastHeightConsensusParamsChanged: state.LastHeightConsensusParamsChanged,
+		ScheduledConsensusParams:         state.ScheduledConsensusParams,
+		JailedValidators:                 state.JailedValidators,
 
 		AppHash: state.AppHash,
 
@@ -125,6 +139,18 @@ func (state State) Copy() State {
 	}
 }
 
+// IsJailed returns true if the application jailed the validator with the
+// given address at the last height, so that its votes at the next height are
+// ignored.
+func (state State) IsJailed(address []byte) bool {
+	for _, jailed := range state.JailedValidators {
+		if bytes.Equal(jailed, address) {
+			return true
+		}
+	}
+	return false
+}
+
 // Equals returns true if the States are identical.
 func (state State) Equals(state2 State) bool {
 	sbz, s2bz := state.Bytes(), state2.Bytes()
@@ -188,6 +214,8 @@ func (state *State) ToProto() (*tmstate.State, error) {
 	sm.LastHeightValidatorsChanged = state.LastHeightValidatorsChanged
 	sm.ConsensusParams = state.ConsensusParams.ToProto()
 	sm.LastHeightConsensusParamsChanged = state.LastHeightConsensusParamsChanged
+	sm.ScheduledConsensusParams = state.ScheduledConsensusParams
+	sm.JailedValidators = state.JailedValidators
 	sm.LastResultsHash = state.LastResultsHash
 	sm.AppHash = state.AppHash
 
@@ -239,6 +267,8 @@ func StateFromProto(pb *tmstate.State) (*State, error) { //nolint:golint
 	state.LastHeightValidatorsChanged = pb.LastHeightValidatorsChanged
 	state.ConsensusParams = types.ConsensusParamsFromProto(pb.ConsensusParams)
 	state.LastHeightConsensusParamsChanged = pb.LastHeightConsensusParamsChanged
+	state.ScheduledConsensusParams = pb.ScheduledConsensusParams
+	state.JailedValidators = pb.JailedValidators
 	state.LastResultsHash = pb.LastResultsHash
 	state.AppHash = pb.AppHash
 
@@ -275,11 +305,12 @@ func (state State) MakeBlock(
 		state.Version.Consensus, state.ChainID,
 		timestamp, state.LastBlockID,
 		state.Validators.Hash(), state.NextValidators.Hash(),
-		state.ConsensusParams.HashConsensusParams(), state.AppHash, state.LastResultsHash,
+		state.ConsensusParams.HashConsensusParamsWithSchedule(state.ScheduledConsensusParams),
+		state.AppHash, state.LastResultsHash,
 		proposerAddress,
 	)
 
-	return block, block.MakePartSet(types.BlockPartSizeBytes)
+	return block, block.MakePartSet(state.ConsensusParams.Block.PartSize())
 }
 
 // MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
@@ -20,6 +20,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -1051,6 +1052,126 @@ func TestConsensusParamsChangesSaveLoad(t *testing.T) {
 	}
 }
 
+func TestScheduledConsensusParams(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	stateStore := sm.NewStore(stateDB)
+
+	updateState := func(state sm.State, scheduled ...abci.ScheduledConsensusParams) (sm.State, error) {
+		block := makeBlock(state, state.LastBlockHeight+1)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: types.PartSetHeader{}}
+		abciResponses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{ScheduledConsensusParamUpdates: scheduled},
+		}
+		return sm.UpdateState(state, blockID, &block.Header, abciResponses, nil)
+	}
+	blockParams := func(maxBytes int64) *tmproto.ConsensusParams {
+		return &tmproto.ConsensusParams{
+			Block: &tmproto.BlockParams{MaxBytes: maxBytes, MaxGas: state.ConsensusParams.Block.MaxGas},
+		}
+	}
+	maxBytes := state.ConsensusParams.Block.MaxBytes
+	initialParams := state.ConsensusParams
+
+	// updates must activate after the next height and result in valid params
+	_, err := updateState(state, abci.ScheduledConsensusParams{Params: blockParams(maxBytes + 1), ActivationHeight: 2})
+	assert.Error(t, err)
+	_, err = updateState(state, abci.ScheduledConsensusParams{ActivationHeight: 3})
+	assert.Error(t, err)
+	_, err = updateState(state, abci.ScheduledConsensusParams{Params: blockParams(0), ActivationHeight: 3})
+	assert.Error(t, err)
+
+	// height 1 schedules updates for heights 4 and 3
+	state, err = updateState(state,
+		abci.ScheduledConsensusParams{Params: blockParams(maxBytes + 2), ActivationHeight: 4},
+		abci.ScheduledConsensusParams{Params: blockParams(maxBytes + 1), ActivationHeight: 3},
+	)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+	assert.Equal(t, initialParams, state.ConsensusParams)
+	require.Len(t, state.ScheduledConsensusParams, 2)
+	assert.EqualValues(t, 3, state.ScheduledConsensusParams[0].ActivationHeight)
+	assert.EqualValues(t, 4, state.ScheduledConsensusParams[1].ActivationHeight)
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state.ScheduledConsensusParams, loadedState.ScheduledConsensusParams)
+
+	// the header of the next block commits to the scheduled updates too
+	block := makeBlock(state, 2)
+	assert.Equal(t, state.ConsensusParams.HashConsensusParamsWithSchedule(state.ScheduledConsensusParams),
+		[]byte(block.ConsensusHash))
+	assert.NotEqual(t, state.ConsensusParams.HashConsensusParams(), []byte(block.ConsensusHash))
+
+	// the updates are applied once the height before their activation height
+	// is committed
+	for height := int64(2); height <= 4; height++ {
+		state, err = updateState(state)
+		require.NoError(t, err)
+		require.NoError(t, stateStore.Save(state))
+	}
+	assert.Empty(t, state.ScheduledConsensusParams)
+	assert.EqualValues(t, 4, state.LastHeightConsensusParamsChanged)
+
+	for height, expMaxBytes := range map[int64]int64{2: maxBytes, 3: maxBytes + 1, 4: maxBytes + 2, 5: maxBytes + 2} {
+		params, err := stateStore.LoadConsensusParams(height)
+		require.NoError(t, err)
+		assert.Equal(t, expMaxBytes, params.Block.MaxBytes, "height %d", height)
+	}
+
+	// the updates still scheduled at every height are stored, e.g. for state
+	// sync
+	for height, expScheduled := range map[int64]int{2: 2, 3: 1, 4: 0, 5: 0} {
+		scheduled, err := stateStore.LoadScheduledConsensusParams(height)
+		require.NoError(t, err)
+		assert.Len(t, scheduled, expScheduled, "height %d", height)
+	}
+}
+
+func TestJailedValidators(t *testing.T) {
+	state, stateDB, _ := makeState(4, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	updateState := func(state sm.State, jailed ...[]byte) (sm.State, error) {
+		block := makeBlock(state, state.LastBlockHeight+1)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: types.PartSetHeader{}}
+		abciResponses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{JailedValidators: jailed},
+		}
+		return sm.UpdateState(state, blockID, &block.Header, abciResponses, nil)
+	}
+	valAddr := state.NextValidators.Validators[0].Address
+	unknownAddr := ed25519.GenPrivKey().PubKey().Address()
+
+	// addresses must be valid
+	_, err := updateState(state, []byte{0x01})
+	assert.Error(t, err)
+
+	// validators with 1/3 or more of the voting power can't be jailed
+	_, err = updateState(state, valAddr, state.NextValidators.Validators[1].Address)
+	assert.Error(t, err)
+
+	// addresses which aren't of a validator of the next height, or repeated,
+	// are dropped
+	state, err = updateState(state, valAddr, unknownAddr, valAddr)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+	assert.Equal(t, [][]byte{valAddr}, state.JailedValidators)
+	assert.True(t, state.IsJailed(valAddr))
+	assert.False(t, state.IsJailed(unknownAddr))
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.True(t, loadedState.IsJailed(valAddr))
+
+	// the validator is only jailed at the next height
+	state, err = updateState(state)
+	require.NoError(t, err)
+	assert.False(t, state.IsJailed(valAddr))
+}
+
 func TestStateProto(t *testing.T) {
 	tearDown, _, state := setupTestCase(t)
 	defer tearDown(t)
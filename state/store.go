@@ -2,8 +2,12 @@ package state
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -15,6 +19,7 @@ import (
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 const (
@@ -23,6 +28,22 @@ const (
 	// https://github.com/tendermint/tendermint/pull/3438
 	// 100000 results in ~ 100ms to get 100 validators (see BenchmarkLoadValidators)
 	valSetCheckpointInterval = 100000
+
+	// StateFormatVersion versions the format in which the state is stored. It
+	// must be increased whenever older software can no longer read or update
+	// the stored state correctly.
+	StateFormatVersion uint64 = 2
+
+	// StateSchemaVersion is the version of the schema of the records written
+	// to the state store. Records of older schemas are still read, and are
+	// migrated by MigrateSchema or when written again. Schema version 1
+	// records are the bare protobuf encoding of their data; later records are
+	// wrapped in a Record envelope.
+	StateSchemaVersion uint32 = 2
+
+	// migrateBatchSize caps the number of records MigrateSchema rewrites in a
+	// single batch.
+	migrateBatchSize = 1000
 )
 
 //------------------------------------------------------------------------
@@ -33,6 +54,7 @@ const (
 	prefixConsensusParams = int64(6)
 	prefixABCIResponses   = int64(7)
 	prefixState           = int64(8)
+	prefixStoreVersion    = int64(14)
 )
 
 func encodeKey(prefix int64, height int64) []byte {
@@ -55,8 +77,8 @@ func abciResponsesKey(height int64) []byte {
 	return encodeKey(prefixABCIResponses, height)
 }
 
-// stateKey should never change after being set in init()
-var stateKey []byte
+// stateKey and storeVersionKey should never change after being set in init()
+var stateKey, storeVersionKey []byte
 
 func init() {
 	var err error
@@ -64,6 +86,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	storeVersionKey, err = orderedcode.Append(nil, prefixStoreVersion)
+	if err != nil {
+		panic(err)
+	}
 }
 
 //----------------------
@@ -87,16 +113,34 @@ type Store interface {
 	LoadValidators(int64) (*types.ValidatorSet, error)
 	// LoadABCIResponses loads the abciResponse for a given height
 	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
+	// Base returns the earliest height with stored ABCI responses, or 0 if
+	// there is none
+	Base() (int64, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (types.ConsensusParams, error)
+	// LoadScheduledConsensusParams loads the consensus param updates scheduled
+	// at later heights as of a given height
+	LoadScheduledConsensusParams(int64) ([]abci.ScheduledConsensusParams, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *tmstate.ABCIResponses) error
+	// SaveValidatorSet saves the validator set for a given height, when the state isn't saved
+	SaveValidatorSet(int64, *types.ValidatorSet) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to prune up to (exclusive)
 	PruneStates(int64) error
+	// LoadStoreVersion loads the version of the software which last wrote to
+	// the store, or nil if it was not recorded
+	LoadStoreVersion() (*tmstate.StoreVersion, error)
+	// SaveStoreVersion records the version of the software writing to the store
+	SaveStoreVersion(tmstate.StoreVersion) error
+	// MigrateSchema migrates the records of heights below the given height
+	// (exclusive) written with an older schema, and returns how many it migrated
+	MigrateSchema(context.Context, int64) (int, error)
+	// Close closes the underlying database
+	Close() error
 }
 
 // dbStore wraps a db (github.com/tendermint/tm-db)
@@ -154,7 +198,7 @@ func (store dbStore) Load() (State, error) {
 }
 
 func (store dbStore) loadState(key []byte) (state State, err error) {
-	buf, err := store.db.Get(key)
+	buf, err := loadRecord(store.db, key)
 	if err != nil {
 		return state, err
 	}
@@ -206,12 +250,12 @@ func (store dbStore) save(state State, key []byte) error {
 	}
 
 	// Save next consensus params.
-	if err := store.saveConsensusParamsInfo(nextHeight,
-		state.LastHeightConsensusParamsChanged, state.ConsensusParams, batch); err != nil {
+	if err := store.saveConsensusParamsInfo(nextHeight, state.LastHeightConsensusParamsChanged,
+		state.ConsensusParams, state.ScheduledConsensusParams, batch); err != nil {
 		return err
 	}
 
-	if err := batch.Set(key, state.Bytes()); err != nil {
+	if err := batch.Set(key, encodeRecord(state.Bytes())); err != nil {
 		return err
 	}
 
@@ -242,18 +286,118 @@ func (store dbStore) Bootstrap(state State) error {
 		return err
 	}
 
-	if err := store.saveConsensusParamsInfo(height,
-		state.LastHeightConsensusParamsChanged, state.ConsensusParams, batch); err != nil {
+	if err := store.saveConsensusParamsInfo(height, state.LastHeightConsensusParamsChanged,
+		state.ConsensusParams, state.ScheduledConsensusParams, batch); err != nil {
 		return err
 	}
 
-	if err := batch.Set(stateKey, state.Bytes()); err != nil {
+	if err := batch.Set(stateKey, encodeRecord(state.Bytes())); err != nil {
 		return err
 	}
 
 	return batch.WriteSync()
 }
 
+// Close closes the underlying database.
+func (store dbStore) Close() error {
+	return store.db.Close()
+}
+
+// LoadStoreVersion loads the version of the software which last wrote to the
+// store. It returns nil if the version was not recorded, i.e. the store is new
+// or was written by software which predates the recording.
+func (store dbStore) LoadStoreVersion() (*tmstate.StoreVersion, error) {
+	buf, err := store.db.Get(storeVersionKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	v := new(tmstate.StoreVersion)
+	if err := v.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal store version: %w", err)
+	}
+	return v, nil
+}
+
+// SaveStoreVersion records the version of the software writing to the store.
+func (store dbStore) SaveStoreVersion(v tmstate.StoreVersion) error {
+	bz, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+	return store.db.SetSync(storeVersionKey, bz)
+}
+
+// CurrentStoreVersion returns the version of this software, as recorded in
+// the state store.
+func CurrentStoreVersion() tmstate.StoreVersion {
+	return tmstate.StoreVersion{
+		Software:    version.TMVersion,
+		StateFormat: StateFormatVersion,
+	}
+}
+
+// CheckStoreVersion returns ErrStoreDowngrade if the stored version is newer
+// than the current one, i.e. the node was downgraded below the software which
+// last wrote to the store. The state format is always compared, the software
+// version only if both versions are semantic versions.
+func CheckStoreVersion(stored *tmstate.StoreVersion, current tmstate.StoreVersion) error {
+	if stored == nil {
+		return nil
+	}
+
+	if stored.StateFormat > current.StateFormat {
+		return ErrStoreDowngrade{Stored: *stored, Current: current}
+	}
+	if cmp, ok := compareSemVer(stored.Software, current.Software); ok && cmp > 0 {
+		return ErrStoreDowngrade{Stored: *stored, Current: current}
+	}
+	return nil
+}
+
+// compareSemVer compares the major, minor and patch versions of two semantic
+// versions, ignoring any pre-release or build suffix. It returns false if
+// either is not a semantic version.
+func compareSemVer(a, b string) (int, bool) {
+	parse := func(v string) ([3]int, bool) {
+		var parts [3]int
+		v = strings.TrimPrefix(v, "v")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		fields := strings.Split(v, ".")
+		if len(fields) != 3 {
+			return parts, false
+		}
+		for i, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil || n < 0 {
+				return parts, false
+			}
+			parts[i] = n
+		}
+		return parts, true
+	}
+
+	va, okA := parse(a)
+	vb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] > vb[i] {
+				return 1, true
+			}
+			return -1, true
+		}
+	}
+	return 0, true
+}
+
 // PruneStates deletes states up to the height specified (exclusive). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at retain height must also exist.
@@ -445,6 +589,29 @@ func ABCIResponsesResultsHash(ar *tmstate.ABCIResponses) []byte {
 	return types.NewResults(ar.DeliverTxs).Hash()
 }
 
+// Base returns the earliest height whose ABCIResponses are stored, i.e. the
+// earliest height of which the state wasn't pruned, or 0 if there is none.
+func (store dbStore) Base() (int64, error) {
+	iter, err := store.db.Iterator(abciResponsesKey(1), abciResponsesKey(math.MaxInt64))
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return 0, iter.Error()
+	}
+	var prefix, height int64
+	remaining, err := orderedcode.Parse(string(iter.Key()), &prefix, &height)
+	if err != nil {
+		return 0, err
+	}
+	if len(remaining) != 0 || prefix != prefixABCIResponses {
+		return 0, fmt.Errorf("unexpected ABCI responses key %X", iter.Key())
+	}
+	return height, nil
+}
+
 // LoadABCIResponses loads the ABCIResponses for the given height from the
 // database. If not found, ErrNoABCIResponsesForHeight is returned.
 //
@@ -452,7 +619,7 @@ func ABCIResponsesResultsHash(ar *tmstate.ABCIResponses) []byte {
 // before we called s.Save(). It can also be used to produce Merkle proofs of
 // the result of txs.
 func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, error) {
-	buf, err := store.db.Get(abciResponsesKey(height))
+	buf, err := loadRecord(store.db, abciResponsesKey(height))
 	if err != nil {
 		return nil, err
 	}
@@ -499,11 +666,33 @@ func (store dbStore) saveABCIResponses(height int64, abciResponses *tmstate.ABCI
 		return err
 	}
 
-	return store.db.SetSync(abciResponsesKey(height), bz)
+	return store.db.SetSync(abciResponsesKey(height), encodeRecord(bz))
 }
 
 //-----------------------------------------------------------------------------
 
+// SaveValidatorSet persists the validator set for the given height. It's used
+// by header-only nodes, which don't execute blocks and so never save the state.
+// As with Save, the set is only persisted if it changed since the previous
+// height, or at checkpoint heights.
+func (store dbStore) SaveValidatorSet(height int64, valSet *types.ValidatorSet) error {
+	lastHeightChanged := height
+	if prevInfo, err := loadValidatorsInfo(store.db, height-1); err == nil {
+		prevSet, err := store.LoadValidators(height - 1)
+		if err == nil && bytes.Equal(prevSet.Hash(), valSet.Hash()) {
+			lastHeightChanged = prevInfo.LastHeightChanged
+		}
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	if err := store.saveValidatorsInfo(height, lastHeightChanged, valSet, batch); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
 // LoadValidators loads the ValidatorSet for a given height.
 // Returns ErrNoValSetForHeight if the validator set can't be found for this height.
 func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
@@ -554,7 +743,7 @@ func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 
 // CONTRACT: Returned ValidatorsInfo can be mutated.
 func loadValidatorsInfo(db dbm.DB, height int64) (*tmstate.ValidatorsInfo, error) {
-	buf, err := db.Get(validatorsKey(height))
+	buf, err := loadRecord(db, validatorsKey(height))
 	if err != nil {
 		return nil, err
 	}
@@ -606,7 +795,7 @@ func (store dbStore) saveValidatorsInfo(
 		return err
 	}
 
-	err = batch.Set(validatorsKey(height), bz)
+	err = batch.Set(validatorsKey(height), encodeRecord(bz))
 	if err != nil {
 		return err
 	}
@@ -648,8 +837,18 @@ func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, e
 	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), nil
 }
 
+// LoadScheduledConsensusParams loads the consensus param updates which were
+// scheduled at later heights when the block at the given height was proposed.
+func (store dbStore) LoadScheduledConsensusParams(height int64) ([]abci.ScheduledConsensusParams, error) {
+	paramsInfo, err := store.loadConsensusParamsInfo(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+	}
+	return paramsInfo.ScheduledConsensusParams, nil
+}
+
 func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusParamsInfo, error) {
-	buf, err := store.db.Get(consensusParamsKey(height))
+	buf, err := loadRecord(store.db, consensusParamsKey(height))
 	if err != nil {
 		return nil, err
 	}
@@ -671,14 +870,17 @@ func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusPa
 // saveConsensusParamsInfo persists the consensus params for the next block to disk.
 // It should be called from s.Save(), right before the state itself is persisted.
 // If the consensus params did not change after processing the latest block,
-// only the last height for which they changed is persisted.
+// only the last height for which they changed is persisted. The scheduled
+// updates are persisted at every height.
 func (store dbStore) saveConsensusParamsInfo(
 	nextHeight, changeHeight int64,
 	params types.ConsensusParams,
+	scheduled []abci.ScheduledConsensusParams,
 	batch dbm.Batch,
 ) error {
 	paramsInfo := &tmstate.ConsensusParamsInfo{
-		LastHeightChanged: changeHeight,
+		LastHeightChanged:        changeHeight,
+		ScheduledConsensusParams: scheduled,
 	}
 
 	if changeHeight == nextHeight {
@@ -689,10 +891,144 @@ func (store dbStore) saveConsensusParamsInfo(
 		return err
 	}
 
-	err = batch.Set(consensusParamsKey(nextHeight), bz)
+	err = batch.Set(consensusParamsKey(nextHeight), encodeRecord(bz))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+//-----------------------------------------------------------------------------
+
+// encodeRecord wraps the encoded data of a record in a Record envelope of the
+// current schema version. The envelope is prefixed with a zero byte, which
+// can't start a protobuf message, to tell it apart from schema version 1
+// records.
+func encodeRecord(data []byte) []byte {
+	rec := tmstate.Record{SchemaVersion: StateSchemaVersion, Data: data}
+	bz := make([]byte, 1+rec.Size())
+	if _, err := rec.MarshalTo(bz[1:]); err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// decodeRecord returns the encoded data of a record and the schema version it
+// was written with.
+func decodeRecord(bz []byte) ([]byte, uint32, error) {
+	if len(bz) == 0 || bz[0] != 0 {
+		return bz, 1, nil
+	}
+
+	rec := new(tmstate.Record)
+	if err := rec.Unmarshal(bz[1:]); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	if rec.SchemaVersion <= 1 || rec.SchemaVersion > StateSchemaVersion {
+		return nil, 0, fmt.Errorf("unknown record schema version %d", rec.SchemaVersion)
+	}
+	return rec.Data, rec.SchemaVersion, nil
+}
+
+// loadRecord loads the encoded data of the record with the given key, or nil
+// if there is none. A record written with an older schema is decoded as is and
+// left untouched, since reads, e.g. of the RPC, run concurrently with the
+// writes of consensus.
+func loadRecord(db dbm.DB, key []byte) ([]byte, error) {
+	bz, err := db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	data, _, err := decodeRecord(bz)
+	if err != nil {
+		return nil, fmt.Errorf("record %X: %w", key, err)
+	}
+	return data, nil
+}
+
+// MigrateSchema migrates the validators, consensus params and ABCI responses
+// of heights below the given one (exclusive) which were written with an older
+// schema. It's safe to run alongside consensus as long as the height is at
+// most the last block height: consensus only writes records of later heights.
+// The state itself is migrated by the next Save. It stops when the context is
+// canceled, and returns the number of records it migrated.
+func (store dbStore) MigrateSchema(ctx context.Context, height int64) (int, error) {
+	migrated := 0
+	for _, keyFn := range []func(int64) []byte{validatorsKey, consensusParamsKey, abciResponsesKey} {
+		start, end := keyFn(1), keyFn(height)
+		for start != nil {
+			if err := ctx.Err(); err != nil {
+				return migrated, err
+			}
+
+			var (
+				n   int
+				err error
+			)
+			start, n, err = store.migrateBatch(start, end)
+			migrated += n
+			if err != nil {
+				return migrated, err
+			}
+		}
+	}
+	return migrated, nil
+}
+
+// migrateBatch migrates the records from start to end (exclusive) written with
+// an older schema, up to migrateBatchSize records at a time. It returns the key
+// to continue from, or nil if it reached the end of the range. The records are
+// written once the iterator is closed, to avoid writing whilst iterating.
+func (store dbStore) migrateBatch(start, end []byte) ([]byte, int, error) {
+	iter, err := store.db.Iterator(start, end)
+	if err != nil {
+		return nil, 0, fmt.Errorf("iterator error: %w", err)
+	}
+
+	type record struct{ key, value []byte }
+	var (
+		records []record
+		next    []byte
+	)
+	for size := 0; iter.Valid(); iter.Next() {
+		if size == migrateBatchSize {
+			next = append([]byte(nil), iter.Key()...)
+			break
+		}
+		size++
+
+		data, version, err := decodeRecord(iter.Value())
+		if err != nil {
+			err = fmt.Errorf("record %X: %w", iter.Key(), err)
+			iter.Close()
+			return nil, 0, err
+		}
+		if version < StateSchemaVersion {
+			records = append(records, record{append([]byte(nil), iter.Key()...), encodeRecord(data)})
+		}
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return nil, 0, err
+	}
+	if err := iter.Close(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(records) == 0 {
+		return next, 0, nil
+	}
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for _, r := range records {
+		if err := batch.Set(r.key, r.value); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return nil, 0, err
+	}
+	return next, len(records), nil
+}
@@ -1,10 +1,13 @@
 package state_test
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
+	"github.com/google/orderedcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -137,6 +140,38 @@ func BenchmarkLoadValidators(b *testing.B) {
 	}
 }
 
+func TestStoreSaveValidatorSet(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	val, _ := factory.RandValidator(true, 10)
+	val2, _ := factory.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val, val2})
+
+	// the first set is saved at the height it's saved from, not the initial height
+	require.NoError(t, stateStore.SaveValidatorSet(10, vals))
+	_, err := stateStore.LoadValidators(9)
+	require.Error(t, err)
+
+	// unchanged sets are derived from the last one which changed
+	require.NoError(t, stateStore.SaveValidatorSet(11, vals.CopyIncrementProposerPriority(1)))
+	require.NoError(t, stateStore.SaveValidatorSet(12, vals.CopyIncrementProposerPriority(2)))
+	loadedVals, err := stateStore.LoadValidators(10)
+	require.NoError(t, err)
+	require.Equal(t, vals, loadedVals)
+	for h := int64(11); h <= 12; h++ {
+		loadedVals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err)
+		require.Equal(t, vals.CopyIncrementProposerPriority(int32(h-10)), loadedVals)
+	}
+
+	// changed sets are saved as is
+	val3, _ := factory.RandValidator(true, 10)
+	vals2 := types.NewValidatorSet([]*types.Validator{val, val2, val3})
+	require.NoError(t, stateStore.SaveValidatorSet(13, vals2))
+	loadedVals, err = stateStore.LoadValidators(13)
+	require.NoError(t, err)
+	require.Equal(t, vals2, loadedVals)
+}
+
 func TestStoreLoadConsensusParams(t *testing.T) {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB)
@@ -232,14 +267,22 @@ func TestPruneStates(t *testing.T) {
 				require.NoError(t, err)
 			}
 
+			base, err := stateStore.Base()
+			require.NoError(t, err)
+			require.Equal(t, tc.startHeight, base)
+
 			// Test assertions
-			err := stateStore.PruneStates(tc.pruneHeight)
+			err = stateStore.PruneStates(tc.pruneHeight)
 			if tc.expectErr {
 				require.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
 
+			base, err = stateStore.Base()
+			require.NoError(t, err)
+			require.Equal(t, tc.pruneHeight, base)
+
 			for h := tc.pruneHeight; h <= tc.endHeight; h++ {
 				vals, err := stateStore.LoadValidators(h)
 				require.NoError(t, err, h)
@@ -283,6 +326,143 @@ func TestPruneStates(t *testing.T) {
 	}
 }
 
+func TestStoreVersion(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	stored, err := stateStore.LoadStoreVersion()
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	current := sm.CurrentStoreVersion()
+	require.NoError(t, stateStore.SaveStoreVersion(current))
+	stored, err = stateStore.LoadStoreVersion()
+	require.NoError(t, err)
+	assert.Equal(t, current, *stored)
+}
+
+func TestCheckStoreVersion(t *testing.T) {
+	current := tmstate.StoreVersion{Software: "0.34.10", StateFormat: 2}
+
+	testCases := []struct {
+		name      string
+		stored    *tmstate.StoreVersion
+		downgrade bool
+	}{
+		{"not recorded", nil, false},
+		{"same version", &tmstate.StoreVersion{Software: "0.34.10", StateFormat: 2}, false},
+		{"older software", &tmstate.StoreVersion{Software: "0.34.9", StateFormat: 2}, false},
+		{"older state format", &tmstate.StoreVersion{Software: "0.34.10", StateFormat: 1}, false},
+		{"newer patch", &tmstate.StoreVersion{Software: "0.34.11", StateFormat: 2}, true},
+		{"newer minor", &tmstate.StoreVersion{Software: "v0.35.0-rc1", StateFormat: 2}, true},
+		{"newer major", &tmstate.StoreVersion{Software: "1.0.0", StateFormat: 2}, true},
+		{"newer state format", &tmstate.StoreVersion{Software: "0.34.10", StateFormat: 3}, true},
+		{"non-semver software", &tmstate.StoreVersion{Software: "dev", StateFormat: 2}, false},
+		{"non-semver software, newer state format", &tmstate.StoreVersion{Software: "dev", StateFormat: 3}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := sm.CheckStoreVersion(tc.stored, current)
+			if tc.downgrade {
+				assert.ErrorAs(t, err, &sm.ErrStoreDowngrade{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStoreMigrateSchema(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB)
+
+	// write schema version 1 records, i.e. bare protobuf
+	abciResponsesKey := func(height int64) []byte {
+		key, err := orderedcode.Append(nil, int64(7), height)
+		require.NoError(t, err)
+		return key
+	}
+	responses := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: 32, Data: []byte("Hello")}},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}
+	bz, err := responses.Marshal()
+	require.NoError(t, err)
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, stateDB.Set(abciResponsesKey(h), bz))
+	}
+
+	vals, _ := factory.RandValidatorSet(1, 10)
+	state := makeRandomStateFromValidatorSet(vals, 3, 1)
+	stateKey, err := orderedcode.Append(nil, int64(8))
+	require.NoError(t, err)
+	require.NoError(t, stateDB.Set(stateKey, state.Bytes()))
+
+	isMigrated := func(key []byte) bool {
+		bz, err := stateDB.Get(key)
+		require.NoError(t, err)
+		return len(bz) > 0 && bz[0] == 0
+	}
+
+	// records are read as they are, without writing
+	loaded, err := stateStore.LoadABCIResponses(1)
+	require.NoError(t, err)
+	assert.Equal(t, responses.DeliverTxs, loaded.DeliverTxs)
+	assert.False(t, isMigrated(abciResponsesKey(1)))
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state.Bytes(), loadedState.Bytes())
+	assert.False(t, isMigrated(stateKey))
+
+	// MigrateSchema migrates the records below the given height
+	migrated, err := stateStore.MigrateSchema(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+	assert.True(t, isMigrated(abciResponsesKey(1)))
+	assert.True(t, isMigrated(abciResponsesKey(2)))
+	assert.False(t, isMigrated(abciResponsesKey(3)))
+
+	migrated, err = stateStore.MigrateSchema(context.Background(), math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, 1, migrated)
+	assert.True(t, isMigrated(abciResponsesKey(3)))
+
+	migrated, err = stateStore.MigrateSchema(context.Background(), math.MaxInt64)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+
+	for h := int64(1); h <= 3; h++ {
+		loaded, err := stateStore.LoadABCIResponses(h)
+		require.NoError(t, err)
+		assert.Equal(t, responses.DeliverTxs, loaded.DeliverTxs)
+	}
+
+	// records written by the store are of the current schema
+	require.NoError(t, stateStore.Save(state))
+	assert.True(t, isMigrated(stateKey))
+	migrated, err = stateStore.MigrateSchema(context.Background(), math.MaxInt64)
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = stateStore.MigrateSchema(ctx, math.MaxInt64)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// records of an unknown schema are rejected
+	rec := tmstate.Record{SchemaVersion: sm.StateSchemaVersion + 1, Data: bz}
+	recBz, err := rec.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, stateDB.Set(abciResponsesKey(4), append([]byte{0}, recBz...)))
+	_, err = stateStore.LoadABCIResponses(4)
+	assert.Error(t, err)
+	_, err = stateStore.MigrateSchema(context.Background(), math.MaxInt64)
+	assert.Error(t, err)
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &tmstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -56,7 +56,7 @@ func validateBlock(state State, block *types.Block) error {
 			block.AppHash,
 		)
 	}
-	hashCP := state.ConsensusParams.HashConsensusParams()
+	hashCP := state.ConsensusParams.HashConsensusParamsWithSchedule(state.ScheduledConsensusParams)
 	if !bytes.Equal(block.ConsensusHash, hashCP) {
 		return fmt.Errorf("wrong Block.Header.ConsensusHash.  Expected %X, got %v",
 			hashCP,
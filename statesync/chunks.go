@@ -1,8 +1,11 @@
 package statesync
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -13,16 +16,33 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 )
 
-// errDone is returned by chunkQueue.Next() when all chunks have been returned.
-var errDone = errors.New("chunk queue has completed")
+var (
+	// errDone is returned by chunkQueue.Next() when all chunks have been returned.
+	errDone = errors.New("chunk queue has completed")
+	// errChunkHashMismatch is returned by chunkQueue.Add() when a chunk doesn't match the
+	// snapshot's chunk hash.
+	errChunkHashMismatch = errors.New("chunk hash mismatch")
+)
 
-// chunk contains data for a chunk.
+// chunk contains data for a chunk. When a chunk is streamed in parts, Chunk
+// contains the part at Offset and Size is the total size of the chunk.
 type chunk struct {
-	Height uint64
-	Format uint32
-	Index  uint32
-	Chunk  []byte
-	Sender p2p.NodeID
+	Height     uint64
+	Format     uint32
+	Index      uint32
+	Chunk      []byte
+	Offset     uint64
+	Size       uint64
+	Sender     p2p.NodeID
+	BaseHeight uint64
+}
+
+// partialChunk tracks a chunk that is being streamed in parts.
+type partialChunk struct {
+	path    string
+	sender  p2p.NodeID
+	size    uint64
+	written uint64
 }
 
 // chunkQueue manages chunks for a state sync process, ordering them if requested. It acts as an
@@ -36,6 +56,7 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]p2p.NodeID      // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkParts     map[uint32]*partialChunk   // chunks being streamed in parts
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
@@ -57,11 +78,14 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]p2p.NodeID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkParts:     make(map[uint32]*partialChunk),
 		waiters:        make(map[uint32][]chan<- uint32),
 	}, nil
 }
 
-// Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
+// Add adds a chunk to the queue. It ignores chunks that already exist, returning false. Chunks
+// streamed in parts are only added once the last part arrives, and false is returned for all
+// other parts.
 func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk == nil || chunk.Chunk == nil {
 		return false, errors.New("cannot add nil chunk")
@@ -79,31 +103,141 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk.Format != q.snapshot.Format {
 		return false, fmt.Errorf("invalid chunk format %v, expected %v", chunk.Format, q.snapshot.Format)
 	}
+	if chunk.BaseHeight != q.snapshot.BaseHeight {
+		return false, fmt.Errorf("invalid chunk base height %v, expected %v", chunk.BaseHeight, q.snapshot.BaseHeight)
+	}
 	if chunk.Index >= q.snapshot.Chunks {
 		return false, fmt.Errorf("received unexpected chunk %v", chunk.Index)
 	}
 	if q.chunkFiles[chunk.Index] != "" {
 		return false, nil
 	}
+	if chunk.Size > 0 {
+		return q.addPart(chunk)
+	}
+	if len(q.snapshot.ChunkHashes) > 0 {
+		hash := sha256.Sum256(chunk.Chunk)
+		if !bytes.Equal(hash[:], q.snapshot.ChunkHashes[chunk.Index]) {
+			return false, fmt.Errorf("%w for chunk %v", errChunkHashMismatch, chunk.Index)
+		}
+	}
 
-	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
+	path := q.chunkPath(chunk.Index)
 	err := ioutil.WriteFile(path, chunk.Chunk, 0600)
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
 
-	q.chunkFiles[chunk.Index] = path
-	q.chunkSenders[chunk.Index] = chunk.Sender
+	q.complete(chunk.Index, path, chunk.Sender)
+	return true, nil
+}
+
+// addPart adds a part of a streamed chunk, appending it to the partial chunk file. A part at
+// offset 0 starts a new stream, replacing any previous partial chunk, e.g. when the chunk was
+// rerequested from a different peer. Parts that don't continue the current stream are ignored.
+// The caller must hold the mutex lock.
+func (q *chunkQueue) addPart(chunk *chunk) (bool, error) {
+	part := q.chunkParts[chunk.Index]
+	if chunk.Offset == 0 {
+		if part != nil {
+			q.removePart(chunk.Index)
+		}
+		part = &partialChunk{
+			path:   q.chunkPath(chunk.Index) + ".part",
+			sender: chunk.Sender,
+			size:   chunk.Size,
+		}
+		q.chunkParts[chunk.Index] = part
+	}
+	if part == nil || part.sender != chunk.Sender || part.size != chunk.Size || part.written != chunk.Offset {
+		return false, nil
+	}
+	if part.written+uint64(len(chunk.Chunk)) > part.size {
+		q.removePart(chunk.Index)
+		return false, fmt.Errorf("chunk %v part at offset %v exceeds chunk size %v",
+			chunk.Index, chunk.Offset, chunk.Size)
+	}
+
+	file, err := os.OpenFile(part.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return false, fmt.Errorf("failed to open chunk %v file %v: %w", chunk.Index, part.path, err)
+	}
+	_, err = file.Write(chunk.Chunk)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		q.removePart(chunk.Index)
+		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, part.path, err)
+	}
+
+	part.written += uint64(len(chunk.Chunk))
+	if part.written < part.size {
+		return false, nil
+	}
+	delete(q.chunkParts, chunk.Index)
+
+	if len(q.snapshot.ChunkHashes) > 0 {
+		hash, err := hashFile(part.path)
+		if err != nil {
+			return false, fmt.Errorf("failed to hash chunk %v: %w", chunk.Index, err)
+		}
+		if !bytes.Equal(hash, q.snapshot.ChunkHashes[chunk.Index]) {
+			_ = os.Remove(part.path)
+			return false, fmt.Errorf("%w for chunk %v", errChunkHashMismatch, chunk.Index)
+		}
+	}
+
+	path := q.chunkPath(chunk.Index)
+	if err := os.Rename(part.path, path); err != nil {
+		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
+	}
+
+	q.complete(chunk.Index, path, chunk.Sender)
+	return true, nil
+}
+
+// complete records a chunk as having arrived and signals any waiters. The caller must hold the
+// mutex lock.
+func (q *chunkQueue) complete(index uint32, path string, sender p2p.NodeID) {
+	q.chunkFiles[index] = path
+	q.chunkSenders[index] = sender
 
 	// Signal any waiters that the chunk has arrived.
-	for _, waiter := range q.waiters[chunk.Index] {
-		waiter <- chunk.Index
+	for _, waiter := range q.waiters[index] {
+		waiter <- index
 		close(waiter)
 	}
 
-	delete(q.waiters, chunk.Index)
+	delete(q.waiters, index)
+}
 
-	return true, nil
+// chunkPath returns the path of the file a chunk is stored in.
+func (q *chunkQueue) chunkPath(index uint32) string {
+	return filepath.Join(q.dir, strconv.FormatUint(uint64(index), 10))
+}
+
+// removePart removes a partial chunk. The caller must hold the mutex lock.
+func (q *chunkQueue) removePart(index uint32) {
+	if part := q.chunkParts[index]; part != nil {
+		_ = os.Remove(part.path)
+		delete(q.chunkParts, index)
+	}
+}
+
+// hashFile returns the SHA-256 hash of a file's contents.
+func hashFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
 }
 
 // Allocate allocates a chunk to the caller, making it responsible for fetching it. Returns
@@ -203,6 +337,12 @@ func (q *chunkQueue) DiscardSender(peerID p2p.NodeID) error {
 		}
 	}
 
+	for index, part := range q.chunkParts {
+		if part.sender == peerID {
+			q.removePart(index)
+		}
+	}
+
 	return nil
 }
 
@@ -246,12 +386,47 @@ func (q *chunkQueue) load(index uint32) (*chunk, error) {
 // Next returns the next chunk from the queue, or errDone if all chunks have been returned. It
 // blocks until the chunk is available. Concurrent Next() calls may return the same chunk.
 func (q *chunkQueue) Next() (*chunk, error) {
+	return q.next(q.load)
+}
+
+// NextFile is like Next(), but returns the chunk without its body along with an open file
+// containing it, such that large chunks can be read in parts. The caller must close the file.
+func (q *chunkQueue) NextFile() (*chunk, *os.File, error) {
+	var file *os.File
+	chunk, err := q.next(func(index uint32) (*chunk, error) {
+		path, ok := q.chunkFiles[index]
+		if !ok {
+			return nil, nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open chunk %v: %w", index, err)
+		}
+		file = f
+
+		return &chunk{
+			Height: q.snapshot.Height,
+			Format: q.snapshot.Format,
+			Index:  index,
+			Sender: q.chunkSenders[index],
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return chunk, file, nil
+}
+
+// next returns the next chunk from the queue using the given load function, which is called
+// with the mutex lock held.
+func (q *chunkQueue) next(load func(uint32) (*chunk, error)) (*chunk, error) {
 	q.Lock()
 
 	var chunk *chunk
 	index, err := q.nextUp()
 	if err == nil {
-		chunk, err = q.load(index)
+		chunk, err = load(index)
 		if err == nil {
 			q.chunkReturned[index] = true
 		}
@@ -275,7 +450,7 @@ func (q *chunkQueue) Next() (*chunk, error) {
 	q.Lock()
 	defer q.Unlock()
 
-	chunk, err = q.load(index)
+	chunk, err = load(index)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -126,6 +127,7 @@ func TestChunkQueue_Add_ChunkErrors(t *testing.T) {
 		"wrong height":  {&chunk{Height: 9, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}}},
 		"wrong format":  {&chunk{Height: 3, Format: 9, Index: 0, Chunk: []byte{3, 1, 0}}},
 		"invalid index": {&chunk{Height: 3, Format: 1, Index: 5, Chunk: []byte{3, 1, 0}}},
+		"delta chunk":   {&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}, BaseHeight: 2}},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -138,6 +140,121 @@ func TestChunkQueue_Add_ChunkErrors(t *testing.T) {
 	}
 }
 
+func TestChunkQueue_Add_ChunkHashes(t *testing.T) {
+	chunks := [][]byte{{3, 1, 0}, {3, 1, 1}}
+	hashes := make([][]byte, 0, len(chunks))
+	for _, c := range chunks {
+		hash := sha256.Sum256(c)
+		hashes = append(hashes, hash[:])
+	}
+	queue, err := newChunkQueue(&snapshot{Height: 3, Format: 1, Chunks: 2, ChunkHashes: hashes}, "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, queue.Close())
+	}()
+
+	// A corrupted or truncated chunk should be rejected
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 9}})
+	require.ErrorIs(t, err, errChunkHashMismatch)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1}})
+	require.ErrorIs(t, err, errChunkHashMismatch)
+	assert.False(t, queue.Has(0))
+	assert.False(t, queue.Has(1))
+
+	// The valid chunks should be accepted
+	for i, c := range chunks {
+		added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: uint32(i), Chunk: c})
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+}
+
+func TestChunkQueue_Add_Parts(t *testing.T) {
+	body := []byte{3, 1, 0, 9, 8}
+	hash := sha256.Sum256(body)
+	queue, err := newChunkQueue(&snapshot{Height: 3, Format: 1, Chunks: 2, ChunkHashes: [][]byte{hash[:], hash[:]}}, "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, queue.Close())
+	}()
+
+	part := func(offset int, end int, sender p2p.NodeID) *chunk {
+		return &chunk{
+			Height: 3, Format: 1, Index: 0, Chunk: body[offset:end],
+			Offset: uint64(offset), Size: uint64(len(body)), Sender: sender,
+		}
+	}
+
+	// Parts are only added once the chunk is complete
+	added, err := queue.Add(part(0, 2, "a"))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// Parts out of order or from another sender are ignored
+	added, err = queue.Add(part(3, 5, "a"))
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = queue.Add(part(2, 3, "b"))
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.False(t, queue.Has(0))
+
+	// A new stream from another sender replaces the partial chunk
+	added, err = queue.Add(part(0, 3, "b"))
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = queue.Add(part(2, 5, "a"))
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	added, err = queue.Add(part(3, 5, "b"))
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.True(t, queue.Has(0))
+	assert.Equal(t, p2p.NodeID("b"), queue.GetSender(0))
+
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.Equal(t, body, c.Chunk)
+
+	// A streamed chunk that doesn't match its hash is rejected once complete
+	corrupted := []byte{3, 1, 0, 9, 9}
+	added, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: corrupted[:3], Size: 5})
+	require.NoError(t, err)
+	assert.False(t, added)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: corrupted[3:], Offset: 3, Size: 5})
+	require.ErrorIs(t, err, errChunkHashMismatch)
+	assert.False(t, queue.Has(1))
+
+	// Parts exceeding the chunk size are rejected
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: body, Size: 4})
+	require.Error(t, err)
+	assert.False(t, queue.Has(1))
+}
+
+func TestChunkQueue_NextFile(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	for i := uint32(0); i < queue.Size(); i++ {
+		_, err := queue.Add(&chunk{Height: 3, Format: 1, Index: i, Chunk: []byte{3, 1, byte(i)}, Sender: "a"})
+		require.NoError(t, err)
+	}
+
+	c, file, err := queue.NextFile()
+	require.NoError(t, err)
+	defer file.Close()
+	assert.Equal(t, &chunk{Height: 3, Format: 1, Index: 0, Sender: "a"}, c)
+
+	body, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 1, 0}, body)
+
+	c, err = queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, c.Index)
+}
+
 func TestChunkQueue_Allocate(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -0,0 +1,99 @@
+package statesync
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/tendermint/tendermint/config"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+)
+
+// chunkCompression returns the wire compression for a chunk-compression
+// config value.
+func chunkCompression(name string) ssproto.ChunkCompression {
+	switch name {
+	case config.ChunkCompressionGzip:
+		return ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP
+	default:
+		return ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE
+	}
+}
+
+// maxChunkSize returns the largest chunk, or chunk part when streamed, that we
+// accept in a single message from peers, which is bounded by the maximum
+// chunk message size.
+func maxChunkSize(cfg *config.StateSyncConfig) int {
+	if cfg.MaxChunkSize > 0 && cfg.MaxChunkSize < chunkMsgSize {
+		return cfg.MaxChunkSize
+	}
+	return chunkMsgSize
+}
+
+// chunkWindowSize returns the size of the parts chunks are streamed in, or 0
+// if chunk streaming is disabled. Parts must fit in a single message.
+func chunkWindowSize(cfg *config.StateSyncConfig) int {
+	if cfg.ChunkWindowSize > maxChunkSize(cfg) {
+		return maxChunkSize(cfg)
+	}
+	return cfg.ChunkWindowSize
+}
+
+// encodeChunk compresses a chunk for sending to a peer. It falls back to
+// sending the chunk uncompressed if the requested compression is unknown or
+// does not make the chunk any smaller.
+func encodeChunk(chunk []byte, compression ssproto.ChunkCompression) ([]byte, ssproto.ChunkCompression, error) {
+	switch compression {
+	case ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(chunk); err != nil {
+			return nil, 0, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, 0, err
+		}
+		if buf.Len() < len(chunk) {
+			return buf.Bytes(), compression, nil
+		}
+	}
+
+	return chunk, ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE, nil
+}
+
+// decodeChunk decompresses a chunk received from a peer. It returns an error
+// if the chunk is larger than maxSize bytes, either before or after
+// decompression.
+func decodeChunk(chunk []byte, compression ssproto.ChunkCompression, maxSize int) ([]byte, error) {
+	if len(chunk) > maxSize {
+		return nil, fmt.Errorf("chunk size %v exceeds maximum %v", len(chunk), maxSize)
+	}
+
+	switch compression {
+	case ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE:
+		return chunk, nil
+
+	case ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP:
+		r, err := gzip.NewReader(bytes.NewReader(chunk))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip chunk: %w", err)
+		}
+		defer r.Close()
+
+		// read one byte past the limit, so oversized chunks can be told apart
+		// from chunks of exactly maxSize bytes
+		bz, err := ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip chunk: %w", err)
+		}
+		if len(bz) > maxSize {
+			return nil, fmt.Errorf("decompressed chunk exceeds maximum size %v", maxSize)
+		}
+		return bz, nil
+
+	default:
+		return nil, fmt.Errorf("unknown chunk compression %v", compression)
+	}
+}
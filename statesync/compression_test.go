@@ -0,0 +1,77 @@
+package statesync
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+)
+
+func TestEncodeDecodeChunk(t *testing.T) {
+	compressible := bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+	random := []byte{0x8f, 0x1a, 0x77, 0x02, 0xe4}
+
+	testcases := map[string]struct {
+		chunk             []byte
+		compression       ssproto.ChunkCompression
+		expectCompression ssproto.ChunkCompression
+	}{
+		"uncompressed": {
+			compressible,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE,
+		},
+		"gzip": {
+			compressible,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP,
+		},
+		"gzip of incompressible chunk is sent uncompressed": {
+			random,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP,
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE,
+		},
+		"unknown compression is sent uncompressed": {
+			compressible,
+			ssproto.ChunkCompression(99),
+			ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE,
+		},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			encoded, compression, err := encodeChunk(tc.chunk, tc.compression)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectCompression, compression)
+
+			decoded, err := decodeChunk(encoded, compression, len(tc.chunk))
+			require.NoError(t, err)
+			require.Equal(t, tc.chunk, decoded)
+		})
+	}
+}
+
+func TestDecodeChunk_Errors(t *testing.T) {
+	chunk := bytes.Repeat([]byte{1}, 1024)
+	compressed, compression, err := encodeChunk(chunk, ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP)
+	require.NoError(t, err)
+	require.Equal(t, ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP, compression)
+
+	// chunk too large on the wire
+	_, err = decodeChunk(chunk, ssproto.ChunkCompression_CHUNK_COMPRESSION_NONE, len(chunk)-1)
+	require.Error(t, err)
+
+	// chunk too large once decompressed
+	_, err = decodeChunk(compressed, compression, len(chunk)-1)
+	require.Error(t, err)
+
+	// truncated chunk
+	_, err = decodeChunk(compressed[:len(compressed)/2], compression, len(chunk))
+	require.Error(t, err)
+
+	// unknown compression
+	_, err = decodeChunk(chunk, ssproto.ChunkCompression(99), len(chunk))
+	require.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
@@ -77,14 +78,17 @@ const (
 type Reactor struct {
 	service.BaseService
 
+	cfg         *config.StateSyncConfig
 	conn        proxy.AppConnSnapshot
 	connQuery   proxy.AppConnQuery
-	tempDir     string
 	snapshotCh  *p2p.Channel
 	chunkCh     *p2p.Channel
 	peerUpdates *p2p.PeerUpdates
 	closeCh     chan struct{}
 
+	// set if the application doesn't take snapshots, so that none are served
+	noServing bool
+
 	// This will only be set when a state sync is in progress. It is used to feed
 	// received snapshots and chunks into the sync.
 	mtx    tmsync.RWMutex
@@ -92,31 +96,39 @@ type Reactor struct {
 }
 
 // NewReactor returns a reference to a new state sync reactor, which implements
-// the service.Service interface. It accepts a logger, connections for snapshots
-// and querying, references to p2p Channels and a channel to listen for peer
-// updates on. Note, the reactor will close all p2p Channels when stopping.
+// the service.Service interface. It accepts a logger, the state sync config,
+// connections for snapshots and querying, references to p2p Channels and a
+// channel to listen for peer updates on. Note, the reactor will close all p2p
+// Channels when stopping.
 func NewReactor(
 	logger log.Logger,
+	cfg *config.StateSyncConfig,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	snapshotCh, chunkCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
-	tempDir string,
 ) *Reactor {
 	r := &Reactor{
+		cfg:         cfg,
 		conn:        conn,
 		connQuery:   connQuery,
 		snapshotCh:  snapshotCh,
 		chunkCh:     chunkCh,
 		peerUpdates: peerUpdates,
 		closeCh:     make(chan struct{}),
-		tempDir:     tempDir,
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
 	return r
 }
 
+// DisableServing stops the reactor from serving snapshots to peers, for
+// applications which advertise that they don't take any. It must be called
+// before the reactor is started.
+func (r *Reactor) DisableServing() {
+	r.noServing = true
+}
+
 // OnStart starts separate go routines for each p2p Channel and listens for
 // envelopes on each. In addition, it also listens for peer updates and handles
 // messages on that p2p channel accordingly. The caller must be sure to execute
@@ -162,6 +174,10 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 
 	switch msg := envelope.Message.(type) {
 	case *ssproto.SnapshotsRequest:
+		if r.noServing {
+			logger.Debug("ignoring snapshots request; the application doesn't take snapshots")
+			return nil
+		}
 		snapshots, err := r.recentSnapshots(recentSnapshots)
 		if err != nil {
 			logger.Error("failed to fetch snapshots", "err", err)
@@ -177,11 +193,13 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 			r.snapshotCh.Out <- p2p.Envelope{
 				To: envelope.From,
 				Message: &ssproto.SnapshotsResponse{
-					Height:   snapshot.Height,
-					Format:   snapshot.Format,
-					Chunks:   snapshot.Chunks,
-					Hash:     snapshot.Hash,
-					Metadata: snapshot.Metadata,
+					Height:      snapshot.Height,
+					Format:      snapshot.Format,
+					Chunks:      snapshot.Chunks,
+					Hash:        snapshot.Hash,
+					Metadata:    snapshot.Metadata,
+					ChunkHashes: snapshot.ChunkHashes,
+					BaseHeight:  snapshot.BaseHeight,
 				},
 			}
 		}
@@ -195,13 +213,15 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 			return nil
 		}
 
-		logger.Debug("received snapshot", "height", msg.Height, "format", msg.Format)
+		logger.Debug("received snapshot", "height", msg.Height, "format", msg.Format, "base_height", msg.BaseHeight)
 		_, err := r.syncer.AddSnapshot(envelope.From, &snapshot{
-			Height:   msg.Height,
-			Format:   msg.Format,
-			Chunks:   msg.Chunks,
-			Hash:     msg.Hash,
-			Metadata: msg.Metadata,
+			Height:      msg.Height,
+			Format:      msg.Format,
+			Chunks:      msg.Chunks,
+			Hash:        msg.Hash,
+			Metadata:    msg.Metadata,
+			ChunkHashes: msg.ChunkHashes,
+			BaseHeight:  msg.BaseHeight,
 		})
 		if err != nil {
 			logger.Error(
@@ -227,47 +247,19 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 	switch msg := envelope.Message.(type) {
 	case *ssproto.ChunkRequest:
-		r.Logger.Debug(
-			"received chunk request",
-			"height", msg.Height,
-			"format", msg.Format,
-			"chunk", msg.Index,
-			"peer", envelope.From,
-		)
-		resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
-			Height: msg.Height,
-			Format: msg.Format,
-			Chunk:  msg.Index,
-		})
-		if err != nil {
-			r.Logger.Error(
-				"failed to load chunk",
-				"height", msg.Height,
-				"format", msg.Format,
-				"chunk", msg.Index,
-				"err", err,
-				"peer", envelope.From,
-			)
+		if r.noServing {
+			r.Logger.Debug("ignoring chunk request; the application doesn't take snapshots",
+				"peer", envelope.From)
 			return nil
 		}
-
 		r.Logger.Debug(
-			"sending chunk",
+			"received chunk request",
 			"height", msg.Height,
 			"format", msg.Format,
 			"chunk", msg.Index,
 			"peer", envelope.From,
 		)
-		r.chunkCh.Out <- p2p.Envelope{
-			To: envelope.From,
-			Message: &ssproto.ChunkResponse{
-				Height:  msg.Height,
-				Format:  msg.Format,
-				Index:   msg.Index,
-				Chunk:   resp.Chunk,
-				Missing: resp.Chunk == nil,
-			},
-		}
+		r.sendChunk(envelope.From, msg)
 
 	case *ssproto.ChunkResponse:
 		r.mtx.RLock()
@@ -285,12 +277,45 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 			"chunk", msg.Index,
 			"peer", envelope.From,
 		)
+
+		if r.cfg.MaxChunkSize > 0 && msg.ChunkSize > uint64(r.cfg.MaxChunkSize) {
+			r.Logger.Error(
+				"received chunk exceeds maximum size",
+				"height", msg.Height,
+				"format", msg.Format,
+				"chunk", msg.Index,
+				"size", msg.ChunkSize,
+				"peer", envelope.From,
+			)
+			return nil
+		}
+
+		var bz []byte
+		if !msg.Missing {
+			var err error
+			bz, err = decodeChunk(msg.Chunk, msg.Compression, maxChunkSize(r.cfg))
+			if err != nil {
+				r.Logger.Error(
+					"failed to decode chunk",
+					"height", msg.Height,
+					"format", msg.Format,
+					"chunk", msg.Index,
+					"err", err,
+					"peer", envelope.From,
+				)
+				return nil
+			}
+		}
+
 		_, err := r.syncer.AddChunk(&chunk{
-			Height: msg.Height,
-			Format: msg.Format,
-			Index:  msg.Index,
-			Chunk:  msg.Chunk,
-			Sender: envelope.From,
+			Height:     msg.Height,
+			Format:     msg.Format,
+			Index:      msg.Index,
+			Chunk:      bz,
+			Offset:     msg.Offset,
+			Size:       msg.ChunkSize,
+			Sender:     envelope.From,
+			BaseHeight: msg.BaseHeight,
 		})
 		if err != nil {
 			r.Logger.Error(
@@ -311,6 +336,107 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// sendChunk loads a chunk from the application and sends it to a peer. If the
+// peer requested a window size and the application supports loading chunks in
+// windows, the chunk is streamed in parts of at most the window size, so that
+// it never has to be held in memory as a whole.
+func (r *Reactor) sendChunk(peerID p2p.NodeID, req *ssproto.ChunkRequest) {
+	logger := r.Logger.With("height", req.Height, "format", req.Format, "chunk", req.Index, "peer", peerID)
+
+	resp, err := r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+		Height:     req.Height,
+		Format:     req.Format,
+		Chunk:      req.Index,
+		Length:     req.WindowSize,
+		BaseHeight: req.BaseHeight,
+	})
+	if err != nil {
+		logger.Error("failed to load chunk", "err", err)
+		return
+	}
+
+	// The application either returned the whole chunk, or no window was
+	// requested.
+	if resp.ChunkSize == 0 {
+		r.sendChunkPart(logger, peerID, req, resp.Chunk, 0, 0)
+		return
+	}
+
+	if req.MaxChunkSize > 0 && resp.ChunkSize > uint64(req.MaxChunkSize) {
+		logger.Info("chunk exceeds requested maximum size", "size", resp.ChunkSize, "max", req.MaxChunkSize)
+		r.sendChunkPart(logger, peerID, req, nil, 0, 0)
+		return
+	}
+
+	var offset uint64
+	for {
+		if len(resp.Chunk) == 0 {
+			logger.Error("application returned empty chunk window", "offset", offset, "size", resp.ChunkSize)
+			return
+		}
+
+		r.sendChunkPart(logger, peerID, req, resp.Chunk, offset, resp.ChunkSize)
+
+		offset += uint64(len(resp.Chunk))
+		if offset >= resp.ChunkSize {
+			return
+		}
+
+		resp, err = r.conn.LoadSnapshotChunkSync(context.Background(), abci.RequestLoadSnapshotChunk{
+			Height:     req.Height,
+			Format:     req.Format,
+			Chunk:      req.Index,
+			Offset:     offset,
+			Length:     req.WindowSize,
+			BaseHeight: req.BaseHeight,
+		})
+		if err != nil {
+			logger.Error("failed to load chunk window", "offset", offset, "err", err)
+			return
+		}
+	}
+}
+
+// sendChunkPart sends a chunk, or a part of it when size is non-zero, to a
+// peer, compressing it as requested.
+func (r *Reactor) sendChunkPart(
+	logger log.Logger,
+	peerID p2p.NodeID,
+	req *ssproto.ChunkRequest,
+	chunk []byte,
+	offset, size uint64,
+) {
+	chunk, compression, err := encodeChunk(chunk, req.Compression)
+	if err != nil {
+		logger.Error("failed to compress chunk", "err", err)
+		return
+	}
+
+	// The peer won't accept a chunk larger than it asked for, so we report
+	// the chunk as missing rather than sending it and have it fetched from
+	// elsewhere.
+	if size == 0 && req.MaxChunkSize > 0 && len(chunk) > int(req.MaxChunkSize) {
+		logger.Info("chunk exceeds requested maximum size", "size", len(chunk), "max", req.MaxChunkSize)
+		chunk = nil
+	}
+
+	logger.Debug("sending chunk", "offset", offset, "size", size, "compression", compression)
+	r.chunkCh.Out <- p2p.Envelope{
+		To: peerID,
+		Message: &ssproto.ChunkResponse{
+			Height:      req.Height,
+			Format:      req.Format,
+			Index:       req.Index,
+			Chunk:       chunk,
+			Missing:     chunk == nil,
+			Compression: compression,
+			Offset:      offset,
+			ChunkSize:   size,
+			BaseHeight:  req.BaseHeight,
+		},
+	}
+}
+
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
@@ -454,11 +580,13 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 		}
 
 		snapshots = append(snapshots, &snapshot{
-			Height:   s.Height,
-			Format:   s.Format,
-			Chunks:   s.Chunks,
-			Hash:     s.Hash,
-			Metadata: s.Metadata,
+			Height:      s.Height,
+			Format:      s.Format,
+			Chunks:      s.Chunks,
+			Hash:        s.Hash,
+			Metadata:    s.Metadata,
+			ChunkHashes: s.ChunkHashes,
+			BaseHeight:  s.BaseHeight,
 		})
 	}
 
@@ -468,13 +596,27 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
+	return r.SyncDelta(stateProvider, 0, discoveryTime)
+}
+
+// SyncDelta runs a state sync like Sync, for an app whose state is already at baseHeight. Besides
+// full snapshots, it accepts delta snapshots of the changes since baseHeight, which are usually
+// much smaller, so that a node which was briefly offline can catch up quickly. Unlike Sync, it
+// gives up if no suitable snapshot was discovered after discoveryTime, since the node can catch
+// up from its local state instead.
+func (r *Reactor) SyncDelta(
+	stateProvider StateProvider,
+	baseHeight int64,
+	discoveryTime time.Duration,
+) (sm.State, *types.Commit, error) {
 	r.mtx.Lock()
 	if r.syncer != nil {
 		r.mtx.Unlock()
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 
-	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out, r.chunkCh.Out, r.tempDir)
+	r.syncer = newSyncer(r.Logger, r.cfg, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out, r.chunkCh.Out)
+	r.syncer.baseHeight = uint64(baseHeight)
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
@@ -84,24 +86,26 @@ func setup(
 		rts.chunkPeerErrCh,
 	)
 
+	cfg := config.DefaultStateSyncConfig()
+
 	rts.reactor = NewReactor(
 		log.NewNopLogger(),
+		cfg,
 		conn,
 		connQuery,
 		rts.snapshotChannel,
 		rts.chunkChannel,
 		rts.peerUpdates,
-		"",
 	)
 
 	rts.syncer = newSyncer(
 		log.NewNopLogger(),
+		cfg,
 		conn,
 		connQuery,
 		stateProvider,
 		rts.snapshotOutCh,
 		rts.chunkOutCh,
-		"",
 	)
 
 	require.NoError(t, rts.reactor.Start())
@@ -186,6 +190,91 @@ func TestReactor_ChunkRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_ChunkRequest_Compression(t *testing.T) {
+	chunk := bytes.Repeat([]byte{1, 2, 3}, 1000)
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("LoadSnapshotChunkSync", context.Background(), abci.RequestLoadSnapshotChunk{
+		Height: 1,
+		Format: 1,
+		Chunk:  1,
+	}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: chunk}, nil)
+
+	rts := setup(t, conn, nil, nil, 2)
+
+	// the chunk should be compressed when requested
+	rts.chunkInCh <- p2p.Envelope{
+		From: p2p.NodeID("aa"),
+		Message: &ssproto.ChunkRequest{
+			Height:      1,
+			Format:      1,
+			Index:       1,
+			Compression: ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP,
+		},
+	}
+
+	response := <-rts.chunkOutCh
+	msg, ok := response.Message.(*ssproto.ChunkResponse)
+	require.True(t, ok)
+	require.Equal(t, ssproto.ChunkCompression_CHUNK_COMPRESSION_GZIP, msg.Compression)
+	require.Less(t, len(msg.Chunk), len(chunk))
+
+	decoded, err := decodeChunk(msg.Chunk, msg.Compression, len(chunk))
+	require.NoError(t, err)
+	require.Equal(t, chunk, decoded)
+
+	// a chunk larger than the requested maximum should be reported as missing
+	rts.chunkInCh <- p2p.Envelope{
+		From:    p2p.NodeID("aa"),
+		Message: &ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1, MaxChunkSize: 100},
+	}
+
+	response = <-rts.chunkOutCh
+	require.Equal(t, &ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Missing: true}, response.Message)
+	require.Empty(t, rts.chunkOutCh)
+
+	conn.AssertExpectations(t)
+}
+
+func TestReactor_ChunkRequest_Stream(t *testing.T) {
+	chunk := []byte{1, 2, 3, 4, 5}
+
+	// mock ABCI connection to load the chunk in windows
+	conn := &proxymocks.AppConnSnapshot{}
+	for offset := 0; offset < len(chunk); offset += 2 {
+		end := offset + 2
+		if end > len(chunk) {
+			end = len(chunk)
+		}
+		conn.On("LoadSnapshotChunkSync", context.Background(), abci.RequestLoadSnapshotChunk{
+			Height: 1,
+			Format: 1,
+			Chunk:  1,
+			Offset: uint64(offset),
+			Length: 2,
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: chunk[offset:end], ChunkSize: uint64(len(chunk))}, nil)
+	}
+
+	rts := setup(t, conn, nil, nil, 2)
+
+	rts.chunkInCh <- p2p.Envelope{
+		From:    p2p.NodeID("aa"),
+		Message: &ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1, WindowSize: 2},
+	}
+
+	for _, expect := range []*ssproto.ChunkResponse{
+		{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2}, Offset: 0, ChunkSize: 5},
+		{Height: 1, Format: 1, Index: 1, Chunk: []byte{3, 4}, Offset: 2, ChunkSize: 5},
+		{Height: 1, Format: 1, Index: 1, Chunk: []byte{5}, Offset: 4, ChunkSize: 5},
+	} {
+		response := <-rts.chunkOutCh
+		require.Equal(t, expect, response.Message)
+	}
+	require.Empty(t, rts.chunkOutCh)
+
+	conn.AssertExpectations(t)
+}
+
 func TestReactor_SnapshotsRequest_InvalidRequest(t *testing.T) {
 	rts := setup(t, nil, nil, nil, 2)
 
@@ -270,6 +359,26 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_DisableServing(t *testing.T) {
+	// the app isn't asked for snapshots or chunks, the mock would fail if so
+	rts := setup(t, nil, nil, nil, 2)
+	rts.reactor.DisableServing()
+
+	rts.snapshotInCh <- p2p.Envelope{
+		From:    p2p.NodeID("aa"),
+		Message: &ssproto.SnapshotsRequest{},
+	}
+	rts.chunkInCh <- p2p.Envelope{
+		From:    p2p.NodeID("aa"),
+		Message: &ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
+	}
+
+	require.Never(t, func() bool {
+		return len(rts.snapshotOutCh) > 0 || len(rts.chunkOutCh) > 0 ||
+			len(rts.snapshotPeerErrCh) > 0 || len(rts.chunkPeerErrCh) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
 // retryUntil will continue to evaluate fn and will return successfully when true
 // or fail when the timeout is reached.
 func retryUntil(t *testing.T, fn func() bool, timeout time.Duration) {
@@ -3,7 +3,7 @@ package statesync
 import (
 	"context"
 	"crypto/sha256"
-	"fmt"
+		"fmt"
 	"math/rand"
 	"sort"
 	"strings"
@@ -24,23 +24,54 @@ type snapshot struct {
 	Hash     []byte
 	Metadata []byte
 
+	// ChunkHashes contains the SHA-256 hash of each chunk, if provided by the
+	// application. Chunks are verified against these before being applied.
+	ChunkHashes [][]byte
+
+	// BaseHeight is the height of the app state a delta snapshot applies on
+	// top of, or 0 for full snapshots.
+	BaseHeight uint64
+
 	trustedAppHash []byte // populated by light client
 }
 
 // Key generates a snapshot key, used for lookups. It takes into account not only the height and
-// format, but also the chunks, hash, and metadata in case peers have generated snapshots in a
-// non-deterministic manner. All fields must be equal for the snapshot to be considered the same.
+// format, but also the chunks, hash, metadata, chunk hashes and base height in case peers have
+// generated snapshots in a non-deterministic manner. All fields must be equal for the snapshot to
+// be considered the same.
 func (s *snapshot) Key() snapshotKey {
 	// Hash.Write() never returns an error.
 	hasher := sha256.New()
-	hasher.Write([]byte(fmt.Sprintf("%v:%v:%v", s.Height, s.Format, s.Chunks)))
+	hasher.Write([]byte(fmt.Sprintf("%v:%v:%v:%v", s.Height, s.Format, s.Chunks, s.BaseHeight)))
 	hasher.Write(s.Hash)
 	hasher.Write(s.Metadata)
+	for _, hash := range s.ChunkHashes {
+		hasher.Write(hash)
+	}
 	var key snapshotKey
 	copy(key[:], hasher.Sum(nil))
 	return key
 }
 
+// ValidateBasic performs basic validation of the snapshot.
+func (s *snapshot) ValidateBasic() error {
+	if s.BaseHeight >= s.Height && s.BaseHeight > 0 {
+		return fmt.Errorf("delta snapshot base height %v is not below its height %v", s.BaseHeight, s.Height)
+	}
+	if len(s.ChunkHashes) == 0 {
+		return nil
+	}
+	if len(s.ChunkHashes) != int(s.Chunks) {
+		return fmt.Errorf("snapshot has %v chunk hashes for %v chunks", len(s.ChunkHashes), s.Chunks)
+	}
+	for i, hash := range s.ChunkHashes {
+		if len(hash) != sha256.Size {
+			return fmt.Errorf("invalid hash size %v for chunk %v", len(hash), i)
+		}
+	}
+	return nil
+}
+
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	stateProvider StateProvider
@@ -171,8 +202,8 @@ func (p *snapshotPool) GetPeers(snapshot *snapshot) []p2p.NodeID {
 }
 
 // Ranked returns a list of snapshots ranked by preference. The current heuristic is very naïve,
-// preferring the snapshot with the greatest height, then greatest format, then greatest number of
-// peers. This can be improved quite a lot.
+// preferring the snapshot with the greatest height, then delta snapshots, which are smaller, then
+// greatest format, then greatest number of peers. This can be improved quite a lot.
 func (p *snapshotPool) Ranked() []*snapshot {
 	p.Lock()
 	defer p.Unlock()
@@ -220,6 +251,10 @@ func (p *snapshotPool) sorterFactory(candidates []*snapshot) func(int, int) bool
 			return true
 		case a.Height < b.Height:
 			return false
+		case a.BaseHeight > b.BaseHeight:
+			return true
+		case a.BaseHeight < b.BaseHeight:
+			return false
 		case len(p.snapshotPeers[a.Key()]) > len(p.snapshotPeers[b.Key()]):
 			return true
 		case a.Format > b.Format:
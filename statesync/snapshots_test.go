@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"crypto/sha256"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -19,6 +20,8 @@ func TestSnapshot_Key(t *testing.T) {
 		"new chunk count": {func(s *snapshot) { s.Chunks = 9 }},
 		"new hash":        {func(s *snapshot) { s.Hash = []byte{9} }},
 		"no metadata":     {func(s *snapshot) { s.Metadata = nil }},
+		"chunk hashes":    {func(s *snapshot) { s.ChunkHashes = [][]byte{{9}} }},
+		"base height":     {func(s *snapshot) { s.BaseHeight = 2 }},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -38,6 +41,43 @@ func TestSnapshot_Key(t *testing.T) {
 	}
 }
 
+func TestSnapshot_ValidateBasic(t *testing.T) {
+	hash := make([]byte, sha256.Size)
+	testcases := map[string]struct {
+		chunkHashes [][]byte
+		expectErr   bool
+	}{
+		"no chunk hashes":       {nil, false},
+		"valid chunk hashes":    {[][]byte{hash, hash}, false},
+		"too few chunk hashes":  {[][]byte{hash}, true},
+		"too many chunk hashes": {[][]byte{hash, hash, hash}, true},
+		"invalid hash size":     {[][]byte{hash, {1, 2, 3}}, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := snapshot{Height: 3, Format: 1, Chunks: 2, ChunkHashes: tc.chunkHashes}
+			err := s.ValidateBasic()
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSnapshot_ValidateBasic_BaseHeight(t *testing.T) {
+	s := snapshot{Height: 3, Format: 1, Chunks: 2, BaseHeight: 2}
+	require.NoError(t, s.ValidateBasic())
+
+	s.BaseHeight = 3
+	require.Error(t, s.ValidateBasic())
+
+	s.BaseHeight = 4
+	require.Error(t, s.ValidateBasic())
+}
+
 func TestSnapshotPool_Add(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return([]byte("app_hash"), nil)
@@ -181,6 +221,23 @@ func TestSnapshotPool_Ranked_Best(t *testing.T) {
 	require.Nil(t, pool.Best())
 }
 
+func TestSnapshotPool_Ranked_Delta(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	pool := newSnapshotPool(stateProvider)
+
+	// delta snapshots are preferred at the same height, but not over greater heights
+	full := &snapshot{Height: 5, Format: 1, Chunks: 4, Hash: []byte{1}}
+	delta := &snapshot{Height: 5, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 3}
+	higher := &snapshot{Height: 6, Format: 1, Chunks: 4, Hash: []byte{3}}
+	for _, s := range []*snapshot{full, delta, higher} {
+		_, err := pool.Add("AA", s)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []*snapshot{higher, delta, full}, pool.Ranked())
+}
+
 func TestSnapshotPool_Reject(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
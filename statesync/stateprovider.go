@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -40,6 +41,27 @@ type lightClientStateProvider struct {
 	version       sm.Version
 	initialHeight int64
 	providers     map[lightprovider.Provider]string
+	logger        log.Logger
+
+	// witnesses which must independently serve the verified header at the
+	// height after a snapshot before its app hash is trusted
+	witnesses     []lightprovider.Provider
+	witnessQuorum int
+}
+
+// StateProviderOption sets an optional parameter on the light client state
+// provider.
+type StateProviderOption func(*lightClientStateProvider)
+
+// WitnessQuorum sets the number of witnesses, i.e. the RPC servers besides the
+// primary, which must serve the same header as the one verified by the light
+// client at the height after a snapshot before its app hash is trusted. A
+// witness serving a different header rejects the snapshot. 0 (default) only
+// relies on light client verification.
+func WitnessQuorum(quorum int) StateProviderOption {
+	return func(s *lightClientStateProvider) {
+		s.witnessQuorum = quorum
+	}
 }
 
 // NewLightClientStateProvider creates a new StateProvider using a light client and RPC clients.
@@ -51,6 +73,7 @@ func NewLightClientStateProvider(
 	servers []string,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
+	options ...StateProviderOption,
 ) (StateProvider, error) {
 	if len(servers) < 2 {
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(servers))
@@ -70,17 +93,28 @@ func NewLightClientStateProvider(
 		providerRemotes[provider] = server
 	}
 
+	s := &lightClientStateProvider{
+		version:       version,
+		initialHeight: initialHeight,
+		providers:     providerRemotes,
+		logger:        logger,
+		witnesses:     providers[1:],
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.witnessQuorum < 0 || s.witnessQuorum > len(s.witnesses) {
+		return nil, fmt.Errorf("witness quorum must be between 0 and the %d witnesses, got %d",
+			len(s.witnesses), s.witnessQuorum)
+	}
+
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
 		lightdb.New(dbm.NewMemDB()), light.Logger(logger))
 	if err != nil {
 		return nil, err
 	}
-	return &lightClientStateProvider{
-		lc:            lc,
-		version:       version,
-		initialHeight: initialHeight,
-		providers:     providerRemotes,
-	}, nil
+	s.lc = lc
+	return s, nil
 }
 
 // AppHash implements StateProvider.
@@ -109,9 +143,45 @@ func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) (
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkWitnesses(ctx, header); err != nil {
+		return nil, err
+	}
 	return header.AppHash, nil
 }
 
+// checkWitnesses checks that at least witnessQuorum witnesses serve the same
+// header as the verified light block. All witnesses are asked: the ones which
+// are unreachable or don't have the block yet don't count towards the quorum,
+// while a witness serving a different header fails the check.
+func (s *lightClientStateProvider) checkWitnesses(ctx context.Context, verified *types.LightBlock) error {
+	if s.witnessQuorum == 0 {
+		return nil
+	}
+
+	var (
+		hash      = verified.Hash()
+		confirmed = 0
+	)
+	for _, witness := range s.witnesses {
+		lb, err := witness.LightBlock(ctx, verified.Height)
+		if err != nil {
+			s.logger.Info("Witness failed to serve the header of a snapshot",
+				"witness", witness, "height", verified.Height, "err", err)
+			continue
+		}
+		if !bytes.Equal(lb.Hash(), hash) {
+			return fmt.Errorf("witness %v serves header %X at height %d, which differs from the verified %X",
+				witness, lb.Hash(), verified.Height, hash)
+		}
+		confirmed++
+	}
+	if confirmed < s.witnessQuorum {
+		return fmt.Errorf("only %d witnesses confirmed the header at height %d, %d required",
+			confirmed, verified.Height, s.witnessQuorum)
+	}
+	return nil
+}
+
 // Commit implements StateProvider.
 func (s *lightClientStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
 	s.Lock()
@@ -168,7 +238,8 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.NextValidators = nextLightBlock.ValidatorSet
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
 
-	// We'll also need to fetch consensus params via RPC, using light client verification.
+	// We'll also need to fetch consensus params, and the updates scheduled at
+	// later heights, via RPC, using light client verification.
 	primaryURL, ok := s.providers[s.lc.Primary()]
 	if !ok || primaryURL == "" {
 		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
@@ -184,6 +255,7 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 			nextLightBlock.Height, err)
 	}
 	state.ConsensusParams = result.ConsensusParams
+	state.ScheduledConsensusParams = result.ScheduledConsensusParams
 	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
 
 	return state, nil
@@ -0,0 +1,199 @@
+package statesync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/provider/mock"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// makeLightBlock returns a light block at the given height, signed by a new
+// validator set.
+func makeLightBlock(t *testing.T, height int64) *types.LightBlock {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	header, err := factory.MakeHeader(&types.Header{
+		Height:             height,
+		Time:               time.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		ProposerAddress:    vals.Proposer.Address,
+	})
+	require.NoError(t, err)
+
+	blockID := factory.MakeBlockIDWithHash(header.Hash())
+	voteSet := types.NewVoteSet(header.ChainID, height, 0, tmproto.PrecommitType, vals)
+	commit, err := factory.MakeCommit(blockID, height, 0, voteSet, privVals, header.Time)
+	require.NoError(t, err)
+
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
+func witnessServing(id string, lb *types.LightBlock) *mock.Mock {
+	return mock.New(id,
+		map[int64]*types.SignedHeader{lb.Height: lb.SignedHeader},
+		map[int64]*types.ValidatorSet{lb.Height: lb.ValidatorSet})
+}
+
+func TestLightClientStateProvider_CheckWitnesses(t *testing.T) {
+	ctx := context.Background()
+	verified := makeLightBlock(t, 10)
+	var (
+		agreeing = witnessServing("agreeing", verified)
+		dead     = mock.NewDeadMock("dead")
+		forked   = witnessServing("forked", makeLightBlock(t, 10))
+	)
+
+	testcases := map[string]struct {
+		witnesses []lightprovider.Provider
+		quorum    int
+		expectErr bool
+	}{
+		"no quorum":                 {[]lightprovider.Provider{forked}, 0, false},
+		"quorum met":                {[]lightprovider.Provider{agreeing, dead, agreeing}, 2, false},
+		"unreachable witness":       {[]lightprovider.Provider{agreeing, dead}, 2, true},
+		"witness serving a fork":    {[]lightprovider.Provider{agreeing, agreeing, forked}, 2, true},
+		"witness lacking the block": {[]lightprovider.Provider{witnessServing("behind", makeLightBlock(t, 9))}, 1, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := &lightClientStateProvider{
+				logger:        log.TestingLogger(),
+				witnesses:     tc.witnesses,
+				witnessQuorum: tc.quorum,
+			}
+			err := s.checkWitnesses(ctx, verified)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// makeChain returns the signed headers and validator sets of the heights 1 to
+// height of a chain signed by a single validator set, whose headers commit to
+// the given consensus hash.
+func makeChain(
+	t *testing.T, height int64, consensusHash []byte,
+) (map[int64]*types.SignedHeader, map[int64]*types.ValidatorSet) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	headers := make(map[int64]*types.SignedHeader, height)
+	valSets := make(map[int64]*types.ValidatorSet, height)
+	start := time.Now().Add(-time.Hour)
+	for h := int64(1); h <= height; h++ {
+		header, err := factory.MakeHeader(&types.Header{
+			Height:             h,
+			Time:               start.Add(time.Duration(h) * time.Minute),
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ConsensusHash:      consensusHash,
+			ProposerAddress:    vals.Proposer.Address,
+		})
+		require.NoError(t, err)
+
+		blockID := factory.MakeBlockIDWithHash(header.Hash())
+		voteSet := types.NewVoteSet(header.ChainID, h, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, h, 0, voteSet, privVals, header.Time)
+		require.NoError(t, err)
+
+		headers[h] = &types.SignedHeader{Header: header, Commit: commit}
+		valSets[h] = vals
+	}
+	return headers, valSets
+}
+
+// serveConsensusParams serves the /consensus_params RPC endpoint, returning the
+// given params and scheduled updates at every height.
+func serveConsensusParams(
+	t *testing.T, params types.ConsensusParams, scheduled []abci.ScheduledConsensusParams,
+) *httptest.Server {
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, map[string]*rpcserver.RPCFunc{
+		"consensus_params": rpcserver.NewRPCFunc(
+			func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+				return &ctypes.ResultConsensusParams{
+					BlockHeight:              *height,
+					ConsensusParams:          params,
+					ScheduledConsensusParams: scheduled,
+				}, nil
+			}, "height", false),
+	}, log.TestingLogger())
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLightClientStateProvider_ScheduledConsensusParams(t *testing.T) {
+	ctx := context.Background()
+
+	// the snapshot is taken at height 3, before the scheduled update activates
+	params := *types.DefaultConsensusParams()
+	scheduled := []abci.ScheduledConsensusParams{{
+		Params:           &tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 1024, MaxGas: 10}},
+		ActivationHeight: 10,
+	}}
+	consensusHash := params.HashConsensusParamsWithSchedule(scheduled)
+	headers, vals := makeChain(t, 5, consensusHash)
+
+	testcases := map[string]struct {
+		served    []abci.ScheduledConsensusParams
+		expectErr bool
+	}{
+		"scheduled updates served":  {scheduled, false},
+		"scheduled updates omitted": {nil, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			primary := mock.New("primary", headers, vals)
+			lc, err := light.NewClient(ctx, factory.DefaultTestChainID,
+				light.TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: headers[1].Hash()},
+				primary, []lightprovider.Provider{mock.New("witness", headers, vals)},
+				lightdb.New(dbm.NewMemDB()), light.Logger(log.TestingLogger()))
+			require.NoError(t, err)
+
+			srv := serveConsensusParams(t, params, tc.served)
+			s := &lightClientStateProvider{
+				lc:        lc,
+				providers: map[lightprovider.Provider]string{primary: srv.URL},
+				logger:    log.TestingLogger(),
+			}
+
+			state, err := s.State(ctx, 3)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, params, state.ConsensusParams)
+			assert.Equal(t, scheduled, state.ScheduledConsensusParams)
+
+			// the restored state validates the next block's consensus hash
+			assert.Equal(t, []byte(headers[4].ConsensusHash),
+				state.ConsensusParams.HashConsensusParamsWithSchedule(state.ScheduledConsensusParams))
+		})
+	}
+}
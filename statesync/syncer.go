@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
@@ -52,6 +54,7 @@ var (
 // sync all snapshots in the pool (pausing to discover new ones), or Sync() to sync a specific
 // snapshot. Snapshots and chunks are fed via AddSnapshot() and AddChunk() as appropriate.
 type syncer struct {
+	cfg           *config.StateSyncConfig
 	logger        log.Logger
 	stateProvider StateProvider
 	conn          proxy.AppConnSnapshot
@@ -59,7 +62,10 @@ type syncer struct {
 	snapshots     *snapshotPool
 	snapshotCh    chan<- p2p.Envelope
 	chunkCh       chan<- p2p.Envelope
-	tempDir       string
+
+	// baseHeight is the height of the local app state, if any. Delta snapshots
+	// are only accepted if they apply on top of it.
+	baseHeight uint64
 
 	mtx    tmsync.RWMutex
 	chunks *chunkQueue
@@ -68,13 +74,14 @@ type syncer struct {
 // newSyncer creates a new syncer.
 func newSyncer(
 	logger log.Logger,
+	cfg *config.StateSyncConfig,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	stateProvider StateProvider,
 	snapshotCh, chunkCh chan<- p2p.Envelope,
-	tempDir string,
 ) *syncer {
 	return &syncer{
+		cfg:           cfg,
 		logger:        logger,
 		stateProvider: stateProvider,
 		conn:          conn,
@@ -82,12 +89,12 @@ func newSyncer(
 		snapshots:     newSnapshotPool(stateProvider),
 		snapshotCh:    snapshotCh,
 		chunkCh:       chunkCh,
-		tempDir:       tempDir,
 	}
 }
 
 // AddChunk adds a chunk to the chunk queue, if any. It returns false if the chunk has already
-// been added to the queue, or an error if there's no sync in progress.
+// been added to the queue, or an error if there's no sync in progress. Senders of chunks that
+// don't match the snapshot's chunk hashes are rejected.
 func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
@@ -95,12 +102,19 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, errors.New("no state sync in progress")
 	}
 	added, err := s.chunks.Add(chunk)
+	if errors.Is(err, errChunkHashMismatch) {
+		s.logger.Info("Rejecting sender of corrupted chunk", "peer", chunk.Sender, "chunk", chunk.Index)
+		s.snapshots.RejectPeer(chunk.Sender)
+	}
 	if err != nil {
 		return false, err
 	}
 	if added {
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
+	} else if chunk.Size > 0 {
+		s.logger.Debug("Added chunk part to queue", "height", chunk.Height, "format", chunk.Format,
+			"chunk", chunk.Index, "offset", chunk.Offset, "size", chunk.Size)
 	} else {
 		s.logger.Debug("Ignoring duplicate chunk in queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
@@ -111,13 +125,21 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 // AddSnapshot adds a snapshot to the snapshot pool. It returns true if a new, previously unseen
 // snapshot was accepted and added.
 func (s *syncer) AddSnapshot(peerID p2p.NodeID, snapshot *snapshot) (bool, error) {
+	if err := snapshot.ValidateBasic(); err != nil {
+		return false, err
+	}
+	if snapshot.BaseHeight > 0 && snapshot.BaseHeight != s.baseHeight {
+		s.logger.Debug("Ignoring delta snapshot for another base height", "height", snapshot.Height,
+			"format", snapshot.Format, "base_height", snapshot.BaseHeight)
+		return false, nil
+	}
 	added, err := s.snapshots.Add(peerID, snapshot)
 	if err != nil {
 		return false, err
 	}
 	if added {
 		s.logger.Info("Discovered new snapshot", "height", snapshot.Height, "format", snapshot.Format,
-			"hash", snapshot.Hash)
+			"hash", snapshot.Hash, "base_height", snapshot.BaseHeight)
 	}
 	return added, nil
 }
@@ -139,8 +161,9 @@ func (s *syncer) RemovePeer(peerID p2p.NodeID) {
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
-// snapshots if none were found and discoveryTime > 0. It returns the latest state and block commit
-// which the caller must use to bootstrap the node.
+// snapshots if none were found and discoveryTime > 0, unless delta snapshots are synced on local
+// app state. It returns the latest state and block commit which the caller must use to bootstrap
+// the node.
 func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.State, *types.Commit, error) {
 	if discoveryTime != 0 && discoveryTime < minimumDiscoveryTime {
 		discoveryTime = 5 * minimumDiscoveryTime
@@ -165,7 +188,9 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			chunks = nil
 		}
 		if snapshot == nil {
-			if discoveryTime == 0 {
+			// A node with local state can catch up without snapshots, so only a single
+			// discovery round is spent on delta syncs.
+			if discoveryTime == 0 || s.baseHeight > 0 {
 				return sm.State{}, nil, errNoSnapshots
 			}
 			retryHook()
@@ -174,7 +199,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			continue
 		}
 		if chunks == nil {
-			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			chunks, err = newChunkQueue(snapshot, s.cfg.TempDir)
 			if err != nil {
 				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
@@ -248,7 +273,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}()
 
 	// Offer snapshot to ABCI app.
-	err := s.offerSnapshot(snapshot)
+	stream, err := s.offerSnapshot(snapshot)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -274,7 +299,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Restore snapshot
-	err = s.applyChunks(chunks)
+	err = s.applyChunks(chunks, stream)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -294,59 +319,64 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 }
 
 // offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
-// response, or nil if the snapshot was accepted.
-func (s *syncer) offerSnapshot(snapshot *snapshot) error {
+// response, or nil if the snapshot was accepted, along with whether the app accepts chunks
+// streamed in parts.
+func (s *syncer) offerSnapshot(snapshot *snapshot) (bool, error) {
 	s.logger.Info("Offering snapshot to ABCI app", "height", snapshot.Height,
-		"format", snapshot.Format, "hash", snapshot.Hash)
+		"format", snapshot.Format, "hash", snapshot.Hash, "base_height", snapshot.BaseHeight)
 	resp, err := s.conn.OfferSnapshotSync(context.Background(), abci.RequestOfferSnapshot{
 		Snapshot: &abci.Snapshot{
-			Height:   snapshot.Height,
-			Format:   snapshot.Format,
-			Chunks:   snapshot.Chunks,
-			Hash:     snapshot.Hash,
-			Metadata: snapshot.Metadata,
+			Height:      snapshot.Height,
+			Format:      snapshot.Format,
+			Chunks:      snapshot.Chunks,
+			Hash:        snapshot.Hash,
+			Metadata:    snapshot.Metadata,
+			ChunkHashes: snapshot.ChunkHashes,
+			BaseHeight:  snapshot.BaseHeight,
 		},
 		AppHash: snapshot.trustedAppHash,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to offer snapshot: %w", err)
+		return false, fmt.Errorf("failed to offer snapshot: %w", err)
 	}
 	switch resp.Result {
 	case abci.ResponseOfferSnapshot_ACCEPT:
 		s.logger.Info("Snapshot accepted, restoring", "height", snapshot.Height,
-			"format", snapshot.Format, "hash", snapshot.Hash)
-		return nil
+			"format", snapshot.Format, "hash", snapshot.Hash, "stream", resp.StreamChunks)
+		return resp.StreamChunks, nil
 	case abci.ResponseOfferSnapshot_ABORT:
-		return errAbort
+		return false, errAbort
 	case abci.ResponseOfferSnapshot_REJECT:
-		return errRejectSnapshot
+		return false, errRejectSnapshot
 	case abci.ResponseOfferSnapshot_REJECT_FORMAT:
-		return errRejectFormat
+		return false, errRejectFormat
 	case abci.ResponseOfferSnapshot_REJECT_SENDER:
-		return errRejectSender
+		return false, errRejectSender
 	default:
-		return fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
+		return false, fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
 	}
 }
 
-// applyChunks applies chunks to the app. It returns various errors depending on the app's
-// response, or nil once the snapshot is fully restored.
-func (s *syncer) applyChunks(chunks *chunkQueue) error {
+// applyChunks applies chunks to the app, streaming them in parts if the app supports it. It
+// returns various errors depending on the app's response, or nil once the snapshot is fully
+// restored.
+func (s *syncer) applyChunks(chunks *chunkQueue, stream bool) error {
+	stream = stream && chunkWindowSize(s.cfg) > 0
 	for {
-		chunk, err := chunks.Next()
+		var (
+			chunk *chunk
+			resp  *abci.ResponseApplySnapshotChunk
+			err   error
+		)
+		if stream {
+			chunk, resp, err = s.applyChunkStream(chunks)
+		} else {
+			chunk, resp, err = s.applyChunk(chunks)
+		}
 		if err == errDone {
 			return nil
 		} else if err != nil {
-			return fmt.Errorf("failed to fetch chunk: %w", err)
-		}
-
-		resp, err := s.conn.ApplySnapshotChunkSync(context.Background(), abci.RequestApplySnapshotChunk{
-			Index:  chunk.Index,
-			Chunk:  chunk.Chunk,
-			Sender: string(chunk.Sender),
-		})
-		if err != nil {
-			return fmt.Errorf("failed to apply chunk %v: %w", chunk.Index, err)
+			return err
 		}
 		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
 			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
@@ -387,6 +417,74 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 	}
 }
 
+// applyChunk applies the next chunk to the app as a whole.
+func (s *syncer) applyChunk(chunks *chunkQueue) (*chunk, *abci.ResponseApplySnapshotChunk, error) {
+	chunk, err := chunks.Next()
+	if err == errDone {
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch chunk: %w", err)
+	}
+
+	resp, err := s.conn.ApplySnapshotChunkSync(context.Background(), abci.RequestApplySnapshotChunk{
+		Index:  chunk.Index,
+		Chunk:  chunk.Chunk,
+		Sender: string(chunk.Sender),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply chunk %v: %w", chunk.Index, err)
+	}
+	return chunk, resp, nil
+}
+
+// applyChunkStream applies the next chunk to the app in parts of the configured window size,
+// reading them from disk such that the chunk is never held in memory as a whole. It returns the
+// app's response to the last part, or to the first part that was not accepted.
+func (s *syncer) applyChunkStream(chunks *chunkQueue) (*chunk, *abci.ResponseApplySnapshotChunk, error) {
+	chunk, file, err := chunks.NextFile()
+	if err == errDone {
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch chunk: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch chunk %v: %w", chunk.Index, err)
+	}
+	size := uint64(info.Size())
+	window := uint64(chunkWindowSize(s.cfg))
+
+	var offset uint64
+	for {
+		n := size - offset
+		if n > window {
+			n = window
+		}
+		part := make([]byte, n)
+		if _, err := io.ReadFull(file, part); err != nil {
+			return nil, nil, fmt.Errorf("failed to read chunk %v: %w", chunk.Index, err)
+		}
+
+		resp, err := s.conn.ApplySnapshotChunkSync(context.Background(), abci.RequestApplySnapshotChunk{
+			Index:     chunk.Index,
+			Chunk:     part,
+			Sender:    string(chunk.Sender),
+			Offset:    offset,
+			ChunkSize: size,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to apply chunk %v: %w", chunk.Index, err)
+		}
+
+		offset += uint64(len(part))
+		if offset >= size || resp.Result != abci.ResponseApplySnapshotChunk_ACCEPT {
+			return chunk, resp, nil
+		}
+	}
+}
+
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
 // will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
@@ -444,9 +542,13 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	s.chunkCh <- p2p.Envelope{
 		To: peer,
 		Message: &ssproto.ChunkRequest{
-			Height: snapshot.Height,
-			Format: snapshot.Format,
-			Index:  chunk,
+			Height:       snapshot.Height,
+			Format:       snapshot.Format,
+			Index:        chunk,
+			Compression:  chunkCompression(s.cfg.ChunkCompression),
+			MaxChunkSize: uint32(s.cfg.MaxChunkSize),
+			WindowSize:   uint32(chunkWindowSize(s.cfg)),
+			BaseHeight:   snapshot.BaseHeight,
 		},
 	}
 }
@@ -2,6 +2,7 @@ package statesync
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"sync"
 	"testing"
@@ -210,6 +211,29 @@ func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	require.Equal(t, errNoSnapshots, err)
 }
 
+func TestSyncer_AddSnapshot_Delta(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+
+	rts := setup(t, nil, nil, stateProvider, 2)
+	rts.syncer.baseHeight = 3
+
+	// delta snapshots are only accepted on top of the local app state
+	added, err := rts.syncer.AddSnapshot("aa", &snapshot{Height: 5, Format: 1, Chunks: 1, BaseHeight: 2})
+	require.NoError(t, err)
+	require.False(t, added)
+
+	added, err = rts.syncer.AddSnapshot("aa", &snapshot{Height: 5, Format: 1, Chunks: 1, BaseHeight: 3})
+	require.NoError(t, err)
+	require.True(t, added)
+
+	added, err = rts.syncer.AddSnapshot("aa", &snapshot{Height: 5, Format: 1, Chunks: 3})
+	require.NoError(t, err)
+	require.True(t, added)
+
+	require.EqualValues(t, 3, rts.syncer.snapshots.Best().BaseHeight)
+}
+
 func TestSyncer_SyncAny_abort(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
@@ -351,6 +375,36 @@ func TestSyncer_SyncAny_reject_sender(t *testing.T) {
 	rts.conn.AssertExpectations(t)
 }
 
+func TestSyncer_AddChunk_RejectsCorruptedChunkSender(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+
+	rts := setup(t, nil, nil, stateProvider, 2)
+
+	peerID := p2p.NodeID("aa")
+	hash := sha256.Sum256([]byte{1, 1, 0})
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}, ChunkHashes: [][]byte{hash[:]}}
+
+	added, err := rts.syncer.AddSnapshot(peerID, s)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	chunks, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, chunks.Close())
+	}()
+	rts.syncer.chunks = chunks
+
+	_, err = rts.syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1}, Sender: peerID})
+	require.ErrorIs(t, err, errChunkHashMismatch)
+	require.Empty(t, rts.syncer.snapshots.GetPeers(s))
+
+	// snapshots with the wrong number of chunk hashes are not accepted
+	_, err = rts.syncer.AddSnapshot(peerID, &snapshot{Height: 2, Format: 1, Chunks: 2, ChunkHashes: [][]byte{hash[:]}})
+	require.Error(t, err)
+}
+
 func TestSyncer_SyncAny_abciError(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
@@ -406,7 +460,7 @@ func TestSyncer_offerSnapshot(t *testing.T) {
 				AppHash:  []byte("app_hash"),
 			}).Return(&abci.ResponseOfferSnapshot{Result: tc.result}, tc.err)
 
-			err := rts.syncer.offerSnapshot(s)
+			_, err := rts.syncer.offerSnapshot(s)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -462,7 +516,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 					Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
 			}
 
-			err = rts.syncer.applyChunks(chunks)
+			err = rts.syncer.applyChunks(chunks, false)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -478,6 +532,44 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 	}
 }
 
+func TestSyncer_applyChunks_Stream(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+
+	rts := setup(t, nil, nil, stateProvider, 2)
+	rts.syncer.cfg.ChunkWindowSize = 2
+
+	chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 2}, "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, chunks.Close())
+	}()
+	_, err = chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 2, 3, 4, 5}, Sender: "a"})
+	require.NoError(t, err)
+	_, err = chunks.Add(&chunk{Height: 1, Format: 1, Index: 1, Chunk: []byte{6, 7, 8}, Sender: "a"})
+	require.NoError(t, err)
+
+	// chunk 0 is applied in windows of 2 bytes
+	for _, req := range []abci.RequestApplySnapshotChunk{
+		{Index: 0, Chunk: []byte{1, 2}, Sender: "a", Offset: 0, ChunkSize: 5},
+		{Index: 0, Chunk: []byte{3, 4}, Sender: "a", Offset: 2, ChunkSize: 5},
+		{Index: 0, Chunk: []byte{5}, Sender: "a", Offset: 4, ChunkSize: 5},
+	} {
+		rts.conn.On("ApplySnapshotChunkSync", ctx, req).Once().Return(&abci.ResponseApplySnapshotChunk{
+			Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+
+	// the app rejecting a part of chunk 1 stops the stream
+	rts.conn.On("ApplySnapshotChunkSync", ctx, abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: []byte{6, 7}, Sender: "a", Offset: 0, ChunkSize: 3,
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{
+		Result: abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}, nil)
+
+	err = rts.syncer.applyChunks(chunks, true)
+	require.Equal(t, errRejectSnapshot, err)
+	rts.conn.AssertExpectations(t)
+}
+
 func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 	// Discarding chunks via refetch_chunks should work the same for all results
 	testcases := map[string]struct {
@@ -527,7 +619,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			// check the queue contents, and finally close the queue to end the goroutine.
 			// We don't really care about the result of applyChunks, since it has separate test.
 			go func() {
-				rts.syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				rts.syncer.applyChunks(chunks, false) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -626,7 +718,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			// However, it will block on e.g. retry result, so we spawn a goroutine that will
 			// be shut down when the chunk queue closes.
 			go func() {
-				rts.syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				rts.syncer.applyChunks(chunks, false) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -2,13 +2,19 @@ package store
 
 import (
 	"bytes"
+	"compress/gzip"
+	"container/list"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
 	dbm "github.com/tendermint/tm-db"
 
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -16,10 +22,13 @@ import (
 /*
 BlockStore is a simple low level store for blocks.
 
-There are three types of information stored:
+There are four types of information stored:
  - BlockMeta:   Meta information about each block
- - Block part:  Parts of each block, aggregated w/ PartSet
+ - Block:       Each block as a single compressed value, along with the size
+                of its parts, from which the parts are rebuilt on demand. Stores
+                written by older versions have one value per part instead.
  - Commit:      The commit part of each block, for gossiping precommit votes
+ - Hash index:  The height of each block and the location of each tx by hash
 
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
@@ -32,12 +41,56 @@ The store can be assumed to contain all contiguous blocks between base and heigh
 */
 type BlockStore struct {
 	db dbm.DB
+
+	syncPolicy   SyncPolicy
+	syncInterval time.Duration
+
+	mtx      tmsync.Mutex
+	lastSync time.Time // guarded by mtx
+
+	// the parts of the last blocks whose parts were loaded, since peers
+	// catching up request all the parts of a block one by one
+	parts *partSetCache
+}
+
+// SyncPolicy defines when the block store flushes the saved blocks to disk.
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes every saved block to disk before returning.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval flushes a saved block to disk if the last flush is older
+	// than the sync interval. Blocks saved in between are flushed along with
+	// the next flush.
+	SyncInterval
+	// SyncNever leaves flushing to the OS.
+	SyncNever
+)
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithSyncPolicy sets when the saved blocks are flushed to disk. The interval
+// is only used by SyncInterval. The default is SyncAlways.
+func WithSyncPolicy(policy SyncPolicy, interval time.Duration) BlockStoreOption {
+	return func(bs *BlockStore) {
+		bs.syncPolicy = policy
+		bs.syncInterval = interval
+	}
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	return &BlockStore{db}
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bs := &BlockStore{
+		db:         db,
+		syncPolicy: SyncAlways,
+		parts:      newPartSetCache(partSetCacheSize),
+	}
+	for _, option := range options {
+		option(bs)
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -134,17 +187,22 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 		return nil
 	}
 
-	pbb := new(tmproto.Block)
-	buf := []byte{}
-	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
-		part := bs.LoadBlockPart(height, i)
-		// If the part is missing (e.g. since it has been deleted after we
-		// loaded the block meta) we consider the whole block to be missing.
-		if part == nil {
-			return nil
+	buf, _ := bs.loadBlockBytes(height)
+	if buf == nil {
+		// blocks saved by older versions have one value per part
+		buf = []byte{}
+		for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
+			part := bs.loadLegacyBlockPart(height, i)
+			// If the part is missing (e.g. since it has been deleted after we
+			// loaded the block meta) we consider the whole block to be missing.
+			if part == nil {
+				return nil
+			}
+			buf = append(buf, part.Bytes...)
 		}
-		buf = append(buf, part.Bytes...)
 	}
+
+	pbb := new(tmproto.Block)
 	err := proto.Unmarshal(buf, pbb)
 	if err != nil {
 		// NOTE: The existence of meta should imply the existence of the
@@ -181,10 +239,67 @@ func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	return bs.LoadBlock(height)
 }
 
+// LoadTxLocation returns the height of the block containing the tx with the
+// given hash, and the index of the tx in the block. found is false if the tx
+// isn't in any of the stored blocks.
+// Panics if it fails to decode the location.
+func (bs *BlockStore) LoadTxLocation(hash []byte) (height int64, index uint32, found bool) {
+	bz, err := bs.db.Get(txHashKey(hash))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return 0, 0, false
+	}
+
+	height, index, err = decodeTxLocation(bz)
+	if err != nil {
+		panic(fmt.Sprintf("failed to decode location of tx %X: %v", hash, err))
+	}
+	return height, index, true
+}
+
 // LoadBlockPart returns the Part at the given index
 // from the block at the given height.
 // If no part is found for the given height and index, it returns nil.
 func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
+	parts := bs.parts.get(height)
+	if parts == nil {
+		buf, partSize := bs.loadBlockBytes(height)
+		if buf == nil {
+			return bs.loadLegacyBlockPart(height, index)
+		}
+		parts = types.NewPartSetFromData(buf, partSize)
+		bs.parts.push(height, parts)
+	}
+
+	if index < 0 || index >= int(parts.Total()) {
+		return nil
+	}
+	return parts.GetPart(index)
+}
+
+// loadBlockBytes returns the encoded block at the given height, and the size
+// of its parts, or nil if the block isn't stored as a single value.
+func (bs *BlockStore) loadBlockBytes(height int64) ([]byte, uint32) {
+	bz, err := bs.db.Get(blockKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return nil, 0
+	}
+
+	buf, partSize, err := decodeBlockValue(bz)
+	if err != nil {
+		panic(fmt.Sprintf("Error reading block: %v", err))
+	}
+	return buf, partSize
+}
+
+// loadLegacyBlockPart loads a block part saved by older versions, which saved
+// each part as a separate value.
+func (bs *BlockStore) loadLegacyBlockPart(height int64, index int) *types.Part {
 	var pbpart = new(tmproto.Part)
 
 	bz, err := bs.db.Get(blockPartKey(height, index))
@@ -292,6 +407,22 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		return 0, fmt.Errorf("height must be equal to or less than the latest height %d", bs.Height())
 	}
 
+	return bs.pruneBlocks(height)
+}
+
+// DiscardBlocks removes all blocks, leaving the store empty, e.g. before a
+// node state syncs past the blocks it has. Seen commits above the last block
+// are kept. It returns the number of blocks removed.
+func (bs *BlockStore) DiscardBlocks() (uint64, error) {
+	height := bs.Height()
+	if height == 0 {
+		return 0, nil
+	}
+	return bs.pruneBlocks(height + 1)
+}
+
+// pruneBlocks removes blocks below height.
+func (bs *BlockStore) pruneBlocks(height int64) (uint64, error) {
 	// when removing the block meta, use the hash to remove the hash key at the same time
 	removeBlockHash := func(key, value []byte, batch dbm.Batch) error {
 		// unmarshal block meta
@@ -311,7 +442,9 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 			return fmt.Errorf("failed to delete hash key: %X: %w", blockHashKey(blockMeta.BlockID.Hash), err)
 		}
 
-		return nil
+		// and the locations of its txs, which are still readable since the
+		// parts are pruned after the block metas
+		return bs.deleteTxLocations(blockMeta.Header.Height, batch)
 	}
 
 	// remove block meta first as this is used to indicate whether the block exists.
@@ -321,6 +454,12 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		return pruned, err
 	}
 
+	bs.parts.removeBelow(height)
+
+	if _, err := bs.pruneRange(blockKey(0), blockKey(height), nil); err != nil {
+		return pruned, err
+	}
+
 	if _, err := bs.pruneRange(blockPartKey(0, 0), blockPartKey(height, 0), nil); err != nil {
 		return pruned, err
 	}
@@ -446,14 +585,11 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic("BlockStore can only save complete block part sets")
 	}
 
-	// Save block parts. This must be done before the block meta, since callers
+	// Save the block. This must be done before the block meta, since callers
 	// typically load the block meta first as an indication that the block exists
-	// and then go on to load block parts - we must make sure the block is
-	// complete as soon as the block meta is written.
-	for i := 0; i < int(blockParts.Total()); i++ {
-		part := blockParts.GetPart(i)
-		bs.saveBlockPart(height, i, part, batch)
-	}
+	// and then go on to load the block or its parts - we must make sure the
+	// block is complete as soon as the block meta is written.
+	bs.saveBlockParts(height, blockParts, batch)
 
 	blockMeta := types.NewBlockMeta(block, blockParts)
 	pbm := blockMeta.ToProto()
@@ -470,6 +606,10 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic(err)
 	}
 
+	if err := saveTxLocations(block, batch); err != nil {
+		panic(err)
+	}
+
 	pbc := block.LastCommit.ToProto()
 	blockCommitBytes := mustEncode(pbc)
 	if err := batch.Set(blockCommitKey(height-1), blockCommitBytes); err != nil {
@@ -489,7 +629,7 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic(err)
 	}
 
-	if err := batch.WriteSync(); err != nil {
+	if err := bs.writeBlockBatch(batch); err != nil {
 		panic(err)
 	}
 
@@ -498,17 +638,120 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	}
 }
 
-func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part, batch dbm.Batch) {
-	pbp, err := part.ToProto()
+// writeBlockBatch writes the batch of a saved block, flushing it to disk as
+// per the sync policy.
+func (bs *BlockStore) writeBlockBatch(batch dbm.Batch) error {
+	switch bs.syncPolicy {
+	case SyncNever:
+		return batch.Write()
+	case SyncInterval:
+		bs.mtx.Lock()
+		defer bs.mtx.Unlock()
+		if now := time.Now(); now.Sub(bs.lastSync) >= bs.syncInterval {
+			if err := batch.WriteSync(); err != nil {
+				return err
+			}
+			bs.lastSync = now
+			return nil
+		}
+		return batch.Write()
+	default:
+		return batch.WriteSync()
+	}
+}
+
+// saveBlockParts saves the parts of a block as a single value, from which the
+// parts are rebuilt on demand. Parts have the same size, except for the last
+// one.
+func (bs *BlockStore) saveBlockParts(height int64, blockParts *types.PartSet, batch dbm.Batch) {
+	var (
+		buf      []byte
+		partSize uint32
+	)
+	for i := 0; i < int(blockParts.Total()); i++ {
+		part := blockParts.GetPart(i)
+		if i == 0 {
+			partSize = uint32(len(part.Bytes))
+		}
+		buf = append(buf, part.Bytes...)
+	}
+
+	bz, err := encodeBlockValue(buf, partSize)
 	if err != nil {
-		panic(fmt.Errorf("unable to make part into proto: %w", err))
+		panic(fmt.Errorf("unable to encode block: %w", err))
 	}
-	partBytes := mustEncode(pbp)
-	if err := batch.Set(blockPartKey(height, index), partBytes); err != nil {
+	if err := batch.Set(blockKey(height), bz); err != nil {
 		panic(err)
 	}
 }
 
+// saveTxLocations indexes the txs of the block by hash, so that they can be
+// looked up without a tx indexer. A tx included in several blocks is located
+// in the latest one.
+func saveTxLocations(block *types.Block, batch dbm.Batch) error {
+	for i, tx := range block.Txs {
+		if err := batch.Set(txHashKey(tx.Hash()), encodeTxLocation(block.Height, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTxLocations deletes the locations of the txs of the block at height,
+// unless they point to another block.
+func (bs *BlockStore) deleteTxLocations(height int64, batch dbm.Batch) error {
+	block := bs.LoadBlock(height)
+	if block == nil {
+		// headers saved without their data have no txs indexed
+		return nil
+	}
+	for _, tx := range block.Txs {
+		if txHeight, _, found := bs.LoadTxLocation(tx.Hash()); !found || txHeight != height {
+			continue
+		}
+		if err := batch.Delete(txHashKey(tx.Hash())); err != nil {
+			return fmt.Errorf("failed to delete tx hash key: %X: %w", txHashKey(tx.Hash()), err)
+		}
+	}
+	return nil
+}
+
+// ReindexBlocks rebuilds the hash indexes of the stored blocks, i.e. the
+// block hash to height and the tx hash to location indexes, e.g. for stores
+// written by versions which didn't index the txs. It returns the number of
+// blocks reindexed.
+func (bs *BlockStore) ReindexBlocks() (int64, error) {
+	var (
+		reindexed int64
+		base      = bs.Base()
+		latest    = bs.Height()
+	)
+	for height := base; base > 0 && height <= latest; height++ {
+		batch := bs.db.NewBatch()
+
+		if meta := bs.LoadBlockMeta(height); meta != nil {
+			if err := batch.Set(blockHashKey(meta.BlockID.Hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+				batch.Close()
+				return reindexed, err
+			}
+		}
+		if block := bs.LoadBlock(height); block != nil {
+			if err := saveTxLocations(block, batch); err != nil {
+				batch.Close()
+				return reindexed, err
+			}
+		}
+
+		err := batch.Write()
+		batch.Close()
+		if err != nil {
+			return reindexed, err
+		}
+		reindexed++
+	}
+	return reindexed, nil
+}
+
 // SaveSeenCommit saves a seen commit, used by e.g. the state sync reactor when bootstrapping node.
 func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) error {
 	pbc := seenCommit.ToProto()
@@ -519,6 +762,125 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(seenCommitKey(height), seenCommitBytes)
 }
 
+// SaveSignedHeader persists the header and commit of a block without its
+// data, used by header-only nodes which don't sync the block txs. The block
+// meta has no size nor txs, and LoadBlock returns nil for such heights. As with
+// SaveBlock, headers must be saved contiguously, unless the store is empty.
+func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader) error {
+	if sh == nil || sh.Header == nil || sh.Commit == nil {
+		return errors.New("BlockStore can only save a complete signed header")
+	}
+	height := sh.Height
+	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
+		return fmt.Errorf("BlockStore can only save contiguous headers. Wanted %v, got %v", w, g)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	blockMeta := &types.BlockMeta{
+		BlockID: sh.Commit.BlockID,
+		Header:  *sh.Header,
+	}
+	if err := batch.Set(blockMetaKey(height), mustEncode(blockMeta.ToProto())); err != nil {
+		return err
+	}
+	if err := batch.Set(blockHashKey(sh.Hash()), []byte(fmt.Sprintf("%d", height))); err != nil {
+		return err
+	}
+
+	// The commit of a signed header is canonical, so it's saved both as the
+	// block commit and as the seen commit of the latest height.
+	commitBytes := mustEncode(sh.Commit.ToProto())
+	if err := batch.Set(blockCommitKey(height), commitBytes); err != nil {
+		return err
+	}
+	if err := batch.Set(seenCommitKey(height), commitBytes); err != nil {
+		return err
+	}
+	if err := batch.Delete(seenCommitKey(height - 1)); err != nil {
+		return err
+	}
+
+	return bs.writeBlockBatch(batch)
+}
+
+// Close closes the underlying database.
+func (bs *BlockStore) Close() error {
+	return bs.db.Close()
+}
+
+// partSetCacheSize is the number of blocks whose parts LoadBlockPart keeps, so
+// that peers catching up from different heights don't make it rebuild the
+// parts of a block for every part they request.
+const partSetCacheSize = 8
+
+// partSetCache is an LRU cache of the part sets of blocks by height.
+type partSetCache struct {
+	mtx      tmsync.Mutex
+	size     int
+	cacheMap map[int64]*list.Element
+	list     *list.List
+}
+
+type cachedPartSet struct {
+	height int64
+	parts  *types.PartSet
+}
+
+func newPartSetCache(size int) *partSetCache {
+	return &partSetCache{
+		size:     size,
+		cacheMap: make(map[int64]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// get returns the parts of the block at height, or nil if they aren't cached.
+func (cache *partSetCache) get(height int64) *types.PartSet {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	e, ok := cache.cacheMap[height]
+	if !ok {
+		return nil
+	}
+	cache.list.MoveToBack(e)
+	return e.Value.(cachedPartSet).parts
+}
+
+// push caches the parts of the block at height, evicting the least recently
+// used parts if the cache is full.
+func (cache *partSetCache) push(height int64, parts *types.PartSet) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if e, ok := cache.cacheMap[height]; ok {
+		e.Value = cachedPartSet{height, parts}
+		cache.list.MoveToBack(e)
+		return
+	}
+	if cache.list.Len() >= cache.size {
+		popped := cache.list.Front()
+		delete(cache.cacheMap, popped.Value.(cachedPartSet).height)
+		cache.list.Remove(popped)
+	}
+	cache.cacheMap[height] = cache.list.PushBack(cachedPartSet{height, parts})
+}
+
+// removeBelow removes the parts of the blocks below height.
+func (cache *partSetCache) removeBelow(height int64) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	for h, e := range cache.cacheMap {
+		if h < height {
+			delete(cache.cacheMap, h)
+			cache.list.Remove(e)
+		}
+	}
+}
+
 //---------------------------------- KEY ENCODING -----------------------------------------
 
 // key prefixes
@@ -529,6 +891,14 @@ const (
 	prefixBlockCommit = int64(2)
 	prefixSeenCommit  = int64(3)
 	prefixBlockHash   = int64(4)
+	prefixTxHash      = int64(15)
+	prefixBlock       = int64(16)
+)
+
+// compression of the block values
+const (
+	blockCompressionNone = int64(0)
+	blockCompressionGzip = int64(1)
 )
 
 func blockMetaKey(height int64) []byte {
@@ -562,6 +932,14 @@ func blockPartKey(height int64, partIndex int) []byte {
 	return key
 }
 
+func blockKey(height int64) []byte {
+	key, err := orderedcode.Append(nil, prefixBlock, height)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 func blockCommitKey(height int64) []byte {
 	key, err := orderedcode.Append(nil, prefixBlockCommit, height)
 	if err != nil {
@@ -586,6 +964,85 @@ func blockHashKey(hash []byte) []byte {
 	return key
 }
 
+func txHashKey(hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixTxHash, string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// encodeTxLocation encodes the height of the block containing a tx, and the
+// index of the tx in the block.
+func encodeTxLocation(height int64, index int) []byte {
+	bz, err := orderedcode.Append(nil, height, int64(index))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func decodeTxLocation(bz []byte) (height int64, index uint32, err error) {
+	var idx int64
+	remaining, err := orderedcode.Parse(string(bz), &height, &idx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(remaining) != 0 {
+		return 0, 0, fmt.Errorf("expected complete tx location but got remainder: %s", remaining)
+	}
+	return height, uint32(idx), nil
+}
+
+// encodeBlockValue encodes an encoded block and the size of its parts,
+// compressing the block unless it doesn't make it any smaller.
+func encodeBlockValue(block []byte, partSize uint32) ([]byte, error) {
+	compression := blockCompressionNone
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(block); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() < len(block) {
+		compression = blockCompressionGzip
+		block = buf.Bytes()
+	}
+
+	bz, err := orderedcode.Append(nil, int64(partSize), compression)
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, block...), nil
+}
+
+func decodeBlockValue(bz []byte) (block []byte, partSize uint32, err error) {
+	var size, compression int64
+	remaining, err := orderedcode.Parse(string(bz), &size, &compression)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch compression {
+	case blockCompressionNone:
+		block = []byte(remaining)
+	case blockCompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader([]byte(remaining)))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer r.Close()
+		if block, err = ioutil.ReadAll(r); err != nil {
+			return nil, 0, err
+		}
+	default:
+		return nil, 0, fmt.Errorf("unknown block compression %d", compression)
+	}
+	return block, uint32(size), nil
+}
+
 //-----------------------------------------------------------------------------
 
 // mustEncode proto encodes a proto.message and panics if fails
@@ -331,6 +331,65 @@ func TestLoadBaseMeta(t *testing.T) {
 	assert.EqualValues(t, 4, bs.Base())
 }
 
+// syncCountingDB counts the batches written with and without a flush to disk.
+type syncCountingDB struct {
+	dbm.DB
+	writes, syncs int
+}
+
+type syncCountingBatch struct {
+	dbm.Batch
+	db *syncCountingDB
+}
+
+func (db *syncCountingDB) NewBatch() dbm.Batch {
+	return &syncCountingBatch{Batch: db.DB.NewBatch(), db: db}
+}
+
+func (b *syncCountingBatch) Write() error {
+	b.db.writes++
+	return b.Batch.Write()
+}
+
+func (b *syncCountingBatch) WriteSync() error {
+	b.db.syncs++
+	return b.Batch.WriteSync()
+}
+
+func TestBlockStoreSyncPolicy(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		policy        SyncPolicy
+		interval      time.Duration
+		writes, syncs int
+	}{
+		"always":              {SyncAlways, 0, 0, 3},
+		"interval":            {SyncInterval, time.Hour, 2, 1},
+		"interval of 0":       {SyncInterval, 0, 0, 3},
+		"never":               {SyncNever, 0, 3, 0},
+		"never with interval": {SyncNever, time.Hour, 3, 0},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			db := &syncCountingDB{DB: dbm.NewMemDB()}
+			bs := NewBlockStore(db, WithSyncPolicy(tc.policy, tc.interval))
+			for h := int64(1); h <= 3; h++ {
+				block := makeBlock(h, state, new(types.Commit))
+				bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+			}
+			assert.Equal(t, tc.writes, db.writes)
+			assert.Equal(t, tc.syncs, db.syncs)
+			assert.EqualValues(t, 3, bs.Height())
+		})
+	}
+}
+
 func TestLoadBlockPart(t *testing.T) {
 	bs, db := freshBlockStore()
 	height, index := int64(10), 1
@@ -364,6 +423,93 @@ func TestLoadBlockPart(t *testing.T) {
 		"expecting successful retrieval of previously saved block")
 }
 
+func TestSaveBlockAsSingleValue(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	block := makeBlock(1, state, new(types.Commit))
+	partSet := block.MakePartSet(64)
+	require.Greater(t, partSet.Total(), uint32(1))
+	bs.SaveBlock(block, partSet, makeTestCommit(1, tmtime.Now()))
+
+	// the block is saved as a single value, without the parts
+	bz, err := bs.db.Get(blockKey(1))
+	require.NoError(t, err)
+	require.NotEmpty(t, bz)
+	bz, err = bs.db.Get(blockPartKey(1, 0))
+	require.NoError(t, err)
+	require.Empty(t, bz)
+
+	require.Equal(t, block.Hash(), bs.LoadBlock(1).Hash())
+	for i := 0; i < int(partSet.Total()); i++ {
+		require.Equal(t, partSet.GetPart(i), bs.LoadBlockPart(1, i))
+	}
+	require.Nil(t, bs.LoadBlockPart(1, int(partSet.Total())))
+	require.Nil(t, bs.LoadBlockPart(2, 0))
+}
+
+func TestLoadLegacyBlockParts(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	block := makeBlock(1, state, new(types.Commit))
+	partSet := block.MakePartSet(64)
+	bs.SaveBlock(block, partSet, makeTestCommit(1, tmtime.Now()))
+
+	// rewrite the block with one value per part, as older versions did
+	require.NoError(t, bs.db.Delete(blockKey(1)))
+	for i := 0; i < int(partSet.Total()); i++ {
+		pbp, err := partSet.GetPart(i).ToProto()
+		require.NoError(t, err)
+		require.NoError(t, bs.db.Set(blockPartKey(1, i), mustEncode(pbp)))
+	}
+
+	require.Equal(t, block.Hash(), bs.LoadBlock(1).Hash())
+	require.Equal(t, partSet.GetPart(1), bs.LoadBlockPart(1, 1))
+}
+
+func TestPartSetCache(t *testing.T) {
+	cache := newPartSetCache(2)
+	parts := make([]*types.PartSet, 4)
+	for i := range parts {
+		parts[i] = types.NewPartSetFromData([]byte{byte(i)}, 1)
+	}
+	cache.push(1, parts[1])
+	cache.push(2, parts[2])
+
+	// the least recently used parts are evicted
+	require.Same(t, parts[1], cache.get(1))
+	cache.push(3, parts[3])
+	require.Nil(t, cache.get(2))
+	require.Same(t, parts[1], cache.get(1))
+	require.Same(t, parts[3], cache.get(3))
+
+	cache.removeBelow(3)
+	require.Nil(t, cache.get(1))
+	require.Same(t, parts[3], cache.get(3))
+}
+
+func TestBlockValueEncoding(t *testing.T) {
+	compressible := bytes.Repeat([]byte("block"), 100)
+	bz, err := encodeBlockValue(compressible, 64)
+	require.NoError(t, err)
+	require.Less(t, len(bz), len(compressible))
+
+	block, partSize, err := decodeBlockValue(bz)
+	require.NoError(t, err)
+	require.Equal(t, compressible, block)
+	require.EqualValues(t, 64, partSize)
+
+	// blocks which don't compress are saved as they are
+	incompressible := tmrand.Bytes(100)
+	bz, err = encodeBlockValue(incompressible, 4096)
+	require.NoError(t, err)
+	block, partSize, err = decodeBlockValue(bz)
+	require.NoError(t, err)
+	require.Equal(t, incompressible, block)
+	require.EqualValues(t, 4096, partSize)
+}
+
 func TestPruneBlocks(t *testing.T) {
 	config := cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -443,6 +589,82 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestDiscardBlocks(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	bs := NewBlockStore(dbm.NewMemDB())
+
+	discarded, err := bs.DiscardBlocks()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, discarded)
+
+	for h := int64(1); h <= 10; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+	seenCommit := makeTestCommit(20, tmtime.Now())
+	require.NoError(t, bs.SaveSeenCommit(20, seenCommit))
+
+	discarded, err = bs.DiscardBlocks()
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, discarded)
+	assert.EqualValues(t, 0, bs.Base())
+	assert.EqualValues(t, 0, bs.Height())
+	assert.Nil(t, bs.LoadBlock(10))
+	assert.Nil(t, bs.LoadSeenCommit(10))
+	assert.Equal(t, seenCommit, bs.LoadSeenCommit(20))
+
+	// the empty store accepts blocks past the discarded ones
+	block := makeBlock(21, state, new(types.Commit))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(21, tmtime.Now()))
+	assert.EqualValues(t, 21, bs.Base())
+	assert.EqualValues(t, 21, bs.Height())
+}
+
+func TestLoadTxLocation(t *testing.T) {
+	bs, db := freshBlockStore()
+
+	var blocks []*types.Block
+	for h := int64(1); h <= 3; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+		blocks = append(blocks, block)
+	}
+
+	for _, block := range blocks {
+		for i, tx := range block.Txs {
+			height, index, found := bs.LoadTxLocation(tx.Hash())
+			require.True(t, found)
+			require.Equal(t, block.Height, height)
+			require.EqualValues(t, i, index)
+		}
+	}
+	_, _, found := bs.LoadTxLocation(types.Tx("unknown").Hash())
+	require.False(t, found)
+
+	// the locations are rebuilt by reindexing
+	for _, tx := range blocks[1].Txs {
+		require.NoError(t, db.Delete(txHashKey(tx.Hash())))
+	}
+	reindexed, err := bs.ReindexBlocks()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, reindexed)
+	height, _, found := bs.LoadTxLocation(blocks[1].Txs[0].Hash())
+	require.True(t, found)
+	require.EqualValues(t, 2, height)
+
+	// the locations of the txs of pruned blocks are deleted
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		_, _, found := bs.LoadTxLocation(block.Txs[0].Hash())
+		require.Equal(t, block.Height == 3, found)
+	}
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
@@ -577,3 +799,37 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+func TestSaveSignedHeader(t *testing.T) {
+	bs, _ := freshBlockStore()
+
+	for h := int64(5); h <= 7; h++ {
+		block := makeBlock(h, state, makeTestCommit(h-1, tmtime.Now()))
+		commit := makeTestCommit(h, tmtime.Now())
+		commit.BlockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(2).Header()}
+		require.NoError(t, bs.SaveSignedHeader(&types.SignedHeader{Header: &block.Header, Commit: commit}))
+
+		require.Equal(t, int64(5), bs.Base())
+		require.Equal(t, h, bs.Height())
+
+		meta := bs.LoadBlockMeta(h)
+		require.NotNil(t, meta)
+		require.Equal(t, block.Hash(), meta.Header.Hash())
+		require.Equal(t, commit.BlockID, meta.BlockID)
+		require.Zero(t, meta.NumTxs)
+
+		require.Equal(t, commit.Hash(), bs.LoadBlockCommit(h).Hash())
+		require.Equal(t, commit.Hash(), bs.LoadSeenCommit(h).Hash())
+		require.Nil(t, bs.LoadSeenCommit(h-1))
+
+		// the block data isn't stored
+		require.Nil(t, bs.LoadBlock(h))
+		require.Nil(t, bs.LoadBlockByHash(block.Hash()))
+	}
+
+	// headers must be contiguous
+	block := makeBlock(9, state, makeTestCommit(8, tmtime.Now()))
+	commit := makeTestCommit(9, tmtime.Now())
+	require.Error(t, bs.SaveSignedHeader(&types.SignedHeader{Header: &block.Header, Commit: commit}))
+	require.Error(t, bs.SaveSignedHeader(&types.SignedHeader{Header: &block.Header}))
+}
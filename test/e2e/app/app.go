@@ -26,6 +26,7 @@ type Application struct {
 	cfg             *Config
 	restoreSnapshot *abci.Snapshot
 	restoreChunks   [][]byte
+	restorePart     []byte // chunk being streamed in parts
 }
 
 // NewApplication creates the application.
@@ -171,7 +172,20 @@ func (app *Application) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abc
 	if err != nil {
 		panic(err)
 	}
-	return abci.ResponseLoadSnapshotChunk{Chunk: chunk}
+	if chunk == nil || req.Length == 0 {
+		return abci.ResponseLoadSnapshotChunk{Chunk: chunk}
+	}
+
+	// load the requested window of the chunk
+	size := uint64(len(chunk))
+	start, end := req.Offset, req.Offset+uint64(req.Length)
+	if start > size {
+		start = size
+	}
+	if end > size {
+		end = size
+	}
+	return abci.ResponseLoadSnapshotChunk{Chunk: chunk[start:end], ChunkSize: size}
 }
 
 // OfferSnapshot implements ABCI.
@@ -181,7 +195,8 @@ func (app *Application) OfferSnapshot(req abci.RequestOfferSnapshot) abci.Respon
 	}
 	app.restoreSnapshot = req.Snapshot
 	app.restoreChunks = [][]byte{}
-	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+	app.restorePart = nil
+	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT, StreamChunks: true}
 }
 
 // ApplySnapshotChunk implements ABCI.
@@ -189,7 +204,18 @@ func (app *Application) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) a
 	if app.restoreSnapshot == nil {
 		panic("No restore in progress")
 	}
-	app.restoreChunks = append(app.restoreChunks, req.Chunk)
+	chunk := req.Chunk
+	if req.ChunkSize > 0 {
+		if req.Offset == 0 {
+			app.restorePart = nil
+		}
+		app.restorePart = append(app.restorePart, req.Chunk...)
+		if uint64(len(app.restorePart)) < req.ChunkSize {
+			return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+		}
+		chunk, app.restorePart = app.restorePart, nil
+	}
+	app.restoreChunks = append(app.restoreChunks, chunk)
 	if len(app.restoreChunks) == int(app.restoreSnapshot.Chunks) {
 		bz := []byte{}
 		for _, chunk := range app.restoreChunks {
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -88,10 +89,11 @@ func (s *SnapshotStore) Create(state *State) (abci.Snapshot, error) {
 		return abci.Snapshot{}, err
 	}
 	snapshot := abci.Snapshot{
-		Height: state.Height,
-		Format: 1,
-		Hash:   hashItems(state.Values),
-		Chunks: byteChunks(bz),
+		Height:      state.Height,
+		Format:      1,
+		Hash:        hashItems(state.Values),
+		Chunks:      byteChunks(bz),
+		ChunkHashes: chunkHashes(bz),
 	}
 	err = ioutil.WriteFile(filepath.Join(s.dir, fmt.Sprintf("%v.json", state.Height)), bz, 0644)
 	if err != nil {
@@ -146,6 +148,16 @@ func byteChunk(bz []byte, index uint32) []byte {
 	}
 }
 
+// chunkHashes calculates the SHA-256 hash of each chunk in the byte slice.
+func chunkHashes(bz []byte) [][]byte {
+	hashes := make([][]byte, 0, byteChunks(bz))
+	for i := uint32(0); i < byteChunks(bz); i++ {
+		hash := sha256.Sum256(byteChunk(bz, i))
+		hashes = append(hashes, hash[:])
+	}
+	return hashes
+}
+
 // byteChunks calculates the number of chunks in the byte slice.
 func byteChunks(bz []byte) uint32 {
 	return uint32(math.Ceil(float64(len(bz)) / snapshotChunkSize))
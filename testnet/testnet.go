@@ -0,0 +1,272 @@
+// Package testnet generates the keys, genesis and configs of Tendermint
+// testnets, as the testnet command does, for use by integration tests and
+// tooling.
+//
+// With a seed, generation is deterministic: the same Config always results in
+// the same network, with the same keys, node IDs and genesis.
+//
+//	tn, err := testnet.Generate(testnet.Config{Validators: 4, Seed: 1})
+//	if err != nil {
+//		return err
+//	}
+//	err = tn.WriteFiles("./mytestnet", nil)
+package testnet
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+const (
+	nodeDirPerm = 0755
+
+	defaultNamePrefix = "node"
+	defaultP2PPort    = 26656
+)
+
+// Config configures the generated testnet.
+type Config struct {
+	// Number of validators and non-validators.
+	Validators    int
+	NonValidators int
+
+	// ChainID of the genesis. Defaults to "chain-" followed by a random string.
+	ChainID string
+	// InitialHeight of the genesis.
+	InitialHeight int64
+	// GenesisTime of the genesis. Defaults to the current time, or to the Unix
+	// epoch if Seed is set.
+	GenesisTime time.Time
+	// KeyType of the validator keys: ed25519 (default) or secp256k1.
+	KeyType string
+
+	// Seed makes the generation deterministic if non-zero. Keys generated from
+	// a seed are only as secret as the seed, so it's only meant for tests.
+	Seed int64
+
+	// NamePrefix prefixes the names of the nodes, which are also their
+	// monikers and the names of their directories, e.g. "node" results in
+	// node0, node1, ... Defaults to "node".
+	NamePrefix string
+	// Hostname returns the hostname or IP of the i-th node, with which the
+	// persistent peers of the nodes are populated. If nil, the nodes have no
+	// persistent peers.
+	Hostname func(i int) string
+	// P2PPort the nodes listen on. Defaults to 26656.
+	P2PPort int
+}
+
+// Node is a node of the testnet.
+type Node struct {
+	Name      string
+	Moniker   string
+	Validator bool
+
+	NodeKey          p2p.NodeKey
+	PrivValidatorKey crypto.PrivKey
+
+	// PersistentPeers are the addresses of all other nodes, if Config.Hostname
+	// is set.
+	PersistentPeers []string
+}
+
+// Testnet is a generated testnet.
+type Testnet struct {
+	Genesis *types.GenesisDoc
+	Nodes   []*Node
+}
+
+// Generate generates a testnet. The validators are the first nodes.
+func Generate(config Config) (*Testnet, error) {
+	if config.Validators < 0 || config.NonValidators < 0 {
+		return nil, errors.New("number of nodes can't be negative")
+	}
+	if config.NamePrefix == "" {
+		config.NamePrefix = defaultNamePrefix
+	}
+	if config.P2PPort == 0 {
+		config.P2PPort = defaultP2PPort
+	}
+
+	gen := newKeyGen(config.Seed)
+	genDoc := &types.GenesisDoc{
+		ChainID:         config.ChainID,
+		GenesisTime:     config.GenesisTime,
+		InitialHeight:   config.InitialHeight,
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	if genDoc.ChainID == "" {
+		genDoc.ChainID = "chain-" + gen.str(6)
+	}
+	if genDoc.GenesisTime.IsZero() {
+		if config.Seed != 0 {
+			genDoc.GenesisTime = time.Unix(0, 0).UTC()
+		} else {
+			genDoc.GenesisTime = tmtime.Now()
+		}
+	}
+	switch config.KeyType {
+	case "", types.ABCIPubKeyTypeEd25519:
+	case types.ABCIPubKeyTypeSecp256k1:
+		genDoc.ConsensusParams.Validator = types.ValidatorParams{
+			PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
+		}
+	default:
+		return nil, fmt.Errorf("key type: %s is not supported", config.KeyType)
+	}
+
+	tn := &Testnet{Genesis: genDoc}
+	for i := 0; i < config.Validators+config.NonValidators; i++ {
+		name := fmt.Sprintf("%s%d", config.NamePrefix, i)
+		nodeKey := gen.ed25519()
+		node := &Node{
+			Name:      name,
+			Moniker:   name,
+			Validator: i < config.Validators,
+			NodeKey: p2p.NodeKey{
+				ID:      p2p.NodeIDFromPubKey(nodeKey.PubKey()),
+				PrivKey: nodeKey,
+			},
+		}
+		if config.KeyType == types.ABCIPubKeyTypeSecp256k1 {
+			node.PrivValidatorKey = gen.secp256k1()
+		} else {
+			node.PrivValidatorKey = gen.ed25519()
+		}
+		if node.Validator {
+			pubKey := node.PrivValidatorKey.PubKey()
+			genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   1,
+				Name:    name,
+			})
+		}
+		tn.Nodes = append(tn.Nodes, node)
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	if config.Hostname != nil {
+		for i, node := range tn.Nodes {
+			for j, peer := range tn.Nodes {
+				if j == i {
+					continue
+				}
+				node.PersistentPeers = append(node.PersistentPeers, p2p.IDAddressString(
+					peer.NodeKey.ID, fmt.Sprintf("%s:%d", config.Hostname(j), config.P2PPort)))
+			}
+		}
+	}
+	return tn, nil
+}
+
+// WriteFiles writes the files of each node to a directory named after it in
+// dir: the config, genesis, node key and validator key and state. The configs
+// are based on config, or the default validator config if nil, with strict
+// address routability turned off.
+func (tn *Testnet) WriteFiles(dir string, config *cfg.Config) error {
+	if config == nil {
+		config = cfg.DefaultValidatorConfig()
+	}
+	for _, node := range tn.Nodes {
+		if err := node.writeFiles(filepath.Join(dir, node.Name), config, tn.Genesis); err != nil {
+			return fmt.Errorf("failed to write files of %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
+
+func (node *Node) writeFiles(nodeDir string, config *cfg.Config, genDoc *types.GenesisDoc) error {
+	for _, subdir := range []string{"config", "data"} {
+		if err := os.MkdirAll(filepath.Join(nodeDir, subdir), nodeDirPerm); err != nil {
+			return err
+		}
+	}
+
+	// Copy the parts of the config which differ between nodes, so the given
+	// config isn't changed.
+	nodeConfig := *config
+	p2pConfig := *config.P2P
+	nodeConfig.P2P = &p2pConfig
+	nodeConfig.Moniker = node.Moniker
+	nodeConfig.P2P.AddrBookStrict = false
+	nodeConfig.P2P.AllowDuplicateIP = true
+	if len(node.PersistentPeers) > 0 {
+		nodeConfig.P2P.PersistentPeers = strings.Join(node.PersistentPeers, ",")
+	}
+
+	if err := node.NodeKey.SaveAs(filepath.Join(nodeDir, nodeConfig.NodeKey)); err != nil {
+		return err
+	}
+	privval.NewFilePV(
+		node.PrivValidatorKey,
+		filepath.Join(nodeDir, nodeConfig.PrivValidatorKey),
+		filepath.Join(nodeDir, nodeConfig.PrivValidatorState),
+	).Save()
+	if err := genDoc.SaveAs(filepath.Join(nodeDir, nodeConfig.Genesis)); err != nil {
+		return err
+	}
+	cfg.WriteConfigFile(nodeDir, &nodeConfig)
+	return nil
+}
+
+// keyGen generates keys and strings, from a seed if given.
+type keyGen struct {
+	rng *rand.Rand // nil without a seed
+}
+
+func newKeyGen(seed int64) *keyGen {
+	if seed == 0 {
+		return &keyGen{}
+	}
+	return &keyGen{rng: rand.New(rand.NewSource(seed))} // nolint:gosec
+}
+
+func (g *keyGen) secret() []byte {
+	secret := make([]byte, 32)
+	_, _ = g.rng.Read(secret)
+	return secret
+}
+
+func (g *keyGen) ed25519() crypto.PrivKey {
+	if g.rng == nil {
+		return ed25519.GenPrivKey()
+	}
+	return ed25519.GenPrivKeyFromSecret(g.secret())
+}
+
+func (g *keyGen) secp256k1() crypto.PrivKey {
+	if g.rng == nil {
+		return secp256k1.GenPrivKey()
+	}
+	return secp256k1.GenPrivKeySecp256k1(g.secret())
+}
+
+func (g *keyGen) str(length int) string {
+	if g.rng == nil {
+		return tmrand.Str(length)
+	}
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	s := make([]byte, length)
+	for i := range s {
+		s[i] = chars[g.rng.Intn(len(chars))]
+	}
+	return string(s)
+}
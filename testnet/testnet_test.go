@@ -0,0 +1,97 @@
+package testnet
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestGenerate(t *testing.T) {
+	config := Config{
+		Validators:    3,
+		NonValidators: 1,
+		Hostname:      func(i int) string { return fmt.Sprintf("host%d", i) },
+	}
+	tn, err := Generate(config)
+	require.NoError(t, err)
+	require.Len(t, tn.Nodes, 4)
+	require.Len(t, tn.Genesis.Validators, 3)
+	require.NoError(t, tn.Genesis.ValidateAndComplete())
+
+	for i, node := range tn.Nodes {
+		require.Equal(t, fmt.Sprintf("node%d", i), node.Name)
+		require.Equal(t, i < 3, node.Validator)
+		require.Len(t, node.PersistentPeers, 3)
+		require.NotContains(t, node.PersistentPeers,
+			p2p.IDAddressString(node.NodeKey.ID, fmt.Sprintf("host%d:26656", i)))
+	}
+	require.Contains(t, tn.Nodes[0].PersistentPeers,
+		p2p.IDAddressString(tn.Nodes[3].NodeKey.ID, "host3:26656"))
+
+	// without a seed, each testnet is different
+	other, err := Generate(config)
+	require.NoError(t, err)
+	require.NotEqual(t, tn.Genesis.ChainID, other.Genesis.ChainID)
+	require.NotEqual(t, tn.Nodes[0].NodeKey.ID, other.Nodes[0].NodeKey.ID)
+
+	_, err = Generate(Config{KeyType: "foo"})
+	require.Error(t, err)
+}
+
+func TestGenerate_Seed(t *testing.T) {
+	for _, keyType := range []string{types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1} {
+		config := Config{Validators: 2, NonValidators: 1, KeyType: keyType, Seed: 42}
+		tn, err := Generate(config)
+		require.NoError(t, err)
+		same, err := Generate(config)
+		require.NoError(t, err)
+		require.Equal(t, tn, same)
+
+		config.Seed = 43
+		other, err := Generate(config)
+		require.NoError(t, err)
+		require.NotEqual(t, tn.Genesis, other.Genesis)
+		require.NotEqual(t, tn.Nodes[2].NodeKey, other.Nodes[2].NodeKey)
+	}
+}
+
+func TestTestnet_WriteFiles(t *testing.T) {
+	tn, err := Generate(Config{
+		Validators: 2,
+		Seed:       1,
+		Hostname:   func(i int) string { return fmt.Sprintf("host%d", i) },
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	config := cfg.DefaultValidatorConfig()
+	require.NoError(t, tn.WriteFiles(dir, config))
+	require.Empty(t, config.P2P.PersistentPeers)
+
+	for _, node := range tn.Nodes {
+		nodeConfig := cfg.DefaultValidatorConfig()
+		nodeConfig.SetRoot(filepath.Join(dir, node.Name))
+
+		nodeKey, err := p2p.LoadNodeKey(nodeConfig.NodeKeyFile())
+		require.NoError(t, err)
+		require.Equal(t, node.NodeKey.ID, nodeKey.ID)
+
+		pv, err := privval.LoadFilePV(nodeConfig.PrivValidatorKeyFile(), nodeConfig.PrivValidatorStateFile())
+		require.NoError(t, err)
+		require.Equal(t, node.PrivValidatorKey.PubKey(), pv.Key.PubKey)
+
+		genDoc, err := types.GenesisDocFromFile(nodeConfig.GenesisFile())
+		require.NoError(t, err)
+		require.Equal(t, tn.Genesis.ChainID, genDoc.ChainID)
+		require.Equal(t, tn.Genesis.Validators, genDoc.Validators)
+
+		require.FileExists(t, filepath.Join(nodeConfig.RootDir, "config", "config.toml"))
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strings"
@@ -156,6 +157,32 @@ func (b *Block) HashesTo(hash []byte) bool {
 	return bytes.Equal(b.Hash(), hash)
 }
 
+// blockRandomnessDomain separates the hashes of Block.Randomness from other
+// hashes. It is versioned, so that the derivation can be replaced, e.g. by a
+// VRF or threshold signatures, at a block protocol upgrade.
+const blockRandomnessDomain = "tendermint/block-randomness/v1"
+
+// Randomness returns a random value for the block, which is passed to the app
+// in BeginBlock. It is a hash of the chain ID, the height and the signatures of
+// the last commit, so it is the same on all nodes and differs at every height.
+// It is not unbiasable: the proposer picks which signatures of the last commit
+// to include, and can thereby choose among several values.
+func (b *Block) Randomness() []byte {
+	hasher := tmhash.New()
+	hasher.Write([]byte(blockRandomnessDomain))
+	hasher.Write([]byte(b.ChainID))
+
+	var buf [binary.MaxVarintLen64]byte
+	hasher.Write(buf[:binary.PutVarint(buf[:], b.Height)])
+	if b.LastCommit != nil {
+		for _, sig := range b.LastCommit.Signatures {
+			hasher.Write(buf[:binary.PutUvarint(buf[:], uint64(len(sig.Signature)))])
+			hasher.Write(sig.Signature)
+		}
+	}
+	return hasher.Sum(nil)
+}
+
 // Size returns size of the block in bytes.
 func (b *Block) Size() int {
 	pbb, err := b.ToProto()
@@ -167,6 +167,39 @@ func TestBlockHashesTo(t *testing.T) {
 	assert.True(t, block.HashesTo(block.Hash()))
 }
 
+func TestBlockRandomness(t *testing.T) {
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+	voteSet, _, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := makeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	block := MakeBlock(h, []Tx{Tx("Hello World")}, commit, nil)
+	randomness := block.Randomness()
+	assert.Len(t, randomness, tmhash.Size)
+
+	// the randomness only depends on the chain ID, the height and the last commit
+	other := MakeBlock(h, []Tx{Tx("Goodbye")}, commit, nil)
+	assert.Equal(t, randomness, other.Randomness())
+
+	other = MakeBlock(h+1, []Tx{Tx("Hello World")}, commit, nil)
+	assert.NotEqual(t, randomness, other.Randomness())
+
+	other = MakeBlock(h, []Tx{Tx("Hello World")}, commit, nil)
+	other.ChainID = "other-chain"
+	assert.NotEqual(t, randomness, other.Randomness())
+
+	// a commit with another subset of signatures yields another value
+	otherCommit := *commit
+	otherCommit.Signatures = append([]CommitSig(nil), commit.Signatures...)
+	otherCommit.Signatures[0] = NewCommitSigAbsent()
+	other = MakeBlock(h, []Tx{Tx("Hello World")}, &otherCommit, nil)
+	assert.NotEqual(t, randomness, other.Randomness())
+
+	// the first block has no last commit
+	assert.Len(t, MakeBlock(1, nil, nil, nil).Randomness(), tmhash.Size)
+}
+
 func TestBlockSize(t *testing.T) {
 	size := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil).Size()
 	if size <= 0 {
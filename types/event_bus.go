@@ -187,6 +187,20 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+// PublishEventMempoolTx publishes a mempool event. It adds the predefined keys
+// EventTypeKey, MempoolTxHashKey and MempoolTxStatusKey.
+func (b *EventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey:       {EventMempoolTx},
+		MempoolTxHashKey:   {fmt.Sprintf("%X", data.Tx.Hash())},
+		MempoolTxStatusKey: {data.Status},
+	}
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStep, data)
 }
@@ -227,6 +241,18 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventAppCommitTimeout(data EventDataAppCommitTimeout) error {
+	return b.Publish(EventAppCommitTimeout, data)
+}
+
+func (b *EventBus) PublishEventFastSyncStall(data EventDataFastSyncStall) error {
+	return b.Publish(EventFastSyncStall, data)
+}
+
+func (b *EventBus) PublishEventBlockTimeDrift(data EventDataBlockTimeDrift) error {
+	return b.Publish(EventBlockTimeDrift, data)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -267,6 +293,10 @@ func (NopEventBus) PublishEventTx(data EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return nil
 }
@@ -306,3 +336,15 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventAppCommitTimeout(data EventDataAppCommitTimeout) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventFastSyncStall(data EventDataFastSyncStall) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventBlockTimeDrift(data EventDataBlockTimeDrift) error {
+	return nil
+}
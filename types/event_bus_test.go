@@ -64,6 +64,47 @@ func TestEventBusPublishEventTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	txSub, err := eventBus.Subscribe(context.Background(), "test", EventQueryMempoolTxFor(tx), 2)
+	require.NoError(t, err)
+	query := fmt.Sprintf("tm.event='MempoolTx' AND mempool_tx.status='%s'", MempoolTxEvicted)
+	evictedSub, err := eventBus.Subscribe(context.Background(), "test-evicted", tmquery.MustParse(query), 2)
+	require.NoError(t, err)
+
+	added := EventDataMempoolTx{Tx: tx, Status: MempoolTxAdded}
+	evicted := EventDataMempoolTx{Tx: tx, Status: MempoolTxEvicted, Reason: "mempool flushed"}
+	require.NoError(t, eventBus.PublishEventMempoolTx(added))
+	require.NoError(t, eventBus.PublishEventMempoolTx(EventDataMempoolTx{Tx: Tx("bar"), Status: MempoolTxEvicted}))
+	require.NoError(t, eventBus.PublishEventMempoolTx(evicted))
+
+	for _, expected := range []EventDataMempoolTx{added, evicted} {
+		select {
+		case msg := <-txSub.Out():
+			assert.Equal(t, expected, msg.Data())
+		case <-time.After(1 * time.Second):
+			t.Fatal("did not receive a mempool event after 1 sec.")
+		}
+	}
+	for _, expected := range []Tx{Tx("bar"), tx} {
+		select {
+		case msg := <-evictedSub.Out():
+			assert.Equal(t, expected, msg.Data().(EventDataMempoolTx).Tx)
+		case <-time.After(1 * time.Second):
+			t.Fatal("did not receive an evicted mempool event after 1 sec.")
+		}
+	}
+}
+
 func TestEventBusPublishEventNewBlock(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
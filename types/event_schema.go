@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+// EventSchema is a registry of the types of the event attributes emitted by
+// the app, which it declares in its Info and InitChain responses. Attributes
+// missing from the schema are strings.
+//
+// Safe for concurrent use by multiple goroutines.
+type EventSchema struct {
+	mtx   tmsync.RWMutex
+	types map[string]abci.EventAttributeType // "type.key" -> type
+}
+
+// NewEventSchema returns an empty schema.
+func NewEventSchema() *EventSchema {
+	return &EventSchema{types: make(map[string]abci.EventAttributeType)}
+}
+
+// Set replaces the schema with the given attribute declarations.
+func (s *EventSchema) Set(attrs []abci.EventAttributeSchema) error {
+	types := make(map[string]abci.EventAttributeType, len(attrs))
+	for _, attr := range attrs {
+		if attr.EventType == "" || attr.Key == "" {
+			return fmt.Errorf("event attribute schema %v has no event type or key", attr)
+		}
+		if _, ok := abci.EventAttributeType_name[int32(attr.Type)]; !ok {
+			return fmt.Errorf("event attribute %s.%s has unknown type %v", attr.EventType, attr.Key, attr.Type)
+		}
+		compositeKey := fmt.Sprintf("%s.%s", attr.EventType, attr.Key)
+		switch compositeKey {
+		case TxHashKey, TxHeightKey, BlockHeightKey:
+			return fmt.Errorf("event attribute %s is reserved", compositeKey)
+		}
+		if _, ok := types[compositeKey]; ok {
+			return fmt.Errorf("event attribute %s is declared more than once", compositeKey)
+		}
+		types[compositeKey] = attr.Type
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.types = types
+	return nil
+}
+
+// Type returns the type of the attribute with the given composite key, e.g.
+// "transfer.amount", and whether it's declared.
+func (s *EventSchema) Type(compositeKey string) (abci.EventAttributeType, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	t, ok := s.types[compositeKey]
+	return t, ok
+}
+
+// ValidateEvents returns an error if the value of any attribute of the events
+// doesn't match its declared type.
+func (s *EventSchema) ValidateEvents(events []abci.Event) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.types) == 0 {
+		return nil
+	}
+	for _, event := range events {
+		for _, attr := range event.Attributes {
+			compositeKey := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+			t, ok := s.types[compositeKey]
+			if !ok {
+				continue
+			}
+			if _, err := ParseEventValue(t, attr.Value); err != nil {
+				return fmt.Errorf("event attribute %s: %w", compositeKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseEventValue parses an event attribute value of the given type into a
+// string, int64, float64 or bool.
+func ParseEventValue(t abci.EventAttributeType, value string) (interface{}, error) {
+	switch t {
+	case abci.EventAttributeType_STRING:
+		return value, nil
+	case abci.EventAttributeType_INT:
+		return strconv.ParseInt(value, 10, 64)
+	case abci.EventAttributeType_FLOAT:
+		return strconv.ParseFloat(value, 64)
+	case abci.EventAttributeType_BOOL:
+		return strconv.ParseBool(value)
+	default:
+		return nil, fmt.Errorf("unknown event attribute type %v", t)
+	}
+}
+
+// NormalizeEventValue returns the canonical form of an event attribute value
+// of the given type, e.g. "+01" becomes "1" for INT and "1.50" becomes "1.5"
+// for FLOAT, so that equal values are indexed the same. Values which don't
+// parse are returned as is.
+func NormalizeEventValue(t abci.EventAttributeType, value string) string {
+	v, err := ParseEventValue(t, value)
+	if err != nil {
+		return value
+	}
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestEventSchemaSet(t *testing.T) {
+	testCases := []struct {
+		name    string
+		attrs   []abci.EventAttributeSchema
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"valid", []abci.EventAttributeSchema{
+			{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_INT},
+			{EventType: "transfer", Key: "ratio", Type: abci.EventAttributeType_FLOAT},
+		}, false},
+		{"no event type", []abci.EventAttributeSchema{
+			{Key: "amount", Type: abci.EventAttributeType_INT},
+		}, true},
+		{"no key", []abci.EventAttributeSchema{
+			{EventType: "transfer", Type: abci.EventAttributeType_INT},
+		}, true},
+		{"unknown type", []abci.EventAttributeSchema{
+			{EventType: "transfer", Key: "amount", Type: 42},
+		}, true},
+		{"duplicate", []abci.EventAttributeSchema{
+			{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_INT},
+			{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_FLOAT},
+		}, true},
+		{"reserved", []abci.EventAttributeSchema{
+			{EventType: "tx", Key: "height", Type: abci.EventAttributeType_INT},
+		}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewEventSchema().Set(tc.attrs)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// An invalid schema leaves the previous one in place.
+	schema := NewEventSchema()
+	require.NoError(t, schema.Set([]abci.EventAttributeSchema{
+		{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_INT},
+	}))
+	require.Error(t, schema.Set([]abci.EventAttributeSchema{{EventType: "transfer"}}))
+	typ, ok := schema.Type("transfer.amount")
+	assert.True(t, ok)
+	assert.Equal(t, abci.EventAttributeType_INT, typ)
+	_, ok = schema.Type("transfer.sender")
+	assert.False(t, ok)
+}
+
+func TestEventSchemaValidateEvents(t *testing.T) {
+	schema := NewEventSchema()
+	require.NoError(t, schema.Set([]abci.EventAttributeSchema{
+		{EventType: "transfer", Key: "amount", Type: abci.EventAttributeType_INT},
+		{EventType: "transfer", Key: "ratio", Type: abci.EventAttributeType_FLOAT},
+		{EventType: "transfer", Key: "ok", Type: abci.EventAttributeType_BOOL},
+	}))
+
+	event := func(key, value string) []abci.Event {
+		return []abci.Event{{
+			Type:       "transfer",
+			Attributes: []abci.EventAttribute{{Key: key, Value: value}},
+		}}
+	}
+	assert.NoError(t, schema.ValidateEvents(event("amount", "-10")))
+	assert.NoError(t, schema.ValidateEvents(event("ratio", "0.5")))
+	assert.NoError(t, schema.ValidateEvents(event("ok", "true")))
+	assert.NoError(t, schema.ValidateEvents(event("sender", "alice")))
+	assert.Error(t, schema.ValidateEvents(event("amount", "1.5")))
+	assert.Error(t, schema.ValidateEvents(event("ratio", "half")))
+	assert.Error(t, schema.ValidateEvents(event("ok", "yes")))
+}
+
+func TestNormalizeEventValue(t *testing.T) {
+	testCases := []struct {
+		typ   abci.EventAttributeType
+		value string
+		want  string
+	}{
+		{abci.EventAttributeType_STRING, "+01", "+01"},
+		{abci.EventAttributeType_INT, "+01", "1"},
+		{abci.EventAttributeType_INT, "-007", "-7"},
+		{abci.EventAttributeType_INT, "1.5", "1.5"},
+		{abci.EventAttributeType_FLOAT, "1.50", "1.5"},
+		{abci.EventAttributeType_FLOAT, "2", "2"},
+		{abci.EventAttributeType_FLOAT, "1e3", "1000"},
+		{abci.EventAttributeType_BOOL, "TRUE", "true"},
+		{abci.EventAttributeType_BOOL, "0", "false"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, NormalizeEventValue(tc.typ, tc.value), "%v %q", tc.typ, tc.value)
+	}
+}
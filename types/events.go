@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -22,6 +23,15 @@ const (
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// Critical events, fired when the node is degraded and needs attention.
+	EventAppCommitTimeout = "AppCommitTimeout"
+	EventBlockTimeDrift   = "BlockTimeDrift"
+	EventFastSyncStall    = "FastSyncStall"
+
+	// Mempool events, fired when a tx is added to or removed from the
+	// mempool, so that clients learn when their pending txs are dropped.
+	EventMempoolTx = "MempoolTx"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -55,6 +65,10 @@ func init() {
 	tmjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	tmjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
+	tmjson.RegisterType(EventDataAppCommitTimeout{}, "tendermint/event/AppCommitTimeout")
+	tmjson.RegisterType(EventDataFastSyncStall{}, "tendermint/event/FastSyncStall")
+	tmjson.RegisterType(EventDataBlockTimeDrift{}, "tendermint/event/BlockTimeDrift")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 }
 
@@ -126,6 +140,70 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// Statuses of EventDataMempoolTx.
+const (
+	// MempoolTxAdded is the status of a tx added to the mempool.
+	MempoolTxAdded = "added"
+	// MempoolTxCommitted is the status of a tx removed from the mempool
+	// because it was committed, whether its DeliverTx succeeded or not.
+	MempoolTxCommitted = "committed"
+	// MempoolTxRecheckFailed is the status of a tx removed from the mempool
+	// because it failed the CheckTx after a block was committed.
+	MempoolTxRecheckFailed = "recheck_failed"
+	// MempoolTxExpired is the status of a tx removed from the mempool because
+	// its expiration height or time, as reported by the app in CheckTx,
+	// passed.
+	MempoolTxExpired = "expired"
+	// MempoolTxEvicted is the status of a tx removed from the mempool for
+	// another reason, e.g. the mempool being flushed.
+	MempoolTxEvicted = "evicted"
+)
+
+// EventDataMempoolTx is fired when a tx is added to or removed from the
+// mempool.
+type EventDataMempoolTx struct {
+	Tx     Tx     `json:"tx"`
+	Status string `json:"status"`
+	// why the tx was removed, if it was not committed
+	Reason string `json:"reason,omitempty"`
+}
+
+// EventDataAppCommitTimeout is fired when the app takes longer than the
+// deadline to answer an ABCI Commit.
+type EventDataAppCommitTimeout struct {
+	Height  int64         `json:"height"`
+	Timeout time.Duration `json:"timeout"`
+	// the directory the debug bundle was written to, if any
+	DebugBundle string `json:"debug_bundle,omitempty"`
+	// whether consensus halts rather than waiting for the app
+	Halt bool `json:"halt"`
+}
+
+// EventDataFastSyncStall is fired when fast sync makes no progress for longer
+// than the stall timeout.
+type EventDataFastSyncStall struct {
+	Height        int64     `json:"height"`
+	MaxPeerHeight int64     `json:"max_peer_height"`
+	LastAdvance   time.Time `json:"last_advance"`
+	// the slowest peers, which were dropped
+	DroppedPeers []string `json:"dropped_peers"`
+}
+
+// EventDataBlockTimeDrift is fired when the time of a committed block drifts
+// from the time the node received it, or the interval between two blocks from
+// the interval between receiving them, by more than the configured bounds.
+type EventDataBlockTimeDrift struct {
+	Height      int64     `json:"height"`
+	Proposer    Address   `json:"proposer"`
+	BlockTime   time.Time `json:"block_time"`
+	ReceiveTime time.Time `json:"receive_time"`
+	// block time - receive time
+	Drift time.Duration `json:"drift"`
+	// (block time - last block time) - (receive time - last receive time), 0
+	// if the node didn't receive the last block in consensus
+	IntervalDrift time.Duration `json:"interval_drift"`
+}
+
 // PUBSUB
 
 const (
@@ -142,13 +220,26 @@ const (
 	// events.
 	BlockHeightKey = "block.height"
 
+	// MempoolTxHashKey is a reserved key, used to specify the hash of the tx of
+	// a mempool event.
+	// see EventBus#PublishEventMempoolTx
+	MempoolTxHashKey = "mempool_tx.hash"
+	// MempoolTxStatusKey is a reserved key, used to specify the status of the
+	// tx of a mempool event.
+	// see EventBus#PublishEventMempoolTx
+	MempoolTxStatusKey = "mempool_tx.status"
+
 	EventTypeBeginBlock = "begin_block"
 	EventTypeEndBlock   = "end_block"
 )
 
 var (
+	EventQueryAppCommitTimeout    = QueryForEvent(EventAppCommitTimeout)
+	EventQueryBlockTimeDrift      = QueryForEvent(EventBlockTimeDrift)
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryFastSyncStall       = QueryForEvent(EventFastSyncStall)
 	EventQueryLock                = QueryForEvent(EventLock)
+	EventQueryMempoolTx           = QueryForEvent(EventMempoolTx)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
@@ -169,6 +260,13 @@ func EventQueryTxFor(tx Tx) tmpubsub.Query {
 	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, tx.Hash()))
 }
 
+// EventQueryMempoolTxFor returns a query for the mempool events of the given
+// tx.
+func EventQueryMempoolTxFor(tx Tx) tmpubsub.Query {
+	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'",
+		EventTypeKey, EventMempoolTx, MempoolTxHashKey, tx.Hash()))
+}
+
 func QueryForEvent(eventType string) tmpubsub.Query {
 	return tmquery.MustParse(fmt.Sprintf("%s='%s'", EventTypeKey, eventType))
 }
@@ -180,8 +278,19 @@ type BlockEventPublisher interface {
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	PublishEventTx(EventDataTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
+	PublishEventAppCommitTimeout(EventDataAppCommitTimeout) error
 }
 
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// FastSyncEventPublisher publishes the fast sync events.
+type FastSyncEventPublisher interface {
+	PublishEventFastSyncStall(EventDataFastSyncStall) error
+}
+
+// MempoolEventPublisher publishes the mempool events.
+type MempoolEventPublisher interface {
+	PublishEventMempoolTx(EventDataMempoolTx) error
+}
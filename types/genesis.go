@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmtime "github.com/tendermint/tendermint/types/time"
@@ -42,6 +45,10 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes   `json:"app_hash"`
 	AppState        json.RawMessage    `json:"app_state,omitempty"`
+
+	// AppStateFile is set instead of AppState when the app state is read from
+	// the genesis file on demand, see GenesisDocFromFileStreaming.
+	AppStateFile *GenesisAppState `json:"-"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -54,6 +61,16 @@ func (genDoc *GenesisDoc) SaveAs(file string) error {
 	return ioutil.WriteFile(file, genDocBytes, 0644) // nolint:gosec
 }
 
+// AppStateReader returns a reader of the app state and its size, whether it
+// was loaded into AppState or is read from AppStateFile. The caller must close
+// the reader.
+func (genDoc *GenesisDoc) AppStateReader() (io.ReadCloser, int64, error) {
+	if len(genDoc.AppState) > 0 || genDoc.AppStateFile == nil {
+		return ioutil.NopCloser(bytes.NewReader(genDoc.AppState)), int64(len(genDoc.AppState)), nil
+	}
+	return genDoc.AppStateFile.open()
+}
+
 // ValidatorHash returns the hash of the validator set contained in the GenesisDoc
 func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	vals := make([]*Validator, len(genDoc.Validators))
@@ -123,6 +140,18 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 	return &genDoc, err
 }
 
+// GenesisDocHash returns the SHA-256 hash of the canonical JSON encoding (RFC
+// 8785) of the given genesis doc JSON, e.g. of the genesis file. Unlike the
+// hash of the file, it doesn't depend on the formatting of the JSON, so tools
+// get the same hash from any copy of the genesis doc.
+func GenesisDocHash(jsonBlob []byte) ([]byte, error) {
+	canonical, err := tmjson.Canonicalize(jsonBlob)
+	if err != nil {
+		return nil, fmt.Errorf("can't canonicalize genesis doc: %w", err)
+	}
+	return tmhash.Sum(canonical), nil
+}
+
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 	jsonBlob, err := ioutil.ReadFile(genDocFile)
@@ -135,3 +164,145 @@ func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 	}
 	return genDoc, nil
 }
+
+// GenesisDocFromFileStreaming reads a GenesisDoc from a file like
+// GenesisDocFromFile, but leaves its app_state in the file rather than loading
+// it into memory, so that genesis files of several GBs can be used. The app
+// state is read on demand through AppStateReader.
+func GenesisDocFromFileStreaming(genDocFile string) (*GenesisDoc, error) {
+	appState := NewGenesisAppState(genDocFile)
+	jsonBlob, err := appState.locate()
+	if err != nil {
+		return nil, err
+	}
+	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	if err != nil {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+	}
+	if appState.size > 0 {
+		genDoc.AppStateFile = appState
+	}
+	return genDoc, nil
+}
+
+// GenesisAppState is the app_state of a genesis file, which is read from the
+// file on demand rather than loaded into memory.
+type GenesisAppState struct {
+	file   string
+	offset int64
+	size   int64 // -1 until located
+}
+
+// NewGenesisAppState returns the app state of the given genesis file. The file
+// is only scanned for the app state once it is read.
+func NewGenesisAppState(genDocFile string) *GenesisAppState {
+	return &GenesisAppState{file: genDocFile, size: -1}
+}
+
+func (as *GenesisAppState) open() (io.ReadCloser, int64, error) {
+	if as.size < 0 {
+		if _, err := as.locate(); err != nil {
+			return nil, 0, err
+		}
+	}
+	f, err := os.Open(as.file)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't open GenesisDoc file: %w", err)
+	}
+	return sectionReadCloser{io.NewSectionReader(f, as.offset, as.size), f}, as.size, nil
+}
+
+// locate scans the genesis file for the position of the app_state value
+// without holding the value in memory, and returns the rest of the genesis
+// doc as a JSON object.
+func (as *GenesisAppState) locate() ([]byte, error) {
+	f, err := os.Open(as.file)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: expected a JSON object", as.file)
+	}
+
+	var rest bytes.Buffer
+	rest.WriteByte('{')
+	as.offset, as.size = 0, 0
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", as.file, err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("error reading GenesisDoc at %s: unexpected %v", as.file, tok)
+		}
+
+		if key != "app_state" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", as.file, err)
+			}
+			if rest.Len() > 1 {
+				rest.WriteByte(',')
+			}
+			keyJSON, _ := json.Marshal(key)
+			rest.Write(keyJSON)
+			rest.WriteByte(':')
+			rest.Write(value)
+			continue
+		}
+
+		// The decoder stops right after the key, so the value begins after the
+		// colon and any whitespace.
+		start := dec.InputOffset()
+		if err := skipJSONValue(dec); err != nil {
+			return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", as.file, err)
+		}
+		end := dec.InputOffset()
+		buf := make([]byte, 1)
+		for ; start < end; start++ {
+			if _, err := f.ReadAt(buf, start); err != nil {
+				return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", as.file, err)
+			}
+			if c := buf[0]; c != ':' && c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				break
+			}
+		}
+		as.offset, as.size = start, end-start
+	}
+	rest.WriteByte('}')
+
+	return rest.Bytes(), nil
+}
+
+// skipJSONValue reads the next JSON value from the decoder token by token.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+type sectionReadCloser struct {
+	*io.SectionReader
+	f *os.File
+}
+
+func (r sectionReadCloser) Close() error {
+	return r.f.Close()
+}
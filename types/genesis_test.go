@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
@@ -147,6 +148,34 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestGenesisDocHash(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"address": "a", "balance": 10}]}`)
+	indented, err := tmjson.MarshalIndent(genDoc, "", "  ")
+	require.NoError(t, err)
+	compact, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	require.NotEqual(t, indented, compact)
+
+	// the hash doesn't depend on the formatting
+	hash, err := GenesisDocHash(indented)
+	require.NoError(t, err)
+	compactHash, err := GenesisDocHash(compact)
+	require.NoError(t, err)
+	assert.Equal(t, hash, compactHash)
+	assert.Len(t, hash, tmhash.Size)
+
+	genDoc.ChainID = "other-chain"
+	other, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	otherHash, err := GenesisDocHash(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	_, err = GenesisDocHash([]byte(`{"chain_id": "a", "chain_id": "b"}`))
+	assert.Error(t, err)
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
@@ -163,3 +192,47 @@ func randomGenesisDoc() *GenesisDoc {
 		AppHash:         []byte{1, 2, 3},
 	}
 }
+
+func TestGenesisDocFromFileStreaming(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "genesis")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	require.NoError(t, tmpfile.Close())
+
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"address": "a", "balance": 10}], "note": "}]"}`)
+	require.NoError(t, genDoc.SaveAs(tmpfile.Name()))
+
+	loaded, err := GenesisDocFromFile(tmpfile.Name())
+	require.NoError(t, err)
+	streamed, err := GenesisDocFromFileStreaming(tmpfile.Name())
+	require.NoError(t, err)
+	require.Nil(t, streamed.AppState)
+	require.NotNil(t, streamed.AppStateFile)
+
+	// the app state is read from the file
+	for _, doc := range []*GenesisDoc{
+		streamed,
+		{AppStateFile: NewGenesisAppState(tmpfile.Name())},
+	} {
+		r, size, err := doc.AppStateReader()
+		require.NoError(t, err)
+		appState, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.EqualValues(t, len(appState), size)
+		assert.JSONEq(t, string(genDoc.AppState), string(appState))
+	}
+
+	// the rest of the genesis doc is the same
+	streamed.AppStateFile = nil
+	loaded.AppState = nil
+	assert.Equal(t, loaded, streamed)
+
+	// genesis docs without app state have no app state file
+	genDoc.AppState = nil
+	require.NoError(t, genDoc.SaveAs(tmpfile.Name()))
+	streamed, err = GenesisDocFromFileStreaming(tmpfile.Name())
+	require.NoError(t, err)
+	assert.Nil(t, streamed.AppStateFile)
+}
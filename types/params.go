@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/sr25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
@@ -17,11 +19,19 @@ const (
 	// MaxBlockSizeBytes is the maximum permitted size of the blocks.
 	MaxBlockSizeBytes = 104857600 // 100MB
 
-	// BlockPartSizeBytes is the size of one block part.
+	// BlockPartSizeBytes is the default size of one block part.
 	BlockPartSizeBytes uint32 = 65536 // 64kB
 
-	// MaxBlockPartsCount is the maximum number of block parts.
-	MaxBlockPartsCount = (MaxBlockSizeBytes / BlockPartSizeBytes) + 1
+	// MinBlockPartSizeBytes is the minimum permitted size of one block part.
+	MinBlockPartSizeBytes uint32 = 4096 // 4kB
+
+	// MaxBlockPartSizeBytes is the maximum permitted size of one block part.
+	// NOTE: a part must fit into a single consensus BlockPart message.
+	MaxBlockPartSizeBytes uint32 = 524288 // 512kB
+
+	// MaxBlockPartsCount is the maximum number of block parts under any
+	// params. See BlockParams.MaxPartsCount for the limit of a chain.
+	MaxBlockPartsCount = (MaxBlockSizeBytes / MinBlockPartSizeBytes) + 1
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
@@ -47,15 +57,17 @@ type ConsensusParams struct {
 // It is amino encoded and hashed into
 // the Header.ConsensusHash.
 type HashedParams struct {
-	BlockMaxBytes int64
-	BlockMaxGas   int64
+	BlockMaxBytes      int64
+	BlockMaxGas        int64
+	BlockPartSizeBytes uint32
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
 // between blocks.
 type BlockParams struct {
-	MaxBytes int64 `json:"max_bytes"`
-	MaxGas   int64 `json:"max_gas"`
+	MaxBytes      int64  `json:"max_bytes"`
+	MaxGas        int64  `json:"max_gas"`
+	PartSizeBytes uint32 `json:"part_size_bytes"`
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
@@ -88,8 +100,9 @@ func DefaultConsensusParams() *ConsensusParams {
 // DefaultBlockParams returns a default BlockParams.
 func DefaultBlockParams() BlockParams {
 	return BlockParams{
-		MaxBytes: 22020096, // 21MB
-		MaxGas:   -1,
+		MaxBytes:      22020096, // 21MB
+		MaxGas:        -1,
+		PartSizeBytes: BlockPartSizeBytes,
 	}
 }
 
@@ -116,6 +129,21 @@ func DefaultVersionParams() VersionParams {
 	}
 }
 
+// PartSize returns the size of the block parts blocks are split into. Params
+// that predate the part size parameter fall back to BlockPartSizeBytes.
+func (params BlockParams) PartSize() uint32 {
+	if params.PartSizeBytes == 0 {
+		return BlockPartSizeBytes
+	}
+	return params.PartSizeBytes
+}
+
+// MaxPartsCount returns the maximum number of parts a block of at most
+// MaxBytes is split into.
+func (params BlockParams) MaxPartsCount() uint32 {
+	return uint32(params.MaxBytes/int64(params.PartSize())) + 1
+}
+
 func (val *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	for i := 0; i < len(val.PubKeyTypes); i++ {
 		if val.PubKeyTypes[i] == pubkeyType {
@@ -142,6 +170,13 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Block.MaxGas)
 	}
 
+	// zero means BlockPartSizeBytes, see BlockParams.PartSize
+	if params.Block.PartSizeBytes != 0 &&
+		(params.Block.PartSizeBytes < MinBlockPartSizeBytes || params.Block.PartSizeBytes > MaxBlockPartSizeBytes) {
+		return fmt.Errorf("block.PartSizeBytes must be 0 or within [%d, %d]. Got %d",
+			MinBlockPartSizeBytes, MaxBlockPartSizeBytes, params.Block.PartSizeBytes)
+	}
+
 	if params.Evidence.MaxAgeNumBlocks <= 0 {
 		return fmt.Errorf("evidence.MaxAgeNumBlocks must be greater than 0. Got %d",
 			params.Evidence.MaxAgeNumBlocks)
@@ -179,15 +214,17 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 }
 
 // Hash returns a hash of a subset of the parameters to store in the block header.
-// Only the Block.MaxBytes and Block.MaxGas are included in the hash.
+// Only the Block.MaxBytes, Block.MaxGas and Block.PartSizeBytes are included
+// in the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
 // protocol. No need for a Merkle tree here, just a small struct to hash.
 func (params ConsensusParams) HashConsensusParams() []byte {
 	hasher := tmhash.New()
 
 	hp := tmproto.HashedParams{
-		BlockMaxBytes: params.Block.MaxBytes,
-		BlockMaxGas:   params.Block.MaxGas,
+		BlockMaxBytes:      params.Block.MaxBytes,
+		BlockMaxGas:        params.Block.MaxGas,
+		BlockPartSizeBytes: params.Block.PartSizeBytes,
 	}
 
 	bz, err := hp.Marshal()
@@ -202,6 +239,29 @@ func (params ConsensusParams) HashConsensusParams() []byte {
 	return hasher.Sum(nil)
 }
 
+// HashConsensusParamsWithSchedule returns the hash stored in the block header
+// for the params and the updates scheduled at later heights: the hash of the
+// params if no update is scheduled, and otherwise the merkle root of the hash
+// of the params and of the scheduled updates, so that they can be verified
+// from the header too.
+func (params ConsensusParams) HashConsensusParamsWithSchedule(scheduled []abci.ScheduledConsensusParams) []byte {
+	hash := params.HashConsensusParams()
+	if len(scheduled) == 0 {
+		return hash
+	}
+
+	items := make([][]byte, 0, len(scheduled)+1)
+	items = append(items, hash)
+	for _, update := range scheduled {
+		bz, err := update.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		items = append(items, bz)
+	}
+	return merkle.HashFromByteSlices(items)
+}
+
 func (params *ConsensusParams) Equals(params2 *ConsensusParams) bool {
 	return params.Block == params2.Block &&
 		params.Evidence == params2.Evidence &&
@@ -221,6 +281,11 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		// a zero part size leaves the current one in place, so applications
+		// unaware of the parameter do not reset it.
+		if params2.Block.PartSizeBytes != 0 {
+			res.Block.PartSizeBytes = params2.Block.PartSizeBytes
+		}
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
@@ -241,8 +306,9 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 	return tmproto.ConsensusParams{
 		Block: &tmproto.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:      params.Block.MaxBytes,
+			MaxGas:        params.Block.MaxGas,
+			PartSizeBytes: params.Block.PartSizeBytes,
 		},
 		Evidence: &tmproto.EvidenceParams{
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
@@ -261,8 +327,9 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams {
 	return ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: pbParams.Block.MaxBytes,
-			MaxGas:   pbParams.Block.MaxGas,
+			MaxBytes:      pbParams.Block.MaxBytes,
+			MaxGas:        pbParams.Block.MaxGas,
+			PartSizeBytes: pbParams.Block.PartSizeBytes,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
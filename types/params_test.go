@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -50,6 +53,43 @@ func TestConsensusParamsValidation(t *testing.T) {
 	}
 }
 
+func TestConsensusParamsValidation_PartSize(t *testing.T) {
+	testCases := []struct {
+		partSize uint32
+		valid    bool
+	}{
+		{0, true},
+		{MinBlockPartSizeBytes - 1, false},
+		{MinBlockPartSizeBytes, true},
+		{BlockPartSizeBytes, true},
+		{MaxBlockPartSizeBytes, true},
+		{MaxBlockPartSizeBytes + 1, false},
+	}
+	for i, tc := range testCases {
+		params := makeParams(1, 0, 2, 0, valEd25519)
+		params.Block.PartSizeBytes = tc.partSize
+		if tc.valid {
+			assert.NoErrorf(t, params.ValidateConsensusParams(), "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, params.ValidateConsensusParams(), "expected error for non valid params (#%d)", i)
+		}
+	}
+}
+
+func TestBlockParamsPartSize(t *testing.T) {
+	assert.Equal(t, BlockPartSizeBytes, BlockParams{}.PartSize())
+	assert.Equal(t, BlockPartSizeBytes, DefaultBlockParams().PartSize())
+	assert.EqualValues(t, 8192, BlockParams{PartSizeBytes: 8192}.PartSize())
+}
+
+func TestBlockParamsMaxPartsCount(t *testing.T) {
+	assert.EqualValues(t, 1, BlockParams{MaxBytes: 100}.MaxPartsCount())
+	assert.EqualValues(t, 2, BlockParams{MaxBytes: int64(BlockPartSizeBytes)}.MaxPartsCount())
+	assert.EqualValues(t, 26, BlockParams{MaxBytes: 100 * 1024, PartSizeBytes: 4096}.MaxPartsCount())
+	assert.EqualValues(t, MaxBlockPartsCount,
+		BlockParams{MaxBytes: MaxBlockSizeBytes, PartSizeBytes: MinBlockPartSizeBytes}.MaxPartsCount())
+}
+
 func makeParams(
 	blockBytes, blockGas int64,
 	evidenceAge int64,
@@ -99,6 +139,39 @@ func TestConsensusParamsHash(t *testing.T) {
 	}
 }
 
+func TestConsensusParamsHash_PartSize(t *testing.T) {
+	params := makeParams(4, 2, 3, 1, valEd25519)
+	hash := params.HashConsensusParams()
+
+	params.Block.PartSizeBytes = 8192
+	assert.NotEqual(t, hash, params.HashConsensusParams())
+
+	// params that leave the part size unset keep their hash
+	hp := tmproto.HashedParams{BlockMaxBytes: 4, BlockMaxGas: 2}
+	bz, err := hp.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, tmhash.Sum(bz), hash)
+}
+
+func TestConsensusParamsHashWithSchedule(t *testing.T) {
+	params := makeParams(4, 2, 3, 1, valEd25519)
+	assert.Equal(t, params.HashConsensusParams(), params.HashConsensusParamsWithSchedule(nil))
+
+	scheduled := []abci.ScheduledConsensusParams{{
+		Params:           &tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 5, MaxGas: 2}},
+		ActivationHeight: 10,
+	}}
+	hash := params.HashConsensusParamsWithSchedule(scheduled)
+	assert.NotEqual(t, params.HashConsensusParams(), hash)
+
+	// the hash depends on the activation height and the scheduled params
+	scheduled[0].ActivationHeight = 11
+	assert.NotEqual(t, hash, params.HashConsensusParamsWithSchedule(scheduled))
+	scheduled[0].ActivationHeight = 10
+	scheduled[0].Params.Evidence = &tmproto.EvidenceParams{MaxAgeNumBlocks: 1}
+	assert.NotEqual(t, hash, params.HashConsensusParamsWithSchedule(scheduled))
+}
+
 func TestConsensusParamsUpdate(t *testing.T) {
 	testCases := []struct {
 		params        ConsensusParams
@@ -154,6 +227,20 @@ func TestConsensusParamsUpdate(t *testing.T) {
 	}
 }
 
+func TestConsensusParamsUpdate_PartSize(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+	params.Block.PartSizeBytes = 8192
+
+	// a zero part size keeps the current one
+	updated := params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200}})
+	assert.EqualValues(t, 8192, updated.Block.PartSizeBytes)
+
+	updated = params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 100, MaxGas: 200, PartSizeBytes: 16384}})
+	assert.EqualValues(t, 16384, updated.Block.PartSizeBytes)
+}
+
 func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	params := makeParams(1, 2, 3, 0, valEd25519)
 
@@ -26,10 +26,12 @@ type Part struct {
 	Proof merkle.Proof     `json:"proof"`
 }
 
-// ValidateBasic performs basic validation.
+// ValidateBasic performs basic validation. It only bounds the part by
+// MaxBlockPartSizeBytes, parts of a chain must also fit into its
+// BlockParams.PartSize.
 func (part *Part) ValidateBasic() error {
-	if len(part.Bytes) > int(BlockPartSizeBytes) {
-		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), BlockPartSizeBytes)
+	if len(part.Bytes) > int(MaxBlockPartSizeBytes) {
+		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), MaxBlockPartSizeBytes)
 	}
 	if err := part.Proof.ValidateBasic(); err != nil {
 		return fmt.Errorf("wrong Proof: %w", err)
@@ -153,6 +155,7 @@ type PartSet struct {
 
 	mtx           tmsync.Mutex
 	parts         []*Part
+	verifiedParts []*Part // parts verified by VerifyPart, but not yet added
 	partsBitArray *bits.BitArray
 	count         uint32
 	// a count of the total size (in bytes). Used to ensure that the
@@ -263,30 +266,65 @@ func (ps *PartSet) Total() uint32 {
 	return ps.total
 }
 
-func (ps *PartSet) AddPart(part *Part) (bool, error) {
+// VerifyPart verifies the proof of a part against the hash of the set without
+// adding it, so that AddPart does not verify it again. It lets the proofs be
+// verified as the parts arrive, outside of the routine which adds them.
+func (ps *PartSet) VerifyPart(part *Part) error {
 	if ps == nil {
-		return false, nil
+		return nil
+	}
+
+	// Invalid part index
+	if part.Index >= ps.total {
+		return ErrPartSetUnexpectedIndex
 	}
+
+	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
+		return ErrPartSetInvalidProof
+	}
+
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
+	if ps.parts[part.Index] == nil {
+		if ps.verifiedParts == nil {
+			ps.verifiedParts = make([]*Part, ps.total)
+		}
+		ps.verifiedParts[part.Index] = part
+	}
+	return nil
+}
+
+func (ps *PartSet) AddPart(part *Part) (bool, error) {
+	if ps == nil {
+		return false, nil
+	}
+
 	// Invalid part index
 	if part.Index >= ps.total {
 		return false, ErrPartSetUnexpectedIndex
 	}
 
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
 	// If part already exists, return false.
 	if ps.parts[part.Index] != nil {
 		return false, nil
 	}
 
-	// Check hash proof
-	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
-		return false, ErrPartSetInvalidProof
+	// Check hash proof, unless VerifyPart already did.
+	if ps.verifiedParts == nil || ps.verifiedParts[part.Index] != part {
+		if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
+			return false, ErrPartSetInvalidProof
+		}
 	}
 
 	// Add part
 	ps.parts[part.Index] = part
+	if ps.verifiedParts != nil {
+		ps.verifiedParts[part.Index] = nil
+	}
 	ps.partsBitArray.SetIndex(int(part.Index), true)
 	ps.count++
 	ps.byteSize += int64(len(part.Bytes))
@@ -2,6 +2,7 @@ package types
 
 import (
 	"io/ioutil"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -88,6 +89,71 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestPartSetConcurrentAddPart(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 50)
+	partSet := NewPartSetFromData(data, testPartSize)
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+
+	// every part is delivered twice, concurrently
+	var wg sync.WaitGroup
+	added := make(chan uint32, 2*partSet.Total())
+	for i := 0; i < 2*int(partSet.Total()); i++ {
+		wg.Add(1)
+		go func(part *Part) {
+			defer wg.Done()
+			ok, err := partSet2.AddPart(part)
+			assert.NoError(t, err)
+			if ok {
+				added <- part.Index
+			}
+		}(partSet.GetPart(i % int(partSet.Total())))
+	}
+	wg.Wait()
+	close(added)
+
+	assert.Len(t, added, int(partSet.Total()))
+	assert.True(t, partSet2.IsComplete())
+	assert.EqualValues(t, len(data), partSet2.ByteSize())
+
+	data2, err := ioutil.ReadAll(partSet2.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+}
+
+func TestPartSetVerifyPart(t *testing.T) {
+	partSet := NewPartSetFromData(tmrand.Bytes(testPartSize*4), testPartSize)
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+
+	// the parts are verified concurrently, and then added without verifying
+	// them again
+	var wg sync.WaitGroup
+	for i := 0; i < int(partSet.Total()); i++ {
+		wg.Add(1)
+		go func(part *Part) {
+			defer wg.Done()
+			assert.NoError(t, partSet2.VerifyPart(part))
+		}(partSet.GetPart(i))
+	}
+	wg.Wait()
+	for i := 0; i < int(partSet.Total()); i++ {
+		added, err := partSet2.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	assert.True(t, partSet2.IsComplete())
+
+	// a part with a wrong proof is neither verified nor added
+	partSet3 := NewPartSetFromHeader(partSet.Header())
+	part := *partSet.GetPart(0)
+	part.Bytes = tmrand.Bytes(testPartSize)
+	assert.Equal(t, ErrPartSetInvalidProof, partSet3.VerifyPart(&part))
+	_, err := partSet3.AddPart(&part)
+	assert.Equal(t, ErrPartSetInvalidProof, err)
+
+	part.Index = partSet.Total()
+	assert.Equal(t, ErrPartSetUnexpectedIndex, partSet3.VerifyPart(&part))
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string
@@ -116,7 +182,7 @@ func TestPartValidateBasic(t *testing.T) {
 		expectErr    bool
 	}{
 		{"Good Part", func(pt *Part) {}, false},
-		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, BlockPartSizeBytes+1) }, true},
+		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, MaxBlockPartSizeBytes+1) }, true},
 		{"Too big proof", func(pt *Part) {
 			pt.Proof = merkle.Proof{
 				Total:    1,
@@ -56,6 +56,15 @@ type Vote struct {
 	ValidatorAddress Address               `json:"validator_address"`
 	ValidatorIndex   int32                 `json:"validator_index"`
 	Signature        []byte                `json:"signature"`
+
+	// verified is set once VerifyOnce succeeded, so that the signature isn't
+	// verified again against the same chain ID and public key.
+	verified *voteVerification
+}
+
+type voteVerification struct {
+	chainID string
+	pubKey  crypto.PubKey
 }
 
 // CommitSig converts the Vote to a CommitSig.
@@ -102,6 +111,7 @@ func VoteSignBytes(chainID string, vote *tmproto.Vote) []byte {
 
 func (vote *Vote) Copy() *Vote {
 	voteCopy := *vote
+	voteCopy.verified = nil // the copy may be mutated
 	return &voteCopy
 }
 
@@ -155,6 +165,50 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	return nil
 }
 
+// VerifyOnce is like Verify, but remembers a successful verification, so that
+// verifying the vote again against the same chain ID and public key, including
+// when it's added to a VoteSet, doesn't check the signature again. It allows
+// verifying votes concurrently, ahead of adding them.
+//
+// NOTE: vote must not be mutated after a successful verification.
+func (vote *Vote) VerifyOnce(chainID string, pubKey crypto.PubKey) error {
+	if vote.verifiedBy(chainID, pubKey) {
+		return nil
+	}
+	if err := vote.Verify(chainID, pubKey); err != nil {
+		return err
+	}
+	vote.verified = &voteVerification{chainID: chainID, pubKey: pubKey}
+	return nil
+}
+
+// VerifyCached is like VerifyOnce, but also skips checking the signature if
+// the same vote, with the same signature, was verified with the cache before,
+// e.g. when it was received from another peer. The signer is identified by the
+// vote's validator address, which Verify checks against the public key.
+func (vote *Vote) VerifyCached(chainID string, pubKey crypto.PubKey, cache *VoteSignatureCache) error {
+	if vote.verifiedBy(chainID, pubKey) {
+		return nil
+	}
+	key := voteSignatureKeyOf(chainID, vote)
+	if cache.has(key) && bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
+		vote.verified = &voteVerification{chainID: chainID, pubKey: pubKey}
+		return nil
+	}
+	if err := vote.VerifyOnce(chainID, pubKey); err != nil {
+		return err
+	}
+	cache.add(key)
+	return nil
+}
+
+// verifiedBy returns whether VerifyOnce verified the vote against the chain ID
+// and public key.
+func (vote *Vote) verifiedBy(chainID string, pubKey crypto.PubKey) bool {
+	v := vote.verified
+	return v != nil && v.chainID == chainID && v.pubKey.Equals(pubKey)
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
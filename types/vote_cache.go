@@ -0,0 +1,96 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// voteSignatureKey identifies a vote signature by everything it signs and the
+// signer. The timestamp is signed too, so a vote with another timestamp but the
+// same signature doesn't hit the cache.
+type voteSignatureKey struct {
+	chainID          string
+	validatorAddress string
+	height           int64
+	round            int32
+	signedMsgType    tmproto.SignedMsgType
+	blockID          string
+	seconds          int64
+	nanos            int
+	signature        string
+}
+
+func voteSignatureKeyOf(chainID string, vote *Vote) voteSignatureKey {
+	return voteSignatureKey{
+		chainID:          chainID,
+		validatorAddress: string(vote.ValidatorAddress),
+		height:           vote.Height,
+		round:            vote.Round,
+		signedMsgType:    vote.Type,
+		blockID:          vote.BlockID.Key(),
+		seconds:          vote.Timestamp.Unix(),
+		nanos:            vote.Timestamp.Nanosecond(),
+		signature:        string(vote.Signature),
+	}
+}
+
+// VoteSignatureCache is a bounded cache of verified vote signatures, so that
+// the same vote gossiped by several peers is only verified once. It evicts the
+// least recently used signature when full. It is safe for concurrent use.
+type VoteSignatureCache struct {
+	mtx        sync.Mutex
+	size       int
+	signatures map[voteSignatureKey]*list.Element
+	list       *list.List
+}
+
+// NewVoteSignatureCache returns a cache of up to size vote signatures.
+func NewVoteSignatureCache(size int) *VoteSignatureCache {
+	return &VoteSignatureCache{
+		size:       size,
+		signatures: make(map[voteSignatureKey]*list.Element, size),
+		list:       list.New(),
+	}
+}
+
+// has returns whether the signature of the vote was verified.
+func (c *VoteSignatureCache) has(key voteSignatureKey) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.signatures[key]
+	if ok {
+		c.list.MoveToBack(e)
+	}
+	return ok
+}
+
+// add records that the signature of the vote was verified.
+func (c *VoteSignatureCache) add(key voteSignatureKey) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.signatures[key]; ok {
+		c.list.MoveToBack(e)
+		return
+	}
+	if c.list.Len() >= c.size {
+		oldest := c.list.Front()
+		delete(c.signatures, oldest.Value.(voteSignatureKey))
+		c.list.Remove(oldest)
+	}
+	c.signatures[key] = c.list.PushBack(key)
+}
+
+// Len returns the number of cached signatures.
+func (c *VoteSignatureCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.list.Len()
+}
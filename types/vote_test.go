@@ -218,6 +218,82 @@ func TestVoteVerify(t *testing.T) {
 	}
 }
 
+func TestVoteVerifyOnce(t *testing.T) {
+	privVal := NewMockPV()
+	pubkey, err := privVal.GetPubKey(context.Background())
+	require.NoError(t, err)
+
+	vote := examplePrevote()
+	vote.ValidatorAddress = pubkey.Address()
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote(context.Background(), "test_chain_id", v))
+	vote.Signature = v.Signature
+
+	require.NoError(t, vote.VerifyOnce("test_chain_id", pubkey))
+	// other chain IDs and keys are still verified
+	assert.Equal(t, ErrVoteInvalidSignature, vote.VerifyOnce("other_chain_id", pubkey))
+	assert.Equal(t, ErrVoteInvalidValidatorAddress, vote.VerifyOnce("test_chain_id", ed25519.GenPrivKey().PubKey()))
+
+	// the signature isn't verified again
+	vote.Signature = []byte("invalid")
+	assert.NoError(t, vote.VerifyOnce("test_chain_id", pubkey))
+
+	// but is verified for copies, which may have been mutated
+	assert.Equal(t, ErrVoteInvalidSignature, vote.Copy().VerifyOnce("test_chain_id", pubkey))
+}
+
+func TestVoteVerifyCached(t *testing.T) {
+	privVal := NewMockPV()
+	pubkey, err := privVal.GetPubKey(context.Background())
+	require.NoError(t, err)
+
+	vote := examplePrevote()
+	vote.ValidatorAddress = pubkey.Address()
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote(context.Background(), "test_chain_id", v))
+	vote.Signature = v.Signature
+
+	cache := NewVoteSignatureCache(1)
+	require.NoError(t, vote.Copy().VerifyCached("test_chain_id", pubkey, cache))
+	assert.Equal(t, 1, cache.Len())
+
+	// the same vote from another peer isn't verified again
+	counter := &countingPubKey{PubKey: pubkey}
+	require.NoError(t, vote.Copy().VerifyCached("test_chain_id", counter, cache))
+	assert.Zero(t, counter.verifications)
+
+	// but votes which differ in what is signed are
+	other := vote.Copy()
+	other.Timestamp = other.Timestamp.Add(time.Second)
+	assert.Equal(t, ErrVoteInvalidSignature, other.VerifyCached("test_chain_id", counter, cache))
+	assert.Equal(t, ErrVoteInvalidSignature, vote.Copy().VerifyCached("other_chain_id", counter, cache))
+	assert.Equal(t, 2, counter.verifications)
+	assert.Equal(t, ErrVoteInvalidValidatorAddress,
+		vote.Copy().VerifyCached("test_chain_id", ed25519.GenPrivKey().PubKey(), cache))
+
+	// the least recently verified signature is evicted
+	other = vote.Copy()
+	other.Round++
+	v = other.ToProto()
+	require.NoError(t, privVal.SignVote(context.Background(), "test_chain_id", v))
+	other.Signature = v.Signature
+	require.NoError(t, other.VerifyCached("test_chain_id", counter, cache))
+	assert.Equal(t, 3, counter.verifications)
+	require.NoError(t, vote.Copy().VerifyCached("test_chain_id", counter, cache))
+	assert.Equal(t, 4, counter.verifications)
+	assert.Equal(t, 1, cache.Len())
+}
+
+type countingPubKey struct {
+	crypto.PubKey
+	verifications int
+}
+
+func (pk *countingPubKey) VerifySignature(msg []byte, sig []byte) bool {
+	pk.verifications++
+	return pk.PubKey.VerifySignature(msg, sig)
+}
+
 func TestVoteString(t *testing.T) {
 	str := examplePrecommit().String()
 	expected := `Vote{56789:6AF1F4111082 12345/02/SIGNED_MSG_TYPE_PRECOMMIT(Precommit) 8B01023386C3 000000000000 @ 2017-12-25T03:00:01.234Z}` //nolint:lll //ignore line length for tests
@@ -4,6 +4,11 @@ import tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 var (
 	TMVersion = TMVersionDefault
+
+	// GitCommit is the commit the binary was built from, and BuildTags the
+	// comma separated build tags, both set by the Makefile.
+	GitCommit = ""
+	BuildTags = ""
 )
 
 const (